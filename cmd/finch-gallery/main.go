@@ -0,0 +1,27 @@
+// Command finch-gallery generates the widget gallery: every registered
+// component rendered to PNG and SVG with its public API, as a browsable,
+// printable HTML page.
+//
+//	go run ./cmd/finch-gallery -out docs/gallery
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/aggnr/finch/test"
+)
+
+func main() {
+	out := flag.String("out", "docs/gallery", "directory to write the gallery to")
+	title := flag.String("title", "Finch Widget Gallery", "page title")
+	flag.Parse()
+
+	gallery := test.BuildGallery(*title)
+	if err := gallery.Save(*out); err != nil {
+		fmt.Fprintf(os.Stderr, "finch-gallery: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Wrote %d components to %s\n", len(gallery.Entries), *out)
+}
@@ -0,0 +1,28 @@
+// Command finch-review runs the reference visual review server. Test runs
+// upload screenshots that differ from their goldens; reviewers approve or
+// reject them at http://localhost:8090/.
+//
+//	go run ./cmd/finch-review -addr :8090 -dir review-assets
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/aggnr/finch/test"
+)
+
+func main() {
+	addr := flag.String("addr", ":8090", "address to listen on")
+	dir := flag.String("dir", "review-assets", "directory to store snapshot images in")
+	flag.Parse()
+
+	server := test.NewReviewServer(test.NewDirStore(*dir))
+	server.SetOnDecision(func(s *test.Snapshot) {
+		log.Printf("%s %s (%s) by %s", s.Status, s.Name, s.ID, s.Reviewer)
+	})
+
+	log.Printf("Visual review server listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, server))
+}
@@ -0,0 +1,529 @@
+package components
+
+import (
+	"image/color"
+	"time"
+)
+
+// defaultExpandDuration is how long an expander takes to open or close
+const defaultExpandDuration = 150 * time.Millisecond
+
+// Expander represents a collapsible section with a clickable header
+type Expander struct {
+	*Node
+	title           string
+	content         Element
+	expanded        bool
+	hovered         bool
+	headerHeight    int
+	fontSize        int
+	progress        float64 // 0 = collapsed, 1 = fully expanded
+	animFrom        float64
+	animStart       time.Time
+	animDuration    time.Duration
+	onToggle        func(bool)
+	accordion       *Accordion // Owning accordion, if any
+	headerColor     color.RGBA
+	hoverColor      color.RGBA
+	textColor       color.RGBA
+	backgroundColor color.RGBA
+}
+
+// NewExpander creates a new collapsed expander with an empty column container as its content
+func NewExpander(id string, title string) *Expander {
+	e := &Expander{
+		Node:            NewNode(id),
+		title:           title,
+		headerHeight:    28,
+		fontSize:        14,
+		animDuration:    defaultExpandDuration,
+		headerColor:     color.RGBA{230, 230, 230, 255},
+		hoverColor:      color.RGBA{215, 215, 215, 255},
+		textColor:       color.RGBA{0, 0, 0, 255},
+		backgroundColor: color.RGBA{255, 255, 255, 255},
+	}
+
+	content := NewFlexContainer(id + "_content")
+	content.SetFlexDirection(FlexColumn)
+	e.SetContent(content)
+
+	return e
+}
+
+// SetTitle sets the header text
+func (e *Expander) SetTitle(title string) {
+	e.title = title
+}
+
+// Title returns the header text
+func (e *Expander) Title() string {
+	return e.title
+}
+
+// SetContent replaces the element shown when expanded
+func (e *Expander) SetContent(content Element) {
+	if e.content != nil {
+		e.Node.RemoveChild(e.content)
+	}
+	e.content = content
+	e.Node.AddChild(content)
+	e.layout()
+}
+
+// Content returns the element shown when expanded
+func (e *Expander) Content() Element {
+	return e.content
+}
+
+// SetExpanded expands or collapses the section, animating the height change
+func (e *Expander) SetExpanded(expanded bool) {
+	if e.expanded == expanded {
+		return
+	}
+
+	e.expanded = expanded
+	e.animFrom = e.progress
+	e.animStart = Now()
+	if AnimationDuration(e.animDuration) <= 0 {
+		e.progress = e.target()
+	}
+	e.layout()
+
+	if expanded && e.accordion != nil {
+		e.accordion.sectionOpened(e)
+	}
+	if e.onToggle != nil {
+		e.onToggle(expanded)
+	}
+}
+
+// IsExpanded returns whether the section is expanded (or expanding)
+func (e *Expander) IsExpanded() bool {
+	return e.expanded
+}
+
+// Toggle flips the expanded state
+func (e *Expander) Toggle() {
+	e.SetExpanded(!e.expanded)
+}
+
+// SetOnToggle sets the handler for when the section is expanded or collapsed
+func (e *Expander) SetOnToggle(handler func(bool)) {
+	e.onToggle = handler
+}
+
+// SetAnimationDuration sets how long the height change takes; zero disables
+// animation, as does the reduced motion preference
+func (e *Expander) SetAnimationDuration(duration time.Duration) {
+	e.animDuration = duration
+}
+
+// SetHeaderHeight sets the height of the header
+func (e *Expander) SetHeaderHeight(height int) {
+	e.headerHeight = height
+	e.layout()
+}
+
+// HeaderHeight returns the height of the header
+func (e *Expander) HeaderHeight() int {
+	return e.headerHeight
+}
+
+// IsAnimating returns whether the height is still changing
+func (e *Expander) IsAnimating() bool {
+	return e.progress != e.target()
+}
+
+// SetContentHeight sets the height of the content area when fully expanded
+func (e *Expander) SetContentHeight(height int) {
+	if e.content == nil {
+		return
+	}
+	bounds := e.Bounds()
+	e.content.SetBounds(Rect{X: bounds.X, Y: bounds.Y + e.headerHeight, Width: bounds.Width, Height: height})
+	e.layout()
+}
+
+// SetBounds sets the position and width; the height follows the header and expanded content
+func (e *Expander) SetBounds(bounds Rect) {
+	e.Node.SetBounds(bounds)
+	if e.content != nil {
+		cb := e.content.Bounds()
+		e.content.SetBounds(Rect{X: bounds.X, Y: bounds.Y + e.headerHeight, Width: bounds.Width, Height: cb.Height})
+	}
+	e.layout()
+}
+
+// CurrentHeight returns the height including the partially revealed content
+func (e *Expander) CurrentHeight() int {
+	contentHeight := 0
+	if e.content != nil {
+		contentHeight = e.content.Bounds().Height
+	}
+	return e.headerHeight + int(float64(contentHeight)*e.progress+0.5)
+}
+
+// target returns the progress value the animation is heading towards
+func (e *Expander) target() float64 {
+	if e.expanded {
+		return 1
+	}
+	return 0
+}
+
+// advance steps the expand animation based on elapsed time
+func (e *Expander) advance() {
+	target := e.target()
+	if e.progress == target {
+		return
+	}
+
+	t := 1.0
+	if duration := AnimationDuration(e.animDuration); duration > 0 {
+		t = float64(since(e.animStart)) / float64(duration)
+	}
+	if t >= 1 {
+		e.progress = target
+	} else {
+		// Ease out so the motion settles gently
+		t = 1 - (1-t)*(1-t)
+		e.progress = e.animFrom + (target-e.animFrom)*t
+	}
+	e.layout()
+}
+
+// layout sizes the expander to its current height and positions the content below the header
+func (e *Expander) layout() {
+	bounds := e.Bounds()
+	e.Node.SetBounds(Rect{X: bounds.X, Y: bounds.Y, Width: bounds.Width, Height: e.CurrentHeight()})
+
+	if e.content == nil {
+		return
+	}
+	if node, ok := e.content.(NodeElement); ok {
+		node.SetRelativePosition(Point{X: 0, Y: e.headerHeight})
+	}
+	if v, ok := e.content.(interface{ SetVisible(bool) }); ok {
+		v.SetVisible(e.progress > 0)
+	}
+}
+
+// headerRect returns the bounds of the clickable header
+func (e *Expander) headerRect() Rect {
+	bounds := e.ComputedBounds()
+	return Rect{X: bounds.X, Y: bounds.Y, Width: bounds.Width, Height: e.headerHeight}
+}
+
+// Update advances the expand animation and updates children
+func (e *Expander) Update() {
+	e.advance()
+	e.Node.Update()
+}
+
+// Draw draws the header and, when expanded, the content clipped to the current height
+func (e *Expander) Draw(surface DrawSurface) {
+	if !e.IsVisible() {
+		return
+	}
+
+	e.advance()
+	bounds := e.ComputedBounds()
+	header := e.headerRect()
+
+	bg := e.headerColor
+	if e.hovered {
+		bg = e.hoverColor
+	}
+	surface.FillRect(header.X, header.Y, header.Width, header.Height, bg)
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, color.RGBA{180, 180, 180, 255})
+
+	// Disclosure arrow pointing right when collapsed and down when expanded
+	cx, cy := header.X+14, header.Y+header.Height/2
+	if e.expanded {
+		surface.DrawLine(cx-4, cy-2, cx, cy+2, e.textColor)
+		surface.DrawLine(cx, cy+2, cx+4, cy-2, e.textColor)
+	} else {
+		surface.DrawLine(cx-2, cy-4, cx+2, cy, e.textColor)
+		surface.DrawLine(cx+2, cy, cx-2, cy+4, e.textColor)
+	}
+
+	textY := header.Y + (header.Height-e.fontSize)/2
+	surface.DrawText(e.title, header.X+28, textY, e.textColor, e.fontSize)
+
+	if e.progress <= 0 || e.content == nil {
+		return
+	}
+
+	// Reveal only as much content as the animation allows
+	visibleHeight := bounds.Height - e.headerHeight
+	surface.FillRect(bounds.X+1, header.Y+header.Height, bounds.Width-2, visibleHeight-1, e.backgroundColor)
+	surface.SetClipRect(bounds.X, header.Y+header.Height, bounds.Width, visibleHeight)
+	e.content.Draw(surface)
+	surface.ResetClipRect()
+}
+
+// HandleMouseDown toggles the section on header clicks and forwards content clicks
+func (e *Expander) HandleMouseDown(event *Event) {
+	x, y := event.X, event.Y
+	if !e.IsVisible() {
+		return
+	}
+
+	if PointInRect(Point{x, y}, e.headerRect()) {
+		e.Toggle()
+		event.Consume()
+		return
+	}
+
+	if e.expanded && e.content != nil && PointInRect(Point{x, y}, e.ComputedBounds()) {
+		e.content.HandleMouseDown(event)
+	}
+}
+
+// HandleMouseUp forwards mouse up events to the content when expanded
+func (e *Expander) HandleMouseUp(event *Event) {
+	if e.IsVisible() && e.expanded && e.content != nil {
+		e.content.HandleMouseUp(event)
+	}
+}
+
+// HandleMouseMove tracks header hover and forwards events to the content when expanded
+func (e *Expander) HandleMouseMove(event *Event) {
+	x, y := event.X, event.Y
+	if !e.IsVisible() {
+		return
+	}
+
+	e.hovered = PointInRect(Point{x, y}, e.headerRect())
+	if e.hovered {
+		event.Consume()
+		return
+	}
+
+	if e.expanded && e.content != nil {
+		e.content.HandleMouseMove(event)
+	}
+}
+
+// Accordion stacks expanders vertically, optionally keeping only one open at a time
+type Accordion struct {
+	*Node
+	sections      []*Expander
+	exclusive     bool
+	spacing       int
+	onSectionOpen func(int)
+}
+
+// NewAccordion creates a new accordion in exclusive mode
+func NewAccordion(id string) *Accordion {
+	return &Accordion{
+		Node:      NewNode(id),
+		sections:  make([]*Expander, 0),
+		exclusive: true,
+		spacing:   2,
+	}
+}
+
+// AddSection adds a collapsed section with the given title and returns it
+func (a *Accordion) AddSection(title string) *Expander {
+	section := NewExpander(NewID(a.ID()+"_section"), title)
+	a.AddExpander(section)
+	return section
+}
+
+// AddExpander adds an existing expander as a section
+func (a *Accordion) AddExpander(section *Expander) {
+	a.sections = append(a.sections, section)
+	a.Node.AddChild(section)
+	section.accordion = a
+
+	if section.IsExpanded() {
+		a.sectionOpened(section)
+	}
+	a.layoutSections()
+}
+
+// RemoveSection removes the section at the given index
+func (a *Accordion) RemoveSection(index int) {
+	if index < 0 || index >= len(a.sections) {
+		return
+	}
+	a.sections[index].accordion = nil
+	a.Node.RemoveChild(a.sections[index])
+	a.sections = append(a.sections[:index], a.sections[index+1:]...)
+	a.layoutSections()
+}
+
+// Section returns the section at the given index
+func (a *Accordion) Section(index int) *Expander {
+	if index < 0 || index >= len(a.sections) {
+		return nil
+	}
+	return a.sections[index]
+}
+
+// SectionCount returns the number of sections
+func (a *Accordion) SectionCount() int {
+	return len(a.sections)
+}
+
+// SetExclusive sets whether opening a section collapses the others
+func (a *Accordion) SetExclusive(exclusive bool) {
+	a.exclusive = exclusive
+	if !exclusive {
+		return
+	}
+
+	// Keep only the first open section
+	found := false
+	for _, section := range a.sections {
+		if section.IsExpanded() {
+			if found {
+				section.SetExpanded(false)
+			}
+			found = true
+		}
+	}
+}
+
+// IsExclusive returns whether only one section may be open at a time
+func (a *Accordion) IsExclusive() bool {
+	return a.exclusive
+}
+
+// SetSpacing sets the gap between sections
+func (a *Accordion) SetSpacing(spacing int) {
+	a.spacing = spacing
+	a.layoutSections()
+}
+
+// SetOnSectionOpen sets the handler for when a section is expanded
+func (a *Accordion) SetOnSectionOpen(handler func(int)) {
+	a.onSectionOpen = handler
+}
+
+// ExpandSection expands the section at the given index
+func (a *Accordion) ExpandSection(index int) {
+	if section := a.Section(index); section != nil {
+		section.SetExpanded(true)
+	}
+}
+
+// CollapseAll collapses every section
+func (a *Accordion) CollapseAll() {
+	for _, section := range a.sections {
+		section.SetExpanded(false)
+	}
+}
+
+// ExpandedIndices returns the indices of the expanded sections
+func (a *Accordion) ExpandedIndices() []int {
+	indices := make([]int, 0)
+	for i, section := range a.sections {
+		if section.IsExpanded() {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// SetBounds sets the bounds and stretches the sections to the new width
+func (a *Accordion) SetBounds(bounds Rect) {
+	a.Node.SetBounds(bounds)
+	a.layoutSections()
+}
+
+// indexOf returns the index of a section, or -1
+func (a *Accordion) indexOf(section *Expander) int {
+	for i, s := range a.sections {
+		if s == section {
+			return i
+		}
+	}
+	return -1
+}
+
+// sectionOpened collapses the other sections in exclusive mode and notifies the open handler
+func (a *Accordion) sectionOpened(opened *Expander) {
+	if a.exclusive {
+		for _, section := range a.sections {
+			if section != opened {
+				section.SetExpanded(false)
+			}
+		}
+	}
+	if a.onSectionOpen != nil {
+		if index := a.indexOf(opened); index >= 0 {
+			a.onSectionOpen(index)
+		}
+	}
+}
+
+// layoutSections stacks the sections using their current (possibly animating) heights
+func (a *Accordion) layoutSections() {
+	bounds := a.Bounds()
+	y := 0
+	for _, section := range a.sections {
+		section.advance()
+		section.SetBounds(Rect{X: bounds.X, Y: bounds.Y + y, Width: bounds.Width, Height: section.CurrentHeight()})
+		section.SetRelativePosition(Point{X: 0, Y: y})
+		y += section.CurrentHeight() + a.spacing
+	}
+}
+
+// Update advances the section animations and restacks them
+func (a *Accordion) Update() {
+	a.Node.Update()
+	a.layoutSections()
+}
+
+// Draw restacks the sections for the current animation frame and draws them
+func (a *Accordion) Draw(surface DrawSurface) {
+	if !a.IsVisible() {
+		return
+	}
+
+	a.layoutSections()
+	for _, section := range a.sections {
+		section.Draw(surface)
+	}
+}
+
+// HandleMouseDown forwards mouse down events to the sections
+func (a *Accordion) HandleMouseDown(e *Event) {
+	if !a.IsVisible() {
+		return
+	}
+	for _, section := range a.sections {
+		if section.HandleMouseDown(e); e.Consumed() {
+			a.layoutSections()
+			return
+		}
+	}
+}
+
+// HandleMouseUp forwards mouse up events to the sections
+func (a *Accordion) HandleMouseUp(e *Event) {
+	if !a.IsVisible() {
+		return
+	}
+	for _, section := range a.sections {
+		if section.HandleMouseUp(e); e.Consumed() {
+			return
+		}
+	}
+}
+
+// HandleMouseMove forwards mouse move events to the sections
+func (a *Accordion) HandleMouseMove(e *Event) {
+	if !a.IsVisible() {
+		return
+	}
+	for _, section := range a.sections {
+		// Every section sees the move so stale hover states are cleared
+		moved := e.unconsumed()
+		if section.HandleMouseMove(moved); moved.Consumed() {
+			e.Consume()
+		}
+	}
+}
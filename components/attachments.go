@@ -0,0 +1,378 @@
+package components
+
+import (
+	"image/color"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Attachment is a file chosen for upload, from the file dialog or dropped
+// onto the window. Dropped files have no Path, so read them with Open.
+type Attachment struct {
+	Name string
+	Path string // Location on disk, empty for dropped files
+	Size int64
+	open func() (io.ReadCloser, error)
+}
+
+// Open opens the file's contents
+func (a Attachment) Open() (io.ReadCloser, error) {
+	if a.open != nil {
+		return a.open()
+	}
+	return os.Open(a.Path)
+}
+
+// Type returns the file extension in upper case, e.g. "PDF"
+func (a Attachment) Type() string {
+	return strings.ToUpper(strings.TrimPrefix(filepath.Ext(a.Name), "."))
+}
+
+// attachmentKind groups a file by extension for its chip icon
+func attachmentKind(name string) (string, color.RGBA) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".png", ".jpg", ".jpeg", ".gif", ".bmp", ".webp", ".svg":
+		return "IMG", color.RGBA{60, 150, 90, 255}
+	case ".pdf", ".doc", ".docx", ".txt", ".md", ".rtf", ".odt":
+		return "DOC", color.RGBA{70, 110, 190, 255}
+	case ".xls", ".xlsx", ".csv", ".ods":
+		return "XLS", color.RGBA{40, 130, 70, 255}
+	case ".zip", ".tar", ".gz", ".7z", ".rar":
+		return "ZIP", color.RGBA{150, 110, 50, 255}
+	case ".mp3", ".wav", ".ogg", ".flac":
+		return "AUD", color.RGBA{150, 70, 160, 255}
+	case ".mp4", ".mov", ".avi", ".mkv", ".webm":
+		return "VID", color.RGBA{190, 70, 70, 255}
+	}
+	return "FILE", color.RGBA{120, 120, 120, 255}
+}
+
+// AttachmentList shows chosen files as removable chips with a type icon and
+// size. Files are added with the "Add file" button, which opens a file
+// dialog, or by dropping them on the list. Add Dialog() to the tree above
+// other content so it is drawn on top when open.
+type AttachmentList struct {
+	*Node
+	attachments     []Attachment
+	dialog          *FileDialog
+	filters         []FileFilter
+	maxFiles        int // 0 for no limit
+	pressed         int // Chip whose remove button the mouse went down on, attachmentAdd, or -1
+	hovered         int
+	chipHeight      int
+	spacing         int
+	fontSize        int
+	onChange        func([]Attachment)
+	backgroundColor color.RGBA
+	chipColor       color.RGBA
+	hoverColor      color.RGBA
+	borderColor     color.RGBA
+	textColor       color.RGBA
+	mutedColor      color.RGBA
+}
+
+// attachmentAdd is the pressed or hovered value for the add button
+const attachmentAdd = -2
+
+// NewAttachmentList creates an empty list with its own file dialog
+func NewAttachmentList(id string) *AttachmentList {
+	l := &AttachmentList{
+		Node:            NewNode(id),
+		dialog:          NewFileDialog(id+"_dialog", FileDialogOpen),
+		pressed:         -1,
+		hovered:         -1,
+		chipHeight:      28,
+		spacing:         6,
+		fontSize:        13,
+		backgroundColor: color.RGBA{250, 250, 250, 255},
+		chipColor:       color.RGBA{232, 236, 242, 255},
+		hoverColor:      color.RGBA{215, 222, 235, 255},
+		borderColor:     color.RGBA{180, 180, 180, 255},
+		textColor:       color.RGBA{0, 0, 0, 255},
+		mutedColor:      color.RGBA{120, 120, 120, 255},
+	}
+	l.dialog.SetOnResult(func(path string, ok bool) {
+		if ok {
+			l.AddFile(path)
+		}
+	})
+	return l
+}
+
+// Dialog returns the file dialog opened by the add button
+func (l *AttachmentList) Dialog() *FileDialog {
+	return l.dialog
+}
+
+// SetFilters limits the files that can be added, in the dialog and by dropping
+func (l *AttachmentList) SetFilters(filters []FileFilter) {
+	l.filters = filters
+	l.dialog.SetFilters(filters)
+}
+
+// Filters returns the extension filters
+func (l *AttachmentList) Filters() []FileFilter {
+	return l.filters
+}
+
+// SetMaxFiles limits how many files can be attached; 0 removes the limit
+func (l *AttachmentList) SetMaxFiles(max int) {
+	l.maxFiles = max
+}
+
+// SetOnChange sets the handler called when files are added or removed
+func (l *AttachmentList) SetOnChange(handler func([]Attachment)) {
+	l.onChange = handler
+}
+
+// Attachments returns the attached files
+func (l *AttachmentList) Attachments() []Attachment {
+	return l.attachments
+}
+
+// Paths returns the paths of the attached files, or their names for
+// dropped files
+func (l *AttachmentList) Paths() []string {
+	paths := make([]string, len(l.attachments))
+	for i, a := range l.attachments {
+		paths[i] = a.Path
+		if paths[i] == "" {
+			paths[i] = a.Name
+		}
+	}
+	return paths
+}
+
+// accepts returns whether another file with the name can be added
+func (l *AttachmentList) accepts(name string) bool {
+	if l.maxFiles > 0 && len(l.attachments) >= l.maxFiles {
+		return false
+	}
+	if len(l.filters) == 0 {
+		return true
+	}
+	for _, filter := range l.filters {
+		if filter.Matches(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddFile attaches a file from disk. Returns false if it is missing, a
+// directory, filtered out or over the limit.
+func (l *AttachmentList) AddFile(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return l.AddAttachment(Attachment{Name: filepath.Base(path), Path: path, Size: info.Size()})
+}
+
+// AddAttachment attaches a file, subject to the filters and limit
+func (l *AttachmentList) AddAttachment(a Attachment) bool {
+	if !l.accepts(a.Name) {
+		return false
+	}
+	l.attachments = append(l.attachments, a)
+	l.changed()
+	return true
+}
+
+// Remove detaches the file at an index
+func (l *AttachmentList) Remove(index int) {
+	if index < 0 || index >= len(l.attachments) {
+		return
+	}
+	l.attachments = append(l.attachments[:index], l.attachments[index+1:]...)
+	l.changed()
+}
+
+// Clear detaches every file
+func (l *AttachmentList) Clear() {
+	l.attachments = nil
+	l.changed()
+}
+
+// changed notifies the change handler
+func (l *AttachmentList) changed() {
+	if l.onChange != nil {
+		l.onChange(l.attachments)
+	}
+}
+
+// HandleFileDrop attaches files dropped on the list. Directories are
+// walked so their files are added.
+func (l *AttachmentList) HandleFileDrop(x, y int, files fs.FS) bool {
+	if !l.IsVisible() || !PointInRect(Point{x, y}, l.ComputedBounds()) {
+		return false
+	}
+	fs.WalkDir(files, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		l.AddAttachment(Attachment{
+			Name: path.Base(name),
+			Size: info.Size(),
+			open: func() (io.ReadCloser, error) { return files.Open(name) },
+		})
+		return nil
+	})
+	return true
+}
+
+// chipRects lays out the chips left to right, wrapping to new rows, followed
+// by the add button
+func (l *AttachmentList) chipRects() ([]Rect, Rect) {
+	bounds := l.ComputedBounds()
+	x, y := bounds.X+l.spacing, bounds.Y+l.spacing
+	place := func(width int) Rect {
+		if x+width > bounds.X+bounds.Width-l.spacing && x > bounds.X+l.spacing {
+			x = bounds.X + l.spacing
+			y += l.chipHeight + l.spacing
+		}
+		rect := Rect{X: x, Y: y, Width: width, Height: l.chipHeight}
+		x += width + l.spacing
+		return rect
+	}
+
+	rects := make([]Rect, len(l.attachments))
+	for i, a := range l.attachments {
+		label := a.Name + "  " + formatSize(a.Size)
+		width := min(l.chipHeight+MeasureText(label)+l.chipHeight, bounds.Width-2*l.spacing)
+		rects[i] = place(width)
+	}
+	add := place(MeasureText("+ Add file") + 20)
+	return rects, add
+}
+
+// removeRect returns the bounds of a chip's remove button
+func (l *AttachmentList) removeRect(chip Rect) Rect {
+	return Rect{X: chip.X + chip.Width - l.chipHeight, Y: chip.Y, Width: l.chipHeight, Height: chip.Height}
+}
+
+// hitTest returns the chip whose remove button is at the point,
+// attachmentAdd for the add button, or -1
+func (l *AttachmentList) hitTest(x, y int) int {
+	p := Point{x, y}
+	chips, add := l.chipRects()
+	if PointInRect(p, add) && l.canAdd() {
+		return attachmentAdd
+	}
+	for i, chip := range chips {
+		if PointInRect(p, l.removeRect(chip)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// canAdd returns whether the limit leaves room for another file
+func (l *AttachmentList) canAdd() bool {
+	return l.maxFiles == 0 || len(l.attachments) < l.maxFiles
+}
+
+// Draw draws the chips, the add button and a drop hint while empty
+func (l *AttachmentList) Draw(surface DrawSurface) {
+	if !l.IsVisible() {
+		return
+	}
+
+	bounds := l.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, l.backgroundColor)
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, l.borderColor)
+	surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
+
+	chips, add := l.chipRects()
+	for i, chip := range chips {
+		a := l.attachments[i]
+		surface.FillRect(chip.X, chip.Y, chip.Width, chip.Height, l.chipColor)
+		surface.DrawRect(chip.X, chip.Y, chip.Width, chip.Height, l.borderColor)
+
+		// Type icon: a colored tile with the file kind
+		kind, kindColor := attachmentKind(a.Name)
+		icon := Rect{X: chip.X + 4, Y: chip.Y + 4, Width: chip.Height - 8, Height: chip.Height - 8}
+		surface.FillRect(icon.X, icon.Y, icon.Width, icon.Height, kindColor)
+		surface.DrawText(kind[:1], icon.X+(icon.Width-MeasureText(kind[:1]))/2, icon.Y+(icon.Height-l.fontSize)/2, color.RGBA{255, 255, 255, 255}, l.fontSize)
+
+		textY := chip.Y + (chip.Height-l.fontSize)/2
+		textX := chip.X + chip.Height
+		surface.DrawText(a.Name, textX, textY, l.textColor, l.fontSize)
+		surface.DrawText(formatSize(a.Size), textX+MeasureText(a.Name+"  "), textY, l.mutedColor, l.fontSize)
+
+		remove := l.removeRect(chip)
+		if l.hovered == i {
+			surface.FillRect(remove.X+4, remove.Y+4, remove.Width-8, remove.Height-8, l.hoverColor)
+		}
+		cx, cy := remove.X+remove.Width/2, remove.Y+remove.Height/2
+		surface.DrawLine(cx-4, cy-4, cx+4, cy+4, l.textColor)
+		surface.DrawLine(cx-4, cy+4, cx+4, cy-4, l.textColor)
+	}
+
+	if l.canAdd() {
+		if l.hovered == attachmentAdd {
+			surface.FillRect(add.X, add.Y, add.Width, add.Height, l.hoverColor)
+		}
+		surface.DrawRect(add.X, add.Y, add.Width, add.Height, l.borderColor)
+		surface.DrawText("+ Add file", add.X+10, add.Y+(add.Height-l.fontSize)/2, l.textColor, l.fontSize)
+		if len(l.attachments) == 0 {
+			surface.DrawText("or drop files here", add.X+add.Width+10, add.Y+(add.Height-l.fontSize)/2, l.mutedColor, l.fontSize)
+		}
+	}
+	surface.ResetClipRect()
+}
+
+// HandleMouseDown presses the add or a remove button; it acts when the
+// mouse is released over it
+func (l *AttachmentList) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !l.IsVisible() {
+		return
+	}
+	if l.pressed != -1 {
+		e.Consume()
+		return
+	}
+	if !PointInRect(Point{x, y}, l.ComputedBounds()) {
+		return
+	}
+	l.pressed = l.hitTest(x, y)
+	e.Consume()
+}
+
+// HandleMouseUp opens the dialog or removes a chip if released over the
+// pressed button
+func (l *AttachmentList) HandleMouseUp(e *Event) {
+	x, y := e.X, e.Y
+	pressed := l.pressed
+	l.pressed = -1
+	if pressed == -1 || l.hitTest(x, y) != pressed {
+		return
+	}
+	if pressed == attachmentAdd {
+		l.dialog.Open()
+	} else {
+		l.Remove(pressed)
+	}
+	e.Consume()
+}
+
+// HandleMouseMove tracks the hovered button
+func (l *AttachmentList) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !l.IsVisible() {
+		return
+	}
+	l.hovered = l.hitTest(x, y)
+	if l.hovered != -1 {
+		e.Consume()
+	}
+}
@@ -0,0 +1,196 @@
+package components
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/mp3"
+	"github.com/hajimehoshi/ebiten/v2/audio/vorbis"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+)
+
+// AudioSampleRate is the sample rate of the shared audio context. Sources
+// at other rates are resampled when decoded.
+const AudioSampleRate = 44100
+
+// ErrUnsupportedAudio is returned for files that aren't WAV, OGG or MP3
+var ErrUnsupportedAudio = errors.New("unsupported audio format")
+
+var audioContextMu sync.Mutex
+
+// sharedAudioContext returns the audio context, creating it on first use.
+// Ebiten allows only one per process, so one created by the application is
+// reused.
+func sharedAudioContext() *audio.Context {
+	audioContextMu.Lock()
+	defer audioContextMu.Unlock()
+	if ctx := audio.CurrentContext(); ctx != nil {
+		return ctx
+	}
+	return audio.NewContext(AudioSampleRate)
+}
+
+// audioStream is a decoded stream that knows its length in bytes
+type audioStream interface {
+	io.ReadSeeker
+	Length() int64
+}
+
+// decodeAudio decodes a WAV, OGG Vorbis or MP3 stream, chosen by the file
+// extension, at the context's sample rate
+func decodeAudio(r io.ReadSeeker, path string, sampleRate int) (audioStream, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".wav":
+		return wav.DecodeWithSampleRate(sampleRate, r)
+	case ".ogg", ".oga":
+		return vorbis.DecodeWithSampleRate(sampleRate, r)
+	case ".mp3":
+		return mp3.DecodeWithSampleRate(sampleRate, r)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedAudio, path)
+}
+
+// load replaces the player with one for a new source. The closer, if any,
+// is closed with the player.
+func (a *Audio) load(r io.ReadSeeker, closer io.Closer, path string) error {
+	a.unload()
+	a.source = path
+
+	ctx := sharedAudioContext()
+	stream, err := decodeAudio(r, path, ctx.SampleRate())
+	if err == nil {
+		a.player, err = ctx.NewPlayer(stream)
+	}
+	if err != nil {
+		if closer != nil {
+			closer.Close()
+		}
+		a.loadErr = err
+		return err
+	}
+
+	a.closer = closer
+	// Decoded streams are 16-bit stereo, four bytes per sample
+	a.duration = time.Duration(stream.Length()) * time.Second / time.Duration(ctx.SampleRate()*4)
+	a.player.SetVolume(a.volume)
+	return nil
+}
+
+// unload stops playback and releases the current source
+func (a *Audio) unload() {
+	if a.player != nil {
+		a.player.Close()
+		a.player = nil
+	}
+	if a.closer != nil {
+		a.closer.Close()
+		a.closer = nil
+	}
+	a.isPlaying = false
+	a.duration = 0
+	a.loadErr = nil
+}
+
+// SetSourceFS loads an audio file from a file system such as an embed.FS.
+// The file is read into memory.
+func (a *Audio) SetSourceFS(fsys fs.FS, path string) error {
+	data, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		a.unload()
+		a.loadErr = err
+		return err
+	}
+	return a.load(bytes.NewReader(data), nil, path)
+}
+
+// SetSourceReader loads audio from a reader; the name's extension picks the
+// decoder
+func (a *Audio) SetSourceReader(r io.ReadSeeker, name string) error {
+	return a.load(r, nil, name)
+}
+
+// openAudioFile opens a file for streaming
+func (a *Audio) openAudioFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		a.unload()
+		a.source = path
+		a.loadErr = err
+		return err
+	}
+	return a.load(file, file, path)
+}
+
+// LoadError returns why the source couldn't be loaded, or nil
+func (a *Audio) LoadError() error {
+	return a.loadErr
+}
+
+// Seek moves playback to a position, clamped to the length of the source
+func (a *Audio) Seek(position time.Duration) error {
+	if a.player == nil {
+		return nil
+	}
+	position = max(0, min(position, a.duration))
+	return a.player.SetPosition(position)
+}
+
+// Position returns how far playback has got
+func (a *Audio) Position() time.Duration {
+	if a.player == nil {
+		return 0
+	}
+	return min(a.player.Position(), a.duration)
+}
+
+// Duration returns the length of the source
+func (a *Audio) Duration() time.Duration {
+	return a.duration
+}
+
+// Progress returns the position as a fraction of the duration, for a seek
+// bar
+func (a *Audio) Progress() float64 {
+	if a.duration <= 0 {
+		return 0
+	}
+	return float64(a.Position()) / float64(a.duration)
+}
+
+// Close stops playback and releases the source
+func (a *Audio) Close() {
+	a.unload()
+}
+
+// advance notices when the player has run out of audio
+func (a *Audio) advance() {
+	if !a.isPlaying || a.player == nil || a.player.IsPlaying() {
+		return
+	}
+	a.isPlaying = false
+	a.player.Rewind()
+	if a.onComplete != nil {
+		a.onComplete()
+	}
+}
+
+// Update checks for the end of playback and updates children
+func (a *Audio) Update() {
+	a.advance()
+	a.Node.Update()
+}
+
+// formatPlaybackTime formats a duration as m:ss
+func formatPlaybackTime(d time.Duration) string {
+	seconds := int(d / time.Second)
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
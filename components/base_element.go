@@ -10,6 +10,7 @@ type BaseElement struct {
 	bounds   Rect
 	parent   Element
 	children []Element
+	ids      map[string]int // Count of each ID in the tree, kept on its root
 	mouseOver bool
 	pressed   bool
 }
@@ -33,6 +34,9 @@ func (b *BaseElement) ID() string {
 
 // SetID sets the element's ID
 func (b *BaseElement) SetID(id string) {
+	if id != b.id {
+		renameID(b, b.id, id)
+	}
 	b.id = id
 }
 
@@ -63,16 +67,30 @@ func (b *BaseElement) Children() []Element {
 
 // AddChild adds a child element
 func (b *BaseElement) AddChild(child Element) {
+	attachIDs(b, child)
 	b.children = append(b.children, child)
 	child.SetParent(b)
 	fmt.Printf("Added child %s to %s\n", child.ID(), b.id)
 }
 
+// InsertChild inserts a child element at an index among the children,
+// clamped to their range
+func (b *BaseElement) InsertChild(index int, child Element) {
+	attachIDs(b, child)
+	index = max(0, min(index, len(b.children)))
+	b.children = append(b.children, nil)
+	copy(b.children[index+1:], b.children[index:])
+	b.children[index] = child
+	child.SetParent(b)
+}
+
 // RemoveChild removes a child element
 func (b *BaseElement) RemoveChild(child Element) {
 	for i, c := range b.children {
 		if c == child {
 			b.children = append(b.children[:i], b.children[i+1:]...)
+			detachIDs(b, child)
+			child.SetParent(nil)
 			break
 		}
 	}
@@ -80,6 +98,10 @@ func (b *BaseElement) RemoveChild(child Element) {
 
 // RemoveAllChildren removes all child elements
 func (b *BaseElement) RemoveAllChildren() {
+	for _, child := range b.children {
+		detachIDs(b, child)
+		child.SetParent(nil)
+	}
 	b.children = make([]Element, 0)
 }
 
@@ -94,7 +116,8 @@ func (b *BaseElement) IsMouseOver(x, y int) bool {
 }
 
 // HandleMouseDown handles mouse down events
-func (b *BaseElement) HandleMouseDown(x, y int) bool {
+func (b *BaseElement) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
 	if b.IsMouseOver(x, y) {
 		b.pressed = true
 		fmt.Printf("MouseDown on %s\n", b.id)
@@ -102,18 +125,18 @@ func (b *BaseElement) HandleMouseDown(x, y int) bool {
 		// Check if any children handle the event
 		for i := len(b.children) - 1; i >= 0; i-- {
 			child := b.children[i]
-			if child.HandleMouseDown(x, y) {
-				return true
+			if child.HandleMouseDown(e); e.Consumed() {
+				return
 			}
 		}
 		
-		return true
+		e.Consume()
 	}
-	return false
 }
 
 // HandleMouseUp handles mouse up events
-func (b *BaseElement) HandleMouseUp(x, y int) bool {
+func (b *BaseElement) HandleMouseUp(e *Event) {
+	x, y := e.X, e.Y
 	wasPressed := b.pressed
 	b.pressed = false
 	
@@ -123,27 +146,27 @@ func (b *BaseElement) HandleMouseUp(x, y int) bool {
 		// Check if any children handle the event
 		for i := len(b.children) - 1; i >= 0; i-- {
 			child := b.children[i]
-			if child.HandleMouseUp(x, y) {
-				return true
+			if child.HandleMouseUp(e); e.Consumed() {
+				return
 			}
 		}
 		
-		return true
+		e.Consume()
+		return
 	}
 	
 	// Still try children even if this element didn't handle it
 	for i := len(b.children) - 1; i >= 0; i-- {
 		child := b.children[i]
-		if child.HandleMouseUp(x, y) {
-			return true
+		if child.HandleMouseUp(e); e.Consumed() {
+			return
 		}
 	}
-	
-	return false
 }
 
 // HandleMouseMove handles mouse move events
-func (b *BaseElement) HandleMouseMove(x, y int) bool {
+func (b *BaseElement) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
 	wasOver := b.mouseOver
 	b.mouseOver = b.IsMouseOver(x, y)
 	
@@ -158,12 +181,14 @@ func (b *BaseElement) HandleMouseMove(x, y int) bool {
 	// Check if any children handle the event
 	for i := len(b.children) - 1; i >= 0; i-- {
 		child := b.children[i]
-		if child.HandleMouseMove(x, y) {
-			return true
+		if child.HandleMouseMove(e); e.Consumed() {
+			return
 		}
 	}
 	
-	return b.mouseOver
+	if b.mouseOver {
+		e.Consume()
+	}
 }
 
 // Draw draws the base element and its children
@@ -0,0 +1,95 @@
+package components
+
+import "sync"
+
+// A batch groups many changes, such as adding a thousand rows, so that work
+// done after each change (re-layout, state notifications) runs once at the
+// end instead. Batches run on the UI goroutine and may nest; the deferred
+// work runs when the outermost one ends, before the next frame is drawn.
+var (
+	batchMu      sync.Mutex
+	batchDepth   int
+	batchQueue   []any          // Keys in the order they were first deferred
+	batchPending map[any]func() // Deferred work by key
+)
+
+// BeginBatch opens a batch
+func BeginBatch() {
+	batchMu.Lock()
+	defer batchMu.Unlock()
+	batchDepth++
+}
+
+// EndBatch closes a batch, running the deferred work if it was the
+// outermost. Work deferred while that work runs is run too.
+func EndBatch() {
+	batchMu.Lock()
+	if batchDepth > 1 {
+		batchDepth--
+		batchMu.Unlock()
+		return
+	}
+
+	// If deferred work panics and the panic is recovered, the batch is
+	// closed and the work not yet run stays queued for the next one
+	var rest []any
+	finished := false
+	defer func() {
+		if !finished {
+			batchMu.Lock()
+			batchQueue = append(rest, batchQueue...)
+			batchDepth = 0
+			batchMu.Unlock()
+		}
+	}()
+
+	for len(batchQueue) > 0 {
+		queue := batchQueue
+		batchQueue = nil
+		for i, key := range queue {
+			fn := batchPending[key]
+			delete(batchPending, key)
+			rest = queue[i+1:]
+			batchMu.Unlock()
+			fn()
+			batchMu.Lock()
+		}
+	}
+	batchDepth = 0
+	finished = true
+	batchMu.Unlock()
+}
+
+// Batch runs fn in a batch
+func Batch(fn func()) {
+	BeginBatch()
+	defer EndBatch()
+	fn()
+}
+
+// InBatch returns whether a batch is open
+func InBatch() bool {
+	batchMu.Lock()
+	defer batchMu.Unlock()
+	return batchDepth > 0
+}
+
+// Batched runs fn now, or when the batch ends if one is open. Work deferred
+// again under the same key, such as one container's layout, runs once, as
+// the latest fn, in the place it was first deferred.
+func Batched(key any, fn func()) {
+	batchMu.Lock()
+	if batchDepth == 0 {
+		batchMu.Unlock()
+		fn()
+		return
+	}
+	defer batchMu.Unlock()
+	if batchPending == nil {
+		batchPending = make(map[any]func())
+	}
+	if _, ok := batchPending[key]; !ok {
+		batchQueue = append(batchQueue, key)
+	}
+	batchPending[key] = fn
+}
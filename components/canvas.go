@@ -0,0 +1,214 @@
+package components
+
+import (
+	"image"
+	"image/color"
+)
+
+// Canvas is an element whose content is drawn by a callback each frame, for
+// charts, games and other custom visuals that don't need a full Element.
+// The callback draws in local coordinates, with (0, 0) at the canvas's top
+// left, clipped to the canvas. Mouse handlers get local coordinates too.
+type Canvas struct {
+	*Node
+	onDraw          func(surface DrawSurface, width, height int)
+	onMouseDown     func(x, y int)
+	onMouseUp       func(x, y int)
+	onMouseMove     func(x, y int)
+	onScroll        func(x, y int, deltaX, deltaY float64)
+	pressed         bool
+	backgroundColor color.RGBA
+}
+
+// NewCanvas creates a canvas that draws with the callback
+func NewCanvas(id string, draw func(surface DrawSurface, width, height int)) *Canvas {
+	return &Canvas{
+		Node:   NewNode(id),
+		onDraw: draw,
+	}
+}
+
+// SetOnDraw replaces the draw callback
+func (c *Canvas) SetOnDraw(draw func(surface DrawSurface, width, height int)) {
+	c.onDraw = draw
+}
+
+// SetBackgroundColor sets a color filled behind the drawing; transparent by
+// default
+func (c *Canvas) SetBackgroundColor(color color.RGBA) {
+	c.backgroundColor = color
+}
+
+// SetOnMouseDown sets the handler for presses on the canvas
+func (c *Canvas) SetOnMouseDown(handler func(x, y int)) {
+	c.onMouseDown = handler
+}
+
+// SetOnMouseUp sets the handler for the release of a press that started on
+// the canvas, wherever it happens
+func (c *Canvas) SetOnMouseUp(handler func(x, y int)) {
+	c.onMouseUp = handler
+}
+
+// SetOnMouseMove sets the handler for moves over the canvas, and for drags
+// that started on it even once they leave it
+func (c *Canvas) SetOnMouseMove(handler func(x, y int)) {
+	c.onMouseMove = handler
+}
+
+// SetOnScroll sets the handler for the mouse wheel over the canvas
+func (c *Canvas) SetOnScroll(handler func(x, y int, deltaX, deltaY float64)) {
+	c.onScroll = handler
+}
+
+// IsPressed returns whether a press that started on the canvas is held
+func (c *Canvas) IsPressed() bool {
+	return c.pressed
+}
+
+// local converts screen coordinates to the canvas's coordinates
+func (c *Canvas) local(x, y int) (int, int) {
+	bounds := c.ComputedBounds()
+	return x - bounds.X, y - bounds.Y
+}
+
+// Draw fills the background, runs the draw callback and draws children
+func (c *Canvas) Draw(surface DrawSurface) {
+	if !c.IsVisible() {
+		return
+	}
+
+	bounds := c.ComputedBounds()
+	if c.backgroundColor.A > 0 {
+		surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, c.backgroundColor)
+	}
+	if c.onDraw != nil && bounds.Width > 0 && bounds.Height > 0 {
+		surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
+		c.onDraw(&canvasSurface{surface: surface, bounds: bounds}, bounds.Width, bounds.Height)
+		surface.ResetClipRect()
+	}
+
+	for _, child := range c.Children() {
+		child.Draw(surface)
+	}
+}
+
+// HandleMouseDown passes presses on the canvas to the handler
+func (c *Canvas) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !c.IsVisible() || !PointInRect(Point{x, y}, c.ComputedBounds()) {
+		return
+	}
+	for i := len(c.Children()) - 1; i >= 0; i-- {
+		if c.Children()[i].HandleMouseDown(e); e.Consumed() {
+			return
+		}
+	}
+	c.pressed = true
+	if c.onMouseDown != nil {
+		c.onMouseDown(c.local(x, y))
+	}
+	e.Consume()
+}
+
+// HandleMouseUp ends a press that started on the canvas
+func (c *Canvas) HandleMouseUp(e *Event) {
+	x, y := e.X, e.Y
+	if !c.pressed {
+		c.Node.HandleMouseUp(e)
+		return
+	}
+	c.pressed = false
+	if c.onMouseUp != nil {
+		c.onMouseUp(c.local(x, y))
+	}
+	e.Consume()
+}
+
+// HandleMouseMove passes moves over the canvas, and drags from it, to the
+// handler
+func (c *Canvas) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !c.IsVisible() {
+		return
+	}
+	if !c.pressed && !PointInRect(Point{x, y}, c.ComputedBounds()) {
+		return
+	}
+	if c.onMouseMove != nil {
+		c.onMouseMove(c.local(x, y))
+	}
+	c.Node.HandleMouseMove(e)
+	if c.pressed {
+		e.Consume()
+	}
+}
+
+// HandleScroll passes the mouse wheel over the canvas to the handler
+func (c *Canvas) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if c.onScroll == nil || !c.IsVisible() || !PointInRect(Point{x, y}, c.ComputedBounds()) {
+		return false
+	}
+	lx, ly := c.local(x, y)
+	c.onScroll(lx, ly, deltaX, deltaY)
+	return true
+}
+
+// canvasSurface draws to a region of another surface, offsetting
+// coordinates by the region's origin and keeping clips inside it
+type canvasSurface struct {
+	surface DrawSurface
+	bounds  Rect
+}
+
+// Clear fills the canvas region, leaving the rest of the screen alone
+func (s *canvasSurface) Clear(color color.RGBA) {
+	s.surface.FillRect(s.bounds.X, s.bounds.Y, s.bounds.Width, s.bounds.Height, color)
+}
+
+// DrawText draws text at a local position
+func (s *canvasSurface) DrawText(text string, x, y int, color color.RGBA, fontSize int) {
+	s.surface.DrawText(text, s.bounds.X+x, s.bounds.Y+y, color, fontSize)
+}
+
+// DrawRect draws a rectangle outline at a local position
+func (s *canvasSurface) DrawRect(x, y, width, height int, color color.RGBA) {
+	s.surface.DrawRect(s.bounds.X+x, s.bounds.Y+y, width, height, color)
+}
+
+// FillRect fills a rectangle at a local position
+func (s *canvasSurface) FillRect(x, y, width, height int, color color.RGBA) {
+	s.surface.FillRect(s.bounds.X+x, s.bounds.Y+y, width, height, color)
+}
+
+// DrawLine draws a line between local points
+func (s *canvasSurface) DrawLine(x1, y1, x2, y2 int, color color.RGBA) {
+	s.surface.DrawLine(s.bounds.X+x1, s.bounds.Y+y1, s.bounds.X+x2, s.bounds.Y+y2, color)
+}
+
+// FillCircle fills a circle at a local position
+func (s *canvasSurface) FillCircle(x, y, radius int, color color.RGBA) {
+	s.surface.FillCircle(s.bounds.X+x, s.bounds.Y+y, radius, color)
+}
+
+// DrawCircle draws a circle outline at a local position
+func (s *canvasSurface) DrawCircle(x, y, radius int, color color.RGBA) {
+	s.surface.DrawCircle(s.bounds.X+x, s.bounds.Y+y, radius, color)
+}
+
+// SetClipRect clips to a local rectangle, within the canvas
+func (s *canvasSurface) SetClipRect(x, y, width, height int) {
+	clip := image.Rect(s.bounds.X+x, s.bounds.Y+y, s.bounds.X+x+width, s.bounds.Y+y+height).
+		Intersect(image.Rect(s.bounds.X, s.bounds.Y, s.bounds.X+s.bounds.Width, s.bounds.Y+s.bounds.Height))
+	s.surface.SetClipRect(clip.Min.X, clip.Min.Y, clip.Dx(), clip.Dy())
+}
+
+// ResetClipRect clips to the whole canvas again
+func (s *canvasSurface) ResetClipRect() {
+	s.surface.SetClipRect(s.bounds.X, s.bounds.Y, s.bounds.Width, s.bounds.Height)
+}
+
+// DrawImage draws an image into a local box
+func (s *canvasSurface) DrawImage(img image.Image, x, y, width, height int, fitMethod ImageFitMethod) {
+	s.surface.DrawImage(img, s.bounds.X+x, s.bounds.Y+y, width, height, fitMethod)
+}
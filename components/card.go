@@ -0,0 +1,209 @@
+package components
+
+import (
+	"image/color"
+)
+
+// Card is a raised panel with an optional header and footer, used to group
+// related content on dashboards
+type Card struct {
+	*Node
+	header          *FlexContainer
+	body            *FlexContainer
+	footer          *FlexContainer
+	headerHeight    int
+	footerHeight    int
+	padding         int
+	paddingSet      bool // Padding was set explicitly, so themes leave it alone
+	elevation       int  // Shadow depth in pixels, 0 for a flat card
+	backgroundColor color.RGBA
+	borderColor     color.RGBA
+	dividerColor    color.RGBA
+	shadowColor     color.RGBA
+	skin            *NinePatch
+}
+
+// NewCard creates a card with the light theme's colors and padding
+func NewCard(id string) *Card {
+	c := &Card{
+		Node:         NewNode(id),
+		header:       NewFlexContainer(id + "_header"),
+		body:         NewFlexContainer(id + "_body"),
+		footer:       NewFlexContainer(id + "_footer"),
+		headerHeight: 40,
+		footerHeight: 40,
+		elevation:    2,
+		shadowColor:  color.RGBA{0, 0, 0, 30},
+	}
+	c.header.SetAlignItems(AlignCenter)
+	c.body.SetFlexDirection(FlexColumn)
+	c.footer.SetAlignItems(AlignCenter)
+	c.footer.SetJustifyContent(AlignEnd)
+	c.Node.AddChild(c.header)
+	c.Node.AddChild(c.body)
+	c.Node.AddChild(c.footer)
+	c.ApplyTheme(LightTheme())
+	return c
+}
+
+// ApplyTheme takes the card's colors and skin, and its padding unless set
+// explicitly, from a theme
+func (c *Card) ApplyTheme(theme Theme) {
+	c.skin = theme.PanelSkin
+	c.backgroundColor = theme.Surface
+	c.borderColor = theme.Border
+	c.dividerColor = theme.Border
+	if !c.paddingSet {
+		c.applyPadding(theme.Padding)
+	}
+}
+
+// Header returns the container for the header; the header is hidden while empty
+func (c *Card) Header() *FlexContainer {
+	return c.header
+}
+
+// Body returns the container for the card's main content
+func (c *Card) Body() *FlexContainer {
+	return c.body
+}
+
+// Footer returns the container for the footer; the footer is hidden while empty
+func (c *Card) Footer() *FlexContainer {
+	return c.footer
+}
+
+// AddChild adds an element to the card's body
+func (c *Card) AddChild(child Element) {
+	c.body.AddChild(child)
+	c.layout()
+}
+
+// SetElevation sets the shadow depth in pixels; zero draws a flat card
+func (c *Card) SetElevation(elevation int) {
+	if elevation < 0 {
+		elevation = 0
+	}
+	c.elevation = elevation
+}
+
+// Elevation returns the shadow depth
+func (c *Card) Elevation() int {
+	return c.elevation
+}
+
+// SetPadding sets the space between the card's edge and its content
+func (c *Card) SetPadding(padding int) {
+	c.paddingSet = true
+	c.applyPadding(padding)
+}
+
+// applyPadding sets the padding of each section
+func (c *Card) applyPadding(padding int) {
+	c.padding = padding
+	for _, section := range []*FlexContainer{c.header, c.body, c.footer} {
+		box := section.GetBoxModel()
+		box.Padding = Spacing{Top: padding / 2, Right: padding, Bottom: padding / 2, Left: padding}
+		section.SetBoxModel(box)
+	}
+	c.layout()
+}
+
+// Padding returns the space between the card's edge and its content
+func (c *Card) Padding() int {
+	return c.padding
+}
+
+// SetHeaderHeight sets the height of the header when it has content
+func (c *Card) SetHeaderHeight(height int) {
+	c.headerHeight = height
+	c.layout()
+}
+
+// SetFooterHeight sets the height of the footer when it has content
+func (c *Card) SetFooterHeight(height int) {
+	c.footerHeight = height
+	c.layout()
+}
+
+// SetSkin draws the card's background with a nine-patch image instead of
+// the fill color and border; nil restores them
+func (c *Card) SetSkin(skin *NinePatch) {
+	c.skin = skin
+}
+
+// SetBackgroundColor sets the card's fill color
+func (c *Card) SetBackgroundColor(color color.RGBA) {
+	c.backgroundColor = color
+}
+
+// SetBounds sets the card's bounds and lays out its sections
+func (c *Card) SetBounds(bounds Rect) {
+	c.Node.SetBounds(bounds)
+	c.layout()
+}
+
+// Layout refreshes the sections after content is added to the header or footer
+func (c *Card) Layout() {
+	c.layout()
+}
+
+// layout stacks the header, body and footer inside the card
+func (c *Card) layout() {
+	if c.header == nil {
+		return
+	}
+	bounds := c.Bounds()
+
+	headerHeight, footerHeight := 0, 0
+	if len(c.header.Children()) > 0 {
+		headerHeight = c.headerHeight
+	}
+	if len(c.footer.Children()) > 0 {
+		footerHeight = c.footerHeight
+	}
+
+	c.header.SetVisible(headerHeight > 0)
+	c.footer.SetVisible(footerHeight > 0)
+
+	bodyBox := c.body.GetBoxModel()
+	bodyBox.Padding.Top, bodyBox.Padding.Bottom = c.padding, c.padding
+	c.body.SetBoxModel(bodyBox)
+
+	c.header.SetBounds(Rect{X: bounds.X, Y: bounds.Y, Width: bounds.Width, Height: headerHeight})
+	c.body.SetBounds(Rect{X: bounds.X, Y: bounds.Y + headerHeight, Width: bounds.Width, Height: bounds.Height - headerHeight - footerHeight})
+	c.footer.SetBounds(Rect{X: bounds.X, Y: bounds.Y + bounds.Height - footerHeight, Width: bounds.Width, Height: footerHeight})
+}
+
+// Draw draws the shadow, the card and its sections
+func (c *Card) Draw(surface DrawSurface) {
+	if !c.IsVisible() {
+		return
+	}
+
+	bounds := c.ComputedBounds()
+
+	// Soft shadow: stacked translucent rects, offset downwards
+	for i := c.elevation; i > 0; i-- {
+		surface.FillRect(bounds.X-i/2, bounds.Y+i, bounds.Width+i, bounds.Height, c.shadowColor)
+	}
+
+	if c.skin != nil {
+		surface.DrawImage(c.skin, bounds.X, bounds.Y, bounds.Width, bounds.Height, ImageFitNinePatch)
+	} else {
+		surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, c.backgroundColor)
+		surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, c.borderColor)
+	}
+
+	if c.header.IsVisible() {
+		hb := c.header.ComputedBounds()
+		c.header.Draw(surface)
+		surface.DrawLine(hb.X, hb.Y+hb.Height, hb.X+hb.Width, hb.Y+hb.Height, c.dividerColor)
+	}
+	c.body.Draw(surface)
+	if c.footer.IsVisible() {
+		fb := c.footer.ComputedBounds()
+		surface.DrawLine(fb.X, fb.Y, fb.X+fb.Width, fb.Y, c.dividerColor)
+		c.footer.Draw(surface)
+	}
+}
@@ -0,0 +1,532 @@
+package components
+
+import (
+	"image/color"
+	"math"
+	"time"
+)
+
+// defaultSlideDuration is how long a carousel takes to slide between panels
+const defaultSlideDuration = 300 * time.Millisecond
+
+// Carousel pages through child panels one at a time, with arrow buttons,
+// dot indicators, swiping and optional auto-advance
+type Carousel struct {
+	*Node
+	slides          []Element
+	current         int
+	other           int     // Panel sliding in or out beside the current one, or -1
+	offset          float64 // Horizontal displacement of the current panel
+	animFrom        float64
+	animStart       time.Time
+	animDuration    time.Duration
+	autoAdvance     time.Duration
+	lastAdvance     time.Time
+	wrap            bool
+	hovered         bool
+	focused         bool
+	pressed         bool // Mouse is down on an arrow or indicator
+	dragging        bool
+	dragStartX      int
+	indicatorHeight int
+	onChange        func(int)
+	backgroundColor color.RGBA
+	arrowColor      color.RGBA
+	dotColor        color.RGBA
+	activeDotColor  color.RGBA
+	focusColor      color.RGBA
+}
+
+// NewCarousel creates an empty carousel that wraps around at the ends
+func NewCarousel(id string) *Carousel {
+	return &Carousel{
+		Node:            NewNode(id),
+		other:           -1,
+		animDuration:    defaultSlideDuration,
+		wrap:            true,
+		indicatorHeight: 20,
+		backgroundColor: color.RGBA{245, 245, 245, 255},
+		arrowColor:      color.RGBA{60, 60, 60, 255},
+		dotColor:        color.RGBA{190, 190, 190, 255},
+		activeDotColor:  color.RGBA{70, 130, 180, 255},
+		focusColor:      color.RGBA{70, 130, 180, 255},
+	}
+}
+
+// AddSlide adds an empty column container as a new panel and returns it
+func (c *Carousel) AddSlide() *FlexContainer {
+	content := NewFlexContainer(NewID(c.ID() + "_slide"))
+	content.SetFlexDirection(FlexColumn)
+	c.AddSlideContent(content)
+	return content
+}
+
+// AddSlideContent adds an element as a new panel
+func (c *Carousel) AddSlideContent(content Element) {
+	c.slides = append(c.slides, content)
+	c.Node.AddChild(content)
+	c.layout()
+}
+
+// RemoveSlide removes the panel at the given index
+func (c *Carousel) RemoveSlide(index int) {
+	if index < 0 || index >= len(c.slides) {
+		return
+	}
+	c.Node.RemoveChild(c.slides[index])
+	c.slides = append(c.slides[:index], c.slides[index+1:]...)
+	if c.current >= len(c.slides) {
+		c.current = max(0, len(c.slides)-1)
+	}
+	c.other, c.offset = -1, 0
+	c.layout()
+}
+
+// SlideCount returns the number of panels
+func (c *Carousel) SlideCount() int {
+	return len(c.slides)
+}
+
+// Slide returns the panel at the given index, or nil
+func (c *Carousel) Slide(index int) Element {
+	if index < 0 || index >= len(c.slides) {
+		return nil
+	}
+	return c.slides[index]
+}
+
+// Current returns the index of the panel being shown
+func (c *Carousel) Current() int {
+	return c.current
+}
+
+// GoTo slides to the panel at the given index
+func (c *Carousel) GoTo(index int) {
+	c.slideTo(index, index > c.current)
+}
+
+// Next slides to the following panel, wrapping to the first if enabled
+func (c *Carousel) Next() {
+	index := c.current + 1
+	if index >= len(c.slides) {
+		if !c.wrap {
+			return
+		}
+		index = 0
+	}
+	c.slideTo(index, true)
+}
+
+// Previous slides to the preceding panel, wrapping to the last if enabled
+func (c *Carousel) Previous() {
+	index := c.current - 1
+	if index < 0 {
+		if !c.wrap {
+			return
+		}
+		index = len(c.slides) - 1
+	}
+	c.slideTo(index, false)
+}
+
+// slideTo makes a panel current, starting it beside the old one so it slides
+// in from the right when moving forward and from the left when moving back
+func (c *Carousel) slideTo(index int, forward bool) {
+	if index < 0 || index >= len(c.slides) || index == c.current {
+		return
+	}
+
+	width := float64(c.slideRect().Width)
+	previous := c.current
+	c.current = index
+	c.other = previous
+	if forward {
+		c.offset = width
+	} else {
+		c.offset = -width
+	}
+	c.startAnimation()
+	c.lastAdvance = Now()
+	c.layout()
+
+	if c.onChange != nil {
+		c.onChange(index)
+	}
+}
+
+// startAnimation eases the current panel from its offset back into place
+func (c *Carousel) startAnimation() {
+	c.animFrom = c.offset
+	c.animStart = Now()
+	if AnimationDuration(c.animDuration) <= 0 {
+		c.offset = 0
+		c.other = -1
+	}
+}
+
+// SetWrap sets whether Next and Previous wrap around at the ends
+func (c *Carousel) SetWrap(wrap bool) {
+	c.wrap = wrap
+}
+
+// SetAutoAdvance moves to the next panel at the given interval; zero disables it.
+// Auto-advance pauses while the pointer is over the carousel or it has focus.
+func (c *Carousel) SetAutoAdvance(interval time.Duration) {
+	c.autoAdvance = interval
+	c.lastAdvance = Now()
+}
+
+// SetAnimationDuration sets how long the slide takes; zero disables
+// animation, as does the reduced motion preference
+func (c *Carousel) SetAnimationDuration(duration time.Duration) {
+	c.animDuration = duration
+}
+
+// SetShowIndicators sets whether the dot indicators are shown below the panels
+func (c *Carousel) SetShowIndicators(show bool) {
+	if show {
+		c.indicatorHeight = 20
+	} else {
+		c.indicatorHeight = 0
+	}
+	c.layout()
+}
+
+// SetOnChange sets the handler called with the new index when the panel changes
+func (c *Carousel) SetOnChange(handler func(int)) {
+	c.onChange = handler
+}
+
+// IsAnimating returns whether a slide is in progress
+func (c *Carousel) IsAnimating() bool {
+	return c.offset != 0
+}
+
+// SetBounds sets the bounds and resizes the panels
+func (c *Carousel) SetBounds(bounds Rect) {
+	c.Node.SetBounds(bounds)
+	c.layout()
+}
+
+// slideRect returns the area panels are shown in, above the indicators
+func (c *Carousel) slideRect() Rect {
+	bounds := c.ComputedBounds()
+	return Rect{X: bounds.X, Y: bounds.Y, Width: bounds.Width, Height: max(0, bounds.Height-c.indicatorHeight)}
+}
+
+// layout positions the current panel at its offset with the other panel beside
+// it, and hides the rest
+func (c *Carousel) layout() {
+	bounds := c.Bounds()
+	height := max(0, bounds.Height-c.indicatorHeight)
+	width := bounds.Width
+
+	for i, slide := range c.slides {
+		x := 0
+		switch i {
+		case c.current:
+			x = int(math.Round(c.offset))
+		case c.other:
+			if c.offset > 0 {
+				x = int(math.Round(c.offset)) - width
+			} else {
+				x = int(math.Round(c.offset)) + width
+			}
+		}
+
+		slide.SetBounds(Rect{X: bounds.X + x, Y: bounds.Y, Width: width, Height: height})
+		if node, ok := slide.(NodeElement); ok {
+			node.SetRelativePosition(Point{X: x, Y: 0})
+		}
+		if v, ok := slide.(interface{ SetVisible(bool) }); ok {
+			v.SetVisible(i == c.current || (i == c.other && c.offset != 0))
+		}
+	}
+}
+
+// advance steps the slide animation and auto-advance based on elapsed time
+func (c *Carousel) advance() {
+	if c.offset != 0 && !c.dragging {
+		t := 1.0
+		if duration := AnimationDuration(c.animDuration); duration > 0 {
+			t = float64(since(c.animStart)) / float64(duration)
+		}
+		if t >= 1 {
+			c.offset = 0
+			c.other = -1
+		} else {
+			// Ease out so the panel settles gently
+			t = 1 - (1-t)*(1-t)
+			c.offset = c.animFrom * (1 - t)
+		}
+		c.layout()
+	}
+
+	if c.autoAdvance > 0 && len(c.slides) > 1 && !c.hovered && !c.focused && !c.dragging {
+		if since(c.lastAdvance) >= c.autoAdvance {
+			c.Next()
+			c.lastAdvance = Now()
+		}
+	}
+}
+
+// Update advances the animation and updates children
+func (c *Carousel) Update() {
+	c.advance()
+	c.Node.Update()
+}
+
+// arrowRects returns the bounds of the previous and next buttons
+func (c *Carousel) arrowRects() (Rect, Rect) {
+	area := c.slideRect()
+	size := 28
+	y := area.Y + (area.Height-size)/2
+	return Rect{X: area.X + 6, Y: y, Width: size, Height: size},
+		Rect{X: area.X + area.Width - size - 6, Y: y, Width: size, Height: size}
+}
+
+// dotCenter returns the center of the indicator for a panel
+func (c *Carousel) dotCenter(index int) Point {
+	bounds := c.ComputedBounds()
+	spacing := 16
+	left := bounds.X + (bounds.Width-(len(c.slides)-1)*spacing)/2
+	return Point{X: left + index*spacing, Y: bounds.Y + bounds.Height - c.indicatorHeight/2}
+}
+
+// dotAt returns the index of the indicator at the given point, or -1
+func (c *Carousel) dotAt(x, y int) int {
+	if c.indicatorHeight == 0 {
+		return -1
+	}
+	for i := range c.slides {
+		center := c.dotCenter(i)
+		if absInt(x-center.X) <= 7 && absInt(y-center.Y) <= 7 {
+			return i
+		}
+	}
+	return -1
+}
+
+// Draw draws the visible panels clipped to the slide area, the arrows and the indicators
+func (c *Carousel) Draw(surface DrawSurface) {
+	if !c.IsVisible() {
+		return
+	}
+
+	c.advance()
+	bounds := c.ComputedBounds()
+	area := c.slideRect()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, c.backgroundColor)
+
+	surface.SetClipRect(area.X, area.Y, area.Width, area.Height)
+	if c.other >= 0 && c.offset != 0 {
+		c.slides[c.other].Draw(surface)
+	}
+	if c.current < len(c.slides) {
+		c.slides[c.current].Draw(surface)
+	}
+	surface.ResetClipRect()
+
+	if len(c.slides) > 1 {
+		prev, next := c.arrowRects()
+		for i, r := range []Rect{prev, next} {
+			cx, cy := r.X+r.Width/2, r.Y+r.Height/2
+			surface.FillCircle(cx, cy, r.Width/2, color.RGBA{255, 255, 255, 200})
+			if i == 0 {
+				surface.DrawLine(cx+3, cy-6, cx-3, cy, c.arrowColor)
+				surface.DrawLine(cx-3, cy, cx+3, cy+6, c.arrowColor)
+			} else {
+				surface.DrawLine(cx-3, cy-6, cx+3, cy, c.arrowColor)
+				surface.DrawLine(cx+3, cy, cx-3, cy+6, c.arrowColor)
+			}
+		}
+	}
+
+	if c.indicatorHeight > 0 {
+		for i := range c.slides {
+			center := c.dotCenter(i)
+			if i == c.current {
+				surface.FillCircle(center.X, center.Y, 4, c.activeDotColor)
+			} else {
+				surface.FillCircle(center.X, center.Y, 3, c.dotColor)
+			}
+		}
+	}
+
+	if c.focused {
+		surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, c.focusColor)
+	}
+}
+
+// HandleMouseDown handles the arrows and indicators, forwards clicks to the
+// current panel's children and otherwise starts a swipe
+func (c *Carousel) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !c.IsVisible() {
+		return
+	}
+	if c.dragging {
+		c.drag(x)
+		e.Consume()
+		return
+	}
+	if c.pressed {
+		e.Consume()
+		return
+	}
+	if !PointInRect(Point{x, y}, c.ComputedBounds()) {
+		c.focused = false
+		return
+	}
+	c.focused = true
+
+	if len(c.slides) > 1 {
+		prev, next := c.arrowRects()
+		if PointInRect(Point{x, y}, prev) {
+			c.pressed = true
+			c.Previous()
+			e.Consume()
+			return
+		}
+		if PointInRect(Point{x, y}, next) {
+			c.pressed = true
+			c.Next()
+			e.Consume()
+			return
+		}
+	}
+	if index := c.dotAt(x, y); index >= 0 {
+		c.pressed = true
+		c.GoTo(index)
+		e.Consume()
+		return
+	}
+
+	if c.current < len(c.slides) && c.offset == 0 {
+		children := c.slides[c.current].Children()
+		for i := len(children) - 1; i >= 0; i-- {
+			if children[i].HandleMouseDown(e); e.Consumed() {
+				return
+			}
+		}
+	}
+
+	if PointInRect(Point{x, y}, c.slideRect()) && len(c.slides) > 1 {
+		c.dragging = true
+		c.dragStartX = x - int(math.Round(c.offset))
+	}
+	e.Consume()
+}
+
+// drag moves the current panel with the pointer, showing the neighbor it is revealing
+func (c *Carousel) drag(x int) {
+	c.offset = float64(x - c.dragStartX)
+	switch {
+	case c.offset < 0:
+		c.other = c.neighbor(1)
+	case c.offset > 0:
+		c.other = c.neighbor(-1)
+	}
+	if c.other < 0 {
+		// Resist dragging past the ends
+		c.offset /= 3
+	}
+	c.layout()
+}
+
+// neighbor returns the index of the panel before or after the current one, or -1
+func (c *Carousel) neighbor(step int) int {
+	index := c.current + step
+	if index < 0 || index >= len(c.slides) {
+		if !c.wrap {
+			return -1
+		}
+		index = (index + len(c.slides)) % len(c.slides)
+	}
+	return index
+}
+
+// HandleMouseUp finishes a swipe, changing panel if it moved far enough
+func (c *Carousel) HandleMouseUp(e *Event) {
+	c.pressed = false
+	if !c.dragging {
+		if c.current < len(c.slides) && c.IsVisible() {
+			c.slides[c.current].HandleMouseUp(e)
+			return
+		}
+		return
+	}
+
+	c.dragging = false
+	width := float64(c.slideRect().Width)
+	if c.other >= 0 && math.Abs(c.offset) > width/4 {
+		// The neighbor becomes current, continuing from where the drag left it
+		previous := c.current
+		c.current = c.other
+		c.other = previous
+		if c.offset < 0 {
+			c.offset += width
+		} else {
+			c.offset -= width
+		}
+		c.lastAdvance = Now()
+		if c.onChange != nil {
+			c.onChange(c.current)
+		}
+	}
+	c.startAnimation()
+	c.layout()
+	e.Consume()
+}
+
+// HandleMouseMove tracks hover to pause auto-advance and forwards events to the current panel
+func (c *Carousel) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !c.IsVisible() {
+		return
+	}
+	if c.dragging {
+		c.drag(x)
+		e.Consume()
+		return
+	}
+	c.hovered = PointInRect(Point{x, y}, c.ComputedBounds())
+	if c.hovered && c.current < len(c.slides) {
+		c.slides[c.current].HandleMouseMove(e)
+	}
+}
+
+// Focus gives the carousel keyboard focus
+func (c *Carousel) Focus() {
+	c.focused = true
+}
+
+// Blur removes keyboard focus from the carousel
+func (c *Carousel) Blur() {
+	c.focused = false
+}
+
+// IsFocused returns whether the carousel receives arrow keys
+func (c *Carousel) IsFocused() bool {
+	return c.focused
+}
+
+// HandleKeyDown pages with Left/Right and jumps with Home/End while focused
+func (c *Carousel) HandleKeyDown(event InputEvent) bool {
+	if !c.focused || !c.IsVisible() || event.Type != InputTypeKeyDown {
+		return false
+	}
+	switch event.Key {
+	case KeyLeft:
+		c.Previous()
+	case KeyRight:
+		c.Next()
+	case KeyHome:
+		c.GoTo(0)
+	case KeyEnd:
+		c.GoTo(len(c.slides) - 1)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,154 @@
+package charts
+
+import (
+	"github.com/aggnr/finch/components"
+)
+
+// BarChart draws series as bars grouped by category, growing up from zero
+// or down for negative values. The bar under the mouse is lightened and its
+// value shown in a tooltip.
+type BarChart struct {
+	chart
+	series  []Series
+	stacked bool
+}
+
+// NewBarChart creates an empty bar chart
+func NewBarChart(id string) *BarChart {
+	return &BarChart{chart: newChart(id)}
+}
+
+// AddSeries adds a set of bars
+func (b *BarChart) AddSeries(series Series) {
+	b.series = append(b.series, series)
+}
+
+// SetSeries replaces all sets of bars
+func (b *BarChart) SetSeries(series []Series) {
+	b.series = series
+}
+
+// Series returns the sets of bars
+func (b *BarChart) Series() []Series {
+	return b.series
+}
+
+// SetStacked sets whether the series are stacked in one bar per category
+// rather than drawn side by side
+func (b *BarChart) SetStacked(stacked bool) {
+	b.stacked = stacked
+}
+
+// barRange returns the value range, summing each category when stacked
+func (b *BarChart) barRange(count int) (float64, float64) {
+	if !b.stacked {
+		return seriesRange(b.series)
+	}
+	lo, hi := 0.0, 0.0
+	for i := 0; i < count; i++ {
+		neg, pos := 0.0, 0.0
+		for _, s := range b.series {
+			if i < len(s.Values) {
+				if s.Values[i] < 0 {
+					neg += s.Values[i]
+				} else {
+					pos += s.Values[i]
+				}
+			}
+		}
+		lo, hi = min(lo, neg), max(hi, pos)
+	}
+	return lo, hi
+}
+
+// bar is a drawn bar, kept for hit testing
+type bar struct {
+	rect     components.Rect
+	series   int
+	category int
+}
+
+// layoutBars positions every bar in the plot
+func (b *BarChart) layoutBars(axis valueAxis, plot components.Rect, count int) []bar {
+	if count == 0 {
+		return nil
+	}
+	var bars []bar
+	slot := plot.Width / count
+	groupWidth := slot * 3 / 4
+	zero := axis.y(0, plot)
+	for i := 0; i < count; i++ {
+		groupX := plot.X + i*slot + (slot-groupWidth)/2
+		neg, pos := 0.0, 0.0
+		for si, s := range b.series {
+			if i >= len(s.Values) {
+				continue
+			}
+			v := s.Values[i]
+			var top, bottom int
+			x, width := groupX, groupWidth
+			if b.stacked {
+				base := &pos
+				if v < 0 {
+					base = &neg
+				}
+				top, bottom = axis.y(*base+v, plot), axis.y(*base, plot)
+				*base += v
+			} else {
+				width = max(1, groupWidth/len(b.series))
+				x = groupX + si*width
+				top, bottom = axis.y(v, plot), zero
+			}
+			if top > bottom {
+				top, bottom = bottom, top
+			}
+			bars = append(bars, bar{
+				rect:     components.Rect{X: x, Y: top, Width: max(1, width-1), Height: max(1, bottom-top)},
+				series:   si,
+				category: i,
+			})
+		}
+	}
+	return bars
+}
+
+// Draw draws the axes, bars, legend and hover highlight
+func (b *BarChart) Draw(surface components.DrawSurface) {
+	if !b.IsVisible() {
+		return
+	}
+
+	area := b.drawFrame(surface)
+	area = b.drawLegend(surface, seriesLegend(b.series), area)
+	count := categoryCount(b.labels, b.series)
+	lo, hi := b.barRange(count)
+	axis := niceAxis(lo, hi, max(2, area.Height/40))
+	plot := b.drawValueAxis(surface, axis, area)
+	if plot.Width <= 0 || plot.Height <= 0 || count == 0 {
+		return
+	}
+
+	centers := make([]int, count)
+	slot := plot.Width / count
+	for i := range centers {
+		centers[i] = plot.X + i*slot + slot/2
+	}
+	b.drawCategoryLabels(surface, centers, plot)
+
+	hovered := -1
+	bars := b.layoutBars(axis, plot, count)
+	for i, bar := range bars {
+		clr := paletteColor(bar.series, b.series[bar.series].Color)
+		if b.hovering && components.PointInRect(b.mouse, bar.rect) {
+			hovered = i
+			clr = lighten(clr, 0.3)
+		}
+		surface.FillRect(bar.rect.X, bar.rect.Y, bar.rect.Width, bar.rect.Height, clr)
+	}
+
+	if hovered >= 0 {
+		bar := bars[hovered]
+		s := b.series[bar.series]
+		b.drawTooltip(surface, []string{b.label(bar.category), s.Name + ": " + b.formatValue(s.Values[bar.category])})
+	}
+}
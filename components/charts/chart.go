@@ -0,0 +1,382 @@
+// Package charts provides line, bar and pie chart elements that draw their
+// axes, labels and legends through a components.DrawSurface and highlight
+// the data under the mouse.
+package charts
+
+import (
+	"image/color"
+	"math"
+	"strconv"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Series is a named set of values drawn in one color. Values line up with
+// the chart's category labels.
+type Series struct {
+	Name   string
+	Values []float64
+	Color  color.RGBA // Zero picks a color from the palette
+}
+
+// Palette is the colors given, in order, to series and slices without one
+var Palette = []color.RGBA{
+	{66, 133, 244, 255},
+	{234, 67, 53, 255},
+	{251, 188, 5, 255},
+	{52, 168, 83, 255},
+	{171, 71, 188, 255},
+	{0, 172, 193, 255},
+	{255, 112, 67, 255},
+	{158, 157, 36, 255},
+}
+
+// paletteColor returns c, or the palette color for index i if c is unset
+func paletteColor(i int, c color.RGBA) color.RGBA {
+	if c.A > 0 {
+		return c
+	}
+	return Palette[i%len(Palette)]
+}
+
+// lighten mixes a color towards white, for highlighting
+func lighten(c color.RGBA, amount float64) color.RGBA {
+	mix := func(v uint8) uint8 {
+		return uint8(float64(v) + (255-float64(v))*amount)
+	}
+	return color.RGBA{mix(c.R), mix(c.G), mix(c.B), c.A}
+}
+
+// chart holds what every chart has: a title, category labels, colors and
+// the mouse position used for hover highlighting
+type chart struct {
+	*components.Node
+	title           string
+	labels          []string
+	fontSize        int
+	showLegend      bool
+	format          func(float64) string
+	hovering        bool
+	mouse           components.Point
+	textColor       color.RGBA
+	axisColor       color.RGBA
+	gridColor       color.RGBA
+	backgroundColor color.RGBA
+	tooltipColor    color.RGBA
+}
+
+// newChart creates the shared chart state
+func newChart(id string) chart {
+	return chart{
+		Node:            components.NewNode(id),
+		fontSize:        12,
+		showLegend:      true,
+		textColor:       color.RGBA{60, 60, 60, 255},
+		axisColor:       color.RGBA{150, 150, 150, 255},
+		gridColor:       color.RGBA{230, 230, 230, 255},
+		backgroundColor: color.RGBA{255, 255, 255, 255},
+		tooltipColor:    color.RGBA{40, 40, 40, 230},
+	}
+}
+
+// SetTitle sets the text drawn above the chart
+func (c *chart) SetTitle(title string) {
+	c.title = title
+}
+
+// SetLabels sets the category labels
+func (c *chart) SetLabels(labels []string) {
+	c.labels = labels
+}
+
+// Labels returns the category labels
+func (c *chart) Labels() []string {
+	return c.labels
+}
+
+// SetShowLegend sets whether the legend is drawn
+func (c *chart) SetShowLegend(show bool) {
+	c.showLegend = show
+}
+
+// SetFontSize sets the size of the labels; the title is drawn larger
+func (c *chart) SetFontSize(size int) {
+	c.fontSize = size
+}
+
+// SetValueFormat sets how values are written in labels and tooltips; nil
+// restores the default
+func (c *chart) SetValueFormat(format func(float64) string) {
+	c.format = format
+}
+
+// SetBackgroundColor sets the background color
+func (c *chart) SetBackgroundColor(color color.RGBA) {
+	c.backgroundColor = color
+}
+
+// SetTextColor sets the color of the title and labels
+func (c *chart) SetTextColor(color color.RGBA) {
+	c.textColor = color
+}
+
+// formatValue writes a value with the chart's format
+func (c *chart) formatValue(v float64) string {
+	if c.format != nil {
+		return c.format(v)
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// label returns the category label at an index, or its number if unset
+func (c *chart) label(i int) string {
+	if i < len(c.labels) {
+		return c.labels[i]
+	}
+	return strconv.Itoa(i + 1)
+}
+
+// HandleMouseMove tracks the mouse for hover highlighting
+func (c *chart) HandleMouseMove(e *components.Event) {
+	x, y := e.X, e.Y
+	c.hovering = c.IsVisible() && components.PointInRect(components.Point{X: x, Y: y}, c.ComputedBounds())
+	c.mouse = components.Point{X: x, Y: y}
+	if c.hovering {
+		e.Consume()
+	}
+}
+
+// drawFrame fills the background and draws the title, returning the area
+// left for the chart
+func (c *chart) drawFrame(surface components.DrawSurface) components.Rect {
+	bounds := c.ComputedBounds()
+	if c.backgroundColor.A > 0 {
+		surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, c.backgroundColor)
+	}
+	area := components.Rect{X: bounds.X + 8, Y: bounds.Y + 8, Width: bounds.Width - 16, Height: bounds.Height - 16}
+	if c.title != "" {
+		titleSize := c.fontSize + 4
+		titleX := bounds.X + (bounds.Width-components.MeasureText(c.title))/2
+		surface.DrawText(c.title, titleX, area.Y, c.textColor, titleSize)
+		area.Y += titleSize + 8
+		area.Height -= titleSize + 8
+	}
+	return area
+}
+
+// legendItem is a swatch and name in the legend
+type legendItem struct {
+	name  string
+	color color.RGBA
+}
+
+// legendHeight returns the height of a legend wrapped to the width
+func (c *chart) legendHeight(items []legendItem, width int) int {
+	if !c.showLegend || len(items) == 0 {
+		return 0
+	}
+	rows := 1
+	x := 0
+	for _, item := range items {
+		w := c.fontSize + 6 + components.MeasureText(item.name) + 16
+		if x > 0 && x+w > width {
+			rows++
+			x = 0
+		}
+		x += w
+	}
+	return rows*(c.fontSize+6) + 4
+}
+
+// drawLegend draws a row of swatches and names along the bottom of the
+// area, wrapping as needed, and returns the area above it
+func (c *chart) drawLegend(surface components.DrawSurface, items []legendItem, area components.Rect) components.Rect {
+	height := c.legendHeight(items, area.Width)
+	if height == 0 {
+		return area
+	}
+	area.Height -= height
+	x, y := area.X, area.Y+area.Height+4
+	for _, item := range items {
+		w := c.fontSize + 6 + components.MeasureText(item.name) + 16
+		if x > area.X && x+w > area.X+area.Width {
+			x = area.X
+			y += c.fontSize + 6
+		}
+		surface.FillRect(x, y+1, c.fontSize-2, c.fontSize-2, item.color)
+		surface.DrawText(item.name, x+c.fontSize+4, y, c.textColor, c.fontSize)
+		x += w
+	}
+	return area
+}
+
+// drawTooltip draws lines of text in a box next to the mouse, kept inside
+// the chart
+func (c *chart) drawTooltip(surface components.DrawSurface, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	bounds := c.ComputedBounds()
+	width := 0
+	for _, line := range lines {
+		width = max(width, components.MeasureText(line))
+	}
+	width += 12
+	height := len(lines)*(c.fontSize+4) + 8
+
+	x, y := c.mouse.X+12, c.mouse.Y+12
+	if x+width > bounds.X+bounds.Width {
+		x = c.mouse.X - 12 - width
+	}
+	if y+height > bounds.Y+bounds.Height {
+		y = c.mouse.Y - 12 - height
+	}
+	x = max(x, bounds.X)
+	y = max(y, bounds.Y)
+
+	surface.FillRect(x, y, width, height, c.tooltipColor)
+	for i, line := range lines {
+		surface.DrawText(line, x+6, y+4+i*(c.fontSize+4), color.RGBA{255, 255, 255, 255}, c.fontSize)
+	}
+}
+
+// valueAxis is the scale of a chart's value axis
+type valueAxis struct {
+	min, max, step float64
+}
+
+// niceAxis returns an axis covering lo to hi with about the given number of
+// ticks at round steps: 1, 2 or 5 times a power of ten
+func niceAxis(lo, hi float64, ticks int) valueAxis {
+	if lo == hi {
+		if lo == 0 {
+			hi = 1
+		} else {
+			lo, hi = math.Min(0, lo), math.Max(0, hi)
+		}
+	}
+	raw := (hi - lo) / float64(max(1, ticks))
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	step := magnitude
+	for _, m := range []float64{1, 2, 5, 10} {
+		step = m * magnitude
+		if step >= raw {
+			break
+		}
+	}
+	return valueAxis{
+		min:  math.Floor(lo/step) * step,
+		max:  math.Ceil(hi/step) * step,
+		step: step,
+	}
+}
+
+// ticks returns the values of the axis's tick marks
+func (a valueAxis) ticks() []float64 {
+	var ticks []float64
+	for v := a.min; v <= a.max+a.step/2; v += a.step {
+		// Snap to the step so float error doesn't show in labels
+		ticks = append(ticks, math.Round(v/a.step)*a.step)
+	}
+	return ticks
+}
+
+// y returns the screen position of a value in a plot area
+func (a valueAxis) y(v float64, plot components.Rect) int {
+	t := (v - a.min) / (a.max - a.min)
+	return plot.Y + plot.Height - int(math.Round(t*float64(plot.Height)))
+}
+
+// tickLabel writes a tick value with no more decimals than the step needs
+func (c *chart) tickLabel(v, step float64) string {
+	if c.format != nil {
+		return c.format(v)
+	}
+	decimals := max(0, int(-math.Floor(math.Log10(step))))
+	if v == 0 {
+		v = 0 // Turn -0 into 0
+	}
+	return strconv.FormatFloat(v, 'f', decimals, 64)
+}
+
+// drawValueAxis draws the grid lines and tick labels of the value axis and
+// returns the plot area to their right
+func (c *chart) drawValueAxis(surface components.DrawSurface, axis valueAxis, area components.Rect) components.Rect {
+	ticks := axis.ticks()
+	labelWidth := 0
+	for _, v := range ticks {
+		labelWidth = max(labelWidth, components.MeasureText(c.tickLabel(v, axis.step)))
+	}
+
+	// Leave room below for category labels
+	plot := components.Rect{
+		X:      area.X + labelWidth + 8,
+		Y:      area.Y + c.fontSize/2,
+		Width:  area.Width - labelWidth - 8,
+		Height: area.Height - c.fontSize/2 - c.fontSize - 8,
+	}
+	if plot.Width <= 0 || plot.Height <= 0 {
+		return plot
+	}
+
+	for _, v := range ticks {
+		y := axis.y(v, plot)
+		lineColor := c.gridColor
+		if v == 0 {
+			lineColor = c.axisColor
+		}
+		surface.DrawLine(plot.X, y, plot.X+plot.Width, y, lineColor)
+		label := c.tickLabel(v, axis.step)
+		surface.DrawText(label, plot.X-8-components.MeasureText(label), y-c.fontSize/2, c.textColor, c.fontSize)
+	}
+	surface.DrawLine(plot.X, plot.Y, plot.X, plot.Y+plot.Height, c.axisColor)
+	return plot
+}
+
+// drawCategoryLabels draws labels centered at the given x positions below
+// the plot, skipping labels that would overlap the previous one
+func (c *chart) drawCategoryLabels(surface components.DrawSurface, centers []int, plot components.Rect) {
+	lastEnd := math.MinInt
+	for i, x := range centers {
+		label := c.label(i)
+		width := components.MeasureText(label)
+		left := x - width/2
+		if left < lastEnd+4 {
+			continue
+		}
+		surface.DrawText(label, left, plot.Y+plot.Height+6, c.textColor, c.fontSize)
+		lastEnd = left + width
+	}
+}
+
+// seriesRange returns the smallest and largest values of the series,
+// including zero so bars and areas have a baseline
+func seriesRange(series []Series) (float64, float64) {
+	lo, hi := 0.0, 0.0
+	for _, s := range series {
+		for _, v := range s.Values {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	return lo, hi
+}
+
+// seriesLegend returns the legend items for series
+func seriesLegend(series []Series) []legendItem {
+	items := make([]legendItem, len(series))
+	for i, s := range series {
+		items[i] = legendItem{name: s.Name, color: paletteColor(i, s.Color)}
+	}
+	return items
+}
+
+// categoryCount returns the number of categories: the longer of the labels
+// and the longest series
+func categoryCount(labels []string, series []Series) int {
+	n := len(labels)
+	for _, s := range series {
+		n = max(n, len(s.Values))
+	}
+	return n
+}
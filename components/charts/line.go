@@ -0,0 +1,119 @@
+package charts
+
+import (
+	"math"
+
+	"github.com/aggnr/finch/components"
+)
+
+// LineChart draws series as lines over evenly spaced categories. Hovering
+// picks the nearest category, marking each series' point there and listing
+// the values in a tooltip.
+type LineChart struct {
+	chart
+	series     []Series
+	showPoints bool
+}
+
+// NewLineChart creates an empty line chart
+func NewLineChart(id string) *LineChart {
+	return &LineChart{chart: newChart(id), showPoints: true}
+}
+
+// AddSeries adds a line
+func (l *LineChart) AddSeries(series Series) {
+	l.series = append(l.series, series)
+}
+
+// SetSeries replaces all lines
+func (l *LineChart) SetSeries(series []Series) {
+	l.series = series
+}
+
+// Series returns the lines
+func (l *LineChart) Series() []Series {
+	return l.series
+}
+
+// SetShowPoints sets whether a dot is drawn at each value
+func (l *LineChart) SetShowPoints(show bool) {
+	l.showPoints = show
+}
+
+// pointX returns the x position of a category in the plot
+func pointX(i, count int, plot components.Rect) int {
+	if count <= 1 {
+		return plot.X + plot.Width/2
+	}
+	return plot.X + i*plot.Width/(count-1)
+}
+
+// hoveredCategory returns the category nearest the mouse, or -1
+func (l *LineChart) hoveredCategory(count int, plot components.Rect) int {
+	if !l.hovering || count == 0 || !components.PointInRect(l.mouse, plot) {
+		return -1
+	}
+	if count == 1 {
+		return 0
+	}
+	t := float64(l.mouse.X-plot.X) / float64(plot.Width)
+	return max(0, min(count-1, int(math.Round(t*float64(count-1)))))
+}
+
+// Draw draws the axes, lines, legend and hover highlight
+func (l *LineChart) Draw(surface components.DrawSurface) {
+	if !l.IsVisible() {
+		return
+	}
+
+	area := l.drawFrame(surface)
+	area = l.drawLegend(surface, seriesLegend(l.series), area)
+	lo, hi := seriesRange(l.series)
+	axis := niceAxis(lo, hi, max(2, area.Height/40))
+	plot := l.drawValueAxis(surface, axis, area)
+	if plot.Width <= 0 || plot.Height <= 0 {
+		return
+	}
+
+	count := categoryCount(l.labels, l.series)
+	centers := make([]int, count)
+	for i := range centers {
+		centers[i] = pointX(i, count, plot)
+	}
+	l.drawCategoryLabels(surface, centers, plot)
+
+	hovered := l.hoveredCategory(count, plot)
+	if hovered >= 0 {
+		surface.DrawLine(centers[hovered], plot.Y, centers[hovered], plot.Y+plot.Height, l.axisColor)
+	}
+
+	var tooltip []string
+	if hovered >= 0 {
+		tooltip = append(tooltip, l.label(hovered))
+	}
+	for si, s := range l.series {
+		clr := paletteColor(si, s.Color)
+		for i := 1; i < len(s.Values); i++ {
+			x1, y1 := centers[i-1], axis.y(s.Values[i-1], plot)
+			x2, y2 := centers[i], axis.y(s.Values[i], plot)
+			// Two passes a pixel apart make the line easier to see
+			surface.DrawLine(x1, y1, x2, y2, clr)
+			surface.DrawLine(x1, y1+1, x2, y2+1, clr)
+		}
+		for i, v := range s.Values {
+			x, y := centers[i], axis.y(v, plot)
+			switch {
+			case i == hovered:
+				surface.FillCircle(x, y, 5, clr)
+				surface.FillCircle(x, y, 2, l.backgroundColor)
+				tooltip = append(tooltip, s.Name+": "+l.formatValue(v))
+			case l.showPoints:
+				surface.FillCircle(x, y, 3, clr)
+			}
+		}
+	}
+
+	if hovered >= 0 {
+		l.drawTooltip(surface, tooltip)
+	}
+}
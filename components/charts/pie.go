@@ -0,0 +1,161 @@
+package charts
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Slice is a labelled share of a pie chart
+type Slice struct {
+	Label string
+	Value float64
+	Color color.RGBA // Zero picks a color from the palette
+}
+
+// PieChart draws slices in proportion to their values, starting at the top
+// and going clockwise, with an optional hole for a donut chart. The slice
+// under the mouse is pulled out slightly and shown in a tooltip with its
+// share of the total.
+type PieChart struct {
+	chart
+	slices []Slice
+	hole   float64 // Radius of the hole as a fraction of the radius
+}
+
+// NewPieChart creates an empty pie chart
+func NewPieChart(id string) *PieChart {
+	return &PieChart{chart: newChart(id)}
+}
+
+// AddSlice adds a slice
+func (p *PieChart) AddSlice(label string, value float64) {
+	p.slices = append(p.slices, Slice{Label: label, Value: value})
+}
+
+// SetSlices replaces all slices
+func (p *PieChart) SetSlices(slices []Slice) {
+	p.slices = slices
+}
+
+// Slices returns the slices
+func (p *PieChart) Slices() []Slice {
+	return p.slices
+}
+
+// SetHole sets the radius of the hole in the middle as a fraction of the
+// radius, from 0 for a pie to just under 1 for a thin ring
+func (p *PieChart) SetHole(fraction float64) {
+	p.hole = max(0, min(fraction, 0.95))
+}
+
+// total returns the sum of the positive slice values
+func (p *PieChart) total() float64 {
+	total := 0.0
+	for _, s := range p.slices {
+		total += max(0, s.Value)
+	}
+	return total
+}
+
+// sliceAt returns the slice at an angle measured clockwise from the top,
+// in turns from 0 to 1
+func (p *PieChart) sliceAt(turn, total float64) int {
+	start := 0.0
+	for i, s := range p.slices {
+		end := start + max(0, s.Value)/total
+		if turn < end {
+			return i
+		}
+		start = end
+	}
+	return len(p.slices) - 1
+}
+
+// turnAt returns the angle of a point around a center, clockwise from the
+// top in turns
+func turnAt(dx, dy float64) float64 {
+	turn := math.Atan2(dx, -dy) / (2 * math.Pi)
+	if turn < 0 {
+		turn++
+	}
+	return turn
+}
+
+// hoveredSlice returns the slice under the mouse, or -1
+func (p *PieChart) hoveredSlice(cx, cy, radius int, total float64) int {
+	if !p.hovering {
+		return -1
+	}
+	dx, dy := float64(p.mouse.X-cx), float64(p.mouse.Y-cy)
+	dist := math.Hypot(dx, dy)
+	if dist > float64(radius) || dist < p.hole*float64(radius) {
+		return -1
+	}
+	return p.sliceAt(turnAt(dx, dy), total)
+}
+
+// Draw draws the slices, legend and hover highlight
+func (p *PieChart) Draw(surface components.DrawSurface) {
+	if !p.IsVisible() {
+		return
+	}
+
+	area := p.drawFrame(surface)
+	items := make([]legendItem, len(p.slices))
+	for i, s := range p.slices {
+		items[i] = legendItem{name: s.Label, color: paletteColor(i, s.Color)}
+	}
+	area = p.drawLegend(surface, items, area)
+
+	total := p.total()
+	radius := min(area.Width, area.Height)/2 - 6
+	if total <= 0 || radius <= 0 {
+		return
+	}
+	cx, cy := area.X+area.Width/2, area.Y+area.Height/2
+	hovered := p.hoveredSlice(cx, cy, radius, total)
+
+	// Fill row by row, one rect per run of pixels in the same slice, since
+	// the surface has no wedge primitive
+	inner := p.hole * float64(radius)
+	outer := float64(radius) + 4 // Room for the hovered slice to grow
+	for y := -int(outer); y <= int(outer); y++ {
+		runStart, runSlice := 0, -1
+		flush := func(end int) {
+			if runSlice >= 0 {
+				clr := paletteColor(runSlice, p.slices[runSlice].Color)
+				if runSlice == hovered {
+					clr = lighten(clr, 0.2)
+				}
+				surface.FillRect(cx+runStart, cy+y, end-runStart, 1, clr)
+			}
+		}
+		for x := -int(outer); x <= int(outer)+1; x++ {
+			slice := -1
+			dx, dy := float64(x)+0.5, float64(y)+0.5
+			if dist := math.Hypot(dx, dy); dist >= inner && dist <= outer {
+				slice = p.sliceAt(turnAt(dx, dy), total)
+				limit := float64(radius)
+				if slice == hovered {
+					limit += 4
+				}
+				if dist > limit {
+					slice = -1
+				}
+			}
+			if slice != runSlice {
+				flush(x)
+				runStart, runSlice = x, slice
+			}
+		}
+	}
+
+	if hovered >= 0 {
+		s := p.slices[hovered]
+		share := max(0, s.Value) / total * 100
+		p.drawTooltip(surface, []string{s.Label, fmt.Sprintf("%s (%.1f%%)", p.formatValue(s.Value), share)})
+	}
+}
@@ -0,0 +1,99 @@
+package components
+
+// MouseButton identifies the mouse button of a click
+type MouseButton int
+
+const (
+	MouseButtonLeft MouseButton = iota
+	MouseButtonRight
+	MouseButtonMiddle
+)
+
+// String returns the button's name
+func (b MouseButton) String() string {
+	switch b {
+	case MouseButtonRight:
+		return "right"
+	case MouseButtonMiddle:
+		return "middle"
+	}
+	return "left"
+}
+
+// ClickDetector turns the held state of each mouse button, sampled once a
+// frame, into click events. A click is a press and release over the same
+// element; presses held past the long-press threshold don't count.
+type ClickDetector struct {
+	buttons [3]clickButton
+}
+
+// clickButton is the press state of one mouse button
+type clickButton struct {
+	held    bool
+	target  Element // Element pressed on
+	gesture *GestureTracker
+}
+
+// NewClickDetector creates a click detector
+func NewClickDetector() *ClickDetector {
+	c := &ClickDetector{}
+	for i := range c.buttons {
+		c.buttons[i].gesture = NewGestureTracker()
+	}
+	return c
+}
+
+// Update records whether a button is held with the pointer at x, y and
+// returns the events a release completes: a click with its count, then a
+// double click on the second click in a row or a context click for the
+// right button. Send them with DispatchEvent.
+func (c *ClickDetector) Update(root Element, button MouseButton, held bool, x, y int) []*Event {
+	b := &c.buttons[button]
+	wasHeld := b.held
+	b.held = held
+	switch {
+	case held && !wasHeld:
+		b.target = ElementAt(root, x, y)
+		b.gesture.Down(x, y)
+		return nil
+	case held || !wasHeld:
+		return nil
+	}
+
+	count := b.gesture.Up(x, y)
+	target := b.target
+	b.target = nil
+	if count == 0 || target == nil || ElementAt(root, x, y) != target {
+		return nil
+	}
+
+	click := c.event(InputTypeClick, button, count, x, y)
+	events := []*Event{click}
+	if count == 2 && button == MouseButtonLeft {
+		events = append(events, c.event(InputTypeDoubleClick, button, count, x, y))
+	}
+	if button == MouseButtonRight {
+		events = append(events, c.event(InputTypeContextClick, button, count, x, y))
+	}
+	return events
+}
+
+// event creates a click event
+func (c *ClickDetector) event(eventType InputType, button MouseButton, count, x, y int) *Event {
+	e := NewMouseEvent(eventType, x, y)
+	e.Button = button
+	e.ClickCount = count
+	return e
+}
+
+// SetOnDoubleClick sets the handler called when the element, or an element
+// inside it, is double-clicked, e.g. to open an item; nil removes it
+func (d *Node) SetOnDoubleClick(handler func(e *Event)) {
+	d.onDoubleClick = handler
+}
+
+// SetOnContextClick sets the handler called when the element, or an element
+// inside it, is right-clicked; nil removes it
+func (d *Node) SetOnContextClick(handler func(e *Event)) {
+	d.onContextClick = handler
+}
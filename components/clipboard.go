@@ -0,0 +1,142 @@
+package components
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"image"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNoClipboardImage is returned when the clipboard holds no image
+	ErrNoClipboardImage = errors.New("clipboard has no image")
+	// ErrClipboardUnsupported is returned when the platform's clipboard
+	// can't be read, e.g. when the helper tool isn't installed
+	ErrClipboardUnsupported = errors.New("clipboard images are not supported here")
+)
+
+// ReadClipboardImage reads an image from the system clipboard as PNG. It
+// uses PowerShell on Windows, osascript on macOS and wl-paste or xclip
+// elsewhere.
+func ReadClipboardImage() (image.Image, error) {
+	var data []byte
+	switch runtime.GOOS {
+	case "windows":
+		out, err := exec.Command("powershell", "-NoProfile", "-STA", "-Command",
+			`Add-Type -AssemblyName System.Windows.Forms; `+
+				`$img = [System.Windows.Forms.Clipboard]::GetImage(); `+
+				`if ($img) { $ms = New-Object System.IO.MemoryStream; `+
+				`$img.Save($ms, [System.Drawing.Imaging.ImageFormat]::Png); `+
+				`[Convert]::ToBase64String($ms.ToArray()) }`).Output()
+		if err != nil {
+			return nil, ErrClipboardUnsupported
+		}
+		if data, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(out))); err != nil {
+			return nil, err
+		}
+	case "darwin":
+		// Prints the PNG as «data PNGf89504E47...»
+		out, err := exec.Command("osascript", "-e", "get the clipboard as «class PNGf»").Output()
+		if err != nil {
+			return nil, ErrNoClipboardImage
+		}
+		text := strings.TrimSpace(string(out))
+		text = strings.TrimPrefix(text, "«data PNGf")
+		text = strings.TrimSuffix(text, "»")
+		if data, err = hex.DecodeString(text); err != nil {
+			return nil, err
+		}
+	default:
+		out, err := exec.Command("wl-paste", "--no-newline", "--type", "image/png").Output()
+		if err != nil {
+			out, err = exec.Command("xclip", "-selection", "clipboard", "-target", "image/png", "-out").Output()
+		}
+		if err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return nil, ErrNoClipboardImage
+			}
+			return nil, ErrClipboardUnsupported
+		}
+		data = out
+	}
+
+	if len(data) == 0 {
+		return nil, ErrNoClipboardImage
+	}
+	return DecodeImage(bytes.NewReader(data))
+}
+
+var (
+	clipboardMu     sync.RWMutex
+	clipboardReader = ReadClipboardImage
+)
+
+// SetClipboardImageReader replaces how clipboard images are read, e.g. with
+// a fake in tests; nil restores the system clipboard
+func SetClipboardImageReader(reader func() (image.Image, error)) {
+	clipboardMu.Lock()
+	defer clipboardMu.Unlock()
+	if reader == nil {
+		reader = ReadClipboardImage
+	}
+	clipboardReader = reader
+}
+
+// clipboardPaste delivers a clipboard image read in the background
+type clipboardPaste struct {
+	img     image.Image
+	err     error
+	handler func(image.Image, error)
+}
+
+// due calls the handler with the result
+func (p *clipboardPaste) due(now time.Time) bool {
+	p.handler(p.img, p.err)
+	return true
+}
+
+// PasteImage reads the clipboard in the background, since the helper tools
+// can take a moment, and calls the handler with the image on a later frame
+// via RunFrameCallbacks
+func PasteImage(handler func(image.Image, error)) {
+	clipboardMu.RLock()
+	reader := clipboardReader
+	clipboardMu.RUnlock()
+
+	go func() {
+		img, err := reader()
+		scheduleFrameCallback(&clipboardPaste{img: img, err: err, handler: handler})
+	}()
+}
+
+// IsPasteKey returns whether a key event is the paste shortcut, Ctrl+V (Cmd+V
+// on macOS) or Shift+Insert
+func IsPasteKey(event InputEvent) bool {
+	if event.Type != InputTypeKeyDown || event.Repeat {
+		return false
+	}
+	return (event.Key == KeyV && event.CtrlDown && !event.ShiftDown) ||
+		(event.Key == KeyInsert && event.ShiftDown && !event.CtrlDown)
+}
+
+// pasteImageOnKey starts an image paste for the paste shortcut, calling the
+// handler only if the clipboard had an image. Returns whether the key was
+// the paste shortcut.
+func pasteImageOnKey(event InputEvent, handler func(image.Image)) bool {
+	if handler == nil || !IsPasteKey(event) {
+		return false
+	}
+	PasteImage(func(img image.Image, err error) {
+		if err == nil && img != nil {
+			handler(img)
+		}
+	})
+	return true
+}
@@ -0,0 +1,506 @@
+package components
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+)
+
+// CodeColors are the colors of a code view and its syntax classes
+type CodeColors struct {
+	Background  color.RGBA
+	Text        color.RGBA
+	Gutter      color.RGBA
+	LineNumber  color.RGBA
+	Highlight   color.RGBA // Background of the highlighted line
+	Keyword     color.RGBA
+	Type        color.RGBA
+	Function    color.RGBA
+	String      color.RGBA
+	Number      color.RGBA
+	Comment     color.RGBA
+	Literal     color.RGBA
+	Property    color.RGBA
+	ScrollThumb color.RGBA
+}
+
+// LightCodeColors returns colors for code on a light background
+func LightCodeColors() CodeColors {
+	return CodeColors{
+		Background:  color.RGBA{255, 255, 255, 255},
+		Text:        color.RGBA{36, 41, 47, 255},
+		Gutter:      color.RGBA{246, 248, 250, 255},
+		LineNumber:  color.RGBA{140, 149, 159, 255},
+		Highlight:   color.RGBA{255, 248, 197, 255},
+		Keyword:     color.RGBA{207, 34, 46, 255},
+		Type:        color.RGBA{149, 56, 0, 255},
+		Function:    color.RGBA{130, 80, 223, 255},
+		String:      color.RGBA{10, 48, 105, 255},
+		Number:      color.RGBA{5, 80, 174, 255},
+		Comment:     color.RGBA{110, 119, 129, 255},
+		Literal:     color.RGBA{5, 80, 174, 255},
+		Property:    color.RGBA{17, 99, 41, 255},
+		ScrollThumb: color.RGBA{0, 0, 0, 60},
+	}
+}
+
+// DarkCodeColors returns colors for code on a dark background
+func DarkCodeColors() CodeColors {
+	return CodeColors{
+		Background:  color.RGBA{30, 31, 34, 255},
+		Text:        color.RGBA{220, 223, 228, 255},
+		Gutter:      color.RGBA{38, 39, 43, 255},
+		LineNumber:  color.RGBA{110, 116, 125, 255},
+		Highlight:   color.RGBA{60, 56, 30, 255},
+		Keyword:     color.RGBA{255, 123, 114, 255},
+		Type:        color.RGBA{255, 166, 87, 255},
+		Function:    color.RGBA{210, 168, 255, 255},
+		String:      color.RGBA{165, 214, 255, 255},
+		Number:      color.RGBA{121, 192, 255, 255},
+		Comment:     color.RGBA{139, 148, 158, 255},
+		Literal:     color.RGBA{121, 192, 255, 255},
+		Property:    color.RGBA{126, 231, 135, 255},
+		ScrollThumb: color.RGBA{255, 255, 255, 60},
+	}
+}
+
+// color returns the color of a syntax class
+func (c CodeColors) color(kind TokenKind) color.RGBA {
+	switch kind {
+	case TokenKeyword:
+		return c.Keyword
+	case TokenType:
+		return c.Type
+	case TokenFunction:
+		return c.Function
+	case TokenString:
+		return c.String
+	case TokenNumber:
+		return c.Number
+	case TokenComment:
+		return c.Comment
+	case TokenLiteral:
+		return c.Literal
+	case TokenProperty:
+		return c.Property
+	}
+	return c.Text
+}
+
+// codeLine is a line of code with tabs expanded and its tokens
+type codeLine struct {
+	runes  []rune
+	tokens []CodeToken
+}
+
+// CodeView shows read-only source code in a monospaced grid with line
+// numbers and syntax highlighting. It scrolls both ways with the mouse wheel
+// or by dragging its scrollbars.
+type CodeView struct {
+	*Node
+	code            string
+	lines           []codeLine
+	highlighter     Highlighter
+	colors          CodeColors
+	fontSize        int
+	tabWidth        int
+	showLineNumbers bool
+	highlightedLine int // 1-based, 0 for none
+	scrollX         int // Pixels
+	scrollY         int // Pixels
+	pressed         bool
+	dragging        int // scrollVertical or scrollHorizontal while a thumb is dragged
+	dragOffset      int
+}
+
+// Scrollbar being dragged
+const (
+	scrollNone = iota
+	scrollVertical
+	scrollHorizontal
+)
+
+// codeScrollbarSize is the thickness of the scrollbars
+const codeScrollbarSize = 8
+
+// NewCodeView creates a code view with no highlighting
+func NewCodeView(id, code string) *CodeView {
+	v := &CodeView{
+		Node:            NewNode(id),
+		colors:          LightCodeColors(),
+		fontSize:        14,
+		tabWidth:        4,
+		showLineNumbers: true,
+	}
+	v.SetCode(code)
+	return v
+}
+
+// SetCode replaces the code shown
+func (v *CodeView) SetCode(code string) {
+	v.code = code
+	v.rehighlight()
+	v.clampScroll()
+}
+
+// Code returns the code shown
+func (v *CodeView) Code() string {
+	return v.code
+}
+
+// SetHighlighter sets how the code is highlighted; nil shows plain text
+func (v *CodeView) SetHighlighter(highlighter Highlighter) {
+	v.highlighter = highlighter
+	v.rehighlight()
+}
+
+// SetLanguage highlights the code with the highlighter registered for a
+// language or file name, such as "go" or "config.json"
+func (v *CodeView) SetLanguage(language string) {
+	v.SetHighlighter(HighlighterFor(language))
+}
+
+// SetColors sets the colors of the view and its syntax classes
+func (v *CodeView) SetColors(colors CodeColors) {
+	v.colors = colors
+}
+
+// ApplyTheme picks light or dark code colors to match a theme, on the
+// theme's surface color
+func (v *CodeView) ApplyTheme(theme Theme) {
+	colors := LightCodeColors()
+	if theme.Scheme == ColorSchemeDark {
+		colors = DarkCodeColors()
+	}
+	colors.Background = theme.Surface
+	colors.LineNumber = theme.MutedText
+	v.colors = colors
+}
+
+// SetFontSize sets the font size, which sets the line height
+func (v *CodeView) SetFontSize(size int) {
+	v.fontSize = size
+	v.clampScroll()
+}
+
+// SetTabWidth sets how many columns a tab advances to
+func (v *CodeView) SetTabWidth(width int) {
+	v.tabWidth = max(1, width)
+	v.rehighlight()
+}
+
+// SetShowLineNumbers sets whether the gutter with line numbers is shown
+func (v *CodeView) SetShowLineNumbers(show bool) {
+	v.showLineNumbers = show
+}
+
+// SetHighlightedLine marks a line, counted from 1, with a background, e.g.
+// the line of an error; 0 clears it
+func (v *CodeView) SetHighlightedLine(line int) {
+	v.highlightedLine = line
+}
+
+// LineCount returns the number of lines
+func (v *CodeView) LineCount() int {
+	return len(v.lines)
+}
+
+// ScrollToLine scrolls so a line, counted from 1, is in view
+func (v *CodeView) ScrollToLine(line int) {
+	_, viewport := v.viewports()
+	top := (line - 1) * v.lineHeight()
+	if top < v.scrollY {
+		v.scrollY = top
+	} else if bottom := top + v.lineHeight(); bottom > v.scrollY+viewport.Height {
+		v.scrollY = bottom - viewport.Height
+	}
+	v.clampScroll()
+}
+
+// ScrollOffset returns the scroll position for saving in a session
+func (v *CodeView) ScrollOffset() Point {
+	return Point{v.scrollX, v.scrollY}
+}
+
+// SetScrollOffset restores a saved scroll position
+func (v *CodeView) SetScrollOffset(offset Point) {
+	v.scrollX, v.scrollY = offset.X, offset.Y
+	v.clampScroll()
+}
+
+// rehighlight splits the code into lines, expands tabs and tokenizes it
+func (v *CodeView) rehighlight() {
+	source := strings.Split(strings.ReplaceAll(v.code, "\r\n", "\n"), "\n")
+	v.lines = make([]codeLine, len(source))
+	state := 0
+	for i, text := range source {
+		line := codeLine{runes: v.expandTabs([]rune(text))}
+		if v.highlighter != nil {
+			line.tokens, state = v.highlighter.HighlightLine(line.runes, state)
+		}
+		v.lines[i] = line
+	}
+}
+
+// expandTabs replaces tabs with spaces up to the next tab stop
+func (v *CodeView) expandTabs(line []rune) []rune {
+	var expanded []rune
+	for _, r := range line {
+		if r == '\t' {
+			for {
+				expanded = append(expanded, ' ')
+				if len(expanded)%v.tabWidth == 0 {
+					break
+				}
+			}
+			continue
+		}
+		expanded = append(expanded, r)
+	}
+	return expanded
+}
+
+// lineHeight returns the height of a line
+func (v *CodeView) lineHeight() int {
+	return ScaleTextSize(v.fontSize) + 4
+}
+
+// charWidth returns the width of a grid cell
+func (v *CodeView) charWidth() int {
+	return max(1, MeasureText("M"))
+}
+
+// gutterWidth returns the width of the line number gutter
+func (v *CodeView) gutterWidth() int {
+	if !v.showLineNumbers {
+		return 0
+	}
+	digits := len(strconv.Itoa(max(1, len(v.lines))))
+	return (digits+2)*v.charWidth() + 4
+}
+
+// contentSize returns the width and height of all the code
+func (v *CodeView) contentSize() (int, int) {
+	columns := 0
+	for _, line := range v.lines {
+		columns = max(columns, len(line.runes))
+	}
+	return columns*v.charWidth() + 8, len(v.lines) * v.lineHeight()
+}
+
+// viewports returns the gutter and the code area, leaving room for
+// scrollbars the content needs
+func (v *CodeView) viewports() (Rect, Rect) {
+	bounds := v.ComputedBounds()
+	gutter := v.gutterWidth()
+	code := Rect{X: bounds.X + gutter, Y: bounds.Y, Width: bounds.Width - gutter, Height: bounds.Height}
+	width, height := v.contentSize()
+	vertical := height > code.Height
+	if vertical {
+		code.Width -= codeScrollbarSize
+	}
+	if width > code.Width {
+		code.Height -= codeScrollbarSize
+		// The horizontal scrollbar may push the last line out of view
+		if !vertical && height > code.Height {
+			code.Width -= codeScrollbarSize
+		}
+	}
+	return Rect{X: bounds.X, Y: bounds.Y, Width: gutter, Height: code.Height}, code
+}
+
+// clampScroll keeps the scroll position within the content
+func (v *CodeView) clampScroll() {
+	_, viewport := v.viewports()
+	width, height := v.contentSize()
+	v.scrollX = max(0, min(v.scrollX, width-viewport.Width))
+	v.scrollY = max(0, min(v.scrollY, height-viewport.Height))
+}
+
+// scrollThumbs returns the vertical and horizontal scrollbar thumbs; a thumb
+// is empty when its direction doesn't scroll
+func (v *CodeView) scrollThumbs() (Rect, Rect) {
+	_, viewport := v.viewports()
+	width, height := v.contentSize()
+	var vertical, horizontal Rect
+	if height > viewport.Height {
+		length := max(20, viewport.Height*viewport.Height/height)
+		offset := v.scrollY * (viewport.Height - length) / (height - viewport.Height)
+		vertical = Rect{X: viewport.X + viewport.Width, Y: viewport.Y + offset, Width: codeScrollbarSize, Height: length}
+	}
+	if width > viewport.Width {
+		length := max(20, viewport.Width*viewport.Width/width)
+		offset := v.scrollX * (viewport.Width - length) / (width - viewport.Width)
+		horizontal = Rect{X: viewport.X + offset, Y: viewport.Y + viewport.Height, Width: length, Height: codeScrollbarSize}
+	}
+	return vertical, horizontal
+}
+
+// Draw draws the visible lines, the gutter and the scrollbars
+func (v *CodeView) Draw(surface DrawSurface) {
+	if !v.IsVisible() {
+		return
+	}
+
+	bounds := v.ComputedBounds()
+	gutter, viewport := v.viewports()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, v.colors.Background)
+	surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
+	defer surface.ResetClipRect()
+
+	lineHeight, charWidth := v.lineHeight(), v.charWidth()
+	first := v.scrollY / lineHeight
+	last := min(len(v.lines)-1, (v.scrollY+viewport.Height)/lineHeight)
+	firstColumn := v.scrollX / charWidth
+	columns := viewport.Width/charWidth + 2
+	// Fonts that aren't monospaced are drawn a character at a time on the grid
+	monospaced := MeasureText("i") == charWidth
+
+	// Code
+	surface.SetClipRect(viewport.X, viewport.Y, viewport.Width, viewport.Height)
+	for i := first; i <= last; i++ {
+		line := v.lines[i]
+		y := viewport.Y + i*lineHeight - v.scrollY
+		if i+1 == v.highlightedLine {
+			surface.FillRect(viewport.X, y, viewport.Width, lineHeight, v.colors.Highlight)
+		}
+		end := min(len(line.runes), firstColumn+columns)
+		column := firstColumn
+		for _, token := range line.tokens {
+			if column >= end {
+				break
+			}
+			// The plain text before the token, then the token
+			v.drawRun(surface, line.runes, column, min(token.Start, end), TokenPlain, viewport, y, monospaced)
+			v.drawRun(surface, line.runes, max(column, token.Start), min(token.End, end), token.Kind, viewport, y, monospaced)
+			column = max(column, token.End)
+		}
+		v.drawRun(surface, line.runes, column, end, TokenPlain, viewport, y, monospaced)
+	}
+
+	// Gutter
+	if gutter.Width > 0 {
+		surface.SetClipRect(gutter.X, gutter.Y, gutter.Width, gutter.Height)
+		surface.FillRect(gutter.X, gutter.Y, gutter.Width, gutter.Height, v.colors.Gutter)
+		for i := first; i <= last; i++ {
+			y := gutter.Y + i*lineHeight - v.scrollY
+			number := strconv.Itoa(i + 1)
+			x := gutter.X + gutter.Width - (len(number)+1)*charWidth
+			clr := v.colors.LineNumber
+			if i+1 == v.highlightedLine {
+				clr = v.colors.Text
+			}
+			surface.DrawText(number, x, y+2, clr, v.fontSize)
+		}
+	}
+
+	// Scrollbars
+	surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
+	vertical, horizontal := v.scrollThumbs()
+	if vertical.Height > 0 {
+		surface.FillRect(vertical.X+2, vertical.Y, vertical.Width-4, vertical.Height, v.colors.ScrollThumb)
+	}
+	if horizontal.Width > 0 {
+		surface.FillRect(horizontal.X, horizontal.Y+2, horizontal.Width, horizontal.Height-4, v.colors.ScrollThumb)
+	}
+}
+
+// drawRun draws the characters from start to end of a line in one color
+func (v *CodeView) drawRun(surface DrawSurface, line []rune, start, end int, kind TokenKind, viewport Rect, y int, monospaced bool) {
+	if start >= end {
+		return
+	}
+	charWidth := v.charWidth()
+	clr := v.colors.color(kind)
+	x := viewport.X + 4 - v.scrollX
+	if monospaced {
+		surface.DrawText(string(line[start:end]), x+start*charWidth, y+2, clr, v.fontSize)
+		return
+	}
+	for i := start; i < end; i++ {
+		if line[i] != ' ' {
+			surface.DrawText(string(line[i]), x+i*charWidth, y+2, clr, v.fontSize)
+		}
+	}
+}
+
+// HandleScroll scrolls with the mouse wheel, sideways with a horizontal
+// wheel or trackpad
+func (v *CodeView) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !v.IsVisible() || !PointInRect(Point{x, y}, v.ComputedBounds()) {
+		return false
+	}
+	oldX, oldY := v.scrollX, v.scrollY
+	v.scrollX -= int(deltaX * float64(v.charWidth()*3))
+	v.scrollY -= int(deltaY * float64(v.lineHeight()*3))
+	v.clampScroll()
+	return v.scrollX != oldX || v.scrollY != oldY
+}
+
+// HandleMouseDown starts dragging a scrollbar thumb, or jumps a page when
+// its track is clicked
+func (v *CodeView) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !v.IsVisible() || !PointInRect(Point{x, y}, v.ComputedBounds()) {
+		return
+	}
+	if v.pressed {
+		e.Consume()
+		return
+	}
+	v.pressed = true
+
+	p := Point{x, y}
+	_, viewport := v.viewports()
+	vertical, horizontal := v.scrollThumbs()
+	switch {
+	case vertical.Height > 0 && x >= vertical.X && y < viewport.Y+viewport.Height:
+		if PointInRect(p, vertical) {
+			v.dragging, v.dragOffset = scrollVertical, y-vertical.Y
+		} else if y < vertical.Y {
+			v.scrollY -= viewport.Height
+		} else {
+			v.scrollY += viewport.Height
+		}
+	case horizontal.Width > 0 && y >= horizontal.Y && x < viewport.X+viewport.Width:
+		if PointInRect(p, horizontal) {
+			v.dragging, v.dragOffset = scrollHorizontal, x-horizontal.X
+		} else if x < horizontal.X {
+			v.scrollX -= viewport.Width
+		} else {
+			v.scrollX += viewport.Width
+		}
+	}
+	v.clampScroll()
+	e.Consume()
+}
+
+// HandleMouseUp ends a press or scrollbar drag
+func (v *CodeView) HandleMouseUp(e *Event) {
+	if !v.pressed {
+		return
+	}
+	v.pressed = false
+	v.dragging = scrollNone
+	e.Consume()
+}
+
+// HandleMouseMove drags a scrollbar thumb
+func (v *CodeView) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if v.dragging == scrollNone {
+		return
+	}
+	_, viewport := v.viewports()
+	width, height := v.contentSize()
+	vertical, horizontal := v.scrollThumbs()
+	if v.dragging == scrollVertical {
+		if span := viewport.Height - vertical.Height; span > 0 {
+			v.scrollY = (y - v.dragOffset - viewport.Y) * (height - viewport.Height) / span
+		}
+	} else if span := viewport.Width - horizontal.Width; span > 0 {
+		v.scrollX = (x - v.dragOffset - viewport.X) * (width - viewport.Width) / span
+	}
+	v.clampScroll()
+	e.Consume()
+}
@@ -0,0 +1,451 @@
+package components
+
+import (
+	"image/color"
+	"strings"
+)
+
+// ComboBox is an editable text field with a dropdown of suggestions that is
+// filtered as the user types. Unlike Select, any text can be entered.
+type ComboBox struct {
+	*Node
+	text            string
+	cursor          int // Rune index of the insertion point
+	placeholder     string
+	items           []string
+	filtered        []int // Indices into items that match the text
+	highlighted     int   // Index into filtered, -1 for none
+	scroll          int   // First visible entry of filtered
+	open            bool
+	focused         bool
+	maxVisible      int
+	itemHeight      int
+	fontSize        int
+	filter          func(item, text string) bool
+	onSelect        func(index int, item string)
+	onChange        func(string)
+	inputFilters    []InputFilter
+	backgroundColor color.RGBA
+	highlightColor  color.RGBA
+	borderColor     color.RGBA
+	textColor       color.RGBA
+}
+
+// NewComboBox creates a new combo box with the given suggestions
+func NewComboBox(id string, items []string) *ComboBox {
+	c := &ComboBox{
+		Node:            NewNode(id),
+		items:           items,
+		highlighted:     -1,
+		maxVisible:      8,
+		itemHeight:      22,
+		fontSize:        14,
+		filter:          containsFold,
+		backgroundColor: color.RGBA{255, 255, 255, 255},
+		highlightColor:  color.RGBA{200, 200, 255, 255},
+		borderColor:     color.RGBA{100, 100, 100, 255},
+		textColor:       color.RGBA{0, 0, 0, 255},
+	}
+	c.refilter()
+	c.SetCursorShape(CursorText)
+	return c
+}
+
+// containsFold is the default filter: a case-insensitive substring match
+func containsFold(item, text string) bool {
+	return strings.Contains(strings.ToLower(item), strings.ToLower(text))
+}
+
+// SetItems replaces the suggestions
+func (c *ComboBox) SetItems(items []string) {
+	c.items = items
+	c.refilter()
+}
+
+// Items returns all suggestions
+func (c *ComboBox) Items() []string {
+	return c.items
+}
+
+// SetText sets the text in the field, passed through the input filters, and
+// refilters the suggestions
+func (c *ComboBox) SetText(text string) {
+	c.text = applyInputFilters(c.inputFilters, c.text, text)
+	if n := len([]rune(c.text)); c.cursor > n {
+		c.cursor = n
+	}
+	c.refilter()
+	if c.onChange != nil {
+		c.onChange(c.text)
+	}
+}
+
+// GetText returns the text in the field
+func (c *ComboBox) GetText() string {
+	return c.text
+}
+
+// SetPlaceholder sets the text shown when the field is empty
+func (c *ComboBox) SetPlaceholder(placeholder string) {
+	c.placeholder = placeholder
+}
+
+// SetFilter sets how suggestions are matched against the text; nil restores
+// the default case-insensitive substring match
+func (c *ComboBox) SetFilter(filter func(item, text string) bool) {
+	if filter == nil {
+		filter = containsFold
+	}
+	c.filter = filter
+	c.refilter()
+}
+
+// SetOnSelect sets the handler for when a suggestion is chosen
+func (c *ComboBox) SetOnSelect(handler func(index int, item string)) {
+	c.onSelect = handler
+}
+
+// SetOnChange sets the handler for when the text changes
+func (c *ComboBox) SetOnChange(handler func(string)) {
+	c.onChange = handler
+}
+
+// SetMaxVisibleItems sets how many suggestions the dropdown shows before scrolling
+func (c *ComboBox) SetMaxVisibleItems(count int) {
+	if count > 0 {
+		c.maxVisible = count
+	}
+}
+
+// FilteredItems returns the suggestions matching the current text
+func (c *ComboBox) FilteredItems() []string {
+	items := make([]string, len(c.filtered))
+	for i, index := range c.filtered {
+		items[i] = c.items[index]
+	}
+	return items
+}
+
+// Open shows the dropdown
+func (c *ComboBox) Open() {
+	c.open = len(c.filtered) > 0
+}
+
+// Close hides the dropdown
+func (c *ComboBox) Close() {
+	c.open = false
+}
+
+// IsOpen returns whether the dropdown is showing
+func (c *ComboBox) IsOpen() bool {
+	return c.open
+}
+
+// Focus gives the field keyboard focus
+func (c *ComboBox) Focus() {
+	c.focused = true
+}
+
+// Blur removes keyboard focus and closes the dropdown
+func (c *ComboBox) Blur() {
+	c.focused = false
+	c.open = false
+}
+
+// IsFocused returns whether the field receives typed characters
+func (c *ComboBox) IsFocused() bool {
+	return c.focused
+}
+
+// SelectItem puts the suggestion at the given index into the field and closes the dropdown
+func (c *ComboBox) SelectItem(index int) {
+	if index < 0 || index >= len(c.items) {
+		return
+	}
+
+	item := c.items[index]
+	c.cursor = len([]rune(item))
+	c.SetText(item)
+	c.open = false
+	if c.onSelect != nil {
+		c.onSelect(index, item)
+	}
+}
+
+// refilter recomputes the matching suggestions and highlights the first one
+func (c *ComboBox) refilter() {
+	c.filtered = c.filtered[:0]
+	for i, item := range c.items {
+		if c.text == "" || c.filter(item, c.text) {
+			c.filtered = append(c.filtered, i)
+		}
+	}
+
+	c.scroll = 0
+	c.highlighted = -1
+	if c.text != "" && len(c.filtered) > 0 {
+		c.highlighted = 0
+	}
+	if len(c.filtered) == 0 {
+		c.open = false
+	}
+}
+
+// highlight moves the highlight to the given filtered entry and scrolls it into view
+func (c *ComboBox) highlight(entry int) {
+	c.highlighted = entry
+	if entry < c.scroll {
+		c.scroll = entry
+	} else if entry >= c.scroll+c.maxVisible {
+		c.scroll = entry - c.maxVisible + 1
+	}
+}
+
+// rowHeight returns the height of a suggestion, grown to fit the text scale
+func (c *ComboBox) rowHeight() int {
+	return max(c.itemHeight, ScaleTextSize(c.fontSize)+6)
+}
+
+// visibleCount returns how many suggestions are shown in the dropdown
+func (c *ComboBox) visibleCount() int {
+	if len(c.filtered) < c.maxVisible {
+		return len(c.filtered)
+	}
+	return c.maxVisible
+}
+
+// dropdownRect returns the bounds of the open dropdown
+func (c *ComboBox) dropdownRect() Rect {
+	bounds := c.ComputedBounds()
+	return Rect{
+		X:      bounds.X,
+		Y:      bounds.Y + bounds.Height,
+		Width:  bounds.Width,
+		Height: c.visibleCount() * c.rowHeight(),
+	}
+}
+
+// entryAt returns the filtered entry under the given point in the dropdown, or -1
+func (c *ComboBox) entryAt(x, y int) int {
+	dropdown := c.dropdownRect()
+	if !c.open || !PointInRect(Point{x, y}, dropdown) {
+		return -1
+	}
+	entry := c.scroll + (y-dropdown.Y)/c.rowHeight()
+	if entry >= len(c.filtered) {
+		return -1
+	}
+	return entry
+}
+
+// SetInputFilters sets the filters typed text passes through before the
+// change handler sees it
+func (c *ComboBox) SetInputFilters(filters ...InputFilter) {
+	c.inputFilters = filters
+}
+
+// insert replaces the runes between from and to with s and moves the cursor
+// after it. Edits rejected by the input filters leave the text unchanged.
+func (c *ComboBox) insert(from, to int, s string) {
+	text, cursor := filterEdit(c.inputFilters, c.text, from, to, s)
+	if cursor < 0 {
+		return
+	}
+	c.cursor = cursor
+	c.SetText(text)
+	c.Open()
+}
+
+// Draw draws the field and, when open, the dropdown of suggestions
+func (c *ComboBox) Draw(surface DrawSurface) {
+	if !c.IsVisible() {
+		return
+	}
+
+	bounds := c.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, c.backgroundColor)
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, c.borderColor)
+
+	textY := bounds.Y + (bounds.Height-c.fontSize)/2
+	if c.text != "" {
+		surface.DrawText(c.text, bounds.X+5, textY, c.textColor, c.fontSize)
+	} else if c.placeholder != "" {
+		surface.DrawText(c.placeholder, bounds.X+5, textY, color.RGBA{180, 180, 180, 255}, c.fontSize)
+	}
+
+	if c.focused {
+		cx := bounds.X + 5 + c.cursor*c.fontSize/2
+		surface.DrawLine(cx, textY, cx, textY+c.fontSize, c.textColor)
+	}
+
+	// Dropdown arrow
+	arrowX := bounds.X + bounds.Width - 20
+	arrowY := bounds.Y + bounds.Height/2
+	surface.DrawLine(arrowX, arrowY-3, arrowX+6, arrowY+3, c.textColor)
+	surface.DrawLine(arrowX+6, arrowY+3, arrowX+12, arrowY-3, c.textColor)
+
+	if !c.open {
+		return
+	}
+
+	dropdown := c.dropdownRect()
+	surface.FillRect(dropdown.X, dropdown.Y, dropdown.Width, dropdown.Height, c.backgroundColor)
+	for i := 0; i < c.visibleCount(); i++ {
+		entry := c.scroll + i
+		y := dropdown.Y + i*c.rowHeight()
+		if entry == c.highlighted {
+			surface.FillRect(dropdown.X, y, dropdown.Width, c.rowHeight(), c.highlightColor)
+		}
+		surface.DrawText(c.items[c.filtered[entry]], dropdown.X+5, y+(c.rowHeight()-c.fontSize)/2, c.textColor, c.fontSize)
+	}
+	surface.DrawRect(dropdown.X, dropdown.Y, dropdown.Width, dropdown.Height, c.borderColor)
+}
+
+// HandleMouseDown focuses the field, toggles the dropdown from the arrow and picks suggestions
+func (c *ComboBox) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !c.IsVisible() {
+		return
+	}
+
+	if entry := c.entryAt(x, y); entry >= 0 {
+		c.SelectItem(c.filtered[entry])
+		e.Consume()
+		return
+	}
+
+	bounds := c.ComputedBounds()
+	if !PointInRect(Point{x, y}, bounds) {
+		c.focused = false
+		c.open = false
+		return
+	}
+
+	c.focused = true
+	if x >= bounds.X+bounds.Width-24 {
+		if c.open {
+			c.Close()
+		} else {
+			c.Open()
+		}
+		e.Consume()
+		return
+	}
+
+	// Place the cursor at the clicked character
+	pos := (x - bounds.X - 5 + c.fontSize/4) / (c.fontSize / 2)
+	if n := len([]rune(c.text)); pos > n {
+		pos = n
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	c.cursor = pos
+	e.Consume()
+}
+
+// HandleMouseUp is a no-op; the combo box acts on mouse down
+func (c *ComboBox) HandleMouseUp(e *Event) {
+	return
+}
+
+// HandleMouseMove highlights the suggestion under the pointer
+func (c *ComboBox) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if entry := c.entryAt(x, y); entry >= 0 {
+		c.highlighted = entry
+		e.Consume()
+	}
+}
+
+// HandleScroll scrolls the open dropdown with the mouse wheel
+func (c *ComboBox) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !c.open || !PointInRect(Point{x, y}, c.dropdownRect()) {
+		return false
+	}
+
+	c.scroll -= int(deltaY)
+	if last := len(c.filtered) - c.visibleCount(); c.scroll > last {
+		c.scroll = last
+	}
+	if c.scroll < 0 {
+		c.scroll = 0
+	}
+	return true
+}
+
+// HandleKeyDown edits the text and navigates the suggestions while focused.
+// Up/Down/PageUp/PageDown move the highlight, Enter picks it and Esc closes
+// the dropdown (or, if already closed, leaves the field).
+func (c *ComboBox) HandleKeyDown(event InputEvent) bool {
+	if !c.focused || !c.IsVisible() {
+		return false
+	}
+
+	runes := []rune(c.text)
+	if event.Type == InputTypeChar {
+		if event.Char < 32 || event.CtrlDown {
+			return false
+		}
+		c.insert(c.cursor, c.cursor, string(event.Char))
+		return true
+	}
+
+	switch event.Key.Base() {
+	case KeyUp, KeyDown, KeyPageUp, KeyPageDown:
+		if !c.open {
+			c.Open()
+			return true
+		}
+		if entry, ok := NavigateList(event, c.highlighted, len(c.filtered), c.maxVisible); ok {
+			c.highlight(entry)
+		}
+	case KeyEnter:
+		if !c.open || c.highlighted < 0 {
+			return false
+		}
+		c.SelectItem(c.filtered[c.highlighted])
+	case KeyEscape:
+		if c.open {
+			c.Close()
+		} else {
+			c.focused = false
+		}
+	case KeyBackspace:
+		from := c.cursor - 1
+		if event.CtrlDown {
+			from = wordLeft(runes, c.cursor)
+		}
+		if from >= 0 && from < c.cursor {
+			c.insert(from, c.cursor, "")
+		}
+	case KeyDelete:
+		to := c.cursor + 1
+		if event.CtrlDown {
+			to = wordRight(runes, c.cursor)
+		}
+		if to <= len(runes) && to > c.cursor {
+			c.insert(c.cursor, to, "")
+		}
+	case KeyLeft:
+		if event.CtrlDown {
+			c.cursor = wordLeft(runes, c.cursor)
+		} else if c.cursor > 0 {
+			c.cursor--
+		}
+	case KeyRight:
+		if event.CtrlDown {
+			c.cursor = wordRight(runes, c.cursor)
+		} else if c.cursor < len(runes) {
+			c.cursor++
+		}
+	case KeyHome:
+		c.cursor = 0
+	case KeyEnd:
+		c.cursor = len(runes)
+	default:
+		return false
+	}
+
+	return true
+}
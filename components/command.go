@@ -0,0 +1,182 @@
+package components
+
+import (
+	"image"
+	"sort"
+)
+
+// Command is an action registered once and shown in any number of places:
+// menu items, toolbar buttons, keyboard shortcuts and the command palette.
+// Those surfaces read the title, icon and enabled state from the command
+// whenever they draw, so changing them here updates every surface.
+type Command struct {
+	ID          string
+	Title       string
+	Description string
+	Icon        image.Image
+	Shortcut    string // Accelerator such as "Ctrl+S"
+	Category    string // Groups commands in the palette, e.g. "File"
+	handler     func()
+	disabled    bool
+	enabledWhen func() bool
+}
+
+// NewCommand creates an enabled command
+func NewCommand(id, title string, handler func()) *Command {
+	return &Command{ID: id, Title: title, handler: handler}
+}
+
+// SetEnabled enables or disables the command
+func (c *Command) SetEnabled(enabled bool) {
+	c.disabled = !enabled
+}
+
+// SetEnabledWhen sets a predicate checked each time the command's state is
+// read, for commands that depend on app state such as a selection
+func (c *Command) SetEnabledWhen(predicate func() bool) {
+	c.enabledWhen = predicate
+}
+
+// IsEnabled returns whether the command can run
+func (c *Command) IsEnabled() bool {
+	if c.disabled || c.handler == nil {
+		return false
+	}
+	return c.enabledWhen == nil || c.enabledWhen()
+}
+
+// SetHandler sets the function the command runs
+func (c *Command) SetHandler(handler func()) {
+	c.handler = handler
+}
+
+// Execute runs the command if it is enabled. Returns whether it ran.
+func (c *Command) Execute() bool {
+	if !c.IsEnabled() {
+		return false
+	}
+	c.handler()
+	return true
+}
+
+// Label returns the title with the category prefix used by the palette
+func (c *Command) Label() string {
+	if c.Category == "" {
+		return c.Title
+	}
+	return c.Category + ": " + c.Title
+}
+
+// shortcutBinding ties an accelerator to a command
+type shortcutBinding struct {
+	accelerator Accelerator
+	command     *Command
+}
+
+// ShortcutManager runs commands from keyboard shortcuts. Disabled commands
+// don't consume their shortcut, so the key event continues on.
+type ShortcutManager struct {
+	bindings []shortcutBinding
+}
+
+// NewShortcutManager creates a shortcut manager with no bindings
+func NewShortcutManager() *ShortcutManager {
+	return &ShortcutManager{}
+}
+
+// Bind binds an accelerator such as "Ctrl+Shift+P" to a command
+func (m *ShortcutManager) Bind(accelerator string, command *Command) error {
+	acc, err := ParseAccelerator(accelerator)
+	if err != nil {
+		return err
+	}
+	m.Unbind(accelerator)
+	m.bindings = append(m.bindings, shortcutBinding{accelerator: acc, command: command})
+	return nil
+}
+
+// Unbind removes the binding for an accelerator
+func (m *ShortcutManager) Unbind(accelerator string) {
+	acc, err := ParseAccelerator(accelerator)
+	if err != nil {
+		return
+	}
+	for i, binding := range m.bindings {
+		if binding.accelerator == acc {
+			m.bindings = append(m.bindings[:i], m.bindings[i+1:]...)
+			return
+		}
+	}
+}
+
+// CommandFor returns the command bound to a key event, or nil
+func (m *ShortcutManager) CommandFor(event InputEvent) *Command {
+	for _, binding := range m.bindings {
+		if binding.accelerator.Matches(event) {
+			return binding.command
+		}
+	}
+	return nil
+}
+
+// HandleKeyDown runs the enabled command bound to the event
+func (m *ShortcutManager) HandleKeyDown(event InputEvent) bool {
+	if command := m.CommandFor(event); command != nil {
+		return command.Execute()
+	}
+	return false
+}
+
+// CommandRegistry holds an app's commands by ID and binds their shortcuts
+type CommandRegistry struct {
+	commands  map[string]*Command
+	shortcuts *ShortcutManager
+}
+
+// NewCommandRegistry creates an empty registry
+func NewCommandRegistry() *CommandRegistry {
+	return &CommandRegistry{
+		commands:  make(map[string]*Command),
+		shortcuts: NewShortcutManager(),
+	}
+}
+
+// Register adds a command, binding its shortcut if it has one. A command
+// with the same ID is replaced.
+func (r *CommandRegistry) Register(command *Command) error {
+	r.commands[command.ID] = command
+	if command.Shortcut != "" {
+		return r.shortcuts.Bind(command.Shortcut, command)
+	}
+	return nil
+}
+
+// Command returns the command with the ID, or nil
+func (r *CommandRegistry) Command(id string) *Command {
+	return r.commands[id]
+}
+
+// Execute runs the command with the ID. Returns whether it ran.
+func (r *CommandRegistry) Execute(id string) bool {
+	if command := r.commands[id]; command != nil {
+		return command.Execute()
+	}
+	return false
+}
+
+// Commands returns every command sorted by label
+func (r *CommandRegistry) Commands() []*Command {
+	commands := make([]*Command, 0, len(r.commands))
+	for _, command := range r.commands {
+		commands = append(commands, command)
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Label() < commands[j].Label()
+	})
+	return commands
+}
+
+// Shortcuts returns the shortcut manager that runs the registry's commands
+func (r *CommandRegistry) Shortcuts() *ShortcutManager {
+	return r.shortcuts
+}
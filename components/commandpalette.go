@@ -0,0 +1,306 @@
+package components
+
+import (
+	"image/color"
+	"sort"
+	"strings"
+)
+
+// CommandPalette is a searchable list of every command in a registry, shown
+// above the UI. Typing filters the list; Enter runs the highlighted command.
+type CommandPalette struct {
+	*Node
+	registry        *CommandRegistry
+	open            bool
+	query           string
+	results         []*Command
+	selected        int
+	scroll          int
+	width           int
+	rowHeight       int
+	maxRows         int
+	fontSize        int
+	focus           *FocusManager
+	scope           *FocusScope
+	backdropColor   color.RGBA
+	backgroundColor color.RGBA
+	selectedColor   color.RGBA
+	textColor       color.RGBA
+	disabledColor   color.RGBA
+	borderColor     color.RGBA
+}
+
+// NewCommandPalette creates a closed palette for a registry's commands
+func NewCommandPalette(id string, registry *CommandRegistry) *CommandPalette {
+	p := &CommandPalette{
+		Node:            NewNode(id),
+		registry:        registry,
+		width:           480,
+		rowHeight:       26,
+		maxRows:         10,
+		fontSize:        14,
+		backdropColor:   color.RGBA{0, 0, 0, 60},
+		backgroundColor: color.RGBA{255, 255, 255, 255},
+		selectedColor:   color.RGBA{200, 220, 255, 255},
+		textColor:       color.RGBA{0, 0, 0, 255},
+		disabledColor:   color.RGBA{160, 160, 160, 255},
+		borderColor:     color.RGBA{150, 150, 150, 255},
+	}
+	p.SetPositionType(PositionFixed)
+	p.Node.SetBounds(Rect{X: 0, Y: 0, Width: ScreenWidth, Height: ScreenHeight})
+	p.SetVisible(false)
+	return p
+}
+
+// SetFocusManager opens a focus scope while the palette is shown
+func (p *CommandPalette) SetFocusManager(focus *FocusManager) {
+	p.focus = focus
+}
+
+// Open shows the palette with an empty query
+func (p *CommandPalette) Open() {
+	if p.open {
+		return
+	}
+	p.open = true
+	p.SetVisible(true)
+	p.SetQuery("")
+	if p.focus != nil {
+		p.scope = p.focus.PushScope(p.ID(), p)
+	}
+}
+
+// Close hides the palette
+func (p *CommandPalette) Close() {
+	if !p.open {
+		return
+	}
+	p.open = false
+	p.SetVisible(false)
+	if p.focus != nil && p.scope != nil {
+		p.focus.PopScope(p.scope)
+		p.scope = nil
+	}
+}
+
+// IsOpen returns whether the palette is showing
+func (p *CommandPalette) IsOpen() bool {
+	return p.open
+}
+
+// SetQuery sets the search text and filters the commands
+func (p *CommandPalette) SetQuery(query string) {
+	p.query = query
+	p.results = p.filter(query)
+	p.selected = 0
+	p.scroll = 0
+}
+
+// Query returns the search text
+func (p *CommandPalette) Query() string {
+	return p.query
+}
+
+// Results returns the commands matching the query, best first
+func (p *CommandPalette) Results() []*Command {
+	return p.results
+}
+
+// filter returns commands whose label contains every word of the query,
+// with labels starting with the query first
+func (p *CommandPalette) filter(query string) []*Command {
+	words := strings.Fields(strings.ToLower(query))
+	var prefix, rest []*Command
+	for _, command := range p.registry.Commands() {
+		label := strings.ToLower(command.Label())
+		title := strings.ToLower(command.Title)
+		matched := true
+		for _, word := range words {
+			if !strings.Contains(label, word) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if len(words) > 0 && strings.HasPrefix(title, words[0]) {
+			prefix = append(prefix, command)
+		} else {
+			rest = append(rest, command)
+		}
+	}
+
+	// Enabled commands before disabled ones, keeping label order otherwise
+	results := append(prefix, rest...)
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].IsEnabled() && !results[j].IsEnabled()
+	})
+	return results
+}
+
+// Run runs the highlighted command and closes the palette
+func (p *CommandPalette) Run() {
+	if p.selected < 0 || p.selected >= len(p.results) {
+		return
+	}
+	command := p.results[p.selected]
+	if !command.IsEnabled() {
+		return
+	}
+	p.Close()
+	command.Execute()
+}
+
+// boxRect returns the bounds of the palette box near the top of the screen
+func (p *CommandPalette) boxRect() Rect {
+	bounds := p.ComputedBounds()
+	rows := min(len(p.results), p.maxRows)
+	height := p.rowHeight + 8 + rows*p.rowHeight
+	width := min(p.width, bounds.Width-20)
+	return Rect{X: bounds.X + (bounds.Width-width)/2, Y: bounds.Y + 60, Width: width, Height: height}
+}
+
+// rowRect returns the bounds of a visible result row
+func (p *CommandPalette) rowRect(row int) Rect {
+	box := p.boxRect()
+	return Rect{X: box.X, Y: box.Y + p.rowHeight + 8 + row*p.rowHeight, Width: box.Width, Height: p.rowHeight}
+}
+
+// Draw draws the query field and the visible results
+func (p *CommandPalette) Draw(surface DrawSurface) {
+	if !p.open {
+		return
+	}
+
+	bounds := p.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, p.backdropColor)
+
+	box := p.boxRect()
+	surface.FillRect(box.X+3, box.Y+3, box.Width, box.Height, color.RGBA{0, 0, 0, 60})
+	surface.FillRect(box.X, box.Y, box.Width, box.Height, p.backgroundColor)
+	surface.DrawRect(box.X, box.Y, box.Width, box.Height, p.borderColor)
+
+	// Query field with a caret
+	field := Rect{X: box.X + 4, Y: box.Y + 4, Width: box.Width - 8, Height: p.rowHeight}
+	surface.DrawRect(field.X, field.Y, field.Width, field.Height, p.borderColor)
+	textY := field.Y + (field.Height-p.fontSize)/2
+	if p.query == "" {
+		surface.DrawText("Type a command", field.X+6, textY, p.disabledColor, p.fontSize)
+	} else {
+		surface.DrawText(p.query, field.X+6, textY, p.textColor, p.fontSize)
+	}
+	caretX := field.X + 6 + MeasureText(p.query)
+	surface.DrawLine(caretX, field.Y+4, caretX, field.Y+field.Height-4, p.textColor)
+
+	for row := 0; row < p.maxRows && p.scroll+row < len(p.results); row++ {
+		index := p.scroll + row
+		command := p.results[index]
+		rect := p.rowRect(row)
+		if index == p.selected {
+			surface.FillRect(rect.X+1, rect.Y, rect.Width-2, rect.Height, p.selectedColor)
+		}
+
+		clr := p.textColor
+		if !command.IsEnabled() {
+			clr = p.disabledColor
+		}
+		y := rect.Y + (rect.Height-p.fontSize)/2
+		surface.DrawText(command.Label(), rect.X+10, y, clr, p.fontSize)
+		if command.Shortcut != "" {
+			x := rect.X + rect.Width - 10 - MeasureText(command.Shortcut)
+			surface.DrawText(command.Shortcut, x, y, p.disabledColor, p.fontSize)
+		}
+	}
+}
+
+// HandleMouseDown runs a clicked command and closes the palette on outside clicks
+func (p *CommandPalette) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !p.open {
+		return
+	}
+	point := Point{x, y}
+	if !PointInRect(point, p.boxRect()) {
+		p.Close()
+		e.Consume()
+		return
+	}
+	for row := 0; row < p.maxRows && p.scroll+row < len(p.results); row++ {
+		if PointInRect(point, p.rowRect(row)) {
+			p.selected = p.scroll + row
+			p.Run()
+			break
+		}
+	}
+	e.Consume()
+}
+
+// HandleMouseUp blocks mouse up events while open
+func (p *CommandPalette) HandleMouseUp(e *Event) {
+	if p.open {
+		e.Consume()
+	}
+}
+
+// HandleMouseMove highlights the result under the pointer
+func (p *CommandPalette) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !p.open {
+		return
+	}
+	for row := 0; row < p.maxRows && p.scroll+row < len(p.results); row++ {
+		if PointInRect(Point{x, y}, p.rowRect(row)) {
+			p.selected = p.scroll + row
+		}
+	}
+	e.Consume()
+}
+
+// HandleScroll scrolls the results with the mouse wheel
+func (p *CommandPalette) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !p.open {
+		return false
+	}
+	p.scroll = max(0, min(p.scroll-int(deltaY), len(p.results)-p.maxRows))
+	return true
+}
+
+// HandleKeyDown edits the query, moves the highlight and runs or closes
+func (p *CommandPalette) HandleKeyDown(event InputEvent) bool {
+	if !p.open {
+		return false
+	}
+
+	if event.Type == InputTypeChar {
+		p.SetQuery(p.query + string(event.Char))
+		return true
+	}
+	if event.Type != InputTypeKeyDown {
+		return true
+	}
+
+	switch event.Key {
+	case KeyEscape:
+		p.Close()
+		return true
+	case KeyEnter, KeyNumpadEnter:
+		p.Run()
+		return true
+	case KeyBackspace:
+		if runes := []rune(p.query); len(runes) > 0 {
+			p.SetQuery(string(runes[:len(runes)-1]))
+		}
+		return true
+	}
+
+	if position, ok := NavigateList(event, p.selected, len(p.results), p.maxRows); ok {
+		p.selected = position
+		if p.selected < p.scroll {
+			p.scroll = p.selected
+		} else if p.selected >= p.scroll+p.maxRows {
+			p.scroll = p.selected - p.maxRows + 1
+		}
+	}
+	return true
+}
@@ -11,7 +11,8 @@ type FlexContainer struct {
 	flexDirection   FlexDirection
 	alignItems      Alignment
 	justifyContent  Alignment
-	spacing         int // Space between items
+	spacing         int  // Space between items
+	fitContent      bool // Size along the direction follows the children
 }
 
 // NewFlexContainer creates a new flex container
@@ -26,9 +27,25 @@ func NewFlexContainer(id string) *FlexContainer {
 	}
 }
 
-// SetFlexDirection sets the flex direction
+// SetFlexDirection sets the flex direction and updates layout
 func (f *FlexContainer) SetFlexDirection(direction FlexDirection) {
+	if direction == f.flexDirection {
+		return
+	}
 	f.flexDirection = direction
+	f.updateLayout()
+}
+
+// SetFitContent sets whether the container sizes itself along its direction
+// to fit its children, e.g. as the content of a ScrollContainer
+func (f *FlexContainer) SetFitContent(fit bool) {
+	f.fitContent = fit
+	f.updateLayout()
+}
+
+// FlexDirection returns the flex direction
+func (f *FlexContainer) FlexDirection() FlexDirection {
+	return f.flexDirection
 }
 
 // SetAlignItems sets the align items property
@@ -46,6 +63,11 @@ func (f *FlexContainer) SetBackgroundColor(color color.RGBA) {
 	f.backgroundColor = color
 }
 
+// BackgroundColor returns the background color
+func (f *FlexContainer) BackgroundColor() color.RGBA {
+	return f.backgroundColor
+}
+
 // Draw draws the flex container and its children
 func (f *FlexContainer) Draw(surface DrawSurface) {
 	if !f.IsVisible() {
@@ -58,6 +80,7 @@ func (f *FlexContainer) Draw(surface DrawSurface) {
 	if f.backgroundColor.A > 0 {
 		surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, f.backgroundColor)
 	}
+	f.drawBorder(surface, bounds)
 	
 	// Perform layout calculations for children here...
 	// (Simplified - a real implementation would position children according to flex rules)
@@ -69,47 +92,107 @@ func (f *FlexContainer) Draw(surface DrawSurface) {
 }
 
 // HandleMouseDown handles mouse down events
-func (f *FlexContainer) HandleMouseDown(x, y int) bool {
+func (f *FlexContainer) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
 	bounds := f.ComputedBounds()
 	if PointInRect(Point{x, y}, bounds) {
 		// Check if any children handle the event (in reverse order for proper z-index)
 		for i := len(f.Children()) - 1; i >= 0; i-- {
 			child := f.Children()[i]
-			if child.HandleMouseDown(x, y) {
-				return true
+			if child.HandleMouseDown(e); e.Consumed() {
+				return
 			}
 		}
 		
 		// If no children handled it, this container handles it
-		return true
+		e.Consume()
 	}
-	return false
 }
 
 // HandleMouseUp handles mouse up events
-func (f *FlexContainer) HandleMouseUp(x, y int) bool {
+func (f *FlexContainer) HandleMouseUp(e *Event) {
 	// Check if any children handle the event (in reverse order for proper z-index)
 	for i := len(f.Children()) - 1; i >= 0; i-- {
 		child := f.Children()[i]
-		if child.HandleMouseUp(x, y) {
-			return true
+		if child.HandleMouseUp(e); e.Consumed() {
+			return
 		}
 	}
-	
-	return false
 }
 
 // HandleMouseMove handles mouse move events
-func (f *FlexContainer) HandleMouseMove(x, y int) bool {
+func (f *FlexContainer) HandleMouseMove(e *Event) {
 	// Check if any children handle the event (in reverse order for proper z-index)
 	for i := len(f.Children()) - 1; i >= 0; i-- {
 		child := f.Children()[i]
-		if child.HandleMouseMove(x, y) {
-			return true
+		if child.HandleMouseMove(e); e.Consumed() {
+			return
 		}
 	}
+}
+
+// outOfFlow reports whether a child is positioned in screen coordinates, like
+// dialogs and menus, and so takes no space in the layout
+func outOfFlow(child Element) bool {
+	node, ok := child.(NodeElement)
+	return ok && node.GetPositionType() == PositionFixed
+}
+
+// flexGrow returns a child's flex-grow weight, or 0 if it keeps its size
+func flexGrow(child Element) int {
+	if item, ok := child.(interface{ FlexGrow() int }); ok {
+		return item.FlexGrow()
+	}
+	return 0
+}
+
+// mainSizes returns the size of each child along the flex direction: its
+// own size, or for growing children a share by weight of the space the
+// others leave. Leftover pixels from rounding go to the last growing child.
+func (f *FlexContainer) mainSizes(available int) []int {
+	children := f.Children()
+	sizes := make([]int, len(children))
+	mainSize := func(child Element) int {
+		if f.flexDirection == FlexRow {
+			return child.Bounds().Width
+		}
+		return child.Bounds().Height
+	}
 	
-	return false
+	free := available
+	totalWeight, inFlow, last := 0, 0, -1
+	for i, child := range children {
+		if outOfFlow(child) {
+			continue
+		}
+		inFlow++
+		if weight := flexGrow(child); weight > 0 {
+			totalWeight += weight
+			last = i
+			continue
+		}
+		sizes[i] = mainSize(child)
+		free -= sizes[i]
+	}
+	if totalWeight == 0 {
+		return sizes
+	}
+	
+	free -= (inFlow - 1) * f.spacing
+	free = max(free, 0)
+	shared := 0
+	for i, child := range children {
+		weight := flexGrow(child)
+		if weight == 0 || outOfFlow(child) {
+			continue
+		}
+		sizes[i] = free * weight / totalWeight
+		shared += sizes[i]
+		if i == last {
+			sizes[i] += free - shared
+		}
+	}
+	return sizes
 }
 
 // SetSpacing sets the spacing between items
@@ -118,21 +201,61 @@ func (f *FlexContainer) SetSpacing(spacing int) {
 	f.updateLayout()
 }
 
+// Spacing returns the spacing between items
+func (f *FlexContainer) Spacing() int {
+	return f.spacing
+}
+
+// SetBounds sets the bounds and updates layout, so the children move and
+// resize with the container
+func (f *FlexContainer) SetBounds(bounds Rect) {
+	f.Node.SetBounds(bounds)
+	f.updateLayout()
+}
+
+// SetPadding sets the padding and updates layout, so the children move
+// inside it
+func (f *FlexContainer) SetPadding(padding Spacing) {
+	f.Node.SetPadding(padding)
+	f.updateLayout()
+}
+
+// SetBoxModel sets the box model and updates layout
+func (f *FlexContainer) SetBoxModel(box BoxModel) {
+	f.Node.SetBoxModel(box)
+	f.updateLayout()
+}
+
 // AddChild adds a child element and updates layout
 func (f *FlexContainer) AddChild(child Element) {
 	f.Node.AddChild(child)
 	f.updateLayout()
 }
 
+// InsertChild inserts a child element at an index and updates layout
+func (f *FlexContainer) InsertChild(index int, child Element) {
+	f.Node.InsertChild(index, child)
+	f.updateLayout()
+}
+
 // RemoveChild removes a child element and updates layout
 func (f *FlexContainer) RemoveChild(child Element) {
 	f.Node.RemoveChild(child)
 	f.updateLayout()
 }
 
-// updateLayout updates the layout of children
+// updateLayout updates the layout of children, once at the end if a batch
+// is open
 func (f *FlexContainer) updateLayout() {
+	Batched(f, f.layoutChildren)
+}
+
+// layoutChildren positions the children along the flex direction
+func (f *FlexContainer) layoutChildren() {
 	if len(f.Children()) == 0 {
+		if f.fitContent {
+			f.fitToContent()
+		}
 		return
 	}
 	
@@ -147,10 +270,15 @@ func (f *FlexContainer) updateLayout() {
 	
 	// Simplified flex layout algorithm
 	if f.flexDirection == FlexRow {
-		// Row layout - items side by side
+		// Row layout - items side by side, growing ones sharing the free width
+		sizes := f.mainSizes(contentWidth)
 		x := contentX
-		for _, child := range f.Children() {
+		for i, child := range f.Children() {
+			if outOfFlow(child) {
+				continue
+			}
 			childBounds := child.Bounds()
+			childBounds.Width = sizes[i]
 			childHeight := childBounds.Height
 			
 			// Vertical alignment
@@ -174,10 +302,15 @@ func (f *FlexContainer) updateLayout() {
 			x += childBounds.Width + f.spacing
 		}
 	} else {
-		// Column layout - items stacked
+		// Column layout - items stacked, growing ones sharing the free height
+		sizes := f.mainSizes(contentHeight)
 		y := contentY
-		for _, child := range f.Children() {
+		for i, child := range f.Children() {
+			if outOfFlow(child) {
+				continue
+			}
 			childBounds := child.Bounds()
+			childBounds.Height = sizes[i]
 			childWidth := childBounds.Width
 			
 			// Horizontal alignment
@@ -201,4 +334,38 @@ func (f *FlexContainer) updateLayout() {
 			y += childBounds.Height + f.spacing
 		}
 	}
+	
+	// Fit the container to its children
+	if f.fitContent {
+		f.fitToContent()
+	}
+}
+
+// fitToContent sizes the container along its direction to its children,
+// the spacing between them and its padding, without moving the children
+func (f *FlexContainer) fitToContent() {
+	boxModel := f.GetBoxModel()
+	total, count := 0, 0
+	for _, child := range f.Children() {
+		if outOfFlow(child) {
+			continue
+		}
+		count++
+		if f.flexDirection == FlexRow {
+			total += child.Bounds().Width
+		} else {
+			total += child.Bounds().Height
+		}
+	}
+	if count > 0 {
+		total += (count - 1) * f.spacing
+	}
+	
+	bounds := f.Bounds()
+	if f.flexDirection == FlexRow {
+		bounds.Width = total + boxModel.Padding.Left + boxModel.Padding.Right
+	} else {
+		bounds.Height = total + boxModel.Padding.Top + boxModel.Padding.Bottom
+	}
+	f.Node.SetBounds(bounds)
 } 
\ No newline at end of file
@@ -0,0 +1,132 @@
+package components
+
+// ContextMenuTarget is implemented by elements that can carry a context menu
+type ContextMenuTarget interface {
+	Element
+	ContextMenu() []*Command
+}
+
+// SetContextMenu sets the commands shown when the element is right-clicked.
+// A nil entry adds a separator.
+func (d *Node) SetContextMenu(commands ...*Command) {
+	d.contextMenu = commands
+}
+
+// ContextMenu returns the element's context menu commands
+func (d *Node) ContextMenu() []*Command {
+	return d.contextMenu
+}
+
+// ContextMenuManager opens the context menu of the element under the pointer,
+// or of the focused element from the keyboard. Menus are built from the
+// element's commands when opened, so disabled commands show as disabled.
+type ContextMenuManager struct {
+	root   Element
+	menu   *PopupMenu
+	target Element
+}
+
+// NewContextMenuManager creates a manager for the element tree. Add Menu()
+// to the tree above other content so the open menu is drawn and receives input.
+func NewContextMenuManager(root Element) *ContextMenuManager {
+	return &ContextMenuManager{
+		root: root,
+		menu: NewPopupMenu(root.ID() + "_context_menu"),
+	}
+}
+
+// Menu returns the popup menu used to show context menus
+func (m *ContextMenuManager) Menu() *PopupMenu {
+	return m.menu
+}
+
+// Target returns the element whose menu is open, or nil
+func (m *ContextMenuManager) Target() Element {
+	if !m.menu.IsOpen() {
+		return nil
+	}
+	return m.target
+}
+
+// OpenAt shows the context menu of the topmost element under the point.
+// Returns false if no element there has one.
+func (m *ContextMenuManager) OpenAt(x, y int) bool {
+	target := contextMenuTargetAt(m.root, x, y)
+	if target == nil {
+		return false
+	}
+	return m.open(target, x, y)
+}
+
+// OpenFor shows an element's context menu below its top-left corner, as used
+// for the Menu key and Shift+F10
+func (m *ContextMenuManager) OpenFor(element Element) bool {
+	for e := element; e != nil; e = e.Parent() {
+		if target, ok := e.(ContextMenuTarget); ok && len(target.ContextMenu()) > 0 {
+			bounds := elementBounds(e)
+			return m.open(target, bounds.X+4, bounds.Y+bounds.Height/2)
+		}
+	}
+	return false
+}
+
+// open fills the menu from the target's commands and shows it
+func (m *ContextMenuManager) open(target ContextMenuTarget, x, y int) bool {
+	m.menu.Clear()
+	for _, command := range target.ContextMenu() {
+		if command == nil {
+			m.menu.AddSeparator()
+			continue
+		}
+		m.menu.AddCommand(command)
+	}
+	m.target = target
+	m.menu.ShowAt(x, y)
+	return true
+}
+
+// Close hides an open context menu
+func (m *ContextMenuManager) Close() {
+	m.menu.Hide()
+}
+
+// HandleKeyDown opens the focused element's menu on the Menu key or Shift+F10
+func (m *ContextMenuManager) HandleKeyDown(event InputEvent, focused Element) bool {
+	if event.Type != InputTypeKeyDown || focused == nil || m.menu.IsOpen() {
+		return false
+	}
+	if event.Key == KeyContextMenu || (event.Key == KeyF10 && event.ShiftDown) {
+		return m.OpenFor(focused)
+	}
+	return false
+}
+
+// elementBounds returns an element's screen bounds
+func elementBounds(element Element) Rect {
+	if node, ok := element.(NodeElement); ok {
+		return node.ComputedBounds()
+	}
+	return element.Bounds()
+}
+
+// contextMenuTargetAt returns the deepest visible element under the point
+// that has a context menu, checking later (topmost) children first
+func contextMenuTargetAt(element Element, x, y int) ContextMenuTarget {
+	if v, ok := element.(interface{ IsVisible() bool }); ok && !v.IsVisible() {
+		return nil
+	}
+
+	children := element.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if target := contextMenuTargetAt(children[i], x, y); target != nil {
+			return target
+		}
+	}
+
+	if target, ok := element.(ContextMenuTarget); ok && len(target.ContextMenu()) > 0 {
+		if PointInRect(Point{x, y}, elementBounds(element)) {
+			return target
+		}
+	}
+	return nil
+}
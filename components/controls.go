@@ -17,11 +17,12 @@ type Button struct {
 	hovered        bool
 	pressed        bool
 	disabled       bool
+	skin           *ButtonSkin
 }
 
 // NewButton creates a new button
 func NewButton(id string, text string) *Button {
-	return &Button{
+	b := &Button{
 		Node:           NewNode(id),
 		text:           text,
 		onClick:        nil,
@@ -34,6 +35,8 @@ func NewButton(id string, text string) *Button {
 		pressed:        false,
 		disabled:       false,
 	}
+	b.SetCursorShape(CursorPointer)
+	return b
 }
 
 // SetDisabled sets whether the button is disabled
@@ -46,6 +49,26 @@ func (b *Button) IsDisabled() bool {
 	return b.disabled
 }
 
+// CursorShapeAt returns the pointer hand, or the arrow while disabled
+func (b *Button) CursorShapeAt(x, y int) CursorShape {
+	if b.disabled {
+		return CursorDefault
+	}
+	return b.Node.CursorShapeAt(x, y)
+}
+
+// SetSkin draws the button with nine-patch images instead of flat colors;
+// nil restores the colors
+func (b *Button) SetSkin(skin *ButtonSkin) {
+	b.skin = skin
+}
+
+// ApplyTheme takes the button's skin from a theme. Colors are left alone so
+// buttons keep any colors set on them.
+func (b *Button) ApplyTheme(theme Theme) {
+	b.skin = theme.ButtonSkin
+}
+
 // Draw draws the button
 func (b *Button) Draw(surface DrawSurface) {
 	if !b.IsVisible() {
@@ -64,11 +87,16 @@ func (b *Button) Draw(surface DrawSurface) {
 		bg = b.hoverColor
 	}
 	
-	// Draw the button background
-	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, bg)
-	
-	// Draw the button border
-	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, color.RGBA{100, 100, 100, 255})
+	if patch := b.skinPatch(); patch != nil {
+		// Draw the skin image for the state
+		surface.DrawImage(patch, bounds.X, bounds.Y, bounds.Width, bounds.Height, ImageFitNinePatch)
+	} else {
+		// Draw the button background
+		surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, bg)
+		
+		// Draw the button border
+		surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, color.RGBA{100, 100, 100, 255})
+	}
 	
 	// Calculate text position to center it
 	textWidth := len(b.text) * b.fontSize / 2
@@ -96,11 +124,29 @@ func (b *Button) Draw(surface DrawSurface) {
 	}
 }
 
+// skinPatch returns the skin image for the button's state, or nil
+func (b *Button) skinPatch() *NinePatch {
+	if b.skin == nil {
+		return nil
+	}
+	return b.skin.patchFor(b.disabled, b.pressed, b.hovered)
+}
+
 // SetOnClick sets the click handler
 func (b *Button) SetOnClick(handler func()) {
 	b.onClick = handler
 }
 
+// Click runs the click handler as a mouse click would, unless the button
+// is disabled. Returns whether it ran.
+func (b *Button) Click() bool {
+	if b.disabled || b.onClick == nil {
+		return false
+	}
+	b.onClick()
+	return true
+}
+
 // SetBackgroundColor sets the button background color
 func (b *Button) SetBackgroundColor(color color.RGBA) {
 	b.backgroundColor = color
@@ -116,15 +162,36 @@ func (b *Button) SetText(text string) {
 	b.text = text
 }
 
+// GetText returns the button text
+func (b *Button) GetText() string {
+	return b.text
+}
+
 // SetFontSize sets the button font size
 func (b *Button) SetFontSize(size int) {
 	b.fontSize = size
 }
 
+// BackgroundColor returns the button background color
+func (b *Button) BackgroundColor() color.RGBA {
+	return b.backgroundColor
+}
+
+// TextColor returns the button text color
+func (b *Button) TextColor() color.RGBA {
+	return b.textColor
+}
+
+// FontSize returns the button font size
+func (b *Button) FontSize() int {
+	return b.fontSize
+}
+
 // HandleMouseDown handles mouse down events
-func (b *Button) HandleMouseDown(x, y int) bool {
+func (b *Button) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
 	if b.disabled {
-		return false
+		return
 	}
 	
 	bounds := b.ComputedBounds()
@@ -134,23 +201,23 @@ func (b *Button) HandleMouseDown(x, y int) bool {
 		// Check if any children handle the event
 		for i := len(b.Children()) - 1; i >= 0; i-- {
 			child := b.Children()[i]
-			if child.HandleMouseDown(x, y) {
-				return true
+			if child.HandleMouseDown(e); e.Consumed() {
+				return
 			}
 		}
 		
-		return true
+		e.Consume()
 	}
-	return false
 }
 
 // HandleMouseUp handles mouse up events
-func (b *Button) HandleMouseUp(x, y int) bool {
+func (b *Button) HandleMouseUp(e *Event) {
+	x, y := e.X, e.Y
 	wasPressed := b.pressed
 	b.pressed = false
 	
 	if b.disabled {
-		return false
+		return
 	}
 	
 	bounds := b.ComputedBounds()
@@ -160,22 +227,22 @@ func (b *Button) HandleMouseUp(x, y int) bool {
 			b.onClick()
 		}
 		
-		return true
+		e.Consume()
+		return
 	}
 	
 	// Still try children even if this element didn't handle it
 	for i := len(b.Children()) - 1; i >= 0; i-- {
 		child := b.Children()[i]
-		if child.HandleMouseUp(x, y) {
-			return true
+		if child.HandleMouseUp(e); e.Consumed() {
+			return
 		}
 	}
-	
-	return false
 }
 
 // HandleMouseMove handles mouse move events
-func (b *Button) HandleMouseMove(x, y int) bool {
+func (b *Button) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
 	wasHovered := b.hovered
 	bounds := b.ComputedBounds()
 	b.hovered = PointInRect(Point{x, y}, bounds)
@@ -183,12 +250,14 @@ func (b *Button) HandleMouseMove(x, y int) bool {
 	// Check if any children handle the event
 	for i := len(b.Children()) - 1; i >= 0; i-- {
 		child := b.Children()[i]
-		if child.HandleMouseMove(x, y) {
-			return true
+		if child.HandleMouseMove(e); e.Consumed() {
+			return
 		}
 	}
 	
-	return b.hovered || wasHovered != b.hovered
+	if b.hovered || wasHovered != b.hovered {
+		e.Consume()
+	}
 }
 
 // WasClicked returns whether the button was clicked (for compatibility)
@@ -215,10 +284,12 @@ type Checkbox struct {
 
 // NewCheckbox creates a new checkbox
 func NewCheckbox(id string) *Checkbox {
-	return &Checkbox{
+	c := &Checkbox{
 		Node: NewNode(id),
 		checked: false,
 	}
+	c.SetCursorShape(CursorPointer)
+	return c
 }
 
 // SetChecked sets whether the checkbox is checked
@@ -274,7 +345,8 @@ func (c *Checkbox) Draw(surface DrawSurface) {
 }
 
 // HandleMouseDown handles mouse down events
-func (c *Checkbox) HandleMouseDown(x, y int) bool {
+func (c *Checkbox) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
 	bounds := c.ComputedBounds()
 	if PointInRect(Point{x, y}, bounds) {
 		// Toggle checked state
@@ -285,12 +357,11 @@ func (c *Checkbox) HandleMouseDown(x, y int) bool {
 			c.checkedChanged(c.checked)
 		}
 		
-		return true
+		e.Consume()
 	}
-	return false
 }
 
 // HandleMouseMove handles mouse move events
-func (c *Checkbox) HandleMouseMove(x, y int) bool {
-	return false
+func (c *Checkbox) HandleMouseMove(e *Event) {
+	return
 } 
\ No newline at end of file
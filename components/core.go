@@ -68,6 +68,7 @@ const (
 	TextAlignLeft TextAlignment = iota
 	TextAlignCenter
 	TextAlignRight
+	TextAlignJustify // Stretch word gaps so lines fill the width; only used by paragraph layout
 )
 
 // NodeElement extends the base Element interface with DOM-like capabilities
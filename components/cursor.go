@@ -0,0 +1,91 @@
+package components
+
+import (
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// CursorShape is the shape of the mouse cursor
+type CursorShape int
+
+const (
+	CursorDefault    CursorShape = iota // The arrow
+	CursorPointer                       // A hand, over things that can be clicked
+	CursorText                          // An I-beam, over editable text
+	CursorCrosshair                     // For picking a point
+	CursorResizeEW                      // Left-right arrows, over vertical split handles
+	CursorResizeNS                      // Up-down arrows, over horizontal split handles
+	CursorResizeNESW                    // Diagonal arrows, over corner handles
+	CursorResizeNWSE                    // Diagonal arrows, over corner handles
+	CursorMove                          // Four arrows, while dragging
+	CursorNotAllowed                    // Over things that can't be used
+)
+
+// ebitenCursorShapes maps cursor shapes to Ebiten cursor shapes
+var ebitenCursorShapes = map[CursorShape]ebiten.CursorShapeType{
+	CursorDefault:    ebiten.CursorShapeDefault,
+	CursorPointer:    ebiten.CursorShapePointer,
+	CursorText:       ebiten.CursorShapeText,
+	CursorCrosshair:  ebiten.CursorShapeCrosshair,
+	CursorResizeEW:   ebiten.CursorShapeEWResize,
+	CursorResizeNS:   ebiten.CursorShapeNSResize,
+	CursorResizeNESW: ebiten.CursorShapeNESWResize,
+	CursorResizeNWSE: ebiten.CursorShapeNWSEResize,
+	CursorMove:       ebiten.CursorShapeMove,
+	CursorNotAllowed: ebiten.CursorShapeNotAllowed,
+}
+
+// CursorShaper is implemented by elements that ask for a cursor shape while
+// the pointer is over them. Elements whose shape depends on the part under
+// the pointer, such as a split handle, override CursorShapeAt.
+type CursorShaper interface {
+	CursorShapeAt(x, y int) CursorShape
+}
+
+// SetCursorShape sets the cursor shape shown while the pointer is over the
+// element, unless an element inside it asks for another
+func (d *Node) SetCursorShape(shape CursorShape) {
+	d.cursorShape = shape
+}
+
+// CursorShape returns the cursor shape set for the element
+func (d *Node) CursorShape() CursorShape {
+	return d.cursorShape
+}
+
+// CursorShapeAt returns the cursor shape for a point over the element
+func (d *Node) CursorShapeAt(x, y int) CursorShape {
+	return d.cursorShape
+}
+
+// CursorAt returns the cursor shape for a point: the shape asked for by the
+// innermost element under it that asks for one, or the default arrow
+func CursorAt(root Element, x, y int) CursorShape {
+	path := HitPath(root, x, y)
+	for i := len(path) - 1; i >= 0; i-- {
+		if shaper, ok := path[i].(CursorShaper); ok {
+			if shape := shaper.CursorShapeAt(x, y); shape != CursorDefault {
+				return shape
+			}
+		}
+	}
+	return CursorDefault
+}
+
+var (
+	systemCursorMu sync.Mutex
+	systemCursor   CursorShape
+)
+
+// SetSystemCursor changes the window's mouse cursor, if it isn't already
+// the given shape
+func SetSystemCursor(shape CursorShape) {
+	systemCursorMu.Lock()
+	defer systemCursorMu.Unlock()
+	if shape == systemCursor {
+		return
+	}
+	systemCursor = shape
+	ebiten.SetCursorShape(ebitenCursorShapes[shape])
+}
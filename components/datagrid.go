@@ -0,0 +1,986 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CellRenderer draws a single cell's value inside the given bounds
+type CellRenderer func(surface DrawSurface, value interface{}, bounds Rect, selected bool)
+
+// DataGridColumn describes a column of a DataGrid
+type DataGridColumn struct {
+	Title    string
+	Field    string // Struct field the column reads from
+	Width    int
+	Sortable bool
+	Renderer CellRenderer                   // Custom drawing; nil draws Format(value) as text
+	Format   func(value interface{}) string // Custom text, such as NumberFormat(2); nil uses fmt.Sprint
+	Less     func(a, b interface{}) bool    // Custom sort order; nil compares by value type
+}
+
+// DataGridSource supplies rows to a DataGrid on demand. Only the rows being
+// drawn are read, so a source can page rows in from a database or file.
+type DataGridSource interface {
+	RowCount() int
+	Value(row int, field string) interface{}
+}
+
+// sliceSource adapts a bound slice of structs to DataGridSource
+type sliceSource struct {
+	data reflect.Value
+}
+
+// RowCount returns the length of the slice
+func (s *sliceSource) RowCount() int {
+	return s.data.Len()
+}
+
+// Value reads a struct field from the row at the given index
+func (s *sliceSource) Value(row int, field string) interface{} {
+	v := s.data.Index(row)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	f := v.FieldByName(field)
+	if !f.IsValid() || !f.CanInterface() {
+		return nil
+	}
+	return f.Interface()
+}
+
+// Item returns the slice element at the given index
+func (s *sliceSource) Item(row int) interface{} {
+	return s.data.Index(row).Interface()
+}
+
+// DataGrid displays rows of structs as a table with sortable columns and row
+// selection. Rows are read only when drawn, and can optionally be paged.
+type DataGrid struct {
+	*Node
+	columns            []*DataGridColumn
+	source             DataGridSource
+	order              []int // Display position -> data index
+	scrollRow          int   // First visible position within the current page
+	pageSize           int   // Rows per page; 0 disables paging
+	page               int
+	draggingThumb      bool
+	dragOffset         int
+	onPageChanged      func(int)
+	sortColumn         int
+	sortAscending      bool
+	selected           map[int]bool // Selected data indices
+	multiSelect        bool
+	hoveredRow         int // Display position, -1 for none
+	cursorRow          int // Display position moved by the keyboard, -1 for none
+	focused            bool
+	rowHeight          int
+	headerHeight       int
+	fontSize           int
+	onSelectionChanged func([]int)
+	onSort             func(int, bool)
+	onRowClick         func(int)
+	pressedRow         int // Data index of the row pressed, -1 for none
+	headerColor        color.RGBA
+	rowColor           color.RGBA
+	altRowColor        color.RGBA
+	selectedColor      color.RGBA
+	hoverColor         color.RGBA
+	gridColor          color.RGBA
+	textColor          color.RGBA
+}
+
+// NewDataGrid creates a new empty data grid
+func NewDataGrid(id string) *DataGrid {
+	return &DataGrid{
+		Node:          NewNode(id),
+		columns:       make([]*DataGridColumn, 0),
+		order:         make([]int, 0),
+		sortColumn:    -1,
+		sortAscending: true,
+		selected:      make(map[int]bool),
+		hoveredRow:    -1,
+		cursorRow:     -1,
+		pressedRow:    -1,
+		rowHeight:     24,
+		headerHeight:  28,
+		fontSize:      13,
+		headerColor:   color.RGBA{225, 225, 225, 255},
+		rowColor:      color.RGBA{255, 255, 255, 255},
+		altRowColor:   color.RGBA{246, 246, 246, 255},
+		selectedColor: color.RGBA{200, 220, 255, 255},
+		hoverColor:    color.RGBA{235, 242, 255, 255},
+		gridColor:     color.RGBA{210, 210, 210, 255},
+		textColor:     color.RGBA{0, 0, 0, 255},
+	}
+}
+
+// AddColumn adds a sortable column reading the named struct field
+func (g *DataGrid) AddColumn(title string, field string, width int) *DataGridColumn {
+	column := &DataGridColumn{Title: title, Field: field, Width: width, Sortable: true}
+	g.columns = append(g.columns, column)
+	return column
+}
+
+// Columns returns the grid's columns
+func (g *DataGrid) Columns() []*DataGridColumn {
+	return g.columns
+}
+
+// ClearColumns removes all columns
+func (g *DataGrid) ClearColumns() {
+	g.columns = make([]*DataGridColumn, 0)
+	g.sortColumn = -1
+}
+
+// Bind sets the rows to a slice of structs (or pointers to structs). If no
+// columns have been added, one column is created per exported field; a
+// `grid:"Title"` tag renames a column and `grid:"-"` skips the field.
+func (g *DataGrid) Bind(slice interface{}) error {
+	v, elem, err := structSlice("Bind", slice)
+	if err != nil {
+		return err
+	}
+
+	if len(g.columns) == 0 {
+		g.addStructColumns(elem)
+	}
+
+	g.SetSource(&sliceSource{data: v})
+	return nil
+}
+
+// Rebind replaces the bound slice with a new version of it, such as after
+// appending to it, keeping the sort order, page and scroll position.
+// Selections past the end of the new slice are dropped.
+func (g *DataGrid) Rebind(slice interface{}) error {
+	v, _, err := structSlice("Rebind", slice)
+	if err != nil {
+		return err
+	}
+
+	g.source = &sliceSource{data: v}
+	g.Refresh()
+	g.clampScroll()
+	return nil
+}
+
+// structSlice checks that a value is a slice of structs (or pointers to
+// structs), returning it and its struct type
+func structSlice(method string, slice interface{}) (reflect.Value, reflect.Type, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return v, nil, fmt.Errorf("datagrid: %s expects a slice, got %T", method, slice)
+	}
+
+	elem := v.Type().Elem()
+	for elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return v, nil, fmt.Errorf("datagrid: %s expects a slice of structs, got %T", method, slice)
+	}
+	return v, elem, nil
+}
+
+// SetSource sets a custom row source, e.g. one backed by a database query
+func (g *DataGrid) SetSource(source DataGridSource) {
+	g.source = source
+	g.selected = make(map[int]bool)
+	g.scrollRow = 0
+	g.page = 0
+	g.Refresh()
+}
+
+// addStructColumns creates a column for each exported field of a struct type
+func (g *DataGrid) addStructColumns(t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		title := field.Name
+		if tag, ok := field.Tag.Lookup("grid"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag != "" {
+				title = tag
+			}
+		}
+
+		g.AddColumn(title, field.Name, len(title)*g.fontSize/2+60)
+	}
+}
+
+// Refresh re-reads the bound slice, keeping the current sort order.
+// Call it after modifying the slice's contents or length.
+func (g *DataGrid) Refresh() {
+	count := g.RowCount()
+	g.order = make([]int, count)
+	for i := range g.order {
+		g.order[i] = i
+	}
+
+	// Drop selections that no longer exist
+	for index := range g.selected {
+		if index >= count {
+			delete(g.selected, index)
+		}
+	}
+
+	if g.sortColumn >= 0 {
+		g.applySort()
+	}
+	g.clampPage()
+}
+
+// RowCount returns the number of bound rows
+func (g *DataGrid) RowCount() int {
+	if g.source == nil {
+		return 0
+	}
+	return g.source.RowCount()
+}
+
+// Row returns the bound item at the given data index
+func (g *DataGrid) Row(index int) interface{} {
+	if index < 0 || index >= g.RowCount() {
+		return nil
+	}
+	if items, ok := g.source.(interface{ Item(int) interface{} }); ok {
+		return items.Item(index)
+	}
+	return nil
+}
+
+// CellValue returns the value of a column for the row at the given data index
+func (g *DataGrid) CellValue(index int, column int) interface{} {
+	if index < 0 || index >= g.RowCount() || column < 0 || column >= len(g.columns) {
+		return nil
+	}
+
+	return g.source.Value(index, g.columns[column].Field)
+}
+
+// cellText formats a cell value for display
+func (g *DataGrid) cellText(column *DataGridColumn, value interface{}) string {
+	if column.Format != nil {
+		return column.Format(value)
+	}
+	if value == nil {
+		return ""
+	}
+	if t, ok := value.(time.Time); ok {
+		return t.Format("2006-01-02 15:04")
+	}
+	return fmt.Sprint(value)
+}
+
+// SortBy sorts the rows by a column; a negative column restores the bound order
+func (g *DataGrid) SortBy(column int, ascending bool) {
+	if column >= len(g.columns) {
+		return
+	}
+
+	g.sortColumn = column
+	g.sortAscending = ascending
+	if column < 0 {
+		g.Refresh()
+	} else {
+		g.applySort()
+	}
+	g.scrollRow = 0
+
+	if g.onSort != nil {
+		g.onSort(column, ascending)
+	}
+}
+
+// SortColumn returns the sorted column (-1 for none) and direction
+func (g *DataGrid) SortColumn() (int, bool) {
+	return g.sortColumn, g.sortAscending
+}
+
+// applySort orders the display rows by the current sort column
+func (g *DataGrid) applySort() {
+	column := g.columns[g.sortColumn]
+	less := column.Less
+	if less == nil {
+		less = compareValues
+	}
+
+	// Read each sort key once rather than on every comparison
+	keys := make([]interface{}, len(g.order))
+	for i := range keys {
+		keys[i] = g.CellValue(i, g.sortColumn)
+	}
+
+	sort.SliceStable(g.order, func(i, j int) bool {
+		a, b := keys[g.order[i]], keys[g.order[j]]
+		if g.sortAscending {
+			return less(a, b)
+		}
+		return less(b, a)
+	})
+}
+
+// compareValues orders two cell values of the same kind
+func compareValues(a, b interface{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b != nil
+	}
+
+	if ta, ok := a.(time.Time); ok {
+		if tb, ok := b.(time.Time); ok {
+			return ta.Before(tb)
+		}
+	}
+
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case va.CanInt() && vb.CanInt():
+		return va.Int() < vb.Int()
+	case va.CanUint() && vb.CanUint():
+		return va.Uint() < vb.Uint()
+	case va.CanFloat() && vb.CanFloat():
+		return va.Float() < vb.Float()
+	case va.Kind() == reflect.Bool && vb.Kind() == reflect.Bool:
+		return !va.Bool() && vb.Bool()
+	}
+
+	return strings.ToLower(fmt.Sprint(a)) < strings.ToLower(fmt.Sprint(b))
+}
+
+// SetMultiSelect sets whether clicking rows toggles them instead of replacing the selection
+func (g *DataGrid) SetMultiSelect(multi bool) {
+	g.multiSelect = multi
+}
+
+// SelectRow selects the row at the given data index, replacing the selection
+func (g *DataGrid) SelectRow(index int) {
+	if index < 0 || index >= g.RowCount() {
+		return
+	}
+	g.selected = map[int]bool{index: true}
+	g.selectionChanged()
+}
+
+// ToggleRow adds or removes the row at the given data index from the selection
+func (g *DataGrid) ToggleRow(index int) {
+	if index < 0 || index >= g.RowCount() {
+		return
+	}
+	if g.selected[index] {
+		delete(g.selected, index)
+	} else {
+		g.selected[index] = true
+	}
+	g.selectionChanged()
+}
+
+// ClearSelection deselects all rows
+func (g *DataGrid) ClearSelection() {
+	g.selected = make(map[int]bool)
+	g.selectionChanged()
+}
+
+// SelectedRows returns the selected data indices in ascending order
+func (g *DataGrid) SelectedRows() []int {
+	indices := make([]int, 0, len(g.selected))
+	for index := range g.selected {
+		indices = append(indices, index)
+	}
+	sort.Ints(indices)
+	return indices
+}
+
+// SelectedItems returns the selected bound items
+func (g *DataGrid) SelectedItems() []interface{} {
+	items := make([]interface{}, 0, len(g.selected))
+	for _, index := range g.SelectedRows() {
+		items = append(items, g.Row(index))
+	}
+	return items
+}
+
+// IsRowSelected checks if the row at the given data index is selected
+func (g *DataGrid) IsRowSelected(index int) bool {
+	return g.selected[index]
+}
+
+// SetOnSelectionChanged sets the handler for when the selection changes
+func (g *DataGrid) SetOnSelectionChanged(handler func([]int)) {
+	g.onSelectionChanged = handler
+}
+
+// SetOnSort sets the handler for when the sort column or direction changes
+func (g *DataGrid) SetOnSort(handler func(int, bool)) {
+	g.onSort = handler
+}
+
+// SetOnRowClick sets a handler called with the data index of a row that is
+// clicked, once the button is released over it
+func (g *DataGrid) SetOnRowClick(handler func(int)) {
+	g.onRowClick = handler
+}
+
+// SetRowHeight sets the height of each row
+func (g *DataGrid) SetRowHeight(height int) {
+	g.rowHeight = height
+}
+
+// selectionChanged notifies the selection handler
+func (g *DataGrid) selectionChanged() {
+	if g.onSelectionChanged != nil {
+		g.onSelectionChanged(g.SelectedRows())
+	}
+}
+
+// SetPageSize enables paging with the given number of rows per page; 0 shows all rows
+func (g *DataGrid) SetPageSize(size int) {
+	if size < 0 {
+		size = 0
+	}
+	g.pageSize = size
+	g.page = 0
+	g.scrollRow = 0
+}
+
+// PageSize returns the number of rows per page, or 0 if paging is off
+func (g *DataGrid) PageSize() int {
+	return g.pageSize
+}
+
+// PageCount returns the number of pages (1 when paging is off)
+func (g *DataGrid) PageCount() int {
+	if g.pageSize <= 0 || len(g.order) == 0 {
+		return 1
+	}
+	return (len(g.order) + g.pageSize - 1) / g.pageSize
+}
+
+// Page returns the current page index
+func (g *DataGrid) Page() int {
+	return g.page
+}
+
+// SetPage shows the page at the given index
+func (g *DataGrid) SetPage(page int) {
+	if page < 0 {
+		page = 0
+	}
+	if page >= g.PageCount() {
+		page = g.PageCount() - 1
+	}
+	if page == g.page {
+		return
+	}
+
+	g.page = page
+	g.scrollRow = 0
+	if g.onPageChanged != nil {
+		g.onPageChanged(page)
+	}
+}
+
+// NextPage shows the next page, if any
+func (g *DataGrid) NextPage() {
+	g.SetPage(g.page + 1)
+}
+
+// PrevPage shows the previous page, if any
+func (g *DataGrid) PrevPage() {
+	g.SetPage(g.page - 1)
+}
+
+// SetOnPageChanged sets the handler for when the current page changes
+func (g *DataGrid) SetOnPageChanged(handler func(int)) {
+	g.onPageChanged = handler
+}
+
+// clampPage keeps the page and scroll position valid after the rows change
+func (g *DataGrid) clampPage() {
+	if g.page >= g.PageCount() {
+		g.page = g.PageCount() - 1
+	}
+	g.clampScroll()
+}
+
+// pageRange returns the display positions [start, end) on the current page
+func (g *DataGrid) pageRange() (int, int) {
+	if g.pageSize <= 0 {
+		return 0, len(g.order)
+	}
+	start := g.page * g.pageSize
+	end := start + g.pageSize
+	if end > len(g.order) {
+		end = len(g.order)
+	}
+	return start, end
+}
+
+// footerHeight returns the height of the paging bar, or 0 if paging is off
+func (g *DataGrid) footerHeight() int {
+	if g.pageSize <= 0 {
+		return 0
+	}
+	return g.headerHeight
+}
+
+// bodyRect returns the area where rows are drawn
+func (g *DataGrid) bodyRect() Rect {
+	bounds := g.ComputedBounds()
+	return Rect{
+		X:      bounds.X,
+		Y:      bounds.Y + g.headerHeight,
+		Width:  bounds.Width,
+		Height: bounds.Height - g.headerHeight - g.footerHeight(),
+	}
+}
+
+// scaledRowHeight returns the row height, grown to fit the text scale
+func (g *DataGrid) scaledRowHeight() int {
+	return max(g.rowHeight, ScaleTextSize(g.fontSize)+6)
+}
+
+// VisibleRowCount returns how many rows fit in the grid at once
+func (g *DataGrid) VisibleRowCount() int {
+	if g.rowHeight <= 0 {
+		return 0
+	}
+	return g.bodyRect().Height / g.scaledRowHeight()
+}
+
+// FirstVisibleRow returns the display position of the topmost visible row
+func (g *DataGrid) FirstVisibleRow() int {
+	start, _ := g.pageRange()
+	return start + g.scrollRow
+}
+
+// Scroll scrolls the rows by the given amount, clamped to the current page
+func (g *DataGrid) Scroll(rows int) {
+	g.scrollRow += rows
+	g.clampScroll()
+}
+
+// ScrollToRow scrolls (and pages) so the row at the given display position is visible
+func (g *DataGrid) ScrollToRow(position int) {
+	if position < 0 || position >= len(g.order) {
+		return
+	}
+	if g.pageSize > 0 {
+		g.SetPage(position / g.pageSize)
+	}
+
+	start, _ := g.pageRange()
+	relative := position - start
+	if relative < g.scrollRow {
+		g.scrollRow = relative
+	} else if visible := g.VisibleRowCount(); visible > 0 && relative >= g.scrollRow+visible {
+		g.scrollRow = relative - visible + 1
+	}
+	g.clampScroll()
+}
+
+// ScrollOffset returns the first visible row of the page for saving in a session
+func (g *DataGrid) ScrollOffset() Point {
+	return Point{X: 0, Y: g.scrollRow}
+}
+
+// SetScrollOffset restores a saved first visible row
+func (g *DataGrid) SetScrollOffset(offset Point) {
+	g.scrollRow = offset.Y
+	g.clampScroll()
+}
+
+// clampScroll keeps the scroll position inside the current page
+func (g *DataGrid) clampScroll() {
+	start, end := g.pageRange()
+	maxScroll := end - start - g.VisibleRowCount()
+	if g.scrollRow > maxScroll {
+		g.scrollRow = maxScroll
+	}
+	if g.scrollRow < 0 {
+		g.scrollRow = 0
+	}
+}
+
+// scrollbarTrack returns the bounds of the vertical scrollbar track
+func (g *DataGrid) scrollbarTrack() Rect {
+	body := g.bodyRect()
+	return Rect{X: body.X + body.Width - 10, Y: body.Y, Width: 10, Height: body.Height}
+}
+
+// scrollbarThumb returns the bounds of the scrollbar thumb, or an empty rect if everything fits
+func (g *DataGrid) scrollbarThumb() Rect {
+	start, end := g.pageRange()
+	total := end - start
+	visible := g.VisibleRowCount()
+	if total <= visible || total == 0 {
+		return Rect{}
+	}
+
+	track := g.scrollbarTrack()
+	height := track.Height * visible / total
+	if height < 20 {
+		height = 20
+	}
+	y := track.Y + (track.Height-height)*g.scrollRow/(total-visible)
+	return Rect{X: track.X + 1, Y: y, Width: track.Width - 2, Height: height}
+}
+
+// prevPageRect returns the bounds of the previous page button in the footer
+func (g *DataGrid) prevPageRect() Rect {
+	bounds := g.ComputedBounds()
+	return Rect{X: bounds.X + 6, Y: bounds.Y + bounds.Height - g.footerHeight() + 4, Width: 24, Height: g.footerHeight() - 8}
+}
+
+// nextPageRect returns the bounds of the next page button in the footer
+func (g *DataGrid) nextPageRect() Rect {
+	prev := g.prevPageRect()
+	return Rect{X: prev.X + prev.Width + 4, Y: prev.Y, Width: prev.Width, Height: prev.Height}
+}
+
+// columnAt returns the index of the column at the given x position, or -1
+func (g *DataGrid) columnAt(x int) int {
+	bounds := g.ComputedBounds()
+	left := bounds.X
+	for i, column := range g.columns {
+		if x >= left && x < left+column.Width {
+			return i
+		}
+		left += column.Width
+	}
+	return -1
+}
+
+// rowAt returns the display position of the row at the given y position, or -1
+func (g *DataGrid) rowAt(y int) int {
+	body := g.bodyRect()
+	if y < body.Y || y >= body.Y+body.Height {
+		return -1
+	}
+
+	_, end := g.pageRange()
+	position := g.FirstVisibleRow() + (y-body.Y)/g.scaledRowHeight()
+	if position >= end {
+		return -1
+	}
+	return position
+}
+
+// Draw draws the header row and the rows that fit in the grid
+func (g *DataGrid) Draw(surface DrawSurface) {
+	if !g.IsVisible() {
+		return
+	}
+
+	bounds := g.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, g.rowColor)
+	surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
+
+	// Header
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, g.headerHeight, g.headerColor)
+	x := bounds.X
+	for i, column := range g.columns {
+		textY := bounds.Y + (g.headerHeight-g.fontSize)/2
+		surface.DrawText(column.Title, x+6, textY, g.textColor, g.fontSize)
+
+		// Sort direction arrow
+		if i == g.sortColumn {
+			ax := x + column.Width - 14
+			ay := bounds.Y + g.headerHeight/2
+			if g.sortAscending {
+				surface.DrawLine(ax, ay+2, ax+4, ay-2, g.textColor)
+				surface.DrawLine(ax+4, ay-2, ax+8, ay+2, g.textColor)
+			} else {
+				surface.DrawLine(ax, ay-2, ax+4, ay+2, g.textColor)
+				surface.DrawLine(ax+4, ay+2, ax+8, ay-2, g.textColor)
+			}
+		}
+
+		x += column.Width
+		surface.DrawLine(x, bounds.Y, x, bounds.Y+bounds.Height, g.gridColor)
+	}
+	surface.DrawLine(bounds.X, bounds.Y+g.headerHeight, bounds.X+bounds.Width, bounds.Y+g.headerHeight, g.gridColor)
+
+	// Rows; only the visible slice of the current page is read from the source
+	g.clampScroll()
+	body := g.bodyRect()
+	surface.SetClipRect(body.X, body.Y, body.Width, body.Height)
+	_, end := g.pageRange()
+	y := body.Y
+	for position := g.FirstVisibleRow(); position < end && y < body.Y+body.Height; position++ {
+		g.drawRow(surface, position, g.order[position], Rect{X: body.X, Y: y, Width: body.Width, Height: g.scaledRowHeight()})
+		y += g.scaledRowHeight()
+	}
+
+	// Scrollbar
+	if thumb := g.scrollbarThumb(); thumb.Height > 0 {
+		track := g.scrollbarTrack()
+		surface.FillRect(track.X, track.Y, track.Width, track.Height, g.altRowColor)
+		surface.FillRect(thumb.X, thumb.Y, thumb.Width, thumb.Height, color.RGBA{180, 180, 180, 255})
+	}
+	surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
+
+	if g.pageSize > 0 {
+		g.drawFooter(surface, bounds)
+	}
+
+	surface.ResetClipRect()
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, color.RGBA{180, 180, 180, 255})
+}
+
+// drawFooter draws the paging bar with previous/next buttons and the page number
+func (g *DataGrid) drawFooter(surface DrawSurface, bounds Rect) {
+	top := bounds.Y + bounds.Height - g.footerHeight()
+	surface.FillRect(bounds.X, top, bounds.Width, g.footerHeight(), g.headerColor)
+	surface.DrawLine(bounds.X, top, bounds.X+bounds.Width, top, g.gridColor)
+
+	for i, r := range []Rect{g.prevPageRect(), g.nextPageRect()} {
+		enabled := (i == 0 && g.page > 0) || (i == 1 && g.page < g.PageCount()-1)
+		arrowColor := g.textColor
+		if !enabled {
+			arrowColor = g.gridColor
+		}
+		surface.FillRect(r.X, r.Y, r.Width, r.Height, g.rowColor)
+		surface.DrawRect(r.X, r.Y, r.Width, r.Height, g.gridColor)
+
+		cx, cy := r.X+r.Width/2, r.Y+r.Height/2
+		if i == 0 {
+			surface.DrawLine(cx+2, cy-4, cx-2, cy, arrowColor)
+			surface.DrawLine(cx-2, cy, cx+2, cy+4, arrowColor)
+		} else {
+			surface.DrawLine(cx-2, cy-4, cx+2, cy, arrowColor)
+			surface.DrawLine(cx+2, cy, cx-2, cy+4, arrowColor)
+		}
+	}
+
+	label := fmt.Sprintf("Page %d of %d (%d rows)", g.page+1, g.PageCount(), len(g.order))
+	next := g.nextPageRect()
+	surface.DrawText(label, next.X+next.Width+10, top+(g.footerHeight()-g.fontSize)/2, g.textColor, g.fontSize)
+}
+
+// drawRow draws the cells of one row
+func (g *DataGrid) drawRow(surface DrawSurface, position int, index int, rowRect Rect) {
+	selected := g.selected[index]
+
+	bg := g.rowColor
+	if selected {
+		bg = g.selectedColor
+	} else if position == g.hoveredRow {
+		bg = g.hoverColor
+	} else if position%2 == 1 {
+		bg = g.altRowColor
+	}
+	surface.FillRect(rowRect.X, rowRect.Y, rowRect.Width, rowRect.Height, bg)
+
+	x := rowRect.X
+	for i, column := range g.columns {
+		cell := Rect{X: x, Y: rowRect.Y, Width: column.Width, Height: rowRect.Height}
+		value := g.CellValue(index, i)
+		if column.Renderer != nil {
+			column.Renderer(surface, value, cell, selected)
+		} else {
+			textY := cell.Y + (cell.Height-g.fontSize)/2
+			surface.DrawText(g.cellText(column, value), cell.X+6, textY, g.textColor, g.fontSize)
+		}
+		x += column.Width
+	}
+
+	surface.DrawLine(rowRect.X, rowRect.Y+rowRect.Height, rowRect.X+rowRect.Width, rowRect.Y+rowRect.Height, g.gridColor)
+
+	if g.focused && position == g.cursorRow {
+		surface.DrawRect(rowRect.X+1, rowRect.Y+1, rowRect.Width-2, rowRect.Height-2, g.textColor)
+	}
+}
+
+// HandleMouseDown sorts on header clicks and selects rows on row clicks
+func (g *DataGrid) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !g.IsVisible() {
+		return
+	}
+
+	bounds := g.ComputedBounds()
+	if !PointInRect(Point{x, y}, bounds) {
+		g.focused = false
+		return
+	}
+	g.focused = true
+
+	// Paging buttons
+	if g.pageSize > 0 && y >= bounds.Y+bounds.Height-g.footerHeight() {
+		p := Point{x, y}
+		if PointInRect(p, g.prevPageRect()) {
+			g.PrevPage()
+		} else if PointInRect(p, g.nextPageRect()) {
+			g.NextPage()
+		}
+		e.Consume()
+		return
+	}
+
+	// Scrollbar: drag the thumb, or page up/down by clicking the track
+	if thumb := g.scrollbarThumb(); thumb.Height > 0 && PointInRect(Point{x, y}, g.scrollbarTrack()) {
+		if y >= thumb.Y && y < thumb.Y+thumb.Height {
+			g.draggingThumb = true
+			g.dragOffset = y - thumb.Y
+		} else if y < thumb.Y {
+			g.Scroll(-g.VisibleRowCount())
+		} else {
+			g.Scroll(g.VisibleRowCount())
+		}
+		e.Consume()
+		return
+	}
+
+	// Header click sorts, toggling direction on the sorted column
+	if y < bounds.Y+g.headerHeight {
+		column := g.columnAt(x)
+		if column >= 0 && g.columns[column].Sortable {
+			ascending := true
+			if column == g.sortColumn {
+				ascending = !g.sortAscending
+			}
+			g.SortBy(column, ascending)
+		}
+		e.Consume()
+		return
+	}
+
+	if position := g.rowAt(y); position >= 0 {
+		if g.pressedRow == g.order[position] {
+			e.Consume()
+			return
+		}
+		g.pressedRow = g.order[position]
+		g.cursorRow = position
+		if g.multiSelect {
+			g.ToggleRow(g.order[position])
+		} else {
+			g.SelectRow(g.order[position])
+		}
+	}
+	e.Consume()
+}
+
+// HandleMouseUp ends a scrollbar drag, or clicks the row pressed if the
+// button is released over it; otherwise the grid acts on mouse down
+func (g *DataGrid) HandleMouseUp(e *Event) {
+	y := e.Y
+	if g.draggingThumb {
+		g.draggingThumb = false
+		e.Consume()
+		return
+	}
+	if g.pressedRow >= 0 {
+		pressed := g.pressedRow
+		g.pressedRow = -1
+		position := g.rowAt(y)
+		if position >= 0 && g.order[position] == pressed && g.onRowClick != nil {
+			g.onRowClick(pressed)
+		}
+		e.Consume()
+	}
+}
+
+// Focus gives the grid keyboard focus
+func (g *DataGrid) Focus() {
+	g.focused = true
+}
+
+// Blur removes keyboard focus from the grid
+func (g *DataGrid) Blur() {
+	g.focused = false
+}
+
+// IsFocused returns whether the grid receives navigation keys
+func (g *DataGrid) IsFocused() bool {
+	return g.focused
+}
+
+// HandleKeyDown moves the selection with Up/Down/PageUp/PageDown/Home/End
+// while the grid is focused. Space toggles the row under the cursor when
+// multi-select is on.
+func (g *DataGrid) HandleKeyDown(event InputEvent) bool {
+	if !g.focused || !g.IsVisible() || event.Type != InputTypeKeyDown {
+		return false
+	}
+
+	if event.Key == KeySpace && g.multiSelect && g.cursorRow >= 0 && g.cursorRow < len(g.order) {
+		g.ToggleRow(g.order[g.cursorRow])
+		return true
+	}
+
+	position, ok := NavigateList(event, g.cursorRow, len(g.order), g.VisibleRowCount())
+	if !ok {
+		return false
+	}
+
+	g.cursorRow = position
+	g.ScrollToRow(position)
+	if !g.multiSelect {
+		g.SelectRow(g.order[position])
+	}
+	return true
+}
+
+// HandleScroll scrolls the rows with the mouse wheel
+func (g *DataGrid) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !g.IsVisible() || !PointInRect(Point{x, y}, g.ComputedBounds()) {
+		return false
+	}
+
+	rows := int(-deltaY * 3)
+	if rows == 0 && deltaY != 0 {
+		rows = 1
+		if deltaY > 0 {
+			rows = -1
+		}
+	}
+	g.Scroll(rows)
+	return true
+}
+
+// HandleMouseMove tracks the hovered row
+func (g *DataGrid) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !g.IsVisible() {
+		return
+	}
+
+	if g.draggingThumb {
+		track := g.scrollbarTrack()
+		thumb := g.scrollbarThumb()
+		start, end := g.pageRange()
+		if span := track.Height - thumb.Height; span > 0 {
+			maxScroll := end - start - g.VisibleRowCount()
+			g.scrollRow = (y - g.dragOffset - track.Y) * maxScroll / span
+			g.clampScroll()
+		}
+		e.Consume()
+		return
+	}
+
+	if !PointInRect(Point{x, y}, g.ComputedBounds()) {
+		g.hoveredRow = -1
+		return
+	}
+
+	g.hoveredRow = g.rowAt(y)
+	e.Consume()
+}
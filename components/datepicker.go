@@ -0,0 +1,490 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+)
+
+// weekStartByRegion lists regions whose week does not start on Monday
+var weekStartByRegion = map[string]time.Weekday{
+	"US": time.Sunday, "CA": time.Sunday, "MX": time.Sunday, "BR": time.Sunday,
+	"JP": time.Sunday, "KR": time.Sunday, "TW": time.Sunday, "IL": time.Sunday,
+	"IN": time.Sunday, "PH": time.Sunday, "ZA": time.Sunday, "SA": time.Sunday,
+	"AE": time.Saturday, "EG": time.Saturday, "IR": time.Saturday, "AF": time.Saturday,
+}
+
+// WeekStartForLocale returns the first day of the week for a locale such as
+// "en-US" or "de_DE". Locales without a region, or unknown regions, start on Monday.
+func WeekStartForLocale(locale string) time.Weekday {
+	locale = strings.ReplaceAll(locale, "_", "-")
+	parts := strings.Split(locale, "-")
+	if len(parts) < 2 {
+		if strings.EqualFold(locale, "en") {
+			return time.Sunday
+		}
+		return time.Monday
+	}
+	if day, ok := weekStartByRegion[strings.ToUpper(parts[len(parts)-1])]; ok {
+		return day
+	}
+	return time.Monday
+}
+
+// dateOnly strips the time of day from t
+func dateOnly(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// DatePicker is a date field that opens a month calendar for picking a day
+type DatePicker struct {
+	*Node
+	value        time.Time
+	hasValue     bool
+	minDate      time.Time // Zero for no minimum
+	maxDate      time.Time // Zero for no maximum
+	weekStart    time.Weekday
+	format       string
+	placeholder  string
+	month        time.Time // First day of the month shown in the calendar
+	cursor       time.Time // Day highlighted by keyboard navigation
+	hoveredDay   time.Time
+	open         bool
+	focused      bool
+	cellSize     int
+	fontSize     int
+	onChange     func(time.Time)
+	fieldColor   color.RGBA
+	popupColor   color.RGBA
+	borderColor  color.RGBA
+	textColor    color.RGBA
+	mutedColor   color.RGBA
+	selectColor  color.RGBA
+	hoverColor   color.RGBA
+	disableColor color.RGBA
+}
+
+// NewDatePicker creates a new date picker with no value
+func NewDatePicker(id string) *DatePicker {
+	today := dateOnly(Now())
+	return &DatePicker{
+		Node:         NewNode(id),
+		weekStart:    time.Monday,
+		format:       "2006-01-02",
+		placeholder:  "Select date...",
+		month:        time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location()),
+		cursor:       today,
+		cellSize:     28,
+		fontSize:     13,
+		fieldColor:   color.RGBA{255, 255, 255, 255},
+		popupColor:   color.RGBA{255, 255, 255, 255},
+		borderColor:  color.RGBA{100, 100, 100, 255},
+		textColor:    color.RGBA{0, 0, 0, 255},
+		mutedColor:   color.RGBA{150, 150, 150, 255},
+		selectColor:  color.RGBA{70, 130, 180, 255},
+		hoverColor:   color.RGBA{220, 230, 245, 255},
+		disableColor: color.RGBA{200, 200, 200, 255},
+	}
+}
+
+// SetValue sets the selected date; the time of day is ignored
+func (d *DatePicker) SetValue(t time.Time) {
+	t = dateOnly(t)
+	if !d.IsSelectable(t) {
+		return
+	}
+
+	changed := !d.hasValue || !d.value.Equal(t)
+	d.value = t
+	d.hasValue = true
+	d.cursor = t
+	d.ShowMonth(t.Year(), t.Month())
+	if changed && d.onChange != nil {
+		d.onChange(t)
+	}
+}
+
+// Value returns the selected date, or the zero time if none is selected
+func (d *DatePicker) Value() time.Time {
+	if !d.hasValue {
+		return time.Time{}
+	}
+	return d.value
+}
+
+// HasValue returns whether a date is selected
+func (d *DatePicker) HasValue() bool {
+	return d.hasValue
+}
+
+// ClearValue removes the selected date
+func (d *DatePicker) ClearValue() {
+	d.hasValue = false
+	d.value = time.Time{}
+}
+
+// SetMinDate sets the earliest selectable date; the zero time removes the limit
+func (d *DatePicker) SetMinDate(t time.Time) {
+	if !t.IsZero() {
+		t = dateOnly(t)
+	}
+	d.minDate = t
+}
+
+// MinDate returns the earliest selectable date, or the zero time if unlimited
+func (d *DatePicker) MinDate() time.Time {
+	return d.minDate
+}
+
+// SetMaxDate sets the latest selectable date; the zero time removes the limit
+func (d *DatePicker) SetMaxDate(t time.Time) {
+	if !t.IsZero() {
+		t = dateOnly(t)
+	}
+	d.maxDate = t
+}
+
+// MaxDate returns the latest selectable date, or the zero time if unlimited
+func (d *DatePicker) MaxDate() time.Time {
+	return d.maxDate
+}
+
+// IsSelectable returns whether the date is within the min and max dates
+func (d *DatePicker) IsSelectable(t time.Time) bool {
+	t = dateOnly(t)
+	if !d.minDate.IsZero() && t.Before(d.minDate) {
+		return false
+	}
+	if !d.maxDate.IsZero() && t.After(d.maxDate) {
+		return false
+	}
+	return true
+}
+
+// SetWeekStart sets the first day of the week shown in the calendar
+func (d *DatePicker) SetWeekStart(day time.Weekday) {
+	d.weekStart = day
+}
+
+// WeekStart returns the first day of the week shown in the calendar
+func (d *DatePicker) WeekStart() time.Weekday {
+	return d.weekStart
+}
+
+// SetLocale sets the week start from a locale such as "en-US"
+func (d *DatePicker) SetLocale(locale string) {
+	d.weekStart = WeekStartForLocale(locale)
+}
+
+// SetFormat sets the time layout used to display the value in the field
+func (d *DatePicker) SetFormat(layout string) {
+	d.format = layout
+}
+
+// SetPlaceholder sets the text shown when no date is selected
+func (d *DatePicker) SetPlaceholder(placeholder string) {
+	d.placeholder = placeholder
+}
+
+// SetOnChange sets the handler for when the selected date changes
+func (d *DatePicker) SetOnChange(handler func(time.Time)) {
+	d.onChange = handler
+}
+
+// ShowMonth shows the given month in the calendar
+func (d *DatePicker) ShowMonth(year int, month time.Month) {
+	d.month = time.Date(year, month, 1, 0, 0, 0, 0, d.month.Location())
+}
+
+// DisplayedMonth returns the first day of the month shown in the calendar
+func (d *DatePicker) DisplayedMonth() time.Time {
+	return d.month
+}
+
+// NextMonth shows the following month
+func (d *DatePicker) NextMonth() {
+	d.month = d.month.AddDate(0, 1, 0)
+}
+
+// PrevMonth shows the previous month
+func (d *DatePicker) PrevMonth() {
+	d.month = d.month.AddDate(0, -1, 0)
+}
+
+// Open shows the calendar at the selected date's month
+func (d *DatePicker) Open() {
+	if d.hasValue {
+		d.cursor = d.value
+		d.ShowMonth(d.value.Year(), d.value.Month())
+	}
+	d.open = true
+}
+
+// Close hides the calendar
+func (d *DatePicker) Close() {
+	d.open = false
+}
+
+// IsOpen returns whether the calendar is showing
+func (d *DatePicker) IsOpen() bool {
+	return d.open
+}
+
+// Focus gives the date picker keyboard focus
+func (d *DatePicker) Focus() {
+	d.focused = true
+}
+
+// Blur removes keyboard focus and closes the calendar
+func (d *DatePicker) Blur() {
+	d.focused = false
+	d.open = false
+}
+
+// IsFocused returns whether the date picker receives keyboard input
+func (d *DatePicker) IsFocused() bool {
+	return d.focused
+}
+
+// gridStart returns the first day shown in the calendar grid
+func (d *DatePicker) gridStart() time.Time {
+	offset := (int(d.month.Weekday()) - int(d.weekStart) + 7) % 7
+	return d.month.AddDate(0, 0, -offset)
+}
+
+// popupRect returns the bounds of the calendar popup
+func (d *DatePicker) popupRect() Rect {
+	bounds := d.ComputedBounds()
+	return Rect{
+		X:      bounds.X,
+		Y:      bounds.Y + bounds.Height,
+		Width:  7*d.cellSize + 8,
+		Height: 2*d.cellSize + 6*d.cellSize + 8,
+	}
+}
+
+// dayRect returns the bounds of the calendar cell at the given row and column
+func (d *DatePicker) dayRect(row, col int) Rect {
+	popup := d.popupRect()
+	return Rect{
+		X:      popup.X + 4 + col*d.cellSize,
+		Y:      popup.Y + 4 + 2*d.cellSize + row*d.cellSize,
+		Width:  d.cellSize,
+		Height: d.cellSize,
+	}
+}
+
+// dayAt returns the day under the given point in the calendar grid
+func (d *DatePicker) dayAt(x, y int) (time.Time, bool) {
+	first := d.dayRect(0, 0)
+	if x < first.X || y < first.Y {
+		return time.Time{}, false
+	}
+	col := (x - first.X) / d.cellSize
+	row := (y - first.Y) / d.cellSize
+	if col > 6 || row > 5 {
+		return time.Time{}, false
+	}
+	return d.gridStart().AddDate(0, 0, row*7+col), true
+}
+
+// Draw draws the field and, when open, the calendar
+func (d *DatePicker) Draw(surface DrawSurface) {
+	if !d.IsVisible() {
+		return
+	}
+
+	bounds := d.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, d.fieldColor)
+	border := d.borderColor
+	if d.focused {
+		border = d.selectColor
+	}
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, border)
+
+	textY := bounds.Y + (bounds.Height-d.fontSize)/2
+	if d.hasValue {
+		surface.DrawText(d.value.Format(d.format), bounds.X+5, textY, d.textColor, d.fontSize)
+	} else {
+		surface.DrawText(d.placeholder, bounds.X+5, textY, d.mutedColor, d.fontSize)
+	}
+
+	// Calendar icon
+	ix := bounds.X + bounds.Width - 22
+	iy := bounds.Y + bounds.Height/2 - 7
+	surface.DrawRect(ix, iy, 14, 14, d.textColor)
+	surface.FillRect(ix, iy, 14, 4, d.textColor)
+
+	if d.open {
+		d.drawCalendar(surface)
+	}
+}
+
+// drawCalendar draws the month header, weekday labels and day grid
+func (d *DatePicker) drawCalendar(surface DrawSurface) {
+	popup := d.popupRect()
+	surface.FillRect(popup.X, popup.Y, popup.Width, popup.Height, d.popupColor)
+	surface.DrawRect(popup.X, popup.Y, popup.Width, popup.Height, d.borderColor)
+
+	// Month header with previous/next arrows
+	headerY := popup.Y + 4 + (d.cellSize-d.fontSize)/2
+	title := d.month.Format("January 2006")
+	surface.DrawText(title, popup.X+(popup.Width-len(title)*d.fontSize/2)/2, headerY, d.textColor, d.fontSize)
+	surface.DrawText("<", popup.X+10, headerY, d.textColor, d.fontSize)
+	surface.DrawText(">", popup.X+popup.Width-18, headerY, d.textColor, d.fontSize)
+
+	// Weekday labels, starting from the configured week start
+	for col := 0; col < 7; col++ {
+		day := time.Weekday((int(d.weekStart) + col) % 7)
+		r := d.dayRect(0, col)
+		surface.DrawText(day.String()[:2], r.X+7, popup.Y+4+d.cellSize+(d.cellSize-d.fontSize)/2, d.mutedColor, d.fontSize)
+	}
+
+	today := dateOnly(Now())
+	start := d.gridStart()
+	for i := 0; i < 42; i++ {
+		day := start.AddDate(0, 0, i)
+		r := d.dayRect(i/7, i%7)
+
+		textColor := d.textColor
+		switch {
+		case !d.IsSelectable(day):
+			textColor = d.disableColor
+		case day.Month() != d.month.Month():
+			textColor = d.mutedColor
+		}
+
+		if d.hasValue && day.Equal(d.value) {
+			surface.FillRect(r.X+1, r.Y+1, r.Width-2, r.Height-2, d.selectColor)
+			textColor = color.RGBA{255, 255, 255, 255}
+		} else if day.Equal(d.hoveredDay) && d.IsSelectable(day) {
+			surface.FillRect(r.X+1, r.Y+1, r.Width-2, r.Height-2, d.hoverColor)
+		}
+		if d.focused && day.Equal(d.cursor) {
+			surface.DrawRect(r.X+1, r.Y+1, r.Width-2, r.Height-2, d.selectColor)
+		}
+		if day.Equal(today) {
+			surface.DrawLine(r.X+8, r.Y+r.Height-5, r.X+r.Width-8, r.Y+r.Height-5, textColor)
+		}
+
+		label := fmt.Sprintf("%d", day.Day())
+		surface.DrawText(label, r.X+(r.Width-len(label)*d.fontSize/2)/2, r.Y+(r.Height-d.fontSize)/2, textColor, d.fontSize)
+	}
+}
+
+// HandleMouseDown opens the calendar from the field and picks days or changes months in it
+func (d *DatePicker) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !d.IsVisible() {
+		return
+	}
+
+	p := Point{x, y}
+	if d.open && PointInRect(p, d.popupRect()) {
+		popup := d.popupRect()
+		if y < popup.Y+4+d.cellSize {
+			if x < popup.X+popup.Width/3 {
+				d.PrevMonth()
+			} else if x > popup.X+popup.Width*2/3 {
+				d.NextMonth()
+			}
+			e.Consume()
+			return
+		}
+		if day, ok := d.dayAt(x, y); ok && d.IsSelectable(day) {
+			d.SetValue(day)
+			d.open = false
+		}
+		e.Consume()
+		return
+	}
+
+	if PointInRect(p, d.ComputedBounds()) {
+		d.focused = true
+		if d.open {
+			d.Close()
+		} else {
+			d.Open()
+		}
+		e.Consume()
+		return
+	}
+
+	d.focused = false
+	d.open = false
+}
+
+// HandleMouseUp is a no-op; the date picker acts on mouse down
+func (d *DatePicker) HandleMouseUp(e *Event) {
+	return
+}
+
+// HandleMouseMove tracks the hovered day
+func (d *DatePicker) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	d.hoveredDay = time.Time{}
+	if !d.open || !PointInRect(Point{x, y}, d.popupRect()) {
+		return
+	}
+	if day, ok := d.dayAt(x, y); ok {
+		d.hoveredDay = day
+	}
+	e.Consume()
+}
+
+// HandleKeyDown navigates the calendar while focused: arrows move by day or
+// week, PageUp/PageDown by month, Home/End to the start or end of the week,
+// Enter picks the highlighted day and Esc closes the calendar
+func (d *DatePicker) HandleKeyDown(event InputEvent) bool {
+	if !d.focused || !d.IsVisible() || event.Type != InputTypeKeyDown {
+		return false
+	}
+
+	if !d.open {
+		switch event.Key.Base() {
+		case KeyEnter, KeySpace, KeyDown:
+			d.Open()
+			return true
+		}
+		return false
+	}
+
+	cursor := d.cursor
+	switch event.Key.Base() {
+	case KeyLeft:
+		cursor = cursor.AddDate(0, 0, -1)
+	case KeyRight:
+		cursor = cursor.AddDate(0, 0, 1)
+	case KeyUp:
+		cursor = cursor.AddDate(0, 0, -7)
+	case KeyDown:
+		cursor = cursor.AddDate(0, 0, 7)
+	case KeyPageUp:
+		cursor = cursor.AddDate(0, -1, 0)
+	case KeyPageDown:
+		cursor = cursor.AddDate(0, 1, 0)
+	case KeyHome:
+		cursor = cursor.AddDate(0, 0, -((int(cursor.Weekday()) - int(d.weekStart) + 7) % 7))
+	case KeyEnd:
+		cursor = cursor.AddDate(0, 0, 6-(int(cursor.Weekday())-int(d.weekStart)+7)%7)
+	case KeyEnter, KeySpace:
+		if d.IsSelectable(d.cursor) {
+			d.SetValue(d.cursor)
+			d.open = false
+		}
+		return true
+	case KeyEscape:
+		d.open = false
+		return true
+	default:
+		return false
+	}
+
+	if d.IsSelectable(cursor) {
+		d.cursor = cursor
+		d.ShowMonth(cursor.Year(), cursor.Month())
+	}
+	return true
+}
@@ -0,0 +1,284 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DesignRules is the design system DesignLint checks elements against
+type DesignRules struct {
+	Colors    []color.RGBA // Allowed besides the theme's colors
+	FontSizes []int        // The type scale; empty allows any size
+	Grid      int          // Spacing must be a multiple of this; 0 allows any
+	Radii     []int        // Allowed corner radii; empty allows any
+}
+
+// DefaultDesignRules returns a 4px grid, a common type scale and a few
+// corner radii
+func DefaultDesignRules() DesignRules {
+	return DesignRules{
+		FontSizes: []int{12, 14, 16, 18, 20, 24, 32},
+		Grid:      4,
+		Radii:     []int{0, 2, 4, 8},
+	}
+}
+
+// LintRule is the kind of problem a lint issue reports
+type LintRule string
+
+const (
+	LintColor   LintRule = "color"
+	LintFont    LintRule = "font"
+	LintSpacing LintRule = "spacing"
+	LintRadius  LintRule = "radius"
+)
+
+// LintIssue is a style value on an element that breaks the design rules
+type LintIssue struct {
+	Element  Element
+	Rule     LintRule
+	Property string // e.g. "background color" or "padding left"
+	Message  string
+}
+
+// String returns the issue with the element it is on
+func (i LintIssue) String() string {
+	return fmt.Sprintf("%s (%T): %s", i.Element.ID(), i.Element, i.Message)
+}
+
+// Style getters DesignLint looks for; elements without them aren't checked
+// for that property
+type (
+	lintBackground interface{ BackgroundColor() color.RGBA }
+	lintTextColor  interface{ TextColor() color.RGBA }
+	lintFontSize   interface{ FontSize() int }
+	lintSpacing    interface{ Spacing() int }
+	lintRadius     interface{ CornerRadius() int }
+)
+
+// DesignLint checks the tree under root for colors that aren't the theme's,
+// font sizes off the type scale, spacing off the grid and corner radii not
+// in the rules, returning the issues in tree order
+func DesignLint(root Element, theme Theme, rules DesignRules) []LintIssue {
+	l := designLinter{theme: theme, rules: rules}
+	l.walk(root)
+	return l.issues
+}
+
+// designLinter collects issues while walking a tree
+type designLinter struct {
+	theme  Theme
+	rules  DesignRules
+	issues []LintIssue
+}
+
+// walk checks an element and its descendants
+func (l *designLinter) walk(element Element) {
+	if _, ok := element.(*DesignLintOverlay); ok {
+		return
+	}
+	l.check(element)
+	for _, child := range element.Children() {
+		l.walk(child)
+	}
+}
+
+// check checks one element's style values
+func (l *designLinter) check(element Element) {
+	if e, ok := element.(lintBackground); ok {
+		l.checkColor(element, "background color", e.BackgroundColor())
+	}
+	if e, ok := element.(lintTextColor); ok {
+		l.checkColor(element, "text color", e.TextColor())
+	}
+	if e, ok := element.(lintFontSize); ok {
+		if size := e.FontSize(); len(l.rules.FontSizes) > 0 && !slices.Contains(l.rules.FontSizes, size) {
+			l.report(element, LintFont, "font size", fmt.Sprintf("font size %d is not on the type scale %v", size, l.rules.FontSizes))
+		}
+	}
+	if e, ok := element.(lintSpacing); ok {
+		l.checkSpacing(element, "item spacing", e.Spacing())
+	}
+	if e, ok := element.(NodeElement); ok {
+		box := e.GetBoxModel()
+		l.checkSpacing(element, "margin top", box.Margin.Top)
+		l.checkSpacing(element, "margin right", box.Margin.Right)
+		l.checkSpacing(element, "margin bottom", box.Margin.Bottom)
+		l.checkSpacing(element, "margin left", box.Margin.Left)
+		l.checkSpacing(element, "padding top", box.Padding.Top)
+		l.checkSpacing(element, "padding right", box.Padding.Right)
+		l.checkSpacing(element, "padding bottom", box.Padding.Bottom)
+		l.checkSpacing(element, "padding left", box.Padding.Left)
+	}
+	if e, ok := element.(lintRadius); ok {
+		if radius := e.CornerRadius(); len(l.rules.Radii) > 0 && !slices.Contains(l.rules.Radii, radius) {
+			l.report(element, LintRadius, "corner radius", fmt.Sprintf("corner radius %d is not one of %v", radius, l.rules.Radii))
+		}
+	}
+}
+
+// checkColor reports a color that is neither the theme's nor allowed by the
+// rules. Transparent colors and the theme's colors at another opacity pass.
+func (l *designLinter) checkColor(element Element, property string, c color.RGBA) {
+	if c.A == 0 {
+		return
+	}
+	allowed := append([]color.RGBA{
+		l.theme.Background, l.theme.Surface, l.theme.Text,
+		l.theme.MutedText, l.theme.Accent, l.theme.Border, l.theme.Error,
+	}, l.rules.Colors...)
+	for _, a := range allowed {
+		if a.R == c.R && a.G == c.G && a.B == c.B {
+			return
+		}
+	}
+	l.report(element, LintColor, property, fmt.Sprintf("%s #%02x%02x%02x is not a theme color", property, c.R, c.G, c.B))
+}
+
+// checkSpacing reports a spacing value off the grid
+func (l *designLinter) checkSpacing(element Element, property string, value int) {
+	if l.rules.Grid > 0 && value%l.rules.Grid != 0 {
+		l.report(element, LintSpacing, property, fmt.Sprintf("%s %d is not a multiple of %d", property, value, l.rules.Grid))
+	}
+}
+
+// report adds an issue
+func (l *designLinter) report(element Element, rule LintRule, property, message string) {
+	l.issues = append(l.issues, LintIssue{Element: element, Rule: rule, Property: property, Message: message})
+}
+
+// FormatLintReport lists issues grouped by element, one element per
+// paragraph
+func FormatLintReport(issues []LintIssue) string {
+	var b strings.Builder
+	var last Element
+	for _, issue := range issues {
+		if issue.Element != last {
+			if last != nil {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "%s (%T)\n", issue.Element.ID(), issue.Element)
+			last = issue.Element
+		}
+		fmt.Fprintf(&b, "  %s: %s\n", issue.Rule, issue.Message)
+	}
+	return b.String()
+}
+
+// DesignLintOverlay outlines elements that break the design rules, listing
+// their issues while hovered. It is added above the rest of the UI, ignores
+// clicks, and checks the tree again every second as the UI changes.
+type DesignLintOverlay struct {
+	*Node
+	root    Element
+	theme   Theme
+	rules   DesignRules
+	issues  []LintIssue
+	checked time.Time
+	mouse   Point
+}
+
+// NewDesignLintOverlay creates an overlay checking the tree under root
+func NewDesignLintOverlay(id string, root Element, theme Theme, rules DesignRules) *DesignLintOverlay {
+	o := &DesignLintOverlay{Node: NewNode(id), root: root, theme: theme, rules: rules}
+	o.SetPositionType(PositionFixed)
+	o.Node.SetBounds(Rect{X: 0, Y: 0, Width: ScreenWidth, Height: ScreenHeight})
+	return o
+}
+
+// SetTheme sets the theme whose colors are allowed
+func (o *DesignLintOverlay) SetTheme(theme Theme) {
+	o.theme = theme
+	o.checked = time.Time{}
+}
+
+// SetRules sets the design rules
+func (o *DesignLintOverlay) SetRules(rules DesignRules) {
+	o.rules = rules
+	o.checked = time.Time{}
+}
+
+// Issues returns the issues found by the last check
+func (o *DesignLintOverlay) Issues() []LintIssue {
+	return o.issues
+}
+
+// Draw outlines the elements with issues and lists the hovered one's
+func (o *DesignLintOverlay) Draw(surface DrawSurface) {
+	if !o.IsVisible() {
+		return
+	}
+	if o.checked.IsZero() || since(o.checked) >= time.Second {
+		o.issues = DesignLint(o.root, o.theme, o.rules)
+		o.checked = Now()
+	}
+
+	outline := color.RGBA{230, 60, 60, 255}
+	var hovered Element
+	var hoveredBounds Rect
+	for i, issue := range o.issues {
+		if i > 0 && o.issues[i-1].Element == issue.Element {
+			continue
+		}
+		node, ok := issue.Element.(NodeElement)
+		if !ok {
+			continue
+		}
+		if v, ok := node.(interface{ IsVisible() bool }); ok && !v.IsVisible() {
+			continue
+		}
+		bounds := node.ComputedBounds()
+		surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, outline)
+		// The innermost element under the mouse comes last in tree order
+		if PointInRect(o.mouse, bounds) {
+			hovered, hoveredBounds = issue.Element, bounds
+		}
+	}
+	if hovered == nil {
+		return
+	}
+
+	// List the hovered element's issues below it, or above near the bottom
+	lines := []string{fmt.Sprintf("%s (%T)", hovered.ID(), hovered)}
+	for _, issue := range o.issues {
+		if issue.Element == hovered {
+			lines = append(lines, issue.Message)
+		}
+	}
+	width := 0
+	for _, line := range lines {
+		width = max(width, MeasureText(line))
+	}
+	width += 16
+	height := len(lines)*18 + 8
+	x := min(hoveredBounds.X, ScreenWidth-width)
+	y := hoveredBounds.Y + hoveredBounds.Height + 4
+	if y+height > ScreenHeight {
+		y = max(0, hoveredBounds.Y-height-4)
+	}
+	surface.FillRect(x, y, width, height, color.RGBA{40, 20, 20, 235})
+	surface.DrawRect(x, y, width, height, outline)
+	for i, line := range lines {
+		surface.DrawText(line, x+8, y+4+i*18, color.RGBA{255, 235, 235, 255}, 14)
+	}
+}
+
+// HandleMouseDown lets clicks through
+func (o *DesignLintOverlay) HandleMouseDown(e *Event) {
+	return
+}
+
+// HandleMouseUp lets releases through
+func (o *DesignLintOverlay) HandleMouseUp(e *Event) {
+	return
+}
+
+// HandleMouseMove tracks the mouse to show the hovered element's issues,
+// letting the move through
+func (o *DesignLintOverlay) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	o.mouse = Point{x, y}
+}
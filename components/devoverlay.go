@@ -0,0 +1,140 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// devOverlayEvents is how many of the last events the dev overlay lists
+const devOverlayEvents = 8
+
+// DevOverlay is a panel for developing an app, shown and hidden with F12.
+// It shows the frame and tick rates, how long the last frame took to draw,
+// the number of elements in the tree and the last input events.
+//
+// Add it to the root after everything else so it is drawn on top and gets
+// F12 first. Without finch, also call BeginDraw and EndDraw around drawing
+// the root, and Record with the events the app dispatches.
+type DevOverlay struct {
+	*Node
+	root      Element
+	shown     bool
+	events    []string // Oldest first
+	drawStart time.Time
+	drawTime  time.Duration
+	elements  int
+	counted   time.Time // When the elements were last counted
+}
+
+// NewDevOverlay creates a hidden dev overlay for the tree under root
+func NewDevOverlay(id string, root Element) *DevOverlay {
+	o := &DevOverlay{Node: NewNode(id), root: root}
+	o.SetPositionType(PositionFixed)
+	o.Node.SetBounds(Rect{X: 0, Y: 0, Width: ScreenWidth, Height: ScreenHeight})
+	return o
+}
+
+// SetShown shows or hides the panel
+func (o *DevOverlay) SetShown(shown bool) {
+	o.shown = shown
+	o.counted = time.Time{}
+}
+
+// IsShown returns whether the panel is showing
+func (o *DevOverlay) IsShown() bool {
+	return o.shown
+}
+
+// BeginDraw marks the start of drawing a frame
+func (o *DevOverlay) BeginDraw() {
+	o.drawStart = Now()
+}
+
+// EndDraw marks the end of drawing a frame, whose time is shown until the
+// next one ends
+func (o *DevOverlay) EndDraw() {
+	if !o.drawStart.IsZero() {
+		o.drawTime = since(o.drawStart)
+	}
+}
+
+// Record adds an event acting on an element, such as a click, to the list
+// of last events; the element may be nil. Repeats of the last event, such
+// as typing into one field, are listed once.
+func (o *DevOverlay) Record(action string, target Element) {
+	event := action
+	if description := DescribeElement(target); description != "" {
+		event += " " + description
+	}
+	if n := len(o.events); n > 0 && o.events[n-1] == event {
+		return
+	}
+	o.events = append(o.events, event)
+	if len(o.events) > devOverlayEvents {
+		o.events = o.events[len(o.events)-devOverlayEvents:]
+	}
+}
+
+// Events returns the last events, oldest first
+func (o *DevOverlay) Events() []string {
+	return o.events
+}
+
+// HandleKeyDown shows or hides the panel on F12
+func (o *DevOverlay) HandleKeyDown(event InputEvent) bool {
+	if event.Type != InputTypeKeyDown || event.Key != KeyF12 || event.Repeat {
+		return false
+	}
+	o.SetShown(!o.shown)
+	return true
+}
+
+// countElements returns the number of elements under and including one
+func countElements(element Element) int {
+	count := 1
+	for _, child := range element.Children() {
+		count += countElements(child)
+	}
+	return count
+}
+
+// Draw draws the panel in the top right corner
+func (o *DevOverlay) Draw(surface DrawSurface) {
+	if !o.shown {
+		return
+	}
+	// Counting walks the whole tree, so it's done twice a second
+	if o.counted.IsZero() || since(o.counted) >= 500*time.Millisecond {
+		o.elements = countElements(o.root)
+		o.counted = Now()
+	}
+
+	lines := []string{
+		fmt.Sprintf("FPS %.1f  TPS %.1f", ebiten.ActualFPS(), ebiten.ActualTPS()),
+		fmt.Sprintf("Draw %.2f ms", float64(o.drawTime.Microseconds())/1000),
+		fmt.Sprintf("Elements %d", o.elements),
+	}
+	if len(o.events) > 0 {
+		lines = append(lines, "Events:")
+		for i := len(o.events) - 1; i >= 0; i-- {
+			lines = append(lines, "  "+o.events[i])
+		}
+	}
+
+	const fontSize, lineHeight, padding, width = 12, 16, 8, 280
+	bounds := o.ComputedBounds()
+	x := bounds.X + bounds.Width - width - padding
+	y := bounds.Y + padding
+	height := len(lines)*lineHeight + padding*2
+	surface.FillRect(x, y, width, height, color.RGBA{20, 20, 24, 220})
+	for i, line := range lines {
+		// Long element text is cut to fit
+		if runes := []rune(line); len(runes) > 44 {
+			line = string(runes[:43]) + "…"
+		}
+		surface.DrawText(line, x+padding, y+padding+i*lineHeight, color.RGBA{230, 230, 230, 255}, fontSize)
+	}
+}
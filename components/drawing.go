@@ -0,0 +1,313 @@
+package components
+
+import (
+	"image"
+	"image/color"
+	"math"
+)
+
+// Brush describes how strokes are painted on a DrawingLayer
+type Brush struct {
+	Color             color.RGBA
+	MinWidth          float64 // Width at zero pressure
+	MaxWidth          float64 // Width at full pressure
+	PressureSensitive bool    // When false, strokes always use MaxWidth
+	PressureOpacity   bool    // When true, lighter pressure paints more transparently
+}
+
+// DefaultBrush returns a black pressure-sensitive pen
+func DefaultBrush() Brush {
+	return Brush{
+		Color:             color.RGBA{0, 0, 0, 255},
+		MinWidth:          1,
+		MaxWidth:          6,
+		PressureSensitive: true,
+	}
+}
+
+// WidthAt returns the stroke width for the given pressure
+func (b Brush) WidthAt(pressure float64) float64 {
+	if !b.PressureSensitive {
+		return b.MaxWidth
+	}
+	return b.MinWidth + (b.MaxWidth-b.MinWidth)*pressure
+}
+
+// ColorAt returns the stroke color for the given pressure
+func (b Brush) ColorAt(pressure float64) color.RGBA {
+	if !b.PressureOpacity {
+		return b.Color
+	}
+	c := b.Color
+	c.A = uint8(float64(c.A) * (0.2 + 0.8*pressure))
+	return c
+}
+
+// StrokePoint is one sample of a stroke
+type StrokePoint struct {
+	X, Y     int
+	Pressure float64
+	TiltX    float64
+	TiltY    float64
+}
+
+// Stroke is a continuous line drawn with one brush
+type Stroke struct {
+	Brush  Brush
+	Points []StrokePoint
+}
+
+// DrawingLayer is a freehand drawing surface. Strokes follow pen pressure
+// when a pen provider supplies it and use constant full pressure otherwise.
+type DrawingLayer struct {
+	*Node
+	strokes    []*Stroke
+	current    *Stroke
+	brush      Brush
+	background color.RGBA
+	onStroke   func(*Stroke)
+	images     []PlacedImage
+	focused    bool
+	onPaste    func(image.Image)
+}
+
+// PlacedImage is an image drawn under the strokes of a drawing layer, with
+// bounds relative to the layer
+type PlacedImage struct {
+	Image  image.Image
+	Bounds Rect
+}
+
+// NewDrawingLayer creates an empty drawing layer
+func NewDrawingLayer(id string) *DrawingLayer {
+	return &DrawingLayer{
+		Node:       NewNode(id),
+		strokes:    make([]*Stroke, 0),
+		brush:      DefaultBrush(),
+		background: color.RGBA{255, 255, 255, 255},
+	}
+}
+
+// SetBrush sets the brush used for new strokes
+func (d *DrawingLayer) SetBrush(brush Brush) {
+	d.brush = brush
+}
+
+// Brush returns the brush used for new strokes
+func (d *DrawingLayer) Brush() Brush {
+	return d.brush
+}
+
+// SetBackgroundColor sets the color behind the strokes
+func (d *DrawingLayer) SetBackgroundColor(c color.RGBA) {
+	d.background = c
+}
+
+// SetOnStroke sets the handler called when a stroke is finished
+func (d *DrawingLayer) SetOnStroke(handler func(*Stroke)) {
+	d.onStroke = handler
+}
+
+// Strokes returns the finished strokes
+func (d *DrawingLayer) Strokes() []*Stroke {
+	return d.strokes
+}
+
+// Clear removes all strokes and images
+func (d *DrawingLayer) Clear() {
+	d.strokes = d.strokes[:0]
+	d.current = nil
+	d.images = nil
+}
+
+// AddImage places an image under the strokes, with bounds relative to the layer
+func (d *DrawingLayer) AddImage(img image.Image, bounds Rect) {
+	d.images = append(d.images, PlacedImage{Image: img, Bounds: bounds})
+}
+
+// Images returns the placed images, bottom first
+func (d *DrawingLayer) Images() []PlacedImage {
+	return d.images
+}
+
+// SetOnPasteImage lets images be pasted from the clipboard while the layer
+// is focused. A pasted image is placed centered at its own size, scaled
+// down to fit, and then passed to the handler.
+func (d *DrawingLayer) SetOnPasteImage(handler func(image.Image)) {
+	d.onPaste = handler
+}
+
+// pasteImage places a pasted image in the middle of the layer
+func (d *DrawingLayer) pasteImage(img image.Image) {
+	bounds := d.Bounds()
+	size := img.Bounds().Size()
+	width, height := size.X, size.Y
+	if width > bounds.Width || height > bounds.Height {
+		scale := math.Min(float64(bounds.Width)/float64(width), float64(bounds.Height)/float64(height))
+		width = int(float64(width) * scale)
+		height = int(float64(height) * scale)
+	}
+	d.AddImage(img, Rect{X: (bounds.Width - width) / 2, Y: (bounds.Height - height) / 2, Width: width, Height: height})
+	if d.onPaste != nil {
+		d.onPaste(img)
+	}
+}
+
+// Focus gives the layer keyboard focus
+func (d *DrawingLayer) Focus() {
+	d.focused = true
+}
+
+// Blur removes keyboard focus
+func (d *DrawingLayer) Blur() {
+	d.focused = false
+}
+
+// IsFocused returns whether the layer receives the paste shortcut
+func (d *DrawingLayer) IsFocused() bool {
+	return d.focused
+}
+
+// HandleKeyDown pastes an image from the clipboard while focused
+func (d *DrawingLayer) HandleKeyDown(event InputEvent) bool {
+	if !d.focused || !d.IsVisible() || d.onPaste == nil {
+		return false
+	}
+	return pasteImageOnKey(event, d.pasteImage)
+}
+
+// Undo removes the most recent stroke
+func (d *DrawingLayer) Undo() {
+	if len(d.strokes) > 0 {
+		d.strokes = d.strokes[:len(d.strokes)-1]
+	}
+}
+
+// BeginStroke starts a stroke at the given point
+func (d *DrawingLayer) BeginStroke(point StrokePoint) {
+	d.current = &Stroke{Brush: d.brush, Points: []StrokePoint{point}}
+}
+
+// AddPoint extends the stroke in progress
+func (d *DrawingLayer) AddPoint(point StrokePoint) {
+	if d.current == nil {
+		d.BeginStroke(point)
+		return
+	}
+	last := d.current.Points[len(d.current.Points)-1]
+	if last.X == point.X && last.Y == point.Y {
+		return
+	}
+	d.current.Points = append(d.current.Points, point)
+}
+
+// EndStroke finishes the stroke in progress
+func (d *DrawingLayer) EndStroke() {
+	if d.current == nil {
+		return
+	}
+	stroke := d.current
+	d.current = nil
+	d.strokes = append(d.strokes, stroke)
+	if d.onStroke != nil {
+		d.onStroke(stroke)
+	}
+}
+
+// Draw draws the background and all strokes, clipped to the layer
+func (d *DrawingLayer) Draw(surface DrawSurface) {
+	if !d.IsVisible() {
+		return
+	}
+
+	bounds := d.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, d.background)
+	surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
+	for _, placed := range d.images {
+		r := placed.Bounds
+		surface.DrawImage(placed.Image, bounds.X+r.X, bounds.Y+r.Y, r.Width, r.Height, ImageFitFill)
+	}
+	for _, stroke := range d.strokes {
+		drawStroke(surface, stroke)
+	}
+	if d.current != nil {
+		drawStroke(surface, d.current)
+	}
+	surface.ResetClipRect()
+}
+
+// drawStroke stamps circles along each segment, interpolating width between samples
+func drawStroke(surface DrawSurface, stroke *Stroke) {
+	points := stroke.Points
+	if len(points) == 1 {
+		p := points[0]
+		radius := int(math.Round(stroke.Brush.WidthAt(p.Pressure) / 2))
+		surface.FillCircle(p.X, p.Y, radius, stroke.Brush.ColorAt(p.Pressure))
+		return
+	}
+
+	for i := 1; i < len(points); i++ {
+		a, b := points[i-1], points[i]
+		length := math.Hypot(float64(b.X-a.X), float64(b.Y-a.Y))
+		width := math.Min(stroke.Brush.WidthAt(a.Pressure), stroke.Brush.WidthAt(b.Pressure))
+		spacing := math.Max(width/3, 1)
+		steps := int(length/spacing) + 1
+
+		for s := 0; s <= steps; s++ {
+			t := float64(s) / float64(steps)
+			pressure := a.Pressure + (b.Pressure-a.Pressure)*t
+			x := float64(a.X) + float64(b.X-a.X)*t
+			y := float64(a.Y) + float64(b.Y-a.Y)*t
+			radius := int(math.Round(stroke.Brush.WidthAt(pressure) / 2))
+			if radius < 1 {
+				surface.DrawLine(int(x), int(y), int(x), int(y), stroke.Brush.ColorAt(pressure))
+				continue
+			}
+			surface.FillCircle(int(math.Round(x)), int(math.Round(y)), radius, stroke.Brush.ColorAt(pressure))
+		}
+	}
+}
+
+// HandleMouseDown starts or continues a stroke with the current pen pressure
+func (d *DrawingLayer) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !d.IsVisible() {
+		return
+	}
+	inside := PointInRect(Point{x, y}, d.ComputedBounds())
+	if d.current == nil {
+		d.focused = inside
+	}
+	if !inside && d.current == nil {
+		return
+	}
+
+	event := NewPointerEvent(InputTypeMouseDown, x, y)
+	point := StrokePoint{X: x, Y: y, Pressure: event.Pressure, TiltX: event.TiltX, TiltY: event.TiltY}
+	if d.current == nil {
+		d.BeginStroke(point)
+	} else {
+		d.AddPoint(point)
+	}
+	e.Consume()
+}
+
+// HandleMouseUp finishes the stroke in progress
+func (d *DrawingLayer) HandleMouseUp(e *Event) {
+	if d.current == nil {
+		return
+	}
+	d.EndStroke()
+	e.Consume()
+}
+
+// HandleMouseMove extends the stroke in progress
+func (d *DrawingLayer) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if d.current == nil {
+		return
+	}
+	event := NewPointerEvent(InputTypeMouseMove, x, y)
+	d.AddPoint(StrokePoint{X: x, Y: y, Pressure: event.Pressure, TiltX: event.TiltX, TiltY: event.TiltY})
+	e.Consume()
+}
@@ -0,0 +1,269 @@
+package components
+
+// EventPhase is the stage of an event's trip through the element tree
+type EventPhase int
+
+const (
+	PhaseNone    EventPhase = iota
+	PhaseCapture            // From the root down to the target's parent
+	PhaseTarget             // At the element under the pointer
+	PhaseBubble             // From the target's parent back up to the root
+)
+
+// Event is a mouse event on its way through the element tree. Capture
+// listeners see it from the root down to the element under the pointer,
+// then that element's own listeners run, then bubble listeners from its
+// parent back up to the root. Afterwards, unless a listener prevented it,
+// the elements' built-in handling (HandleMouseDown and friends) gets the
+// same event as its default action, until an element consumes it.
+type Event struct {
+	InputEvent
+	Target        Element // Innermost element under the pointer, or nil
+	CurrentTarget Element // Element whose listeners are running
+	Phase         EventPhase
+	Path          []Element   // Root first, target last
+	HandledBy     Element     // Element that used the event, or nil
+	Button        MouseButton // For clicks
+	ClickCount    int         // For clicks: 1 for a single click, 2 for a double click, ...
+
+	stopped   bool
+	prevented bool
+	consumed  bool
+}
+
+// NewMouseEvent creates a mouse event of the given type at a point
+func NewMouseEvent(eventType InputType, x, y int) *Event {
+	return &Event{InputEvent: InputEvent{Type: eventType, X: x, Y: y, Pressure: 1}}
+}
+
+// StopPropagation stops the event reaching listeners further along its
+// path and marks the current element as having handled it
+func (e *Event) StopPropagation() {
+	e.stopped = true
+	e.handle()
+}
+
+// PreventDefault stops the elements' built-in handling of the event, such
+// as a button's click, and marks the current element as having handled it
+func (e *Event) PreventDefault() {
+	e.prevented = true
+	e.handle()
+}
+
+// IsPropagationStopped returns whether a listener stopped the event
+func (e *Event) IsPropagationStopped() bool {
+	return e.stopped
+}
+
+// IsDefaultPrevented returns whether a listener prevented the built-in
+// handling
+func (e *Event) IsDefaultPrevented() bool {
+	return e.prevented
+}
+
+// Consume marks the event as used by an element's built-in handling, which
+// ends the default action
+func (e *Event) Consume() {
+	e.consumed = true
+}
+
+// Consumed returns whether an element's built-in handling used the event
+func (e *Event) Consumed() bool {
+	return e.consumed
+}
+
+// unconsumed returns a copy of the event that no element has consumed, for
+// giving several elements each their own chance to use it
+func (e *Event) unconsumed() *Event {
+	copied := *e
+	copied.consumed = false
+	return &copied
+}
+
+// at returns an unconsumed copy of the event moved to a point, e.g. off
+// every element so they clear their hover states
+func (e *Event) at(x, y int) *Event {
+	copied := e.unconsumed()
+	copied.X, copied.Y = x, y
+	return copied
+}
+
+// Handled returns whether a listener or element used the event
+func (e *Event) Handled() bool {
+	return e.HandledBy != nil
+}
+
+// handle records the current element as the handler unless one was
+// recorded already
+func (e *Event) handle() {
+	if e.HandledBy == nil {
+		e.HandledBy = e.CurrentTarget
+	}
+}
+
+// eventListener is a function listening for one type of event
+type eventListener struct {
+	eventType InputType
+	capture   bool
+	fn        func(*Event)
+}
+
+// EventTarget is implemented by elements that take event listeners
+type EventTarget interface {
+	EventListeners(eventType InputType, capture bool) []func(*Event)
+}
+
+// AddEventListener adds a listener called when an event of the given type
+// reaches the element or bubbles up from an element inside it
+func (d *Node) AddEventListener(eventType InputType, listener func(*Event)) {
+	d.listeners = append(d.listeners, eventListener{eventType: eventType, fn: listener})
+}
+
+// AddCaptureListener adds a listener called as an event of the given type
+// travels down to an element inside this one, before the element sees it
+func (d *Node) AddCaptureListener(eventType InputType, listener func(*Event)) {
+	d.listeners = append(d.listeners, eventListener{eventType: eventType, capture: true, fn: listener})
+}
+
+// RemoveEventListeners removes the element's listeners for a type of event
+func (d *Node) RemoveEventListeners(eventType InputType) {
+	kept := d.listeners[:0]
+	for _, l := range d.listeners {
+		if l.eventType != eventType {
+			kept = append(kept, l)
+		}
+	}
+	d.listeners = kept
+}
+
+// EventListeners returns the element's capture or bubble listeners for a
+// type of event, in the order they were added, followed by its double or
+// context click handler, which stops the event
+func (d *Node) EventListeners(eventType InputType, capture bool) []func(*Event) {
+	var fns []func(*Event)
+	for _, l := range d.listeners {
+		if l.eventType == eventType && l.capture == capture {
+			fns = append(fns, l.fn)
+		}
+	}
+	hook := d.onDoubleClick
+	if eventType == InputTypeContextClick {
+		hook = d.onContextClick
+	} else if eventType != InputTypeDoubleClick {
+		hook = nil
+	}
+	if hook != nil && !capture {
+		fns = append(fns, func(e *Event) {
+			hook(e)
+			e.StopPropagation()
+		})
+	}
+	return fns
+}
+
+// HitPath returns the visible elements under a point from the root down to
+// the innermost, topmost one. Fixed-position layers that cover the screen,
+// such as the toast area, are only on the path through their children.
+func HitPath(root Element, x, y int) []Element {
+	target := ElementAt(root, x, y)
+	if target == nil {
+		return []Element{root}
+	}
+	var path []Element
+	var walk func(element Element) bool
+	walk = func(element Element) bool {
+		path = append(path, element)
+		if element == target {
+			return true
+		}
+		children := element.Children()
+		for i := len(children) - 1; i >= 0; i-- {
+			if walk(children[i]) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	walk(root)
+	return path
+}
+
+// DispatchEvent sends a mouse event through the tree under root, calling
+// listeners along its path, then, unless a listener prevented it, the
+// built-in handlers of root's children, topmost first, as the default
+// action. The root itself is the page and doesn't take the event. Returns
+// whether anything handled it; HandledBy says what.
+func DispatchEvent(root Element, e *Event) bool {
+	e.Path = HitPath(root, e.X, e.Y)
+	e.Target = e.Path[len(e.Path)-1]
+	if e.Target == root {
+		e.Target = nil
+	}
+	last := len(e.Path) - 1
+
+	// Capture, root first
+	for i := 0; i < last && !e.stopped; i++ {
+		e.Phase = PhaseCapture
+		e.callListeners(e.Path[i], true)
+	}
+
+	// The target's own listeners, capture ones first
+	if e.Target != nil && !e.stopped {
+		e.Phase = PhaseTarget
+		e.callListeners(e.Target, true)
+		if !e.stopped {
+			e.callListeners(e.Target, false)
+		}
+	}
+
+	// Bubble, back up to the root
+	for i := last - 1; i >= 0 && !e.stopped; i-- {
+		e.Phase = PhaseBubble
+		e.callListeners(e.Path[i], false)
+	}
+	e.Phase = PhaseNone
+	e.CurrentTarget = nil
+
+	if !e.prevented {
+		defaultAction(root, e)
+	}
+	if e.consumed && e.HandledBy == nil {
+		e.HandledBy = e.Target
+		if e.HandledBy == nil {
+			e.HandledBy = root
+		}
+	}
+	return e.Handled()
+}
+
+// callListeners runs an element's capture or bubble listeners for the event
+func (e *Event) callListeners(element Element, capture bool) {
+	target, ok := element.(EventTarget)
+	if !ok {
+		return
+	}
+	e.CurrentTarget = element
+	for _, fn := range target.EventListeners(e.Type, capture) {
+		fn(e)
+		if e.stopped {
+			return
+		}
+	}
+}
+
+// defaultAction passes the event to the built-in handlers of root's
+// children, topmost first, until one consumes it
+func defaultAction(root Element, e *Event) {
+	children := root.Children()
+	for i := len(children) - 1; i >= 0 && !e.consumed; i-- {
+		switch e.Type {
+		case InputTypeMouseDown:
+			children[i].HandleMouseDown(e)
+		case InputTypeMouseUp:
+			children[i].HandleMouseUp(e)
+		case InputTypeMouseMove:
+			children[i].HandleMouseMove(e)
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package components
+
+import "image/color"
+
+// ExpandableText is a block of wrapped text that shows only its first few
+// lines, fading out the last one, with a "Show more" link that reveals the
+// rest. The height follows the visible lines and is recomputed when the
+// width changes.
+type ExpandableText struct {
+	*Node
+	text            string
+	style           ParagraphStyle
+	maxLines        int
+	expanded        bool
+	lines           []ParagraphLine
+	layoutWidth     int
+	pressed         bool
+	hovered         bool
+	moreLabel       string
+	lessLabel       string
+	onToggle        func(expanded bool)
+	backgroundColor color.RGBA
+	linkColor       color.RGBA
+}
+
+// NewExpandableText creates a collapsed block that shows up to maxLines
+// lines
+func NewExpandableText(id, text string, maxLines int) *ExpandableText {
+	e := &ExpandableText{
+		Node:            NewNode(id),
+		text:            text,
+		style:           DefaultParagraphStyle(),
+		maxLines:        max(1, maxLines),
+		layoutWidth:     -1,
+		moreLabel:       "Show more",
+		lessLabel:       "Show less",
+		backgroundColor: color.RGBA{255, 255, 255, 255},
+		linkColor:       color.RGBA{30, 100, 200, 255},
+	}
+	e.style.SpaceAfter = 0
+	return e
+}
+
+// SetText replaces the text
+func (e *ExpandableText) SetText(text string) {
+	e.text = text
+	e.relayout()
+}
+
+// Text returns the text
+func (e *ExpandableText) Text() string {
+	return e.text
+}
+
+// SetStyle sets how the text is wrapped and drawn
+func (e *ExpandableText) SetStyle(style ParagraphStyle) {
+	e.style = style
+	e.relayout()
+}
+
+// SetMaxLines sets how many lines are shown while collapsed
+func (e *ExpandableText) SetMaxLines(lines int) {
+	e.maxLines = max(1, lines)
+	e.relayout()
+}
+
+// MaxLines returns how many lines are shown while collapsed
+func (e *ExpandableText) MaxLines() int {
+	return e.maxLines
+}
+
+// SetLabels sets the link text for expanding and collapsing
+func (e *ExpandableText) SetLabels(more, less string) {
+	e.moreLabel = more
+	e.lessLabel = less
+}
+
+// SetBackgroundColor sets the background, which the last collapsed line
+// fades into
+func (e *ExpandableText) SetBackgroundColor(color color.RGBA) {
+	e.backgroundColor = color
+}
+
+// SetLinkColor sets the color of the toggle link
+func (e *ExpandableText) SetLinkColor(color color.RGBA) {
+	e.linkColor = color
+}
+
+// SetExpanded shows all of the text or only the first lines
+func (e *ExpandableText) SetExpanded(expanded bool) {
+	if e.expanded == expanded {
+		return
+	}
+	e.expanded = expanded
+	e.resize()
+	if e.onToggle != nil {
+		e.onToggle(expanded)
+	}
+}
+
+// IsExpanded returns whether all of the text is shown
+func (e *ExpandableText) IsExpanded() bool {
+	return e.expanded
+}
+
+// Toggle flips between showing all and the first lines
+func (e *ExpandableText) Toggle() {
+	e.SetExpanded(!e.expanded)
+}
+
+// SetOnToggle sets the handler for when the text is expanded or collapsed
+func (e *ExpandableText) SetOnToggle(handler func(expanded bool)) {
+	e.onToggle = handler
+}
+
+// IsTruncated returns whether the text has more lines than are shown while
+// collapsed; if not, no toggle is drawn
+func (e *ExpandableText) IsTruncated() bool {
+	return len(e.layout()) > e.maxLines
+}
+
+// SetBounds sets the position and width; the height follows the visible
+// lines
+func (e *ExpandableText) SetBounds(bounds Rect) {
+	e.Node.SetBounds(bounds)
+	e.resize()
+}
+
+// relayout discards the wrapped lines after a change to the text or style
+func (e *ExpandableText) relayout() {
+	e.layoutWidth = -1
+	e.resize()
+}
+
+// layout wraps the text to the current width, reusing the last layout
+// while the width is unchanged
+func (e *ExpandableText) layout() []ParagraphLine {
+	width := e.Bounds().Width
+	if width != e.layoutWidth {
+		e.lines = LayoutParagraph(e.text, width, e.style)
+		e.layoutWidth = width
+	}
+	return e.lines
+}
+
+// visibleLines returns how many lines are drawn
+func (e *ExpandableText) visibleLines() int {
+	lines := len(e.layout())
+	if e.expanded {
+		return lines
+	}
+	return min(lines, e.maxLines)
+}
+
+// toggleHeight returns the height of the row holding the toggle link
+func (e *ExpandableText) toggleHeight() int {
+	if !e.IsTruncated() {
+		return 0
+	}
+	return e.style.FontSize + 8
+}
+
+// resize sets the height to fit the visible lines and the toggle
+func (e *ExpandableText) resize() {
+	bounds := e.Bounds()
+	bounds.Height = e.visibleLines()*e.style.lineHeight() + e.toggleHeight()
+	e.Node.SetBounds(bounds)
+}
+
+// toggleRect returns the clickable area of the toggle link
+func (e *ExpandableText) toggleRect() Rect {
+	bounds := e.ComputedBounds()
+	label := e.moreLabel
+	if e.expanded {
+		label = e.lessLabel
+	}
+	height := e.toggleHeight()
+	return Rect{X: bounds.X, Y: bounds.Y + bounds.Height - height, Width: MeasureText(label) + 4, Height: height}
+}
+
+// Draw draws the visible lines, fading the last one while collapsed, and
+// the toggle link
+func (e *ExpandableText) Draw(surface DrawSurface) {
+	if !e.IsVisible() {
+		return
+	}
+
+	// Catch width changes made by a parent's layout
+	if e.Bounds().Width != e.layoutWidth {
+		e.resize()
+	}
+	bounds := e.ComputedBounds()
+	if e.backgroundColor.A > 0 {
+		surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, e.backgroundColor)
+	}
+
+	lines := e.layout()
+	visible := e.visibleLines()
+	lineHeight := e.style.lineHeight()
+	for _, line := range lines[:visible] {
+		for _, word := range line.Words {
+			surface.DrawText(word.Text, bounds.X+word.X, bounds.Y+line.Y, e.style.Color, e.style.FontSize)
+			if e.style.Bold {
+				surface.DrawText(word.Text, bounds.X+word.X+1, bounds.Y+line.Y, e.style.Color, e.style.FontSize)
+			}
+		}
+	}
+
+	if !e.IsTruncated() {
+		return
+	}
+
+	if !e.expanded && e.backgroundColor.A > 0 {
+		// Fade the last line into the background with bands of rising opacity
+		fadeHeight := lineHeight
+		fadeTop := bounds.Y + (visible-1)*lineHeight
+		const steps = 8
+		for i := 0; i < steps; i++ {
+			band := e.backgroundColor
+			band.A = uint8(int(e.backgroundColor.A) * (i + 1) / (steps + 1))
+			y := fadeTop + fadeHeight*i/steps
+			surface.FillRect(bounds.X, y, bounds.Width, fadeTop+fadeHeight*(i+1)/steps-y, band)
+		}
+	}
+
+	label := e.moreLabel
+	if e.expanded {
+		label = e.lessLabel
+	}
+	toggle := e.toggleRect()
+	textY := toggle.Y + (toggle.Height-e.style.FontSize)/2
+	surface.DrawText(label, toggle.X, textY, e.linkColor, e.style.FontSize)
+	if e.hovered {
+		surface.DrawLine(toggle.X, textY+e.style.FontSize, toggle.X+MeasureText(label), textY+e.style.FontSize, e.linkColor)
+	}
+}
+
+// HandleMouseDown presses the toggle link
+func (e *ExpandableText) HandleMouseDown(event *Event) {
+	x, y := event.X, event.Y
+	if !e.IsVisible() || !e.IsTruncated() {
+		return
+	}
+	e.pressed = PointInRect(Point{x, y}, e.toggleRect())
+	if e.pressed {
+		event.Consume()
+	}
+}
+
+// HandleMouseUp toggles when the link is released over
+func (e *ExpandableText) HandleMouseUp(event *Event) {
+	x, y := event.X, event.Y
+	if !e.pressed {
+		return
+	}
+	e.pressed = false
+	if PointInRect(Point{x, y}, e.toggleRect()) {
+		e.Toggle()
+	}
+	event.Consume()
+}
+
+// CursorShapeAt returns the pointer hand over the toggle link
+func (e *ExpandableText) CursorShapeAt(x, y int) CursorShape {
+	if e.IsTruncated() && PointInRect(Point{x, y}, e.toggleRect()) {
+		return CursorPointer
+	}
+	return e.Node.CursorShapeAt(x, y)
+}
+
+// HandleMouseMove underlines the toggle link while hovered
+func (e *ExpandableText) HandleMouseMove(event *Event) {
+	x, y := event.X, event.Y
+	e.hovered = e.IsVisible() && e.IsTruncated() && PointInRect(Point{x, y}, e.toggleRect())
+	if e.hovered {
+		event.Consume()
+	}
+}
@@ -0,0 +1,589 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileDialogMode selects whether a FileDialog opens or saves a file
+type FileDialogMode int
+
+const (
+	FileDialogOpen FileDialogMode = iota
+	FileDialogSave
+)
+
+// FileFilter limits the files listed to the given extensions, e.g.
+// FileFilter{Name: "Images", Extensions: []string{".png", ".jpg"}}.
+// An empty extension list matches every file.
+type FileFilter struct {
+	Name       string
+	Extensions []string
+}
+
+// Matches returns whether the file name has one of the filter's extensions
+func (f FileFilter) Matches(name string) bool {
+	if len(f.Extensions) == 0 {
+		return true
+	}
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range f.Extensions {
+		if strings.ToLower(e) == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// Label returns the name shown in the filter list
+func (f FileFilter) Label() string {
+	if len(f.Extensions) == 0 {
+		return f.Name
+	}
+	return fmt.Sprintf("%s (%s)", f.Name, strings.Join(f.Extensions, ", "))
+}
+
+// fileEntry is a row in the file list
+type fileEntry struct {
+	name  string
+	isDir bool
+	size  int64
+}
+
+// treeEntry is a row in the directory tree
+type treeEntry struct {
+	path  string
+	name  string
+	depth int
+}
+
+// FileDialog is an in-UI file browser for opening and saving files, shown in
+// a Modal since Ebiten has no native dialogs. The left pane shows the path to
+// the current directory and its subdirectories; the right pane lists files.
+type FileDialog struct {
+	*Modal
+	mode         FileDialogMode
+	dir          string
+	showHidden   bool
+	filters      []FileFilter
+	filter       int
+	tree         []treeEntry
+	entries      []fileEntry
+	selected     int // Index into entries, -1 for none
+	scroll       int // First visible entry
+	treeScroll   int
+	nameInput    *TextArea
+	filterSelect *Select
+	okButton     *Button
+	cancelButton *Button
+	errorText    string
+	rowHeight    int
+	fontSize     int
+	onResult     func(path string, ok bool)
+	textColor    color.RGBA
+	mutedColor   color.RGBA
+	selectColor  color.RGBA
+	paneColor    color.RGBA
+}
+
+// NewFileDialog creates a file dialog starting in the working directory
+func NewFileDialog(id string, mode FileDialogMode) *FileDialog {
+	title := "Open File"
+	okText := "Open"
+	if mode == FileDialogSave {
+		title = "Save File"
+		okText = "Save"
+	}
+
+	d := &FileDialog{
+		Modal:        NewModal(id, title),
+		mode:         mode,
+		filters:      []FileFilter{{Name: "All files"}},
+		selected:     -1,
+		nameInput:    NewTextArea(id + "_name"),
+		filterSelect: NewSelect(id+"_filter", nil),
+		okButton:     NewButton(id+"_ok", okText),
+		cancelButton: NewButton(id+"_cancel", "Cancel"),
+		rowHeight:    20,
+		fontSize:     13,
+		textColor:    color.RGBA{0, 0, 0, 255},
+		mutedColor:   color.RGBA{120, 120, 120, 255},
+		selectColor:  color.RGBA{200, 220, 255, 255},
+		paneColor:    color.RGBA{250, 250, 250, 255},
+	}
+	d.SetDialogSize(640, 420)
+	d.nameInput.SetPlaceholder("File name")
+	d.nameInput.SetSingleLine(true)
+	d.okButton.SetOnClick(d.accept)
+	d.cancelButton.SetOnClick(d.cancel)
+	d.filterSelect.SetOnChange(func(index int) {
+		if index >= 0 {
+			d.filter = index
+			d.Refresh()
+		}
+	})
+
+	for _, child := range []Element{d.nameInput, d.filterSelect, d.okButton, d.cancelButton} {
+		if node, ok := child.(interface{ SetPositionType(PositionType) }); ok {
+			node.SetPositionType(PositionFixed)
+		}
+		d.Content().AddChild(child)
+	}
+
+	if wd, err := os.Getwd(); err == nil {
+		d.dir = wd
+	}
+	d.setFilterOptions()
+	d.layoutControls()
+	return d
+}
+
+// SetDirectory sets the directory being browsed
+func (d *FileDialog) SetDirectory(dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(abs)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", abs)
+	}
+	d.dir = abs
+	d.Refresh()
+	return nil
+}
+
+// Directory returns the directory being browsed
+func (d *FileDialog) Directory() string {
+	return d.dir
+}
+
+// SetFilters sets the extension filters offered; the first is selected
+func (d *FileDialog) SetFilters(filters []FileFilter) {
+	if len(filters) == 0 {
+		filters = []FileFilter{{Name: "All files"}}
+	}
+	d.filters = filters
+	d.filter = 0
+	d.setFilterOptions()
+	d.Refresh()
+}
+
+// SetFileName sets the name in the file name box, e.g. a default name when saving
+func (d *FileDialog) SetFileName(name string) {
+	d.nameInput.SetText(name)
+	d.nameInput.SetCursor(len([]rune(name)))
+}
+
+// SetShowHidden sets whether files starting with a dot are listed
+func (d *FileDialog) SetShowHidden(show bool) {
+	d.showHidden = show
+	d.Refresh()
+}
+
+// SetOnResult sets the handler called with the chosen path, or with ok false if cancelled
+func (d *FileDialog) SetOnResult(handler func(path string, ok bool)) {
+	d.onResult = handler
+}
+
+// Open refreshes the listing and shows the dialog
+func (d *FileDialog) Open() {
+	d.errorText = ""
+	d.Refresh()
+	d.layoutControls()
+	d.Modal.Open()
+}
+
+// setFilterOptions fills the filter list from the filters
+func (d *FileDialog) setFilterOptions() {
+	labels := make([]string, len(d.filters))
+	for i, f := range d.filters {
+		labels[i] = f.Label()
+	}
+	d.filterSelect.SetOptions(labels)
+	d.filterSelect.selectedIndex = d.filter
+}
+
+// Refresh re-reads the current directory
+func (d *FileDialog) Refresh() {
+	d.entries = d.entries[:0]
+	d.selected = -1
+	d.scroll = 0
+	d.buildTree()
+
+	items, err := os.ReadDir(d.dir)
+	if err != nil {
+		d.errorText = err.Error()
+		return
+	}
+
+	filter := d.filters[d.filter]
+	for _, item := range items {
+		name := item.Name()
+		if !d.showHidden && strings.HasPrefix(name, ".") {
+			continue
+		}
+		if !item.IsDir() && !filter.Matches(name) {
+			continue
+		}
+		entry := fileEntry{name: name, isDir: item.IsDir()}
+		if info, err := item.Info(); err == nil {
+			entry.size = info.Size()
+		}
+		d.entries = append(d.entries, entry)
+	}
+
+	// Directories first, then by name
+	sort.SliceStable(d.entries, func(i, j int) bool {
+		if d.entries[i].isDir != d.entries[j].isDir {
+			return d.entries[i].isDir
+		}
+		return strings.ToLower(d.entries[i].name) < strings.ToLower(d.entries[j].name)
+	})
+}
+
+// buildTree lists the ancestors of the current directory followed by its subdirectories
+func (d *FileDialog) buildTree() {
+	d.tree = d.tree[:0]
+	d.treeScroll = 0
+
+	ancestors := make([]string, 0)
+	for p := d.dir; ; {
+		ancestors = append([]string{p}, ancestors...)
+		parent := filepath.Dir(p)
+		if parent == p {
+			break
+		}
+		p = parent
+	}
+
+	for depth, p := range ancestors {
+		name := filepath.Base(p)
+		if depth == 0 {
+			name = p
+		}
+		d.tree = append(d.tree, treeEntry{path: p, name: name, depth: depth})
+	}
+
+	items, err := os.ReadDir(d.dir)
+	if err != nil {
+		return
+	}
+	for _, item := range items {
+		if item.IsDir() && (d.showHidden || !strings.HasPrefix(item.Name(), ".")) {
+			d.tree = append(d.tree, treeEntry{
+				path:  filepath.Join(d.dir, item.Name()),
+				name:  item.Name(),
+				depth: len(ancestors),
+			})
+		}
+	}
+}
+
+// Layout of the dialog's panes and controls
+
+// treeRect returns the bounds of the directory tree pane
+func (d *FileDialog) treeRect() Rect {
+	c := d.ContentBounds()
+	return Rect{X: c.X + 10, Y: c.Y + 30, Width: 180, Height: c.Height - 120}
+}
+
+// listRect returns the bounds of the file list pane
+func (d *FileDialog) listRect() Rect {
+	c := d.ContentBounds()
+	tree := d.treeRect()
+	x := tree.X + tree.Width + 10
+	return Rect{X: x, Y: tree.Y, Width: c.X + c.Width - 10 - x, Height: tree.Height}
+}
+
+// layoutControls positions the file name box, filter list and buttons below the panes
+func (d *FileDialog) layoutControls() {
+	c := d.ContentBounds()
+	y := c.Y + c.Height - 80
+	d.nameInput.SetBounds(Rect{X: c.X + 10, Y: y, Width: c.Width - 230, Height: 30})
+	d.filterSelect.SetBounds(Rect{X: c.X + c.Width - 210, Y: y, Width: 200, Height: 30})
+	d.okButton.SetBounds(Rect{X: c.X + c.Width - 200, Y: y + 40, Width: 90, Height: 30})
+	d.cancelButton.SetBounds(Rect{X: c.X + c.Width - 100, Y: y + 40, Width: 90, Height: 30})
+}
+
+// visibleRows returns how many rows fit in a pane
+func (d *FileDialog) visibleRows(pane Rect) int {
+	return pane.Height / d.rowHeight
+}
+
+// SelectedPath returns the path the dialog would return if accepted now
+func (d *FileDialog) SelectedPath() string {
+	name := strings.TrimSpace(d.nameInput.GetText())
+	if name == "" {
+		return ""
+	}
+	if filepath.IsAbs(name) {
+		return name
+	}
+	path := filepath.Join(d.dir, name)
+
+	// Saving with a filter adds its first extension if none was typed
+	if d.mode == FileDialogSave && filepath.Ext(name) == "" {
+		if exts := d.filters[d.filter].Extensions; len(exts) > 0 {
+			path += exts[0]
+		}
+	}
+	return path
+}
+
+// accept enters a chosen directory, or returns the chosen file
+func (d *FileDialog) accept() {
+	path := d.SelectedPath()
+	if path == "" {
+		d.errorText = "Enter a file name"
+		return
+	}
+
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && info.IsDir():
+		d.nameInput.SetText("")
+		if err := d.SetDirectory(path); err != nil {
+			d.errorText = err.Error()
+		}
+		return
+	case err != nil && d.mode == FileDialogOpen:
+		d.errorText = "File not found: " + filepath.Base(path)
+		return
+	}
+
+	onResult := d.onResult
+	d.Close()
+	if onResult != nil {
+		onResult(path, true)
+	}
+}
+
+// cancel closes the dialog without a result
+func (d *FileDialog) cancel() {
+	onResult := d.onResult
+	d.Close()
+	if onResult != nil {
+		onResult("", false)
+	}
+}
+
+// selectEntry selects a file list entry and puts file names in the name box
+func (d *FileDialog) selectEntry(index int) {
+	if index < 0 || index >= len(d.entries) {
+		return
+	}
+	d.selected = index
+	if visible := d.visibleRows(d.listRect()); index >= d.scroll+visible {
+		d.scroll = index - visible + 1
+	} else if index < d.scroll {
+		d.scroll = index
+	}
+	if !d.entries[index].isDir {
+		d.SetFileName(d.entries[index].name)
+	}
+}
+
+// openEntry enters a directory entry or accepts a file entry
+func (d *FileDialog) openEntry(index int) {
+	if index < 0 || index >= len(d.entries) {
+		return
+	}
+	entry := d.entries[index]
+	if entry.isDir {
+		if err := d.SetDirectory(filepath.Join(d.dir, entry.name)); err != nil {
+			d.errorText = err.Error()
+		}
+		return
+	}
+	d.SetFileName(entry.name)
+	d.accept()
+}
+
+// formatSize returns a human-readable file size
+func formatSize(size int64) string {
+	switch {
+	case size >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(size)/(1<<30))
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(size)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", size)
+}
+
+// Draw draws the dialog with its path, panes and controls
+func (d *FileDialog) Draw(surface DrawSurface) {
+	if !d.IsOpen() {
+		return
+	}
+
+	// The modal draws the backdrop, frame and controls; panes are drawn between
+	d.layoutControls()
+	d.Modal.Draw(surface)
+
+	c := d.ContentBounds()
+	surface.DrawText(d.dir, c.X+10, c.Y+8, d.mutedColor, d.fontSize)
+
+	// Directory tree
+	tree := d.treeRect()
+	surface.FillRect(tree.X, tree.Y, tree.Width, tree.Height, d.paneColor)
+	surface.SetClipRect(tree.X, tree.Y, tree.Width, tree.Height)
+	for i := d.treeScroll; i < len(d.tree) && i-d.treeScroll < d.visibleRows(tree); i++ {
+		entry := d.tree[i]
+		y := tree.Y + (i-d.treeScroll)*d.rowHeight
+		if entry.path == d.dir {
+			surface.FillRect(tree.X, y, tree.Width, d.rowHeight, d.selectColor)
+		}
+		surface.DrawText(entry.name, tree.X+6+entry.depth*10, y+(d.rowHeight-d.fontSize)/2, d.textColor, d.fontSize)
+	}
+	surface.ResetClipRect()
+	surface.DrawRect(tree.X, tree.Y, tree.Width, tree.Height, color.RGBA{180, 180, 180, 255})
+
+	// File list
+	list := d.listRect()
+	surface.FillRect(list.X, list.Y, list.Width, list.Height, d.paneColor)
+	surface.SetClipRect(list.X, list.Y, list.Width, list.Height)
+	for i := d.scroll; i < len(d.entries) && i-d.scroll < d.visibleRows(list); i++ {
+		entry := d.entries[i]
+		y := list.Y + (i-d.scroll)*d.rowHeight
+		if i == d.selected {
+			surface.FillRect(list.X, y, list.Width, d.rowHeight, d.selectColor)
+		}
+		textY := y + (d.rowHeight-d.fontSize)/2
+		if entry.isDir {
+			surface.DrawText("[+] "+entry.name, list.X+6, textY, d.textColor, d.fontSize)
+		} else {
+			surface.DrawText(entry.name, list.X+6, textY, d.textColor, d.fontSize)
+			size := formatSize(entry.size)
+			surface.DrawText(size, list.X+list.Width-10-len(size)*d.fontSize/2, textY, d.mutedColor, d.fontSize)
+		}
+	}
+	if len(d.entries) == 0 {
+		surface.DrawText("No matching files", list.X+6, list.Y+6, d.mutedColor, d.fontSize)
+	}
+	surface.ResetClipRect()
+	surface.DrawRect(list.X, list.Y, list.Width, list.Height, color.RGBA{180, 180, 180, 255})
+
+	if d.errorText != "" {
+		surface.DrawText(d.errorText, c.X+10, c.Y+c.Height-32, color.RGBA{200, 30, 30, 255}, d.fontSize)
+	}
+
+	// Keep the filter dropdown above the panes when open
+	if d.filterSelect.isOpen {
+		d.filterSelect.Draw(surface)
+	}
+}
+
+// HandleMouseDown navigates the tree and selects or opens files in the list.
+// Clicking a selected file again opens it.
+func (d *FileDialog) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !d.IsOpen() {
+		return
+	}
+
+	// The open filter dropdown overlaps the panes and gets clicks first
+	if d.filterSelect.isOpen {
+		d.Modal.HandleMouseDown(e)
+		return
+	}
+
+	p := Point{x, y}
+	if tree := d.treeRect(); PointInRect(p, tree) {
+		index := d.treeScroll + (y-tree.Y)/d.rowHeight
+		if index < len(d.tree) && d.tree[index].path != d.dir {
+			if err := d.SetDirectory(d.tree[index].path); err != nil {
+				d.errorText = err.Error()
+			}
+		}
+		e.Consume()
+		return
+	}
+
+	if list := d.listRect(); PointInRect(p, list) {
+		index := d.scroll + (y-list.Y)/d.rowHeight
+		if index < len(d.entries) {
+			if index == d.selected {
+				d.openEntry(index)
+			} else {
+				d.selectEntry(index)
+			}
+		}
+		e.Consume()
+		return
+	}
+
+	d.Modal.HandleMouseDown(e)
+}
+
+// HandleScroll scrolls the pane under the pointer
+func (d *FileDialog) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !d.IsOpen() {
+		return false
+	}
+
+	rows := -int(deltaY * 3)
+	p := Point{x, y}
+	clamp := func(v, count, visible int) int {
+		if v > count-visible {
+			v = count - visible
+		}
+		if v < 0 {
+			v = 0
+		}
+		return v
+	}
+	if tree := d.treeRect(); PointInRect(p, tree) {
+		d.treeScroll = clamp(d.treeScroll+rows, len(d.tree), d.visibleRows(tree))
+	} else if list := d.listRect(); PointInRect(p, list) {
+		d.scroll = clamp(d.scroll+rows, len(d.entries), d.visibleRows(list))
+	}
+	return true
+}
+
+// HandleKeyDown moves through the file list with the navigation keys, opens
+// the selection with Enter, goes up a directory with Backspace and cancels
+// with Esc. Keys the focused name box uses are handled there first.
+func (d *FileDialog) HandleKeyDown(event InputEvent) bool {
+	if !d.IsOpen() {
+		return false
+	}
+	if event.Type != InputTypeKeyDown {
+		return d.Modal.HandleKeyDown(event)
+	}
+
+	switch event.Key.Base() {
+	case KeyEscape:
+		d.cancel()
+		return true
+	case KeyEnter:
+		if d.selected >= 0 && d.entries[d.selected].isDir {
+			d.openEntry(d.selected)
+		} else {
+			d.accept()
+		}
+		return true
+	case KeyBackspace:
+		if parent := filepath.Dir(d.dir); parent != d.dir {
+			if err := d.SetDirectory(parent); err != nil {
+				d.errorText = err.Error()
+			}
+		}
+		return true
+	}
+
+	if index, ok := NavigateList(event, d.selected, len(d.entries), d.visibleRows(d.listRect())); ok {
+		d.selectEntry(index)
+		return true
+	}
+	return d.Modal.HandleKeyDown(event)
+}
@@ -0,0 +1,177 @@
+package components
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// ColorFilter defines a debug filter applied to the final rendered frame
+type ColorFilter int
+
+const (
+	ColorFilterNone         ColorFilter = iota
+	ColorFilterProtanopia               // Red-blind simulation
+	ColorFilterDeuteranopia             // Green-blind simulation
+	ColorFilterTritanopia               // Blue-blind simulation
+	ColorFilterGrayscale                // Luminance only, for checking contrast
+)
+
+// String returns a human-readable name for the filter
+func (f ColorFilter) String() string {
+	switch f {
+	case ColorFilterProtanopia:
+		return "Protanopia"
+	case ColorFilterDeuteranopia:
+		return "Deuteranopia"
+	case ColorFilterTritanopia:
+		return "Tritanopia"
+	case ColorFilterGrayscale:
+		return "Grayscale"
+	default:
+		return "None"
+	}
+}
+
+// colorFilterMatrices holds the row-major RGB transforms for each filter.
+// The color-blindness matrices follow Machado et al. (2009) at full severity.
+var colorFilterMatrices = map[ColorFilter][9]float32{
+	ColorFilterProtanopia: {
+		0.152286, 1.052583, -0.204868,
+		0.114503, 0.786281, 0.099216,
+		-0.003882, -0.048116, 1.051998,
+	},
+	ColorFilterDeuteranopia: {
+		0.367322, 0.860646, -0.227968,
+		0.280085, 0.672501, 0.047413,
+		-0.011820, 0.042940, 0.968881,
+	},
+	ColorFilterTritanopia: {
+		1.255528, -0.076749, -0.178779,
+		-0.078411, 0.930809, 0.147602,
+		0.004733, 0.691367, 0.303900,
+	},
+	ColorFilterGrayscale: {
+		0.2126, 0.7152, 0.0722,
+		0.2126, 0.7152, 0.0722,
+		0.2126, 0.7152, 0.0722,
+	},
+}
+
+// colorFilterShaderSource applies a 3x3 color matrix to un-premultiplied pixels
+var colorFilterShaderSource = []byte(`//kage:unit pixels
+
+package main
+
+var Matrix mat3
+
+func Fragment(dstPos vec4, srcPos vec2, color vec4) vec4 {
+	c := imageSrc0At(srcPos)
+	if c.a == 0 {
+		return c
+	}
+	rgb := clamp(Matrix*(c.rgb/c.a), 0, 1)
+	return vec4(rgb*c.a, c.a)
+}
+`)
+
+// ColorFilterPass renders a frame through a color filter shader.
+// Draw the UI into the image returned by Begin, then call End to
+// composite the filtered result onto the screen.
+type ColorFilterPass struct {
+	filter    ColorFilter
+	shader    *ebiten.Shader
+	offscreen *ebiten.Image
+	failed    bool
+}
+
+// NewColorFilterPass creates a new color filter pass with no filter active
+func NewColorFilterPass() *ColorFilterPass {
+	return &ColorFilterPass{
+		filter: ColorFilterNone,
+	}
+}
+
+// SetFilter sets the active filter
+func (p *ColorFilterPass) SetFilter(filter ColorFilter) {
+	p.filter = filter
+}
+
+// Filter returns the active filter
+func (p *ColorFilterPass) Filter() ColorFilter {
+	return p.filter
+}
+
+// CycleFilter switches to the next filter, wrapping back to none
+func (p *ColorFilterPass) CycleFilter() ColorFilter {
+	p.filter = (p.filter + 1) % (ColorFilterGrayscale + 1)
+	return p.filter
+}
+
+// IsActive returns whether a filter will be applied to the frame
+func (p *ColorFilterPass) IsActive() bool {
+	return p.filter != ColorFilterNone && !p.failed
+}
+
+// Begin returns the image the frame should be drawn into
+func (p *ColorFilterPass) Begin(screen *ebiten.Image) *ebiten.Image {
+	if !p.IsActive() || !p.ensureShader() {
+		return screen
+	}
+
+	// Recreate the offscreen buffer when the screen size changes
+	bounds := screen.Bounds()
+	if p.offscreen == nil || p.offscreen.Bounds() != bounds {
+		if p.offscreen != nil {
+			p.offscreen.Deallocate()
+		}
+		p.offscreen = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	}
+
+	p.offscreen.Clear()
+	return p.offscreen
+}
+
+// End draws the filtered frame onto the screen if Begin returned an offscreen buffer
+func (p *ColorFilterPass) End(screen *ebiten.Image) {
+	if !p.IsActive() || p.shader == nil || p.offscreen == nil {
+		return
+	}
+
+	m := colorFilterMatrices[p.filter]
+
+	// Kage matrices are column-major
+	matrix := []float32{
+		m[0], m[3], m[6],
+		m[1], m[4], m[7],
+		m[2], m[5], m[8],
+	}
+
+	bounds := p.offscreen.Bounds()
+	op := &ebiten.DrawRectShaderOptions{}
+	op.Images[0] = p.offscreen
+	op.Uniforms = map[string]any{
+		"Matrix": matrix,
+	}
+	screen.DrawRectShader(bounds.Dx(), bounds.Dy(), p.shader, op)
+}
+
+// ensureShader compiles the filter shader on first use
+func (p *ColorFilterPass) ensureShader() bool {
+	if p.shader != nil {
+		return true
+	}
+	if p.failed {
+		return false
+	}
+
+	shader, err := ebiten.NewShader(colorFilterShaderSource)
+	if err != nil {
+		fmt.Printf("Error compiling color filter shader: %v\n", err)
+		p.failed = true
+		return false
+	}
+
+	p.shader = shader
+	return true
+}
@@ -0,0 +1,247 @@
+package components
+
+// Focusable is implemented by elements that can receive keyboard focus
+type Focusable interface {
+	Element
+	Focus()
+	Blur()
+	IsFocused() bool
+}
+
+// FocusScope is a region of the UI, such as a dialog or popover, with its own
+// focus history. When the scope is closed, focus returns to its restore target.
+type FocusScope struct {
+	id            string
+	root          Element
+	history       []Focusable // Most recently focused last
+	opener        Focusable   // Element that had focus when the scope opened
+	restoreTarget Focusable
+	onClose       func()
+}
+
+// ID returns the scope's identifier
+func (s *FocusScope) ID() string {
+	return s.id
+}
+
+// Root returns the element whose subtree the scope covers
+func (s *FocusScope) Root() Element {
+	return s.root
+}
+
+// Opener returns the element that had focus when the scope was opened, or nil
+func (s *FocusScope) Opener() Focusable {
+	return s.opener
+}
+
+// SetRestoreTarget overrides the element that receives focus when the scope
+// closes; nil restores to the opener
+func (s *FocusScope) SetRestoreTarget(target Focusable) {
+	s.restoreTarget = target
+}
+
+// RestoreTarget returns the element that receives focus when the scope closes
+func (s *FocusScope) RestoreTarget() Focusable {
+	if s.restoreTarget != nil {
+		return s.restoreTarget
+	}
+	return s.opener
+}
+
+// SetOnClose sets a handler called after the scope is closed and focus restored
+func (s *FocusScope) SetOnClose(handler func()) {
+	s.onClose = handler
+}
+
+// History returns the elements focused within the scope, most recent last
+func (s *FocusScope) History() []Focusable {
+	return s.history
+}
+
+// LastFocused returns the most recently focused element in the scope, or nil
+func (s *FocusScope) LastFocused() Focusable {
+	if len(s.history) == 0 {
+		return nil
+	}
+	return s.history[len(s.history)-1]
+}
+
+// record moves an element to the end of the scope's history
+func (s *FocusScope) record(element Focusable) {
+	for i, f := range s.history {
+		if f == element {
+			s.history = append(s.history[:i], s.history[i+1:]...)
+			break
+		}
+	}
+	s.history = append(s.history, element)
+}
+
+// contains returns whether the element is inside the scope's subtree
+func (s *FocusScope) contains(element Element) bool {
+	var walk func(e Element) bool
+	walk = func(e Element) bool {
+		if e == element {
+			return true
+		}
+		for _, child := range e.Children() {
+			if walk(child) {
+				return true
+			}
+		}
+		return false
+	}
+	return walk(s.root)
+}
+
+// FocusManager tracks which element has keyboard focus and a stack of focus
+// scopes. The bottom scope covers the whole UI.
+type FocusManager struct {
+	scopes  []*FocusScope
+	focused Focusable
+}
+
+// NewFocusManager creates a focus manager whose root scope covers the given element
+func NewFocusManager(root Element) *FocusManager {
+	return &FocusManager{
+		scopes: []*FocusScope{{id: "root", root: root}},
+	}
+}
+
+// Focused returns the element with keyboard focus, or nil
+func (m *FocusManager) Focused() Focusable {
+	if m.focused != nil && !m.focused.IsFocused() {
+		m.focused = nil
+	}
+	return m.focused
+}
+
+// SetFocus moves keyboard focus to the element; nil clears focus
+func (m *FocusManager) SetFocus(element Focusable) {
+	if m.focused != nil && m.focused != element {
+		m.focused.Blur()
+	}
+	m.focused = element
+	if element == nil {
+		return
+	}
+
+	element.Focus()
+	m.CurrentScope().record(element)
+}
+
+// RequestFocus moves keyboard focus to the element and scrolls it into
+// view, e.g. to bring up the first invalid field of a form
+func (m *FocusManager) RequestFocus(element Focusable) {
+	m.SetFocus(element)
+	if scrolled, ok := element.(interface{ ScrollIntoView() }); ok {
+		scrolled.ScrollIntoView()
+	}
+}
+
+// CurrentScope returns the topmost open scope
+func (m *FocusManager) CurrentScope() *FocusScope {
+	return m.scopes[len(m.scopes)-1]
+}
+
+// PushScope opens a scope over the given subtree, remembering the currently
+// focused element so it can be restored when the scope closes
+func (m *FocusManager) PushScope(id string, root Element) *FocusScope {
+	scope := &FocusScope{
+		id:     id,
+		root:   root,
+		opener: m.Focused(),
+	}
+	m.scopes = append(m.scopes, scope)
+
+	// Keyboard input goes to the new scope, not the element that opened it
+	if scope.opener != nil {
+		scope.opener.Blur()
+		m.focused = nil
+	}
+	return scope
+}
+
+// PopScope closes the scope, along with any scopes opened after it, and
+// returns focus to its restore target. The root scope cannot be closed.
+func (m *FocusManager) PopScope(scope *FocusScope) {
+	index := -1
+	for i := 1; i < len(m.scopes); i++ {
+		if m.scopes[i] == scope {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return
+	}
+
+	closed := m.scopes[index:]
+	m.scopes = m.scopes[:index]
+
+	// Fall back to the last element focused in the scope below if the target is gone
+	target := scope.RestoreTarget()
+	if target == nil || !m.CurrentScope().contains(target) {
+		target = m.CurrentScope().LastFocused()
+	}
+	m.SetFocus(target)
+
+	for i := len(closed) - 1; i >= 0; i-- {
+		if closed[i].onClose != nil {
+			closed[i].onClose()
+		}
+	}
+}
+
+// Sync records focus changes made by elements themselves, e.g. a text field
+// focusing itself when clicked. Call it after dispatching input.
+func (m *FocusManager) Sync() {
+	scope := m.CurrentScope()
+	found := findFocused(scope.root, m.focused)
+	if found == nil {
+		if m.focused != nil && !m.focused.IsFocused() {
+			m.focused = nil
+		}
+		return
+	}
+	if found != m.focused {
+		m.SetFocus(found)
+	}
+}
+
+// findFocused returns a focused element in the subtree other than current,
+// or current if it is the only one
+func findFocused(element Element, current Focusable) Focusable {
+	var result Focusable
+	var walk func(e Element) bool
+	walk = func(e Element) bool {
+		if f, ok := e.(Focusable); ok && f.IsFocused() {
+			if f != current {
+				result = f
+				return true
+			}
+			result = f
+		}
+		for _, child := range e.Children() {
+			if walk(child) {
+				return true
+			}
+		}
+		return false
+	}
+	walk(element)
+	return result
+}
+
+// focusWithin returns whether an element inside the given one has focus
+func focusWithin(element Element) bool {
+	for _, child := range element.Children() {
+		if f, ok := child.(Focusable); ok && f.IsFocused() {
+			return true
+		}
+		if focusWithin(child) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,178 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+)
+
+// FontChain is an ordered list of font faces. Each rune is drawn with the
+// first face that has a glyph for it, e.g. a UI font, then a CJK font, then
+// an emoji font.
+type FontChain struct {
+	mu      sync.Mutex
+	faces   []font.Face
+	cache   map[rune]int // Index of the face covering a rune, -1 for none
+	missing map[rune]bool
+}
+
+// TextRun is a piece of text drawn with a single face; Face is nil when no
+// face in the chain covers the run's runes
+type TextRun struct {
+	Text string
+	Face font.Face
+}
+
+// NewFontChain creates a fallback chain from the given faces in order
+func NewFontChain(faces ...font.Face) *FontChain {
+	return &FontChain{
+		faces:   faces,
+		cache:   make(map[rune]int),
+		missing: make(map[rune]bool),
+	}
+}
+
+// DefaultFontChain returns a chain holding only the built-in bitmap font
+func DefaultFontChain() *FontChain {
+	return NewFontChain(basicfont.Face7x13)
+}
+
+// Add appends a face to the end of the chain
+func (c *FontChain) Add(face font.Face) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.faces = append(c.faces, face)
+	c.cache = make(map[rune]int)
+}
+
+// Faces returns the faces in fallback order
+func (c *FontChain) Faces() []font.Face {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]font.Face(nil), c.faces...)
+}
+
+// Primary returns the first face, used for metrics
+func (c *FontChain) Primary() font.Face {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.faces) == 0 {
+		return basicfont.Face7x13
+	}
+	return c.faces[0]
+}
+
+// FaceFor returns the first face with a glyph for the rune, or false if none has one
+func (c *FontChain) FaceFor(r rune) (font.Face, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index, ok := c.cache[r]
+	if !ok {
+		index = -1
+		for i, face := range c.faces {
+			if _, found := face.GlyphAdvance(r); found {
+				index = i
+				break
+			}
+		}
+		c.cache[r] = index
+	}
+	if index < 0 {
+		return nil, false
+	}
+	return c.faces[index], true
+}
+
+// Runs splits text into runs that share a face. Missing runes get a run of
+// their own with a nil face. Whitespace and control runes stay with the run
+// before them.
+func (c *FontChain) Runs(text string) []TextRun {
+	var runs []TextRun
+	start := 0
+	var current font.Face
+	started := false
+
+	for i, r := range text {
+		face, ok := c.FaceFor(r)
+		if !ok && (r == ' ' || r == '\t' || r < 0x20) {
+			if started {
+				continue
+			}
+			face, ok = c.Primary(), true
+		}
+		if !ok {
+			c.reportMissing(r)
+			if started {
+				runs = append(runs, TextRun{Text: text[start:i], Face: current})
+			}
+			runs = append(runs, TextRun{Text: string(r)})
+			start = i + len(string(r))
+			started = false
+			continue
+		}
+		if started && face != current {
+			runs = append(runs, TextRun{Text: text[start:i], Face: current})
+			start = i
+		}
+		if !started {
+			start = i
+			started = true
+		}
+		current = face
+	}
+	if started {
+		runs = append(runs, TextRun{Text: text[start:], Face: current})
+	}
+	return runs
+}
+
+// reportMissing prints a rune no face covers, once per rune
+func (c *FontChain) reportMissing(r rune) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.missing[r] {
+		return
+	}
+	c.missing[r] = true
+	fmt.Printf("finch: no font covers %U %q\n", r, r)
+}
+
+// MissingRunes returns the runes that could not be drawn so far
+func (c *FontChain) MissingRunes() []rune {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	runes := make([]rune, 0, len(c.missing))
+	for r := range c.missing {
+		runes = append(runes, r)
+	}
+	return runes
+}
+
+// tofuColor is the outline color of the box drawn for a missing glyph
+var tofuColor = color.RGBA{200, 0, 0, 255}
+
+var (
+	fontChainMu sync.RWMutex
+	activeFonts = DefaultFontChain()
+)
+
+// SetFontChain sets the fallback chain used to draw text; nil restores the built-in font
+func SetFontChain(chain *FontChain) {
+	fontChainMu.Lock()
+	defer fontChainMu.Unlock()
+	if chain == nil {
+		chain = DefaultFontChain()
+	}
+	activeFonts = chain
+}
+
+// CurrentFontChain returns the fallback chain used to draw text
+func CurrentFontChain() *FontChain {
+	fontChainMu.RLock()
+	defer fontChainMu.RUnlock()
+	return activeFonts
+}
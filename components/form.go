@@ -1,7 +1,10 @@
 package components
 
 import (
+	"image"
 	"image/color"
+	"reflect"
+	"strings"
 )
 
 // TextArea represents a multi-line text input
@@ -13,11 +16,30 @@ type TextArea struct {
 	onChange    func(string)
 	focused     bool
 	placeholder string
+	cursor      int // Rune index of the insertion point
+	singleLine  bool
+	filters     []InputFilter
+	onPaste     func(image.Image)
+	wrap        bool
+	scrollY     int // Pixels scrolled down
+	goalX       int // Cursor x kept while moving up and down, or -1
+	composition Composition // Input method text shown at the cursor
+	
+	// Wrapped lines, cached for the text and width they were laid out for
+	lines       []textLine
+	linesText   string
+	linesWidth  int
+}
+
+// textLine is a line of a text area as drawn, after wrapping, as rune
+// indices into the text; end is before the newline or wrap point
+type textLine struct {
+	start, end int
 }
 
 // NewTextArea creates a new text area
 func NewTextArea(id string) *TextArea {
-	return &TextArea{
+	t := &TextArea{
 		Node:        NewNode(id),
 		text:        "",
 		fontSize:    14,
@@ -25,12 +47,19 @@ func NewTextArea(id string) *TextArea {
 		onChange:    nil,
 		focused:     false,
 		placeholder: "",
+		wrap:        true,
+		goalX:       -1,
 	}
+	t.SetCursorShape(CursorText)
+	return t
 }
 
-// SetText sets the text content
+// SetText sets the text content, passed through the input filters
 func (t *TextArea) SetText(text string) {
-	t.text = text
+	t.text = applyInputFilters(t.filters, t.text, text)
+	if n := len([]rune(t.text)); t.cursor > n {
+		t.cursor = n
+	}
 	if t.onChange != nil {
 		t.onChange(t.text)
 	}
@@ -41,6 +70,245 @@ func (t *TextArea) GetText() string {
 	return t.text
 }
 
+// SetSingleLine makes the text area a one-line field. Enter and Up/Down are
+// then left for the surrounding UI, e.g. to submit a dialog.
+func (t *TextArea) SetSingleLine(singleLine bool) {
+	t.singleLine = singleLine
+}
+
+// SetWrap sets whether long lines wrap at word boundaries to fit the width,
+// which is the default; unwrapped lines are cut off. Single-line fields
+// never wrap.
+func (t *TextArea) SetWrap(wrap bool) {
+	t.wrap = wrap
+	t.lines = nil
+}
+
+// SetCursor moves the insertion point to the given rune index
+func (t *TextArea) SetCursor(pos int) {
+	if n := len([]rune(t.text)); pos > n {
+		pos = n
+	}
+	if pos < 0 {
+		pos = 0
+	}
+	t.cursor = pos
+	t.goalX = -1
+	t.scrollToCursor()
+}
+
+// LineCount returns the number of lines, counting newlines rather than
+// wrapped lines
+func (t *TextArea) LineCount() int {
+	return strings.Count(t.text, "\n") + 1
+}
+
+// Line returns the text of a line, counted from 0, without its newline
+func (t *TextArea) Line(line int) string {
+	lines := strings.Split(t.text, "\n")
+	if line < 0 || line >= len(lines) {
+		return ""
+	}
+	return lines[line]
+}
+
+// CursorLineColumn returns the line and column of the insertion point,
+// both counted from 0, ignoring wrapping
+func (t *TextArea) CursorLineColumn() (int, int) {
+	return lineColumn([]rune(t.text), t.cursor)
+}
+
+// SetCursorLineColumn moves the insertion point to a line and column, both
+// counted from 0; positions past the end of a line or the text are clamped
+func (t *TextArea) SetCursorLineColumn(line, column int) {
+	runes := []rune(t.text)
+	pos := 0
+	for ; line > 0 && pos < len(runes); line-- {
+		pos = lineEnd(runes, pos)
+		if pos < len(runes) {
+			pos++
+		}
+	}
+	t.SetCursor(min(pos + max(column, 0), lineEnd(runes, pos)))
+}
+
+// ScrollOffset returns the scroll position for saving in a session
+func (t *TextArea) ScrollOffset() Point {
+	return Point{0, t.scrollY}
+}
+
+// SetScrollOffset restores a saved scroll position
+func (t *TextArea) SetScrollOffset(offset Point) {
+	t.scrollY = offset.Y
+	t.clampScroll()
+}
+
+// Cursor returns the rune index of the insertion point
+func (t *TextArea) Cursor() int {
+	return t.cursor
+}
+
+// SetInputFilters sets the filters edits pass through before the change
+// handler sees them, e.g. DigitsOnly() and MaxLength(4) for a PIN field
+func (t *TextArea) SetInputFilters(filters ...InputFilter) {
+	t.filters = filters
+}
+
+// AddInputFilter adds a filter after the existing ones
+func (t *TextArea) AddInputFilter(filter InputFilter) {
+	t.filters = append(t.filters, filter)
+}
+
+// SetOnPasteImage sets the handler called with an image pasted from the
+// clipboard while the text area is focused, e.g. to add it as an attachment
+func (t *TextArea) SetOnPasteImage(handler func(image.Image)) {
+	t.onPaste = handler
+}
+
+// insert replaces the runes between from and to with s and moves the cursor
+// after it. Edits rejected by the input filters leave the text unchanged.
+func (t *TextArea) insert(from, to int, s string) {
+	text, cursor := filterEdit(t.filters, t.text, from, to, s)
+	if cursor < 0 {
+		return
+	}
+	t.cursor = cursor
+	t.SetText(text)
+}
+
+// visibleLines returns how many lines fit in the text area
+func (t *TextArea) visibleLines() int {
+	lines := t.contentRect().Height / t.lineHeight()
+	if lines < 1 {
+		lines = 1
+	}
+	return lines
+}
+
+// lineHeight returns the distance between lines
+func (t *TextArea) lineHeight() int {
+	return ScaleTextSize(t.fontSize) + 4
+}
+
+// contentRect returns the area text is drawn in, inside the padding and
+// the scrollbar
+func (t *TextArea) contentRect() Rect {
+	bounds := t.ComputedBounds()
+	return Rect{X: bounds.X + 5, Y: bounds.Y + 5, Width: bounds.Width - 14, Height: bounds.Height - 10}
+}
+
+// layoutLines returns the lines as drawn, wrapping each line of the text at
+// spaces to fit the width, or mid-word for words wider than a line
+func (t *TextArea) layoutLines() []textLine {
+	width := t.contentRect().Width
+	if t.lines != nil && t.linesText == t.text && t.linesWidth == width {
+		return t.lines
+	}
+	t.lines, t.linesText, t.linesWidth = nil, t.text, width
+	
+	runes := []rune(t.text)
+	for start := 0; ; {
+		end := lineEnd(runes, start)
+		pos := start
+		for {
+			if !t.wrap || t.singleLine || width <= 0 {
+				t.lines = append(t.lines, textLine{pos, end})
+				break
+			}
+			
+			// Fit as many runes as possible, remembering the last space
+			fit, lastSpace, x := pos, -1, 0
+			for fit < end {
+				x += MeasureText(string(runes[fit]))
+				if x > width {
+					break
+				}
+				if runes[fit] == ' ' {
+					lastSpace = fit + 1
+				}
+				fit++
+			}
+			if fit == end {
+				t.lines = append(t.lines, textLine{pos, end})
+				break
+			}
+			if lastSpace > pos {
+				fit = lastSpace
+			} else if fit == pos {
+				fit = pos + 1
+			}
+			t.lines = append(t.lines, textLine{pos, fit})
+			pos = fit
+		}
+		if end >= len(runes) {
+			break
+		}
+		start = end + 1
+	}
+	return t.lines
+}
+
+// lineOf returns the drawn line a position is on; a position where a line
+// wraps belongs to the start of the next line
+func (t *TextArea) lineOf(pos int) int {
+	lines := t.layoutLines()
+	for i := len(lines) - 1; i > 0; i-- {
+		if lines[i].start <= pos {
+			return i
+		}
+	}
+	return 0
+}
+
+// xOf returns the horizontal offset of a position within its drawn line
+func (t *TextArea) xOf(pos int) int {
+	line := t.layoutLines()[t.lineOf(pos)]
+	return MeasureText(string([]rune(t.text)[line.start:pos]))
+}
+
+// posAt returns the position on a drawn line closest to a horizontal offset
+func (t *TextArea) posAt(index, x int) int {
+	line := t.layoutLines()[index]
+	runes := []rune(t.text)
+	left := 0
+	for pos := line.start; pos < line.end; pos++ {
+		right := left + MeasureText(string(runes[pos]))
+		if x < (left + right) / 2 {
+			return pos
+		}
+		left = right
+	}
+	return line.end
+}
+
+// moveDrawnLines moves the cursor up or down by drawn lines, keeping it
+// near the same horizontal offset
+func (t *TextArea) moveDrawnLines(delta int) {
+	if t.goalX < 0 {
+		t.goalX = t.xOf(t.cursor)
+	}
+	index := t.lineOf(t.cursor) + delta
+	index = max(0, min(index, len(t.layoutLines()) - 1))
+	t.cursor = t.posAt(index, t.goalX)
+}
+
+// clampScroll keeps the scroll position within the text
+func (t *TextArea) clampScroll() {
+	maxScroll := len(t.layoutLines()) * t.lineHeight() - t.contentRect().Height
+	t.scrollY = max(0, min(t.scrollY, maxScroll))
+}
+
+// scrollToCursor scrolls just enough for the cursor's line to be in view
+func (t *TextArea) scrollToCursor() {
+	top := t.lineOf(t.cursor) * t.lineHeight()
+	if top < t.scrollY {
+		t.scrollY = top
+	} else if bottom := top + t.lineHeight(); bottom > t.scrollY + t.contentRect().Height {
+		t.scrollY = bottom - t.contentRect().Height
+	}
+	t.clampScroll()
+}
+
 // SetFontSize sets the font size
 func (t *TextArea) SetFontSize(size int) {
 	t.fontSize = size
@@ -75,13 +343,41 @@ func (t *TextArea) Draw(surface DrawSurface) {
 	// Draw border
 	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, color.RGBA{100, 100, 100, 255})
 	
-	// Draw text or placeholder if empty
+	// Draw the visible lines, or the placeholder if empty
+	content := t.contentRect()
+	lineHeight := t.lineHeight()
+	surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
 	if t.text != "" {
-		surface.DrawText(t.text, bounds.X + 5, bounds.Y + 5, t.textColor, t.fontSize)
+		runes := []rune(t.text)
+		for i, line := range t.layoutLines() {
+			y := content.Y + i*lineHeight - t.scrollY
+			if y + lineHeight < bounds.Y || y > bounds.Y + bounds.Height {
+				continue
+			}
+			surface.DrawText(string(runes[line.start:line.end]), content.X, y, t.textColor, t.fontSize)
+		}
 	} else if t.placeholder != "" {
 		// Draw placeholder with a lighter color
-		surface.DrawText(t.placeholder, bounds.X + 5, bounds.Y + 5, color.RGBA{180, 180, 180, 255}, t.fontSize)
+		surface.DrawText(t.placeholder, content.X, content.Y, color.RGBA{180, 180, 180, 255}, t.fontSize)
+	}
+	
+	// Draw the cursor, or the input method's composition over the text at
+	// the cursor
+	if t.focused && t.composition.Text != "" {
+		t.drawComposition(surface)
+	} else if t.focused {
+		cx := content.X + t.xOf(t.cursor)
+		cy := content.Y + t.lineOf(t.cursor)*lineHeight - t.scrollY
+		surface.DrawLine(cx, cy, cx, cy + lineHeight - 2, t.textColor)
+	}
+	
+	// Draw a scroll indicator when the text is taller than the area
+	if total := len(t.layoutLines()) * lineHeight; total > content.Height && content.Height > 0 {
+		length := max(16, content.Height * content.Height / total)
+		offset := t.scrollY * (content.Height - length) / (total - content.Height)
+		surface.FillRect(bounds.X + bounds.Width - 6, content.Y + offset, 3, length, color.RGBA{0, 0, 0, 70})
 	}
+	surface.ResetClipRect()
 	
 	// Draw children (if any)
 	for _, child := range t.Children() {
@@ -89,183 +385,223 @@ func (t *TextArea) Draw(surface DrawSurface) {
 	}
 }
 
-// HandleMouseDown handles mouse down events
-func (t *TextArea) HandleMouseDown(x, y int) bool {
+// HandleMouseDown focuses the text area and moves the cursor to the
+// clicked position
+func (t *TextArea) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
 	bounds := t.ComputedBounds()
 	if PointInRect(Point{x, y}, bounds) {
 		t.focused = true
-		return true
+		content := t.contentRect()
+		index := (y - content.Y + t.scrollY) / t.lineHeight()
+		index = max(0, min(index, len(t.layoutLines()) - 1))
+		t.cursor = t.posAt(index, x - content.X)
+		t.goalX = -1
+		e.Consume()
+		return
 	} else {
 		t.focused = false
 	}
-	
-	return false
 }
 
-// Select represents a dropdown select box
-type Select struct {
-	*Node
-	options        []string
-	selectedIndex  int
-	onChange       func(int)
-	isOpen         bool
-	backgroundColor color.RGBA
-	textColor      color.RGBA
-	fontSize       int
+// HandleScroll scrolls the text with the mouse wheel
+func (t *TextArea) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !t.IsVisible() || !PointInRect(Point{x, y}, t.ComputedBounds()) {
+		return false
+	}
+	old := t.scrollY
+	t.scrollY -= int(deltaY * float64(t.lineHeight() * 3))
+	t.clampScroll()
+	return t.scrollY != old
 }
 
-// NewSelect creates a new select box
-func NewSelect(id string, options []string) *Select {
-	return &Select{
-		Node:           NewNode(id),
-		options:        options,
-		selectedIndex:  -1,
-		onChange:       nil,
-		isOpen:         false,
-		backgroundColor: color.RGBA{240, 240, 240, 255},
-		textColor:      color.RGBA{0, 0, 0, 255},
-		fontSize:       14,
-	}
+// Focus gives the text area keyboard focus
+func (t *TextArea) Focus() {
+	t.focused = true
 }
 
-// SetOptions sets the available options
-func (s *Select) SetOptions(options []string) {
-	s.options = options
-	if s.selectedIndex >= len(options) {
-		s.selectedIndex = -1
-	}
+// Blur removes keyboard focus from the text area
+func (t *TextArea) Blur() {
+	t.focused = false
+	t.composition = Composition{}
 }
 
-// GetOptions returns the available options
-func (s *Select) GetOptions() []string {
-	return s.options
+// IsFocused returns whether the text area receives typed characters
+func (t *TextArea) IsFocused() bool {
+	return t.focused
 }
 
-// SetSelectedIndex sets the selected option index
-func (s *Select) SetSelectedIndex(index int) {
-	if index >= -1 && index < len(s.options) {
-		s.selectedIndex = index
-		if s.onChange != nil {
-			s.onChange(index)
-		}
-	}
+// CaretRect returns the bounds of the cursor on screen
+func (t *TextArea) CaretRect() Rect {
+	content := t.contentRect()
+	x := content.X + t.xOf(t.cursor)
+	y := content.Y + t.lineOf(t.cursor)*t.lineHeight() - t.scrollY
+	return Rect{X: x, Y: y, Width: 1, Height: t.lineHeight()}
 }
 
-// GetSelectedIndex returns the selected option index
-func (s *Select) GetSelectedIndex() int {
-	return s.selectedIndex
+// SetComposition shows text being composed with the input method at the
+// cursor; keys go to the input method until it is committed or cleared
+func (t *TextArea) SetComposition(composition Composition) {
+	t.composition = composition
 }
 
-// GetSelectedOption returns the selected option text
-func (s *Select) GetSelectedOption() string {
-	if s.selectedIndex >= 0 && s.selectedIndex < len(s.options) {
-		return s.options[s.selectedIndex]
-	}
-	return ""
+// Composition returns the input method text shown at the cursor
+func (t *TextArea) Composition() Composition {
+	return t.composition
 }
 
-// SetOnChange sets the change handler
-func (s *Select) SetOnChange(handler func(int)) {
-	s.onChange = handler
+// drawComposition draws the composed text at the cursor on a background
+// covering the text behind it, underlined, with the part being converted
+// underlined more heavily, and the cursor after it
+func (t *TextArea) drawComposition(surface DrawSurface) {
+	caret := t.CaretRect()
+	runes := []rune(t.composition.Text)
+	start := max(0, min(t.composition.SelectionStart, len(runes)))
+	end := max(start, min(t.composition.SelectionEnd, len(runes)))
+	width := MeasureText(t.composition.Text)
+	baseline := caret.Y + caret.Height - 3
+	
+	surface.FillRect(caret.X, caret.Y, width + 1, caret.Height, color.RGBA{255, 255, 255, 255})
+	surface.DrawText(t.composition.Text, caret.X, caret.Y, t.textColor, t.fontSize)
+	surface.DrawLine(caret.X, baseline, caret.X + width, baseline, t.textColor)
+	if end > start {
+		x1 := caret.X + MeasureText(string(runes[:start]))
+		x2 := caret.X + MeasureText(string(runes[:end]))
+		surface.FillRect(x1, baseline, x2 - x1, 2, t.textColor)
+	}
+	cx := caret.X + MeasureText(string(runes[:end]))
+	surface.DrawLine(cx, caret.Y, cx, caret.Y + caret.Height - 2, t.textColor)
 }
 
-// Draw draws the select box
-func (s *Select) Draw(surface DrawSurface) {
-	if !s.IsVisible() {
-		return
+// HandleKeyDown edits the text while the text area is focused. Held keys
+// repeat according to the input timing. Arrows, Home/End and PageUp/PageDown
+// move the cursor; with Ctrl, arrows and Backspace/Delete work a word at a
+// time and Home/End go to the start or end of the text.
+func (t *TextArea) HandleKeyDown(event InputEvent) (handled bool) {
+	if !t.focused || !t.IsVisible() {
+		return false
 	}
 	
-	bounds := s.ComputedBounds()
-	
-	// Draw background
-	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, s.backgroundColor)
-	
-	// Draw border
-	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, color.RGBA{100, 100, 100, 255})
-	
-	// Draw selected option or placeholder
-	text := "Select..."
-	if s.selectedIndex >= 0 && s.selectedIndex < len(s.options) {
-		text = s.options[s.selectedIndex]
+	// Keys belong to the input method while it is composing
+	if t.composition.Text != "" && event.Type == InputTypeKeyDown {
+		return true
 	}
 	
-	surface.DrawText(text, bounds.X + 5, bounds.Y + (bounds.Height - s.fontSize) / 2, s.textColor, s.fontSize)
-	
-	// Draw dropdown arrow
-	arrowX := bounds.X + bounds.Width - 20
-	arrowY := bounds.Y + bounds.Height / 2
+	runes := []rune(t.text)
+	if t.cursor > len(runes) {
+		t.cursor = len(runes)
+	}
 	
-	// Simple triangle
-	surface.DrawLine(arrowX, arrowY - 3, arrowX + 6, arrowY + 3, s.textColor)
-	surface.DrawLine(arrowX + 6, arrowY + 3, arrowX + 12, arrowY - 3, s.textColor)
+	// Keep the cursor in view after a key is used, and forget the column
+	// kept for Up/Down unless it moved vertically
+	goalX := t.goalX
+	defer func() {
+		if handled {
+			t.scrollToCursor()
+		} else {
+			t.goalX = goalX
+		}
+	}()
+	t.goalX = -1
 	
-	// If open, draw dropdown list
-	if s.isOpen {
-		dropdownHeight := len(s.options) * 20
-		
-		// Draw dropdown background
-		surface.FillRect(bounds.X, bounds.Y + bounds.Height, bounds.Width, dropdownHeight, s.backgroundColor)
-		
-		// Draw dropdown border
-		surface.DrawRect(bounds.X, bounds.Y + bounds.Height, bounds.Width, dropdownHeight, color.RGBA{100, 100, 100, 255})
-		
-		// Draw options
-		for i, option := range s.options {
-			optionY := bounds.Y + bounds.Height + i * 20
-			
-			// Highlight selected option
-			if i == s.selectedIndex {
-				surface.FillRect(bounds.X, optionY, bounds.Width, 20, color.RGBA{200, 200, 255, 255})
-			}
-			
-			// Draw option text
-			surface.DrawText(option, bounds.X + 5, optionY + 3, s.textColor, s.fontSize)
+	if event.Type == InputTypeChar {
+		if event.Char < 32 || event.CtrlDown {
+			return false
 		}
+		t.insert(t.cursor, t.cursor, string(event.Char))
+		return true
 	}
 	
-	// Draw children (if any)
-	for _, child := range s.Children() {
-		child.Draw(surface)
+	if pasteImageOnKey(event, t.onPaste) {
+		return true
 	}
-}
-
-// HandleMouseDown handles mouse down events
-func (s *Select) HandleMouseDown(x, y int) bool {
-	bounds := s.ComputedBounds()
 	
-	// Check if click is in main select box
-	if PointInRect(Point{x, y}, bounds) {
-		s.isOpen = !s.isOpen
-		return true
+	if t.singleLine {
+		switch event.Key.Base() {
+		case KeyEnter, KeyUp, KeyDown, KeyPageUp, KeyPageDown:
+			return false
+		}
 	}
 	
-	// If open, check if click is in dropdown area
-	if s.isOpen {
-		dropdownHeight := len(s.options) * 20
-		dropdownBounds := Rect{bounds.X, bounds.Y + bounds.Height, bounds.Width, dropdownHeight}
-		
-		if PointInRect(Point{x, y}, dropdownBounds) {
-			// Calculate which option was clicked
-			optionIndex := (y - (bounds.Y + bounds.Height)) / 20
-			if optionIndex >= 0 && optionIndex < len(s.options) {
-				s.SetSelectedIndex(optionIndex)
-				s.isOpen = false
-				return true
-			}
+	switch event.Key.Base() {
+	case KeyBackspace:
+		from := t.cursor - 1
+		if event.CtrlDown {
+			from = wordLeft(runes, t.cursor)
+		}
+		if from >= 0 && from < t.cursor {
+			t.insert(from, t.cursor, "")
+		}
+	case KeyDelete:
+		to := t.cursor + 1
+		if event.CtrlDown {
+			to = wordRight(runes, t.cursor)
+		}
+		if to <= len(runes) && to > t.cursor {
+			t.insert(t.cursor, to, "")
+		}
+	case KeyEnter:
+		t.insert(t.cursor, t.cursor, "\n")
+	case KeyLeft:
+		if event.CtrlDown {
+			t.cursor = wordLeft(runes, t.cursor)
+		} else if t.cursor > 0 {
+			t.cursor--
+		}
+	case KeyRight:
+		if event.CtrlDown {
+			t.cursor = wordRight(runes, t.cursor)
+		} else if t.cursor < len(runes) {
+			t.cursor++
+		}
+	case KeyUp:
+		t.goalX = goalX
+		t.moveDrawnLines(-1)
+	case KeyDown:
+		t.goalX = goalX
+		t.moveDrawnLines(1)
+	case KeyPageUp:
+		t.goalX = goalX
+		t.moveDrawnLines(-t.visibleLines())
+	case KeyPageDown:
+		t.goalX = goalX
+		t.moveDrawnLines(t.visibleLines())
+	case KeyHome:
+		if event.CtrlDown {
+			t.cursor = 0
+		} else {
+			t.cursor = t.layoutLines()[t.lineOf(t.cursor)].start
+		}
+	case KeyEnd:
+		if event.CtrlDown {
+			t.cursor = len(runes)
 		} else {
-			// Close dropdown if click outside
-			s.isOpen = false
-			return true
+			// Stop before the space a wrapped line breaks at, so the cursor
+			// stays on the line
+			line := t.layoutLines()[t.lineOf(t.cursor)]
+			t.cursor = line.end
+			if line.end > line.start && line.end < len(runes) && runes[line.end] != '\n' {
+				t.cursor--
+			}
 		}
+	case KeyEscape:
+		t.focused = false
+	default:
+		return false
 	}
 	
-	return false
+	return true
 }
 
 // Form represents a form container with submit capability
 type Form struct {
 	*Node
-	onSubmit func(map[string]string)
+	onSubmit           func(map[string]string)
+	bound              reflect.Value // Struct set by Bind
+	boundFields        []boundField
+	onBoundSubmit      func(error)
+	validators         []fieldValidators
+	onValidationFailed func(map[string]string)
 }
 
 // NewForm creates a new form
@@ -281,8 +617,25 @@ func (f *Form) SetOnSubmit(handler func(map[string]string)) {
 	f.onSubmit = handler
 }
 
-// Submit submits the form, collecting values from input elements
+// Submit validates the form, then writes the inputs into the bound struct
+// if there is one and collects values from input elements. If a field is
+// invalid the messages are shown and only the validation handler is called.
 func (f *Form) Submit() {
+	if errors := f.Validate(); len(errors) > 0 {
+		f.focusFirstError(f, errors)
+		if f.onValidationFailed != nil {
+			f.onValidationFailed(errors)
+		}
+		return
+	}
+	
+	if f.bound.IsValid() {
+		err := f.WriteBack()
+		if f.onBoundSubmit != nil {
+			f.onBoundSubmit(err)
+		}
+	}
+	
 	if f.onSubmit == nil {
 		return
 	}
@@ -295,6 +648,25 @@ func (f *Form) Submit() {
 	f.onSubmit(formData)
 }
 
+// Files returns the files attached in the form's AttachmentList with the
+// given ID. Submitted form data holds their paths one per line; use this
+// from the submit handler to read them, including files that were dropped.
+func (f *Form) Files(id string) []Attachment {
+	var found []Attachment
+	var walk func(element Element)
+	walk = func(element Element) {
+		if list, ok := element.(*AttachmentList); ok && list.ID() == id {
+			found = list.Attachments()
+			return
+		}
+		for _, child := range element.Children() {
+			walk(child)
+		}
+	}
+	walk(f)
+	return found
+}
+
 // collectFormData recursively collects form data from input elements
 func (f *Form) collectFormData(element Element, formData map[string]string) {
 	// Check if element is a form input and get its value
@@ -307,7 +679,23 @@ func (f *Form) collectFormData(element Element, formData map[string]string) {
 			formData[checkbox.ID()] = "false"
 		}
 	} else if select_, ok := element.(*Select); ok {
-		formData[select_.ID()] = select_.GetSelectedOption()
+		if select_.IsMultiple() {
+			formData[select_.ID()] = strings.Join(select_.GetSelectedOptions(), "\n")
+		} else {
+			formData[select_.ID()] = select_.GetSelectedOption()
+		}
+	} else if combo, ok := element.(*ComboBox); ok {
+		formData[combo.ID()] = combo.GetText()
+	} else if list, ok := element.(*AttachmentList); ok {
+		formData[list.ID()] = strings.Join(list.Paths(), "\n")
+	} else if picker, ok := element.(*TimePicker); ok {
+		formData[picker.ID()] = picker.String()
+	} else if picker, ok := element.(*DatePicker); ok {
+		if picker.HasValue() {
+			formData[picker.ID()] = picker.Value().Format("2006-01-02")
+		} else {
+			formData[picker.ID()] = ""
+		}
 	}
 	
 	// Recursively process children
@@ -329,14 +717,12 @@ func (f *Form) Draw(surface DrawSurface) {
 }
 
 // HandleMouseDown handles mouse down events
-func (f *Form) HandleMouseDown(x, y int) bool {
+func (f *Form) HandleMouseDown(e *Event) {
 	// Check if any children handle the event
 	for i := len(f.Children()) - 1; i >= 0; i-- {
 		child := f.Children()[i]
-		if child.HandleMouseDown(x, y) {
-			return true
+		if child.HandleMouseDown(e); e.Consumed() {
+			return
 		}
 	}
-	
-	return false
 } 
\ No newline at end of file
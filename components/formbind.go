@@ -0,0 +1,360 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dateLayout is how dates are written in text inputs and form data
+const dateLayout = "2006-01-02"
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// boundField is a struct field tied to a form input
+type boundField struct {
+	name  string
+	index int
+	input Element
+}
+
+// Bind ties the form to a struct, given as a pointer, and fills the inputs
+// from it. Each exported field is matched to the input whose ID is in its
+// `form:"id"` tag, or else to the input whose ID equals the field name
+// ignoring case; `form:"-"` skips a field. Fields can be strings, bools,
+// integers, floats, time.Time and time.Duration, and a multiple Select can
+// be bound to a []string of options or []int of indices. Submit writes the
+// inputs back into the struct before calling the handlers.
+//
+//	type Signup struct {
+//		Email   string    `form:"email"`
+//		Age     int       `form:"age"`
+//		Agree   bool      `form:"terms"`
+//		Birth   time.Time `form:"birthday"`
+//	}
+func (f *Form) Bind(target any) error {
+	if err := f.bind("Bind", target); err != nil {
+		return err
+	}
+	f.Populate()
+	return nil
+}
+
+// Attach ties the form to a struct like Bind, but leaves the inputs as they
+// are, e.g. to receive what was typed into a form built without one
+func (f *Form) Attach(target any) error {
+	return f.bind("Attach", target)
+}
+
+// bind matches the struct's fields to the form's inputs
+func (f *Form) bind(method string, target any) error {
+	v := reflect.ValueOf(target)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("form: %s needs a pointer to a struct, got %T", method, target)
+	}
+	v = v.Elem()
+
+	inputs := make(map[string]Element)
+	var walk func(element Element)
+	walk = func(element Element) {
+		switch element.(type) {
+		case *TextArea, *MaskedInput, *ComboBox, *Select, *Checkbox, *DatePicker, *TimePicker:
+			inputs[strings.ToLower(element.ID())] = element
+		}
+		for _, child := range element.Children() {
+			walk(child)
+		}
+	}
+	walk(f)
+
+	var fields []boundField
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		id := field.Tag.Get("form")
+		if !field.IsExported() || id == "-" {
+			continue
+		}
+		if id == "" {
+			id = field.Name
+		}
+		input, ok := inputs[strings.ToLower(id)]
+		if !ok {
+			continue
+		}
+		if !bindableType(field.Type) && !isMultiSelectField(input, field.Type) {
+			return fmt.Errorf("form: field %s has unsupported type %s", field.Name, field.Type)
+		}
+		fields = append(fields, boundField{name: field.Name, index: i, input: input})
+	}
+
+	f.bound, f.boundFields = v, fields
+	return nil
+}
+
+// SetOnBoundSubmit sets the handler called on submit after the inputs are
+// written into the bound struct. Values that can't be converted, such as
+// letters in a number field, fail validation first, so err is normally nil.
+func (f *Form) SetOnBoundSubmit(handler func(err error)) {
+	f.onBoundSubmit = handler
+}
+
+// Populate fills the inputs from the bound struct, e.g. after changing it
+func (f *Form) Populate() {
+	if !f.bound.IsValid() {
+		return
+	}
+	for _, field := range f.boundFields {
+		populateInput(field.input, f.bound.Field(field.index))
+	}
+}
+
+// WriteBack writes the inputs into the bound struct. Fields whose input
+// can't be converted keep their value and are reported in the error.
+func (f *Form) WriteBack() error {
+	if !f.bound.IsValid() {
+		return nil
+	}
+	var errs []error
+	for _, field := range f.boundFields {
+		if err := readInput(field.input, f.bound.Field(field.index)); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", field.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// bindableType returns whether a field type can be bound to an input
+func bindableType(t reflect.Type) bool {
+	if t == timeType || t == durationType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// isMultiSelectField returns whether a field is a []string or []int bound
+// to a multiple Select
+func isMultiSelectField(input Element, t reflect.Type) bool {
+	s, ok := input.(*Select)
+	return ok && s.IsMultiple() && t.Kind() == reflect.Slice &&
+		(t.Elem().Kind() == reflect.String || t.Elem().Kind() == reflect.Int)
+}
+
+// formatField returns a field's value as text for a text input
+func formatField(v reflect.Value) string {
+	switch {
+	case v.Type() == timeType:
+		if t := v.Interface().(time.Time); !t.IsZero() {
+			return t.Format(dateLayout)
+		}
+		return ""
+	case v.Type() == durationType:
+		return v.Interface().(time.Duration).String()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	}
+	return v.String()
+}
+
+// parseField sets a field from text typed into an input. Empty text gives
+// the zero value.
+func parseField(v reflect.Value, text string) error {
+	if v.Kind() == reflect.String {
+		v.SetString(text)
+		return nil
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		v.SetZero()
+		return nil
+	}
+	switch {
+	case v.Type() == timeType:
+		t, err := time.ParseInLocation(dateLayout, text, time.Local)
+		if err != nil {
+			return fmt.Errorf("%q is not a date like 2006-01-02", text)
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(text)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration like 1h30m", text)
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", text)
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a positive whole number", text)
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a number", text)
+		}
+		v.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", text)
+		}
+		v.SetBool(b)
+	}
+	return nil
+}
+
+// isIntKind returns whether a kind is a signed or unsigned integer
+func isIntKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Uint64
+}
+
+// populateInput shows a field's value in an input
+func populateInput(input Element, v reflect.Value) {
+	switch input := input.(type) {
+	case *Checkbox:
+		if v.Kind() == reflect.Bool {
+			input.SetChecked(v.Bool())
+		} else {
+			input.SetChecked(formatField(v) == "true")
+		}
+	case *DatePicker:
+		t, ok := v.Interface().(time.Time)
+		if !ok {
+			t, _ = time.ParseInLocation(dateLayout, formatField(v), time.Local)
+		}
+		if t.IsZero() {
+			input.ClearValue()
+		} else {
+			input.SetValue(t)
+		}
+	case *TimePicker:
+		switch value := v.Interface().(type) {
+		case time.Duration:
+			input.SetValue(value)
+		case time.Time:
+			input.SetTime(value)
+		default:
+			if d, err := time.ParseDuration(formatField(v)); err == nil {
+				input.SetValue(d)
+			}
+		}
+	case *Select:
+		if v.Kind() == reflect.Slice {
+			var indices []int
+			for i := 0; i < v.Len(); i++ {
+				if v.Index(i).Kind() == reflect.Int {
+					indices = append(indices, int(v.Index(i).Int()))
+				} else {
+					indices = append(indices, slices.Index(input.GetOptions(), v.Index(i).String()))
+				}
+			}
+			input.SetSelectedIndices(indices)
+		} else if isIntKind(v.Kind()) && v.Type() != durationType {
+			input.SetSelectedIndex(int(v.Convert(reflect.TypeOf(0)).Int()))
+		} else {
+			input.SetSelectedIndex(slices.Index(input.GetOptions(), formatField(v)))
+		}
+	case *TextArea:
+		input.SetText(formatField(v))
+	case *MaskedInput:
+		input.SetValue(formatField(v))
+	case *ComboBox:
+		input.SetText(formatField(v))
+	}
+}
+
+// readInput sets a field from an input's value
+func readInput(input Element, v reflect.Value) error {
+	switch input := input.(type) {
+	case *Checkbox:
+		if v.Kind() == reflect.Bool {
+			v.SetBool(input.IsChecked())
+			return nil
+		}
+		return parseField(v, strconv.FormatBool(input.IsChecked()))
+	case *DatePicker:
+		if v.Type() == timeType {
+			v.Set(reflect.ValueOf(input.Value()))
+			return nil
+		}
+		if !input.HasValue() {
+			return parseField(v, "")
+		}
+		return parseField(v, input.Value().Format(dateLayout))
+	case *TimePicker:
+		switch v.Type() {
+		case durationType:
+			v.SetInt(int64(input.Value()))
+			return nil
+		case timeType:
+			// Keep the field's date, with the picked time of day
+			date := v.Interface().(time.Time)
+			if date.IsZero() {
+				date = Now()
+			}
+			v.Set(reflect.ValueOf(input.Time(date)))
+			return nil
+		}
+		return parseField(v, input.String())
+	case *Select:
+		if v.Kind() == reflect.Slice {
+			values := reflect.MakeSlice(v.Type(), 0, len(input.GetSelectedIndices()))
+			for _, index := range input.GetSelectedIndices() {
+				if v.Type().Elem().Kind() == reflect.Int {
+					values = reflect.Append(values, reflect.ValueOf(index).Convert(v.Type().Elem()))
+				} else {
+					values = reflect.Append(values, reflect.ValueOf(input.GetOptions()[index]).Convert(v.Type().Elem()))
+				}
+			}
+			v.Set(values)
+			return nil
+		}
+		if isIntKind(v.Kind()) && v.Type() != durationType {
+			if v.Kind() >= reflect.Uint && input.GetSelectedIndex() < 0 {
+				return errors.New("nothing is selected")
+			}
+			v.Set(reflect.ValueOf(input.GetSelectedIndex()).Convert(v.Type()))
+			return nil
+		}
+		return parseField(v, input.GetSelectedOption())
+	case *TextArea:
+		return parseField(v, input.GetText())
+	case *MaskedInput:
+		return parseField(v, input.RawValue())
+	case *ComboBox:
+		return parseField(v, input.GetText())
+	}
+	return nil
+}
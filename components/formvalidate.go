@@ -0,0 +1,242 @@
+package components
+
+import (
+	"errors"
+	"image/color"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator checks the value of a form field as it appears in the submitted
+// form data, returning an error with the message to show if it's invalid
+type Validator func(value string) error
+
+// ValidateRequired rejects empty and blank values, and unchecked checkboxes
+func ValidateRequired(message string) Validator {
+	return func(value string) error {
+		if value = strings.TrimSpace(value); value == "" || value == "false" {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// ValidateMinLength rejects values shorter than n characters; empty values
+// pass, so combine it with ValidateRequired for required fields
+func ValidateMinLength(n int, message string) Validator {
+	return func(value string) error {
+		if value != "" && utf8.RuneCountInString(value) < n {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// ValidatePattern rejects values that don't match a regular expression;
+// empty values pass
+func ValidatePattern(pattern *regexp.Regexp, message string) Validator {
+	return func(value string) error {
+		if value != "" && !pattern.MatchString(value) {
+			return errors.New(message)
+		}
+		return nil
+	}
+}
+
+// emailPattern matches addresses with a local part, an @ and a domain with
+// at least one dot
+var emailPattern = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+// ValidateEmail rejects values that don't look like an email address; empty
+// values pass
+func ValidateEmail(message string) Validator {
+	return ValidatePattern(emailPattern, message)
+}
+
+// fieldValidators are the validators of one form field, run in order
+type fieldValidators struct {
+	id         string
+	validators []Validator
+}
+
+// SetValidators sets the validators of the input with the given ID,
+// replacing any set before; Submit runs them in order and stops at the
+// first that fails
+func (f *Form) SetValidators(id string, validators ...Validator) {
+	for i, field := range f.validators {
+		if field.id == id {
+			if len(validators) == 0 {
+				f.validators = append(f.validators[:i], f.validators[i+1:]...)
+			} else {
+				f.validators[i].validators = validators
+			}
+			return
+		}
+	}
+	if len(validators) > 0 {
+		f.validators = append(f.validators, fieldValidators{id: id, validators: validators})
+	}
+}
+
+// SetOnValidationFailed sets the handler called instead of the submit
+// handlers when a field is invalid, with the error messages by input ID
+func (f *Form) SetOnValidationFailed(handler func(errors map[string]string)) {
+	f.onValidationFailed = handler
+}
+
+// Validate checks every field, shows the messages in the form's ErrorText
+// slots, clearing the rest, and returns the messages by input ID. Fields of
+// a bound struct are also checked for values that can't be converted.
+func (f *Form) Validate() map[string]string {
+	formData := make(map[string]string)
+	f.collectFormData(f, formData)
+
+	messages := make(map[string]string)
+	for _, field := range f.validators {
+		for _, validate := range field.validators {
+			if err := validate(formData[field.id]); err != nil {
+				messages[field.id] = err.Error()
+				break
+			}
+		}
+	}
+	if f.bound.IsValid() {
+		for _, field := range f.boundFields {
+			id := field.input.ID()
+			if _, failed := messages[id]; failed {
+				continue
+			}
+			// Convert into a scratch value so the struct is left as it was
+			scratch := reflect.New(f.bound.Field(field.index).Type()).Elem()
+			scratch.Set(f.bound.Field(field.index))
+			if err := readInput(field.input, scratch); err != nil {
+				messages[id] = err.Error()
+			}
+		}
+	}
+
+	f.showErrors(f, messages, true)
+	return messages
+}
+
+// focusFirstError focuses the first invalid input under an element, in
+// tree order, and scrolls it into view. Returns whether it found one.
+func (f *Form) focusFirstError(element Element, messages map[string]string) bool {
+	if _, invalid := messages[element.ID()]; invalid {
+		if input, ok := element.(Focusable); ok {
+			input.Focus()
+			if scrolled, ok := element.(interface{ ScrollIntoView() }); ok {
+				scrolled.ScrollIntoView()
+			}
+			return true
+		}
+	}
+	for _, child := range element.Children() {
+		if f.focusFirstError(child, messages) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetFieldError shows a message in the ErrorText slots of an input, e.g. an
+// error from a server; an empty message clears them
+func (f *Form) SetFieldError(id, message string) {
+	f.showErrors(f, map[string]string{id: message}, false)
+}
+
+// ClearErrors empties every ErrorText slot in the form
+func (f *Form) ClearErrors() {
+	f.showErrors(f, nil, true)
+}
+
+// showErrors sets the message of each ErrorText slot under an element.
+// With all set, slots of fields missing from messages are cleared;
+// otherwise they're left as they are.
+func (f *Form) showErrors(element Element, messages map[string]string, all bool) {
+	if slot, ok := element.(*ErrorText); ok {
+		if message, found := messages[slot.Field()]; found || all {
+			slot.SetMessage(message)
+		}
+	}
+	for _, child := range element.Children() {
+		f.showErrors(child, messages, all)
+	}
+}
+
+// ErrorText is a slot under a form field that shows the field's validation
+// message in the theme's error color. It keeps its height while empty so
+// the form doesn't shift when messages come and go.
+type ErrorText struct {
+	*Node
+	field     string
+	message   string
+	textColor color.RGBA
+	fontSize  int
+}
+
+// ErrorTextFontSize is the standard font size of validation messages
+const ErrorTextFontSize = 12
+
+// NewErrorText creates an empty message slot for the input with the given ID
+func NewErrorText(id, field string) *ErrorText {
+	return &ErrorText{
+		Node:      NewNode(id),
+		field:     field,
+		textColor: LightTheme().Error,
+		fontSize:  ErrorTextFontSize,
+	}
+}
+
+// Field returns the ID of the input the messages are about
+func (e *ErrorText) Field() string {
+	return e.field
+}
+
+// SetMessage sets the message shown; empty hides it
+func (e *ErrorText) SetMessage(message string) {
+	e.message = message
+}
+
+// GetText returns the message shown
+func (e *ErrorText) GetText() string {
+	return e.message
+}
+
+// SetTextColor sets the message color
+func (e *ErrorText) SetTextColor(textColor color.RGBA) {
+	e.textColor = textColor
+}
+
+// TextColor returns the message color
+func (e *ErrorText) TextColor() color.RGBA {
+	return e.textColor
+}
+
+// SetFontSize sets the message font size
+func (e *ErrorText) SetFontSize(size int) {
+	e.fontSize = size
+}
+
+// FontSize returns the message font size
+func (e *ErrorText) FontSize() int {
+	return e.fontSize
+}
+
+// ApplyTheme colors the message with the theme's error color, if it has one
+func (e *ErrorText) ApplyTheme(theme Theme) {
+	if theme.Error.A != 0 {
+		e.textColor = theme.Error
+	}
+}
+
+// Draw draws the message, if any
+func (e *ErrorText) Draw(surface DrawSurface) {
+	if !e.IsVisible() || e.message == "" {
+		return
+	}
+	bounds := e.ComputedBounds()
+	surface.DrawText(e.message, bounds.X, bounds.Y+(bounds.Height-e.fontSize)/2, e.textColor, e.fontSize)
+}
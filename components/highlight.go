@@ -0,0 +1,248 @@
+package components
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// TokenKind is the syntax class of a piece of code, which sets its color
+type TokenKind int
+
+const (
+	TokenPlain TokenKind = iota
+	TokenKeyword
+	TokenType
+	TokenFunction
+	TokenString
+	TokenNumber
+	TokenComment
+	TokenLiteral  // true, false, nil, null
+	TokenProperty // Object keys, e.g. in JSON
+)
+
+// CodeToken is a run of a line in one syntax class, as rune offsets; parts
+// of the line not covered by a token are plain
+type CodeToken struct {
+	Kind       TokenKind
+	Start, End int
+}
+
+// Highlighter splits code into tokens a line at a time. The state carries
+// constructs that span lines, such as block comments, from one line to the
+// next; the first line starts at 0.
+type Highlighter interface {
+	HighlightLine(line []rune, state int) (tokens []CodeToken, next int)
+}
+
+var (
+	highlightersMu sync.RWMutex
+	highlighters   = map[string]Highlighter{
+		"go":   GoHighlighter{},
+		"json": JSONHighlighter{},
+	}
+)
+
+// RegisterHighlighter makes a highlighter available under a language name,
+// such as "yaml", which is also matched as a file extension
+func RegisterHighlighter(language string, highlighter Highlighter) {
+	highlightersMu.Lock()
+	defer highlightersMu.Unlock()
+	highlighters[strings.ToLower(language)] = highlighter
+}
+
+// HighlighterFor returns the highlighter for a language name or a file name
+// with its extension, such as "go" or "main.go", or nil if there is none
+func HighlighterFor(language string) Highlighter {
+	language = strings.ToLower(language)
+	if i := strings.LastIndex(language, "."); i >= 0 {
+		language = language[i+1:]
+	}
+	highlightersMu.RLock()
+	defer highlightersMu.RUnlock()
+	return highlighters[language]
+}
+
+// isIdentRune returns whether a rune can be part of an identifier
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// scanNumber returns the end of a number starting at i, accepting hex,
+// octal and binary prefixes, fractions, exponents and digit separators
+func scanNumber(line []rune, i int) int {
+	for i < len(line) {
+		r := unicode.ToLower(line[i])
+		switch {
+		case unicode.IsDigit(r) || r == '_' || r == '.' || (r >= 'a' && r <= 'f') || r == 'x' || r == 'o' || r == 'i':
+			i++
+		case (r == '+' || r == '-') && i > 0 && (unicode.ToLower(line[i-1]) == 'e' || unicode.ToLower(line[i-1]) == 'p'):
+			i++
+		case r == 'p':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// scanQuoted returns the end of a string or character literal opened at i,
+// skipping escaped quotes; unterminated literals run to the end of the line
+func scanQuoted(line []rune, i int) int {
+	quote := line[i]
+	for i++; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		}
+	}
+	return len(line)
+}
+
+// Go highlighter states
+const (
+	goStateCode = iota
+	goStateComment
+	goStateRawString
+)
+
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true,
+	"default": true, "defer": true, "else": true, "fallthrough": true, "for": true,
+	"func": true, "go": true, "goto": true, "if": true, "import": true,
+	"interface": true, "map": true, "package": true, "range": true, "return": true,
+	"select": true, "struct": true, "switch": true, "type": true, "var": true,
+}
+
+var goTypes = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true,
+	"complex128": true, "error": true, "float32": true, "float64": true, "int": true,
+	"int8": true, "int16": true, "int32": true, "int64": true, "rune": true,
+	"string": true, "uint": true, "uint8": true, "uint16": true, "uint32": true,
+	"uint64": true, "uintptr": true,
+}
+
+var goLiterals = map[string]bool{"true": true, "false": true, "nil": true, "iota": true}
+
+// GoHighlighter highlights Go source
+type GoHighlighter struct{}
+
+// HighlightLine tokenizes a line of Go
+func (GoHighlighter) HighlightLine(line []rune, state int) ([]CodeToken, int) {
+	var tokens []CodeToken
+	i := 0
+
+	// Finish a block comment or raw string from an earlier line
+	if state != goStateCode {
+		kind, end := TokenComment, "*/"
+		if state == goStateRawString {
+			kind, end = TokenString, "`"
+		}
+		j := strings.Index(string(line), end)
+		if j < 0 {
+			return []CodeToken{{kind, 0, len(line)}}, state
+		}
+		i = len([]rune(string(line)[:j])) + len([]rune(end))
+		tokens = append(tokens, CodeToken{kind, 0, i})
+		state = goStateCode
+	}
+
+	for i < len(line) {
+		r := line[i]
+		start := i
+		switch {
+		case r == '/' && i+1 < len(line) && line[i+1] == '/':
+			tokens = append(tokens, CodeToken{TokenComment, i, len(line)})
+			return tokens, goStateCode
+		case r == '/' && i+1 < len(line) && line[i+1] == '*':
+			i += 2
+			for i+1 < len(line) && !(line[i] == '*' && line[i+1] == '/') {
+				i++
+			}
+			if i+1 >= len(line) {
+				tokens = append(tokens, CodeToken{TokenComment, start, len(line)})
+				return tokens, goStateComment
+			}
+			i += 2
+			tokens = append(tokens, CodeToken{TokenComment, start, i})
+		case r == '`':
+			i++
+			for i < len(line) && line[i] != '`' {
+				i++
+			}
+			if i >= len(line) {
+				tokens = append(tokens, CodeToken{TokenString, start, len(line)})
+				return tokens, goStateRawString
+			}
+			i++
+			tokens = append(tokens, CodeToken{TokenString, start, i})
+		case r == '"' || r == '\'':
+			i = scanQuoted(line, i)
+			tokens = append(tokens, CodeToken{TokenString, start, i})
+		case unicode.IsDigit(r) || (r == '.' && i+1 < len(line) && unicode.IsDigit(line[i+1])):
+			i = scanNumber(line, i)
+			tokens = append(tokens, CodeToken{TokenNumber, start, i})
+		case isIdentRune(r):
+			for i < len(line) && isIdentRune(line[i]) {
+				i++
+			}
+			word := string(line[start:i])
+			switch {
+			case goKeywords[word]:
+				tokens = append(tokens, CodeToken{TokenKeyword, start, i})
+			case goTypes[word]:
+				tokens = append(tokens, CodeToken{TokenType, start, i})
+			case goLiterals[word]:
+				tokens = append(tokens, CodeToken{TokenLiteral, start, i})
+			case i < len(line) && line[i] == '(':
+				tokens = append(tokens, CodeToken{TokenFunction, start, i})
+			}
+		default:
+			i++
+		}
+	}
+	return tokens, state
+}
+
+// JSONHighlighter highlights JSON, coloring object keys apart from string
+// values
+type JSONHighlighter struct{}
+
+// HighlightLine tokenizes a line of JSON
+func (JSONHighlighter) HighlightLine(line []rune, state int) ([]CodeToken, int) {
+	var tokens []CodeToken
+	for i := 0; i < len(line); {
+		r := line[i]
+		start := i
+		switch {
+		case r == '"':
+			i = scanQuoted(line, i)
+			kind := TokenString
+			j := i
+			for j < len(line) && unicode.IsSpace(line[j]) {
+				j++
+			}
+			if j < len(line) && line[j] == ':' {
+				kind = TokenProperty
+			}
+			tokens = append(tokens, CodeToken{kind, start, i})
+		case r == '-' || unicode.IsDigit(r):
+			i = scanNumber(line, i+1)
+			tokens = append(tokens, CodeToken{TokenNumber, start, i})
+		case unicode.IsLetter(r):
+			for i < len(line) && unicode.IsLetter(line[i]) {
+				i++
+			}
+			switch string(line[start:i]) {
+			case "true", "false", "null":
+				tokens = append(tokens, CodeToken{TokenLiteral, start, i})
+			}
+		default:
+			i++
+		}
+	}
+	return tokens, state
+}
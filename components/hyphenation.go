@@ -0,0 +1,133 @@
+package components
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// softHyphen marks a break opportunity inside a word; it is only drawn,
+// as a hyphen, when the line breaks there
+const softHyphen = '\u00ad'
+
+// Hyphenator finds the places a word may be broken across lines
+type Hyphenator interface {
+	// Hyphenate returns rune offsets inside word where a hyphen may be inserted
+	Hyphenate(word string) []int
+}
+
+// HyphenatorFunc adapts a function to the Hyphenator interface
+type HyphenatorFunc func(word string) []int
+
+// Hyphenate calls the function
+func (f HyphenatorFunc) Hyphenate(word string) []int {
+	return f(word)
+}
+
+// SyllableHyphenator breaks words between syllables using vowel/consonant
+// patterns: a break goes before the last consonant of a cluster between two
+// vowels ("hap-pen", "mo-ment"). It is a heuristic, not a dictionary, and
+// suits languages spelled roughly as pronounced.
+type SyllableHyphenator struct {
+	Vowels   string   // Letters treated as vowels, lower case
+	Digraphs []string // Consonant pairs kept together, e.g. "th"
+	MinLeft  int      // Minimum letters before a break
+	MinRight int      // Minimum letters after a break
+}
+
+// Hyphenate returns the break positions in word
+func (h SyllableHyphenator) Hyphenate(word string) []int {
+	runes := []rune(strings.ToLower(word))
+	isVowel := func(i int) bool {
+		return strings.ContainsRune(h.Vowels, runes[i])
+	}
+
+	var breaks []int
+	for i := max(h.MinLeft, 1); i <= len(runes)-h.MinRight; i++ {
+		if !unicode.IsLetter(runes[i]) || !unicode.IsLetter(runes[i-1]) {
+			continue
+		}
+		// Consonant followed by a vowel starts a syllable if a vowel came before it
+		if i+1 >= len(runes) || isVowel(i) || !isVowel(i+1) {
+			continue
+		}
+		j := i - 1
+		for j >= 0 && unicode.IsLetter(runes[j]) && !isVowel(j) {
+			j--
+		}
+		if j < 0 || !unicode.IsLetter(runes[j]) {
+			continue
+		}
+		// Break before a digraph rather than inside it
+		at := i
+		for _, d := range h.Digraphs {
+			if at-1 > j && string(runes[at-1:at+1]) == d {
+				at--
+				break
+			}
+		}
+		if at >= h.MinLeft {
+			breaks = append(breaks, at)
+		}
+	}
+	return breaks
+}
+
+var (
+	hyphenatorMu sync.RWMutex
+	hyphenators  = map[string]Hyphenator{
+		"en": SyllableHyphenator{Vowels: "aeiouy", Digraphs: []string{"ch", "ck", "gh", "ph", "sh", "th", "wh"}, MinLeft: 2, MinRight: 3},
+		"de": SyllableHyphenator{Vowels: "aeiouyäöü", Digraphs: []string{"ch", "ck"}, MinLeft: 2, MinRight: 2},
+		"es": SyllableHyphenator{Vowels: "aeiouáéíóú", MinLeft: 2, MinRight: 2},
+		"it": SyllableHyphenator{Vowels: "aeiouàèéìòù", MinLeft: 2, MinRight: 2},
+		"fr": SyllableHyphenator{Vowels: "aeiouyàâéèêëîïôûù", Digraphs: []string{"ch", "gn", "ph"}, MinLeft: 2, MinRight: 3},
+		"nl": SyllableHyphenator{Vowels: "aeiouyë", MinLeft: 2, MinRight: 2},
+	}
+)
+
+// RegisterHyphenator sets the hyphenator used for a language, e.g. "en" or "pt-BR"
+func RegisterHyphenator(language string, h Hyphenator) {
+	hyphenatorMu.Lock()
+	defer hyphenatorMu.Unlock()
+	hyphenators[strings.ToLower(language)] = h
+}
+
+// HyphenatorFor returns the hyphenator for a language tag, falling back from
+// "en-GB" to "en", or nil if none is registered
+func HyphenatorFor(language string) Hyphenator {
+	hyphenatorMu.RLock()
+	defer hyphenatorMu.RUnlock()
+	language = strings.ToLower(strings.ReplaceAll(language, "_", "-"))
+	for language != "" {
+		if h, ok := hyphenators[language]; ok {
+			return h
+		}
+		i := strings.LastIndex(language, "-")
+		if i < 0 {
+			break
+		}
+		language = language[:i]
+	}
+	return nil
+}
+
+// hyphenationPoints returns the break positions in word: its soft hyphens if
+// it has any, otherwise those from the language's hyphenator
+func hyphenationPoints(word string, h Hyphenator) []int {
+	if strings.ContainsRune(word, softHyphen) {
+		var points []int
+		i := 0
+		for _, r := range word {
+			if r == softHyphen {
+				points = append(points, i)
+				continue
+			}
+			i++
+		}
+		return points
+	}
+	if h == nil {
+		return nil
+	}
+	return h.Hyphenate(word)
+}
@@ -0,0 +1,177 @@
+package components
+
+import (
+	"image"
+	"image/color"
+	"sort"
+	"sync"
+)
+
+// iconGrid is the size of the square grid built-in icons are drawn on,
+// scaled to the icon's bounds
+const iconGrid = 16
+
+// iconDrawer draws an icon into a square box in a color
+type iconDrawer func(surface DrawSurface, box Rect, c color.RGBA)
+
+// iconLines returns a drawer for an icon made of lines on the icon grid,
+// each given as x1, y1, x2, y2
+func iconLines(lines ...[4]int) iconDrawer {
+	return func(surface DrawSurface, box Rect, c color.RGBA) {
+		at := func(v int) int { return v * box.Width / iconGrid }
+		for _, l := range lines {
+			surface.DrawLine(box.X+at(l[0]), box.Y+at(l[1]), box.X+at(l[2]), box.Y+at(l[3]), c)
+		}
+	}
+}
+
+var (
+	iconsMu sync.RWMutex
+	icons   = map[string]iconDrawer{
+		"check":         iconLines([4]int{3, 8, 7, 12}, [4]int{7, 12, 13, 4}),
+		"chevron-down":  iconLines([4]int{3, 6, 8, 11}, [4]int{8, 11, 13, 6}),
+		"chevron-left":  iconLines([4]int{10, 3, 5, 8}, [4]int{5, 8, 10, 13}),
+		"chevron-right": iconLines([4]int{6, 3, 11, 8}, [4]int{11, 8, 6, 13}),
+		"chevron-up":    iconLines([4]int{3, 10, 8, 5}, [4]int{8, 5, 13, 10}),
+		"close":         iconLines([4]int{3, 3, 13, 13}, [4]int{3, 13, 13, 3}),
+		"menu":          iconLines([4]int{2, 4, 14, 4}, [4]int{2, 8, 14, 8}, [4]int{2, 12, 14, 12}),
+		"minus":         iconLines([4]int{3, 8, 13, 8}),
+		"plus":          iconLines([4]int{8, 3, 8, 13}, [4]int{3, 8, 13, 8}),
+		"search": func(surface DrawSurface, box Rect, c color.RGBA) {
+			at := func(v int) int { return v * box.Width / iconGrid }
+			surface.DrawCircle(box.X+at(7), box.Y+at(7), at(4), c)
+			surface.DrawLine(box.X+at(10), box.Y+at(10), box.X+at(14), box.Y+at(14), c)
+		},
+	}
+)
+
+// RegisterIcon adds an image as a named icon, replacing any with the same
+// name. Image icons are drawn in their own colors.
+func RegisterIcon(name string, img image.Image) {
+	iconsMu.Lock()
+	defer iconsMu.Unlock()
+	icons[name] = func(surface DrawSurface, box Rect, c color.RGBA) {
+		surface.DrawImage(img, box.X, box.Y, box.Width, box.Height, ImageFitContain)
+	}
+}
+
+// IconNames returns the names of every icon, built-in and registered, sorted
+func IconNames() []string {
+	iconsMu.RLock()
+	defer iconsMu.RUnlock()
+	names := make([]string, 0, len(icons))
+	for name := range icons {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupIcon returns the drawer for a named icon
+func lookupIcon(name string) (iconDrawer, bool) {
+	iconsMu.RLock()
+	defer iconsMu.RUnlock()
+	draw, ok := icons[name]
+	return draw, ok
+}
+
+// Icon draws a named icon, either a built-in line icon such as "close" or
+// "search", or an image added with RegisterIcon
+type Icon struct {
+	*Node
+	name    string
+	color   color.RGBA
+	colored bool // The color was set, so the theme doesn't change it
+	pressed bool
+	onClick func()
+}
+
+// NewIcon creates an icon showing the named icon
+func NewIcon(id string, name string) *Icon {
+	return &Icon{
+		Node:  NewNode(id),
+		name:  name,
+		color: color.RGBA{0, 0, 0, 255},
+	}
+}
+
+// SetName changes which icon is shown
+func (i *Icon) SetName(name string) {
+	i.name = name
+}
+
+// Name returns the name of the icon shown
+func (i *Icon) Name() string {
+	return i.name
+}
+
+// SetColor sets the color line icons are drawn in, in place of the
+// theme's text color
+func (i *Icon) SetColor(c color.RGBA) {
+	i.color = c
+	i.colored = true
+}
+
+// ApplyTheme draws line icons in the text color unless a color was set
+func (i *Icon) ApplyTheme(theme Theme) {
+	if !i.colored {
+		i.color = theme.Text
+	}
+}
+
+// SetOnClick sets the handler called when the icon is clicked, showing a
+// pointer cursor over it while set
+func (i *Icon) SetOnClick(handler func()) {
+	i.onClick = handler
+	if handler != nil {
+		i.SetCursorShape(CursorPointer)
+	} else {
+		i.SetCursorShape(CursorDefault)
+	}
+}
+
+// Draw draws the icon centered in a square that fits the bounds. Unknown
+// names are drawn as an empty box.
+func (i *Icon) Draw(surface DrawSurface) {
+	if !i.IsVisible() {
+		return
+	}
+	bounds := i.ComputedBounds()
+	size := min(bounds.Width, bounds.Height)
+	box := Rect{
+		X:      bounds.X + (bounds.Width-size)/2,
+		Y:      bounds.Y + (bounds.Height-size)/2,
+		Width:  size,
+		Height: size,
+	}
+
+	draw, ok := lookupIcon(i.name)
+	if !ok {
+		surface.DrawRect(box.X+size/8, box.Y+size/8, size*3/4, size*3/4, i.color)
+		return
+	}
+	draw(surface, box, i.color)
+}
+
+// HandleMouseDown starts a click on a clickable icon
+func (i *Icon) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if i.onClick == nil || !i.IsVisible() || !PointInRect(Point{x, y}, i.ComputedBounds()) {
+		return
+	}
+	i.pressed = true
+	e.Consume()
+}
+
+// HandleMouseUp clicks the icon if the button is released over it
+func (i *Icon) HandleMouseUp(e *Event) {
+	x, y := e.X, e.Y
+	if !i.pressed {
+		return
+	}
+	i.pressed = false
+	if i.onClick != nil && PointInRect(Point{x, y}, i.ComputedBounds()) {
+		i.onClick()
+	}
+	e.Consume()
+}
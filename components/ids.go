@@ -0,0 +1,139 @@
+package components
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+)
+
+var (
+	idCounter atomic.Uint64
+	idPrefix  atomic.Pointer[string]
+)
+
+// NewID returns an ID that no other call returns, made of the ID prefix, a
+// kind such as "button" and a number, e.g. "button_12"
+func NewID(kind string) string {
+	prefix := ""
+	if p := idPrefix.Load(); p != nil {
+		prefix = *p
+	}
+	return prefix + kind + "_" + strconv.FormatUint(idCounter.Add(1), 10)
+}
+
+// SetIDPrefix sets text put before every ID from NewID, e.g. "settings." to
+// tell apart the elements of several UIs in one process
+func SetIDPrefix(prefix string) {
+	idPrefix.Store(&prefix)
+}
+
+// baseElement is an element built on a BaseElement, as every element is
+type baseElement interface {
+	base() *BaseElement
+}
+
+// base returns the element itself
+func (b *BaseElement) base() *BaseElement {
+	return b
+}
+
+// baseOf returns the BaseElement of an element, or nil
+func baseOf(element Element) *BaseElement {
+	if e, ok := element.(baseElement); ok {
+		return e.base()
+	}
+	return nil
+}
+
+// rootOf returns the BaseElement at the top of an element's tree
+func rootOf(b *BaseElement) *BaseElement {
+	for b.parent != nil {
+		parent := baseOf(b.parent)
+		if parent == nil {
+			break
+		}
+		b = parent
+	}
+	return b
+}
+
+// idIndex returns the number of elements with each ID in the tree, kept on
+// the tree's root. It is built when first needed, then kept up to date as
+// elements are added and removed.
+func (b *BaseElement) idIndex() map[string]int {
+	if b.ids == nil {
+		b.ids = make(map[string]int)
+		countIDs(b, b.ids)
+	}
+	return b.ids
+}
+
+// countIDs adds the IDs of an element and its descendants to counts
+func countIDs(element Element, counts map[string]int) {
+	if id := element.ID(); id != "" {
+		counts[id]++
+	}
+	for _, child := range element.Children() {
+		countIDs(child, counts)
+	}
+}
+
+// attachIDs adds the IDs of a subtree joining a parent's tree to the
+// tree's index, reporting any already used there, which lookups by ID
+// can't tell apart
+func attachIDs(parent *BaseElement, child Element) {
+	root := rootOf(parent)
+	index := root.idIndex()
+
+	added := make(map[string]int)
+	if b := baseOf(child); b != nil && b.ids != nil {
+		added = b.ids
+		b.ids = nil // Only the root keeps an index
+	} else {
+		countIDs(child, added)
+	}
+	for id, n := range added {
+		if index[id] > 0 {
+			fmt.Printf("Warning: element ID %q is already used in the tree of %s\n", id, root.id)
+		}
+		index[id] += n
+	}
+}
+
+// detachIDs removes the IDs of a subtree leaving a parent's tree from the
+// tree's index; the subtree keeps them as its own
+func detachIDs(parent *BaseElement, child Element) {
+	root := rootOf(parent)
+	if root.ids == nil {
+		return
+	}
+	removed := make(map[string]int)
+	countIDs(child, removed)
+	for id, n := range removed {
+		if root.ids[id] -= n; root.ids[id] <= 0 {
+			delete(root.ids, id)
+		}
+	}
+	if b := baseOf(child); b != nil {
+		b.ids = removed
+	}
+}
+
+// renameID updates the index of an element's tree for a new ID
+func renameID(b *BaseElement, oldID, newID string) {
+	root := rootOf(b)
+	if root.ids == nil {
+		return
+	}
+	if oldID != "" {
+		if root.ids[oldID]--; root.ids[oldID] <= 0 {
+			delete(root.ids, oldID)
+		}
+	}
+	if newID != "" {
+		if root.ids[newID] > 0 {
+			fmt.Printf("Warning: element ID %q is already used in the tree of %s\n", newID, root.id)
+		}
+		root.ids[newID]++
+	}
+}
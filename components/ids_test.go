@@ -0,0 +1,122 @@
+package components
+
+import (
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestNewIDUnique(t *testing.T) {
+	const goroutines, perGoroutine = 8, 500
+	ids := make(chan string, goroutines*perGoroutine)
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < perGoroutine; j++ {
+				ids <- NewID("button")
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[string]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Fatalf("NewID returned %q twice", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestNewIDPrefix(t *testing.T) {
+	defer SetIDPrefix("")
+
+	if id := NewID("label"); !strings.HasPrefix(id, "label_") {
+		t.Errorf("NewID(%q) = %q, want the kind first", "label", id)
+	}
+	SetIDPrefix("settings.")
+	if id := NewID("label"); !strings.HasPrefix(id, "settings.label_") {
+		t.Errorf("NewID(%q) = %q, want the prefix first", "label", id)
+	}
+}
+
+func TestIDIndexCountsDuplicates(t *testing.T) {
+	root := NewBaseElement("root")
+	root.AddChild(NewBaseElement("a"))
+	child := NewBaseElement("b")
+	root.AddChild(child)
+	child.AddChild(NewBaseElement("a"))
+
+	index := root.idIndex()
+	if index["a"] != 2 || index["b"] != 1 || index["root"] != 1 {
+		t.Errorf("index = %v, want a twice and b and root once", index)
+	}
+	if child.ids != nil {
+		t.Error("an element below the root keeps an index")
+	}
+}
+
+func TestIDIndexSubtrees(t *testing.T) {
+	root := NewBaseElement("root")
+	root.idIndex()
+
+	// A subtree built on its own brings its index along
+	subtree := NewBaseElement("panel")
+	subtree.AddChild(NewBaseElement("field"))
+	subtree.AddChild(NewBaseElement("field"))
+	if n := subtree.idIndex()["field"]; n != 2 {
+		t.Fatalf("subtree counts field %d times, want 2", n)
+	}
+	root.AddChild(subtree)
+	if n := root.idIndex()["field"]; n != 2 {
+		t.Errorf("root counts field %d times after adding the subtree, want 2", n)
+	}
+	if subtree.ids != nil {
+		t.Error("the subtree kept its index after joining the tree")
+	}
+
+	// Removing it takes its IDs out of the tree, and the subtree keeps them
+	root.RemoveChild(subtree)
+	if _, ok := root.idIndex()["field"]; ok {
+		t.Error("root still counts field after removing the subtree")
+	}
+	if n := subtree.idIndex()["field"]; n != 2 {
+		t.Errorf("removed subtree counts field %d times, want 2", n)
+	}
+	if subtree.Parent() != nil {
+		t.Error("removed subtree still has a parent")
+	}
+}
+
+func TestIDIndexRename(t *testing.T) {
+	root := NewBaseElement("root")
+	child := NewBaseElement("old")
+	root.AddChild(child)
+	root.idIndex()
+
+	child.SetID("new")
+
+	index := root.idIndex()
+	if _, ok := index["old"]; ok {
+		t.Error("index still has the old ID")
+	}
+	if index["new"] != 1 {
+		t.Errorf("index counts the new ID %d times, want 1", index["new"])
+	}
+}
+
+func TestIDIndexRemoveAllChildren(t *testing.T) {
+	root := NewBaseElement("root")
+	root.AddChild(NewBaseElement("a"))
+	root.AddChild(NewBaseElement("b"))
+
+	root.RemoveAllChildren()
+
+	index := root.idIndex()
+	if len(index) != 1 || index["root"] != 1 {
+		t.Errorf("index = %v, want only root", index)
+	}
+}
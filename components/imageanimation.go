@@ -0,0 +1,279 @@
+package components
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"io"
+	"time"
+)
+
+// ImageAnimation is a sequence of frames played by an Image, decoded from an
+// animated GIF or cut from a sprite sheet. It is also an image.Image showing
+// its first frame, so it can be used wherever a still image can.
+type ImageAnimation struct {
+	Frames []image.Image
+	Delays []time.Duration // How long each frame shows
+	Loops  int             // Times to play through; 0 repeats forever
+}
+
+// minFrameDelay is used for frames with no delay, as browsers do
+const minFrameDelay = 100 * time.Millisecond
+
+// ColorModel returns the first frame's color model
+func (a *ImageAnimation) ColorModel() color.Model {
+	return a.Frames[0].ColorModel()
+}
+
+// Bounds returns the first frame's bounds
+func (a *ImageAnimation) Bounds() image.Rectangle {
+	return a.Frames[0].Bounds()
+}
+
+// At returns a pixel of the first frame
+func (a *ImageAnimation) At(x, y int) color.Color {
+	return a.Frames[0].At(x, y)
+}
+
+// Delay returns how long a frame shows, never less than 10ms
+func (a *ImageAnimation) Delay(frame int) time.Duration {
+	if frame < len(a.Delays) && a.Delays[frame] >= 10*time.Millisecond {
+		return a.Delays[frame]
+	}
+	return minFrameDelay
+}
+
+// Duration returns the time to play through all frames once
+func (a *ImageAnimation) Duration() time.Duration {
+	var total time.Duration
+	for frame := range a.Frames {
+		total += a.Delay(frame)
+	}
+	return total
+}
+
+// DecodeAnimation decodes every frame of a GIF. Frames are composited onto
+// the full canvas following each frame's disposal method, so they can be
+// drawn on their own.
+func DecodeAnimation(r io.Reader) (*ImageAnimation, error) {
+	g, err := gif.DecodeAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	canvasRect := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if canvasRect.Empty() && len(g.Image) > 0 {
+		canvasRect = g.Image[0].Bounds()
+	}
+	canvas := image.NewRGBA(canvasRect)
+	anim := &ImageAnimation{}
+
+	for i, frame := range g.Image {
+		var previous *image.RGBA
+		if i < len(g.Disposal) && g.Disposal[i] == gif.DisposalPrevious {
+			previous = cloneRGBA(canvas)
+		}
+
+		draw.Draw(canvas, frame.Bounds(), frame, frame.Bounds().Min, draw.Over)
+		anim.Frames = append(anim.Frames, cloneRGBA(canvas))
+		delay := time.Duration(0)
+		if i < len(g.Delay) {
+			delay = time.Duration(g.Delay[i]) * 10 * time.Millisecond
+		}
+		anim.Delays = append(anim.Delays, delay)
+
+		if i < len(g.Disposal) {
+			switch g.Disposal[i] {
+			case gif.DisposalBackground:
+				draw.Draw(canvas, frame.Bounds(), image.Transparent, image.Point{}, draw.Src)
+			case gif.DisposalPrevious:
+				canvas = previous
+			}
+		}
+	}
+
+	// GIF loop counts are repeats after the first play, with -1 for none
+	switch {
+	case g.LoopCount < 0:
+		anim.Loops = 1
+	case g.LoopCount > 0:
+		anim.Loops = g.LoopCount + 1
+	}
+	return anim, nil
+}
+
+// cloneRGBA copies an image's pixels
+func cloneRGBA(src *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(src.Bounds())
+	copy(dst.Pix, src.Pix)
+	return dst
+}
+
+// NewSpriteSheet cuts frames of the given size from a sheet, left to right
+// then top to bottom. count limits the number of frames for sheets with a
+// partly filled last row; 0 uses every cell.
+func NewSpriteSheet(sheet image.Image, frameWidth, frameHeight, count int, frameDelay time.Duration) *ImageAnimation {
+	bounds := sheet.Bounds()
+	anim := &ImageAnimation{}
+	if frameWidth <= 0 || frameHeight <= 0 {
+		return anim
+	}
+
+	for y := bounds.Min.Y; y+frameHeight <= bounds.Max.Y; y += frameHeight {
+		for x := bounds.Min.X; x+frameWidth <= bounds.Max.X; x += frameWidth {
+			if count > 0 && len(anim.Frames) == count {
+				return anim
+			}
+			anim.Frames = append(anim.Frames, cropImage(sheet, image.Rect(x, y, x+frameWidth, y+frameHeight)))
+			anim.Delays = append(anim.Delays, frameDelay)
+		}
+	}
+	return anim
+}
+
+// cropImage returns part of an image, sharing pixels when the image supports it
+func cropImage(img image.Image, rect image.Rectangle) image.Image {
+	if sub, ok := img.(interface {
+		SubImage(image.Rectangle) image.Image
+	}); ok {
+		return sub.SubImage(rect)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, rect.Dx(), rect.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, rect.Min, draw.Src)
+	return dst
+}
+
+// SetAnimation shows an animation, starting from the first frame. It plays
+// straight away unless the user prefers reduced motion, in which case the
+// first frame stays until Play is called.
+func (i *Image) SetAnimation(anim *ImageAnimation) {
+	i.SetSource(nil)
+	if anim == nil || len(anim.Frames) == 0 {
+		return
+	}
+	i.anim = anim
+	i.loops = anim.Loops
+	i.showFrame(0)
+	i.loadState = ImageLoaded
+	i.playing = !ReducedMotion()
+}
+
+// Animation returns the animation being shown, or nil for a still image
+func (i *Image) Animation() *ImageAnimation {
+	return i.anim
+}
+
+// Play starts or resumes the animation. After a finished animation it
+// starts again from the first frame.
+func (i *Image) Play() {
+	if i.anim == nil || i.playing {
+		return
+	}
+	if i.finished {
+		i.showFrame(0)
+	} else {
+		i.frameStart = Now().Add(-i.pausedAt)
+	}
+	i.playing = true
+}
+
+// Pause stops the animation on the current frame
+func (i *Image) Pause() {
+	if !i.playing {
+		return
+	}
+	i.advance()
+	i.pausedAt = since(i.frameStart)
+	i.playing = false
+}
+
+// Stop pauses the animation and goes back to the first frame
+func (i *Image) Stop() {
+	i.playing = false
+	if i.anim != nil {
+		i.showFrame(0)
+	}
+}
+
+// IsPlaying returns whether the animation is running
+func (i *Image) IsPlaying() bool {
+	return i.playing
+}
+
+// SetFrame shows a frame of the animation
+func (i *Image) SetFrame(frame int) {
+	if i.anim == nil || frame < 0 || frame >= len(i.anim.Frames) {
+		return
+	}
+	plays := i.plays
+	i.showFrame(frame)
+	i.plays = plays
+}
+
+// Frame returns the index of the frame being shown
+func (i *Image) Frame() int {
+	return i.frame
+}
+
+// SetLoops overrides how many times the animation plays; 0 repeats forever
+func (i *Image) SetLoops(loops int) {
+	i.loops = loops
+}
+
+// SetOnAnimationEnd sets the handler called when the last loop finishes
+func (i *Image) SetOnAnimationEnd(handler func()) {
+	i.onAnimationEnd = handler
+}
+
+// showFrame displays a frame and restarts its timer
+func (i *Image) showFrame(frame int) {
+	i.frame = frame
+	i.source = i.anim.Frames[frame]
+	i.frameStart = Now()
+	i.pausedAt = 0
+	i.plays = 0
+	i.finished = false
+}
+
+// advance moves through the frames whose time has passed
+func (i *Image) advance() {
+	if !i.playing || i.anim == nil {
+		return
+	}
+
+	elapsed := since(i.frameStart)
+	if elapsed > i.anim.Duration() && i.loops == 0 {
+		// After a long stall, skip whole loops rather than stepping through them
+		elapsed %= i.anim.Duration()
+		i.frameStart = Now().Add(-elapsed)
+	}
+
+	for elapsed >= i.anim.Delay(i.frame) {
+		delay := i.anim.Delay(i.frame)
+		elapsed -= delay
+		i.frameStart = i.frameStart.Add(delay)
+
+		if i.frame+1 < len(i.anim.Frames) {
+			i.frame++
+			continue
+		}
+		i.plays++
+		if i.loops > 0 && i.plays >= i.loops {
+			i.playing = false
+			i.finished = true
+			if i.onAnimationEnd != nil {
+				i.onAnimationEnd()
+			}
+			break
+		}
+		i.frame = 0
+	}
+	i.source = i.anim.Frames[i.frame]
+}
+
+// Update advances the animation and updates children
+func (i *Image) Update() {
+	i.advance()
+	i.Node.Update()
+}
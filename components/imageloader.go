@@ -0,0 +1,326 @@
+package components
+
+import (
+	"container/list"
+	"fmt"
+	"image"
+	_ "image/gif"  // Register the GIF decoder
+	_ "image/jpeg" // Register the JPEG decoder
+	_ "image/png"  // Register the PNG decoder
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DecodeImage decodes a PNG, JPEG or GIF image from a reader. For animated
+// GIFs the first frame is returned.
+func DecodeImage(r io.Reader) (image.Image, error) {
+	img, _, err := image.Decode(r)
+	return img, err
+}
+
+// decodeFile decodes an image file, keeping every frame of an animated GIF
+// as an *ImageAnimation
+func decodeFile(r io.Reader, path string) (image.Image, error) {
+	if !strings.EqualFold(filepath.Ext(path), ".gif") {
+		return DecodeImage(r)
+	}
+	anim, err := DecodeAnimation(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(anim.Frames) == 1 {
+		return anim.Frames[0], nil
+	}
+	return anim, nil
+}
+
+// LoadImageFile decodes an image file from disk, using the image cache.
+// Animated GIFs are returned as an *ImageAnimation.
+func LoadImageFile(path string) (image.Image, error) {
+	return loadCached(path, func() (image.Image, error) {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return decodeFile(file, path)
+	})
+}
+
+// LoadImageFS decodes an image from a file system such as an embed.FS,
+// using the image cache. Animated GIFs are returned as an *ImageAnimation.
+func LoadImageFS(fsys fs.FS, path string) (image.Image, error) {
+	load := func() (image.Image, error) {
+		file, err := fsys.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return decodeFile(file, path)
+	}
+	// File systems that can't be map keys are loaded without the cache
+	if !reflect.TypeOf(fsys).Comparable() {
+		return load()
+	}
+	return loadCached(fsImageKey{fsys, path}, load)
+}
+
+// fsImageKey identifies an image in a file system in the cache
+type fsImageKey struct {
+	fsys fs.FS
+	path string
+}
+
+// ImageCache keeps recently decoded images up to a budget of decoded bytes,
+// dropping the least recently used first
+type ImageCache struct {
+	mu       sync.Mutex
+	maxBytes int
+	bytes    int
+	order    *list.List // Front is the most recently used
+	entries  map[interface{}]*list.Element
+}
+
+// imageCacheEntry is an image in the cache
+type imageCacheEntry struct {
+	key   interface{}
+	img   image.Image
+	bytes int
+}
+
+// NewImageCache creates a cache holding up to maxBytes of decoded pixels
+func NewImageCache(maxBytes int) *ImageCache {
+	return &ImageCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[interface{}]*list.Element),
+	}
+}
+
+// Get returns a cached image and marks it as recently used
+func (c *ImageCache) Get(key interface{}) (image.Image, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*imageCacheEntry).img, true
+}
+
+// Put adds an image, evicting old images to stay within the budget. Images
+// larger than the whole budget aren't cached.
+func (c *ImageCache) Put(key interface{}, img image.Image) {
+	size := imageBytes(img)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+	if size > c.maxBytes {
+		return
+	}
+	c.entries[key] = c.order.PushFront(&imageCacheEntry{key: key, img: img, bytes: size})
+	c.bytes += size
+	c.evict()
+}
+
+// Remove drops an image from the cache
+func (c *ImageCache) Remove(key interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.remove(element)
+	}
+}
+
+// Clear drops every image
+func (c *ImageCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.order.Init()
+	c.entries = make(map[interface{}]*list.Element)
+	c.bytes = 0
+}
+
+// SetMaxBytes changes the budget, evicting images if it shrank
+func (c *ImageCache) SetMaxBytes(maxBytes int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxBytes = maxBytes
+	c.evict()
+}
+
+// Len returns the number of cached images
+func (c *ImageCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Bytes returns the decoded size of the cached images
+func (c *ImageCache) Bytes() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.bytes
+}
+
+// remove drops an entry; the caller holds the lock
+func (c *ImageCache) remove(element *list.Element) {
+	entry := element.Value.(*imageCacheEntry)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+	c.bytes -= entry.bytes
+}
+
+// evict drops the least recently used entries until within budget; the
+// caller holds the lock
+func (c *ImageCache) evict() {
+	for c.bytes > c.maxBytes && c.order.Len() > 0 {
+		c.remove(c.order.Back())
+	}
+}
+
+// imageBytes estimates the decoded size of an image at four bytes a pixel
+func imageBytes(img image.Image) int {
+	if anim, ok := img.(*ImageAnimation); ok {
+		total := 0
+		for _, frame := range anim.Frames {
+			total += imageBytes(frame)
+		}
+		return total
+	}
+	bounds := img.Bounds()
+	return bounds.Dx() * bounds.Dy() * 4
+}
+
+var (
+	imageCacheMu sync.RWMutex
+	imageCache   = NewImageCache(64 << 20)
+
+	// Loads in progress, so images requested by several elements at once
+	// are only decoded once
+	inflightMu sync.Mutex
+	inflight   = make(map[interface{}]*imageLoad)
+)
+
+// imageLoad is a decode in progress that other callers can wait for
+type imageLoad struct {
+	done chan struct{}
+	img  image.Image
+	err  error
+}
+
+// SetImageCache replaces the cache used for loaded images; nil disables caching
+func SetImageCache(cache *ImageCache) {
+	imageCacheMu.Lock()
+	defer imageCacheMu.Unlock()
+	imageCache = cache
+}
+
+// CurrentImageCache returns the cache used for loaded images
+func CurrentImageCache() *ImageCache {
+	imageCacheMu.RLock()
+	defer imageCacheMu.RUnlock()
+	return imageCache
+}
+
+// loadCached returns a cached image or decodes it, sharing the decode with
+// concurrent callers for the same key. Errors aren't cached, so a missing
+// file is retried on the next load.
+func loadCached(key interface{}, load func() (image.Image, error)) (image.Image, error) {
+	cache := CurrentImageCache()
+	if cache != nil {
+		if img, ok := cache.Get(key); ok {
+			return img, nil
+		}
+	}
+
+	inflightMu.Lock()
+	if pending, ok := inflight[key]; ok {
+		inflightMu.Unlock()
+		<-pending.done
+		return pending.img, pending.err
+	}
+	pending := &imageLoad{done: make(chan struct{})}
+	inflight[key] = pending
+	inflightMu.Unlock()
+
+	pending.img, pending.err = load()
+	if pending.err == nil && cache != nil {
+		cache.Put(key, pending.img)
+	}
+
+	inflightMu.Lock()
+	delete(inflight, key)
+	inflightMu.Unlock()
+	close(pending.done)
+	return pending.img, pending.err
+}
+
+// ImageLoadState is where an image element is in loading its source
+type ImageLoadState int
+
+const (
+	ImageEmpty   ImageLoadState = iota // No source set
+	ImageLoading                       // Decoding in the background
+	ImageLoaded                        // Source ready to draw
+	ImageFailed                        // Source could not be loaded
+)
+
+// imageLoaded delivers a background load to its element on the UI goroutine
+type imageLoaded struct {
+	image      *Image
+	generation int
+	img        image.Image
+	err        error
+}
+
+// due applies the result unless a newer source replaced it
+func (l *imageLoaded) due(now time.Time) bool {
+	l.image.finishLoad(l.generation, l.img, l.err)
+	return true
+}
+
+// loadAsync decodes in the background and hands the result to the element
+// on the next frame, via RunFrameCallbacks
+func (i *Image) loadAsync(load func() (image.Image, error)) {
+	i.SetSource(nil)
+	i.loadState = ImageLoading
+	i.loadStarted = Now()
+
+	generation := i.generation
+	go func() {
+		img, err := load()
+		scheduleFrameCallback(&imageLoaded{image: i, generation: generation, img: img, err: err})
+	}()
+}
+
+// finishLoad stores a finished load and notifies the handler
+func (i *Image) finishLoad(generation int, img image.Image, err error) {
+	if generation != i.generation {
+		return
+	}
+	if err != nil {
+		i.loadState = ImageFailed
+		i.loadErr = fmt.Errorf("loading image %s: %w", i.srcPath, err)
+	} else if anim, ok := img.(*ImageAnimation); ok {
+		path := i.srcPath
+		i.SetAnimation(anim)
+		i.srcPath = path
+	} else {
+		i.loadState = ImageLoaded
+		i.source = img
+	}
+	if i.onLoad != nil {
+		i.onLoad(i.loadErr)
+	}
+}
@@ -0,0 +1,152 @@
+package components
+
+import (
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/exp/textinput"
+)
+
+// Composition is text being put together with an OS input method, such as
+// kana typed before it is converted to kanji. It is shown at the caret but
+// isn't part of the text until the input method commits it.
+type Composition struct {
+	Text           string
+	SelectionStart int // Rune index of the part the input method is converting
+	SelectionEnd   int
+}
+
+// IMEClient is implemented by text fields that take input method text
+type IMEClient interface {
+	Focusable
+	// CaretRect returns the caret's bounds on screen; the input method's
+	// candidate window opens below it
+	CaretRect() Rect
+	// SetComposition shows the text being composed at the caret; an empty
+	// composition ends it
+	SetComposition(composition Composition)
+}
+
+// imeSession is an input method session for the field receiving text
+type imeSession struct {
+	client    IMEClient
+	scale     float64 // Window pixels per UI pixel
+	states    chan textinput.State
+	end       func()
+	x, y      int  // Where the session was started, in window pixels
+	composing bool // Composed text is showing
+	failed    bool // The input method failed; typed characters are used instead
+}
+
+// ime is the session of the field set with SetIMEClient
+var ime imeSession
+
+// SetIMEClient sets the text field input method text goes to, usually the
+// focused one, or nil when no field is focused. Scale converts the field's
+// coordinates to window pixels, for placing the candidate window.
+func SetIMEClient(client IMEClient, scale float64) {
+	ime.scale = scale
+	if client == ime.client {
+		return
+	}
+	if ime.client != nil {
+		ime.client.SetComposition(Composition{})
+	}
+	ime.stop()
+	ime.client = client
+}
+
+// stop ends the session with the input method, if one is running
+func (s *imeSession) stop() {
+	if s.end != nil {
+		s.end()
+	}
+	s.states, s.end = nil, nil
+	s.composing = false
+}
+
+// pollTypedChars returns the characters typed since the last frame. While a
+// text field is the IME client they come from the input method, which also
+// updates the field's composition, and used reports whether the input
+// method took keyboard input this frame, so keys that went into a
+// composition aren't handled again as key presses.
+func pollTypedChars() (chars []rune, used bool) {
+	if ime.client == nil || ime.failed {
+		return ebiten.AppendInputChars(nil), false
+	}
+
+	caret := ime.client.CaretRect()
+	x := int(float64(caret.X) * ime.scale)
+	y := int(float64(caret.Y+caret.Height) * ime.scale)
+
+	// Move the candidate window with the caret between compositions
+	if ime.states != nil && !ime.composing && (x != ime.x || y != ime.y) {
+		ime.stop()
+	}
+
+	used = ime.composing
+	for {
+		if ime.states == nil {
+			ime.states, ime.end = textinput.Start(x, y)
+			ime.x, ime.y = x, y
+			if ime.states == nil {
+				return chars, used
+			}
+		}
+		open, composed := ime.drain(&chars)
+		used = used || composed
+		if ime.failed {
+			ime.client.SetComposition(Composition{})
+			return append(chars, ebiten.AppendInputChars(nil)...), false
+		}
+		if open {
+			return chars, used
+		}
+		// The session ended; start another for the rest of the frame
+		ime.states, ime.end = nil, nil
+		if ime.composing {
+			ime.compose(textinput.State{})
+		}
+	}
+}
+
+// drain reads what the input method has sent without waiting, adding
+// committed text to chars and showing the rest as the client's
+// composition. Returns whether the session is still open and whether a
+// composition was shown.
+func (s *imeSession) drain(chars *[]rune) (open, composed bool) {
+	for {
+		select {
+		case state, ok := <-s.states:
+			if state.Error != nil {
+				s.failed = true
+				s.stop()
+				return false, composed
+			}
+			if !ok {
+				return false, composed
+			}
+			if state.Committed {
+				*chars = append(*chars, []rune(state.Text)...)
+				s.compose(textinput.State{})
+				continue
+			}
+			s.compose(state)
+			composed = true
+		default:
+			return true, composed
+		}
+	}
+}
+
+// compose shows the text being composed in the client
+func (s *imeSession) compose(state textinput.State) {
+	s.composing = state.Text != ""
+	start := min(max(state.CompositionSelectionStartInBytes, 0), len(state.Text))
+	end := min(max(state.CompositionSelectionEndInBytes, start), len(state.Text))
+	s.client.SetComposition(Composition{
+		Text:           state.Text,
+		SelectionStart: utf8.RuneCountInString(state.Text[:start]),
+		SelectionEnd:   utf8.RuneCountInString(state.Text[:end]),
+	})
+}
@@ -0,0 +1,431 @@
+package components
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// keyNames maps keys to their display names
+var keyNames = map[Key]string{
+	KeyEscape:    "Esc",
+	KeyEnter:     "Enter",
+	KeyBackspace: "Backspace",
+	KeyTab:       "Tab",
+	KeySpace:     "Space",
+	KeyDelete:    "Delete",
+	KeyUp:        "Up",
+	KeyDown:      "Down",
+	KeyLeft:      "Left",
+	KeyRight:     "Right",
+
+	KeyHome:           "Home",
+	KeyEnd:            "End",
+	KeyPageUp:         "PageUp",
+	KeyPageDown:       "PageDown",
+	KeyInsert:         "Insert",
+	KeyNumpadAdd:      "NumAdd",
+	KeyNumpadSubtract: "NumSubtract",
+	KeyNumpadMultiply: "NumMultiply",
+	KeyNumpadDivide:   "NumDivide",
+	KeyNumpadDecimal:  "NumDecimal",
+	KeyNumpadEnter:    "NumEnter",
+	KeyNumpadEqual:    "NumEqual",
+	KeyMinus:          "-",
+	KeyEqual:          "=",
+	KeyComma:          ",",
+	KeyPeriod:         ".",
+	KeySlash:          "/",
+	KeyBackslash:      "\\",
+	KeySemicolon:      ";",
+	KeyQuote:          "'",
+	KeyBracketLeft:    "[",
+	KeyBracketRight:   "]",
+	KeyBackquote:      "`",
+	KeyCapsLock:       "CapsLock",
+	KeyNumLock:        "NumLock",
+	KeyScrollLock:     "ScrollLock",
+	KeyPrintScreen:    "PrintScreen",
+	KeyPause:          "Pause",
+	KeyContextMenu:    "Menu",
+}
+
+// ebitenKeys maps Ebiten keys to framework keys
+var ebitenKeys = map[ebiten.Key]Key{
+	ebiten.KeyEscape:    KeyEscape,
+	ebiten.KeyEnter:     KeyEnter,
+	ebiten.KeyBackspace: KeyBackspace,
+	ebiten.KeyTab:       KeyTab,
+	ebiten.KeySpace:     KeySpace,
+	ebiten.KeyDelete:    KeyDelete,
+	ebiten.KeyUp:        KeyUp,
+	ebiten.KeyDown:      KeyDown,
+	ebiten.KeyLeft:      KeyLeft,
+	ebiten.KeyRight:     KeyRight,
+
+	ebiten.KeyHome:           KeyHome,
+	ebiten.KeyEnd:            KeyEnd,
+	ebiten.KeyPageUp:         KeyPageUp,
+	ebiten.KeyPageDown:       KeyPageDown,
+	ebiten.KeyInsert:         KeyInsert,
+	ebiten.KeyNumpadAdd:      KeyNumpadAdd,
+	ebiten.KeyNumpadSubtract: KeyNumpadSubtract,
+	ebiten.KeyNumpadMultiply: KeyNumpadMultiply,
+	ebiten.KeyNumpadDivide:   KeyNumpadDivide,
+	ebiten.KeyNumpadDecimal:  KeyNumpadDecimal,
+	ebiten.KeyNumpadEnter:    KeyNumpadEnter,
+	ebiten.KeyNumpadEqual:    KeyNumpadEqual,
+	ebiten.KeyMinus:          KeyMinus,
+	ebiten.KeyEqual:          KeyEqual,
+	ebiten.KeyComma:          KeyComma,
+	ebiten.KeyPeriod:         KeyPeriod,
+	ebiten.KeySlash:          KeySlash,
+	ebiten.KeyBackslash:      KeyBackslash,
+	ebiten.KeySemicolon:      KeySemicolon,
+	ebiten.KeyQuote:          KeyQuote,
+	ebiten.KeyBracketLeft:    KeyBracketLeft,
+	ebiten.KeyBracketRight:   KeyBracketRight,
+	ebiten.KeyBackquote:      KeyBackquote,
+	ebiten.KeyCapsLock:       KeyCapsLock,
+	ebiten.KeyNumLock:        KeyNumLock,
+	ebiten.KeyScrollLock:     KeyScrollLock,
+	ebiten.KeyPrintScreen:    KeyPrintScreen,
+	ebiten.KeyPause:          KeyPause,
+	ebiten.KeyContextMenu:    KeyContextMenu,
+}
+
+func init() {
+	// Letters, digits and function keys are laid out contiguously in both enums
+	for i := 0; i < 26; i++ {
+		key := KeyA + Key(i)
+		keyNames[key] = string(rune('A' + i))
+		ebitenKeys[ebiten.KeyA+ebiten.Key(i)] = key
+	}
+	for i := 0; i < 10; i++ {
+		key := Key0 + Key(i)
+		keyNames[key] = string(rune('0' + i))
+		ebitenKeys[ebiten.KeyDigit0+ebiten.Key(i)] = key
+	}
+	for i := 0; i < 10; i++ {
+		key := KeyNumpad0 + Key(i)
+		keyNames[key] = fmt.Sprintf("Num%d", i)
+		ebitenKeys[ebiten.KeyNumpad0+ebiten.Key(i)] = key
+	}
+	for i := 0; i < 12; i++ {
+		key := KeyF1 + Key(i)
+		keyNames[key] = fmt.Sprintf("F%d", i+1)
+		ebitenKeys[ebiten.KeyF1+ebiten.Key(i)] = key
+	}
+}
+
+// String returns the display name of the key
+func (k Key) String() string {
+	if name, ok := keyNames[k]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// Base returns the main keyboard equivalent of a numeric keypad key, so
+// handlers can treat Numpad 5 like 5 and NumEnter like Enter
+func (k Key) Base() Key {
+	switch {
+	case k >= KeyNumpad0 && k <= KeyNumpad9:
+		return Key0 + (k - KeyNumpad0)
+	case k == KeyNumpadEnter:
+		return KeyEnter
+	case k == KeyNumpadSubtract:
+		return KeyMinus
+	case k == KeyNumpadDivide:
+		return KeySlash
+	case k == KeyNumpadDecimal:
+		return KeyPeriod
+	}
+	return k
+}
+
+// IsNavigation returns whether the key moves a cursor or scroll position
+func (k Key) IsNavigation() bool {
+	switch k {
+	case KeyUp, KeyDown, KeyLeft, KeyRight, KeyHome, KeyEnd, KeyPageUp, KeyPageDown:
+		return true
+	}
+	return false
+}
+
+// KeyFromEbiten converts an Ebiten key to a framework key
+func KeyFromEbiten(key ebiten.Key) Key {
+	if k, ok := ebitenKeys[key]; ok {
+		return k
+	}
+	return KeyUnknown
+}
+
+// keyRepeater tracks held keys for PollKeyEvents
+var keyRepeater = NewKeyRepeater()
+
+// PollKeyEvents returns key down events for keys pressed since the last frame,
+// repeat events for held keys according to the input timing, and typed
+// characters, including text committed by the OS input method. Keys the
+// input method used for a composition are left out.
+func PollKeyEvents() []InputEvent {
+	events := make([]InputEvent, 0)
+	typed, composing := pollTypedChars()
+
+	ctrl := ebiten.IsKeyPressed(ebiten.KeyControl) || ebiten.IsKeyPressed(ebiten.KeyMeta)
+	shift := ebiten.IsKeyPressed(ebiten.KeyShift)
+	alt := ebiten.IsKeyPressed(ebiten.KeyAlt)
+
+	for _, k := range inpututil.AppendJustReleasedKeys(nil) {
+		keyRepeater.Release(KeyFromEbiten(k))
+	}
+
+	for _, k := range inpututil.AppendPressedKeys(nil) {
+		key := KeyFromEbiten(k)
+		if key == KeyUnknown {
+			continue
+		}
+
+		repeat := false
+		if inpututil.IsKeyJustPressed(k) {
+			keyRepeater.Press(key)
+		} else if keyRepeater.Held(key) {
+			repeat = true
+		} else {
+			continue
+		}
+		if composing {
+			continue
+		}
+
+		events = append(events, InputEvent{
+			Type:      InputTypeKeyDown,
+			Key:       key,
+			ShiftDown: shift,
+			CtrlDown:  ctrl,
+			AltDown:   alt,
+			Repeat:    repeat,
+		})
+	}
+
+	for _, ch := range typed {
+		events = append(events, InputEvent{
+			Type:      InputTypeChar,
+			Char:      ch,
+			ShiftDown: shift,
+			CtrlDown:  ctrl,
+			AltDown:   alt,
+		})
+	}
+
+	return events
+}
+
+// DispatchKeyEvent delivers a key event to the element tree, topmost elements first.
+// Returns true if an element handled the event.
+func DispatchKeyEvent(element Element, event InputEvent) bool {
+	if v, ok := element.(interface{ IsVisible() bool }); ok && !v.IsVisible() {
+		return false
+	}
+
+	children := element.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if DispatchKeyEvent(children[i], event) {
+			return true
+		}
+	}
+
+	if handler, ok := element.(KeyHandler); ok {
+		return handler.HandleKeyDown(event)
+	}
+
+	return false
+}
+
+// DispatchScrollEvent delivers a mouse wheel movement to the element tree,
+// topmost elements first. Returns true if an element handled it.
+func DispatchScrollEvent(element Element, x, y int, deltaX, deltaY float64) bool {
+	if v, ok := element.(interface{ IsVisible() bool }); ok && !v.IsVisible() {
+		return false
+	}
+
+	children := element.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if DispatchScrollEvent(children[i], x, y, deltaX, deltaY) {
+			return true
+		}
+	}
+
+	if handler, ok := element.(ScrollHandler); ok {
+		return handler.HandleScroll(x, y, deltaX, deltaY)
+	}
+
+	return false
+}
+
+// ElementAt returns the innermost visible element under a point, topmost
+// elements first, or nil. Fixed-position layers, such as the toast area,
+// cover the screen and so only match through their children. Children of a
+// scroller only match inside its viewport.
+func ElementAt(element Element, x, y int) Element {
+	if v, ok := element.(interface{ IsVisible() bool }); ok && !v.IsVisible() {
+		return nil
+	}
+	if scroller, ok := element.(Scroller); ok && !PointInRect(Point{x, y}, scroller.ViewportRect()) {
+		return nil
+	}
+
+	children := element.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if hit := ElementAt(children[i], x, y); hit != nil {
+			return hit
+		}
+	}
+
+	bounds := element.Bounds()
+	if node, ok := element.(NodeElement); ok {
+		if node.GetPositionType() == PositionFixed {
+			return nil
+		}
+		bounds = node.ComputedBounds()
+	}
+	if PointInRect(Point{x, y}, bounds) {
+		return element
+	}
+	return nil
+}
+
+// DispatchFileDrop delivers files dropped on the window to the element tree,
+// topmost elements first. Returns true if an element took them.
+func DispatchFileDrop(element Element, x, y int, files fs.FS) bool {
+	if v, ok := element.(interface{ IsVisible() bool }); ok && !v.IsVisible() {
+		return false
+	}
+
+	children := element.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if DispatchFileDrop(children[i], x, y, files) {
+			return true
+		}
+	}
+
+	if handler, ok := element.(FileDropHandler); ok {
+		return handler.HandleFileDrop(x, y, files)
+	}
+
+	return false
+}
+
+// NavigateList maps Up/Down/Home/End/PageUp/PageDown to a new position in a
+// list of count items, where page is the number of items visible at once.
+// Returns false if the event is not a list navigation key.
+func NavigateList(event InputEvent, current, count, page int) (int, bool) {
+	if event.Type != InputTypeKeyDown || count == 0 {
+		return current, false
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	next := current
+	switch event.Key {
+	case KeyUp:
+		next--
+	case KeyDown:
+		next++
+	case KeyPageUp:
+		next -= page
+	case KeyPageDown:
+		next += page
+	case KeyHome:
+		next = 0
+	case KeyEnd:
+		next = count - 1
+	default:
+		return current, false
+	}
+
+	if next < 0 {
+		next = 0
+	}
+	if next >= count {
+		next = count - 1
+	}
+	return next, true
+}
+
+// Accelerator represents a keyboard shortcut such as Ctrl+S
+type Accelerator struct {
+	Key   Key
+	Ctrl  bool
+	Shift bool
+	Alt   bool
+}
+
+// ParseAccelerator parses a shortcut string like "Ctrl+Shift+S"
+func ParseAccelerator(s string) (Accelerator, error) {
+	var acc Accelerator
+	if strings.TrimSpace(s) == "" {
+		return acc, fmt.Errorf("empty accelerator")
+	}
+
+	for _, part := range strings.Split(s, "+") {
+		part = strings.TrimSpace(part)
+		switch strings.ToLower(part) {
+		case "ctrl", "control", "cmd":
+			acc.Ctrl = true
+		case "shift":
+			acc.Shift = true
+		case "alt", "option":
+			acc.Alt = true
+		case "esc", "escape":
+			acc.Key = KeyEscape
+		default:
+			acc.Key = KeyUnknown
+			for key, name := range keyNames {
+				if strings.EqualFold(name, part) {
+					acc.Key = key
+					break
+				}
+			}
+			if acc.Key == KeyUnknown {
+				return acc, fmt.Errorf("unknown key %q in accelerator %q", part, s)
+			}
+		}
+	}
+
+	if acc.Key == KeyUnknown {
+		return acc, fmt.Errorf("accelerator %q has no key", s)
+	}
+
+	return acc, nil
+}
+
+// Matches checks if a key event triggers the accelerator. Keypad keys match
+// their main keyboard equivalents unless the accelerator names the keypad key.
+func (a Accelerator) Matches(event InputEvent) bool {
+	return event.Type == InputTypeKeyDown &&
+		(event.Key == a.Key || event.Key.Base() == a.Key) &&
+		event.CtrlDown == a.Ctrl &&
+		event.ShiftDown == a.Shift &&
+		event.AltDown == a.Alt
+}
+
+// String returns the display form of the accelerator
+func (a Accelerator) String() string {
+	parts := make([]string, 0, 4)
+	if a.Ctrl {
+		parts = append(parts, "Ctrl")
+	}
+	if a.Shift {
+		parts = append(parts, "Shift")
+	}
+	if a.Alt {
+		parts = append(parts, "Alt")
+	}
+	parts = append(parts, a.Key.String())
+	return strings.Join(parts, "+")
+}
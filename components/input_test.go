@@ -0,0 +1,116 @@
+package components
+
+import "testing"
+
+func TestParseAccelerator(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Accelerator
+	}{
+		{"S", Accelerator{Key: KeyS}},
+		{"Ctrl+S", Accelerator{Key: KeyS, Ctrl: true}},
+		{"ctrl+shift+s", Accelerator{Key: KeyS, Ctrl: true, Shift: true}},
+		{"Shift+Ctrl+S", Accelerator{Key: KeyS, Ctrl: true, Shift: true}},
+		{"S+Alt+Ctrl", Accelerator{Key: KeyS, Ctrl: true, Alt: true}},
+		{" Ctrl + Alt + Delete ", Accelerator{Key: KeyDelete, Ctrl: true, Alt: true}},
+		{"Control+Option+A", Accelerator{Key: KeyA, Ctrl: true, Alt: true}},
+		{"Cmd+Q", Accelerator{Key: KeyQ, Ctrl: true}},
+		{"Escape", Accelerator{Key: KeyEscape}},
+		{"esc", Accelerator{Key: KeyEscape}},
+		{"Ctrl+=", Accelerator{Key: KeyEqual, Ctrl: true}},
+		{"Ctrl+-", Accelerator{Key: KeyMinus, Ctrl: true}},
+		{"F12", Accelerator{Key: KeyF12}},
+		{"Shift+PageDown", Accelerator{Key: KeyPageDown, Shift: true}},
+		{"NumEnter", Accelerator{Key: KeyNumpadEnter}},
+	}
+	for _, tt := range tests {
+		got, err := ParseAccelerator(tt.in)
+		if err != nil {
+			t.Errorf("ParseAccelerator(%q) returned error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAccelerator(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseAcceleratorRejects(t *testing.T) {
+	for _, in := range []string{
+		"",
+		"   ",
+		"Ctrl",
+		"Ctrl+Shift",
+		"Ctrl+Foo",
+		"Hyper+S",
+		"Ctrl+F13",
+		"Ctrl+",
+	} {
+		if got, err := ParseAccelerator(in); err == nil {
+			t.Errorf("ParseAccelerator(%q) = %+v, want an error", in, got)
+		}
+	}
+}
+
+func TestAcceleratorString(t *testing.T) {
+	tests := []struct {
+		acc  Accelerator
+		want string
+	}{
+		{Accelerator{Key: KeyS}, "S"},
+		{Accelerator{Key: KeyS, Ctrl: true}, "Ctrl+S"},
+		{Accelerator{Key: KeyS, Alt: true, Shift: true, Ctrl: true}, "Ctrl+Shift+Alt+S"},
+		{Accelerator{Key: KeyEscape, Shift: true}, "Shift+Esc"},
+		{Accelerator{Key: KeyF5, Alt: true}, "Alt+F5"},
+	}
+	for _, tt := range tests {
+		if got := tt.acc.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.acc, got, tt.want)
+		}
+	}
+}
+
+func TestAcceleratorRoundTrip(t *testing.T) {
+	for key := range keyNames {
+		for _, acc := range []Accelerator{
+			{Key: key},
+			{Key: key, Ctrl: true},
+			{Key: key, Ctrl: true, Shift: true, Alt: true},
+		} {
+			got, err := ParseAccelerator(acc.String())
+			if err != nil {
+				t.Errorf("ParseAccelerator(%q) returned error: %v", acc.String(), err)
+				continue
+			}
+			if got != acc {
+				t.Errorf("ParseAccelerator(%q) = %+v, want %+v", acc.String(), got, acc)
+			}
+		}
+	}
+}
+
+func TestAcceleratorMatches(t *testing.T) {
+	ctrlS := Accelerator{Key: KeyS, Ctrl: true}
+	enter := Accelerator{Key: KeyEnter}
+	numEnter := Accelerator{Key: KeyNumpadEnter}
+	tests := []struct {
+		name  string
+		acc   Accelerator
+		event InputEvent
+		want  bool
+	}{
+		{"exact", ctrlS, InputEvent{Type: InputTypeKeyDown, Key: KeyS, CtrlDown: true}, true},
+		{"missing modifier", ctrlS, InputEvent{Type: InputTypeKeyDown, Key: KeyS}, false},
+		{"extra modifier", ctrlS, InputEvent{Type: InputTypeKeyDown, Key: KeyS, CtrlDown: true, ShiftDown: true}, false},
+		{"other key", ctrlS, InputEvent{Type: InputTypeKeyDown, Key: KeyD, CtrlDown: true}, false},
+		{"key up", ctrlS, InputEvent{Type: InputTypeKeyUp, Key: KeyS, CtrlDown: true}, false},
+		{"keypad matches main key", enter, InputEvent{Type: InputTypeKeyDown, Key: KeyNumpadEnter}, true},
+		{"keypad key named", numEnter, InputEvent{Type: InputTypeKeyDown, Key: KeyNumpadEnter}, true},
+		{"main key doesn't match keypad", numEnter, InputEvent{Type: InputTypeKeyDown, Key: KeyEnter}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.acc.Matches(tt.event); got != tt.want {
+			t.Errorf("%s: %v.Matches(%+v) = %v, want %v", tt.name, tt.acc, tt.event, got, tt.want)
+		}
+	}
+}
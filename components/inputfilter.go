@@ -0,0 +1,125 @@
+package components
+
+import (
+	"strings"
+	"unicode"
+)
+
+// InputFilter checks an edit to a text field before it is applied. It gets
+// the text before the edit and the text the edit would produce, and returns
+// the text to keep: proposed to accept it, old to reject it, or anything else
+// to rewrite it. Filters run on typing, pasting, deleting and SetText, before
+// the change handler is called.
+type InputFilter func(old, proposed string) string
+
+// AllowRunes drops runes the predicate rejects, e.g. AllowRunes(unicode.IsDigit)
+func AllowRunes(allowed func(rune) bool) InputFilter {
+	return func(old, proposed string) string {
+		return strings.Map(func(r rune) rune {
+			if allowed(r) {
+				return r
+			}
+			return -1
+		}, proposed)
+	}
+}
+
+// AllowChars drops runes that aren't in the set
+func AllowChars(set string) InputFilter {
+	return AllowRunes(func(r rune) bool {
+		return strings.ContainsRune(set, r)
+	})
+}
+
+// DigitsOnly drops everything but decimal digits
+func DigitsOnly() InputFilter {
+	return AllowRunes(unicode.IsDigit)
+}
+
+// NumericOnly allows a decimal number: digits, one decimal point and a
+// leading minus sign. Edits that would break the number are rejected.
+func NumericOnly() InputFilter {
+	return func(old, proposed string) string {
+		for i, r := range proposed {
+			switch {
+			case unicode.IsDigit(r):
+			case r == '-' && i == 0:
+			case r == '.' && !strings.ContainsRune(proposed[:i], '.'):
+			default:
+				return old
+			}
+		}
+		return proposed
+	}
+}
+
+// MaxLength limits the text to n runes. Edits that would go over are
+// rejected; text that is already too long, e.g. from SetText, is cut.
+func MaxLength(n int) InputFilter {
+	return func(old, proposed string) string {
+		runes := []rune(proposed)
+		if len(runes) <= n {
+			return proposed
+		}
+		if len([]rune(old)) <= n {
+			return old
+		}
+		return string(runes[:n])
+	}
+}
+
+// TransformText rewrites the text with a function, e.g. TransformText(strings.ToUpper)
+func TransformText(fn func(string) string) InputFilter {
+	return func(old, proposed string) string {
+		return fn(proposed)
+	}
+}
+
+// Uppercase converts typed text to upper case
+func Uppercase() InputFilter {
+	return TransformText(strings.ToUpper)
+}
+
+// Lowercase converts typed text to lower case
+func Lowercase() InputFilter {
+	return TransformText(strings.ToLower)
+}
+
+// TrimLeadingSpace drops spaces at the start of the text. Trailing space
+// isn't trimmed while typing, as it would stop words being separated.
+func TrimLeadingSpace() InputFilter {
+	return TransformText(func(s string) string {
+		return strings.TrimLeftFunc(s, unicode.IsSpace)
+	})
+}
+
+// applyInputFilters runs the filters in order, each seeing the previous
+// one's result
+func applyInputFilters(filters []InputFilter, old, proposed string) string {
+	for _, filter := range filters {
+		proposed = filter(old, proposed)
+	}
+	return proposed
+}
+
+// filterEdit applies filters to replacing the runes between from and to with
+// s. It returns the text to keep and where the cursor goes, which is after
+// the filtered form of everything up to the end of the inserted text.
+func filterEdit(filters []InputFilter, text string, from, to int, s string) (string, int) {
+	runes := []rune(text)
+	before := string(runes[:from]) + s
+	proposed := before + string(runes[to:])
+	if len(filters) == 0 {
+		return proposed, len([]rune(before))
+	}
+
+	result := applyInputFilters(filters, text, proposed)
+	switch result {
+	case text:
+		return text, -1
+	case proposed:
+		return result, len([]rune(before))
+	}
+	cursor := len([]rune(applyInputFilters(filters, "", before)))
+	return result, min(cursor, len([]rune(result)))
+}
@@ -102,17 +102,18 @@ func (i *Inspector) drawNode(surface DrawSurface, node NodeElement, x, y int, de
 }
 
 // HandleMouseDown handles mouse down events
-func (i *Inspector) HandleMouseDown(x, y int) bool {
+func (i *Inspector) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
 	bounds := i.ComputedBounds()
 	if !PointInRect(Point{x, y}, bounds) {
-		return false
+		return
 	}
 	
 	// Handle clicks on tree nodes
 	nodeY := bounds.Y + 30
 	i.handleNodeClick(i.root, bounds.X + 10, &nodeY, 0, x, y)
 	
-	return true
+	e.Consume()
 }
 
 // handleNodeClick recursively handles clicks on tree nodes
@@ -155,6 +156,43 @@ func (i *Inspector) handleNodeClick(node NodeElement, x int, yPtr *int, depth in
 	return false
 }
 
+// SelectedNode returns the selected node, or nil
+func (i *Inspector) SelectedNode() NodeElement {
+	return i.selectedNode
+}
+
+// SelectNode selects a node and expands its ancestors so it is visible in the tree.
+// Returns false if the node is not part of the inspected tree.
+func (i *Inspector) SelectNode(node NodeElement) bool {
+	path := nodePath(i.root, node)
+	if path == nil {
+		return false
+	}
+	
+	for _, ancestor := range path[:len(path)-1] {
+		i.expanded[ancestor] = true
+	}
+	i.selectedNode = node
+	return true
+}
+
+// nodePath returns the nodes from root down to target, or nil if target isn't in the tree
+func nodePath(root NodeElement, target NodeElement) []NodeElement {
+	if root == target {
+		return []NodeElement{root}
+	}
+	
+	for _, child := range root.Children() {
+		if domChild, ok := child.(NodeElement); ok {
+			if path := nodePath(domChild, target); path != nil {
+				return append([]NodeElement{root}, path...)
+			}
+		}
+	}
+	
+	return nil
+}
+
 // HighlightNode temporarily highlights a node in the UI
 func (i *Inspector) HighlightNode(node NodeElement) {
 	// In a real implementation, this would draw a highlight around the node
@@ -184,4 +222,216 @@ func (i *Inspector) dumpNodeTreeRecursive(sb *strings.Builder, node NodeElement,
 			i.dumpNodeTreeRecursive(sb, domChild, depth + 1)
 		}
 	}
-} 
\ No newline at end of file
+} 
+// MeasureOverlay is a debugging tool that draws the pixel distances between
+// the edges of two elements. Hover an element to anchor it, then hold Alt
+// over another element to measure the spacing between them.
+type MeasureOverlay struct {
+	*Node
+	root      NodeElement
+	enabled   bool
+	altDown   bool
+	anchor    NodeElement
+	target    NodeElement
+	lineColor color.RGBA
+}
+
+// measureSegment represents a measured distance drawn between two points
+type measureSegment struct {
+	x1, y1, x2, y2 int
+	distance       int
+}
+
+// NewMeasureOverlay creates a new measurement overlay for the given root element
+func NewMeasureOverlay(id string, root NodeElement) *MeasureOverlay {
+	overlay := &MeasureOverlay{
+		Node:      NewNode(id),
+		root:      root,
+		enabled:   false,
+		lineColor: color.RGBA{255, 0, 128, 255},
+	}
+	overlay.SetPositionType(PositionFixed)
+	return overlay
+}
+
+// SetEnabled turns measurement mode on or off
+func (m *MeasureOverlay) SetEnabled(enabled bool) {
+	m.enabled = enabled
+	if !enabled {
+		m.anchor = nil
+		m.target = nil
+	}
+}
+
+// IsEnabled returns whether measurement mode is active
+func (m *MeasureOverlay) IsEnabled() bool {
+	return m.enabled
+}
+
+// SetAltDown updates the state of the Alt modifier key
+func (m *MeasureOverlay) SetAltDown(down bool) {
+	m.altDown = down
+	if !down {
+		m.target = nil
+	}
+}
+
+// Anchor returns the element currently anchored for measurement
+func (m *MeasureOverlay) Anchor() NodeElement {
+	return m.anchor
+}
+
+// Target returns the element currently measured against the anchor
+func (m *MeasureOverlay) Target() NodeElement {
+	return m.target
+}
+
+// HandleMouseMove updates the anchor or target element under the cursor
+func (m *MeasureOverlay) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !m.enabled || m.root == nil {
+		return
+	}
+
+	element := nodeElementAt(m.root, x, y)
+	if m.altDown && m.anchor != nil {
+		if element != m.anchor {
+			m.target = element
+		} else {
+			m.target = nil
+		}
+	} else {
+		m.anchor = element
+	}
+
+	// Measurement never consumes input
+}
+
+// HandleMouseDown lets clicks pass through the overlay
+func (m *MeasureOverlay) HandleMouseDown(e *Event) {
+	return
+}
+
+// HandleMouseUp lets clicks pass through the overlay
+func (m *MeasureOverlay) HandleMouseUp(e *Event) {
+	return
+}
+
+// Draw draws the anchor and target outlines and the distances between them
+func (m *MeasureOverlay) Draw(surface DrawSurface) {
+	if !m.enabled || m.anchor == nil {
+		return
+	}
+
+	anchorBounds := m.anchor.ComputedBounds()
+	surface.DrawRect(anchorBounds.X, anchorBounds.Y, anchorBounds.Width, anchorBounds.Height, color.RGBA{0, 120, 255, 255})
+
+	if m.target == nil {
+		// Show the anchor size while no target is selected
+		size := fmt.Sprintf("%d x %d", anchorBounds.Width, anchorBounds.Height)
+		m.drawValue(surface, size, anchorBounds.X, anchorBounds.Y+anchorBounds.Height+2)
+		return
+	}
+
+	targetBounds := m.target.ComputedBounds()
+	surface.DrawRect(targetBounds.X, targetBounds.Y, targetBounds.Width, targetBounds.Height, m.lineColor)
+
+	for _, segment := range measureDistances(anchorBounds, targetBounds) {
+		surface.DrawLine(segment.x1, segment.y1, segment.x2, segment.y2, m.lineColor)
+		midX := (segment.x1 + segment.x2) / 2
+		midY := (segment.y1 + segment.y2) / 2
+		m.drawValue(surface, fmt.Sprintf("%d", segment.distance), midX+2, midY+2)
+	}
+}
+
+// drawValue draws a measurement label with a solid background
+func (m *MeasureOverlay) drawValue(surface DrawSurface, value string, x, y int) {
+	width := len(value)*7 + 6
+	surface.FillRect(x, y, width, 16, m.lineColor)
+	surface.DrawText(value, x+3, y+1, color.RGBA{255, 255, 255, 255}, 12)
+}
+
+// measureDistances computes the horizontal and vertical edge distances between two rects
+func measureDistances(a, b Rect) []measureSegment {
+	segments := make([]measureSegment, 0, 4)
+
+	// Horizontal distances, drawn through the vertical center of b
+	centerY := b.Y + b.Height/2
+	if b.X >= a.X+a.Width {
+		segments = append(segments, measureSegment{a.X + a.Width, centerY, b.X, centerY, b.X - (a.X + a.Width)})
+	} else if b.X+b.Width <= a.X {
+		segments = append(segments, measureSegment{b.X + b.Width, centerY, a.X, centerY, a.X - (b.X + b.Width)})
+	} else {
+		if a.X != b.X {
+			segments = append(segments, measureSegment{minInt(a.X, b.X), centerY, maxInt(a.X, b.X), centerY, absInt(a.X - b.X)})
+		}
+		if a.X+a.Width != b.X+b.Width {
+			left, right := a.X+a.Width, b.X+b.Width
+			segments = append(segments, measureSegment{minInt(left, right), centerY, maxInt(left, right), centerY, absInt(left - right)})
+		}
+	}
+
+	// Vertical distances, drawn through the horizontal center of b
+	centerX := b.X + b.Width/2
+	if b.Y >= a.Y+a.Height {
+		segments = append(segments, measureSegment{centerX, a.Y + a.Height, centerX, b.Y, b.Y - (a.Y + a.Height)})
+	} else if b.Y+b.Height <= a.Y {
+		segments = append(segments, measureSegment{centerX, b.Y + b.Height, centerX, a.Y, a.Y - (b.Y + b.Height)})
+	} else {
+		if a.Y != b.Y {
+			segments = append(segments, measureSegment{centerX, minInt(a.Y, b.Y), centerX, maxInt(a.Y, b.Y), absInt(a.Y - b.Y)})
+		}
+		if a.Y+a.Height != b.Y+b.Height {
+			top, bottom := a.Y+a.Height, b.Y+b.Height
+			segments = append(segments, measureSegment{centerX, minInt(top, bottom), centerX, maxInt(top, bottom), absInt(top - bottom)})
+		}
+	}
+
+	return segments
+}
+
+// nodeElementAt finds the deepest visible node element containing the point
+func nodeElementAt(node NodeElement, x, y int) NodeElement {
+	if v, ok := node.(interface{ IsVisible() bool }); ok && !v.IsVisible() {
+		return nil
+	}
+	if !PointInRect(Point{x, y}, node.ComputedBounds()) {
+		return nil
+	}
+
+	// Check children in reverse order to find the topmost element
+	children := node.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if child, ok := children[i].(NodeElement); ok {
+			if found := nodeElementAt(child, x, y); found != nil {
+				return found
+			}
+		}
+	}
+
+	return node
+}
+
+// minInt returns the smaller of two integers
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// maxInt returns the larger of two integers
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// absInt returns the absolute value of an integer
+func absInt(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -3,6 +3,7 @@ package components
 import (
 	"image/color"
 	"image"
+	"io/fs"
 )
 
 // ScreenWidth and ScreenHeight define the default screen dimensions
@@ -21,6 +22,9 @@ const (
 	InputTypeKeyDown
 	InputTypeKeyUp
 	InputTypeChar
+	InputTypeClick        // Press and release on one element, with any button
+	InputTypeDoubleClick  // Second left click in quick succession
+	InputTypeContextClick // Right click
 )
 
 // Key represents keyboard keys
@@ -33,7 +37,98 @@ const (
 	KeyBackspace
 	KeyTab
 	KeySpace
-	// Add more keys as needed
+	KeyDelete
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyA
+	KeyB
+	KeyC
+	KeyD
+	KeyE
+	KeyF
+	KeyG
+	KeyH
+	KeyI
+	KeyJ
+	KeyK
+	KeyL
+	KeyM
+	KeyN
+	KeyO
+	KeyP
+	KeyQ
+	KeyR
+	KeyS
+	KeyT
+	KeyU
+	KeyV
+	KeyW
+	KeyX
+	KeyY
+	KeyZ
+	Key0
+	Key1
+	Key2
+	Key3
+	Key4
+	Key5
+	Key6
+	Key7
+	Key8
+	Key9
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+	KeyHome
+	KeyEnd
+	KeyPageUp
+	KeyPageDown
+	KeyInsert
+	KeyNumpad0
+	KeyNumpad1
+	KeyNumpad2
+	KeyNumpad3
+	KeyNumpad4
+	KeyNumpad5
+	KeyNumpad6
+	KeyNumpad7
+	KeyNumpad8
+	KeyNumpad9
+	KeyNumpadAdd
+	KeyNumpadSubtract
+	KeyNumpadMultiply
+	KeyNumpadDivide
+	KeyNumpadDecimal
+	KeyNumpadEnter
+	KeyNumpadEqual
+	KeyMinus
+	KeyEqual
+	KeyComma
+	KeyPeriod
+	KeySlash
+	KeyBackslash
+	KeySemicolon
+	KeyQuote
+	KeyBracketLeft
+	KeyBracketRight
+	KeyBackquote
+	KeyCapsLock
+	KeyNumLock
+	KeyScrollLock
+	KeyPrintScreen
+	KeyPause
+	KeyContextMenu
 )
 
 // InputEvent represents an input event
@@ -46,6 +141,13 @@ type InputEvent struct {
 	ShiftDown bool
 	CtrlDown  bool
 	AltDown   bool
+	Repeat    bool // Generated by holding the key down
+
+	// Pointer details, where the backend can supply them
+	PointerType PointerType
+	Pressure    float64 // 0 to 1; 1 for devices without pressure
+	TiltX       float64 // Degrees
+	TiltY       float64 // Degrees
 }
 
 // Element is the interface for all UI elements
@@ -61,10 +163,11 @@ type Element interface {
 	AddChild(child Element)
 	RemoveChild(child Element)
 	
-	// Input handling
-	HandleMouseDown(x, y int) bool
-	HandleMouseUp(x, y int) bool
-	HandleMouseMove(x, y int) bool
+	// Input handling: the element's built-in handling of mouse events,
+	// which calls Consume on an event it uses
+	HandleMouseDown(e *Event)
+	HandleMouseUp(e *Event)
+	HandleMouseMove(e *Event)
 	
 	// Rendering
 	Draw(surface DrawSurface)
@@ -73,6 +176,22 @@ type Element interface {
 	Update()
 }
 
+// KeyHandler is implemented by elements that respond to keyboard input
+type KeyHandler interface {
+	HandleKeyDown(event InputEvent) bool
+}
+
+// ScrollHandler is implemented by elements that respond to the mouse wheel
+type ScrollHandler interface {
+	HandleScroll(x, y int, deltaX, deltaY float64) bool
+}
+
+// FileDropHandler is implemented by elements that accept files dropped on
+// the window from the OS
+type FileDropHandler interface {
+	HandleFileDrop(x, y int, files fs.FS) bool
+}
+
 // Rect represents a rectangle with position and dimensions
 type Rect struct {
 	X, Y, Width, Height int
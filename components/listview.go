@@ -0,0 +1,520 @@
+package components
+
+import (
+	"image/color"
+	"time"
+)
+
+// ListDragThreshold is how far, in pixels, a press on a list item must move
+// before it starts dragging the item rather than clicking it
+const ListDragThreshold = 5
+
+// defaultIndicatorDuration is how long the insertion line takes to slide to
+// a new gap while an item is dragged
+const defaultIndicatorDuration = 100 * time.Millisecond
+
+// ListView stacks items in a column. When it's reorderable, an item can be
+// dragged to a new place: the item follows the pointer, a line slides to
+// the gap it would land in, and releasing moves it there. Presses that
+// don't move far reach the item as usual, so buttons and check boxes in
+// the items keep working. Once focused by a press, the navigation keys move
+// a cursor over the items.
+type ListView struct {
+	*Node
+	items           []Element
+	focused         bool
+	current         Element // Item under the keyboard cursor, or nil
+	spacing         int
+	reorderable     bool
+	onReorder       func(oldIndex, newIndex int)
+	backgroundColor color.RGBA
+	indicatorColor  color.RGBA
+
+	pressed   bool // A press began in the list and is still held
+	dragIndex int  // Item under the press, or -1
+	pressY    int
+	dragging  bool
+	dragY     int // Pointer y while dragging
+	dropSlot  int // Gap the item would land in: 0 is above the first item
+
+	indicatorY     float64 // Drawn y of the insertion line, from the list's top
+	indicatorFrom  float64
+	indicatorTo    float64
+	indicatorStart time.Time
+}
+
+// NewListView creates an empty list whose items can't be reordered
+func NewListView(id string) *ListView {
+	return &ListView{
+		Node:           NewNode(id),
+		dragIndex:      -1,
+		indicatorColor: LightTheme().Accent,
+	}
+}
+
+// AddItem adds an item at the end of the list
+func (l *ListView) AddItem(item Element) {
+	l.InsertItem(len(l.items), item)
+}
+
+// InsertItem adds an item at an index, moving later items down
+func (l *ListView) InsertItem(index int, item Element) {
+	index = max(0, min(index, len(l.items)))
+	l.items = append(l.items, nil)
+	copy(l.items[index+1:], l.items[index:])
+	l.items[index] = item
+	l.Node.AddChild(item)
+	l.layoutItems()
+}
+
+// RemoveItem removes the item at an index
+func (l *ListView) RemoveItem(index int) {
+	if index < 0 || index >= len(l.items) {
+		return
+	}
+	l.cancelDrag()
+	if l.items[index] == l.current {
+		l.current = nil
+	}
+	l.Node.RemoveChild(l.items[index])
+	l.items = append(l.items[:index], l.items[index+1:]...)
+	l.layoutItems()
+}
+
+// Clear removes every item
+func (l *ListView) Clear() {
+	l.cancelDrag()
+	for _, item := range l.items {
+		l.Node.RemoveChild(item)
+	}
+	l.items = nil
+	l.current = nil
+}
+
+// Item returns the item at an index, or nil
+func (l *ListView) Item(index int) Element {
+	if index < 0 || index >= len(l.items) {
+		return nil
+	}
+	return l.items[index]
+}
+
+// Items returns the items, top to bottom
+func (l *ListView) Items() []Element {
+	return l.items
+}
+
+// ItemCount returns the number of items
+func (l *ListView) ItemCount() int {
+	return len(l.items)
+}
+
+// IndexOf returns the index of an item, or -1
+func (l *ListView) IndexOf(item Element) int {
+	for i, it := range l.items {
+		if it == item {
+			return i
+		}
+	}
+	return -1
+}
+
+// MoveItem moves the item at oldIndex so it ends up at newIndex. The reorder
+// handler isn't called; it only reports moves made by dragging.
+func (l *ListView) MoveItem(oldIndex, newIndex int) {
+	if oldIndex < 0 || oldIndex >= len(l.items) || newIndex < 0 || newIndex >= len(l.items) {
+		return
+	}
+	item := l.items[oldIndex]
+	if oldIndex < newIndex {
+		copy(l.items[oldIndex:], l.items[oldIndex+1:newIndex+1])
+	} else {
+		copy(l.items[newIndex+1:], l.items[newIndex:oldIndex])
+	}
+	l.items[newIndex] = item
+	l.layoutItems()
+}
+
+// SetSpacing sets the gap between items
+func (l *ListView) SetSpacing(spacing int) {
+	l.spacing = spacing
+	l.layoutItems()
+}
+
+// Spacing returns the gap between items
+func (l *ListView) Spacing() int {
+	return l.spacing
+}
+
+// SetReorderable sets whether items can be dragged to a new place
+func (l *ListView) SetReorderable(reorderable bool) {
+	l.reorderable = reorderable
+	if !reorderable {
+		l.cancelDrag()
+	}
+}
+
+// IsReorderable returns whether items can be dragged to a new place
+func (l *ListView) IsReorderable() bool {
+	return l.reorderable
+}
+
+// SetOnReorder sets the handler called after an item is dragged from
+// oldIndex to newIndex; the list has already moved it
+func (l *ListView) SetOnReorder(handler func(oldIndex, newIndex int)) {
+	l.onReorder = handler
+}
+
+// IsDragging returns whether an item is being dragged
+func (l *ListView) IsDragging() bool {
+	return l.dragging
+}
+
+// SetBackgroundColor sets the background color
+func (l *ListView) SetBackgroundColor(backgroundColor color.RGBA) {
+	l.backgroundColor = backgroundColor
+}
+
+// SetIndicatorColor sets the color of the insertion line shown while dragging
+func (l *ListView) SetIndicatorColor(indicatorColor color.RGBA) {
+	l.indicatorColor = indicatorColor
+}
+
+// ApplyTheme colors the insertion line with the theme's accent
+func (l *ListView) ApplyTheme(theme Theme) {
+	l.indicatorColor = theme.Accent
+}
+
+// CursorShapeAt returns the move arrows while an item is dragged
+func (l *ListView) CursorShapeAt(x, y int) CursorShape {
+	if l.dragging {
+		return CursorMove
+	}
+	return l.Node.CursorShapeAt(x, y)
+}
+
+// SetBounds sets the bounds and stretches the items to the new width
+func (l *ListView) SetBounds(bounds Rect) {
+	l.Node.SetBounds(bounds)
+	l.layoutItems()
+}
+
+// layoutItems stacks the items at their own heights. A dragged item
+// follows the pointer, kept within the list, while the others stay put.
+func (l *ListView) layoutItems() {
+	bounds := l.Bounds()
+	y := 0
+	for i, item := range l.items {
+		height := item.Bounds().Height
+		top := y
+		if l.dragging && i == l.dragIndex {
+			top = max(0, min(y+l.dragY-l.pressY, bounds.Height-height))
+		}
+		item.SetBounds(Rect{X: bounds.X, Y: bounds.Y + top, Width: bounds.Width, Height: height})
+		if node, ok := item.(NodeElement); ok {
+			node.SetRelativePosition(Point{X: 0, Y: top})
+		}
+		y += height + l.spacing
+	}
+}
+
+// itemTop returns the y of an item's resting place, from the list's top
+func (l *ListView) itemTop(index int) int {
+	y := 0
+	for _, item := range l.items[:index] {
+		y += item.Bounds().Height + l.spacing
+	}
+	return y
+}
+
+// itemAt returns the index of the item under a point, or -1
+func (l *ListView) itemAt(x, y int) int {
+	if !PointInRect(Point{x, y}, l.ComputedBounds()) {
+		return -1
+	}
+	for i, item := range l.items {
+		if PointInRect(Point{x, y}, itemBounds(item)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// itemBounds returns an item's bounds on screen
+func itemBounds(item Element) Rect {
+	if node, ok := item.(NodeElement); ok {
+		return node.ComputedBounds()
+	}
+	return item.Bounds()
+}
+
+// slotAt returns the gap nearest a y on screen: the gap above the first
+// item whose middle is below it, or the end of the list
+func (l *ListView) slotAt(y int) int {
+	top := l.ComputedBounds().Y
+	for i, item := range l.items {
+		if y < top+l.itemTop(i)+item.Bounds().Height/2 {
+			return i
+		}
+	}
+	return len(l.items)
+}
+
+// slotY returns the y of the middle of a gap, from the list's top
+func (l *ListView) slotY(slot int) float64 {
+	if slot == 0 {
+		return 0
+	}
+	return float64(l.itemTop(slot)) - float64(l.spacing)/2
+}
+
+// drag follows the pointer during a press, starting a drag once it has
+// moved far enough from where the press began
+func (l *ListView) drag(y int) {
+	if !l.pressed || l.dragIndex < 0 || !l.reorderable {
+		return
+	}
+	if !l.dragging {
+		if absInt(y-l.pressY) < ListDragThreshold {
+			return
+		}
+		l.dragging = true
+		l.dropSlot = l.dragIndex
+		l.indicatorY = l.slotY(l.dropSlot)
+		l.indicatorTo = l.indicatorY
+		l.cancelPress()
+	}
+	l.dragY = y
+	if slot := l.slotAt(y); slot != l.dropSlot {
+		l.dropSlot = slot
+		l.indicatorFrom = l.indicatorY
+		l.indicatorTo = l.slotY(slot)
+		l.indicatorStart = Now()
+	}
+	l.layoutItems()
+}
+
+// drop moves the dragged item into the gap under the pointer
+func (l *ListView) drop() {
+	oldIndex := l.dragIndex
+	newIndex := l.dropSlot
+	if newIndex > oldIndex {
+		newIndex--
+	}
+	l.dragging = false
+	l.dragIndex = -1
+	l.MoveItem(oldIndex, newIndex)
+	if newIndex != oldIndex && l.onReorder != nil {
+		l.onReorder(oldIndex, newIndex)
+	}
+}
+
+// cancelDrag ends a drag without moving anything
+func (l *ListView) cancelDrag() {
+	l.dragging = false
+	l.dragIndex = -1
+	l.layoutItems()
+}
+
+// cancelPress sends the items a release away from all of them, so a
+// button pressed at the start of a drag is let go without being clicked
+func (l *ListView) cancelPress() {
+	for _, item := range l.items {
+		item.HandleMouseUp(NewMouseEvent(InputTypeMouseUp, -1, -1))
+	}
+}
+
+// advanceIndicator slides the insertion line towards its gap
+func (l *ListView) advanceIndicator() {
+	if l.indicatorY == l.indicatorTo {
+		return
+	}
+	t := 1.0
+	if duration := AnimationDuration(defaultIndicatorDuration); duration > 0 {
+		t = float64(since(l.indicatorStart)) / float64(duration)
+	}
+	if t >= 1 {
+		l.indicatorY = l.indicatorTo
+		return
+	}
+	// Ease out so the line settles gently
+	t = 1 - (1-t)*(1-t)
+	l.indicatorY = l.indicatorFrom + (l.indicatorTo-l.indicatorFrom)*t
+}
+
+// Draw draws the items and, while dragging, the insertion line, with the
+// dragged item on top
+func (l *ListView) Draw(surface DrawSurface) {
+	if !l.IsVisible() {
+		return
+	}
+	bounds := l.ComputedBounds()
+	if l.backgroundColor.A > 0 {
+		surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, l.backgroundColor)
+	}
+
+	l.layoutItems()
+	for i, item := range l.items {
+		if !l.dragging || i != l.dragIndex {
+			item.Draw(surface)
+		}
+	}
+	if !l.dragging {
+		if l.focused && l.IndexOf(l.current) >= 0 {
+			cb := itemBounds(l.current)
+			surface.DrawRect(cb.X, cb.Y, cb.Width, cb.Height, l.indicatorColor)
+		}
+		return
+	}
+
+	l.advanceIndicator()
+	y := bounds.Y + int(l.indicatorY+0.5)
+	surface.FillRect(bounds.X, y-1, bounds.Width, 2, l.indicatorColor)
+	surface.FillRect(bounds.X, y-3, 2, 6, l.indicatorColor)
+	surface.FillRect(bounds.X+bounds.Width-2, y-3, 2, 6, l.indicatorColor)
+
+	dragged := l.items[l.dragIndex]
+	dragged.Draw(surface)
+	db := itemBounds(dragged)
+	surface.DrawRect(db.X, db.Y, db.Width, db.Height, l.indicatorColor)
+}
+
+// HandleMouseDown starts a press on an item and passes it on, or carries
+// on a drag while the button is held. A press on an item puts the cursor
+// on it and focuses the list, unless an element inside the item took focus.
+func (l *ListView) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !l.IsVisible() {
+		return
+	}
+	if !l.pressed {
+		l.dragIndex = l.itemAt(x, y)
+		if l.dragIndex < 0 {
+			l.focused = PointInRect(Point{x, y}, l.ComputedBounds())
+			return
+		}
+		l.pressed = true
+		l.pressY = y
+		l.current = l.items[l.dragIndex]
+	} else {
+		l.drag(y)
+		if l.dragging {
+			e.Consume()
+			return
+		}
+	}
+	for i := len(l.items) - 1; i >= 0; i-- {
+		if l.items[i].HandleMouseDown(e); e.Consumed() {
+			break
+		}
+	}
+	l.focused = !focusWithin(l)
+	if PointInRect(Point{x, y}, l.ComputedBounds()) {
+		e.Consume()
+	}
+}
+
+// Focus gives the list the navigation keys
+func (l *ListView) Focus() {
+	l.focused = true
+}
+
+// Blur takes the navigation keys away
+func (l *ListView) Blur() {
+	l.focused = false
+}
+
+// IsFocused returns whether the navigation keys move the list's cursor
+func (l *ListView) IsFocused() bool {
+	return l.focused
+}
+
+// Current returns the index of the item under the keyboard cursor, or -1
+func (l *ListView) Current() int {
+	return l.IndexOf(l.current)
+}
+
+// SetCurrent puts the keyboard cursor on the item at an index; -1 removes it
+func (l *ListView) SetCurrent(index int) {
+	l.current = l.Item(index)
+}
+
+// HandleKeyDown moves the cursor while the list is focused: an item with
+// Up/Down, a page with PageUp/PageDown, and to the first or last item with
+// Home/End or Ctrl+Up/Down. The item it lands on is scrolled into view.
+func (l *ListView) HandleKeyDown(event InputEvent) bool {
+	if !l.focused || !l.IsVisible() || l.dragging || event.Type != InputTypeKeyDown {
+		return false
+	}
+
+	current := l.IndexOf(l.current)
+	position, ok := 0, false
+	switch {
+	case event.CtrlDown && event.Key == KeyUp:
+		position, ok = 0, len(l.items) > 0
+	case event.CtrlDown && event.Key == KeyDown:
+		position, ok = len(l.items)-1, len(l.items) > 0
+	default:
+		position, ok = NavigateList(event, current, len(l.items), l.pageSize())
+	}
+	if !ok {
+		return false
+	}
+
+	l.current = l.items[position]
+	if item, ok := l.current.(interface{ ScrollIntoView() }); ok {
+		item.ScrollIntoView()
+	}
+	return true
+}
+
+// pageSize returns how many items of average height fit in the list
+func (l *ListView) pageSize() int {
+	if len(l.items) == 0 {
+		return 1
+	}
+	average := l.itemTop(len(l.items)) / len(l.items)
+	return max(1, l.Bounds().Height/max(1, average))
+}
+
+// HandleMouseUp drops a dragged item, or passes the release to the items
+func (l *ListView) HandleMouseUp(e *Event) {
+	if !l.IsVisible() {
+		return
+	}
+	wasPressed := l.pressed
+	l.pressed = false
+	if l.dragging {
+		l.drop()
+		e.Consume()
+		return
+	}
+	if wasPressed {
+		l.dragIndex = -1
+	}
+	for i := len(l.items) - 1; i >= 0; i-- {
+		if l.items[i].HandleMouseUp(e); e.Consumed() {
+			return
+		}
+	}
+}
+
+// HandleMouseMove carries on a drag, or passes the move to the items
+func (l *ListView) HandleMouseMove(e *Event) {
+	y := e.Y
+	if !l.IsVisible() {
+		return
+	}
+	l.drag(y)
+	if l.dragging {
+		e.Consume()
+		return
+	}
+	for _, item := range l.items {
+		// Every item sees the move so stale hover states are cleared
+		moved := e.unconsumed()
+		if item.HandleMouseMove(moved); moved.Consumed() {
+			e.Consume()
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package components
+
+import "testing"
+
+// testListView returns a focused 100 pixel high list of ten 20 pixel high
+// items, so five fit on a page, with the cursor on the first
+func testListView() *ListView {
+	l := NewListView("list")
+	l.SetBounds(Rect{X: 0, Y: 0, Width: 200, Height: 100})
+	for i := 0; i < 10; i++ {
+		item := NewFlexContainer(NewID("item"))
+		item.SetBounds(Rect{X: 0, Y: 0, Width: 200, Height: 20})
+		l.AddItem(item)
+	}
+	l.HandleMouseDown(NewMouseEvent(InputTypeMouseDown, 10, 5))
+	l.HandleMouseUp(NewMouseEvent(InputTypeMouseUp, 10, 5))
+	return l
+}
+
+func TestListViewKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		start int
+		key   Key
+		ctrl  bool
+		want  int
+	}{
+		{"down", 0, KeyDown, false, 1},
+		{"up", 3, KeyUp, false, 2},
+		{"up at the top", 0, KeyUp, false, 0},
+		{"down at the end", 9, KeyDown, false, 9},
+		{"page down", 0, KeyPageDown, false, 5},
+		{"page down near the end", 7, KeyPageDown, false, 9},
+		{"page up", 8, KeyPageUp, false, 3},
+		{"home", 6, KeyHome, false, 0},
+		{"end", 2, KeyEnd, false, 9},
+		{"ctrl+up", 6, KeyUp, true, 0},
+		{"ctrl+down", 2, KeyDown, true, 9},
+	}
+	for _, tt := range tests {
+		l := testListView()
+		l.SetCurrent(tt.start)
+		handled := l.HandleKeyDown(InputEvent{Type: InputTypeKeyDown, Key: tt.key, CtrlDown: tt.ctrl})
+		if !handled || l.Current() != tt.want {
+			t.Errorf("%s from %d: cursor on %d (handled %v), want %d", tt.name, tt.start, l.Current(), handled, tt.want)
+		}
+	}
+}
+
+func TestListViewPressMovesCursor(t *testing.T) {
+	l := testListView()
+	if !l.IsFocused() || l.Current() != 0 {
+		t.Fatalf("after a press on the first item, focused %v with the cursor on %d", l.IsFocused(), l.Current())
+	}
+
+	l.HandleMouseDown(NewMouseEvent(InputTypeMouseDown, 10, 45))
+	l.HandleMouseUp(NewMouseEvent(InputTypeMouseUp, 10, 45))
+	if l.Current() != 2 {
+		t.Errorf("after a press on the third item the cursor is on %d", l.Current())
+	}
+
+	l.HandleMouseDown(NewMouseEvent(InputTypeMouseDown, 500, 500))
+	if l.IsFocused() {
+		t.Error("press outside didn't blur the list")
+	}
+	if l.HandleKeyDown(InputEvent{Type: InputTypeKeyDown, Key: KeyDown}) {
+		t.Error("unfocused list handled a key")
+	}
+}
+
+func TestListViewCursorFollowsItem(t *testing.T) {
+	l := testListView()
+	l.SetCurrent(4)
+	item := l.Item(4)
+
+	l.MoveItem(4, 1)
+	if l.Current() != 1 {
+		t.Errorf("cursor on %d after its item moved to 1", l.Current())
+	}
+	l.RemoveItem(l.IndexOf(item))
+	if l.Current() != -1 {
+		t.Errorf("cursor on %d after its item was removed", l.Current())
+	}
+	if !l.HandleKeyDown(InputEvent{Type: InputTypeKeyDown, Key: KeyDown}) || l.Current() != 0 {
+		t.Errorf("Down without a cursor put it on %d, want 0", l.Current())
+	}
+}
+
+func TestListViewScrollsCursorIntoView(t *testing.T) {
+	root := NewFlexContainer("root")
+	s := NewScrollContainer("scroll")
+	s.SetSpacing(0)
+	s.SetBounds(Rect{X: 0, Y: 0, Width: 200, Height: 100})
+	l := NewListView("list")
+	l.SetBounds(Rect{X: 0, Y: 0, Width: 200, Height: 400})
+	for i := 0; i < 20; i++ {
+		item := NewFlexContainer(NewID("item"))
+		item.SetBounds(Rect{X: 0, Y: 0, Width: 200, Height: 20})
+		l.AddItem(item)
+	}
+	s.AddChild(l)
+	root.AddChild(s)
+	l.Focus()
+
+	l.HandleKeyDown(InputEvent{Type: InputTypeKeyDown, Key: KeyEnd})
+
+	if s.ScrollY() != s.MaxScroll() || s.MaxScroll() == 0 {
+		t.Errorf("scrolled to %d of %d, want the last item in view", s.ScrollY(), s.MaxScroll())
+	}
+}
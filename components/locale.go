@@ -0,0 +1,391 @@
+package components
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// ValueFormat turns a value into display text. The formats returned by
+// NumberFormat, CurrencyFormat and the others use the locale active when
+// they run, so text formatted while drawing follows SetLocale. A ValueFormat
+// can be used as a DataGridColumn's Format.
+type ValueFormat func(value interface{}) string
+
+// Locale formats numbers, amounts of money, dates and relative times the
+// way a language and region write them
+type Locale struct {
+	name     string
+	tag      language.Tag
+	printer  *message.Printer
+	currency currency.Unit
+	date     string // time.Format layout for dates
+	clock    string // time.Format layout for times of day
+	relative relativeWords
+}
+
+// currencyAfterAmount lists languages that write the currency symbol after
+// the amount, as in "12,50 €"
+var currencyAfterAmount = map[string]bool{
+	"cs": true, "da": true, "de": true, "es": true, "fi": true, "fr": true,
+	"it": true, "nb": true, "pl": true, "pt": true, "ru": true, "sv": true,
+}
+
+// twelveHourRegions lists regions that write times of day with AM and PM
+var twelveHourRegions = map[string]bool{
+	"US": true, "CA": true, "AU": true, "NZ": true, "IN": true, "PH": true,
+}
+
+// dateLayouts lists date layouts by language and region, then by language
+var dateLayouts = map[string]string{
+	"en-US": "1/2/2006", "en-CA": "2006-01-02",
+	"en": "02/01/2006", "de": "02.01.2006", "fr": "02/01/2006", "es": "02/01/2006",
+	"it": "02/01/2006", "pt": "02/01/2006", "nl": "02-01-2006", "ru": "02.01.2006",
+	"pl": "02.01.2006", "sv": "2006-01-02", "ja": "2006/01/02", "zh": "2006/01/02",
+	"ko": "2006. 1. 2.",
+}
+
+// relativeWords are the phrases used to describe a time relative to now
+type relativeWords struct {
+	justNow, yesterday, tomorrow string
+	ago, in                      string       // Wrap an amount, e.g. "%s ago"
+	spaced                       bool         // Whether a space separates the amount from its unit
+	units                        [3][2]string // Singular and plural of minute, hour and day
+}
+
+// relativeWordsByLanguage lists the relative time phrases of each language;
+// others use English
+var relativeWordsByLanguage = map[string]relativeWords{
+	"en": {"just now", "yesterday", "tomorrow", "%s ago", "in %s", true,
+		[3][2]string{{"minute", "minutes"}, {"hour", "hours"}, {"day", "days"}}},
+	"de": {"gerade eben", "gestern", "morgen", "vor %s", "in %s", true,
+		[3][2]string{{"Minute", "Minuten"}, {"Stunde", "Stunden"}, {"Tag", "Tagen"}}},
+	"fr": {"à l'instant", "hier", "demain", "il y a %s", "dans %s", true,
+		[3][2]string{{"minute", "minutes"}, {"heure", "heures"}, {"jour", "jours"}}},
+	"es": {"ahora mismo", "ayer", "mañana", "hace %s", "dentro de %s", true,
+		[3][2]string{{"minuto", "minutos"}, {"hora", "horas"}, {"día", "días"}}},
+	"ja": {"たった今", "昨日", "明日", "%s前", "%s後", false,
+		[3][2]string{{"分", "分"}, {"時間", "時間"}, {"日", "日"}}},
+}
+
+// NewLocale creates a locale from a name such as "en-US", "de_DE" or "fr".
+// Names that can't be parsed return an error.
+func NewLocale(name string) (*Locale, error) {
+	tag, err := language.Parse(strings.ReplaceAll(name, "_", "-"))
+	if err != nil {
+		return nil, fmt.Errorf("locale %q: %w", name, err)
+	}
+	base, _ := tag.Base()
+	region, _ := tag.Region()
+	unit, _ := currency.FromTag(tag)
+
+	date, ok := dateLayouts[base.String()+"-"+region.String()]
+	if !ok {
+		if date, ok = dateLayouts[base.String()]; !ok {
+			date = "2006-01-02"
+		}
+	}
+	clock := "15:04"
+	if twelveHourRegions[region.String()] {
+		clock = "3:04 PM"
+	}
+	relative, ok := relativeWordsByLanguage[base.String()]
+	if !ok {
+		relative = relativeWordsByLanguage["en"]
+	}
+
+	return &Locale{
+		name:     tag.String(),
+		tag:      tag,
+		printer:  message.NewPrinter(tag),
+		currency: unit,
+		date:     date,
+		clock:    clock,
+		relative: relative,
+	}, nil
+}
+
+var (
+	localeMu      sync.RWMutex
+	currentLocale = mustLocale("en-US")
+)
+
+// mustLocale creates a locale known to parse
+func mustLocale(name string) *Locale {
+	locale, err := NewLocale(name)
+	if err != nil {
+		panic(err)
+	}
+	return locale
+}
+
+// SetLocale sets the locale values are formatted for across the UI
+func SetLocale(name string) error {
+	locale, err := NewLocale(name)
+	if err != nil {
+		return err
+	}
+	localeMu.Lock()
+	defer localeMu.Unlock()
+	currentLocale = locale
+	return nil
+}
+
+// CurrentLocale returns the locale set with SetLocale, en-US by default
+func CurrentLocale() *Locale {
+	localeMu.RLock()
+	defer localeMu.RUnlock()
+	return currentLocale
+}
+
+// Name returns the locale's name, such as "en-US"
+func (l *Locale) Name() string {
+	return l.name
+}
+
+// WeekStart returns the first day of the week in the locale's region
+func (l *Locale) WeekStart() time.Weekday {
+	return WeekStartForLocale(l.name)
+}
+
+// FormatNumber formats a number with the locale's digit grouping and
+// decimal separator, rounded to the given number of decimals
+func (l *Locale) FormatNumber(value float64, decimals int) string {
+	return l.sprint(number.Decimal(value, number.Scale(decimals)))
+}
+
+// FormatInteger formats a whole number with the locale's digit grouping
+func (l *Locale) FormatInteger(value int64) string {
+	return l.sprint(number.Decimal(value))
+}
+
+// FormatPercent formats a fraction as a percentage, so 0.25 is "25%"
+func (l *Locale) FormatPercent(value float64, decimals int) string {
+	return l.sprint(number.Percent(value, number.Scale(decimals)))
+}
+
+// FormatCurrency formats an amount of money in a currency given by its ISO
+// code, such as "EUR", or in the locale's own currency when code is empty.
+// The amount is rounded to the currency's usual decimals and the symbol is
+// placed where the locale writes it.
+func (l *Locale) FormatCurrency(amount float64, code string) string {
+	unit := l.currency
+	if code != "" {
+		parsed, err := currency.ParseISO(code)
+		if err != nil {
+			return l.FormatNumber(amount, 2) + " " + code
+		}
+		unit = parsed
+	}
+	scale, _ := currency.Standard.Rounding(unit)
+	digits := l.FormatNumber(math.Abs(amount), scale)
+	symbol := l.sprint(currency.Symbol(unit))
+
+	sign := ""
+	if amount < 0 && strings.ContainsAny(digits, "123456789") {
+		sign = "-"
+	}
+	base, _ := l.tag.Base()
+	if currencyAfterAmount[base.String()] {
+		return sign + digits + " " + symbol
+	}
+	if runes := []rune(symbol); len(runes) > 0 && unicode.IsLetter(runes[len(runes)-1]) {
+		// Letter codes such as "CHF" need a space before the digits
+		return sign + symbol + " " + digits
+	}
+	return sign + symbol + digits
+}
+
+// FormatDate formats the date part of a time, such as "1/2/2006" in the
+// US or "02.01.2006" in Germany
+func (l *Locale) FormatDate(t time.Time) string {
+	return t.Format(l.date)
+}
+
+// FormatTime formats the time of day, on a 12 or 24-hour clock as the
+// locale's region does
+func (l *Locale) FormatTime(t time.Time) string {
+	return t.Format(l.clock)
+}
+
+// FormatDateTime formats a date and time of day
+func (l *Locale) FormatDateTime(t time.Time) string {
+	return l.FormatDate(t) + " " + l.FormatTime(t)
+}
+
+// FormatRelativeTime describes a time relative to now in the locale's
+// language, such as "5 minutes ago" or "vor 5 Minuten"
+func (l *Locale) FormatRelativeTime(t, now time.Time) string {
+	return l.relative.format(t, now, l.FormatInteger)
+}
+
+// FormatValue formats a value of any type: numbers with digit grouping,
+// times as a date and time, and other values with fmt.Sprint
+func (l *Locale) FormatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case int:
+		return l.FormatInteger(int64(v))
+	case int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return l.sprint(number.Decimal(v))
+	case float32:
+		return l.sprint(number.Decimal(v, number.MaxFractionDigits(2)))
+	case float64:
+		return l.sprint(number.Decimal(v, number.MaxFractionDigits(2)))
+	case time.Time:
+		return l.FormatDateTime(v)
+	}
+	return fmt.Sprint(value)
+}
+
+// groupSpaces replaces the non-breaking spaces some locales group digits with
+var groupSpaces = strings.NewReplacer("\u00a0", " ", "\u202f", " ")
+
+// sprint prints with the locale's printer. Non-breaking spaces used as
+// digit group separators become plain spaces, which every font can draw.
+func (l *Locale) sprint(value interface{}) string {
+	return groupSpaces.Replace(l.printer.Sprint(value))
+}
+
+// format describes a time relative to now with the phrases
+func (w relativeWords) format(t, now time.Time, formatAmount func(int64) string) string {
+	diff := now.Sub(t)
+	future := diff < 0
+	if future {
+		diff = -diff
+	}
+
+	var amount, unit int
+	switch {
+	case diff < justNow:
+		return w.justNow
+	case diff < time.Hour:
+		amount, unit = max(1, int(diff/time.Minute)), 0
+	case diff < 24*time.Hour:
+		amount, unit = int(diff/time.Hour), 1
+	case diff < 48*time.Hour:
+		if future {
+			return w.tomorrow
+		}
+		return w.yesterday
+	default:
+		amount, unit = int(diff/(24*time.Hour)), 2
+	}
+
+	name := w.units[unit][0]
+	if amount != 1 {
+		name = w.units[unit][1]
+	}
+	separator := ""
+	if w.spaced {
+		separator = " "
+	}
+	text := formatAmount(int64(amount)) + separator + name
+	if future {
+		return fmt.Sprintf(w.in, text)
+	}
+	return fmt.Sprintf(w.ago, text)
+}
+
+// NumberFormat formats numbers for the current locale, rounded to the
+// given number of decimals
+func NumberFormat(decimals int) ValueFormat {
+	return func(value interface{}) string {
+		if f, ok := toFloat(value); ok {
+			return CurrentLocale().FormatNumber(f, decimals)
+		}
+		return CurrentLocale().FormatValue(value)
+	}
+}
+
+// PercentFormat formats fractions as percentages for the current locale
+func PercentFormat(decimals int) ValueFormat {
+	return func(value interface{}) string {
+		if f, ok := toFloat(value); ok {
+			return CurrentLocale().FormatPercent(f, decimals)
+		}
+		return CurrentLocale().FormatValue(value)
+	}
+}
+
+// CurrencyFormat formats amounts of money for the current locale, in the
+// currency with the ISO code or the locale's own when code is empty
+func CurrencyFormat(code string) ValueFormat {
+	return func(value interface{}) string {
+		if f, ok := toFloat(value); ok {
+			return CurrentLocale().FormatCurrency(f, code)
+		}
+		return CurrentLocale().FormatValue(value)
+	}
+}
+
+// DateFormat formats times as dates for the current locale
+func DateFormat() ValueFormat {
+	return func(value interface{}) string {
+		if t, ok := value.(time.Time); ok {
+			return CurrentLocale().FormatDate(t)
+		}
+		return CurrentLocale().FormatValue(value)
+	}
+}
+
+// DateTimeFormat formats times as a date and time of day for the current locale
+func DateTimeFormat() ValueFormat {
+	return func(value interface{}) string {
+		if t, ok := value.(time.Time); ok {
+			return CurrentLocale().FormatDateTime(t)
+		}
+		return CurrentLocale().FormatValue(value)
+	}
+}
+
+// RelativeTimeFormat formats times relative to now, such as "2 hours ago",
+// for the current locale
+func RelativeTimeFormat() ValueFormat {
+	return func(value interface{}) string {
+		if t, ok := value.(time.Time); ok {
+			return CurrentLocale().FormatRelativeTime(t, Now())
+		}
+		return CurrentLocale().FormatValue(value)
+	}
+}
+
+// toFloat converts a numeric value to a float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
@@ -0,0 +1,132 @@
+package components
+
+import (
+	"image/color"
+	"strings"
+)
+
+// MarkdownStyles are the paragraph styles Markdown blocks are drawn in
+type MarkdownStyles struct {
+	Body  ParagraphStyle
+	Quote ParagraphStyle
+	Code  ParagraphStyle // Fenced code blocks
+	Item  ParagraphStyle // List items
+}
+
+// DefaultMarkdownStyles returns body text with inline spans, indented list
+// items and quotes, and shaded code blocks
+func DefaultMarkdownStyles() MarkdownStyles {
+	body := DefaultParagraphStyle()
+	body.Marks = true
+
+	quote := body
+	quote.Indent = 16
+	quote.Color = color.RGBA{90, 90, 90, 255}
+
+	code := DefaultParagraphStyle()
+	code.Indent = 8
+	code.Background = color.RGBA{0, 0, 0, 15}
+	code.SpaceAfter = 14
+
+	item := body
+	item.Indent = 16
+	item.SpaceAfter = 4
+
+	return MarkdownStyles{Body: body, Quote: quote, Code: code, Item: item}
+}
+
+// ParseMarkdown turns Markdown into paragraphs for a RichText. It handles
+// # headings, paragraphs separated by blank lines, "-", "*" and "1." list
+// items, > quotes and ``` fenced code blocks, with **bold**, `code` and
+// [label](target) links inside them. Rules made of three or more dashes are
+// left out.
+func ParseMarkdown(src string, styles MarkdownStyles) []Paragraph {
+	var paragraphs []Paragraph
+	var text []string // Lines of the block being read
+	style := styles.Body
+	inCode := false
+
+	// flush ends the block being read as a paragraph
+	flush := func() {
+		if len(text) > 0 {
+			separator := " "
+			if inCode {
+				separator = "\n"
+			}
+			paragraphs = append(paragraphs, Paragraph{Text: strings.Join(text, separator), Style: style})
+		}
+		text = nil
+		style = styles.Body
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		// Fenced code keeps its lines as they are
+		if strings.HasPrefix(trimmed, "```") {
+			if inCode {
+				flush()
+				inCode = false
+			} else {
+				flush()
+				inCode = true
+				style = styles.Code
+			}
+			continue
+		}
+		if inCode {
+			text = append(text, line)
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			heading := HeadingStyle(level)
+			heading.Marks = true
+			paragraphs = append(paragraphs, Paragraph{
+				Text:    strings.TrimSpace(trimmed[level:]),
+				Style:   heading,
+				Heading: min(level, 6),
+			})
+		case len(trimmed) >= 3 && strings.Trim(trimmed, "-") == "":
+			flush()
+		case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "), strings.HasPrefix(trimmed, "+ "):
+			flush()
+			text = []string{"• " + strings.TrimSpace(trimmed[2:])}
+			style = styles.Item
+		case orderedItem(trimmed):
+			flush()
+			text = []string{trimmed}
+			style = styles.Item
+		case strings.HasPrefix(trimmed, ">"):
+			if style != styles.Quote {
+				flush()
+				style = styles.Quote
+			}
+			text = append(text, strings.TrimSpace(strings.TrimPrefix(trimmed, ">")))
+		default:
+			// Lines without a marker continue the paragraph, quote or item
+			text = append(text, trimmed)
+		}
+	}
+	flush()
+
+	// The last item of a list is spaced like body text
+	for i := range paragraphs {
+		next := i + 1
+		if paragraphs[i].Style == styles.Item && (next == len(paragraphs) || paragraphs[next].Style != styles.Item) {
+			paragraphs[i].Style.SpaceAfter = styles.Body.SpaceAfter
+		}
+	}
+	return paragraphs
+}
+
+// orderedItem returns whether a line starts an ordered list item, e.g. "1. "
+func orderedItem(line string) bool {
+	digits := len(line) - len(strings.TrimLeft(line, "0123456789"))
+	return digits > 0 && strings.HasPrefix(line[digits:], ". ")
+}
@@ -0,0 +1,342 @@
+package components
+
+import (
+	"image/color"
+	"unicode"
+)
+
+// Mask characters: each marks a slot the user fills; any other character in
+// a mask is a literal that is inserted automatically
+const (
+	MaskDigit        = '#' // 0-9
+	MaskLetter       = 'A' // Any letter
+	MaskAlphanumeric = '*' // Letter or digit
+)
+
+// MaskedInput is a single-line field that formats what is typed with a
+// pattern such as "(###) ###-####" or "##/##/####". Only the slots are
+// stored; literals are shown as the user reaches them, and characters that
+// don't fit the next slot are ignored.
+type MaskedInput struct {
+	*Node
+	mask            []rune
+	slots           []int  // Indices into mask of the fillable positions
+	raw             []rune // What the user typed, one rune per filled slot
+	cursor          int    // Index into raw
+	focused         bool
+	fontSize        int
+	placeholderChar rune
+	onChange        func(raw, formatted string)
+	onComplete      func(formatted string)
+	backgroundColor color.RGBA
+	borderColor     color.RGBA
+	invalidColor    color.RGBA
+	textColor       color.RGBA
+	hintColor       color.RGBA
+}
+
+// NewMaskedInput creates an empty field for a mask
+func NewMaskedInput(id, mask string) *MaskedInput {
+	m := &MaskedInput{
+		Node:            NewNode(id),
+		fontSize:        14,
+		placeholderChar: '_',
+		backgroundColor: color.RGBA{255, 255, 255, 255},
+		borderColor:     color.RGBA{100, 100, 100, 255},
+		invalidColor:    color.RGBA{200, 60, 60, 255},
+		textColor:       color.RGBA{0, 0, 0, 255},
+		hintColor:       color.RGBA{180, 180, 180, 255},
+	}
+	m.SetMask(mask)
+	m.SetCursorShape(CursorText)
+	return m
+}
+
+// SetMask changes the pattern, keeping as much of the typed value as fits
+func (m *MaskedInput) SetMask(mask string) {
+	m.mask = []rune(mask)
+	m.slots = m.slots[:0]
+	for i, r := range m.mask {
+		if isMaskSlot(r) {
+			m.slots = append(m.slots, i)
+		}
+	}
+	m.SetValue(string(m.raw))
+}
+
+// Mask returns the pattern
+func (m *MaskedInput) Mask() string {
+	return string(m.mask)
+}
+
+// isMaskSlot returns whether a mask character is filled by the user
+func isMaskSlot(r rune) bool {
+	return r == MaskDigit || r == MaskLetter || r == MaskAlphanumeric
+}
+
+// fits returns whether a rune can fill a slot
+func (m *MaskedInput) fits(slot int, r rune) bool {
+	switch m.mask[m.slots[slot]] {
+	case MaskDigit:
+		return unicode.IsDigit(r)
+	case MaskLetter:
+		return unicode.IsLetter(r)
+	default:
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}
+}
+
+// SetValue sets the raw value, e.g. "5551234567". Literals in the value
+// are skipped, so formatted text can be passed too, and runes that don't
+// fit their slot are dropped.
+func (m *MaskedInput) SetValue(value string) {
+	m.raw = m.raw[:0]
+	for _, r := range value {
+		if len(m.raw) == len(m.slots) {
+			break
+		}
+		if m.fits(len(m.raw), r) {
+			m.raw = append(m.raw, r)
+		}
+	}
+	m.cursor = len(m.raw)
+	m.changed()
+}
+
+// RawValue returns the typed characters without literals
+func (m *MaskedInput) RawValue() string {
+	return string(m.raw)
+}
+
+// FormattedValue returns the typed characters with the literals that come
+// before the last one, e.g. "(555) 12" part way through a phone number
+func (m *MaskedInput) FormattedValue() string {
+	return m.format(len(m.raw))
+}
+
+// format returns the mask filled with the first n typed characters, ending
+// at the last filled slot, or with trailing literals once complete
+func (m *MaskedInput) format(n int) string {
+	if n == 0 {
+		return ""
+	}
+	end := len(m.mask)
+	if n < len(m.slots) {
+		end = m.slots[n-1] + 1
+	}
+	out := make([]rune, 0, end)
+	slot := 0
+	for _, r := range m.mask[:end] {
+		if isMaskSlot(r) {
+			out = append(out, m.raw[slot])
+			slot++
+		} else {
+			out = append(out, r)
+		}
+	}
+	return string(out)
+}
+
+// display returns the whole mask with typed characters filled in and the
+// placeholder character in empty slots
+func (m *MaskedInput) display() string {
+	out := make([]rune, len(m.mask))
+	slot := 0
+	for i, r := range m.mask {
+		switch {
+		case !isMaskSlot(r):
+			out[i] = r
+		case slot < len(m.raw):
+			out[i] = m.raw[slot]
+			slot++
+		default:
+			out[i] = m.placeholderChar
+		}
+	}
+	return string(out)
+}
+
+// IsComplete returns whether every slot is filled
+func (m *MaskedInput) IsComplete() bool {
+	return len(m.raw) == len(m.slots)
+}
+
+// SetPlaceholderChar sets the character shown in empty slots
+func (m *MaskedInput) SetPlaceholderChar(r rune) {
+	m.placeholderChar = r
+}
+
+// SetFontSize sets the font size
+func (m *MaskedInput) SetFontSize(size int) {
+	m.fontSize = size
+}
+
+// SetOnChange sets the handler called with the raw and formatted value
+// after each edit
+func (m *MaskedInput) SetOnChange(handler func(raw, formatted string)) {
+	m.onChange = handler
+}
+
+// SetOnComplete sets the handler called when the last slot is filled
+func (m *MaskedInput) SetOnComplete(handler func(formatted string)) {
+	m.onComplete = handler
+}
+
+// changed notifies the handlers of an edit
+func (m *MaskedInput) changed() {
+	if m.onChange != nil {
+		m.onChange(m.RawValue(), m.FormattedValue())
+	}
+	if m.IsComplete() && len(m.slots) > 0 && m.onComplete != nil {
+		m.onComplete(m.FormattedValue())
+	}
+}
+
+// typeRune inserts a rune at the cursor if it fits the slot there. Runes
+// after the cursor shift right and are dropped if they no longer fit.
+func (m *MaskedInput) typeRune(r rune) bool {
+	if m.cursor >= len(m.slots) || !m.fits(m.cursor, r) {
+		return false
+	}
+	rest := append([]rune{r}, m.raw[m.cursor:]...)
+	m.raw = m.raw[:m.cursor]
+	for _, next := range rest {
+		if len(m.raw) == len(m.slots) || !m.fits(len(m.raw), next) {
+			break
+		}
+		m.raw = append(m.raw, next)
+	}
+	m.cursor++
+	m.changed()
+	return true
+}
+
+// remove deletes the typed character at index i
+func (m *MaskedInput) remove(i int) {
+	m.raw = append(m.raw[:i], m.raw[i+1:]...)
+	// Runes that moved left may not fit their new slots
+	for j := i; j < len(m.raw); j++ {
+		if !m.fits(j, m.raw[j]) {
+			m.raw = m.raw[:j]
+			break
+		}
+	}
+	m.cursor = min(m.cursor, len(m.raw))
+	m.changed()
+}
+
+// caretX returns the x position of the cursor, after the literals that
+// precede the next slot
+func (m *MaskedInput) caretX(bounds Rect) int {
+	index := len(m.mask)
+	if m.cursor < len(m.slots) {
+		index = m.slots[m.cursor]
+	}
+	return bounds.X + 5 + MeasureText(string([]rune(m.display())[:index]))
+}
+
+// Draw draws the formatted value with placeholders for empty slots
+func (m *MaskedInput) Draw(surface DrawSurface) {
+	if !m.IsVisible() {
+		return
+	}
+
+	bounds := m.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, m.backgroundColor)
+	border := m.borderColor
+	if !m.focused && len(m.raw) > 0 && !m.IsComplete() {
+		border = m.invalidColor
+	}
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, border)
+
+	textY := bounds.Y + (bounds.Height-m.fontSize)/2
+	if len(m.raw) > 0 || m.focused {
+		// Hint at the whole pattern, then draw the filled part over it
+		surface.DrawText(m.display(), bounds.X+5, textY, m.hintColor, m.fontSize)
+		surface.DrawText(m.FormattedValue(), bounds.X+5, textY, m.textColor, m.fontSize)
+	} else {
+		surface.DrawText(string(m.mask), bounds.X+5, textY, m.hintColor, m.fontSize)
+	}
+
+	if m.focused {
+		cx := m.caretX(bounds)
+		surface.DrawLine(cx, textY, cx, textY+m.fontSize, m.textColor)
+	}
+}
+
+// HandleMouseDown focuses the field and puts the cursor at the end of the
+// typed characters
+func (m *MaskedInput) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !m.IsVisible() {
+		return
+	}
+	if !PointInRect(Point{x, y}, m.ComputedBounds()) {
+		m.focused = false
+		return
+	}
+	m.focused = true
+	m.cursor = len(m.raw)
+	e.Consume()
+}
+
+// Focus gives the field keyboard focus
+func (m *MaskedInput) Focus() {
+	m.focused = true
+}
+
+// Blur removes keyboard focus
+func (m *MaskedInput) Blur() {
+	m.focused = false
+}
+
+// IsFocused returns whether the field receives typed characters
+func (m *MaskedInput) IsFocused() bool {
+	return m.focused
+}
+
+// HandleKeyDown fills slots from typed characters. Typing a literal of the
+// mask is accepted and skipped, so pasted or fully typed values also work.
+func (m *MaskedInput) HandleKeyDown(event InputEvent) bool {
+	if !m.focused || !m.IsVisible() {
+		return false
+	}
+
+	if event.Type == InputTypeChar {
+		if event.Char < 32 || event.CtrlDown {
+			return false
+		}
+		// Literals and runes that don't fit are swallowed
+		m.typeRune(event.Char)
+		return true
+	}
+
+	switch event.Key.Base() {
+	case KeyBackspace:
+		if m.cursor > 0 {
+			m.cursor--
+			m.remove(m.cursor)
+		}
+	case KeyDelete:
+		if m.cursor < len(m.raw) {
+			m.remove(m.cursor)
+		}
+	case KeyLeft:
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case KeyRight:
+		if m.cursor < len(m.raw) {
+			m.cursor++
+		}
+	case KeyHome:
+		m.cursor = 0
+	case KeyEnd:
+		m.cursor = len(m.raw)
+	case KeyEscape:
+		m.focused = false
+	default:
+		return false
+	}
+	return true
+}
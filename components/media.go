@@ -3,6 +3,11 @@ package components
 import (
 	"image"
 	"image/color"
+	"io"
+	"io/fs"
+	"time"
+	
+	"github.com/hajimehoshi/ebiten/v2/audio"
 )
 
 // Image represents an image element in the UI
@@ -11,6 +16,33 @@ type Image struct {
 	source    image.Image
 	srcPath   string
 	fitMethod ImageFitMethod
+	
+	// Loading state for sources decoded in the background
+	loadState   ImageLoadState
+	loadErr     error
+	loadStarted time.Time
+	generation  int // Bumped per source so stale loads are dropped
+	onLoad      func(error)
+	
+	// Playback of animated GIFs and sprite sheets
+	anim           *ImageAnimation
+	playing        bool
+	finished       bool
+	frame          int
+	frameStart     time.Time
+	pausedAt       time.Duration // Time into the frame when paused
+	plays          int           // Completed passes through the frames
+	loops          int
+	onAnimationEnd func()
+	
+	focused      bool
+	onPasteImage func(image.Image)
+	pressed      bool
+	onClick      func()
+	
+	placeholderColor color.RGBA
+	indicatorColor   color.RGBA
+	errorColor       color.RGBA
 }
 
 // ImageFitMethod defines how an image should be sized to fit its container
@@ -20,6 +52,7 @@ const (
 	ImageFitContain ImageFitMethod = iota // Maintain aspect ratio, fit within bounds
 	ImageFitCover                         // Maintain aspect ratio, cover entire bounds (may crop)
 	ImageFitFill                          // Stretch to fill bounds (may distort)
+	ImageFitNinePatch                     // Stretch the center and edges, keeping corners (see NinePatch)
 )
 
 // NewImage creates a new image element
@@ -29,18 +62,79 @@ func NewImage(id string) *Image {
 		source:    nil,
 		srcPath:   "",
 		fitMethod: ImageFitContain,
+		placeholderColor: color.RGBA{230, 230, 230, 255},
+		indicatorColor:   color.RGBA{150, 150, 150, 255},
+		errorColor:       color.RGBA{200, 60, 60, 255},
 	}
 }
 
 // SetSource sets the image source
 func (i *Image) SetSource(img image.Image) {
+	i.generation++
+	i.anim = nil
+	i.playing = false
 	i.source = img
+	i.srcPath = ""
+	i.loadErr = nil
+	if img != nil {
+		i.loadState = ImageLoaded
+	} else {
+		i.loadState = ImageEmpty
+	}
 }
 
-// SetSourcePath sets the path to the image source
+// SetSourcePath loads a PNG, JPEG or GIF file in the background. A loading
+// placeholder is drawn until it is ready, and an error placeholder if it
+// fails. Results are delivered by RunFrameCallbacks.
 func (i *Image) SetSourcePath(path string) {
+	i.loadAsync(func() (image.Image, error) {
+		return LoadImageFile(path)
+	})
+	i.srcPath = path
+}
+
+// SetSourceFS loads an image from a file system such as an embed.FS in the
+// background
+func (i *Image) SetSourceFS(fsys fs.FS, path string) {
+	i.loadAsync(func() (image.Image, error) {
+		return LoadImageFS(fsys, path)
+	})
 	i.srcPath = path
-	// In a real implementation, this would load the image from the path
+}
+
+// SetSourceReader decodes an image from a reader in the background. The
+// reader must not be used by anything else until the load finishes.
+func (i *Image) SetSourceReader(r io.Reader) {
+	i.loadAsync(func() (image.Image, error) {
+		return DecodeImage(r)
+	})
+	i.srcPath = ""
+}
+
+// SourcePath returns the path of the image source, if loaded from a file
+func (i *Image) SourcePath() string {
+	return i.srcPath
+}
+
+// Source returns the decoded image, or nil while loading or after an error
+func (i *Image) Source() image.Image {
+	return i.source
+}
+
+// LoadState returns whether the source is loading, loaded or failed
+func (i *Image) LoadState() ImageLoadState {
+	return i.loadState
+}
+
+// LoadError returns why the source failed to load, or nil
+func (i *Image) LoadError() error {
+	return i.loadErr
+}
+
+// SetOnLoad sets the handler called when a background load finishes, with
+// the error if it failed
+func (i *Image) SetOnLoad(handler func(error)) {
+	i.onLoad = handler
 }
 
 // SetFitMethod sets how the image should fit within its bounds
@@ -48,16 +142,29 @@ func (i *Image) SetFitMethod(method ImageFitMethod) {
 	i.fitMethod = method
 }
 
-// Draw draws the image
+// Draw draws the image, or a placeholder while loading or after an error
 func (i *Image) Draw(surface DrawSurface) {
-	if !i.IsVisible() || i.source == nil {
+	if !i.IsVisible() {
 		return
 	}
 	
+	i.advance()
 	bounds := i.ComputedBounds()
 	
-	// Draw the image
-	surface.DrawImage(i.source, bounds.X, bounds.Y, bounds.Width, bounds.Height, i.fitMethod)
+	switch i.loadState {
+	case ImageLoaded:
+		surface.DrawImage(i.source, bounds.X, bounds.Y, bounds.Width, bounds.Height, i.fitMethod)
+	case ImageLoading:
+		i.drawLoading(surface, bounds)
+	case ImageFailed:
+		i.drawFailed(surface, bounds)
+	default:
+		return
+	}
+	
+	if i.focused {
+		surface.DrawRect(bounds.X-2, bounds.Y-2, bounds.Width+4, bounds.Height+4, i.indicatorColor)
+	}
 	
 	// Draw children (if any)
 	for _, child := range i.Children() {
@@ -65,16 +172,124 @@ func (i *Image) Draw(surface DrawSurface) {
 	}
 }
 
+// drawLoading draws a placeholder with three dots pulsing in turn
+func (i *Image) drawLoading(surface DrawSurface, bounds Rect) {
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, i.placeholderColor)
+	
+	active := int(since(i.loadStarted)/(300*time.Millisecond)) % 3
+	cx, cy := bounds.X+bounds.Width/2, bounds.Y+bounds.Height/2
+	for dot := 0; dot < 3; dot++ {
+		radius := 2
+		if dot == active {
+			radius = 4
+		}
+		surface.FillCircle(cx+(dot-1)*12, cy, radius, i.indicatorColor)
+	}
+}
+
+// drawFailed draws a placeholder with a cross, like a broken image
+func (i *Image) drawFailed(surface DrawSurface, bounds Rect) {
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, i.placeholderColor)
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, i.errorColor)
+	
+	size := min(bounds.Width, bounds.Height) / 4
+	cx, cy := bounds.X+bounds.Width/2, bounds.Y+bounds.Height/2
+	surface.DrawLine(cx-size, cy-size, cx+size, cy+size, i.errorColor)
+	surface.DrawLine(cx-size, cy+size, cx+size, cy-size, i.errorColor)
+}
+
 // HandleMouseDown handles mouse down events
-func (i *Image) HandleMouseDown(x, y int) bool {
+func (i *Image) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
 	// Image doesn't handle mouse events directly, but we check children
 	for j := len(i.Children()) - 1; j >= 0; j-- {
 		child := i.Children()[j]
-		if child.HandleMouseDown(x, y) {
-			return true
+		if child.HandleMouseDown(e); e.Consumed() {
+			return
 		}
 	}
-	return false
+	
+	if !i.IsVisible() {
+		return
+	}
+	inside := PointInRect(Point{x, y}, i.ComputedBounds())
+	
+	// Images that accept pastes take focus when clicked
+	if i.onPasteImage != nil {
+		i.focused = inside
+	}
+	
+	// Clickable images are clicked when the button is released over them
+	if i.onClick != nil && inside {
+		i.pressed = true
+	}
+	if inside && (i.onPasteImage != nil || i.onClick != nil) {
+		e.Consume()
+	}
+}
+
+// HandleMouseUp clicks the image if it was pressed and the button is
+// released over it
+func (i *Image) HandleMouseUp(e *Event) {
+	x, y := e.X, e.Y
+	if !i.pressed {
+		i.Node.HandleMouseUp(e)
+		return
+	}
+	i.pressed = false
+	if i.onClick != nil && PointInRect(Point{x, y}, i.ComputedBounds()) {
+		i.onClick()
+	}
+	e.Consume()
+}
+
+// SetOnClick sets the handler called when the image is clicked, showing a
+// pointer cursor over it while set
+func (i *Image) SetOnClick(handler func()) {
+	i.onClick = handler
+	if handler != nil {
+		i.SetCursorShape(CursorPointer)
+	} else {
+		i.SetCursorShape(CursorDefault)
+	}
+}
+
+// SetOnPasteImage lets the image be replaced from the clipboard. Clicking
+// the image focuses it; pasting then shows the clipboard image and calls the
+// handler with it. nil turns pasting off.
+func (i *Image) SetOnPasteImage(handler func(image.Image)) {
+	i.onPasteImage = handler
+	if handler == nil {
+		i.focused = false
+	}
+}
+
+// Focus gives the image keyboard focus if it accepts pastes
+func (i *Image) Focus() {
+	i.focused = i.onPasteImage != nil
+}
+
+// Blur removes keyboard focus
+func (i *Image) Blur() {
+	i.focused = false
+}
+
+// IsFocused returns whether the image receives the paste shortcut
+func (i *Image) IsFocused() bool {
+	return i.focused
+}
+
+// HandleKeyDown pastes an image from the clipboard while focused
+func (i *Image) HandleKeyDown(event InputEvent) bool {
+	if !i.focused || !i.IsVisible() {
+		return false
+	}
+	return pasteImageOnKey(event, func(img image.Image) {
+		i.SetSource(img)
+		if i.onPasteImage != nil {
+			i.onPasteImage(img)
+		}
+	})
 }
 
 // Video represents a video element in the UI
@@ -194,7 +409,8 @@ func (v *Video) Draw(surface DrawSurface) {
 }
 
 // HandleMouseDown handles mouse down events
-func (v *Video) HandleMouseDown(x, y int) bool {
+func (v *Video) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
 	bounds := v.ComputedBounds()
 	if PointInRect(Point{x, y}, bounds) {
 		// Toggle play/pause on click
@@ -203,21 +419,21 @@ func (v *Video) HandleMouseDown(x, y int) bool {
 		} else {
 			v.Play()
 		}
-		return true
+		e.Consume()
+		return
 	}
 	
 	// Check children
 	for i := len(v.Children()) - 1; i >= 0; i-- {
 		child := v.Children()[i]
-		if child.HandleMouseDown(x, y) {
-			return true
+		if child.HandleMouseDown(e); e.Consumed() {
+			return
 		}
 	}
-	
-	return false
 }
 
-// Audio represents an audio element in the UI
+// Audio represents an audio element in the UI. Sources are played through
+// ebiten's audio package; WAV, OGG Vorbis and MP3 files are supported.
 type Audio struct {
 	*Node
 	source     string
@@ -226,6 +442,11 @@ type Audio struct {
 	onPause    func()
 	onComplete func()
 	volume     float64
+	
+	player   *audio.Player
+	closer   io.Closer // File the player streams from, if any
+	duration time.Duration
+	loadErr  error
 }
 
 // NewAudio creates a new audio element
@@ -240,15 +461,22 @@ func NewAudio(id string) *Audio {
 	}
 }
 
-// SetSource sets the audio source
+// SetSource loads an audio file, streaming it from disk. Errors are kept
+// for LoadError, and the control stays silent.
 func (a *Audio) SetSource(source string) {
-	a.source = source
+	a.openAudioFile(source)
+}
+
+// Source returns the path of the audio source
+func (a *Audio) Source() string {
+	return a.source
 }
 
 // Play starts playing the audio
 func (a *Audio) Play() {
-	if !a.isPlaying {
+	if !a.isPlaying && a.player != nil {
 		a.isPlaying = true
+		a.player.Play()
 		if a.onPlay != nil {
 			a.onPlay()
 		}
@@ -259,6 +487,7 @@ func (a *Audio) Play() {
 func (a *Audio) Pause() {
 	if a.isPlaying {
 		a.isPlaying = false
+		a.player.Pause()
 		if a.onPause != nil {
 			a.onPause()
 		}
@@ -279,6 +508,14 @@ func (a *Audio) SetVolume(volume float64) {
 	} else {
 		a.volume = volume
 	}
+	if a.player != nil {
+		a.player.SetVolume(a.volume)
+	}
+}
+
+// Volume returns the audio volume
+func (a *Audio) Volume() float64 {
+	return a.volume
 }
 
 // SetOnPlay sets the handler for when the audio starts playing
@@ -296,11 +533,31 @@ func (a *Audio) SetOnComplete(handler func()) {
 	a.onComplete = handler
 }
 
+// audioLayout returns the play button, the seek bar track and the volume
+// slider track of the control
+func (a *Audio) audioLayout() (button, seek, volume Rect) {
+	bounds := a.ComputedBounds()
+	buttonSize := bounds.Height - 10
+	button = Rect{bounds.X + 5, bounds.Y + 5, buttonSize, buttonSize}
+	
+	// The volume slider takes a quarter of the space after the button and
+	// the time label, the seek bar the rest
+	timeWidth := MeasureText("00:00 / 00:00")
+	left := button.X + buttonSize + 10
+	width := bounds.Width - buttonSize - timeWidth - 35
+	volumeWidth := width / 4
+	centerY := bounds.Y + bounds.Height / 2
+	seek = Rect{left, centerY - 5, width - volumeWidth - 10, 10}
+	volume = Rect{left + width - volumeWidth + timeWidth + 10, centerY - 5, volumeWidth, 10}
+	return button, seek, volume
+}
+
 // Draw draws the audio control
 func (a *Audio) Draw(surface DrawSurface) {
 	if !a.IsVisible() {
 		return
 	}
+	a.advance()
 	
 	bounds := a.ComputedBounds()
 	
@@ -308,11 +565,16 @@ func (a *Audio) Draw(surface DrawSurface) {
 	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, color.RGBA{80, 80, 80, 255})
 	
 	// Draw play/pause button
-	buttonX := bounds.X + 5
-	buttonY := bounds.Y + 5
-	buttonSize := bounds.Height - 10
+	button, seek, volume := a.audioLayout()
+	buttonX := button.X
+	buttonY := button.Y
+	buttonSize := button.Width
 	
-	surface.FillRect(buttonX, buttonY, buttonSize, buttonSize, color.RGBA{120, 120, 120, 255})
+	buttonColor := color.RGBA{120, 120, 120, 255}
+	if a.player == nil {
+		buttonColor = color.RGBA{100, 100, 100, 255}
+	}
+	surface.FillRect(buttonX, buttonY, buttonSize, buttonSize, buttonColor)
 	
 	if a.isPlaying {
 		// Draw pause icon
@@ -333,16 +595,29 @@ func (a *Audio) Draw(surface DrawSurface) {
 		surface.DrawLine(playX, playY + buttonSize / 2, playX + buttonSize / 3, playY + buttonSize / 4, color.RGBA{255, 255, 255, 255})
 	}
 	
-	// Draw volume slider background
-	sliderX := buttonX + buttonSize + 10
-	sliderY := bounds.Y + bounds.Height / 2
-	sliderWidth := bounds.Width - buttonSize - 20
+	// Draw seek bar with the played part filled
+	centerY := seek.Y + seek.Height / 2
+	surface.DrawLine(seek.X, centerY, seek.X + seek.Width, centerY, color.RGBA{150, 150, 150, 255})
+	played := int(a.Progress() * float64(seek.Width))
+	surface.FillRect(seek.X, centerY - 2, played, 4, color.RGBA{100, 160, 255, 255})
+	surface.FillRect(seek.X + played - 3, centerY - 5, 6, 10, color.RGBA{230, 230, 230, 255})
 	
-	surface.DrawLine(sliderX, sliderY, sliderX + sliderWidth, sliderY, color.RGBA{150, 150, 150, 255})
+	// Draw position and duration, or the load error
+	label := formatPlaybackTime(a.Position()) + " / " + formatPlaybackTime(a.duration)
+	labelColor := color.RGBA{220, 220, 220, 255}
+	if a.loadErr != nil {
+		label = "Can't play"
+		labelColor = color.RGBA{255, 140, 140, 255}
+	}
+	surface.DrawText(label, seek.X + seek.Width + 10, centerY - 6, labelColor, 12)
+	
+	// Draw volume slider background
+	sliderY := volume.Y + volume.Height / 2
+	surface.DrawLine(volume.X, sliderY, volume.X + volume.Width, sliderY, color.RGBA{150, 150, 150, 255})
 	
 	// Draw volume slider position
-	volumePos := int(a.volume * float64(sliderWidth))
-	surface.FillRect(sliderX, sliderY - 3, volumePos, 6, color.RGBA{200, 200, 200, 255})
+	volumePos := int(a.volume * float64(volume.Width))
+	surface.FillRect(volume.X, sliderY - 3, volumePos, 6, color.RGBA{200, 200, 200, 255})
 	
 	// Draw children (if any)
 	for _, child := range a.Children() {
@@ -351,46 +626,48 @@ func (a *Audio) Draw(surface DrawSurface) {
 }
 
 // HandleMouseDown handles mouse down events
-func (a *Audio) HandleMouseDown(x, y int) bool {
+func (a *Audio) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
 	bounds := a.ComputedBounds()
 	if !PointInRect(Point{x, y}, bounds) {
-		return false
+		return
 	}
 	
 	// Check if click is on play/pause button
-	buttonX := bounds.X + 5
-	buttonY := bounds.Y + 5
-	buttonSize := bounds.Height - 10
-	
-	buttonRect := Rect{buttonX, buttonY, buttonSize, buttonSize}
-	if PointInRect(Point{x, y}, buttonRect) {
+	button, seek, volume := a.audioLayout()
+	if PointInRect(Point{x, y}, button) {
 		// Toggle play/pause
 		if a.isPlaying {
 			a.Pause()
 		} else {
 			a.Play()
 		}
-		return true
+		e.Consume()
+		return
 	}
 	
-	// Check if click is on volume slider
-	sliderX := buttonX + buttonSize + 10
-	sliderY := bounds.Y + bounds.Height / 2
-	sliderWidth := bounds.Width - buttonSize - 20
+	// Check if click is on the seek bar
+	if seek.Width > 0 && PointInRect(Point{x, y}, seek) {
+		a.Seek(time.Duration(float64(x - seek.X) / float64(seek.Width) * float64(a.duration)))
+		e.Consume()
+		return
+	}
 	
-	if x >= sliderX && x <= sliderX + sliderWidth && y >= sliderY - 5 && y <= sliderY + 5 {
+	// Check if click is on volume slider
+	if volume.Width > 0 && PointInRect(Point{x, y}, volume) {
 		// Set volume based on click position
-		a.SetVolume(float64(x - sliderX) / float64(sliderWidth))
-		return true
+		a.SetVolume(float64(x - volume.X) / float64(volume.Width))
+		e.Consume()
+		return
 	}
 	
 	// Check children
 	for i := len(a.Children()) - 1; i >= 0; i-- {
 		child := a.Children()[i]
-		if child.HandleMouseDown(x, y) {
-			return true
+		if child.HandleMouseDown(e); e.Consumed() {
+			return
 		}
 	}
 	
-	return true
+	e.Consume()
 } 
\ No newline at end of file
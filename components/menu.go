@@ -0,0 +1,603 @@
+package components
+
+import (
+	"image"
+	"image/color"
+)
+
+// Menu metrics shared by popup menus and menu bars
+const (
+	menuItemHeight      = 24
+	menuSeparatorHeight = 9
+	menuIconSize        = 16
+	menuPadding         = 4
+	menuMinWidth        = 120
+)
+
+// MenuItem represents a single entry in a popup menu
+type MenuItem struct {
+	Label       string
+	Icon        image.Image
+	Accelerator string // Keyboard shortcut such as "Ctrl+S", shown right-aligned
+	Separator   bool
+	Disabled    bool
+	Checkable   bool // Toggles Checked each time the item is selected
+	Checked     bool
+	Submenu     *PopupMenu
+	Command     *Command // When set, the label, icon, accelerator and enabled state follow the command
+	OnSelect    func()
+}
+
+// syncCommand copies the item's display state from its command
+func (item *MenuItem) syncCommand() {
+	if item.Command == nil {
+		return
+	}
+	item.Label = item.Command.Title
+	item.Icon = item.Command.Icon
+	item.Accelerator = item.Command.Shortcut
+	item.Disabled = !item.Command.IsEnabled()
+}
+
+// AddCheckItem adds an item with a check mark that toggles when selected
+func (m *PopupMenu) AddCheckItem(label string, checked bool, handler func()) *MenuItem {
+	item := m.AddItem(label, handler)
+	item.Checkable = true
+	item.Checked = checked
+	return item
+}
+
+// PopupMenu represents a context or popup menu that can be shown at a point
+type PopupMenu struct {
+	*Node
+	items           []*MenuItem
+	open            bool
+	hoveredIndex    int
+	openSubmenu     *PopupMenu
+	parentMenu      *PopupMenu
+	screenBounds    Rect
+	onDismiss       func()
+	backgroundColor color.RGBA
+	hoverColor      color.RGBA
+	textColor       color.RGBA
+	disabledColor   color.RGBA
+	fontSize        int
+}
+
+// NewPopupMenu creates a new popup menu
+func NewPopupMenu(id string) *PopupMenu {
+	menu := &PopupMenu{
+		Node:            NewNode(id),
+		items:           make([]*MenuItem, 0),
+		open:            false,
+		hoveredIndex:    -1,
+		screenBounds:    Rect{X: 0, Y: 0, Width: ScreenWidth, Height: ScreenHeight},
+		backgroundColor: color.RGBA{250, 250, 250, 255},
+		hoverColor:      color.RGBA{200, 200, 255, 255},
+		textColor:       color.RGBA{0, 0, 0, 255},
+		disabledColor:   color.RGBA{160, 160, 160, 255},
+		fontSize:        14,
+	}
+
+	// Menus are positioned in screen coordinates
+	menu.SetPositionType(PositionFixed)
+	menu.SetVisible(false)
+
+	return menu
+}
+
+// AddItem adds a selectable item to the menu
+func (m *PopupMenu) AddItem(label string, handler func()) *MenuItem {
+	item := &MenuItem{Label: label, OnSelect: handler}
+	m.items = append(m.items, item)
+	m.updateSize()
+	return item
+}
+
+// AddIconItem adds a selectable item with an icon to the menu
+func (m *PopupMenu) AddIconItem(label string, icon image.Image, handler func()) *MenuItem {
+	item := m.AddItem(label, handler)
+	item.Icon = icon
+	return item
+}
+
+// AddCommand adds an item that runs a command and shows its current state
+func (m *PopupMenu) AddCommand(command *Command) *MenuItem {
+	item := &MenuItem{Command: command, OnSelect: func() { command.Execute() }}
+	item.syncCommand()
+	m.items = append(m.items, item)
+	m.updateSize()
+	return item
+}
+
+// AddSeparator adds a separator line to the menu
+func (m *PopupMenu) AddSeparator() {
+	m.items = append(m.items, &MenuItem{Separator: true})
+	m.updateSize()
+}
+
+// AddSubmenu adds an item that opens a nested menu and returns the nested menu
+func (m *PopupMenu) AddSubmenu(label string) *PopupMenu {
+	submenu := NewPopupMenu(m.ID() + "_sub_" + label)
+	submenu.parentMenu = m
+	submenu.screenBounds = m.screenBounds
+	m.items = append(m.items, &MenuItem{Label: label, Submenu: submenu})
+	m.updateSize()
+	return submenu
+}
+
+// Items returns the menu items
+func (m *PopupMenu) Items() []*MenuItem {
+	return m.items
+}
+
+// Clear removes all items from the menu
+func (m *PopupMenu) Clear() {
+	m.items = make([]*MenuItem, 0)
+	m.hoveredIndex = -1
+	m.openSubmenu = nil
+	m.updateSize()
+}
+
+// SetScreenBounds sets the area the menu must stay within when shown
+func (m *PopupMenu) SetScreenBounds(bounds Rect) {
+	m.screenBounds = bounds
+	for _, item := range m.items {
+		if item.Submenu != nil {
+			item.Submenu.SetScreenBounds(bounds)
+		}
+	}
+}
+
+// SetOnDismiss sets the handler called when the menu closes without a selection
+func (m *PopupMenu) SetOnDismiss(handler func()) {
+	m.onDismiss = handler
+}
+
+// IsOpen returns whether the menu is currently shown
+func (m *PopupMenu) IsOpen() bool {
+	return m.open
+}
+
+// ShowAt opens the menu at the given screen point, such as where a context
+// menu was asked for. Where it would overflow the screen it flips to the
+// left of or above the point, and it is kept within the screen bounds.
+func (m *PopupMenu) ShowAt(x, y int) {
+	m.place(x, y, x, y)
+}
+
+// ShowBelow opens the menu below an anchor such as a menu title, lined up
+// with its left edge. If it doesn't fit below the anchor it flips above it,
+// and if it doesn't fit to the right it lines up with the anchor's right
+// edge instead.
+func (m *PopupMenu) ShowBelow(anchor Rect) {
+	m.place(anchor.X, anchor.Y+anchor.Height, anchor.X+anchor.Width, anchor.Y)
+}
+
+// place opens the menu with its top-left corner at x, y, unless it would
+// overflow the right or bottom of the screen, in which case its right edge
+// goes to flipX or its bottom edge to flipY. Then it is clamped to the screen.
+func (m *PopupMenu) place(x, y, flipX, flipY int) {
+	m.updateSize()
+	bounds := m.Bounds()
+	screen := m.screenBounds
+
+	// Flip to the other side of the anchor if the menu would overflow
+	if x+bounds.Width > screen.X+screen.Width {
+		x = flipX - bounds.Width
+	}
+	if y+bounds.Height > screen.Y+screen.Height {
+		y = flipY - bounds.Height
+	}
+
+	// Clamp to the screen, keeping the top-left corner on it
+	x = max(screen.X, min(x, screen.X+screen.Width-bounds.Width))
+	y = max(screen.Y, min(y, screen.Y+screen.Height-bounds.Height))
+
+	bounds.X = x
+	bounds.Y = y
+	m.SetBounds(bounds)
+
+	m.open = true
+	m.hoveredIndex = -1
+	m.SetVisible(true)
+}
+
+// Hide closes the menu and any open submenu
+func (m *PopupMenu) Hide() {
+	if m.openSubmenu != nil {
+		m.openSubmenu.Hide()
+		m.openSubmenu = nil
+	}
+	m.open = false
+	m.hoveredIndex = -1
+	m.SetVisible(false)
+}
+
+// Dismiss closes the menu chain and notifies the dismiss handler
+func (m *PopupMenu) Dismiss() {
+	root := m.rootMenu()
+	if !root.open {
+		return
+	}
+	root.Hide()
+	if root.onDismiss != nil {
+		root.onDismiss()
+	}
+}
+
+// rootMenu returns the top-level menu of a submenu chain
+func (m *PopupMenu) rootMenu() *PopupMenu {
+	root := m
+	for root.parentMenu != nil {
+		root = root.parentMenu
+	}
+	return root
+}
+
+// updateSize recalculates the menu size from its items
+func (m *PopupMenu) updateSize() {
+	width := menuMinWidth
+	height := menuPadding * 2
+
+	for _, item := range m.items {
+		if item.Separator {
+			height += menuSeparatorHeight
+			continue
+		}
+		item.syncCommand()
+		height += menuItemHeight
+
+		// Icon column, label, accelerator, and submenu arrow
+		itemWidth := menuIconSize + menuPadding*4 + len(item.Label)*m.fontSize/2 + 16
+		if item.Accelerator != "" {
+			itemWidth += len(item.Accelerator)*m.fontSize/2 + menuPadding*6
+		}
+		if itemWidth > width {
+			width = itemWidth
+		}
+	}
+
+	bounds := m.Bounds()
+	bounds.Width = width
+	bounds.Height = height
+	m.SetBounds(bounds)
+}
+
+// itemRect returns the bounds of the item at the given index
+func (m *PopupMenu) itemRect(index int) Rect {
+	bounds := m.ComputedBounds()
+	y := bounds.Y + menuPadding
+
+	for i, item := range m.items {
+		height := menuItemHeight
+		if item.Separator {
+			height = menuSeparatorHeight
+		}
+		if i == index {
+			return Rect{X: bounds.X, Y: y, Width: bounds.Width, Height: height}
+		}
+		y += height
+	}
+
+	return Rect{}
+}
+
+// itemAt returns the index of the item at the given point, or -1
+func (m *PopupMenu) itemAt(x, y int) int {
+	for i := range m.items {
+		if PointInRect(Point{x, y}, m.itemRect(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// containsPoint checks if the point is over this menu or any open submenu
+func (m *PopupMenu) containsPoint(x, y int) bool {
+	if !m.open {
+		return false
+	}
+	if PointInRect(Point{x, y}, m.ComputedBounds()) {
+		return true
+	}
+	return m.openSubmenu != nil && m.openSubmenu.containsPoint(x, y)
+}
+
+// openSubmenuAt opens the submenu of the item at the given index
+func (m *PopupMenu) openSubmenuAt(index int) {
+	item := m.items[index]
+	if item.Submenu == m.openSubmenu {
+		return
+	}
+
+	if m.openSubmenu != nil {
+		m.openSubmenu.Hide()
+		m.openSubmenu = nil
+	}
+
+	if item.Submenu == nil || item.Disabled {
+		return
+	}
+
+	// Open to the right, lined up with the item; flip to the left or up
+	// if it would overflow
+	rect := m.itemRect(index)
+	submenu := item.Submenu
+	submenu.place(rect.X+rect.Width-2, rect.Y-menuPadding, rect.X+2, rect.Y+rect.Height+menuPadding)
+	m.openSubmenu = submenu
+}
+
+// activate selects the item at the given index
+func (m *PopupMenu) activate(index int) {
+	item := m.items[index]
+	item.syncCommand()
+	if item.Separator || item.Disabled {
+		return
+	}
+
+	if item.Submenu != nil {
+		m.openSubmenuAt(index)
+		return
+	}
+
+	if item.Checkable {
+		item.Checked = !item.Checked
+	}
+
+	// Close the whole menu chain before running the handler
+	m.rootMenu().Hide()
+	if item.OnSelect != nil {
+		item.OnSelect()
+	}
+}
+
+// Draw draws the menu and any open submenu
+func (m *PopupMenu) Draw(surface DrawSurface) {
+	if !m.open || !m.IsVisible() {
+		return
+	}
+
+	bounds := m.ComputedBounds()
+
+	// Draw drop shadow, background and border
+	surface.FillRect(bounds.X+3, bounds.Y+3, bounds.Width, bounds.Height, color.RGBA{0, 0, 0, 60})
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, m.backgroundColor)
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, color.RGBA{150, 150, 150, 255})
+
+	for i, item := range m.items {
+		rect := m.itemRect(i)
+
+		if item.Separator {
+			lineY := rect.Y + rect.Height/2
+			surface.DrawLine(rect.X+menuPadding, lineY, rect.X+rect.Width-menuPadding, lineY, color.RGBA{200, 200, 200, 255})
+			continue
+		}
+		item.syncCommand()
+
+		// Highlight the hovered item or the item whose submenu is open
+		highlighted := i == m.hoveredIndex || (item.Submenu != nil && item.Submenu == m.openSubmenu)
+		if highlighted && !item.Disabled {
+			surface.FillRect(rect.X+1, rect.Y, rect.Width-2, rect.Height, m.hoverColor)
+		}
+
+		textColor := m.textColor
+		if item.Disabled {
+			textColor = m.disabledColor
+		}
+
+		// Draw icon or check mark
+		iconX := rect.X + menuPadding*2
+		iconY := rect.Y + (rect.Height-menuIconSize)/2
+		if item.Checkable && item.Checked {
+			surface.DrawLine(iconX+3, iconY+8, iconX+6, iconY+12, textColor)
+			surface.DrawLine(iconX+6, iconY+12, iconX+13, iconY+4, textColor)
+		} else if item.Icon != nil {
+			surface.DrawImage(item.Icon, iconX, iconY, menuIconSize, menuIconSize, ImageFitContain)
+		}
+
+		// Draw label
+		textX := iconX + menuIconSize + menuPadding*2
+		textY := rect.Y + (rect.Height-m.fontSize)/2
+		surface.DrawText(item.Label, textX, textY, textColor, m.fontSize)
+
+		// Draw accelerator right-aligned
+		if item.Accelerator != "" {
+			accX := rect.X + rect.Width - menuPadding*4 - len(item.Accelerator)*m.fontSize/2
+			surface.DrawText(item.Accelerator, accX, textY, m.disabledColor, m.fontSize)
+		}
+
+		// Draw submenu arrow
+		if item.Submenu != nil {
+			arrowX := rect.X + rect.Width - 12
+			arrowY := rect.Y + rect.Height/2
+			surface.DrawLine(arrowX, arrowY-4, arrowX+4, arrowY, textColor)
+			surface.DrawLine(arrowX+4, arrowY, arrowX, arrowY+4, textColor)
+		}
+	}
+
+	if m.openSubmenu != nil {
+		m.openSubmenu.Draw(surface)
+	}
+}
+
+// HandleMouseDown handles mouse down events, dismissing the menu on outside clicks
+func (m *PopupMenu) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !m.open {
+		return
+	}
+
+	if m.containsPoint(x, y) {
+		e.Consume()
+		return
+	}
+
+	// Click outside the menu chain closes it
+	m.Dismiss()
+	e.Consume()
+}
+
+// HandleMouseUp handles mouse up events, activating the item under the cursor
+func (m *PopupMenu) HandleMouseUp(e *Event) {
+	x, y := e.X, e.Y
+	if !m.open {
+		return
+	}
+
+	// Let an open submenu handle the event first
+	if m.openSubmenu != nil && m.openSubmenu.containsPoint(x, y) {
+		m.openSubmenu.HandleMouseUp(e)
+		return
+	}
+
+	if !PointInRect(Point{x, y}, m.ComputedBounds()) {
+		return
+	}
+
+	if index := m.itemAt(x, y); index >= 0 {
+		m.activate(index)
+	}
+
+	e.Consume()
+}
+
+// HandleMouseMove handles mouse move events, tracking hover and opening submenus
+func (m *PopupMenu) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !m.open {
+		return
+	}
+
+	if m.openSubmenu != nil && m.openSubmenu.containsPoint(x, y) {
+		m.openSubmenu.HandleMouseMove(e)
+		return
+	}
+
+	if !PointInRect(Point{x, y}, m.ComputedBounds()) {
+		m.hoveredIndex = -1
+		return
+	}
+
+	m.hoveredIndex = m.itemAt(x, y)
+	if m.hoveredIndex >= 0 && !m.items[m.hoveredIndex].Separator {
+		m.openSubmenuAt(m.hoveredIndex)
+	}
+
+	e.Consume()
+}
+
+// HandleKeyDown handles keyboard navigation and accelerators while the menu is open
+func (m *PopupMenu) HandleKeyDown(event InputEvent) bool {
+	if !m.open {
+		return false
+	}
+
+	// The innermost open submenu receives navigation keys
+	if m.openSubmenu != nil && m.openSubmenu.hoveredIndex >= 0 {
+		return m.openSubmenu.HandleKeyDown(event)
+	}
+
+	switch event.Key {
+	case KeyEscape:
+		if m.parentMenu != nil {
+			m.parentMenu.openSubmenu = nil
+			m.Hide()
+		} else {
+			m.Dismiss()
+		}
+		return true
+	case KeyDown:
+		m.moveHover(1)
+		return true
+	case KeyUp:
+		m.moveHover(-1)
+		return true
+	case KeyRight:
+		if m.hoveredIndex >= 0 && m.items[m.hoveredIndex].Submenu != nil {
+			m.openSubmenuAt(m.hoveredIndex)
+			if m.openSubmenu != nil {
+				m.openSubmenu.moveHover(1)
+			}
+		}
+		return true
+	case KeyLeft:
+		if m.parentMenu != nil {
+			m.parentMenu.openSubmenu = nil
+			m.Hide()
+			return true
+		}
+		return false
+	case KeyEnter, KeySpace:
+		if m.hoveredIndex >= 0 {
+			m.activate(m.hoveredIndex)
+			if m.openSubmenu != nil {
+				m.openSubmenu.moveHover(1)
+			}
+		}
+		return true
+	}
+
+	return m.TriggerAccelerator(event)
+}
+
+// TriggerAccelerator activates the enabled item whose accelerator matches the event.
+// Returns true if an item was triggered.
+func (m *PopupMenu) TriggerAccelerator(event InputEvent) bool {
+	for _, item := range m.items {
+		item.syncCommand()
+		if item.Disabled || item.Separator {
+			continue
+		}
+
+		if item.Submenu != nil {
+			if item.Submenu.TriggerAccelerator(event) {
+				return true
+			}
+			continue
+		}
+
+		if item.Accelerator == "" {
+			continue
+		}
+
+		acc, err := ParseAccelerator(item.Accelerator)
+		if err != nil || !acc.Matches(event) {
+			continue
+		}
+
+		if item.Checkable {
+			item.Checked = !item.Checked
+		}
+		if m.open {
+			m.rootMenu().Hide()
+		}
+		if item.OnSelect != nil {
+			item.OnSelect()
+		}
+		return true
+	}
+
+	return false
+}
+
+// moveHover moves the hovered item by delta, skipping separators and disabled items
+func (m *PopupMenu) moveHover(delta int) {
+	count := len(m.items)
+	if count == 0 {
+		return
+	}
+
+	index := m.hoveredIndex
+	if index < 0 && delta < 0 {
+		index = count
+	}
+	for i := 0; i < count; i++ {
+		index = (index + delta + count) % count
+		m.items[index].syncCommand()
+		if !m.items[index].Separator && !m.items[index].Disabled {
+			m.hoveredIndex = index
+			return
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package components
+
+import "testing"
+
+// testMenu returns a menu of a few items on an 800x600 screen, with its size
+func testMenu() (*PopupMenu, Rect) {
+	menu := NewPopupMenu("menu")
+	menu.SetScreenBounds(Rect{X: 0, Y: 0, Width: 800, Height: 600})
+	for _, label := range []string{"Cut", "Copy", "Paste"} {
+		menu.AddItem(label, nil)
+	}
+	menu.updateSize()
+	return menu, menu.Bounds()
+}
+
+func TestPopupMenuShowAt(t *testing.T) {
+	menu, size := testMenu()
+	tests := []struct {
+		name         string
+		x, y         int
+		wantX, wantY int
+	}{
+		{"fits", 100, 100, 100, 100},
+		{"flips left", 790, 100, 790 - size.Width, 100},
+		{"flips up", 100, 590, 100, 590 - size.Height},
+		{"flips both", 790, 590, 790 - size.Width, 590 - size.Height},
+		{"clamped", 900, 700, 800 - size.Width, 600 - size.Height},
+		{"kept on screen", -20, -20, 0, 0},
+	}
+	for _, tt := range tests {
+		menu.ShowAt(tt.x, tt.y)
+		if b := menu.Bounds(); b.X != tt.wantX || b.Y != tt.wantY {
+			t.Errorf("%s: ShowAt(%d, %d) put the menu at (%d, %d), want (%d, %d)",
+				tt.name, tt.x, tt.y, b.X, b.Y, tt.wantX, tt.wantY)
+		}
+	}
+	if !menu.IsOpen() {
+		t.Error("menu isn't open after ShowAt")
+	}
+}
+
+func TestPopupMenuShowBelow(t *testing.T) {
+	menu, size := testMenu()
+
+	anchor := Rect{X: 100, Y: 0, Width: 60, Height: 28}
+	menu.ShowBelow(anchor)
+	if b := menu.Bounds(); b.X != 100 || b.Y != 28 {
+		t.Errorf("menu at (%d, %d), want below the anchor at (100, 28)", b.X, b.Y)
+	}
+
+	// Near the bottom of the screen it opens above the anchor, not over it
+	anchor = Rect{X: 100, Y: 580, Width: 60, Height: 20}
+	menu.ShowBelow(anchor)
+	if b := menu.Bounds(); b.Y != 580-size.Height {
+		t.Errorf("menu top at %d, want above the anchor at %d", b.Y, 580-size.Height)
+	}
+
+	// Near the right edge it lines up with the anchor's right edge
+	anchor = Rect{X: 780, Y: 0, Width: 20, Height: 28}
+	menu.ShowBelow(anchor)
+	if b := menu.Bounds(); b.X != 800-size.Width {
+		t.Errorf("menu left at %d, want lined up with the anchor's right edge at %d", b.X, 800-size.Width)
+	}
+}
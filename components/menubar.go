@@ -0,0 +1,257 @@
+package components
+
+import (
+	"image/color"
+)
+
+// menuBarEntry is a top-level title in a menu bar and the menu it opens
+type menuBarEntry struct {
+	title string
+	menu  *PopupMenu
+}
+
+// MenuBar represents a desktop-style menu bar (File, Edit, ...) with dropdown menus
+type MenuBar struct {
+	*Node
+	entries         []*menuBarEntry
+	activeIndex     int
+	hoveredIndex    int
+	backgroundColor color.RGBA
+	activeColor     color.RGBA
+	textColor       color.RGBA
+	fontSize        int
+	keysElsewhere   bool // Accelerators are run by a ShortcutManager instead
+}
+
+// NewMenuBar creates a new menu bar
+func NewMenuBar(id string) *MenuBar {
+	return &MenuBar{
+		Node:            NewNode(id),
+		entries:         make([]*menuBarEntry, 0),
+		activeIndex:     -1,
+		hoveredIndex:    -1,
+		backgroundColor: color.RGBA{235, 235, 235, 255},
+		activeColor:     color.RGBA{200, 200, 255, 255},
+		textColor:       color.RGBA{0, 0, 0, 255},
+		fontSize:        14,
+	}
+}
+
+// AddMenu adds a top-level menu and returns it for populating
+func (b *MenuBar) AddMenu(title string) *PopupMenu {
+	menu := NewPopupMenu(b.ID() + "_" + title)
+	menu.SetOnDismiss(func() {
+		b.activeIndex = -1
+	})
+	b.entries = append(b.entries, &menuBarEntry{title: title, menu: menu})
+	return menu
+}
+
+// Menu returns the top-level menu with the given title, or nil
+func (b *MenuBar) Menu(title string) *PopupMenu {
+	for _, entry := range b.entries {
+		if entry.title == title {
+			return entry.menu
+		}
+	}
+	return nil
+}
+
+// SetTriggerAccelerators sets whether the bar runs items from their
+// accelerators while no menu is open. Turn it off when the items' commands
+// are bound to a ShortcutManager, which runs them only for keys no element
+// used; the accelerators are still shown.
+func (b *MenuBar) SetTriggerAccelerators(trigger bool) {
+	b.keysElsewhere = !trigger
+}
+
+// IsOpen returns whether any of the menus is open
+func (b *MenuBar) IsOpen() bool {
+	return b.activeIndex >= 0
+}
+
+// SetScreenBounds sets the area the dropdown menus must stay within
+func (b *MenuBar) SetScreenBounds(bounds Rect) {
+	for _, entry := range b.entries {
+		entry.menu.SetScreenBounds(bounds)
+	}
+}
+
+// titleRect returns the bounds of the title at the given index
+func (b *MenuBar) titleRect(index int) Rect {
+	bounds := b.ComputedBounds()
+	x := bounds.X + menuPadding
+
+	for i, entry := range b.entries {
+		width := len(entry.title)*b.fontSize/2 + menuPadding*4
+		if i == index {
+			return Rect{X: x, Y: bounds.Y, Width: width, Height: bounds.Height}
+		}
+		x += width
+	}
+
+	return Rect{}
+}
+
+// titleAt returns the index of the title at the given point, or -1
+func (b *MenuBar) titleAt(x, y int) int {
+	for i := range b.entries {
+		if PointInRect(Point{x, y}, b.titleRect(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// OpenMenu opens the menu at the given index, closing any other open menu
+func (b *MenuBar) OpenMenu(index int) {
+	if index < 0 || index >= len(b.entries) {
+		return
+	}
+
+	if b.activeIndex >= 0 && b.activeIndex != index {
+		b.entries[b.activeIndex].menu.Hide()
+	}
+
+	rect := b.titleRect(index)
+	b.entries[index].menu.ShowBelow(rect)
+	b.activeIndex = index
+}
+
+// CloseMenu closes the open menu, if any
+func (b *MenuBar) CloseMenu() {
+	if b.activeIndex >= 0 {
+		b.entries[b.activeIndex].menu.Hide()
+	}
+	b.activeIndex = -1
+}
+
+// activeMenu returns the open menu, or nil
+func (b *MenuBar) activeMenu() *PopupMenu {
+	if b.activeIndex < 0 {
+		return nil
+	}
+	return b.entries[b.activeIndex].menu
+}
+
+// Draw draws the menu bar and the open menu
+func (b *MenuBar) Draw(surface DrawSurface) {
+	if !b.IsVisible() {
+		return
+	}
+
+	bounds := b.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, b.backgroundColor)
+	surface.DrawLine(bounds.X, bounds.Y+bounds.Height-1, bounds.X+bounds.Width, bounds.Y+bounds.Height-1, color.RGBA{180, 180, 180, 255})
+
+	for i, entry := range b.entries {
+		rect := b.titleRect(i)
+		if i == b.activeIndex || (b.activeIndex < 0 && i == b.hoveredIndex) {
+			surface.FillRect(rect.X, rect.Y, rect.Width, rect.Height-1, b.activeColor)
+		}
+		textY := rect.Y + (rect.Height-b.fontSize)/2
+		surface.DrawText(entry.title, rect.X+menuPadding*2, textY, b.textColor, b.fontSize)
+	}
+
+	// The open menu is drawn last so it overlaps the content below the bar
+	if menu := b.activeMenu(); menu != nil {
+		menu.Draw(surface)
+	}
+}
+
+// HandleMouseDown opens or closes menus when titles are clicked
+func (b *MenuBar) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if index := b.titleAt(x, y); index >= 0 {
+		if index == b.activeIndex {
+			b.CloseMenu()
+		} else {
+			b.OpenMenu(index)
+		}
+		e.Consume()
+		return
+	}
+
+	if menu := b.activeMenu(); menu != nil {
+		// Clicks outside dismiss the open menu
+		menu.HandleMouseDown(e)
+		return
+	}
+
+	if PointInRect(Point{x, y}, b.ComputedBounds()) {
+		e.Consume()
+	}
+}
+
+// HandleMouseUp activates menu items under the cursor
+func (b *MenuBar) HandleMouseUp(e *Event) {
+	if menu := b.activeMenu(); menu != nil {
+		menu.HandleMouseUp(e)
+		if !menu.IsOpen() {
+			b.activeIndex = -1
+		}
+	}
+}
+
+// HandleMouseMove tracks hover and switches between open menus
+func (b *MenuBar) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	b.hoveredIndex = b.titleAt(x, y)
+
+	// While a menu is open, hovering another title switches to its menu
+	if b.activeIndex >= 0 && b.hoveredIndex >= 0 && b.hoveredIndex != b.activeIndex {
+		b.OpenMenu(b.hoveredIndex)
+		e.Consume()
+		return
+	}
+
+	if menu := b.activeMenu(); menu != nil {
+		if menu.HandleMouseMove(e); e.Consumed() {
+			return
+		}
+	}
+
+	if b.hoveredIndex >= 0 {
+		e.Consume()
+	}
+}
+
+// HandleKeyDown handles menu navigation and accelerators for all menus
+func (b *MenuBar) HandleKeyDown(event InputEvent) bool {
+	if menu := b.activeMenu(); menu != nil {
+		// Left/Right at the top level moves between menus
+		if menu.openSubmenu == nil {
+			switch event.Key {
+			case KeyLeft:
+				b.OpenMenu((b.activeIndex - 1 + len(b.entries)) % len(b.entries))
+				return true
+			case KeyRight:
+				hovered := menu.hoveredIndex
+				if hovered < 0 || menu.items[hovered].Submenu == nil {
+					b.OpenMenu((b.activeIndex + 1) % len(b.entries))
+					return true
+				}
+			}
+		}
+
+		if menu.HandleKeyDown(event) {
+			if !menu.IsOpen() {
+				b.activeIndex = -1
+			}
+			return true
+		}
+	}
+
+	// Accelerators work whether or not a menu is open
+	if b.keysElsewhere {
+		return false
+	}
+	for _, entry := range b.entries {
+		if entry.menu.TriggerAccelerator(event) {
+			b.activeIndex = -1
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,280 @@
+package components
+
+import (
+	"image/color"
+)
+
+// Modal is a dialog shown above the rest of the UI. While open it takes all
+// mouse and keyboard input, and when a focus manager is set it opens a focus
+// scope so focus returns to the opener when it closes.
+type Modal struct {
+	*Node
+	title          string
+	content        *FlexContainer
+	dialogWidth    int
+	dialogHeight   int
+	titleHeight    int
+	open           bool
+	dismissible    bool // Esc, the close button and backdrop clicks close the modal
+	focus          *FocusManager
+	scope          *FocusScope
+	onClose        func()
+	onSubmit       func() // Enter's default action, such as clicking OK
+	backdropColor  color.RGBA
+	dialogColor    color.RGBA
+	titleColor     color.RGBA
+	titleTextColor color.RGBA
+	borderColor    color.RGBA
+	closeHovered   bool
+}
+
+// NewModal creates a closed modal covering the screen
+func NewModal(id string, title string) *Modal {
+	m := &Modal{
+		Node:           NewNode(id),
+		title:          title,
+		content:        NewFlexContainer(id + "_content"),
+		dialogWidth:    480,
+		dialogHeight:   320,
+		titleHeight:    32,
+		dismissible:    true,
+		backdropColor:  color.RGBA{0, 0, 0, 120},
+		dialogColor:    color.RGBA{255, 255, 255, 255},
+		titleColor:     color.RGBA{235, 235, 235, 255},
+		titleTextColor: color.RGBA{0, 0, 0, 255},
+		borderColor:    color.RGBA{150, 150, 150, 255},
+	}
+	m.SetPositionType(PositionFixed)
+	m.Node.SetBounds(Rect{X: 0, Y: 0, Width: ScreenWidth, Height: ScreenHeight})
+	m.SetVisible(false)
+
+	m.content.SetPositionType(PositionFixed)
+	m.content.SetFlexDirection(FlexColumn)
+	m.AddChild(m.content)
+	m.layout()
+	return m
+}
+
+// SetTitle sets the title shown in the title bar
+func (m *Modal) SetTitle(title string) {
+	m.title = title
+}
+
+// Title returns the title
+func (m *Modal) Title() string {
+	return m.title
+}
+
+// Content returns the container for the dialog's elements; children should use
+// PositionFixed bounds inside ContentBounds
+func (m *Modal) Content() *FlexContainer {
+	return m.content
+}
+
+// SetBounds sets the area the backdrop covers, normally the whole screen
+func (m *Modal) SetBounds(bounds Rect) {
+	m.Node.SetBounds(bounds)
+	m.layout()
+}
+
+// SetDialogSize sets the size of the dialog box, which is centered in the modal
+func (m *Modal) SetDialogSize(width, height int) {
+	m.dialogWidth = width
+	m.dialogHeight = height
+	m.layout()
+}
+
+// SetDismissible sets whether Esc, the close button and backdrop clicks close the modal
+func (m *Modal) SetDismissible(dismissible bool) {
+	m.dismissible = dismissible
+}
+
+// SetFocusManager sets the focus manager used to scope and restore focus
+func (m *Modal) SetFocusManager(focus *FocusManager) {
+	m.focus = focus
+}
+
+// FocusScope returns the focus scope while the modal is open, or nil
+func (m *Modal) FocusScope() *FocusScope {
+	return m.scope
+}
+
+// SetOnClose sets the handler called when the modal closes
+func (m *Modal) SetOnClose(handler func()) {
+	m.onClose = handler
+}
+
+// SetOnSubmit sets the default action run when Enter is pressed and no
+// content element handles it, such as choosing OK
+func (m *Modal) SetOnSubmit(handler func()) {
+	m.onSubmit = handler
+}
+
+// DialogBounds returns the bounds of the dialog box
+func (m *Modal) DialogBounds() Rect {
+	bounds := m.ComputedBounds()
+	return Rect{
+		X:      bounds.X + (bounds.Width-m.dialogWidth)/2,
+		Y:      bounds.Y + (bounds.Height-m.dialogHeight)/2,
+		Width:  m.dialogWidth,
+		Height: m.dialogHeight,
+	}
+}
+
+// ContentBounds returns the area of the dialog below the title bar
+func (m *Modal) ContentBounds() Rect {
+	dialog := m.DialogBounds()
+	return Rect{
+		X:      dialog.X,
+		Y:      dialog.Y + m.titleHeight,
+		Width:  dialog.Width,
+		Height: dialog.Height - m.titleHeight,
+	}
+}
+
+// layout positions the content container inside the dialog
+func (m *Modal) layout() {
+	m.content.SetBounds(m.ContentBounds())
+}
+
+// closeRect returns the bounds of the close button in the title bar
+func (m *Modal) closeRect() Rect {
+	dialog := m.DialogBounds()
+	return Rect{X: dialog.X + dialog.Width - m.titleHeight, Y: dialog.Y, Width: m.titleHeight, Height: m.titleHeight}
+}
+
+// Open shows the modal
+func (m *Modal) Open() {
+	if m.open {
+		return
+	}
+	m.open = true
+	m.SetVisible(true)
+	m.layout()
+	if m.focus != nil {
+		m.scope = m.focus.PushScope(m.ID(), m)
+	}
+}
+
+// Close hides the modal and restores focus to the element that opened it
+func (m *Modal) Close() {
+	if !m.open {
+		return
+	}
+	m.open = false
+	m.SetVisible(false)
+	if m.focus != nil && m.scope != nil {
+		m.focus.PopScope(m.scope)
+		m.scope = nil
+	}
+	if m.onClose != nil {
+		m.onClose()
+	}
+}
+
+// IsOpen returns whether the modal is showing
+func (m *Modal) IsOpen() bool {
+	return m.open
+}
+
+// Draw draws the backdrop, the dialog box and its content
+func (m *Modal) Draw(surface DrawSurface) {
+	if !m.open {
+		return
+	}
+
+	bounds := m.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, m.backdropColor)
+
+	dialog := m.DialogBounds()
+	surface.FillRect(dialog.X+4, dialog.Y+4, dialog.Width, dialog.Height, color.RGBA{0, 0, 0, 60})
+	surface.FillRect(dialog.X, dialog.Y, dialog.Width, dialog.Height, m.dialogColor)
+	surface.FillRect(dialog.X, dialog.Y, dialog.Width, m.titleHeight, m.titleColor)
+	surface.DrawText(m.title, dialog.X+10, dialog.Y+(m.titleHeight-14)/2, m.titleTextColor, 14)
+
+	if m.dismissible {
+		c := m.closeRect()
+		if m.closeHovered {
+			surface.FillRect(c.X, c.Y, c.Width, c.Height, color.RGBA{220, 80, 80, 255})
+		}
+		cx, cy := c.X+c.Width/2, c.Y+c.Height/2
+		surface.DrawLine(cx-5, cy-5, cx+5, cy+5, m.titleTextColor)
+		surface.DrawLine(cx+5, cy-5, cx-5, cy+5, m.titleTextColor)
+	}
+	surface.DrawRect(dialog.X, dialog.Y, dialog.Width, dialog.Height, m.borderColor)
+
+	for _, child := range m.Children() {
+		child.Draw(surface)
+	}
+}
+
+// HandleMouseDown routes clicks to the dialog's content and blocks them from
+// reaching the UI underneath
+func (m *Modal) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !m.open {
+		return
+	}
+
+	p := Point{x, y}
+	if m.dismissible && PointInRect(p, m.closeRect()) {
+		m.Close()
+		e.Consume()
+		return
+	}
+
+	for i := len(m.Children()) - 1; i >= 0; i-- {
+		if m.Children()[i].HandleMouseDown(e); e.Consumed() {
+			return
+		}
+	}
+
+	if m.dismissible && !PointInRect(p, m.DialogBounds()) {
+		m.Close()
+	}
+	e.Consume()
+}
+
+// HandleMouseUp routes mouse up events to the dialog's content
+func (m *Modal) HandleMouseUp(e *Event) {
+	if !m.open {
+		return
+	}
+	for i := len(m.Children()) - 1; i >= 0; i-- {
+		m.Children()[i].HandleMouseUp(e.unconsumed())
+	}
+	e.Consume()
+}
+
+// HandleMouseMove routes mouse movement to the dialog's content
+func (m *Modal) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !m.open {
+		return
+	}
+	m.closeHovered = PointInRect(Point{x, y}, m.closeRect())
+	for i := len(m.Children()) - 1; i >= 0; i-- {
+		m.Children()[i].HandleMouseMove(e.unconsumed())
+	}
+	e.Consume()
+}
+
+// HandleScroll blocks wheel events from reaching the UI underneath
+func (m *Modal) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	return m.open
+}
+
+// HandleKeyDown closes the modal on Esc and keeps keys from reaching the UI
+// underneath. Content elements receive keys before the modal.
+func (m *Modal) HandleKeyDown(event InputEvent) bool {
+	if !m.open {
+		return false
+	}
+	if event.Type == InputTypeKeyDown && event.Key == KeyEscape && m.dismissible {
+		m.Close()
+	}
+	if event.Type == InputTypeKeyDown && event.Key == KeyEnter && m.onSubmit != nil {
+		m.onSubmit()
+	}
+	return true
+}
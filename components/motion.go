@@ -0,0 +1,110 @@
+package components
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MotionPreference chooses whether non-essential animations play
+type MotionPreference int
+
+const (
+	MotionAuto    MotionPreference = iota // Follow the OS setting
+	MotionReduced                         // Skip non-essential animations
+	MotionFull                            // Always animate
+)
+
+var (
+	motionMu         sync.Mutex
+	motionPreference = MotionAuto
+	systemReduced    bool
+	systemDetected   bool
+)
+
+// DetectReducedMotion asks the OS whether the user prefers less motion, or
+// returns false for the second value if it is unknown. It checks the Windows
+// window animation setting, the macOS Reduce motion option, or whether GNOME
+// animations are turned off.
+func DetectReducedMotion() (bool, bool) {
+	switch runtime.GOOS {
+	case "windows":
+		out, err := exec.Command("reg", "query",
+			`HKCU\Control Panel\Desktop\WindowMetrics`, "/v", "MinAnimate").Output()
+		if err != nil {
+			return false, false
+		}
+		fields := strings.Fields(string(out))
+		return len(fields) > 0 && fields[len(fields)-1] == "0", true
+	case "darwin":
+		out, err := exec.Command("defaults", "read", "com.apple.universalaccess", "reduceMotion").Output()
+		if err != nil {
+			return false, true
+		}
+		return strings.TrimSpace(string(out)) == "1", true
+	default:
+		out, err := exec.Command("gsettings", "get", "org.gnome.desktop.interface", "enable-animations").Output()
+		if err != nil {
+			return false, false
+		}
+		return strings.TrimSpace(string(out)) == "false", true
+	}
+}
+
+// SetMotionPreference sets whether animations follow the OS, are reduced or always play
+func SetMotionPreference(preference MotionPreference) {
+	motionMu.Lock()
+	defer motionMu.Unlock()
+	motionPreference = preference
+}
+
+// GetMotionPreference returns the current motion preference
+func GetMotionPreference() MotionPreference {
+	motionMu.Lock()
+	defer motionMu.Unlock()
+	return motionPreference
+}
+
+// RefreshReducedMotion detects the OS setting again; it is otherwise read once
+func RefreshReducedMotion() {
+	reduced, _ := DetectReducedMotion()
+	motionMu.Lock()
+	defer motionMu.Unlock()
+	systemReduced = reduced
+	systemDetected = true
+}
+
+// ReducedMotion returns whether non-essential animations should be skipped
+func ReducedMotion() bool {
+	motionMu.Lock()
+	preference := motionPreference
+	detected := systemDetected
+	motionMu.Unlock()
+
+	switch preference {
+	case MotionReduced:
+		return true
+	case MotionFull:
+		return false
+	}
+
+	if !detected {
+		RefreshReducedMotion()
+	}
+	motionMu.Lock()
+	defer motionMu.Unlock()
+	return systemReduced
+}
+
+// AnimationDuration returns how long a non-essential animation or transition
+// should take: the given duration, or zero when motion is reduced so the
+// change happens at once. Animations that carry meaning, such as a progress
+// indicator, should use their duration directly.
+func AnimationDuration(d time.Duration) time.Duration {
+	if ReducedMotion() {
+		return 0
+	}
+	return d
+}
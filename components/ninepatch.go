@@ -0,0 +1,158 @@
+package components
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// NinePatch is an image split into a 3x3 grid by insets from each edge.
+// When drawn at any size, the corners keep their size, the edges stretch
+// along one axis and the center stretches both ways, so button and panel
+// skins stay crisp. It is also an image.Image of the source, so it can be
+// passed anywhere an image can; DrawImage always draws it as a nine-patch.
+type NinePatch struct {
+	Image                    image.Image
+	Left, Top, Right, Bottom int // Size of the fixed borders in source pixels
+}
+
+// NewNinePatch creates a nine-patch with the given border insets
+func NewNinePatch(img image.Image, left, top, right, bottom int) *NinePatch {
+	return &NinePatch{Image: img, Left: left, Top: top, Right: right, Bottom: bottom}
+}
+
+// ParseNinePatch reads an Android-style .9.png: the image has a one pixel
+// frame in which opaque black runs along the top and left edges mark the
+// stretchable area. The frame is cropped off.
+func ParseNinePatch(img image.Image) *NinePatch {
+	b := img.Bounds()
+	inner := image.Rect(b.Min.X+1, b.Min.Y+1, b.Max.X-1, b.Max.Y-1)
+	if inner.Empty() {
+		return NewNinePatch(img, 0, 0, 0, 0)
+	}
+
+	// Find the first and last marked pixel along the top and left edges
+	isMark := func(c color.Color) bool {
+		r, g, bl, a := c.RGBA()
+		return a == 0xffff && r == 0 && g == 0 && bl == 0
+	}
+	left, right := -1, -1
+	for x := inner.Min.X; x < inner.Max.X; x++ {
+		if isMark(img.At(x, b.Min.Y)) {
+			if left < 0 {
+				left = x - inner.Min.X
+			}
+			right = inner.Max.X - x - 1
+		}
+	}
+	top, bottom := -1, -1
+	for y := inner.Min.Y; y < inner.Max.Y; y++ {
+		if isMark(img.At(b.Min.X, y)) {
+			if top < 0 {
+				top = y - inner.Min.Y
+			}
+			bottom = inner.Max.Y - y - 1
+		}
+	}
+	if left < 0 {
+		left, right = 0, 0
+	}
+	if top < 0 {
+		top, bottom = 0, 0
+	}
+	return NewNinePatch(cropImage(img, inner), left, top, right, bottom)
+}
+
+// ColorModel returns the source image's color model
+func (n *NinePatch) ColorModel() color.Model {
+	return n.Image.ColorModel()
+}
+
+// Bounds returns the source image's bounds
+func (n *NinePatch) Bounds() image.Rectangle {
+	return n.Image.Bounds()
+}
+
+// At returns a pixel of the source image
+func (n *NinePatch) At(x, y int) color.Color {
+	return n.Image.At(x, y)
+}
+
+// ContentInsets returns the border sizes, for padding content drawn on top
+func (n *NinePatch) ContentInsets() Spacing {
+	return Spacing{Top: n.Top, Right: n.Right, Bottom: n.Bottom, Left: n.Left}
+}
+
+// ninePatchSpans splits a source length and a destination length into three
+// spans each. If the destination is smaller than both borders, the borders
+// shrink in proportion and the middle disappears.
+func ninePatchSpans(source, start, end, dest int) ([3]int, [3]int) {
+	src := [3]int{start, source - start - end, end}
+	dst := [3]int{start, dest - start - end, end}
+	if dst[1] < 0 {
+		if start+end > 0 {
+			dst[0] = dest * start / (start + end)
+		}
+		dst[1] = 0
+		dst[2] = dest - dst[0]
+	}
+	return src, dst
+}
+
+// drawNinePatch draws the nine parts of a nine-patch into a box on the
+// target, which is already clipped by the caller
+func drawNinePatch(target *ebiten.Image, patch *NinePatch, x, y, width, height int) {
+	texture := textureFor(patch.Image)
+	tb := texture.Bounds()
+	srcW, dstW := ninePatchSpans(tb.Dx(), patch.Left, patch.Right, width)
+	srcH, dstH := ninePatchSpans(tb.Dy(), patch.Top, patch.Bottom, height)
+
+	sy, dy := tb.Min.Y, y
+	for row := 0; row < 3; row++ {
+		sx, dx := tb.Min.X, x
+		for col := 0; col < 3; col++ {
+			if srcW[col] > 0 && srcH[row] > 0 && dstW[col] > 0 && dstH[row] > 0 {
+				part := texture.SubImage(image.Rect(sx, sy, sx+srcW[col], sy+srcH[row])).(*ebiten.Image)
+				op := &ebiten.DrawImageOptions{}
+				op.GeoM.Scale(float64(dstW[col])/float64(srcW[col]), float64(dstH[row])/float64(srcH[row]))
+				op.GeoM.Translate(float64(dx), float64(dy))
+				// Corners drawn at their own size stay pixel exact
+				if srcW[col] != dstW[col] || srcH[row] != dstH[row] {
+					op.Filter = ebiten.FilterLinear
+				}
+				target.DrawImage(part, op)
+			}
+			sx += srcW[col]
+			dx += dstW[col]
+		}
+		sy += srcH[row]
+		dy += dstH[row]
+	}
+}
+
+// ButtonSkin is a set of nine-patch images for each button state. Missing
+// states fall back to Normal.
+type ButtonSkin struct {
+	Normal   *NinePatch
+	Hover    *NinePatch
+	Pressed  *NinePatch
+	Disabled *NinePatch
+}
+
+// patchFor returns the image for a button state
+func (s *ButtonSkin) patchFor(disabled, pressed, hovered bool) *NinePatch {
+	var patch *NinePatch
+	switch {
+	case disabled:
+		patch = s.Disabled
+	case pressed:
+		patch = s.Pressed
+	case hovered:
+		patch = s.Hover
+	}
+	if patch == nil {
+		patch = s.Normal
+	}
+	return patch
+}
@@ -15,6 +15,12 @@ type Node struct {
 	justifyContent  Alignment
 	classNames      []string
 	visible         bool
+	contextMenu     []*Command
+	listeners       []eventListener
+	onDoubleClick   func(*Event)
+	onContextClick  func(*Event)
+	cursorShape     CursorShape
+	flexGrow        int // Share of a flex container's free space; 0 keeps the element's size
 }
 
 // NewNode creates a new node
@@ -57,11 +63,48 @@ func (d *Node) GetRelativePosition() Point {
 	return d.relativePos
 }
 
+// SetPadding sets the space between the element's edges and its content,
+// keeping its margin and border
+func (d *Node) SetPadding(padding Spacing) {
+	d.boxModel.Padding = padding
+}
+
+// SetMargin sets the space around the element, keeping its padding and border
+func (d *Node) SetMargin(margin Spacing) {
+	d.boxModel.Margin = margin
+}
+
+// SetBorder sets the element's border, keeping its padding and margin
+func (d *Node) SetBorder(border Border) {
+	d.boxModel.Border = border
+}
+
 // SetRelativePosition sets the relative position
 func (d *Node) SetRelativePosition(pos Point) {
 	d.relativePos = pos
 }
 
+// SetFlexGrow sets the element's flex-grow weight. In a flex container,
+// elements with a weight share the space the others leave along the
+// container's direction, in proportion to their weights; 0 keeps the
+// element's own size.
+func (d *Node) SetFlexGrow(weight int) {
+	d.flexGrow = max(weight, 0)
+	
+	// Lay the container out again with the new shares
+	path := d.pathFromRoot()
+	if len(path) >= 2 {
+		if parent, ok := path[len(path)-2].(*FlexContainer); ok {
+			parent.updateLayout()
+		}
+	}
+}
+
+// FlexGrow returns the element's flex-grow weight
+func (d *Node) FlexGrow() int {
+	return d.flexGrow
+}
+
 // IsVisible returns whether the element is visible
 func (d *Node) IsVisible() bool {
 	return d.visible
@@ -162,6 +205,16 @@ func (d *Node) Draw(surface DrawSurface) {
 	bounds := d.ComputedBounds()
 	
 	// Draw borders if they exist
+	d.drawBorder(surface, bounds)
+	
+	// Draw all children
+	for _, child := range d.Children() {
+		child.Draw(surface)
+	}
+}
+
+// drawBorder draws the border, if any, inside the edges of bounds
+func (d *Node) drawBorder(surface DrawSurface, bounds Rect) {
 	if d.boxModel.Border.Style != BorderNone {
 		borderColor := d.boxModel.Border.Color
 		
@@ -205,11 +258,6 @@ func (d *Node) Draw(surface DrawSurface) {
 				borderColor)
 		}
 	}
-	
-	// Draw all children
-	for _, child := range d.Children() {
-		child.Draw(surface)
-	}
 }
 
 // QuerySelector finds the first element matching the selector
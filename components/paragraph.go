@@ -0,0 +1,631 @@
+package components
+
+import (
+	"image/color"
+	"strings"
+	"unicode"
+)
+
+// ParagraphStyle controls how a paragraph is wrapped and drawn
+type ParagraphStyle struct {
+	FontSize   int
+	Color      color.RGBA
+	Align      TextAlignment
+	LineHeight int    // Distance between baselines; 0 uses FontSize + 4
+	SpaceAfter int    // Gap below the paragraph
+	Hyphenate  bool   // Break long words at hyphenation points
+	Language   string // Language tag used to pick a hyphenator, e.g. "en"
+	Bold       bool
+	Marks      bool       // Draw **bold** and `code` spans, removing the markers
+	Indent     int        // Left inset, e.g. for list items and quotes
+	Background color.RGBA // Fill behind the paragraph, e.g. for code blocks
+}
+
+// DefaultParagraphStyle returns left-aligned body text
+func DefaultParagraphStyle() ParagraphStyle {
+	return ParagraphStyle{
+		FontSize:   14,
+		Color:      color.RGBA{0, 0, 0, 255},
+		Align:      TextAlignLeft,
+		SpaceAfter: 10,
+		Language:   "en",
+	}
+}
+
+// lineHeight returns the distance between lines
+func (s ParagraphStyle) lineHeight() int {
+	if s.LineHeight > 0 {
+		return ScaleTextSize(s.LineHeight)
+	}
+	return ScaleTextSize(s.FontSize) + 4
+}
+
+// HeadingStyle returns the style for a heading level from 1 (largest) to 6
+func HeadingStyle(level int) ParagraphStyle {
+	level = max(1, min(level, 6))
+	style := DefaultParagraphStyle()
+	style.FontSize = 26 - 2*level
+	style.SpaceAfter = 12 - level
+	style.Bold = true
+	return style
+}
+
+// PlacedWord is a word positioned on a line, relative to the line's start
+type PlacedWord struct {
+	Text  string
+	X     int
+	Width int
+	Link  string // Link target, e.g. "#install", or empty
+	Bold  bool   // Inside a **bold** span
+	Code  bool   // Inside a `code` span
+}
+
+// wordToken is a word of paragraph text with the link and spans it belongs to
+type wordToken struct {
+	text string
+	link string
+	bold bool
+	code bool
+}
+
+// tokenize splits a line into words, turning [label](target) links into
+// words that carry the target
+func tokenize(line string) []wordToken {
+	var tokens []wordToken
+	for line != "" {
+		open := strings.Index(line, "[")
+		if open < 0 {
+			break
+		}
+		mid := strings.Index(line[open:], "](")
+		if mid < 0 {
+			break
+		}
+		mid += open
+		end := strings.Index(line[mid:], ")")
+		if end < 0 {
+			break
+		}
+		end += mid
+
+		for _, word := range strings.Fields(line[:open]) {
+			tokens = append(tokens, wordToken{text: word})
+		}
+		target := line[mid+2 : end]
+		for _, word := range strings.Fields(line[open+1 : mid]) {
+			tokens = append(tokens, wordToken{text: word, link: target})
+		}
+		line = line[end+1:]
+
+		// Keep trailing punctuation on the link's last word so no gap appears
+		if n := strings.IndexFunc(line, unicode.IsSpace); n != 0 && len(tokens) > 0 {
+			if n < 0 {
+				n = len(line)
+			}
+			tokens[len(tokens)-1].text += line[:n]
+			line = line[n:]
+		}
+	}
+	for _, word := range strings.Fields(line) {
+		tokens = append(tokens, wordToken{text: word})
+	}
+	return tokens
+}
+
+// markSpans marks the words inside **bold** and `code` spans, which may
+// cover several words, and removes the markers
+func markSpans(tokens []wordToken) []wordToken {
+	bold, code := false, false
+	for i := range tokens {
+		t := &tokens[i]
+		if strings.HasPrefix(t.text, "**") {
+			t.text, bold = t.text[2:], true
+		}
+		if strings.HasPrefix(t.text, "`") {
+			t.text, code = t.text[1:], true
+		}
+		t.bold, t.code = bold, code
+		if end := strings.LastIndex(t.text, "**"); end >= 0 && bold {
+			t.text, bold = t.text[:end]+t.text[end+2:], false
+		}
+		if end := strings.LastIndex(t.text, "`"); end >= 0 && code {
+			t.text, code = t.text[:end]+t.text[end+1:], false
+		}
+	}
+	return tokens
+}
+
+// ParagraphLine is one wrapped line of a paragraph
+type ParagraphLine struct {
+	Words []PlacedWord
+	Y     int // Top of the line relative to the paragraph
+	Width int // Width of the line's content after alignment
+}
+
+// LayoutParagraph wraps text to the width and positions its words according
+// to the style. Newlines force a break; justified lines stretch their gaps,
+// except the last line and lines ending in a forced break. Links written as
+// [label](target) are drawn as their label and reported on PlacedWord.
+func LayoutParagraph(text string, width int, style ParagraphStyle) []ParagraphLine {
+	var hyphenator Hyphenator
+	if style.Hyphenate {
+		hyphenator = HyphenatorFor(style.Language)
+	}
+	space := MeasureText(" ")
+
+	var lines []ParagraphLine
+	y := 0
+	for _, hardLine := range strings.Split(text, "\n") {
+		words := tokenize(hardLine)
+		if style.Marks {
+			words = markSpans(words)
+		}
+		if len(words) == 0 {
+			lines = append(lines, ParagraphLine{Y: y})
+			y += style.lineHeight()
+			continue
+		}
+
+		var current []wordToken
+		lineWidth := 0
+		flush := func(last bool) {
+			lines = append(lines, placeLine(current, width, space, style.Align, last, y))
+			y += style.lineHeight()
+			current = nil
+			lineWidth = 0
+		}
+
+		for i := 0; i < len(words); i++ {
+			word := words[i]
+			plain := word
+			plain.text = stripSoftHyphens(word.text)
+			wordWidth := MeasureText(plain.text)
+			gap := 0
+			if len(current) > 0 {
+				gap = space
+			}
+			if lineWidth+gap+wordWidth <= width {
+				current = append(current, plain)
+				lineWidth += gap + wordWidth
+				continue
+			}
+
+			// Try to fit the start of the word with a hyphen
+			if head, tail, ok := splitHyphenated(word.text, width-lineWidth-gap, hyphenator); ok {
+				start := word
+				start.text = head
+				current = append(current, start)
+				flush(false)
+				words[i].text = tail
+				i--
+				continue
+			}
+
+			if len(current) == 0 {
+				// Nothing fits; let the word overflow
+				current = append(current, plain)
+				flush(false)
+				continue
+			}
+			flush(false)
+			i--
+		}
+		if len(current) > 0 {
+			flush(true)
+		}
+	}
+	return lines
+}
+
+// splitHyphenated returns the longest start of word that fits in room with a
+// trailing hyphen, and the rest of the word
+func splitHyphenated(word string, room int, hyphenator Hyphenator) (string, string, bool) {
+	points := hyphenationPoints(word, hyphenator)
+	if len(points) == 0 {
+		return "", "", false
+	}
+	runes := []rune(stripSoftHyphens(word))
+	for i := len(points) - 1; i >= 0; i-- {
+		p := points[i]
+		if p <= 0 || p >= len(runes) {
+			continue
+		}
+		head := string(runes[:p]) + "-"
+		if MeasureText(head) <= room {
+			// Keep the remaining soft hyphens as break points for the tail
+			return head, tailAfter(word, p), true
+		}
+	}
+	return "", "", false
+}
+
+// tailAfter returns word from the given rune offset, counting offsets without soft hyphens
+func tailAfter(word string, offset int) string {
+	i := 0
+	for pos, r := range word {
+		if r == softHyphen {
+			continue
+		}
+		if i == offset {
+			return word[pos:]
+		}
+		i++
+	}
+	return ""
+}
+
+// stripSoftHyphens removes soft hyphens, which are invisible unless a line breaks there
+func stripSoftHyphens(word string) string {
+	return strings.ReplaceAll(word, string(softHyphen), "")
+}
+
+// placeLine positions the words of a line for the alignment
+func placeLine(words []wordToken, width, space int, align TextAlignment, last bool, y int) ParagraphLine {
+	line := ParagraphLine{Y: y}
+	natural := 0
+	widths := make([]int, len(words))
+	for i, word := range words {
+		widths[i] = MeasureText(word.text)
+		natural += widths[i]
+	}
+	gaps := len(words) - 1
+	natural += gaps * space
+
+	x := 0
+	extra := width - natural
+	switch align {
+	case TextAlignCenter:
+		x = extra / 2
+	case TextAlignRight:
+		x = extra
+	}
+
+	justify := align == TextAlignJustify && !last && gaps > 0 && extra > 0
+	for i, word := range words {
+		line.Words = append(line.Words, PlacedWord{Text: word.text, X: x, Width: widths[i], Link: word.link, Bold: word.bold, Code: word.code})
+		x += widths[i] + space
+		if justify {
+			// Spread the extra space evenly, handing out the remainder from the left
+			x += extra / gaps
+			if i < extra%gaps {
+				x++
+			}
+		}
+	}
+	if len(line.Words) > 0 {
+		lastWord := line.Words[len(line.Words)-1]
+		line.Width = lastWord.X + lastWord.Width
+	}
+	return line
+}
+
+// Paragraph is a block of text with its own style. A paragraph with a
+// Heading level appears in the document outline; its Anchor defaults to a
+// slug of its text.
+type Paragraph struct {
+	Text    string
+	Style   ParagraphStyle
+	Heading int // 1-6 for headings, 0 for body text
+	Anchor  string
+}
+
+// OutlineEntry is a heading in a document outline, with the headings below
+// it nested as children. It implements TreeItem so an outline can be shown
+// in a TreeView as a table of contents.
+type OutlineEntry struct {
+	Title   string
+	Anchor  string
+	Level   int
+	Entries []*OutlineEntry
+}
+
+// Label returns the heading text
+func (e *OutlineEntry) Label() string {
+	return e.Title
+}
+
+// Items returns the nested headings
+func (e *OutlineEntry) Items() []TreeItem {
+	items := make([]TreeItem, len(e.Entries))
+	for i, entry := range e.Entries {
+		items[i] = entry
+	}
+	return items
+}
+
+// Slug turns heading text into an anchor id: lower case letters and digits
+// with hyphens between words
+func Slug(text string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(text) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+	return b.String()
+}
+
+// RichText draws a sequence of styled paragraphs, wrapped to its width and
+// scrolled with the mouse wheel
+type RichText struct {
+	*Node
+	paragraphs  []Paragraph
+	layouts     [][]ParagraphLine
+	offsets     []int // Top of each paragraph in the document
+	layoutWidth int
+	padding     int
+	scrollY     int
+	background  color.RGBA
+	linkColor   color.RGBA
+	codeColor   color.RGBA // Fill behind `code` spans
+	onLink      func(target string)
+}
+
+// NewRichText creates an empty rich text element
+func NewRichText(id string) *RichText {
+	return &RichText{
+		Node:        NewNode(id),
+		layoutWidth: -1,
+		padding:     4,
+		linkColor:   color.RGBA{30, 100, 200, 255},
+		codeColor:   color.RGBA{0, 0, 0, 20},
+	}
+}
+
+// AddParagraph appends a paragraph
+func (r *RichText) AddParagraph(text string, style ParagraphStyle) {
+	r.paragraphs = append(r.paragraphs, Paragraph{Text: text, Style: style})
+	r.layoutWidth = -1
+}
+
+// AddHeading appends a heading of the given level, with an anchor made from its text
+func (r *RichText) AddHeading(level int, text string) {
+	r.paragraphs = append(r.paragraphs, Paragraph{Text: text, Style: HeadingStyle(level), Heading: level})
+	r.layoutWidth = -1
+}
+
+// AddParagraphs appends several paragraphs, e.g. from ParseMarkdown
+func (r *RichText) AddParagraphs(paragraphs []Paragraph) {
+	r.paragraphs = append(r.paragraphs, paragraphs...)
+	r.layoutWidth = -1
+}
+
+// SetParagraphs replaces the paragraphs
+func (r *RichText) SetParagraphs(paragraphs []Paragraph) {
+	r.paragraphs = paragraphs
+	r.layoutWidth = -1
+	r.scrollY = 0
+}
+
+// Paragraphs returns the paragraphs
+func (r *RichText) Paragraphs() []Paragraph {
+	return r.paragraphs
+}
+
+// SetBackgroundColor sets the fill drawn behind the text
+func (r *RichText) SetBackgroundColor(color color.RGBA) {
+	r.background = color
+}
+
+// SetOnLinkClicked sets the handler for links that do not point into the
+// document; links starting with "#" scroll to the anchor instead
+func (r *RichText) SetOnLinkClicked(handler func(target string)) {
+	r.onLink = handler
+}
+
+// anchor returns the anchor of a paragraph, or "" for body text without one
+func (p Paragraph) anchor() string {
+	if p.Anchor != "" {
+		return p.Anchor
+	}
+	if p.Heading > 0 {
+		return Slug(p.Text)
+	}
+	return ""
+}
+
+// Outline returns the document's headings, nested by level
+func (r *RichText) Outline() []*OutlineEntry {
+	var roots []*OutlineEntry
+	var stack []*OutlineEntry
+	for _, p := range r.paragraphs {
+		if p.Heading <= 0 {
+			continue
+		}
+		entry := &OutlineEntry{Title: p.Text, Anchor: p.anchor(), Level: p.Heading}
+		for len(stack) > 0 && stack[len(stack)-1].Level >= entry.Level {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			roots = append(roots, entry)
+		} else {
+			parent := stack[len(stack)-1]
+			parent.Entries = append(parent.Entries, entry)
+		}
+		stack = append(stack, entry)
+	}
+	return roots
+}
+
+// layout wraps every paragraph to the current width, reusing the last
+// layout while the width and paragraphs are unchanged
+func (r *RichText) layout() [][]ParagraphLine {
+	width := r.Bounds().Width - 2*r.padding
+	if width == r.layoutWidth {
+		return r.layouts
+	}
+	r.layouts = r.layouts[:0]
+	r.offsets = r.offsets[:0]
+	y := r.padding
+	for _, p := range r.paragraphs {
+		lines := LayoutParagraph(p.Text, width-p.Style.Indent, p.Style)
+		r.layouts = append(r.layouts, lines)
+		r.offsets = append(r.offsets, y)
+		y += len(lines)*p.Style.lineHeight() + p.Style.SpaceAfter
+	}
+	r.layoutWidth = width
+	return r.layouts
+}
+
+// ContentHeight returns the height of all paragraphs at the current width
+func (r *RichText) ContentHeight() int {
+	height := 2 * r.padding
+	for i, lines := range r.layout() {
+		height += len(lines)*r.paragraphs[i].Style.lineHeight() + r.paragraphs[i].Style.SpaceAfter
+	}
+	return height
+}
+
+// ScrollTo scrolls so the given document offset is at the top, within limits
+func (r *RichText) ScrollTo(y int) {
+	r.scrollY = max(0, min(y, r.ContentHeight()-r.Bounds().Height))
+}
+
+// ScrollY returns the scroll offset
+func (r *RichText) ScrollY() int {
+	return r.scrollY
+}
+
+// ScrollOffset returns the scroll position for saving in a session
+func (r *RichText) ScrollOffset() Point {
+	return Point{X: 0, Y: r.scrollY}
+}
+
+// SetScrollOffset restores a saved scroll position
+func (r *RichText) SetScrollOffset(offset Point) {
+	r.ScrollTo(offset.Y)
+}
+
+// AnchorOffset returns the document offset of the paragraph with the anchor
+func (r *RichText) AnchorOffset(id string) (int, bool) {
+	r.layout()
+	for i, p := range r.paragraphs {
+		if p.anchor() == id {
+			return r.offsets[i], true
+		}
+	}
+	return 0, false
+}
+
+// ScrollToAnchor scrolls the paragraph with the anchor to the top and
+// returns false if there is no such anchor
+func (r *RichText) ScrollToAnchor(id string) bool {
+	y, ok := r.AnchorOffset(strings.TrimPrefix(id, "#"))
+	if ok {
+		r.ScrollTo(y - r.padding)
+	}
+	return ok
+}
+
+// CursorShapeAt returns the pointer hand over links
+func (r *RichText) CursorShapeAt(x, y int) CursorShape {
+	if _, ok := r.linkAt(x, y); ok {
+		return CursorPointer
+	}
+	return r.Node.CursorShapeAt(x, y)
+}
+
+// linkAt returns the link target of the word at a screen position
+func (r *RichText) linkAt(x, y int) (string, bool) {
+	bounds := r.ComputedBounds()
+	for i, lines := range r.layout() {
+		style := r.paragraphs[i].Style
+		top := bounds.Y + r.offsets[i] - r.scrollY
+		for _, line := range lines {
+			lineY := top + line.Y
+			if y < lineY || y >= lineY+style.lineHeight() {
+				continue
+			}
+			for _, word := range line.Words {
+				wordX := bounds.X + r.padding + style.Indent + word.X
+				if word.Link != "" && x >= wordX && x < wordX+word.Width {
+					return word.Link, true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// HandleMouseDown follows links: "#anchor" targets scroll the document and
+// others go to the link handler
+func (r *RichText) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !r.IsVisible() || !PointInRect(Point{x, y}, r.ComputedBounds()) {
+		return
+	}
+	target, ok := r.linkAt(x, y)
+	if !ok {
+		return
+	}
+	if strings.HasPrefix(target, "#") {
+		r.ScrollToAnchor(target)
+	} else if r.onLink != nil {
+		r.onLink(target)
+	}
+	e.Consume()
+}
+
+// HandleScroll scrolls the document with the mouse wheel
+func (r *RichText) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !r.IsVisible() || !PointInRect(Point{x, y}, r.ComputedBounds()) || r.ContentHeight() <= r.Bounds().Height {
+		return false
+	}
+	r.ScrollTo(r.scrollY - int(deltaY*20))
+	return true
+}
+
+// Draw draws the visible paragraphs
+func (r *RichText) Draw(surface DrawSurface) {
+	if !r.IsVisible() {
+		return
+	}
+
+	bounds := r.ComputedBounds()
+	if r.background.A > 0 {
+		surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, r.background)
+	}
+
+	surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
+	for i, lines := range r.layout() {
+		style := r.paragraphs[i].Style
+		top := bounds.Y + r.offsets[i] - r.scrollY
+		if style.Background.A > 0 {
+			left := bounds.X + r.padding + style.Indent
+			surface.FillRect(left-4, top-2, bounds.Width-2*r.padding-style.Indent+8, len(lines)*style.lineHeight()+4, style.Background)
+		}
+		for _, line := range lines {
+			lineY := top + line.Y
+			if lineY+style.lineHeight() < bounds.Y || lineY > bounds.Y+bounds.Height {
+				continue
+			}
+			for _, word := range line.Words {
+				wordX := bounds.X + r.padding + style.Indent + word.X
+				clr := style.Color
+				if word.Code {
+					surface.FillRect(wordX-2, lineY-1, word.Width+4, style.lineHeight(), r.codeColor)
+				}
+				if word.Link != "" {
+					clr = r.linkColor
+					surface.DrawLine(wordX, lineY+style.FontSize, wordX+word.Width, lineY+style.FontSize, clr)
+				}
+				surface.DrawText(word.Text, wordX, lineY, clr, style.FontSize)
+				if style.Bold || word.Bold {
+					surface.DrawText(word.Text, wordX+1, lineY, clr, style.FontSize)
+				}
+			}
+		}
+	}
+	surface.ResetClipRect()
+
+	for _, child := range r.Children() {
+		child.Draw(surface)
+	}
+}
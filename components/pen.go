@@ -0,0 +1,78 @@
+package components
+
+import "sync"
+
+// PointerType identifies the device that produced a pointer event
+type PointerType int
+
+const (
+	PointerMouse PointerType = iota
+	PointerPen
+	PointerTouch
+)
+
+// PenState is the pressure and tilt of a pointer. Devices that cannot report
+// them, such as a mouse, use full pressure and no tilt.
+type PenState struct {
+	Type     PointerType
+	Pressure float64 // 0 (no contact) to 1 (full pressure)
+	TiltX    float64 // Degrees, -90 to 90
+	TiltY    float64 // Degrees, -90 to 90
+}
+
+// DefaultPenState returns the state used when no pen is available
+func DefaultPenState() PenState {
+	return PenState{Type: PointerMouse, Pressure: 1}
+}
+
+// PenProvider reports the current pen state. It returns false when no pen is
+// in contact, in which case the default mouse state is used.
+type PenProvider func() (PenState, bool)
+
+var (
+	penMu       sync.RWMutex
+	penProvider PenProvider
+)
+
+// SetPenProvider installs a platform-specific source of pen pressure and tilt;
+// nil removes it. Ebiten does not expose pen data, so without a provider all
+// pointer input has constant full pressure.
+func SetPenProvider(provider PenProvider) {
+	penMu.Lock()
+	defer penMu.Unlock()
+	penProvider = provider
+}
+
+// CurrentPen returns the current pen state, falling back to the default mouse state
+func CurrentPen() PenState {
+	penMu.RLock()
+	provider := penProvider
+	penMu.RUnlock()
+
+	if provider != nil {
+		if state, ok := provider(); ok {
+			if state.Pressure < 0 {
+				state.Pressure = 0
+			}
+			if state.Pressure > 1 {
+				state.Pressure = 1
+			}
+			return state
+		}
+	}
+	return DefaultPenState()
+}
+
+// NewPointerEvent creates a pointer event at the given position carrying the current pen state
+func NewPointerEvent(eventType InputType, x, y int) InputEvent {
+	pen := CurrentPen()
+	return InputEvent{
+		Type:        eventType,
+		X:           x,
+		Y:           y,
+		PointerType: pen.Type,
+		Pressure:    pen.Pressure,
+		TiltX:       pen.TiltX,
+		TiltY:       pen.TiltY,
+	}
+}
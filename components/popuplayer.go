@@ -0,0 +1,132 @@
+package components
+
+import "slices"
+
+// Popup is a floating element shown on a PopupLayer, such as the open list
+// of a Select. Popups are positioned in screen coordinates.
+type Popup interface {
+	Element
+	// Dismiss closes the popup after a press outside it
+	Dismiss()
+}
+
+// PopupLayer floats popups above the rest of the page, so a drop-down list
+// isn't drawn under later siblings or clipped by its container. A press
+// outside every popup dismisses them all and is swallowed until the button
+// is released, so it doesn't also reach the element under the pointer.
+// Add it to the root above the page content; elements find it with
+// PopupLayerFor.
+type PopupLayer struct {
+	*Node
+	popups     []Element // Bottom to top
+	opening    bool      // The press that showed a popup is still held
+	dismissing bool      // A press outside the popups is being swallowed
+}
+
+// NewPopupLayer creates an empty popup layer covering the screen
+func NewPopupLayer(id string) *PopupLayer {
+	l := &PopupLayer{Node: NewNode(id)}
+	l.SetPositionType(PositionFixed)
+	l.SetBounds(Rect{X: 0, Y: 0, Width: ScreenWidth, Height: ScreenHeight})
+	return l
+}
+
+// PopupLayerFor returns the popup layer in the tree an element is part of,
+// found among the children of its root, or nil if there is none
+func PopupLayerFor(element Element) *PopupLayer {
+	root := element
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	for _, child := range root.Children() {
+		if layer, ok := child.(*PopupLayer); ok {
+			return layer
+		}
+	}
+	return nil
+}
+
+// Show adds a popup on top of those already shown. A press held while it
+// is shown, such as the one that opened it, doesn't dismiss it.
+func (l *PopupLayer) Show(popup Popup) {
+	if !l.IsShowing(popup) {
+		l.popups = append(l.popups, popup)
+		l.opening = true
+	}
+}
+
+// Close removes a popup without dismissing it
+func (l *PopupLayer) Close(popup Popup) {
+	l.popups = slices.DeleteFunc(l.popups, func(e Element) bool { return e == popup })
+}
+
+// IsShowing returns whether a popup is shown
+func (l *PopupLayer) IsShowing(popup Popup) bool {
+	return slices.Contains(l.popups, Element(popup))
+}
+
+// DismissAll dismisses and removes every popup, topmost first
+func (l *PopupLayer) DismissAll() {
+	popups := l.popups
+	l.popups = nil
+	for i := len(popups) - 1; i >= 0; i-- {
+		popups[i].(Popup).Dismiss()
+	}
+}
+
+// Children returns the popups shown, bottom to top
+func (l *PopupLayer) Children() []Element {
+	return l.popups
+}
+
+// Draw draws the popups, oldest first
+func (l *PopupLayer) Draw(surface DrawSurface) {
+	if !l.IsVisible() {
+		return
+	}
+	for _, child := range l.Children() {
+		child.Draw(surface)
+	}
+}
+
+// HandleMouseDown passes a press to the popup under it, or dismisses the
+// popups if it is outside them all
+func (l *PopupLayer) HandleMouseDown(e *Event) {
+	if l.dismissing {
+		e.Consume()
+		return
+	}
+	children := l.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if children[i].HandleMouseDown(e); e.Consumed() {
+			return
+		}
+	}
+	if len(children) == 0 || l.opening {
+		return
+	}
+	l.DismissAll()
+	l.dismissing = true
+	e.Consume()
+}
+
+// HandleMouseUp ends the current press and passes the release to the popups
+func (l *PopupLayer) HandleMouseUp(e *Event) {
+	l.opening, l.dismissing = false, false
+	children := l.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if children[i].HandleMouseUp(e); e.Consumed() {
+			return
+		}
+	}
+}
+
+// HandleMouseMove passes pointer movement to the popups
+func (l *PopupLayer) HandleMouseMove(e *Event) {
+	children := l.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if children[i].HandleMouseMove(e); e.Consumed() {
+			return
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"time"
+)
+
+// indeterminateCycle is how long the moving segment of an indeterminate
+// progress bar takes to cross the bar
+const indeterminateCycle = 1500 * time.Millisecond
+
+// ProgressBar shows how far along a task is, or that it's busy when how
+// far isn't known
+type ProgressBar struct {
+	*Node
+	value         float64 // 0 to 1
+	indeterminate bool
+	started       time.Time // When the indeterminate animation started
+	showPercent   bool
+	fontSize      int
+	trackColor    color.RGBA
+	fillColor     color.RGBA
+	textColor     color.RGBA
+}
+
+// NewProgressBar creates an empty progress bar
+func NewProgressBar(id string) *ProgressBar {
+	return &ProgressBar{
+		Node:       NewNode(id),
+		fontSize:   12,
+		trackColor: color.RGBA{225, 225, 225, 255},
+		fillColor:  color.RGBA{60, 120, 220, 255},
+		textColor:  color.RGBA{0, 0, 0, 255},
+	}
+}
+
+// SetValue sets how far along the task is, from 0 to 1
+func (p *ProgressBar) SetValue(value float64) {
+	p.value = math.Max(0, math.Min(value, 1))
+}
+
+// Value returns how far along the task is, from 0 to 1
+func (p *ProgressBar) Value() float64 {
+	return p.value
+}
+
+// SetIndeterminate sets whether the bar shows a moving segment instead of a
+// value, for tasks whose progress isn't known
+func (p *ProgressBar) SetIndeterminate(indeterminate bool) {
+	if indeterminate && !p.indeterminate {
+		p.started = Now()
+	}
+	p.indeterminate = indeterminate
+}
+
+// IsIndeterminate returns whether the bar shows a moving segment
+func (p *ProgressBar) IsIndeterminate() bool {
+	return p.indeterminate
+}
+
+// SetShowPercent sets whether the value is written on the bar as a percentage
+func (p *ProgressBar) SetShowPercent(show bool) {
+	p.showPercent = show
+}
+
+// ApplyTheme fills the bar with the accent color
+func (p *ProgressBar) ApplyTheme(theme Theme) {
+	p.fillColor = theme.Accent
+	p.trackColor = theme.Border
+	p.textColor = theme.Text
+}
+
+// Draw draws the track and the filled part, or the moving segment
+func (p *ProgressBar) Draw(surface DrawSurface) {
+	if !p.IsVisible() {
+		return
+	}
+	bounds := p.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, p.trackColor)
+
+	if p.indeterminate {
+		segment := bounds.Width / 3
+		if AnimationDuration(indeterminateCycle) == 0 {
+			// Without motion, a still segment in the middle shows it's busy
+			surface.FillRect(bounds.X+segment, bounds.Y, segment, bounds.Height, p.fillColor)
+			return
+		}
+		t := float64(since(p.started)%indeterminateCycle) / float64(indeterminateCycle)
+		left := bounds.X - segment + int(t*float64(bounds.Width+segment))
+		right := left + segment
+		left, right = max(left, bounds.X), min(right, bounds.X+bounds.Width)
+		if right > left {
+			surface.FillRect(left, bounds.Y, right-left, bounds.Height, p.fillColor)
+		}
+		return
+	}
+
+	surface.FillRect(bounds.X, bounds.Y, int(math.Round(p.value*float64(bounds.Width))), bounds.Height, p.fillColor)
+	if p.showPercent {
+		text := fmt.Sprintf("%d%%", int(math.Round(p.value*100)))
+		x := bounds.X + (bounds.Width-MeasureText(text))/2
+		y := bounds.Y + (bounds.Height-p.fontSize)/2
+		surface.DrawText(text, x, y, p.textColor, p.fontSize)
+	}
+}
@@ -0,0 +1,223 @@
+package components
+
+import "image/color"
+
+// RadioGroup is a column of options of which one can be selected
+type RadioGroup struct {
+	*Node
+	options     []string
+	selected    int // -1 for none
+	hovered     int // -1 for none
+	pressed     bool
+	focused     bool
+	fontSize    int
+	optionSize  int // Height of each option
+	onChange    func(int)
+	textColor   color.RGBA
+	borderColor color.RGBA
+	accentColor color.RGBA
+	hoverColor  color.RGBA
+}
+
+// NewRadioGroup creates a radio group with no option selected
+func NewRadioGroup(id string, options []string) *RadioGroup {
+	r := &RadioGroup{
+		Node:        NewNode(id),
+		options:     options,
+		selected:    -1,
+		hovered:     -1,
+		fontSize:    14,
+		optionSize:  24,
+		textColor:   color.RGBA{0, 0, 0, 255},
+		borderColor: color.RGBA{130, 130, 130, 255},
+		accentColor: color.RGBA{60, 120, 220, 255},
+		hoverColor:  color.RGBA{0, 0, 0, 15},
+	}
+	r.SetCursorShape(CursorPointer)
+	return r
+}
+
+// SetOptions replaces the options, clearing the selection
+func (r *RadioGroup) SetOptions(options []string) {
+	r.options = options
+	r.selected = -1
+	r.hovered = -1
+}
+
+// Options returns the options
+func (r *RadioGroup) Options() []string {
+	return r.options
+}
+
+// SetSelected selects the option at an index, or none for -1
+func (r *RadioGroup) SetSelected(index int) {
+	if index < -1 || index >= len(r.options) || index == r.selected {
+		return
+	}
+	r.selected = index
+	if r.onChange != nil {
+		r.onChange(index)
+	}
+}
+
+// Selected returns the index of the selected option, or -1
+func (r *RadioGroup) Selected() int {
+	return r.selected
+}
+
+// SelectedOption returns the selected option, or "" if none is selected
+func (r *RadioGroup) SelectedOption() string {
+	if r.selected < 0 {
+		return ""
+	}
+	return r.options[r.selected]
+}
+
+// SetOnChange sets the handler called with the index of a newly selected option
+func (r *RadioGroup) SetOnChange(handler func(int)) {
+	r.onChange = handler
+}
+
+// SetFontSize sets the size of the option text
+func (r *RadioGroup) SetFontSize(size int) {
+	r.fontSize = size
+}
+
+// ApplyTheme colors the selected dot with the accent
+func (r *RadioGroup) ApplyTheme(theme Theme) {
+	r.textColor = theme.Text
+	r.borderColor = theme.MutedText
+	r.accentColor = theme.Accent
+}
+
+// rowHeight returns the height of an option, grown for large text
+func (r *RadioGroup) rowHeight() int {
+	return max(r.optionSize, ScaleTextSize(r.fontSize)+6)
+}
+
+// PreferredHeight returns the height that fits every option
+func (r *RadioGroup) PreferredHeight() int {
+	return len(r.options) * r.rowHeight()
+}
+
+// optionAt returns the option at a point, or -1
+func (r *RadioGroup) optionAt(x, y int) int {
+	bounds := r.ComputedBounds()
+	if !PointInRect(Point{x, y}, bounds) {
+		return -1
+	}
+	index := (y - bounds.Y) / r.rowHeight()
+	if index >= len(r.options) {
+		return -1
+	}
+	return index
+}
+
+// Draw draws a circle and label per option, with a dot in the selected one
+func (r *RadioGroup) Draw(surface DrawSurface) {
+	if !r.IsVisible() {
+		return
+	}
+	bounds := r.ComputedBounds()
+	height := r.rowHeight()
+	radius := 7
+	for i, option := range r.options {
+		y := bounds.Y + i*height
+		if i == r.hovered {
+			surface.FillRect(bounds.X, y, bounds.Width, height, r.hoverColor)
+		}
+		cx, cy := bounds.X+radius+2, y+height/2
+		border := r.borderColor
+		if i == r.selected {
+			border = r.accentColor
+			surface.FillCircle(cx, cy, radius-3, r.accentColor)
+		}
+		surface.DrawCircle(cx, cy, radius, border)
+		surface.DrawText(option, cx+radius+8, y+(height-r.fontSize)/2, r.textColor, r.fontSize)
+	}
+	if r.focused {
+		surface.DrawRect(bounds.X, bounds.Y, bounds.Width, len(r.options)*height, r.accentColor)
+	}
+}
+
+// HandleMouseDown selects the option under the pointer
+func (r *RadioGroup) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !r.IsVisible() {
+		return
+	}
+	if r.pressed {
+		e.Consume()
+		return
+	}
+	index := r.optionAt(x, y)
+	if index < 0 {
+		r.focused = false
+		return
+	}
+	r.pressed = true
+	r.focused = true
+	r.SetSelected(index)
+	e.Consume()
+}
+
+// HandleMouseUp ends a press
+func (r *RadioGroup) HandleMouseUp(e *Event) {
+	if !r.pressed {
+		return
+	}
+	r.pressed = false
+	e.Consume()
+}
+
+// HandleMouseMove highlights the option under the pointer
+func (r *RadioGroup) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !r.IsVisible() {
+		return
+	}
+	r.hovered = r.optionAt(x, y)
+	if r.hovered >= 0 {
+		e.Consume()
+	}
+}
+
+// Focus gives the radio group keyboard focus
+func (r *RadioGroup) Focus() {
+	r.focused = true
+}
+
+// Blur removes keyboard focus from the radio group
+func (r *RadioGroup) Blur() {
+	r.focused = false
+}
+
+// IsFocused returns whether the radio group receives arrow keys
+func (r *RadioGroup) IsFocused() bool {
+	return r.focused
+}
+
+// HandleKeyDown moves the selection with the arrow keys, wrapping around,
+// and to the first and last options with Home and End
+func (r *RadioGroup) HandleKeyDown(event InputEvent) bool {
+	if !r.focused || event.Type != InputTypeKeyDown || len(r.options) == 0 {
+		return false
+	}
+	switch event.Key {
+	case KeyDown, KeyRight:
+		r.SetSelected((r.selected + 1) % len(r.options))
+	case KeyUp, KeyLeft:
+		if r.selected <= 0 {
+			r.SetSelected(len(r.options) - 1)
+		} else {
+			r.SetSelected(r.selected - 1)
+		}
+	case KeyHome:
+		r.SetSelected(0)
+	case KeyEnd:
+		r.SetSelected(len(r.options) - 1)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,281 @@
+package components
+
+import (
+	"image/color"
+	"math"
+)
+
+// Rating is a row of stars for picking a score, optionally in half steps
+type Rating struct {
+	*Node
+	value       float64
+	maxValue    int
+	halfSteps   bool
+	readOnly    bool
+	focused     bool
+	hover       float64 // Value previewed under the pointer, or -1
+	starSize    int
+	gap         int
+	onChange    func(float64)
+	filledColor color.RGBA
+	emptyColor  color.RGBA
+	hoverColor  color.RGBA
+	outline     color.RGBA
+}
+
+// NewRating creates a five-star rating with no value
+func NewRating(id string) *Rating {
+	r := &Rating{
+		Node:        NewNode(id),
+		maxValue:    5,
+		hover:       -1,
+		starSize:    24,
+		gap:         4,
+		filledColor: color.RGBA{250, 190, 30, 255},
+		emptyColor:  color.RGBA{225, 225, 225, 255},
+		hoverColor:  color.RGBA{255, 215, 110, 255},
+		outline:     color.RGBA{170, 130, 20, 255},
+	}
+	r.SetCursorShape(CursorPointer)
+	return r
+}
+
+// SetValue sets the score, rounded to the step size and clamped to 0..max
+func (r *Rating) SetValue(value float64) {
+	value = r.round(value)
+	if value == r.value {
+		return
+	}
+	r.value = value
+	if r.onChange != nil {
+		r.onChange(value)
+	}
+}
+
+// Value returns the score
+func (r *Rating) Value() float64 {
+	return r.value
+}
+
+// SetMax sets the number of stars
+func (r *Rating) SetMax(max int) {
+	if max < 1 {
+		max = 1
+	}
+	r.maxValue = max
+	r.value = r.round(r.value)
+}
+
+// Max returns the number of stars
+func (r *Rating) Max() int {
+	return r.maxValue
+}
+
+// SetHalfSteps sets whether half stars can be picked
+func (r *Rating) SetHalfSteps(half bool) {
+	r.halfSteps = half
+	r.value = r.round(r.value)
+}
+
+// SetReadOnly sets whether the rating only displays its value
+func (r *Rating) SetReadOnly(readOnly bool) {
+	r.readOnly = readOnly
+	r.hover = -1
+}
+
+// CursorShapeAt returns the pointer hand, or the arrow while read-only
+func (r *Rating) CursorShapeAt(x, y int) CursorShape {
+	if r.readOnly {
+		return CursorDefault
+	}
+	return r.Node.CursorShapeAt(x, y)
+}
+
+// SetStarSize sets the size of each star in pixels
+func (r *Rating) SetStarSize(size int) {
+	r.starSize = size
+}
+
+// SetOnChange sets the handler called when the score changes
+func (r *Rating) SetOnChange(handler func(float64)) {
+	r.onChange = handler
+}
+
+// round snaps a value to whole or half stars within range
+func (r *Rating) round(value float64) float64 {
+	if r.halfSteps {
+		value = math.Round(value*2) / 2
+	} else {
+		value = math.Round(value)
+	}
+	return math.Max(0, math.Min(value, float64(r.maxValue)))
+}
+
+// valueAt returns the score for a pointer position, or -1 outside the stars
+func (r *Rating) valueAt(x, y int) float64 {
+	bounds := r.ComputedBounds()
+	if y < bounds.Y || y >= bounds.Y+r.starSize || x < bounds.X {
+		return -1
+	}
+	step := r.starSize + r.gap
+	star := (x - bounds.X) / step
+	if star >= r.maxValue {
+		return -1
+	}
+	offset := (x - bounds.X) - star*step
+	if offset >= r.starSize {
+		return -1
+	}
+	if r.halfSteps && offset < r.starSize/2 {
+		return float64(star) + 0.5
+	}
+	return float64(star + 1)
+}
+
+// starPoints returns the outline of a five-pointed star centered at cx, cy
+func starPoints(cx, cy, radius float64) [][2]float64 {
+	points := make([][2]float64, 10)
+	inner := radius * 0.45
+	for i := range points {
+		r := radius
+		if i%2 == 1 {
+			r = inner
+		}
+		angle := -math.Pi/2 + float64(i)*math.Pi/5
+		points[i] = [2]float64{cx + r*math.Cos(angle), cy + r*math.Sin(angle)}
+	}
+	return points
+}
+
+// fillPolygon fills a polygon with horizontal lines, stopping at maxX
+func fillPolygon(surface DrawSurface, points [][2]float64, maxX float64, clr color.RGBA) {
+	minY, maxY := points[0][1], points[0][1]
+	for _, p := range points {
+		minY = math.Min(minY, p[1])
+		maxY = math.Max(maxY, p[1])
+	}
+
+	for y := math.Floor(minY) + 0.5; y <= maxY; y++ {
+		// Collect the edges crossing this scanline
+		var xs []float64
+		for i := range points {
+			a, b := points[i], points[(i+1)%len(points)]
+			if (a[1] <= y && b[1] > y) || (b[1] <= y && a[1] > y) {
+				xs = append(xs, a[0]+(y-a[1])/(b[1]-a[1])*(b[0]-a[0]))
+			}
+		}
+		for i := 0; i < len(xs); i++ {
+			for j := i + 1; j < len(xs); j++ {
+				if xs[j] < xs[i] {
+					xs[i], xs[j] = xs[j], xs[i]
+				}
+			}
+		}
+		for i := 0; i+1 < len(xs); i += 2 {
+			x1, x2 := xs[i], math.Min(xs[i+1], maxX)
+			if x2 > x1 {
+				surface.DrawLine(int(math.Round(x1)), int(y), int(math.Round(x2)), int(y), clr)
+			}
+		}
+	}
+}
+
+// Draw draws the stars, filled up to the hovered or current value
+func (r *Rating) Draw(surface DrawSurface) {
+	if !r.IsVisible() {
+		return
+	}
+
+	bounds := r.ComputedBounds()
+	shown, fill := r.value, r.filledColor
+	if r.hover >= 0 {
+		shown, fill = r.hover, r.hoverColor
+	}
+
+	radius := float64(r.starSize) / 2
+	for i := 0; i < r.maxValue; i++ {
+		left := float64(bounds.X + i*(r.starSize+r.gap))
+		points := starPoints(left+radius, float64(bounds.Y)+radius+1, radius)
+
+		fillPolygon(surface, points, left+float64(r.starSize), r.emptyColor)
+		if amount := shown - float64(i); amount > 0 {
+			fillPolygon(surface, points, left+float64(r.starSize)*math.Min(amount, 1), fill)
+		}
+		for j := range points {
+			a, b := points[j], points[(j+1)%len(points)]
+			surface.DrawLine(int(a[0]), int(a[1]), int(b[0]), int(b[1]), r.outline)
+		}
+	}
+
+	if r.focused {
+		width := r.maxValue*(r.starSize+r.gap) - r.gap
+		surface.DrawRect(bounds.X-2, bounds.Y-1, width+4, r.starSize+3, r.outline)
+	}
+}
+
+// HandleMouseDown sets the value to the star under the pointer
+func (r *Rating) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !r.IsVisible() || r.readOnly {
+		return
+	}
+	value := r.valueAt(x, y)
+	if value < 0 {
+		r.focused = false
+		return
+	}
+	r.focused = true
+	r.SetValue(value)
+	e.Consume()
+}
+
+// Focus gives the rating keyboard focus
+func (r *Rating) Focus() {
+	r.focused = true
+}
+
+// Blur removes keyboard focus from the rating
+func (r *Rating) Blur() {
+	r.focused = false
+}
+
+// IsFocused returns whether the rating receives arrow keys
+func (r *Rating) IsFocused() bool {
+	return r.focused
+}
+
+// HandleMouseMove previews the value under the pointer
+func (r *Rating) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !r.IsVisible() || r.readOnly {
+		return
+	}
+	r.hover = r.valueAt(x, y)
+	if r.hover >= 0 {
+		e.Consume()
+	}
+}
+
+// HandleKeyDown changes the value with the arrow keys, Home and End while focused
+func (r *Rating) HandleKeyDown(event InputEvent) bool {
+	if r.readOnly || !r.focused || event.Type != InputTypeKeyDown {
+		return false
+	}
+	step := 1.0
+	if r.halfSteps {
+		step = 0.5
+	}
+	switch event.Key {
+	case KeyRight, KeyUp:
+		r.SetValue(r.value + step)
+	case KeyLeft, KeyDown:
+		r.SetValue(r.value - step)
+	case KeyHome:
+		r.SetValue(0)
+	case KeyEnd:
+		r.SetValue(float64(r.maxValue))
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+package components
+
+import (
+	"image/color"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ComponentInfo describes a component for tools such as the documentation
+// gallery: how to create one with default props and how big to draw it
+type ComponentInfo struct {
+	Name        string
+	Description string
+	Width       int
+	Height      int
+	New         func(id string) Element
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]ComponentInfo)
+)
+
+// RegisterComponent adds a component to the registry, replacing any with the same name
+func RegisterComponent(info ComponentInfo) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[info.Name] = info
+}
+
+// LookupComponent returns the registered component with the name
+func LookupComponent(name string) (ComponentInfo, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	info, ok := registry[name]
+	return info, ok
+}
+
+// RegisteredComponents returns every registered component sorted by name
+func RegisteredComponents() []ComponentInfo {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	infos := make([]ComponentInfo, 0, len(registry))
+	for _, info := range registry {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// Built-in components
+func init() {
+	black := color.RGBA{0, 0, 0, 255}
+	builtins := []ComponentInfo{
+		{"Accordion", "Stack of collapsible sections where one is open at a time", 320, 120,
+			func(id string) Element {
+				a := NewAccordion(id)
+				a.AddSection("First")
+				a.AddSection("Second")
+				return a
+			}},
+		{"AttachmentList", "Removable file chips added from a dialog or by dropping files", 400, 80,
+			func(id string) Element {
+				l := NewAttachmentList(id)
+				l.AddAttachment(Attachment{Name: "report.pdf", Size: 248 << 10})
+				l.AddAttachment(Attachment{Name: "photo.jpg", Size: 3 << 20})
+				return l
+			}},
+		{"Button", "Clickable button with a text label", 120, 36,
+			func(id string) Element { return NewButton(id, "Button") }},
+		{"Canvas", "Area drawn by a callback in local coordinates", 320, 160,
+			func(id string) Element {
+				return NewCanvas(id, func(surface DrawSurface, width, height int) {
+					surface.DrawRect(0, 0, width-1, height-1, black)
+					surface.DrawLine(0, height-1, width-1, 0, black)
+				})
+			}},
+		{"Card", "Raised panel with optional header and footer", 320, 160,
+			func(id string) Element { return NewCard(id) }},
+		{"Carousel", "Pages through panels with arrows, indicators and swiping", 320, 160,
+			func(id string) Element {
+				c := NewCarousel(id)
+				c.AddSlide()
+				c.AddSlide()
+				c.AddSlide()
+				return c
+			}},
+		{"Checkbox", "Box that toggles between checked and unchecked", 24, 24,
+			func(id string) Element { return NewCheckbox(id) }},
+		{"CodeView", "Read-only source code with line numbers and syntax highlighting", 400, 160,
+			func(id string) Element {
+				v := NewCodeView(id, "package main\n\nfunc main() {\n\tfmt.Println(\"Hello\", 42) // Greet\n}")
+				v.SetLanguage("go")
+				return v
+			}},
+		{"ComboBox", "Text field with a filtered drop-down of suggestions", 240, 32,
+			func(id string) Element { return NewComboBox(id, []string{"Apple", "Banana", "Cherry"}) }},
+		{"DataGrid", "Sortable, pageable table of rows", 480, 200,
+			func(id string) Element { return NewDataGrid(id) }},
+		{"DatePicker", "Date field with a month calendar", 240, 32,
+			func(id string) Element { return NewDatePicker(id) }},
+		{"DrawingLayer", "Freehand, pressure-sensitive drawing area", 320, 200,
+			func(id string) Element { return NewDrawingLayer(id) }},
+		{"ErrorText", "Validation message slot under a form field", 240, 18,
+			func(id string) Element {
+				e := NewErrorText(id, "email")
+				e.SetMessage("Enter a valid email address")
+				return e
+			}},
+		{"ExpandableText", "Text cut to a few lines with a \"Show more\" toggle", 320, 80,
+			func(id string) Element {
+				return NewExpandableText(id, "Long descriptions are cut to a few lines with the last one faded out. "+
+					"The link below reveals the rest of the text and collapses it again.", 2)
+			}},
+		{"Expander", "Collapsible section with a header", 320, 120,
+			func(id string) Element { return NewExpander(id, "Details") }},
+		{"FlexContainer", "Row or column layout container", 320, 100,
+			func(id string) Element { return NewFlexContainer(id) }},
+		{"Icon", "Named line icon, or an image registered as an icon", 24, 24,
+			func(id string) Element { return NewIcon(id, "search") }},
+		{"Label", "Single line of text", 200, 20,
+			func(id string) Element { return NewLabel(id, "Label", 14, black) }},
+		{"ListView", "Column of items that can be dragged to reorder them", 240, 100,
+			func(id string) Element {
+				l := NewListView(id)
+				l.SetReorderable(true)
+				l.SetSpacing(4)
+				for _, text := range []string{"First", "Second", "Third"} {
+					label := NewLabel(NewID(id+"_item"), text, 14, black)
+					label.SetBounds(Rect{Width: 240, Height: 24})
+					l.AddItem(label)
+				}
+				return l
+			}},
+		{"MaskedInput", "Field that formats typed text with a pattern such as a phone number", 200, 32,
+			func(id string) Element { return NewMaskedInput(id, "(###) ###-####") }},
+		{"MultiSelect", "Drop-down list where several options can be checked", 240, 32,
+			func(id string) Element {
+				s := NewMultiSelect(id, []string{"Red", "Green", "Blue"})
+				s.SetSelectedIndices([]int{0, 2})
+				return s
+			}},
+		{"ProgressBar", "Bar showing how far along a task is", 240, 12,
+			func(id string) Element {
+				p := NewProgressBar(id)
+				p.SetValue(0.6)
+				return p
+			}},
+		{"RadioGroup", "Column of options of which one can be selected", 200, 72,
+			func(id string) Element {
+				r := NewRadioGroup(id, []string{"Small", "Medium", "Large"})
+				r.SetSelected(1)
+				return r
+			}},
+		{"Rating", "Row of stars for picking a score", 140, 26,
+			func(id string) Element {
+				r := NewRating(id)
+				r.SetValue(3)
+				return r
+			}},
+		{"RichText", "Wrapped, styled paragraphs with headings and links", 400, 160,
+			func(id string) Element {
+				r := NewRichText(id)
+				r.AddHeading(1, "Heading")
+				r.AddParagraph("Body text wraps to the width of the element.", DefaultParagraphStyle())
+				return r
+			}},
+		{"ScrollContainer", "Column of elements that scrolls when they don't fit", 240, 120,
+			func(id string) Element {
+				s := NewScrollContainer(id)
+				for _, text := range []string{"One", "Two", "Three", "Four", "Five", "Six", "Seven", "Eight"} {
+					label := NewLabel(NewID(id+"_item"), text, 14, black)
+					label.SetBounds(Rect{Width: 220, Height: 24})
+					s.AddChild(label)
+				}
+				return s
+			}},
+		{"Select", "Drop-down list of options", 200, 32,
+			func(id string) Element { return NewSelect(id, []string{"One", "Two", "Three"}) }},
+		{"Slider", "Thumb dragged along a track to pick a number", 240, 24,
+			func(id string) Element {
+				s := NewSlider(id, 0, 100)
+				s.SetValue(40)
+				return s
+			}},
+		{"TabControl", "Tabbed panels", 400, 200,
+			func(id string) Element { return NewTabControl(id) }},
+		{"Text", "Block of text", 200, 20,
+			func(id string) Element { return NewText(id, "Text", 14, black) }},
+		{"TextArea", "Multi-line text editor", 320, 120,
+			func(id string) Element { return NewTextArea(id) }},
+		{"TimeAgoLabel", "Time shown relative to now that refreshes itself", 160, 20,
+			func(id string) Element { return NewTimeAgoLabel(id, Now().Add(-5*time.Minute), 14, black) }},
+		{"TimePicker", "Spinner-style time of day field", 160, 32,
+			func(id string) Element { return NewTimePicker(id) }},
+		{"ToggleSwitch", "On/off switch with a label", 160, 28,
+			func(id string) Element {
+				t := NewToggleSwitch(id, "Enabled")
+				t.SetOn(true)
+				return t
+			}},
+		{"Toolbar", "Row of icon and toggle buttons that overflow into a menu", 320, 32,
+			func(id string) Element {
+				t := NewToolbar(id)
+				t.AddButton("New", nil, nil)
+				t.AddButton("Open", nil, nil)
+				t.AddSeparator()
+				t.AddToggle("Bold", nil, true, nil)
+				return t
+			}},
+		{"TreeView", "Hierarchy of items with expandable branches", 240, 160,
+			func(id string) Element { return NewTreeView(id) }},
+	}
+	for _, info := range builtins {
+		RegisterComponent(info)
+	}
+}
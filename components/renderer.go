@@ -6,7 +6,6 @@ import (
 	"math"
 
 	"github.com/hajimehoshi/ebiten/v2"
-	"github.com/hajimehoshi/ebiten/v2/text"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/basicfont"
@@ -35,7 +34,7 @@ func (r *EbitenRenderer) Clear(color color.RGBA) {
 
 // DrawText draws text at the specified position
 func (r *EbitenRenderer) DrawText(txt string, x, y int, clr color.RGBA, fontSize int) {
-	text.Draw(r.target, txt, r.font, x, y+13, clr) // +13 for font baseline
+	drawShapedText(r.target, txt, x, y, clr)
 }
 
 // DrawRect draws a rectangle with the specified position and dimensions
@@ -117,12 +116,13 @@ func (r *EbitenRenderer) ResetClipRect() {
 	r.clipRect = Rect{X: 0, Y: 0, Width: ScreenWidth, Height: ScreenHeight}
 }
 
-// DrawImage draws an image with the specified fit method
+// DrawImage draws an image with the specified fit method, clipped to its box
+// and the clip rect
 func (r *EbitenRenderer) DrawImage(img image.Image, x, y, width, height int, fitMethod ImageFitMethod) {
-    // Implementation needed for EbitenRenderer
-    // For now, just draw a placeholder
-    r.FillRect(x, y, width, height, color.RGBA{200, 200, 200, 255})
-    r.DrawRect(x, y, width, height, color.RGBA{150, 150, 150, 255})
+	if img == nil {
+		return
+	}
+	drawImageFitted(r.target, img, x, y, width, height, fitMethod, r.clipRect)
 }
 
 // EbitenDrawSurface implements DrawSurface using Ebiten
@@ -169,8 +169,7 @@ func (e *EbitenDrawSurface) DrawLine(x1, y1, x2, y2 int, color color.RGBA) {
 // DrawText draws text at the specified position
 func (e *EbitenDrawSurface) DrawText(txt string, x, y int, color color.RGBA, fontSize int) {
 	// In a real implementation, you'd use font caching and handle size changes
-	// For this demo, just use the basic font
-	text.Draw(e.target, txt, e.font, x, y+13, color) // +13 for font baseline
+	drawShapedText(e.target, txt, x, y, color)
 }
 
 // FillCircle fills a circle with the specified center, radius, and color
@@ -217,63 +216,5 @@ func (e *EbitenDrawSurface) DrawImage(img image.Image, x, y, width, height int,
 		e.DrawRect(x, y, width, height, color.RGBA{150, 150, 150, 255})
 		return
 	}
-	
-	// Convert to Ebiten image if needed
-	var eImg *ebiten.Image
-	if ebi, ok := img.(*ebiten.Image); ok {
-		eImg = ebi
-	} else {
-		// In a real app, you'd convert the image.Image to an ebiten.Image here
-		// For now, just draw a placeholder
-		e.FillRect(x, y, width, height, color.RGBA{200, 200, 200, 255})
-		e.DrawRect(x, y, width, height, color.RGBA{150, 150, 150, 255})
-		return
-	}
-	
-	// Get image dimensions
-	imgWidth, imgHeight := eImg.Size()
-	
-	// Calculate scaling based on fit method
-	var scale float64
-	var offsetX, offsetY int
-	
-	switch fitMethod {
-	case ImageFitContain:
-		// Scale to fit within bounds while maintaining aspect ratio
-		scaleX := float64(width) / float64(imgWidth)
-		scaleY := float64(height) / float64(imgHeight)
-		scale = math.Min(scaleX, scaleY)
-		
-		// Center the image
-		offsetX = x + (width-int(float64(imgWidth)*scale))/2
-		offsetY = y + (height-int(float64(imgHeight)*scale))/2
-		
-	case ImageFitCover:
-		// Scale to cover entire bounds while maintaining aspect ratio
-		scaleX := float64(width) / float64(imgWidth)
-		scaleY := float64(height) / float64(imgHeight)
-		scale = math.Max(scaleX, scaleY)
-		
-		// Center the image
-		offsetX = x + (width-int(float64(imgWidth)*scale))/2
-		offsetY = y + (height-int(float64(imgHeight)*scale))/2
-		
-	case ImageFitFill:
-		// Scale to fill bounds, potentially distorting the image
-		scaleX := float64(width) / float64(imgWidth)
-		scaleY := float64(height) / float64(imgHeight)
-		
-		// Draw the image with different scales for X and Y
-		op := &ebiten.DrawImageOptions{}
-		op.GeoM.Scale(scaleX, scaleY)
-		op.GeoM.Translate(float64(x), float64(y))
-		e.target.DrawImage(eImg, op)
-		return // Early return because we handled this case differently
-	}
-	
-	// Draw the image
-	op := &ebiten.DrawImageOptions{}
-	op.GeoM.Scale(scale, scale)
-	op.GeoM.Translate(float64(offsetX), float64(offsetY))
-	e.target.DrawImage(eImg, op)
-} 
\ No newline at end of file
+	drawImageFitted(e.target, img, x, y, width, height, fitMethod, Rect{X: x, Y: y, Width: width, Height: height})
+}
\ No newline at end of file
@@ -0,0 +1,327 @@
+package components
+
+import "image/color"
+
+// Scroller is implemented by elements that scroll their children within a
+// viewport. Only the part of a child inside the viewport can be clicked.
+type Scroller interface {
+	Element
+	// ViewportRect returns the area on screen the children are shown in
+	ViewportRect() Rect
+	// ScrollRectIntoView scrolls the least distance that brings a rect on
+	// screen into the viewport, or its top if it's taller than the viewport
+	ScrollRectIntoView(rect Rect)
+}
+
+// scrollStep is how far one notch of the mouse wheel scrolls
+const scrollStep = 40
+
+// ScrollContainer stacks its children in a column and scrolls them with the
+// mouse wheel when they're taller than the container, and with the
+// navigation keys once it's focused by a press inside it
+type ScrollContainer struct {
+	*Node
+	spacing         int
+	scrollY         int // Pixels scrolled down
+	focused         bool
+	contentHeight   int
+	backgroundColor color.RGBA
+	scrollbarColor  color.RGBA
+	onScroll        func(y int)
+}
+
+// NewScrollContainer creates an empty scroll container
+func NewScrollContainer(id string) *ScrollContainer {
+	return &ScrollContainer{
+		Node:           NewNode(id),
+		spacing:        5,
+		scrollbarColor: color.RGBA{0, 0, 0, 70},
+	}
+}
+
+// AddChild adds a child below the others
+func (s *ScrollContainer) AddChild(child Element) {
+	s.Node.AddChild(child)
+	s.layout()
+}
+
+// RemoveChild removes a child, keeping the scroll position in range
+func (s *ScrollContainer) RemoveChild(child Element) {
+	s.Node.RemoveChild(child)
+	s.layout()
+}
+
+// SetSpacing sets the gap between children
+func (s *ScrollContainer) SetSpacing(spacing int) {
+	s.spacing = spacing
+	s.layout()
+}
+
+// SetBackgroundColor sets the background color
+func (s *ScrollContainer) SetBackgroundColor(backgroundColor color.RGBA) {
+	s.backgroundColor = backgroundColor
+}
+
+// SetBounds sets the bounds and restacks the children
+func (s *ScrollContainer) SetBounds(bounds Rect) {
+	s.Node.SetBounds(bounds)
+	s.layout()
+}
+
+// ScrollY returns how far the content is scrolled down
+func (s *ScrollContainer) ScrollY() int {
+	return s.scrollY
+}
+
+// ScrollTo scrolls so the content is y pixels down, kept within range
+func (s *ScrollContainer) ScrollTo(y int) {
+	old := s.scrollY
+	s.scrollY = y
+	s.layout()
+	if s.scrollY != old && s.onScroll != nil {
+		s.onScroll(s.scrollY)
+	}
+}
+
+// SetOnScroll sets the handler called with the new position when the
+// content is scrolled
+func (s *ScrollContainer) SetOnScroll(handler func(y int)) {
+	s.onScroll = handler
+}
+
+// MaxScroll returns how far the content can scroll
+func (s *ScrollContainer) MaxScroll() int {
+	return max(0, s.contentHeight-s.Bounds().Height)
+}
+
+// ContentHeight returns the height of the stacked children
+func (s *ScrollContainer) ContentHeight() int {
+	return s.contentHeight
+}
+
+// ScrollOffset returns the scroll position, for saving with the session
+func (s *ScrollContainer) ScrollOffset() Point {
+	return Point{X: 0, Y: s.scrollY}
+}
+
+// SetScrollOffset restores a saved scroll position
+func (s *ScrollContainer) SetScrollOffset(offset Point) {
+	s.ScrollTo(offset.Y)
+}
+
+// ViewportRect returns the container's bounds on screen
+func (s *ScrollContainer) ViewportRect() Rect {
+	return s.ComputedBounds()
+}
+
+// ScrollRectIntoView scrolls the least distance that shows a rect on screen
+func (s *ScrollContainer) ScrollRectIntoView(rect Rect) {
+	viewport := s.ViewportRect()
+	switch {
+	case rect.Y < viewport.Y || rect.Height > viewport.Height:
+		s.ScrollTo(s.scrollY - (viewport.Y - rect.Y))
+	case rect.Y+rect.Height > viewport.Y+viewport.Height:
+		s.ScrollTo(s.scrollY + rect.Y + rect.Height - viewport.Y - viewport.Height)
+	}
+}
+
+// layout clamps the scroll position and stacks the children, shifted up by
+// it, at their own sizes
+func (s *ScrollContainer) layout() {
+	bounds := s.Bounds()
+	s.contentHeight = 0
+	for _, child := range s.Children() {
+		if !outOfFlow(child) {
+			s.contentHeight += child.Bounds().Height + s.spacing
+		}
+	}
+	s.contentHeight = max(0, s.contentHeight-s.spacing)
+	s.scrollY = max(0, min(s.scrollY, s.MaxScroll()))
+
+	y := -s.scrollY
+	for _, child := range s.Children() {
+		if outOfFlow(child) {
+			continue
+		}
+		childBounds := child.Bounds()
+		child.SetBounds(Rect{X: bounds.X, Y: bounds.Y + y, Width: childBounds.Width, Height: childBounds.Height})
+		if node, ok := child.(NodeElement); ok {
+			node.SetRelativePosition(Point{X: 0, Y: y})
+		}
+		y += childBounds.Height + s.spacing
+	}
+}
+
+// Draw draws the children clipped to the container, with a scroll
+// indicator when they don't all fit
+func (s *ScrollContainer) Draw(surface DrawSurface) {
+	if !s.IsVisible() {
+		return
+	}
+	bounds := s.ComputedBounds()
+	if s.backgroundColor.A > 0 {
+		surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, s.backgroundColor)
+	}
+
+	s.layout()
+	surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
+	for _, child := range s.Children() {
+		child.Draw(surface)
+	}
+	if maxScroll := s.MaxScroll(); maxScroll > 0 {
+		length := max(16, bounds.Height*bounds.Height/s.contentHeight)
+		offset := s.scrollY * (bounds.Height - length) / maxScroll
+		surface.FillRect(bounds.X+bounds.Width-6, bounds.Y+offset, 3, length, s.scrollbarColor)
+	}
+	surface.ResetClipRect()
+}
+
+// HandleScroll scrolls the content with the mouse wheel
+func (s *ScrollContainer) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !s.IsVisible() || !PointInRect(Point{x, y}, s.ComputedBounds()) {
+		return false
+	}
+	old := s.scrollY
+	s.ScrollTo(s.scrollY - int(deltaY*scrollStep))
+	return s.scrollY != old
+}
+
+// Focus gives the container the navigation keys
+func (s *ScrollContainer) Focus() {
+	s.focused = true
+}
+
+// Blur takes the navigation keys away
+func (s *ScrollContainer) Blur() {
+	s.focused = false
+}
+
+// IsFocused returns whether the container scrolls with the navigation keys
+func (s *ScrollContainer) IsFocused() bool {
+	return s.focused
+}
+
+// HandleKeyDown scrolls while the container is focused: a step with
+// Up/Down, a page with PageUp/PageDown, and to the top or bottom with
+// Home/End or Ctrl+Up/Down. Returns whether it scrolled, so a container
+// already at the end leaves the key to one around it.
+func (s *ScrollContainer) HandleKeyDown(event InputEvent) bool {
+	if !s.focused || !s.IsVisible() || event.Type != InputTypeKeyDown {
+		return false
+	}
+
+	// A page is the height shown less a step, so a strip stays in view
+	page := max(scrollStep, s.Bounds().Height-scrollStep)
+	y := s.scrollY
+	switch {
+	case event.Key == KeyHome, event.CtrlDown && event.Key == KeyUp:
+		y = 0
+	case event.Key == KeyEnd, event.CtrlDown && event.Key == KeyDown:
+		y = s.MaxScroll()
+	case event.Key == KeyUp:
+		y -= scrollStep
+	case event.Key == KeyDown:
+		y += scrollStep
+	case event.Key == KeyPageUp:
+		y -= page
+	case event.Key == KeyPageDown:
+		y += page
+	default:
+		return false
+	}
+
+	old := s.scrollY
+	s.ScrollTo(y)
+	return s.scrollY != old
+}
+
+// HandleMouseDown passes presses inside the container to the children. The
+// container takes focus unless an element inside it did.
+func (s *ScrollContainer) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !s.IsVisible() || !PointInRect(Point{x, y}, s.ComputedBounds()) {
+		s.focused = false
+		return
+	}
+	children := s.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if children[i].HandleMouseDown(e); e.Consumed() {
+			break
+		}
+	}
+	s.focused = !focusWithin(s)
+}
+
+// HandleMouseUp passes releases to the children
+func (s *ScrollContainer) HandleMouseUp(e *Event) {
+	if !s.IsVisible() {
+		return
+	}
+	children := s.Children()
+	for i := len(children) - 1; i >= 0; i-- {
+		if children[i].HandleMouseUp(e); e.Consumed() {
+			return
+		}
+	}
+}
+
+// HandleMouseMove passes moves inside the container to the children;
+// outside it they see the pointer as gone, clearing hover states
+func (s *ScrollContainer) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !s.IsVisible() {
+		return
+	}
+	if !PointInRect(Point{x, y}, s.ComputedBounds()) {
+		x, y = -1, -1
+	}
+	for _, child := range s.Children() {
+		moved := e.at(x, y)
+		if child.HandleMouseMove(moved); moved.Consumed() {
+			e.Consume()
+		}
+	}
+}
+
+// asNode returns the node itself, so an element can be matched to the
+// node embedded in it
+func (d *Node) asNode() *Node {
+	return d
+}
+
+// ScrollIntoView scrolls every scroller the element is inside, innermost
+// first, so that the element is shown
+func (d *Node) ScrollIntoView() {
+	path := d.pathFromRoot()
+	for i := len(path) - 2; i >= 0; i-- {
+		if scroller, ok := path[i].(Scroller); ok {
+			scroller.ScrollRectIntoView(d.ComputedBounds())
+		}
+	}
+}
+
+// pathFromRoot returns the elements from the top of the element's tree
+// down to the element itself, found through the children of each
+func (d *Node) pathFromRoot() []Element {
+	var root Element = d.BaseElement
+	for root.Parent() != nil {
+		root = root.Parent()
+	}
+	var path []Element
+	var walk func(element Element) bool
+	walk = func(element Element) bool {
+		path = append(path, element)
+		if n, ok := element.(interface{ asNode() *Node }); ok && n.asNode() == d {
+			return true
+		}
+		for _, child := range element.Children() {
+			if walk(child) {
+				return true
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	walk(root)
+	return path
+}
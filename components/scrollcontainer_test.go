@@ -0,0 +1,90 @@
+package components
+
+import "testing"
+
+// testScrollContainer returns a focused 200 pixel high container holding
+// ten 100 pixel high children, so it can scroll 800 pixels
+func testScrollContainer() *ScrollContainer {
+	s := NewScrollContainer("scroll")
+	s.SetSpacing(0)
+	s.SetBounds(Rect{X: 0, Y: 0, Width: 300, Height: 200})
+	for i := 0; i < 10; i++ {
+		child := NewFlexContainer(NewID("child"))
+		child.SetBounds(Rect{X: 0, Y: 0, Width: 300, Height: 100})
+		s.AddChild(child)
+	}
+	s.HandleMouseDown(NewMouseEvent(InputTypeMouseDown, 10, 10))
+	return s
+}
+
+func TestScrollContainerKeys(t *testing.T) {
+	page := 200 - scrollStep
+	tests := []struct {
+		name  string
+		start int
+		key   Key
+		ctrl  bool
+		want  int
+	}{
+		{"down", 0, KeyDown, false, scrollStep},
+		{"up", 100, KeyUp, false, 100 - scrollStep},
+		{"page down", 0, KeyPageDown, false, page},
+		{"page up", 500, KeyPageUp, false, 500 - page},
+		{"page down at the end", 700, KeyPageDown, false, 800},
+		{"page up at the top", 50, KeyPageUp, false, 0},
+		{"home", 500, KeyHome, false, 0},
+		{"end", 0, KeyEnd, false, 800},
+		{"ctrl+up", 500, KeyUp, true, 0},
+		{"ctrl+down", 0, KeyDown, true, 800},
+	}
+	for _, tt := range tests {
+		s := testScrollContainer()
+		s.ScrollTo(tt.start)
+		handled := s.HandleKeyDown(InputEvent{Type: InputTypeKeyDown, Key: tt.key, CtrlDown: tt.ctrl})
+		if !handled || s.ScrollY() != tt.want {
+			t.Errorf("%s from %d: scrolled to %d (handled %v), want %d", tt.name, tt.start, s.ScrollY(), handled, tt.want)
+		}
+	}
+}
+
+func TestScrollContainerKeysNeedFocus(t *testing.T) {
+	s := testScrollContainer()
+	if !s.IsFocused() {
+		t.Fatal("press inside didn't focus the container")
+	}
+
+	s.HandleMouseDown(NewMouseEvent(InputTypeMouseDown, 500, 500))
+	if s.IsFocused() {
+		t.Fatal("press outside didn't blur the container")
+	}
+	if s.HandleKeyDown(InputEvent{Type: InputTypeKeyDown, Key: KeyPageDown}) || s.ScrollY() != 0 {
+		t.Error("unfocused container scrolled")
+	}
+}
+
+func TestScrollContainerLeavesKeysAtEnd(t *testing.T) {
+	s := testScrollContainer()
+	if s.HandleKeyDown(InputEvent{Type: InputTypeKeyDown, Key: KeyHome}) {
+		t.Error("Home at the top was handled")
+	}
+	if s.HandleKeyDown(InputEvent{Type: InputTypeKeyDown, Key: KeyA}) {
+		t.Error("a letter was handled")
+	}
+}
+
+func TestScrollContainerChildTakesFocus(t *testing.T) {
+	s := NewScrollContainer("scroll")
+	s.SetBounds(Rect{X: 0, Y: 0, Width: 300, Height: 200})
+	field := NewTextArea("field")
+	field.SetBounds(Rect{X: 0, Y: 0, Width: 300, Height: 40})
+	s.AddChild(field)
+
+	s.HandleMouseDown(NewMouseEvent(InputTypeMouseDown, 10, 10))
+
+	if !field.IsFocused() {
+		t.Fatal("press on the field didn't focus it")
+	}
+	if s.IsFocused() {
+		t.Error("container took focus from the field inside it")
+	}
+}
@@ -0,0 +1,603 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+)
+
+// Select represents a dropdown select box. The open list floats on the
+// tree's PopupLayer, if it has one, and otherwise is drawn below the box.
+// In multiple mode each option has a check box, picking one toggles it
+// without closing the list, and the closed box shows the chosen options
+// as chips.
+type Select struct {
+	*Node
+	options         []string
+	selectedIndex   int
+	onChange        func(int)
+	multiple        bool
+	selected        []int // Chosen options in multiple mode, in option order
+	onSelection     func([]int)
+	isOpen          bool
+	highlighted     int // Option under the pointer or keyboard cursor in the open list
+	scroll          int // First option shown in the open list
+	maxVisible      int
+	itemHeight      int
+	focused         bool
+	pressed         bool // The press that opened or closed the list is still held
+	list            *selectList
+	layer           *PopupLayer // Layer the list is shown on while open
+	backgroundColor color.RGBA
+	textColor       color.RGBA
+	borderColor     color.RGBA
+	highlightColor  color.RGBA
+	fontSize        int
+}
+
+// NewSelect creates a new select box
+func NewSelect(id string, options []string) *Select {
+	s := &Select{
+		Node:            NewNode(id),
+		options:         options,
+		selectedIndex:   -1,
+		highlighted:     -1,
+		maxVisible:      8,
+		itemHeight:      20,
+		backgroundColor: color.RGBA{240, 240, 240, 255},
+		textColor:       color.RGBA{0, 0, 0, 255},
+		borderColor:     color.RGBA{100, 100, 100, 255},
+		highlightColor:  color.RGBA{200, 200, 255, 255},
+		fontSize:        14,
+	}
+	s.list = &selectList{Node: NewNode(id + "_list"), s: s}
+	s.SetCursorShape(CursorPointer)
+	s.list.SetCursorShape(CursorPointer)
+	return s
+}
+
+// NewMultiSelect creates a select box in multiple mode
+func NewMultiSelect(id string, options []string) *Select {
+	s := NewSelect(id, options)
+	s.SetMultiple(true)
+	return s
+}
+
+// SetOptions sets the available options
+func (s *Select) SetOptions(options []string) {
+	s.options = options
+	if s.selectedIndex >= len(options) {
+		s.selectedIndex = -1
+	}
+	s.selected = slices.DeleteFunc(s.selected, func(i int) bool { return i >= len(options) })
+	if len(options) == 0 {
+		s.Close()
+	}
+	s.highlight(min(s.highlighted, len(options)-1))
+}
+
+// GetOptions returns the available options
+func (s *Select) GetOptions() []string {
+	return s.options
+}
+
+// SetSelectedIndex sets the selected option index. In multiple mode it
+// chooses just that option, or none for -1.
+func (s *Select) SetSelectedIndex(index int) {
+	if index < -1 || index >= len(s.options) {
+		return
+	}
+	if s.multiple {
+		if index < 0 {
+			s.SetSelectedIndices(nil)
+		} else {
+			s.SetSelectedIndices([]int{index})
+		}
+		return
+	}
+	s.selectedIndex = index
+	if s.onChange != nil {
+		s.onChange(index)
+	}
+}
+
+// GetSelectedIndex returns the selected option index; in multiple mode, the
+// first chosen option
+func (s *Select) GetSelectedIndex() int {
+	return s.selectedIndex
+}
+
+// GetSelectedOption returns the selected option text
+func (s *Select) GetSelectedOption() string {
+	if s.selectedIndex >= 0 && s.selectedIndex < len(s.options) {
+		return s.options[s.selectedIndex]
+	}
+	return ""
+}
+
+// SetMultiple sets whether several options can be chosen. Switching keeps
+// the selected option, or the first of the chosen ones.
+func (s *Select) SetMultiple(multiple bool) {
+	if multiple == s.multiple {
+		return
+	}
+	s.multiple = multiple
+	s.selected = nil
+	if multiple && s.selectedIndex >= 0 {
+		s.selected = []int{s.selectedIndex}
+	}
+}
+
+// IsMultiple returns whether several options can be chosen
+func (s *Select) IsMultiple() bool {
+	return s.multiple
+}
+
+// SetSelectedIndices chooses the given options in multiple mode; out of
+// range indices are ignored
+func (s *Select) SetSelectedIndices(indices []int) {
+	if !s.multiple {
+		return
+	}
+	s.selected = s.selected[:0]
+	for _, index := range indices {
+		if index >= 0 && index < len(s.options) && !slices.Contains(s.selected, index) {
+			s.selected = append(s.selected, index)
+		}
+	}
+	slices.Sort(s.selected)
+	s.selectionChanged()
+}
+
+// GetSelectedIndices returns the chosen options in option order. Outside
+// multiple mode it holds the selected option, if any.
+func (s *Select) GetSelectedIndices() []int {
+	if !s.multiple {
+		if s.selectedIndex < 0 {
+			return nil
+		}
+		return []int{s.selectedIndex}
+	}
+	return slices.Clone(s.selected)
+}
+
+// GetSelectedOptions returns the text of the chosen options
+func (s *Select) GetSelectedOptions() []string {
+	var options []string
+	for _, index := range s.GetSelectedIndices() {
+		options = append(options, s.options[index])
+	}
+	return options
+}
+
+// SetOnSelectionChange sets the handler called with the chosen options
+// whenever they change in multiple mode
+func (s *Select) SetOnSelectionChange(handler func(indices []int)) {
+	s.onSelection = handler
+}
+
+// toggle adds an option to the chosen ones in multiple mode, or removes it
+func (s *Select) toggle(index int) {
+	if i, found := slices.BinarySearch(s.selected, index); found {
+		s.selected = slices.Delete(s.selected, i, i+1)
+	} else {
+		s.selected = slices.Insert(s.selected, i, index)
+	}
+	s.selectionChanged()
+}
+
+// selectionChanged updates the selected option to the first chosen one and
+// notifies the handlers
+func (s *Select) selectionChanged() {
+	first := -1
+	if len(s.selected) > 0 {
+		first = s.selected[0]
+	}
+	if first != s.selectedIndex {
+		s.selectedIndex = first
+		if s.onChange != nil {
+			s.onChange(first)
+		}
+	}
+	if s.onSelection != nil {
+		s.onSelection(slices.Clone(s.selected))
+	}
+}
+
+// SetOnChange sets the change handler
+func (s *Select) SetOnChange(handler func(int)) {
+	s.onChange = handler
+}
+
+// SetMaxVisibleOptions sets how many options the open list shows before it
+// scrolls
+func (s *Select) SetMaxVisibleOptions(count int) {
+	s.maxVisible = max(count, 1)
+	s.highlight(s.highlighted)
+}
+
+// Open shows the list of options, with the selected one highlighted
+func (s *Select) Open() {
+	if s.isOpen || len(s.options) == 0 {
+		return
+	}
+	s.isOpen = true
+	s.scroll = 0
+	s.highlight(max(s.selectedIndex, 0))
+	if s.layer = PopupLayerFor(s); s.layer != nil {
+		s.list.SetBounds(s.listRect())
+		s.layer.Show(s.list)
+	}
+}
+
+// Close hides the list of options
+func (s *Select) Close() {
+	s.isOpen = false
+	if s.layer != nil {
+		s.layer.Close(s.list)
+		s.layer = nil
+	}
+}
+
+// IsOpen returns whether the list of options is showing
+func (s *Select) IsOpen() bool {
+	return s.isOpen
+}
+
+// Focus gives the select keyboard focus
+func (s *Select) Focus() {
+	s.focused = true
+}
+
+// Blur removes keyboard focus and closes the list
+func (s *Select) Blur() {
+	s.focused = false
+	s.Close()
+}
+
+// IsFocused returns whether the select receives keys
+func (s *Select) IsFocused() bool {
+	return s.focused
+}
+
+// highlight moves the list cursor to an option, scrolling it into view
+func (s *Select) highlight(index int) {
+	s.highlighted = index
+	if index < 0 {
+		s.scroll = 0
+		return
+	}
+	if index < s.scroll {
+		s.scroll = index
+	} else if index >= s.scroll+s.maxVisible {
+		s.scroll = index - s.maxVisible + 1
+	}
+	s.scroll = max(min(s.scroll, len(s.options)-s.visibleCount()), 0)
+}
+
+// rowHeight returns the height of an option, grown to fit the text scale
+func (s *Select) rowHeight() int {
+	return max(s.itemHeight, ScaleTextSize(s.fontSize)+6)
+}
+
+// visibleCount returns how many options the open list shows
+func (s *Select) visibleCount() int {
+	return min(len(s.options), s.maxVisible)
+}
+
+// listRect returns the bounds of the open list: below the box, or above it
+// if there isn't room below
+func (s *Select) listRect() Rect {
+	bounds := s.ComputedBounds()
+	height := s.visibleCount() * s.rowHeight()
+	bottom := ScreenHeight
+	if s.layer != nil {
+		layer := s.layer.ComputedBounds()
+		bottom = layer.Y + layer.Height
+	}
+	y := bounds.Y + bounds.Height
+	if y+height > bottom && bounds.Y-height >= 0 {
+		y = bounds.Y - height
+	}
+	return Rect{X: bounds.X, Y: y, Width: bounds.Width, Height: height}
+}
+
+// optionAt returns the option under a point in the open list, or -1
+func (s *Select) optionAt(x, y int) int {
+	list := s.listRect()
+	if !s.isOpen || !PointInRect(Point{x, y}, list) {
+		return -1
+	}
+	if index := s.scroll + (y-list.Y)/s.rowHeight(); index < len(s.options) {
+		return index
+	}
+	return -1
+}
+
+// choose selects an option and closes the list; in multiple mode it
+// toggles the option and leaves the list open
+func (s *Select) choose(index int) {
+	if s.multiple {
+		if index >= 0 {
+			s.toggle(index)
+		}
+		return
+	}
+	s.Close()
+	if index >= 0 && index != s.selectedIndex {
+		s.SetSelectedIndex(index)
+	}
+}
+
+// Draw draws the select box, and the open list if there is no popup layer
+func (s *Select) Draw(surface DrawSurface) {
+	if !s.IsVisible() {
+		return
+	}
+
+	bounds := s.ComputedBounds()
+
+	// Draw background and border, in the highlight color while focused
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, s.backgroundColor)
+	borderColor := s.borderColor
+	if s.focused {
+		borderColor = color.RGBA{70, 130, 180, 255}
+	}
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, borderColor)
+
+	// Draw selected option, chips for the chosen ones, or placeholder
+	if s.multiple && len(s.selected) > 0 {
+		s.drawChips(surface, bounds)
+	} else {
+		text := "Select..."
+		if s.selectedIndex >= 0 && s.selectedIndex < len(s.options) {
+			text = s.options[s.selectedIndex]
+		}
+		surface.DrawText(text, bounds.X+5, bounds.Y+(bounds.Height-s.fontSize)/2, s.textColor, s.fontSize)
+	}
+
+	// Draw dropdown arrow
+	arrowX := bounds.X + bounds.Width - 20
+	arrowY := bounds.Y + bounds.Height/2
+	surface.DrawLine(arrowX, arrowY-3, arrowX+6, arrowY+3, s.textColor)
+	surface.DrawLine(arrowX+6, arrowY+3, arrowX+12, arrowY-3, s.textColor)
+
+	// Draw children (if any)
+	for _, child := range s.Children() {
+		child.Draw(surface)
+	}
+
+	if s.isOpen && s.layer == nil {
+		s.drawList(surface)
+	}
+}
+
+// drawChips draws the chosen options as chips left to right, ending with a
+// "+N" count of those that don't fit before the arrow
+func (s *Select) drawChips(surface DrawSurface, bounds Rect) {
+	chipColor := color.RGBA{222, 226, 236, 255}
+	charWidth := max(s.fontSize/2, 1)
+	height := min(bounds.Height-8, s.fontSize+8)
+	y := bounds.Y + (bounds.Height-height)/2
+	x := bounds.X + 4
+	right := bounds.X + bounds.Width - 26
+	for i, index := range s.selected {
+		label := []rune(s.options[index])
+		width := len(label)*charWidth + 12
+
+		// Keep room for the count of the chips after this one
+		reserve := 0
+		if rest := len(s.selected) - i - 1; rest > 0 {
+			reserve = len(fmt.Sprintf("+%d", rest))*charWidth + 8
+		}
+		if x+width+reserve > right && i > 0 {
+			surface.DrawText(fmt.Sprintf("+%d", len(s.selected)-i), x+2, bounds.Y+(bounds.Height-s.fontSize)/2, s.textColor, s.fontSize)
+			return
+		}
+
+		// A first chip too wide for the box is cut short
+		if width = min(width, right-x-reserve); len(label)*charWidth+12 > width {
+			label = append(label[:max((width-12)/charWidth-1, 0)], '…')
+		}
+		surface.FillRect(x, y, width, height, chipColor)
+		surface.DrawText(string(label), x+6, y+(height-s.fontSize)/2, s.textColor, s.fontSize)
+		x += width + 4
+	}
+}
+
+// drawList draws the open list of options with a scroll bar if they don't
+// all fit
+func (s *Select) drawList(surface DrawSurface) {
+	list := s.listRect()
+	s.list.SetBounds(list)
+
+	surface.FillRect(list.X+3, list.Y+3, list.Width, list.Height, color.RGBA{0, 0, 0, 60})
+	surface.FillRect(list.X, list.Y, list.Width, list.Height, s.backgroundColor)
+	for i := 0; i < s.visibleCount(); i++ {
+		index := s.scroll + i
+		y := list.Y + i*s.rowHeight()
+		if index == s.highlighted {
+			surface.FillRect(list.X, y, list.Width, s.rowHeight(), s.highlightColor)
+		}
+		textColor := s.textColor
+		if s.multiple {
+			// Check box for each option
+			boxY := y + (s.rowHeight()-12)/2
+			surface.FillRect(list.X+4, boxY, 12, 12, color.RGBA{255, 255, 255, 255})
+			surface.DrawRect(list.X+4, boxY, 12, 12, s.borderColor)
+		}
+		if index == s.selectedIndex || (s.multiple && slices.Contains(s.selected, index)) {
+			// Check mark beside the chosen options
+			surface.DrawLine(list.X+6, y+s.rowHeight()/2, list.X+9, y+s.rowHeight()/2+3, textColor)
+			surface.DrawLine(list.X+9, y+s.rowHeight()/2+3, list.X+14, y+s.rowHeight()/2-3, textColor)
+		}
+		surface.DrawText(s.options[index], list.X+18, y+(s.rowHeight()-s.fontSize)/2, textColor, s.fontSize)
+	}
+
+	if len(s.options) > s.maxVisible {
+		thumbHeight := max(list.Height*s.maxVisible/len(s.options), 12)
+		thumbY := list.Y + (list.Height-thumbHeight)*s.scroll/(len(s.options)-s.maxVisible)
+		surface.FillRect(list.X+list.Width-5, thumbY, 3, thumbHeight, color.RGBA{160, 160, 160, 255})
+	}
+	surface.DrawRect(list.X, list.Y, list.Width, list.Height, s.borderColor)
+}
+
+// HandleMouseDown opens or closes the list when the box is pressed. Without
+// a popup layer it also handles presses on the open list and outside it.
+func (s *Select) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !s.IsVisible() {
+		return
+	}
+	if s.pressed {
+		e.Consume()
+		return
+	}
+
+	if s.isOpen && s.layer == nil {
+		if s.list.HandleMouseDown(e); e.Consumed() {
+			return
+		}
+	}
+
+	if PointInRect(Point{x, y}, s.ComputedBounds()) {
+		s.pressed = true
+		s.focused = true
+		if s.isOpen {
+			s.Close()
+		} else {
+			s.Open()
+		}
+		e.Consume()
+		return
+	}
+
+	s.focused = false
+	if s.isOpen && s.layer == nil {
+		// Close the list on a press outside it, keeping the press
+		s.Close()
+		s.pressed = true
+		e.Consume()
+	}
+}
+
+// HandleMouseUp ends a press; without a popup layer, releasing over an
+// option picks it
+func (s *Select) HandleMouseUp(e *Event) {
+	s.pressed = false
+	if s.isOpen && s.layer == nil {
+		s.list.HandleMouseUp(e)
+	}
+}
+
+// HandleMouseMove highlights the option under the pointer when the list is
+// drawn without a popup layer
+func (s *Select) HandleMouseMove(e *Event) {
+	if s.isOpen && s.layer == nil {
+		s.list.HandleMouseMove(e)
+	}
+}
+
+// HandleScroll scrolls the list when it is drawn without a popup layer
+func (s *Select) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	return s.isOpen && s.layer == nil && s.list.HandleScroll(x, y, deltaX, deltaY)
+}
+
+// HandleKeyDown works the select from the keyboard while focused. With the
+// list closed, Up/Down change the selection (or open the list in multiple
+// mode) and Enter, Space or Alt+Down open the list; with it open,
+// Up/Down/Home/End/PageUp/PageDown move the highlight, Enter or Space pick
+// or toggle it and Esc or Tab close the list.
+func (s *Select) HandleKeyDown(event InputEvent) bool {
+	if !s.focused || !s.IsVisible() || event.Type != InputTypeKeyDown {
+		return false
+	}
+
+	if !s.isOpen {
+		switch {
+		case event.Key == KeyEnter || event.Key == KeySpace || (event.Key == KeyDown && event.AltDown),
+			s.multiple && (event.Key == KeyUp || event.Key == KeyDown):
+			s.Open()
+			return true
+		case event.Key == KeyEscape:
+			s.focused = false
+			return true
+		}
+		if index, ok := NavigateList(event, s.selectedIndex, len(s.options), s.maxVisible); ok {
+			if index != s.selectedIndex {
+				s.SetSelectedIndex(index)
+			}
+			return true
+		}
+		return false
+	}
+
+	switch event.Key {
+	case KeyEnter, KeySpace:
+		s.choose(s.highlighted)
+		return true
+	case KeyEscape:
+		s.Close()
+		return true
+	case KeyTab:
+		s.Close()
+		return false
+	}
+	if index, ok := NavigateList(event, s.highlighted, len(s.options), s.maxVisible); ok {
+		s.highlight(index)
+	}
+	return true
+}
+
+// selectList is the open list of a Select, shown as a popup
+type selectList struct {
+	*Node
+	s *Select
+}
+
+// Draw draws the list
+func (l *selectList) Draw(surface DrawSurface) {
+	if l.s.isOpen {
+		l.s.drawList(surface)
+	}
+}
+
+// HandleMouseDown keeps presses on the list
+func (l *selectList) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if PointInRect(Point{x, y}, l.s.listRect()) {
+		e.Consume()
+	}
+}
+
+// HandleMouseUp picks the option released over
+func (l *selectList) HandleMouseUp(e *Event) {
+	x, y := e.X, e.Y
+	if index := l.s.optionAt(x, y); index >= 0 {
+		l.s.choose(index)
+		e.Consume()
+	}
+}
+
+// HandleMouseMove highlights the option under the pointer
+func (l *selectList) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if index := l.s.optionAt(x, y); index >= 0 {
+		l.s.highlighted = index
+		e.Consume()
+	}
+}
+
+// HandleScroll scrolls the list with the mouse wheel
+func (l *selectList) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !PointInRect(Point{x, y}, l.s.listRect()) {
+		return false
+	}
+	l.s.scroll = max(min(l.s.scroll-int(deltaY), len(l.s.options)-l.s.visibleCount()), 0)
+	return true
+}
+
+// Dismiss closes the list after a press outside it
+func (l *selectList) Dismiss() {
+	l.s.Close()
+}
@@ -0,0 +1,520 @@
+package components
+
+import (
+	"image/color"
+	"math"
+)
+
+// Transform is the position, size and rotation applied by a SelectionFrame
+type Transform struct {
+	X, Y          int
+	Width, Height int
+	Rotation      float64 // Radians, clockwise around the center
+}
+
+// Rect returns the unrotated bounds of the transform
+func (t Transform) Rect() Rect {
+	return Rect{X: t.X, Y: t.Y, Width: t.Width, Height: t.Height}
+}
+
+// Center returns the center of the transform
+func (t Transform) Center() Point {
+	return Point{t.X + t.Width/2, t.Y + t.Height/2}
+}
+
+// FrameHandle identifies the part of a SelectionFrame being dragged
+type FrameHandle int
+
+const (
+	FrameHandleNone FrameHandle = iota
+	FrameHandleMove
+	FrameHandleTopLeft
+	FrameHandleTop
+	FrameHandleTopRight
+	FrameHandleRight
+	FrameHandleBottomRight
+	FrameHandleBottom
+	FrameHandleBottomLeft
+	FrameHandleLeft
+	FrameHandleRotate
+)
+
+// SelectionFrame wraps an element with handles to move, resize and rotate it.
+// The frame draws the wrapped element and sets its bounds as it is dragged,
+// so the element should be added to the tree through the frame rather than directly.
+// Rotation is reported through the transform and drawn on the frame outline;
+// it is up to the wrapped element or the handler to apply it when drawing.
+type SelectionFrame struct {
+	*Node
+	target         Element
+	transform      Transform
+	dragging       FrameHandle
+	hovered        FrameHandle
+	dragStart      Point
+	startTransform Transform
+	startAngle     float64
+	handleSize     int
+	rotateOffset   int
+	minWidth       int
+	minHeight      int
+	keepAspect     bool
+	allowMove      bool
+	allowResize    bool
+	allowRotate    bool
+	rotationSnap   float64 // Radians; 0 for free rotation
+	gridSnap       int     // Pixels; 0 for no snapping
+	constraint     Rect    // Area the frame must stay inside; zero size for none
+	focused        bool
+	onChange       func(Transform)
+	onChangeEnd    func(Transform)
+	frameColor     color.RGBA
+	handleColor    color.RGBA
+}
+
+// NewSelectionFrame creates a frame around the element, starting at its current bounds
+func NewSelectionFrame(id string, target Element) *SelectionFrame {
+	f := &SelectionFrame{
+		Node:         NewNode(id),
+		handleSize:   8,
+		rotateOffset: 24,
+		minWidth:     10,
+		minHeight:    10,
+		allowMove:    true,
+		allowResize:  true,
+		allowRotate:  true,
+		frameColor:   color.RGBA{30, 144, 255, 255},
+		handleColor:  color.RGBA{255, 255, 255, 255},
+	}
+	f.SetTarget(target)
+	return f
+}
+
+// SetTarget sets the element the frame wraps
+func (f *SelectionFrame) SetTarget(target Element) {
+	f.target = target
+	if target != nil {
+		b := target.Bounds()
+		f.transform = Transform{X: b.X, Y: b.Y, Width: b.Width, Height: b.Height}
+		f.Node.SetBounds(b)
+	}
+}
+
+// Target returns the wrapped element
+func (f *SelectionFrame) Target() Element {
+	return f.target
+}
+
+// SetTransform moves, resizes and rotates the wrapped element
+func (f *SelectionFrame) SetTransform(t Transform) {
+	f.apply(t)
+}
+
+// Transform returns the current transform
+func (f *SelectionFrame) Transform() Transform {
+	return f.transform
+}
+
+// SetBounds sets the position and size, keeping the rotation
+func (f *SelectionFrame) SetBounds(bounds Rect) {
+	t := f.transform
+	t.X, t.Y, t.Width, t.Height = bounds.X, bounds.Y, bounds.Width, bounds.Height
+	f.apply(t)
+}
+
+// SetMinSize sets the smallest size resizing can reach
+func (f *SelectionFrame) SetMinSize(width, height int) {
+	f.minWidth = width
+	f.minHeight = height
+}
+
+// SetKeepAspectRatio sets whether corner handles keep the width/height ratio
+func (f *SelectionFrame) SetKeepAspectRatio(keep bool) {
+	f.keepAspect = keep
+}
+
+// SetAllowMove sets whether the frame can be dragged
+func (f *SelectionFrame) SetAllowMove(allow bool) {
+	f.allowMove = allow
+}
+
+// SetAllowResize sets whether the resize handles are shown
+func (f *SelectionFrame) SetAllowResize(allow bool) {
+	f.allowResize = allow
+}
+
+// SetAllowRotate sets whether the rotate handle is shown
+func (f *SelectionFrame) SetAllowRotate(allow bool) {
+	f.allowRotate = allow
+}
+
+// SetRotationSnap snaps rotation to multiples of the given angle in radians; 0 disables
+func (f *SelectionFrame) SetRotationSnap(angle float64) {
+	f.rotationSnap = angle
+}
+
+// SetGridSnap snaps position and size to a grid of the given size; 0 disables
+func (f *SelectionFrame) SetGridSnap(size int) {
+	f.gridSnap = size
+}
+
+// SetConstraint keeps the frame inside the given area, e.g. the image being
+// cropped; a zero-size rect removes the constraint
+func (f *SelectionFrame) SetConstraint(area Rect) {
+	f.constraint = area
+	f.apply(f.transform)
+}
+
+// SetOnChange sets the handler called whenever the transform changes during a drag
+func (f *SelectionFrame) SetOnChange(handler func(Transform)) {
+	f.onChange = handler
+}
+
+// SetOnChangeEnd sets the handler called when a drag finishes
+func (f *SelectionFrame) SetOnChangeEnd(handler func(Transform)) {
+	f.onChangeEnd = handler
+}
+
+// IsDragging returns whether a handle is being dragged
+func (f *SelectionFrame) IsDragging() bool {
+	return f.dragging != FrameHandleNone
+}
+
+// Focus gives the frame keyboard focus so arrow keys nudge it
+func (f *SelectionFrame) Focus() {
+	f.focused = true
+}
+
+// Blur removes keyboard focus from the frame
+func (f *SelectionFrame) Blur() {
+	f.focused = false
+}
+
+// IsFocused returns whether arrow keys nudge the frame
+func (f *SelectionFrame) IsFocused() bool {
+	return f.focused
+}
+
+// snap rounds a value to the grid
+func (f *SelectionFrame) snap(v int) int {
+	if f.gridSnap <= 0 {
+		return v
+	}
+	return int(math.Round(float64(v)/float64(f.gridSnap))) * f.gridSnap
+}
+
+// apply constrains the transform, updates the wrapped element and notifies the handler
+func (f *SelectionFrame) apply(t Transform) {
+	if t.Width < f.minWidth {
+		t.Width = f.minWidth
+	}
+	if t.Height < f.minHeight {
+		t.Height = f.minHeight
+	}
+
+	if c := f.constraint; c.Width > 0 && c.Height > 0 {
+		if t.Width > c.Width {
+			t.Width = c.Width
+		}
+		if t.Height > c.Height {
+			t.Height = c.Height
+		}
+		if t.X < c.X {
+			t.X = c.X
+		}
+		if t.Y < c.Y {
+			t.Y = c.Y
+		}
+		if t.X+t.Width > c.X+c.Width {
+			t.X = c.X + c.Width - t.Width
+		}
+		if t.Y+t.Height > c.Y+c.Height {
+			t.Y = c.Y + c.Height - t.Height
+		}
+	}
+
+	if t == f.transform {
+		return
+	}
+	f.transform = t
+	f.Node.SetBounds(t.Rect())
+	if f.target != nil {
+		f.target.SetBounds(t.Rect())
+	}
+	if f.onChange != nil {
+		f.onChange(t)
+	}
+}
+
+// rotatePoint rotates p around the transform's center by its rotation
+func (f *SelectionFrame) rotatePoint(p Point) Point {
+	if f.transform.Rotation == 0 {
+		return p
+	}
+	c := f.transform.Center()
+	sin, cos := math.Sincos(f.transform.Rotation)
+	dx, dy := float64(p.X-c.X), float64(p.Y-c.Y)
+	return Point{
+		X: c.X + int(math.Round(dx*cos-dy*sin)),
+		Y: c.Y + int(math.Round(dx*sin+dy*cos)),
+	}
+}
+
+// unrotatePoint maps a screen point into the frame's unrotated space
+func (f *SelectionFrame) unrotatePoint(p Point) Point {
+	if f.transform.Rotation == 0 {
+		return p
+	}
+	c := f.transform.Center()
+	sin, cos := math.Sincos(-f.transform.Rotation)
+	dx, dy := float64(p.X-c.X), float64(p.Y-c.Y)
+	return Point{
+		X: c.X + int(math.Round(dx*cos-dy*sin)),
+		Y: c.Y + int(math.Round(dx*sin+dy*cos)),
+	}
+}
+
+// handlePoints returns the unrotated centers of the resize handles and the rotate handle
+func (f *SelectionFrame) handlePoints() map[FrameHandle]Point {
+	t := f.transform
+	left, top := t.X, t.Y
+	right, bottom := t.X+t.Width, t.Y+t.Height
+	midX, midY := t.X+t.Width/2, t.Y+t.Height/2
+
+	points := make(map[FrameHandle]Point)
+	if f.allowResize {
+		points[FrameHandleTopLeft] = Point{left, top}
+		points[FrameHandleTop] = Point{midX, top}
+		points[FrameHandleTopRight] = Point{right, top}
+		points[FrameHandleRight] = Point{right, midY}
+		points[FrameHandleBottomRight] = Point{right, bottom}
+		points[FrameHandleBottom] = Point{midX, bottom}
+		points[FrameHandleBottomLeft] = Point{left, bottom}
+		points[FrameHandleLeft] = Point{left, midY}
+	}
+	if f.allowRotate {
+		points[FrameHandleRotate] = Point{midX, top - f.rotateOffset}
+	}
+	return points
+}
+
+// HandleAt returns the handle under the given screen point
+func (f *SelectionFrame) HandleAt(x, y int) FrameHandle {
+	p := f.unrotatePoint(Point{x, y})
+	half := f.handleSize/2 + 2
+	for handle, center := range f.handlePoints() {
+		if absInt(p.X-center.X) <= half && absInt(p.Y-center.Y) <= half {
+			return handle
+		}
+	}
+	if f.allowMove && PointInRect(p, f.transform.Rect()) {
+		return FrameHandleMove
+	}
+	return FrameHandleNone
+}
+
+// Draw draws the wrapped element, the frame outline and its handles
+func (f *SelectionFrame) Draw(surface DrawSurface) {
+	if !f.IsVisible() {
+		return
+	}
+
+	if f.target != nil {
+		f.target.Draw(surface)
+	}
+
+	// Outline through the rotated corners
+	t := f.transform
+	corners := []Point{
+		f.rotatePoint(Point{t.X, t.Y}),
+		f.rotatePoint(Point{t.X + t.Width, t.Y}),
+		f.rotatePoint(Point{t.X + t.Width, t.Y + t.Height}),
+		f.rotatePoint(Point{t.X, t.Y + t.Height}),
+	}
+	for i, c := range corners {
+		n := corners[(i+1)%len(corners)]
+		surface.DrawLine(c.X, c.Y, n.X, n.Y, f.frameColor)
+	}
+
+	points := f.handlePoints()
+	if rotate, ok := points[FrameHandleRotate]; ok {
+		top := f.rotatePoint(Point{t.X + t.Width/2, t.Y})
+		r := f.rotatePoint(rotate)
+		surface.DrawLine(top.X, top.Y, r.X, r.Y, f.frameColor)
+		surface.FillCircle(r.X, r.Y, f.handleSize/2+1, f.handleColor)
+		surface.DrawCircle(r.X, r.Y, f.handleSize/2+1, f.frameColor)
+	}
+
+	half := f.handleSize / 2
+	for handle, center := range points {
+		if handle == FrameHandleRotate {
+			continue
+		}
+		c := f.rotatePoint(center)
+		fill := f.handleColor
+		if handle == f.hovered || handle == f.dragging {
+			fill = f.frameColor
+		}
+		surface.FillRect(c.X-half, c.Y-half, f.handleSize, f.handleSize, fill)
+		surface.DrawRect(c.X-half, c.Y-half, f.handleSize, f.handleSize, f.frameColor)
+	}
+}
+
+// HandleMouseDown starts dragging the handle under the pointer
+func (f *SelectionFrame) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !f.IsVisible() {
+		return
+	}
+	if f.dragging != FrameHandleNone {
+		f.drag(x, y)
+		e.Consume()
+		return
+	}
+
+	handle := f.HandleAt(x, y)
+	if handle == FrameHandleNone {
+		f.focused = false
+		return
+	}
+
+	f.focused = true
+	f.dragging = handle
+	f.dragStart = Point{x, y}
+	f.startTransform = f.transform
+	c := f.transform.Center()
+	f.startAngle = math.Atan2(float64(y-c.Y), float64(x-c.X))
+	e.Consume()
+}
+
+// HandleMouseUp finishes a drag
+func (f *SelectionFrame) HandleMouseUp(e *Event) {
+	if f.dragging == FrameHandleNone {
+		return
+	}
+	f.dragging = FrameHandleNone
+	if f.onChangeEnd != nil && f.transform != f.startTransform {
+		f.onChangeEnd(f.transform)
+	}
+	e.Consume()
+}
+
+// HandleMouseMove updates the drag in progress and tracks the hovered handle
+func (f *SelectionFrame) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if f.dragging != FrameHandleNone {
+		f.drag(x, y)
+		e.Consume()
+		return
+	}
+	f.hovered = f.HandleAt(x, y)
+	if f.hovered != FrameHandleNone {
+		e.Consume()
+	}
+}
+
+// drag applies pointer movement since the drag started to the transform
+func (f *SelectionFrame) drag(x, y int) {
+	start := f.startTransform
+	t := start
+
+	if f.dragging == FrameHandleRotate {
+		c := start.Center()
+		angle := start.Rotation + math.Atan2(float64(y-c.Y), float64(x-c.X)) - f.startAngle
+		if f.rotationSnap > 0 {
+			angle = math.Round(angle/f.rotationSnap) * f.rotationSnap
+		}
+		t.Rotation = math.Mod(angle, 2*math.Pi)
+		f.apply(t)
+		return
+	}
+
+	// Resize along the frame's own axes so rotated frames resize naturally
+	sin, cos := math.Sincos(-start.Rotation)
+	rdx, rdy := float64(x-f.dragStart.X), float64(y-f.dragStart.Y)
+	dx := int(math.Round(rdx*cos - rdy*sin))
+	dy := int(math.Round(rdx*sin + rdy*cos))
+
+	switch f.dragging {
+	case FrameHandleMove:
+		t.X = f.snap(start.X + x - f.dragStart.X)
+		t.Y = f.snap(start.Y + y - f.dragStart.Y)
+		f.apply(t)
+		return
+	case FrameHandleTopLeft, FrameHandleLeft, FrameHandleBottomLeft:
+		t.Width = start.Width - dx
+	case FrameHandleTopRight, FrameHandleRight, FrameHandleBottomRight:
+		t.Width = start.Width + dx
+	}
+	switch f.dragging {
+	case FrameHandleTopLeft, FrameHandleTop, FrameHandleTopRight:
+		t.Height = start.Height - dy
+	case FrameHandleBottomLeft, FrameHandleBottom, FrameHandleBottomRight:
+		t.Height = start.Height + dy
+	}
+
+	t.Width = f.snap(t.Width)
+	t.Height = f.snap(t.Height)
+	if t.Width < f.minWidth {
+		t.Width = f.minWidth
+	}
+	if t.Height < f.minHeight {
+		t.Height = f.minHeight
+	}
+
+	// Corner handles keep the aspect ratio by following the larger change
+	corner := f.dragging == FrameHandleTopLeft || f.dragging == FrameHandleTopRight ||
+		f.dragging == FrameHandleBottomLeft || f.dragging == FrameHandleBottomRight
+	if f.keepAspect && corner && start.Width > 0 && start.Height > 0 {
+		scaleX := float64(t.Width) / float64(start.Width)
+		scaleY := float64(t.Height) / float64(start.Height)
+		scale := math.Max(scaleX, scaleY)
+		t.Width = int(math.Round(float64(start.Width) * scale))
+		t.Height = int(math.Round(float64(start.Height) * scale))
+	}
+
+	// Keep the opposite edge in place
+	switch f.dragging {
+	case FrameHandleTopLeft, FrameHandleLeft, FrameHandleBottomLeft:
+		t.X = start.X + start.Width - t.Width
+	}
+	switch f.dragging {
+	case FrameHandleTopLeft, FrameHandleTop, FrameHandleTopRight:
+		t.Y = start.Y + start.Height - t.Height
+	}
+
+	f.apply(t)
+}
+
+// HandleKeyDown nudges the frame with the arrow keys while focused; Shift moves by 10
+func (f *SelectionFrame) HandleKeyDown(event InputEvent) bool {
+	if !f.focused || !f.allowMove || event.Type != InputTypeKeyDown {
+		return false
+	}
+
+	step := 1
+	if event.ShiftDown {
+		step = 10
+	}
+	if f.gridSnap > 0 {
+		step = f.gridSnap
+	}
+
+	t := f.transform
+	switch event.Key {
+	case KeyLeft:
+		t.X -= step
+	case KeyRight:
+		t.X += step
+	case KeyUp:
+		t.Y -= step
+	case KeyDown:
+		t.Y += step
+	default:
+		return false
+	}
+	f.apply(t)
+	if f.onChangeEnd != nil {
+		f.onChangeEnd(f.transform)
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package components
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ScrollPositioner is implemented by elements whose scroll position is saved
+// with the app session
+type ScrollPositioner interface {
+	ScrollOffset() Point
+	SetScrollOffset(offset Point)
+}
+
+// elementPath returns the child indices leading from the root to an element,
+// e.g. "0/3/1". Generated IDs change between runs; paths don't, as long as
+// the app builds the same tree.
+func elementPath(indices []int) string {
+	parts := make([]string, len(indices))
+	for i, index := range indices {
+		parts[i] = strconv.Itoa(index)
+	}
+	return strings.Join(parts, "/")
+}
+
+// ScrollOffsets collects the scroll positions of every scrolled element in
+// the tree, keyed by their path from the root
+func ScrollOffsets(root Element) map[string]Point {
+	offsets := make(map[string]Point)
+	var walk func(element Element, path []int)
+	walk = func(element Element, path []int) {
+		if scroller, ok := element.(ScrollPositioner); ok {
+			if offset := scroller.ScrollOffset(); offset != (Point{}) {
+				offsets[elementPath(path)] = offset
+			}
+		}
+		for i, child := range element.Children() {
+			walk(child, append(path, i))
+		}
+	}
+	walk(root, nil)
+	return offsets
+}
+
+// RestoreScrollOffsets applies positions collected by ScrollOffsets to the
+// elements at the same paths
+func RestoreScrollOffsets(root Element, offsets map[string]Point) {
+	var walk func(element Element, path []int)
+	walk = func(element Element, path []int) {
+		if scroller, ok := element.(ScrollPositioner); ok {
+			if offset, ok := offsets[elementPath(path)]; ok {
+				scroller.SetScrollOffset(offset)
+			}
+		}
+		for i, child := range element.Children() {
+			walk(child, append(path, i))
+		}
+	}
+	walk(root, nil)
+}
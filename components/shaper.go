@@ -0,0 +1,124 @@
+package components
+
+import (
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"golang.org/x/image/font"
+)
+
+// ShapedText is a string laid out by a Shaper, ready to measure and draw
+type ShapedText interface {
+	// Width returns the advance of the whole text in pixels
+	Width() int
+	// Draw draws the text with its top-left corner at x, y
+	Draw(target *ebiten.Image, x, y int, clr color.RGBA)
+}
+
+// Shaper turns a string into positioned glyphs. A shaper for complex
+// scripts applies ligatures and contextual forms and orders right-to-left
+// text; the default SimpleShaper draws one glyph per rune, left to right.
+type Shaper interface {
+	Shape(text string, fonts *FontChain) ShapedText
+}
+
+// SimpleShaper draws each rune with the first face in the font chain that
+// covers it, and a box for runes no face covers
+type SimpleShaper struct{}
+
+// Shape splits the text into runs by face
+func (SimpleShaper) Shape(txt string, fonts *FontChain) ShapedText {
+	return &simpleText{runs: fonts.Runs(txt), primary: fonts.Primary()}
+}
+
+// simpleText is the output of SimpleShaper
+type simpleText struct {
+	runs    []TextRun
+	primary font.Face
+}
+
+// tofuWidth returns the width of the box drawn for a missing glyph
+func (s *simpleText) tofuWidth() int {
+	return font.MeasureString(s.primary, "M").Ceil()
+}
+
+// Width returns the advance of the whole text in pixels
+func (s *simpleText) Width() int {
+	width := 0
+	for _, run := range s.runs {
+		if run.Face == nil {
+			width += s.tofuWidth()
+			continue
+		}
+		width += font.MeasureString(run.Face, run.Text).Ceil()
+	}
+	return width
+}
+
+// Draw draws the runs in order, outlining a box for each missing glyph
+func (s *simpleText) Draw(target *ebiten.Image, x, y int, clr color.RGBA) {
+	baseline := y + s.primary.Metrics().Height.Ceil() // 13 for the built-in font
+	boxWidth := s.tofuWidth()
+
+	for _, run := range s.runs {
+		if run.Face == nil {
+			x1, y1 := float32(x+1), float32(y+1)
+			x2, y2 := float32(x+boxWidth-1), float32(baseline)
+			vector.StrokeLine(target, x1, y1, x2, y1, 1, tofuColor, false)
+			vector.StrokeLine(target, x2, y1, x2, y2, 1, tofuColor, false)
+			vector.StrokeLine(target, x2, y2, x1, y2, 1, tofuColor, false)
+			vector.StrokeLine(target, x1, y2, x1, y1, 1, tofuColor, false)
+			x += boxWidth
+			continue
+		}
+		text.Draw(target, run.Text, run.Face, x, baseline, clr)
+		x += font.MeasureString(run.Face, run.Text).Ceil()
+	}
+}
+
+var (
+	shaperMu     sync.RWMutex
+	activeShaper Shaper = SimpleShaper{}
+)
+
+// SetShaper sets the shaper used to draw text; nil restores SimpleShaper
+func SetShaper(shaper Shaper) {
+	shaperMu.Lock()
+	defer shaperMu.Unlock()
+	if shaper == nil {
+		shaper = SimpleShaper{}
+	}
+	activeShaper = shaper
+}
+
+// CurrentShaper returns the shaper used to draw text
+func CurrentShaper() Shaper {
+	shaperMu.RLock()
+	defer shaperMu.RUnlock()
+	return activeShaper
+}
+
+// MeasureText returns the drawn width of text in pixels using the current
+// shaper and font chain, at the current text scale
+func MeasureText(txt string) int {
+	width := CurrentShaper().Shape(txt, CurrentFontChain()).Width()
+	if scale := TextScale(); scale != TextScaleNormal {
+		return int(math.Round(float64(width) * scale))
+	}
+	return width
+}
+
+// drawShapedText shapes text with the current shaper and font chain and
+// draws it at the current text scale
+func drawShapedText(target *ebiten.Image, txt string, x, y int, clr color.RGBA) {
+	shaped := CurrentShaper().Shape(txt, CurrentFontChain())
+	if scale := TextScale(); scale != TextScaleNormal {
+		drawScaledText(target, shaped, x, y, clr, scale)
+		return
+	}
+	shaped.Draw(target, x, y, clr)
+}
@@ -0,0 +1,210 @@
+//go:build harfbuzz
+
+package components
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"unicode"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	textv2 "github.com/hajimehoshi/ebiten/v2/text/v2"
+	"golang.org/x/text/language"
+)
+
+// HarfBuzzShaper shapes text with the Go port of HarfBuzz that Ebiten's
+// text/v2 package uses, so Arabic and Indic scripts get their ligatures,
+// contextual forms and right-to-left ordering. Build with -tags harfbuzz
+// and enable it with SetShaper.
+//
+// Each run of a single script is shaped separately; runs are placed left to
+// right in logical order, so mixed-direction paragraphs are not reordered.
+type HarfBuzzShaper struct {
+	sources []*textv2.GoTextFaceSource
+	size    float64
+	faces   map[*unicode.RangeTable]shaperFace // Faces built so far, by script
+}
+
+// shaperFace is a built face and whether it runs right to left
+type shaperFace struct {
+	face textv2.Face
+	rtl  bool
+}
+
+// NewHarfBuzzShaper creates a shaper from TrueType or OpenType font data in
+// fallback order, drawn at the given size in pixels
+func NewHarfBuzzShaper(size float64, fonts ...[]byte) (*HarfBuzzShaper, error) {
+	s := &HarfBuzzShaper{size: size, faces: make(map[*unicode.RangeTable]shaperFace)}
+	for _, data := range fonts {
+		source, err := textv2.NewGoTextFaceSource(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		s.sources = append(s.sources, source)
+	}
+	return s, nil
+}
+
+// scriptRun is a piece of text in a single script
+type scriptRun struct {
+	text   string
+	script *unicode.RangeTable
+}
+
+// shapedScripts lists the scripts the shaper tags, with their ISO 15924 codes
+var shapedScripts = []struct {
+	table *unicode.RangeTable
+	code  string
+	rtl   bool
+}{
+	{unicode.Arabic, "Arab", true},
+	{unicode.Hebrew, "Hebr", true},
+	{unicode.Syriac, "Syrc", true},
+	{unicode.Thaana, "Thaa", true},
+	{unicode.Devanagari, "Deva", false},
+	{unicode.Bengali, "Beng", false},
+	{unicode.Gurmukhi, "Guru", false},
+	{unicode.Gujarati, "Gujr", false},
+	{unicode.Oriya, "Orya", false},
+	{unicode.Tamil, "Taml", false},
+	{unicode.Telugu, "Telu", false},
+	{unicode.Kannada, "Knda", false},
+	{unicode.Malayalam, "Mlym", false},
+	{unicode.Sinhala, "Sinh", false},
+	{unicode.Thai, "Thai", false},
+	{unicode.Khmer, "Khmr", false},
+	{unicode.Myanmar, "Mymr", false},
+}
+
+// scriptOf returns the shaped script a rune belongs to, or nil for others
+func scriptOf(r rune) *unicode.RangeTable {
+	for _, s := range shapedScripts {
+		if unicode.Is(s.table, r) {
+			return s.table
+		}
+	}
+	if unicode.IsLetter(r) {
+		return unicode.Latin
+	}
+	return nil
+}
+
+// splitScripts splits text into runs of one script; spaces, digits and
+// punctuation stay with the run before them
+func splitScripts(txt string) []scriptRun {
+	var runs []scriptRun
+	start := 0
+	var current *unicode.RangeTable
+	for i, r := range txt {
+		script := scriptOf(r)
+		if script == nil || script == current {
+			continue
+		}
+		if current != nil && i > start {
+			runs = append(runs, scriptRun{text: txt[start:i], script: current})
+			start = i
+		}
+		current = script
+	}
+	if start < len(txt) {
+		runs = append(runs, scriptRun{text: txt[start:], script: current})
+	}
+	return runs
+}
+
+// face returns the text/v2 face used for a run and whether it is right to
+// left, building it the first time a script is shaped
+func (s *HarfBuzzShaper) face(script *unicode.RangeTable) (textv2.Face, bool) {
+	if cached, ok := s.faces[script]; ok {
+		return cached.face, cached.rtl
+	}
+	face, rtl := s.buildFace(script)
+	s.faces[script] = shaperFace{face: face, rtl: rtl}
+	return face, rtl
+}
+
+// buildFace builds the text/v2 face used for a run of a script
+func (s *HarfBuzzShaper) buildFace(script *unicode.RangeTable) (textv2.Face, bool) {
+	direction := textv2.DirectionLeftToRight
+	var tag language.Script
+	for _, known := range shapedScripts {
+		if known.table == script {
+			tag = language.MustParseScript(known.code)
+			if known.rtl {
+				direction = textv2.DirectionRightToLeft
+			}
+		}
+	}
+
+	faces := make([]textv2.Face, 0, len(s.sources))
+	for _, source := range s.sources {
+		faces = append(faces, &textv2.GoTextFace{Source: source, Size: s.size, Direction: direction, Script: tag})
+	}
+	rtl := direction == textv2.DirectionRightToLeft
+	if len(faces) == 1 {
+		return faces[0], rtl
+	}
+	multi, err := textv2.NewMultiFace(faces...)
+	if err != nil {
+		return faces[0], rtl
+	}
+	return multi, rtl
+}
+
+// Shape shapes each script run; the font chain is used only when no fonts were given
+func (s *HarfBuzzShaper) Shape(txt string, fonts *FontChain) ShapedText {
+	if len(s.sources) == 0 {
+		return SimpleShaper{}.Shape(txt, fonts)
+	}
+	shaped := &harfBuzzText{}
+	for _, run := range splitScripts(txt) {
+		face, rtl := s.face(run.script)
+		shaped.runs = append(shaped.runs, harfBuzzRun{
+			text:    run.text,
+			face:    face,
+			rtl:     rtl,
+			advance: textv2.Advance(run.text, face),
+		})
+	}
+	return shaped
+}
+
+// harfBuzzRun is one shaped script run
+type harfBuzzRun struct {
+	text    string
+	face    textv2.Face
+	rtl     bool
+	advance float64
+}
+
+// harfBuzzText is the output of HarfBuzzShaper
+type harfBuzzText struct {
+	runs []harfBuzzRun
+}
+
+// Width returns the advance of the whole text in pixels
+func (h *harfBuzzText) Width() int {
+	width := 0.0
+	for _, run := range h.runs {
+		width += run.advance
+	}
+	return int(math.Ceil(width))
+}
+
+// Draw draws the runs left to right; right-to-left runs are drawn from
+// their right edge as text/v2 expects
+func (h *harfBuzzText) Draw(target *ebiten.Image, x, y int, clr color.RGBA) {
+	left := float64(x)
+	for _, run := range h.runs {
+		op := &textv2.DrawOptions{}
+		start := left
+		if run.rtl {
+			start += run.advance
+		}
+		op.GeoM.Translate(start, float64(y))
+		op.ColorScale.ScaleWithColor(clr)
+		textv2.Draw(target, run.text, run.face, op)
+		left += run.advance
+	}
+}
@@ -0,0 +1,251 @@
+package components
+
+import (
+	"image/color"
+	"math"
+)
+
+// Slider picks a number in a range by dragging a thumb along a track
+type Slider struct {
+	*Node
+	value      float64
+	minValue   float64
+	maxValue   float64
+	step       float64 // Zero for any value
+	pressed    bool    // The thumb is being dragged
+	hovered    bool
+	focused    bool
+	thumbSize  int
+	onChange   func(float64)
+	trackColor color.RGBA
+	fillColor  color.RGBA
+	thumbColor color.RGBA
+	focusColor color.RGBA
+}
+
+// NewSlider creates a slider for a range, starting at its minimum
+func NewSlider(id string, min, max float64) *Slider {
+	s := &Slider{
+		Node:       NewNode(id),
+		thumbSize:  16,
+		trackColor: color.RGBA{210, 210, 210, 255},
+		fillColor:  color.RGBA{60, 120, 220, 255},
+		thumbColor: color.RGBA{255, 255, 255, 255},
+		focusColor: color.RGBA{60, 120, 220, 120},
+	}
+	s.SetRange(min, max)
+	s.SetCursorShape(CursorPointer)
+	return s
+}
+
+// SetValue sets the value, snapped to the step and clamped to the range
+func (s *Slider) SetValue(value float64) {
+	value = s.snap(value)
+	if value == s.value {
+		return
+	}
+	s.value = value
+	if s.onChange != nil {
+		s.onChange(value)
+	}
+}
+
+// Value returns the value
+func (s *Slider) Value() float64 {
+	return s.value
+}
+
+// SetRange sets the lowest and highest values, keeping the value within them
+func (s *Slider) SetRange(min, max float64) {
+	if max < min {
+		min, max = max, min
+	}
+	s.minValue, s.maxValue = min, max
+	s.value = s.snap(s.value)
+}
+
+// Min returns the lowest value
+func (s *Slider) Min() float64 {
+	return s.minValue
+}
+
+// Max returns the highest value
+func (s *Slider) Max() float64 {
+	return s.maxValue
+}
+
+// SetStep sets the increment values snap to, e.g. 1 for whole numbers;
+// zero allows any value
+func (s *Slider) SetStep(step float64) {
+	s.step = math.Max(0, step)
+	s.value = s.snap(s.value)
+}
+
+// Step returns the increment values snap to
+func (s *Slider) Step() float64 {
+	return s.step
+}
+
+// SetOnChange sets the handler called when the value changes
+func (s *Slider) SetOnChange(handler func(float64)) {
+	s.onChange = handler
+}
+
+// ApplyTheme colors the filled part of the track and the focus ring with
+// the accent
+func (s *Slider) ApplyTheme(theme Theme) {
+	s.fillColor = theme.Accent
+	s.trackColor = theme.Border
+	s.thumbColor = theme.Surface
+	s.focusColor = color.RGBA{theme.Accent.R, theme.Accent.G, theme.Accent.B, 120}
+}
+
+// IsDragging returns whether the thumb is being dragged
+func (s *Slider) IsDragging() bool {
+	return s.pressed
+}
+
+// snap rounds a value to the step and clamps it to the range
+func (s *Slider) snap(value float64) float64 {
+	if s.step > 0 {
+		value = s.minValue + math.Round((value-s.minValue)/s.step)*s.step
+	}
+	return math.Max(s.minValue, math.Min(value, s.maxValue))
+}
+
+// track returns the left end and length of the track the thumb's center
+// moves along
+func (s *Slider) track() (left, length int) {
+	bounds := s.ComputedBounds()
+	return bounds.X + s.thumbSize/2, max(1, bounds.Width-s.thumbSize)
+}
+
+// valueAt returns the value for a pointer position along the track
+func (s *Slider) valueAt(x int) float64 {
+	left, length := s.track()
+	t := math.Max(0, math.Min(float64(x-left)/float64(length), 1))
+	return s.minValue + t*(s.maxValue-s.minValue)
+}
+
+// thumbX returns the center of the thumb
+func (s *Slider) thumbX() int {
+	left, length := s.track()
+	if s.maxValue == s.minValue {
+		return left
+	}
+	return left + int(math.Round((s.value-s.minValue)/(s.maxValue-s.minValue)*float64(length)))
+}
+
+// Draw draws the track, filled up to the thumb, and the thumb
+func (s *Slider) Draw(surface DrawSurface) {
+	if !s.IsVisible() {
+		return
+	}
+	bounds := s.ComputedBounds()
+	left, length := s.track()
+	centerY := bounds.Y + bounds.Height/2
+	thumbX := s.thumbX()
+
+	surface.FillRect(left, centerY-2, length, 4, s.trackColor)
+	surface.FillRect(left, centerY-2, thumbX-left, 4, s.fillColor)
+
+	radius := s.thumbSize / 2
+	if s.focused {
+		surface.FillCircle(thumbX, centerY, radius+3, s.focusColor)
+	}
+	surface.FillCircle(thumbX, centerY, radius, s.thumbColor)
+	border := s.fillColor
+	if !s.hovered && !s.pressed {
+		border = s.trackColor
+	}
+	surface.DrawCircle(thumbX, centerY, radius, border)
+}
+
+// HandleMouseDown jumps the thumb to a press on the slider and drags it
+// while the button is held
+func (s *Slider) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !s.IsVisible() {
+		return
+	}
+	if !s.pressed {
+		if !PointInRect(Point{x, y}, s.ComputedBounds()) {
+			s.focused = false
+			return
+		}
+		s.pressed = true
+		s.focused = true
+	}
+	s.SetValue(s.valueAt(x))
+	e.Consume()
+}
+
+// HandleMouseUp ends a drag
+func (s *Slider) HandleMouseUp(e *Event) {
+	if !s.pressed {
+		return
+	}
+	s.pressed = false
+	e.Consume()
+}
+
+// HandleMouseMove drags the thumb while pressed and tracks hovering
+func (s *Slider) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !s.IsVisible() {
+		return
+	}
+	s.hovered = PointInRect(Point{x, y}, s.ComputedBounds())
+	if s.pressed {
+		s.SetValue(s.valueAt(x))
+		e.Consume()
+		return
+	}
+	if s.hovered {
+		e.Consume()
+	}
+}
+
+// Focus gives the slider keyboard focus
+func (s *Slider) Focus() {
+	s.focused = true
+}
+
+// Blur removes keyboard focus from the slider
+func (s *Slider) Blur() {
+	s.focused = false
+}
+
+// IsFocused returns whether the slider receives arrow keys
+func (s *Slider) IsFocused() bool {
+	return s.focused
+}
+
+// HandleKeyDown moves the value a step with the arrow keys, ten steps with
+// Page Up and Page Down, and to the ends with Home and End
+func (s *Slider) HandleKeyDown(event InputEvent) bool {
+	if !s.focused || event.Type != InputTypeKeyDown {
+		return false
+	}
+	step := s.step
+	if step == 0 {
+		step = (s.maxValue - s.minValue) / 100
+	}
+	switch event.Key {
+	case KeyRight, KeyUp:
+		s.SetValue(s.value + step)
+	case KeyLeft, KeyDown:
+		s.SetValue(s.value - step)
+	case KeyPageUp:
+		s.SetValue(s.value + 10*step)
+	case KeyPageDown:
+		s.SetValue(s.value - 10*step)
+	case KeyHome:
+		s.SetValue(s.minValue)
+	case KeyEnd:
+		s.SetValue(s.maxValue)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,325 @@
+package components
+
+import (
+	"image/color"
+)
+
+// TabPage represents a single tab and its content panel
+type TabPage struct {
+	Title     string
+	Content   Element
+	Closeable bool
+}
+
+// TabControl represents a set of tab headers with one visible content panel
+type TabControl struct {
+	*Node
+	pages           []*TabPage
+	selectedIndex   int
+	hoveredIndex    int
+	headerHeight    int
+	fontSize        int
+	onTabChanged    func(int)
+	onTabClosed     func(*TabPage)
+	headerColor     color.RGBA
+	selectedColor   color.RGBA
+	hoverColor      color.RGBA
+	textColor       color.RGBA
+	backgroundColor color.RGBA
+}
+
+// NewTabControl creates a new tab control
+func NewTabControl(id string) *TabControl {
+	return &TabControl{
+		Node:            NewNode(id),
+		pages:           make([]*TabPage, 0),
+		selectedIndex:   -1,
+		hoveredIndex:    -1,
+		headerHeight:    32,
+		fontSize:        14,
+		headerColor:     color.RGBA{220, 220, 220, 255},
+		selectedColor:   color.RGBA{255, 255, 255, 255},
+		hoverColor:      color.RGBA{235, 235, 235, 255},
+		textColor:       color.RGBA{0, 0, 0, 255},
+		backgroundColor: color.RGBA{255, 255, 255, 255},
+	}
+}
+
+// AddTab adds a tab with an empty column container as its content
+func (t *TabControl) AddTab(title string) *TabPage {
+	content := NewFlexContainer(NewID(t.ID() + "_panel"))
+	content.SetFlexDirection(FlexColumn)
+	return t.AddTabContent(title, content)
+}
+
+// AddTabContent adds a tab showing the given content element
+func (t *TabControl) AddTabContent(title string, content Element) *TabPage {
+	page := &TabPage{Title: title, Content: content}
+	t.pages = append(t.pages, page)
+	t.Node.AddChild(content)
+
+	// The first tab is selected automatically
+	if t.selectedIndex < 0 {
+		t.selectedIndex = 0
+	}
+
+	t.layoutPages()
+	return page
+}
+
+// RemoveTab removes the tab at the given index
+func (t *TabControl) RemoveTab(index int) {
+	if index < 0 || index >= len(t.pages) {
+		return
+	}
+
+	page := t.pages[index]
+	t.pages = append(t.pages[:index], t.pages[index+1:]...)
+	t.Node.RemoveChild(page.Content)
+
+	// Keep a valid selection, preferring the tab that took this one's place
+	previous := t.selectedIndex
+	if t.selectedIndex > index || t.selectedIndex >= len(t.pages) {
+		t.selectedIndex--
+	}
+	t.layoutPages()
+
+	if previous == index && t.selectedIndex >= 0 && t.onTabChanged != nil {
+		t.onTabChanged(t.selectedIndex)
+	}
+}
+
+// CloseTab removes the tab at the given index and notifies the close handler
+func (t *TabControl) CloseTab(index int) {
+	if index < 0 || index >= len(t.pages) {
+		return
+	}
+
+	page := t.pages[index]
+	t.RemoveTab(index)
+	if t.onTabClosed != nil {
+		t.onTabClosed(page)
+	}
+}
+
+// SelectTab selects the tab at the given index
+func (t *TabControl) SelectTab(index int) {
+	if index < 0 || index >= len(t.pages) || index == t.selectedIndex {
+		return
+	}
+
+	t.selectedIndex = index
+	t.layoutPages()
+
+	if t.onTabChanged != nil {
+		t.onTabChanged(index)
+	}
+}
+
+// GetSelectedIndex returns the selected tab index, or -1 if there are no tabs
+func (t *TabControl) GetSelectedIndex() int {
+	return t.selectedIndex
+}
+
+// GetSelectedTab returns the selected tab, or nil if there are no tabs
+func (t *TabControl) GetSelectedTab() *TabPage {
+	if t.selectedIndex < 0 {
+		return nil
+	}
+	return t.pages[t.selectedIndex]
+}
+
+// Tab returns the tab at the given index
+func (t *TabControl) Tab(index int) *TabPage {
+	if index < 0 || index >= len(t.pages) {
+		return nil
+	}
+	return t.pages[index]
+}
+
+// TabCount returns the number of tabs
+func (t *TabControl) TabCount() int {
+	return len(t.pages)
+}
+
+// SetTabCloseable sets whether the tab at the given index shows a close button
+func (t *TabControl) SetTabCloseable(index int, closeable bool) {
+	if page := t.Tab(index); page != nil {
+		page.Closeable = closeable
+	}
+}
+
+// SetOnTabChanged sets the handler for when the selected tab changes
+func (t *TabControl) SetOnTabChanged(handler func(int)) {
+	t.onTabChanged = handler
+}
+
+// SetOnTabClosed sets the handler for when a tab is closed by the user
+func (t *TabControl) SetOnTabClosed(handler func(*TabPage)) {
+	t.onTabClosed = handler
+}
+
+// SetHeaderHeight sets the height of the tab header strip
+func (t *TabControl) SetHeaderHeight(height int) {
+	t.headerHeight = height
+	t.layoutPages()
+}
+
+// SetBounds sets the bounds and resizes the content panels
+func (t *TabControl) SetBounds(bounds Rect) {
+	t.Node.SetBounds(bounds)
+	t.layoutPages()
+}
+
+// layoutPages sizes the content panels and shows only the selected one
+func (t *TabControl) layoutPages() {
+	bounds := t.Bounds()
+	contentHeight := bounds.Height - t.headerHeight
+	if contentHeight < 0 {
+		contentHeight = 0
+	}
+
+	for i, page := range t.pages {
+		page.Content.SetBounds(Rect{X: bounds.X, Y: bounds.Y + t.headerHeight, Width: bounds.Width, Height: contentHeight})
+		if node, ok := page.Content.(NodeElement); ok {
+			node.SetRelativePosition(Point{X: 0, Y: t.headerHeight})
+		}
+		if v, ok := page.Content.(interface{ SetVisible(bool) }); ok {
+			v.SetVisible(i == t.selectedIndex)
+		}
+	}
+}
+
+// headerRect returns the bounds of the tab header at the given index
+func (t *TabControl) headerRect(index int) Rect {
+	bounds := t.ComputedBounds()
+	x := bounds.X
+
+	for i, page := range t.pages {
+		width := len(page.Title)*t.fontSize/2 + 24
+		if page.Closeable {
+			width += 18
+		}
+		if width < 80 {
+			width = 80
+		}
+		if i == index {
+			return Rect{X: x, Y: bounds.Y, Width: width, Height: t.headerHeight}
+		}
+		x += width
+	}
+
+	return Rect{}
+}
+
+// closeRect returns the bounds of the close button on the tab header at the given index
+func (t *TabControl) closeRect(index int) Rect {
+	header := t.headerRect(index)
+	return Rect{X: header.X + header.Width - 20, Y: header.Y + (header.Height-12)/2, Width: 12, Height: 12}
+}
+
+// headerAt returns the index of the tab header at the given point, or -1
+func (t *TabControl) headerAt(x, y int) int {
+	for i := range t.pages {
+		if PointInRect(Point{x, y}, t.headerRect(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// CursorShapeAt returns the pointer hand over the tab headers
+func (t *TabControl) CursorShapeAt(x, y int) CursorShape {
+	if t.headerAt(x, y) >= 0 {
+		return CursorPointer
+	}
+	return t.Node.CursorShapeAt(x, y)
+}
+
+// Draw draws the tab headers and the selected content panel
+func (t *TabControl) Draw(surface DrawSurface) {
+	if !t.IsVisible() {
+		return
+	}
+
+	bounds := t.ComputedBounds()
+
+	// Draw header strip and content background
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, t.headerHeight, t.headerColor)
+	surface.FillRect(bounds.X, bounds.Y+t.headerHeight, bounds.Width, bounds.Height-t.headerHeight, t.backgroundColor)
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, color.RGBA{180, 180, 180, 255})
+
+	for i, page := range t.pages {
+		rect := t.headerRect(i)
+
+		bg := t.headerColor
+		if i == t.selectedIndex {
+			bg = t.selectedColor
+		} else if i == t.hoveredIndex {
+			bg = t.hoverColor
+		}
+		surface.FillRect(rect.X, rect.Y, rect.Width, rect.Height, bg)
+		surface.DrawRect(rect.X, rect.Y, rect.Width, rect.Height, color.RGBA{180, 180, 180, 255})
+
+		textY := rect.Y + (rect.Height-t.fontSize)/2
+		surface.DrawText(page.Title, rect.X+12, textY, t.textColor, t.fontSize)
+
+		if page.Closeable {
+			c := t.closeRect(i)
+			surface.DrawLine(c.X+2, c.Y+2, c.X+c.Width-2, c.Y+c.Height-2, t.textColor)
+			surface.DrawLine(c.X+c.Width-2, c.Y+2, c.X+2, c.Y+c.Height-2, t.textColor)
+		}
+	}
+
+	// Only the selected panel is drawn
+	if page := t.GetSelectedTab(); page != nil {
+		page.Content.Draw(surface)
+	}
+}
+
+// HandleMouseDown selects or closes tabs and forwards content clicks
+func (t *TabControl) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !t.IsVisible() {
+		return
+	}
+
+	if index := t.headerAt(x, y); index >= 0 {
+		if t.pages[index].Closeable && PointInRect(Point{x, y}, t.closeRect(index)) {
+			t.CloseTab(index)
+		} else {
+			t.SelectTab(index)
+		}
+		e.Consume()
+		return
+	}
+
+	if page := t.GetSelectedTab(); page != nil {
+		page.Content.HandleMouseDown(e)
+	}
+}
+
+// HandleMouseUp forwards mouse up events to the selected content panel
+func (t *TabControl) HandleMouseUp(e *Event) {
+	if page := t.GetSelectedTab(); page != nil && t.IsVisible() {
+		page.Content.HandleMouseUp(e)
+	}
+}
+
+// HandleMouseMove tracks header hover and forwards events to the selected content panel
+func (t *TabControl) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !t.IsVisible() {
+		return
+	}
+
+	t.hoveredIndex = t.headerAt(x, y)
+	if t.hoveredIndex >= 0 {
+		e.Consume()
+		return
+	}
+
+	if page := t.GetSelectedTab(); page != nil {
+		page.Content.HandleMouseMove(e)
+	}
+}
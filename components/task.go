@@ -0,0 +1,255 @@
+package components
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// TaskState is where a task is in its life
+type TaskState int
+
+const (
+	TaskRunning TaskState = iota
+	TaskSucceeded
+	TaskFailed
+	TaskCanceled
+)
+
+// Task is work running on its own goroutine. Progress and completion are
+// delivered on the UI goroutine through RunFrameCallbacks, so handlers can
+// touch elements directly.
+type Task struct {
+	title  string
+	cancel context.CancelFunc
+
+	// Written by the task's goroutine
+	mu              sync.Mutex
+	pending         float64
+	progressPending bool
+
+	// Owned by the UI goroutine
+	state      TaskState
+	progress   float64 // 0 to 1, or negative when unknown
+	err        error
+	onProgress func(float64)
+	onDone     func(error)
+	observers  []func(*Task)
+}
+
+// StartTask runs fn on a new goroutine. fn should stop when ctx is
+// canceled and may call report with its progress from 0 to 1.
+func StartTask(title string, fn func(ctx context.Context, report func(progress float64)) error) *Task {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &Task{title: title, cancel: cancel, progress: -1}
+	go func() {
+		err := fn(ctx, t.report)
+		cancel()
+		scheduleFrameCallback(&taskDone{task: t, err: err})
+	}()
+	return t
+}
+
+// report records progress from the task's goroutine, delivering only the
+// latest value when several arrive within a frame
+func (t *Task) report(progress float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = progress
+	if !t.progressPending {
+		t.progressPending = true
+		scheduleFrameCallback(&taskProgress{task: t})
+	}
+}
+
+// Title returns the name the task was started with
+func (t *Task) Title() string {
+	return t.title
+}
+
+// Cancel asks the task to stop by canceling its context
+func (t *Task) Cancel() {
+	t.cancel()
+}
+
+// State returns whether the task is running or how it ended
+func (t *Task) State() TaskState {
+	return t.state
+}
+
+// IsDone returns whether the task has ended
+func (t *Task) IsDone() bool {
+	return t.state != TaskRunning
+}
+
+// Progress returns the last reported progress, or a negative value if none
+// was reported
+func (t *Task) Progress() float64 {
+	return t.progress
+}
+
+// Err returns the error the task ended with
+func (t *Task) Err() error {
+	return t.err
+}
+
+// SetOnProgress sets the handler called with each progress report
+func (t *Task) SetOnProgress(handler func(float64)) {
+	t.onProgress = handler
+}
+
+// SetOnDone sets the handler called when the task ends, with its error; a
+// canceled task gets context.Canceled
+func (t *Task) SetOnDone(handler func(error)) {
+	t.onDone = handler
+	if t.IsDone() && handler != nil {
+		handler(t.err)
+	}
+}
+
+// observe adds a watcher called after every progress report and on
+// completion, for helpers such as task toasts
+func (t *Task) observe(observer func(*Task)) {
+	t.observers = append(t.observers, observer)
+}
+
+// notify calls the observers
+func (t *Task) notify() {
+	for _, observer := range t.observers {
+		observer(t)
+	}
+}
+
+// taskProgress delivers a task's latest progress
+type taskProgress struct {
+	task *Task
+}
+
+// due passes the progress to the handlers
+func (p *taskProgress) due(now time.Time) bool {
+	t := p.task
+	t.mu.Lock()
+	progress := t.pending
+	t.progressPending = false
+	t.mu.Unlock()
+
+	if t.IsDone() {
+		return true
+	}
+	t.progress = progress
+	if t.onProgress != nil {
+		t.onProgress(progress)
+	}
+	t.notify()
+	return true
+}
+
+// taskDone delivers a task's result
+type taskDone struct {
+	task *Task
+	err  error
+}
+
+// due records the result and calls the handlers
+func (d *taskDone) due(now time.Time) bool {
+	t := d.task
+	t.err = d.err
+	switch {
+	case d.err == nil:
+		t.state = TaskSucceeded
+		t.progress = 1
+	case errors.Is(d.err, context.Canceled):
+		t.state = TaskCanceled
+	default:
+		t.state = TaskFailed
+	}
+	if t.onDone != nil {
+		t.onDone(t.err)
+	}
+	t.notify()
+	return true
+}
+
+// TaskToast is a toast that follows a task: a progress bar and a Cancel
+// button while it runs, then a success or failure message
+type TaskToast struct {
+	toast    *Toast
+	success  string
+	failure  string
+	action   *ToastAction
+	duration time.Duration
+}
+
+// TrackTask shows a progress toast for a task and updates it as the task
+// runs and ends
+func (c *ToastCenter) TrackTask(task *Task) *TaskToast {
+	tt := &TaskToast{
+		toast:    c.Show(task.Title(), ToastProgress),
+		success:  task.Title() + " finished",
+		failure:  task.Title() + " failed",
+		duration: 6 * time.Second,
+	}
+	tt.toast.SetActions(ToastAction{Label: "Cancel", Handler: task.Cancel})
+	tt.toast.SetProgress(task.Progress())
+	task.observe(tt.update)
+	if task.IsDone() {
+		tt.update(task)
+	}
+	return tt
+}
+
+// SetSuccessMessage sets the message shown when the task succeeds
+func (tt *TaskToast) SetSuccessMessage(message string) {
+	tt.success = message
+}
+
+// SetFailureMessage sets the message shown before the error when the task
+// fails
+func (tt *TaskToast) SetFailureMessage(message string) {
+	tt.failure = message
+}
+
+// SetResultAction sets a button shown when the task succeeds, such as
+// "Open result"
+func (tt *TaskToast) SetResultAction(label string, handler func()) {
+	tt.action = &ToastAction{Label: label, Handler: handler}
+}
+
+// SetDuration sets how long the result stays up; zero keeps it until
+// dismissed
+func (tt *TaskToast) SetDuration(duration time.Duration) {
+	tt.duration = duration
+}
+
+// Toast returns the toast, e.g. to dismiss it early
+func (tt *TaskToast) Toast() *Toast {
+	return tt.toast
+}
+
+// update reflects the task's progress or result in the toast
+func (tt *TaskToast) update(task *Task) {
+	toast := tt.toast
+	switch task.State() {
+	case TaskRunning:
+		toast.SetProgress(task.Progress())
+		return
+	case TaskSucceeded:
+		toast.SetKind(ToastSuccess)
+		toast.SetMessage(tt.success)
+		if tt.action != nil {
+			toast.SetActions(*tt.action)
+		} else {
+			toast.SetActions()
+		}
+	case TaskFailed:
+		toast.SetKind(ToastError)
+		toast.SetMessage(tt.failure + ": " + task.Err().Error())
+		toast.SetActions()
+	case TaskCanceled:
+		toast.SetKind(ToastInfo)
+		toast.SetMessage(task.Title() + " canceled")
+		toast.SetActions()
+	}
+	toast.SetDuration(tt.duration)
+}
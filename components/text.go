@@ -48,6 +48,16 @@ func (t *Text) SetTextColor(color color.RGBA) {
 	t.textColor = color
 }
 
+// TextColor returns the text color
+func (t *Text) TextColor() color.RGBA {
+	return t.textColor
+}
+
+// FontSize returns the font size
+func (t *Text) FontSize() int {
+	return t.fontSize
+}
+
 // SetBold sets whether the text is bold
 func (t *Text) SetBold(bold bool) {
 	t.bold = bold
@@ -91,27 +101,25 @@ func (t *Text) Draw(surface DrawSurface) {
 }
 
 // HandleMouseDown handles mouse down events
-func (t *Text) HandleMouseDown(x, y int) bool {
+func (t *Text) HandleMouseDown(e *Event) {
 	// Text doesn't handle mouse events directly, but we check children
 	for i := len(t.Children()) - 1; i >= 0; i-- {
 		child := t.Children()[i]
-		if child.HandleMouseDown(x, y) {
-			return true
+		if child.HandleMouseDown(e); e.Consumed() {
+			return
 		}
 	}
-	return false
 }
 
 // HandleMouseMove handles mouse move events
-func (t *Text) HandleMouseMove(x, y int) bool {
+func (t *Text) HandleMouseMove(e *Event) {
 	// Text doesn't handle mouse events directly, but we check children
 	for i := len(t.Children()) - 1; i >= 0; i-- {
 		child := t.Children()[i]
-		if child.HandleMouseMove(x, y) {
-			return true
+		if child.HandleMouseMove(e); e.Consumed() {
+			return
 		}
 	}
-	return false
 }
 
 // Label represents a label element in the UI
@@ -123,6 +131,8 @@ type Label struct {
 	bold      bool
 	italic    bool
 	alignment TextAlignment
+	value     interface{} // Value shown through format, if set
+	format    ValueFormat
 }
 
 // NewLabel creates a new label
@@ -141,6 +151,26 @@ func NewLabel(id string, text string, fontSize int, textColor color.RGBA) *Label
 // SetText sets the label text
 func (l *Label) SetText(text string) {
 	l.text = text
+	l.format = nil
+}
+
+// SetValue shows a value as formatted text, such as a number or date with
+// NumberFormat or DateFormat. The text is formatted again each time the
+// label is drawn, so it follows changes to the locale.
+func (l *Label) SetValue(value interface{}, format ValueFormat) {
+	if format == nil {
+		format = func(value interface{}) string {
+			return CurrentLocale().FormatValue(value)
+		}
+	}
+	l.text = format(value)
+	l.value = value
+	l.format = format
+}
+
+// Value returns the value set with SetValue
+func (l *Label) Value() interface{} {
+	return l.value
 }
 
 // GetText returns the label text
@@ -158,6 +188,16 @@ func (l *Label) SetTextColor(color color.RGBA) {
 	l.textColor = color
 }
 
+// TextColor returns the text color
+func (l *Label) TextColor() color.RGBA {
+	return l.textColor
+}
+
+// FontSize returns the font size
+func (l *Label) FontSize() int {
+	return l.fontSize
+}
+
 // SetBold sets whether the text is bold
 func (l *Label) SetBold(bold bool) {
 	l.bold = bold
@@ -185,6 +225,9 @@ func (l *Label) Draw(surface DrawSurface) {
 	}
 	
 	bounds := l.ComputedBounds()
+	if l.format != nil {
+		l.text = l.format(l.value)
+	}
 	
 	// Calculate text position based on alignment
 	textWidth := len(l.text) * l.fontSize / 2
@@ -209,25 +252,23 @@ func (l *Label) Draw(surface DrawSurface) {
 }
 
 // HandleMouseDown handles mouse down events
-func (l *Label) HandleMouseDown(x, y int) bool {
+func (l *Label) HandleMouseDown(e *Event) {
 	// Label doesn't handle mouse events directly, but we check children
 	for i := len(l.Children()) - 1; i >= 0; i-- {
 		child := l.Children()[i]
-		if child.HandleMouseDown(x, y) {
-			return true
+		if child.HandleMouseDown(e); e.Consumed() {
+			return
 		}
 	}
-	return false
 }
 
 // HandleMouseMove handles mouse move events
-func (l *Label) HandleMouseMove(x, y int) bool {
+func (l *Label) HandleMouseMove(e *Event) {
 	// Label doesn't handle mouse events directly, but we check children
 	for i := len(l.Children()) - 1; i >= 0; i-- {
 		child := l.Children()[i]
-		if child.HandleMouseMove(x, y) {
-			return true
+		if child.HandleMouseMove(e); e.Consumed() {
+			return
 		}
 	}
-	return false
 } 
\ No newline at end of file
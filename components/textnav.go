@@ -0,0 +1,59 @@
+package components
+
+import "unicode"
+
+// Helpers for moving a cursor through text. Positions are rune indices.
+
+// isWordRune returns whether the rune is part of a word for Ctrl+arrow movement
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// wordLeft returns the start of the word before pos, skipping any separators first
+func wordLeft(runes []rune, pos int) int {
+	for pos > 0 && !isWordRune(runes[pos-1]) {
+		pos--
+	}
+	for pos > 0 && isWordRune(runes[pos-1]) {
+		pos--
+	}
+	return pos
+}
+
+// wordRight returns the end of the word after pos, skipping any separators first
+func wordRight(runes []rune, pos int) int {
+	for pos < len(runes) && !isWordRune(runes[pos]) {
+		pos++
+	}
+	for pos < len(runes) && isWordRune(runes[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// lineStart returns the position of the first rune on pos's line
+func lineStart(runes []rune, pos int) int {
+	for pos > 0 && runes[pos-1] != '\n' {
+		pos--
+	}
+	return pos
+}
+
+// lineEnd returns the position just before the newline ending pos's line
+func lineEnd(runes []rune, pos int) int {
+	for pos < len(runes) && runes[pos] != '\n' {
+		pos++
+	}
+	return pos
+}
+
+// lineColumn returns the zero-based line and column of pos
+func lineColumn(runes []rune, pos int) (int, int) {
+	line := 0
+	for i := 0; i < pos; i++ {
+		if runes[i] == '\n' {
+			line++
+		}
+	}
+	return line, pos - lineStart(runes, pos)
+}
@@ -0,0 +1,83 @@
+package components
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"sync"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Text scales offered to low-vision users, as in OS accessibility settings
+const (
+	TextScaleNormal  = 1.0
+	TextScaleLarge   = 1.25
+	TextScaleLarger  = 1.5
+	TextScaleLargest = 2.0
+)
+
+var (
+	textScaleMu sync.RWMutex
+	textScale   = TextScaleNormal
+
+	// textScratch is where text is drawn before it is scaled onto the target
+	textScratch *ebiten.Image
+)
+
+// SetTextScale sets how much larger than their font size all text is drawn
+// and measured, e.g. TextScaleLarger for 150%. Line and row heights grow
+// with it; fixed element sizes set before the change don't.
+func SetTextScale(scale float64) {
+	if scale <= 0 {
+		scale = TextScaleNormal
+	}
+	textScaleMu.Lock()
+	defer textScaleMu.Unlock()
+	textScale = scale
+}
+
+// TextScale returns the UI-wide text scale factor
+func TextScale() float64 {
+	textScaleMu.RLock()
+	defer textScaleMu.RUnlock()
+	return textScale
+}
+
+// ScaleTextSize returns a text size or text-based length, in pixels, at the
+// current text scale
+func ScaleTextSize(size int) int {
+	return int(math.Round(float64(size) * TextScale()))
+}
+
+// drawScaledText draws shaped text enlarged by the text scale, through a
+// scratch image so every shaper and font is scaled the same way
+func drawScaledText(target *ebiten.Image, shaped ShapedText, x, y int, clr color.RGBA, scale float64) {
+	primary := CurrentFontChain().Primary()
+	width := shaped.Width()
+	height := primary.Metrics().Height.Ceil() + primary.Metrics().Descent.Ceil()
+	if width <= 0 || height <= 0 {
+		return
+	}
+
+	if textScratch == nil || textScratch.Bounds().Dx() < width || textScratch.Bounds().Dy() < height {
+		w, h := width, height
+		if textScratch != nil {
+			w = max(w, textScratch.Bounds().Dx())
+			h = max(h, textScratch.Bounds().Dy())
+		}
+		textScratch = ebiten.NewImage(w, h)
+	}
+	region := textScratch.SubImage(image.Rect(0, 0, width, height)).(*ebiten.Image)
+	region.Clear()
+	shaped.Draw(region, 0, 0, clr)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(scale, scale)
+	op.GeoM.Translate(float64(x), float64(y))
+	// Whole-number scales keep bitmap fonts crisp
+	if scale != math.Trunc(scale) {
+		op.Filter = ebiten.FilterLinear
+	}
+	target.DrawImage(region, op)
+}
@@ -0,0 +1,104 @@
+package components
+
+import (
+	"image"
+	"math"
+	"reflect"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// textures holds GPU copies of images drawn by the renderers, keyed by the
+// source image. Images are treated as immutable once drawn; call
+// InvalidateImage after changing an image's pixels.
+var textures = NewImageCache(128 << 20)
+
+// textureFor returns an ebiten.Image for an image, converting and caching it
+// on first use
+func textureFor(img image.Image) *ebiten.Image {
+	if eImg, ok := img.(*ebiten.Image); ok {
+		return eImg
+	}
+	// Images of types that can't be map keys are converted every time
+	if !reflect.TypeOf(img).Comparable() {
+		return ebiten.NewImageFromImage(img)
+	}
+	if texture, ok := textures.Get(img); ok {
+		return texture.(*ebiten.Image)
+	}
+	texture := ebiten.NewImageFromImage(img)
+	textures.Put(img, texture)
+	return texture
+}
+
+// InvalidateImage drops the cached texture for an image whose pixels changed
+func InvalidateImage(img image.Image) {
+	if img != nil && reflect.TypeOf(img).Comparable() {
+		textures.Remove(img)
+	}
+}
+
+// fitImage returns the transform that places an image of the given size in a
+// box according to the fit method
+func fitImage(imgWidth, imgHeight, x, y, width, height int, fitMethod ImageFitMethod) ebiten.GeoM {
+	var geo ebiten.GeoM
+	if imgWidth == 0 || imgHeight == 0 {
+		return geo
+	}
+	scaleX := float64(width) / float64(imgWidth)
+	scaleY := float64(height) / float64(imgHeight)
+
+	switch fitMethod {
+	case ImageFitFill:
+		geo.Scale(scaleX, scaleY)
+		geo.Translate(float64(x), float64(y))
+		return geo
+	case ImageFitCover:
+		scaleX = math.Max(scaleX, scaleY)
+	default:
+		scaleX = math.Min(scaleX, scaleY)
+	}
+
+	// Centre the scaled image in the box
+	scaledWidth := float64(imgWidth) * scaleX
+	scaledHeight := float64(imgHeight) * scaleX
+	geo.Scale(scaleX, scaleX)
+	geo.Translate(float64(x)+(float64(width)-scaledWidth)/2, float64(y)+(float64(height)-scaledHeight)/2)
+	return geo
+}
+
+// drawImageFitted draws an image into a box on the target, clipped to both
+// the box and the clip rect, so covered images don't spill out
+func drawImageFitted(target *ebiten.Image, img image.Image, x, y, width, height int, fitMethod ImageFitMethod, clip Rect) {
+	if width <= 0 || height <= 0 {
+		return
+	}
+	visible := image.Rect(x, y, x+width, y+height).
+		Intersect(image.Rect(clip.X, clip.Y, clip.X+clip.Width, clip.Y+clip.Height)).
+		Intersect(target.Bounds())
+	if visible.Empty() {
+		return
+	}
+
+	clipped := target.SubImage(visible).(*ebiten.Image)
+	if patch, ok := img.(*NinePatch); ok {
+		drawNinePatch(clipped, patch, x, y, width, height)
+		return
+	}
+	if fitMethod == ImageFitNinePatch {
+		// Plain images get borders of a third of their size
+		size := img.Bounds().Size()
+		drawNinePatch(clipped, NewNinePatch(img, size.X/3, size.Y/3, size.X/3, size.Y/3), x, y, width, height)
+		return
+	}
+
+	texture := textureFor(img)
+	size := texture.Bounds().Size()
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM = fitImage(size.X, size.Y, x, y, width, height, fitMethod)
+	if size.X != width || size.Y != height {
+		op.Filter = ebiten.FilterLinear
+	}
+	// Drawing to a sub-image clips to it while keeping target coordinates
+	clipped.DrawImage(texture, op)
+}
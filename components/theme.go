@@ -0,0 +1,315 @@
+package components
+
+import (
+	"image/color"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ColorScheme is a light or dark appearance preference
+type ColorScheme int
+
+const (
+	ColorSchemeLight ColorScheme = iota
+	ColorSchemeDark
+)
+
+// String returns "light" or "dark"
+func (s ColorScheme) String() string {
+	if s == ColorSchemeDark {
+		return "dark"
+	}
+	return "light"
+}
+
+// Theme is a named set of colors for the UI
+type Theme struct {
+	Name       string
+	Scheme     ColorScheme
+	Background color.RGBA
+	Surface    color.RGBA
+	Text       color.RGBA
+	MutedText  color.RGBA
+	Accent     color.RGBA
+	Border     color.RGBA
+	Error      color.RGBA // Validation messages and destructive actions
+	Padding    int        // Default inner spacing of cards and panels
+	Fonts      *FontChain // Font fallback order; nil keeps the current chain
+
+	// Image-based skins; nil draws the flat colors above
+	ButtonSkin *ButtonSkin
+	PanelSkin  *NinePatch // Background of cards and panels
+}
+
+// LightTheme returns the default light theme
+func LightTheme() Theme {
+	return Theme{
+		Name:       "light",
+		Scheme:     ColorSchemeLight,
+		Background: color.RGBA{240, 240, 240, 255},
+		Surface:    color.RGBA{255, 255, 255, 255},
+		Text:       color.RGBA{0, 0, 0, 255},
+		MutedText:  color.RGBA{120, 120, 120, 255},
+		Accent:     color.RGBA{70, 130, 180, 255},
+		Border:     color.RGBA{180, 180, 180, 255},
+		Error:      color.RGBA{198, 40, 40, 255},
+		Padding:    12,
+	}
+}
+
+// DarkTheme returns the default dark theme
+func DarkTheme() Theme {
+	return Theme{
+		Name:       "dark",
+		Scheme:     ColorSchemeDark,
+		Background: color.RGBA{32, 33, 36, 255},
+		Surface:    color.RGBA{45, 46, 50, 255},
+		Text:       color.RGBA{232, 234, 237, 255},
+		MutedText:  color.RGBA{154, 160, 166, 255},
+		Accent:     color.RGBA{138, 180, 248, 255},
+		Border:     color.RGBA{80, 82, 88, 255},
+		Error:      color.RGBA{242, 139, 130, 255},
+		Padding:    12,
+	}
+}
+
+// HighContrastTheme returns a theme with white text on black and bright
+// accents, for low-vision users
+func HighContrastTheme() Theme {
+	return Theme{
+		Name:       "high-contrast",
+		Scheme:     ColorSchemeDark,
+		Background: color.RGBA{0, 0, 0, 255},
+		Surface:    color.RGBA{0, 0, 0, 255},
+		Text:       color.RGBA{255, 255, 255, 255},
+		MutedText:  color.RGBA{255, 255, 255, 255},
+		Accent:     color.RGBA{255, 255, 0, 255},
+		Border:     color.RGBA{255, 255, 255, 255},
+		Error:      color.RGBA{255, 110, 110, 255},
+		Padding:    12,
+	}
+}
+
+// ColorSchemeDetector reports the OS appearance preference, or false if it is unknown
+type ColorSchemeDetector func() (ColorScheme, bool)
+
+// DetectSystemColorScheme asks the OS whether dark mode is on. It checks the
+// Windows personalization setting, the macOS interface style, or the GNOME
+// color scheme and GTK_THEME on other systems.
+func DetectSystemColorScheme() (ColorScheme, bool) {
+	switch runtime.GOOS {
+	case "windows":
+		out, err := exec.Command("reg", "query",
+			`HKCU\Software\Microsoft\Windows\CurrentVersion\Themes\Personalize`,
+			"/v", "AppsUseLightTheme").Output()
+		if err != nil {
+			return ColorSchemeLight, false
+		}
+		if strings.Contains(string(out), "0x0") {
+			return ColorSchemeDark, true
+		}
+		return ColorSchemeLight, true
+	case "darwin":
+		// The key only exists while dark mode is on
+		out, err := exec.Command("defaults", "read", "-g", "AppleInterfaceStyle").Output()
+		if err == nil && strings.Contains(strings.ToLower(string(out)), "dark") {
+			return ColorSchemeDark, true
+		}
+		return ColorSchemeLight, true
+	default:
+		if out, err := exec.Command("gsettings", "get", "org.gnome.desktop.interface", "color-scheme").Output(); err == nil {
+			if strings.Contains(string(out), "dark") {
+				return ColorSchemeDark, true
+			}
+			return ColorSchemeLight, true
+		}
+		if gtk := os.Getenv("GTK_THEME"); gtk != "" {
+			if strings.HasSuffix(strings.ToLower(gtk), ":dark") {
+				return ColorSchemeDark, true
+			}
+			return ColorSchemeLight, true
+		}
+		return ColorSchemeLight, false
+	}
+}
+
+// ThemeManager selects the light or dark theme to match the OS preference
+// unless the app overrides it, and reports theme changes
+type ThemeManager struct {
+	mu        sync.Mutex
+	light     Theme
+	dark      Theme
+	override  *Theme
+	scheme    ColorScheme // Last detected OS preference
+	pending   ColorScheme // Detected by the watcher, applied by Poll
+	current   Theme
+	detector  ColorSchemeDetector
+	onChanged []func(Theme)
+	stop      chan struct{}
+}
+
+// NewThemeManager creates a theme manager and detects the OS preference
+func NewThemeManager() *ThemeManager {
+	m := &ThemeManager{
+		light:    LightTheme(),
+		dark:     DarkTheme(),
+		detector: DetectSystemColorScheme,
+	}
+	if scheme, ok := m.detector(); ok {
+		m.scheme = scheme
+	}
+	m.pending = m.scheme
+	m.current = m.resolve()
+	return m
+}
+
+// resolve returns the theme that should be active
+func (m *ThemeManager) resolve() Theme {
+	if m.override != nil {
+		return *m.override
+	}
+	if m.scheme == ColorSchemeDark {
+		return m.dark
+	}
+	return m.light
+}
+
+// update switches to the resolved theme and notifies handlers if it changed
+func (m *ThemeManager) update() {
+	theme := m.resolve()
+	if theme == m.current {
+		return
+	}
+	m.current = theme
+	for _, handler := range m.onChanged {
+		handler(theme)
+	}
+}
+
+// Current returns the active theme
+func (m *ThemeManager) Current() Theme {
+	return m.current
+}
+
+// SystemScheme returns the last detected OS preference
+func (m *ThemeManager) SystemScheme() ColorScheme {
+	return m.scheme
+}
+
+// SetThemes sets the themes used for the light and dark OS preferences
+func (m *ThemeManager) SetThemes(light, dark Theme) {
+	m.light = light
+	m.dark = dark
+	m.update()
+}
+
+// Themes returns the themes used for the light and dark OS preferences
+func (m *ThemeManager) Themes() (light, dark Theme) {
+	return m.light, m.dark
+}
+
+// Override uses the given theme regardless of the OS preference
+func (m *ThemeManager) Override(theme Theme) {
+	m.override = &theme
+	m.update()
+}
+
+// ClearOverride goes back to following the OS preference
+func (m *ThemeManager) ClearOverride() {
+	m.override = nil
+	m.update()
+}
+
+// IsOverridden returns whether the app has fixed the theme
+func (m *ThemeManager) IsOverridden() bool {
+	return m.override != nil
+}
+
+// SetDetector replaces how the OS preference is detected, e.g. with a
+// platform-specific hook; nil restores the default
+func (m *ThemeManager) SetDetector(detector ColorSchemeDetector) {
+	if detector == nil {
+		detector = DetectSystemColorScheme
+	}
+	m.mu.Lock()
+	m.detector = detector
+	m.mu.Unlock()
+	m.Refresh()
+}
+
+// OnThemeChanged adds a handler called when the active theme changes
+func (m *ThemeManager) OnThemeChanged(handler func(Theme)) {
+	m.onChanged = append(m.onChanged, handler)
+}
+
+// Refresh detects the OS preference now and applies it
+func (m *ThemeManager) Refresh() {
+	m.mu.Lock()
+	detector := m.detector
+	m.mu.Unlock()
+
+	if scheme, ok := detector(); ok {
+		m.mu.Lock()
+		m.pending = scheme
+		m.mu.Unlock()
+	}
+	m.Poll()
+}
+
+// Poll applies a preference change found by the watcher. Call it from the UI
+// thread, e.g. once per frame; handlers run on the calling goroutine.
+func (m *ThemeManager) Poll() {
+	m.mu.Lock()
+	scheme := m.pending
+	m.mu.Unlock()
+
+	if scheme != m.scheme {
+		m.scheme = scheme
+		m.update()
+	}
+}
+
+// StartWatching checks the OS preference in the background at the given
+// interval; changes are applied on the next Poll
+func (m *ThemeManager) StartWatching(interval time.Duration) {
+	m.StopWatching()
+
+	stop := make(chan struct{})
+	m.mu.Lock()
+	m.stop = stop
+	m.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				m.mu.Lock()
+				detector := m.detector
+				m.mu.Unlock()
+				if scheme, ok := detector(); ok {
+					m.mu.Lock()
+					m.pending = scheme
+					m.mu.Unlock()
+				}
+			}
+		}
+	}()
+}
+
+// StopWatching stops the background check
+func (m *ThemeManager) StopWatching() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stop != nil {
+		close(m.stop)
+		m.stop = nil
+	}
+}
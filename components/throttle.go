@@ -0,0 +1,250 @@
+package components
+
+import (
+	"sync"
+	"time"
+)
+
+// frameCallback is deferred work checked once per frame
+type frameCallback interface {
+	// due runs the callback if its time has come and reports whether it is finished
+	due(now time.Time) bool
+}
+
+var (
+	frameMu        sync.Mutex
+	frameCallbacks []frameCallback
+)
+
+// scheduleFrameCallback queues work to be checked on each frame
+func scheduleFrameCallback(callback frameCallback) {
+	frameMu.Lock()
+	defer frameMu.Unlock()
+	frameCallbacks = append(frameCallbacks, callback)
+}
+
+// RunFrameCallbacks runs debounced and throttled calls that have come due.
+// The app calls it once per frame from its update loop, so callbacks run on
+// the UI goroutine; tests can call it after advancing a ManualClock.
+//
+// If a callback panics, the others still run and stay scheduled, and the
+// first panic is raised again once they have.
+func RunFrameCallbacks() {
+	frameMu.Lock()
+	callbacks := frameCallbacks
+	frameCallbacks = nil
+	frameMu.Unlock()
+
+	now := Now()
+	var remaining []frameCallback
+	var panicked interface{}
+	for _, callback := range callbacks {
+		finished, r := runFrameCallback(callback, now)
+		if !finished {
+			remaining = append(remaining, callback)
+		}
+		if r != nil && panicked == nil {
+			panicked = r
+		}
+	}
+
+	if len(remaining) > 0 {
+		frameMu.Lock()
+		frameCallbacks = append(remaining, frameCallbacks...)
+		frameMu.Unlock()
+	}
+	if panicked != nil {
+		panic(panicked)
+	}
+}
+
+// runFrameCallback runs a callback, recovering a panic in it. A callback
+// that panics is finished, unless it's a repeating timer, which runs again
+// at its next interval.
+func runFrameCallback(callback frameCallback, now time.Time) (finished bool, panicked interface{}) {
+	defer func() {
+		if r := recover(); r != nil {
+			timer, ok := callback.(*Timer)
+			finished = !ok || !timer.Active()
+			panicked = r
+		}
+	}()
+	return callback.due(now), nil
+}
+
+// dispatched is a function queued to run on the UI goroutine
+type dispatched func()
+
+// due runs the function
+func (d dispatched) due(now time.Time) bool {
+	d()
+	return true
+}
+
+// Dispatch queues fn to run on the UI goroutine on the next frame. It is
+// safe to call from any goroutine, so background work can hand results to
+// elements without racing with Update and Draw.
+func Dispatch(fn func()) {
+	scheduleFrameCallback(dispatched(fn))
+}
+
+// Debouncer delays calls until no new call has arrived for the wait time,
+// then calls the function once with the latest value
+type Debouncer[T any] struct {
+	mu        sync.Mutex
+	fn        func(T)
+	wait      time.Duration
+	value     T
+	deadline  time.Time
+	scheduled bool
+}
+
+// NewDebouncer creates a debouncer for a function
+func NewDebouncer[T any](wait time.Duration, fn func(T)) *Debouncer[T] {
+	return &Debouncer[T]{fn: fn, wait: wait}
+}
+
+// Debounce returns a function that calls fn with the latest value once calls
+// have stopped for the wait time, e.g. to search after typing pauses
+func Debounce[T any](wait time.Duration, fn func(T)) func(T) {
+	return NewDebouncer(wait, fn).Call
+}
+
+// Call records a value and restarts the wait
+func (d *Debouncer[T]) Call(value T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.value = value
+	d.deadline = Now().Add(d.wait)
+	if !d.scheduled {
+		d.scheduled = true
+		scheduleFrameCallback(d)
+	}
+}
+
+// Pending returns whether a call is waiting to run
+func (d *Debouncer[T]) Pending() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.scheduled
+}
+
+// Flush runs a waiting call now
+func (d *Debouncer[T]) Flush() {
+	d.mu.Lock()
+	if !d.scheduled {
+		d.mu.Unlock()
+		return
+	}
+	d.deadline = time.Time{}
+	d.mu.Unlock()
+	d.due(Now())
+}
+
+// Cancel drops a waiting call
+func (d *Debouncer[T]) Cancel() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var zero T
+	d.value = zero
+	d.deadline = time.Time{}
+	d.scheduled = false
+}
+
+// due calls the function once the wait has passed
+func (d *Debouncer[T]) due(now time.Time) bool {
+	d.mu.Lock()
+	if !d.scheduled {
+		d.mu.Unlock()
+		return true
+	}
+	if now.Before(d.deadline) {
+		d.mu.Unlock()
+		return false
+	}
+	value := d.value
+	d.scheduled = false
+	d.mu.Unlock()
+
+	d.fn(value)
+	return true
+}
+
+// Throttler calls a function at most once per interval. The first call runs
+// immediately; later calls within the interval are collapsed into one
+// trailing call with the latest value.
+type Throttler[T any] struct {
+	mu        sync.Mutex
+	fn        func(T)
+	interval  time.Duration
+	value     T
+	last      time.Time
+	scheduled bool
+}
+
+// NewThrottler creates a throttler for a function
+func NewThrottler[T any](interval time.Duration, fn func(T)) *Throttler[T] {
+	return &Throttler[T]{fn: fn, interval: interval}
+}
+
+// Throttle returns a function that calls fn at most once per interval, e.g.
+// to recompute layout while a scroll or resize is in progress
+func Throttle[T any](interval time.Duration, fn func(T)) func(T) {
+	return NewThrottler(interval, fn).Call
+}
+
+// Call runs the function now if the interval has passed, otherwise records
+// the value for a trailing call
+func (t *Throttler[T]) Call(value T) {
+	t.mu.Lock()
+	now := Now()
+	if !t.scheduled && (t.last.IsZero() || now.Sub(t.last) >= t.interval) {
+		t.last = now
+		t.mu.Unlock()
+		t.fn(value)
+		return
+	}
+
+	t.value = value
+	if !t.scheduled {
+		t.scheduled = true
+		scheduleFrameCallback(t)
+	}
+	t.mu.Unlock()
+}
+
+// Pending returns whether a trailing call is waiting to run
+func (t *Throttler[T]) Pending() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.scheduled
+}
+
+// Cancel drops a waiting trailing call
+func (t *Throttler[T]) Cancel() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var zero T
+	t.value = zero
+	t.scheduled = false
+}
+
+// due runs the trailing call once the interval has passed
+func (t *Throttler[T]) due(now time.Time) bool {
+	t.mu.Lock()
+	if !t.scheduled {
+		t.mu.Unlock()
+		return true
+	}
+	if now.Sub(t.last) < t.interval {
+		t.mu.Unlock()
+		return false
+	}
+	value := t.value
+	t.scheduled = false
+	t.last = now
+	t.mu.Unlock()
+
+	t.fn(value)
+	return true
+}
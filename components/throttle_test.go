@@ -0,0 +1,114 @@
+package components
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDebouncer(t *testing.T) {
+	clock := useManualClock(t)
+	var got []string
+	d := NewDebouncer(100*time.Millisecond, func(v string) { got = append(got, v) })
+
+	d.Call("a")
+	clock.Advance(60 * time.Millisecond)
+	d.Call("ab") // Restarts the wait
+	clock.Advance(60 * time.Millisecond)
+	RunFrameCallbacks()
+	if len(got) != 0 {
+		t.Fatalf("called with %v before the wait after the last call", got)
+	}
+	if !d.Pending() {
+		t.Error("no call pending during the wait")
+	}
+
+	clock.Advance(40 * time.Millisecond)
+	RunFrameCallbacks()
+	if want := []string{"ab"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("called with %v, want %v", got, want)
+	}
+	if d.Pending() {
+		t.Error("call still pending after running")
+	}
+}
+
+func TestDebouncerFlushAndCancel(t *testing.T) {
+	clock := useManualClock(t)
+	var got []int
+	d := NewDebouncer(time.Second, func(v int) { got = append(got, v) })
+
+	d.Call(1)
+	d.Flush()
+	if want := []int{1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("after Flush called with %v, want %v", got, want)
+	}
+
+	d.Call(2)
+	d.Cancel()
+	clock.Advance(2 * time.Second)
+	RunFrameCallbacks()
+	if want := []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after Cancel called with %v, want %v", got, want)
+	}
+}
+
+func TestThrottler(t *testing.T) {
+	clock := useManualClock(t)
+	var got []int
+	th := NewThrottler(100*time.Millisecond, func(v int) { got = append(got, v) })
+
+	th.Call(1) // Runs now
+	th.Call(2)
+	th.Call(3) // Replaces 2 in the trailing call
+	if want := []int{1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("called with %v, want %v", got, want)
+	}
+	if !th.Pending() {
+		t.Error("no trailing call pending")
+	}
+
+	clock.Advance(50 * time.Millisecond)
+	RunFrameCallbacks()
+	if len(got) != 1 {
+		t.Fatalf("trailing call ran within the interval: %v", got)
+	}
+
+	clock.Advance(50 * time.Millisecond)
+	RunFrameCallbacks()
+	if want := []int{1, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("called with %v, want %v", got, want)
+	}
+
+	// The trailing call starts a new interval
+	clock.Advance(50 * time.Millisecond)
+	th.Call(4)
+	if len(got) != 2 {
+		t.Fatalf("called within the interval after the trailing call: %v", got)
+	}
+	clock.Advance(100 * time.Millisecond)
+	th.Call(5) // Still waiting on the trailing call
+	RunFrameCallbacks()
+	if want := []int{1, 3, 5}; !reflect.DeepEqual(got, want) {
+		t.Errorf("called with %v, want %v", got, want)
+	}
+}
+
+func TestThrottlerCancel(t *testing.T) {
+	clock := useManualClock(t)
+	var got []int
+	th := NewThrottler(100*time.Millisecond, func(v int) { got = append(got, v) })
+
+	th.Call(1)
+	th.Call(2)
+	th.Cancel()
+	clock.Advance(time.Second)
+	RunFrameCallbacks()
+
+	if want := []int{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("called with %v, want %v", got, want)
+	}
+	if th.Pending() {
+		t.Error("trailing call pending after Cancel")
+	}
+}
@@ -0,0 +1,145 @@
+package components
+
+import (
+	"image/color"
+	"strconv"
+	"time"
+)
+
+// justNow is how close to now a time is shown as "just now"
+const justNow = 45 * time.Second
+
+// FormatTimeAgo describes a time relative to now, e.g. "just now",
+// "5 minutes ago", "yesterday" or "in 3 hours"
+func FormatTimeAgo(t, now time.Time) string {
+	return relativeWordsByLanguage["en"].format(t, now, func(amount int64) string {
+		return strconv.FormatInt(amount, 10)
+	})
+}
+
+// timeAgoChange returns how long until the relative text for a time
+// changes, so labels refresh no more often than needed
+func timeAgoChange(t, now time.Time) time.Duration {
+	diff := now.Sub(t)
+	if diff < 0 {
+		// Counting down: the text changes as the gap drops below a whole unit
+		diff = -diff
+		if diff < justNow {
+			return diff + justNow
+		}
+		return diff%timeAgoUnit(diff) + time.Second
+	}
+	if diff < justNow {
+		return justNow - diff
+	}
+	unit := timeAgoUnit(diff)
+	return unit - diff%unit
+}
+
+// timeAgoUnit returns the unit the text counts in for a gap
+func timeAgoUnit(diff time.Duration) time.Duration {
+	switch {
+	case diff < time.Hour:
+		return time.Minute
+	case diff < 24*time.Hour:
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// TimeAgoLabel shows a time relative to now, such as "2 minutes ago" in the
+// current locale's language, and keeps the text current by refreshing
+// itself when it would change. Times further away than a threshold are
+// shown as a date instead.
+type TimeAgoLabel struct {
+	*Label
+	time           time.Time
+	absoluteAfter  time.Duration
+	absoluteFormat string
+	generation     int // Bumped per change so stale refreshes are dropped
+}
+
+// NewTimeAgoLabel creates a label for a time
+func NewTimeAgoLabel(id string, t time.Time, fontSize int, textColor color.RGBA) *TimeAgoLabel {
+	l := &TimeAgoLabel{
+		Label:          NewLabel(id, "", fontSize, textColor),
+		absoluteAfter:  7 * 24 * time.Hour,
+		absoluteFormat: "Jan 2, 2006",
+	}
+	l.SetTime(t)
+	return l
+}
+
+// SetTime sets the time shown
+func (l *TimeAgoLabel) SetTime(t time.Time) {
+	l.time = t
+	l.refresh()
+}
+
+// Time returns the time shown
+func (l *TimeAgoLabel) Time() time.Time {
+	return l.time
+}
+
+// SetAbsoluteAfter sets how far from now a time can be before it is shown
+// with the absolute format; zero always shows relative text
+func (l *TimeAgoLabel) SetAbsoluteAfter(threshold time.Duration) {
+	l.absoluteAfter = threshold
+	l.refresh()
+}
+
+// SetAbsoluteFormat sets the time.Format layout used past the threshold
+func (l *TimeAgoLabel) SetAbsoluteFormat(layout string) {
+	l.absoluteFormat = layout
+	l.refresh()
+}
+
+// refresh updates the text and schedules the next refresh for when it
+// would change
+func (l *TimeAgoLabel) refresh() {
+	l.generation++
+	now := Now()
+	diff := now.Sub(l.time)
+	if diff < 0 {
+		diff = -diff
+	}
+
+	if l.absoluteAfter > 0 && diff >= l.absoluteAfter {
+		l.SetText(l.time.Format(l.absoluteFormat))
+		if l.time.Before(now) {
+			// Past times stay absolute
+			return
+		}
+		// Future times turn relative once within the threshold
+		l.scheduleRefresh(now.Add(diff - l.absoluteAfter + time.Second))
+		return
+	}
+
+	l.SetText(CurrentLocale().FormatRelativeTime(l.time, now))
+	l.scheduleRefresh(now.Add(max(time.Second, timeAgoChange(l.time, now))))
+}
+
+// scheduleRefresh queues a refresh on the frame callbacks
+func (l *TimeAgoLabel) scheduleRefresh(at time.Time) {
+	scheduleFrameCallback(&timeAgoRefresh{label: l, generation: l.generation, at: at})
+}
+
+// timeAgoRefresh updates a label's text once its time comes
+type timeAgoRefresh struct {
+	label      *TimeAgoLabel
+	generation int
+	at         time.Time
+}
+
+// due refreshes the label at the scheduled time, dropping refreshes made
+// stale by a newer change
+func (r *timeAgoRefresh) due(now time.Time) bool {
+	if r.label.generation != r.generation {
+		return true
+	}
+	if now.Before(r.at) {
+		return false
+	}
+	r.label.refresh()
+	return true
+}
@@ -0,0 +1,481 @@
+package components
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+)
+
+// TimeSegment identifies a part of the time shown in a TimePicker
+type TimeSegment int
+
+const (
+	TimeSegmentHour TimeSegment = iota
+	TimeSegmentMinute
+	TimeSegmentSecond
+	TimeSegmentPeriod // AM/PM in 12-hour mode
+)
+
+// TimePicker is a spinner-style time of day field. Click or arrow to a
+// segment, then use Up/Down, the wheel, the spin buttons or digits to change it.
+type TimePicker struct {
+	*Node
+	value        time.Duration // Time since midnight
+	minTime      time.Duration
+	maxTime      time.Duration
+	use24Hour    bool
+	showSeconds  bool
+	minuteStep   int
+	active       TimeSegment
+	typed        string // Digits typed into the active segment so far
+	focused      bool
+	fontSize     int
+	onChange     func(time.Duration)
+	fieldColor   color.RGBA
+	borderColor  color.RGBA
+	textColor    color.RGBA
+	activeColor  color.RGBA
+	buttonColor  color.RGBA
+	focusedColor color.RGBA
+}
+
+// NewTimePicker creates a new time picker set to midnight
+func NewTimePicker(id string) *TimePicker {
+	return &TimePicker{
+		Node:         NewNode(id),
+		maxTime:      24*time.Hour - time.Second,
+		use24Hour:    true,
+		minuteStep:   1,
+		fontSize:     14,
+		fieldColor:   color.RGBA{255, 255, 255, 255},
+		borderColor:  color.RGBA{100, 100, 100, 255},
+		textColor:    color.RGBA{0, 0, 0, 255},
+		activeColor:  color.RGBA{200, 220, 255, 255},
+		buttonColor:  color.RGBA{230, 230, 230, 255},
+		focusedColor: color.RGBA{70, 130, 180, 255},
+	}
+}
+
+// SetValue sets the time of day as a duration since midnight, clamped to the min and max times
+func (t *TimePicker) SetValue(d time.Duration) {
+	d = d.Truncate(time.Second)
+	if d < t.minTime {
+		d = t.minTime
+	}
+	if d > t.maxTime {
+		d = t.maxTime
+	}
+	if d == t.value {
+		return
+	}
+
+	t.value = d
+	if t.onChange != nil {
+		t.onChange(d)
+	}
+}
+
+// Value returns the time of day as a duration since midnight
+func (t *TimePicker) Value() time.Duration {
+	return t.value
+}
+
+// SetTime sets the value from the time of day of tm
+func (t *TimePicker) SetTime(tm time.Time) {
+	t.SetValue(time.Duration(tm.Hour())*time.Hour + time.Duration(tm.Minute())*time.Minute + time.Duration(tm.Second())*time.Second)
+}
+
+// Time returns the picked time of day on the given date
+func (t *TimePicker) Time(date time.Time) time.Time {
+	return dateOnly(date).Add(t.value)
+}
+
+// Hour returns the hour (0-23)
+func (t *TimePicker) Hour() int {
+	return int(t.value / time.Hour)
+}
+
+// Minute returns the minute (0-59)
+func (t *TimePicker) Minute() int {
+	return int(t.value/time.Minute) % 60
+}
+
+// Second returns the second (0-59)
+func (t *TimePicker) Second() int {
+	return int(t.value/time.Second) % 60
+}
+
+// SetMinTime sets the earliest selectable time of day
+func (t *TimePicker) SetMinTime(d time.Duration) {
+	t.minTime = d
+	if t.value < d {
+		t.SetValue(d)
+	}
+}
+
+// SetMaxTime sets the latest selectable time of day
+func (t *TimePicker) SetMaxTime(d time.Duration) {
+	t.maxTime = d
+	if t.value > d {
+		t.SetValue(d)
+	}
+}
+
+// SetUse24Hour switches between 24-hour and 12-hour (AM/PM) display
+func (t *TimePicker) SetUse24Hour(use24Hour bool) {
+	t.use24Hour = use24Hour
+	if use24Hour && t.active == TimeSegmentPeriod {
+		t.active = TimeSegmentHour
+	}
+}
+
+// SetShowSeconds sets whether a seconds segment is shown
+func (t *TimePicker) SetShowSeconds(show bool) {
+	t.showSeconds = show
+	if !show && t.active == TimeSegmentSecond {
+		t.active = TimeSegmentMinute
+	}
+}
+
+// SetMinuteStep sets how many minutes one step of the minute segment changes
+func (t *TimePicker) SetMinuteStep(step int) {
+	if step > 0 && step < 60 {
+		t.minuteStep = step
+	}
+}
+
+// SetOnChange sets the handler for when the time changes
+func (t *TimePicker) SetOnChange(handler func(time.Duration)) {
+	t.onChange = handler
+}
+
+// Focus gives the time picker keyboard focus
+func (t *TimePicker) Focus() {
+	t.focused = true
+}
+
+// Blur removes keyboard focus from the time picker
+func (t *TimePicker) Blur() {
+	t.focused = false
+	t.typed = ""
+}
+
+// IsFocused returns whether the time picker receives keyboard input
+func (t *TimePicker) IsFocused() bool {
+	return t.focused
+}
+
+// SetActiveSegment sets the segment changed by the keyboard and spin buttons
+func (t *TimePicker) SetActiveSegment(segment TimeSegment) {
+	for _, s := range t.segments() {
+		if s == segment {
+			t.active = segment
+			t.typed = ""
+			return
+		}
+	}
+}
+
+// ActiveSegment returns the segment changed by the keyboard and spin buttons
+func (t *TimePicker) ActiveSegment() TimeSegment {
+	return t.active
+}
+
+// Step changes the active segment by the given number of steps, wrapping within the day
+func (t *TimePicker) Step(steps int) {
+	var delta time.Duration
+	switch t.active {
+	case TimeSegmentHour:
+		delta = time.Duration(steps) * time.Hour
+	case TimeSegmentMinute:
+		delta = time.Duration(steps*t.minuteStep) * time.Minute
+	case TimeSegmentSecond:
+		delta = time.Duration(steps) * time.Second
+	case TimeSegmentPeriod:
+		delta = time.Duration(steps) * 12 * time.Hour
+	}
+
+	day := 24 * time.Hour
+	t.SetValue(((t.value+delta)%day + day) % day)
+}
+
+// segments returns the segments shown in the current mode
+func (t *TimePicker) segments() []TimeSegment {
+	segments := []TimeSegment{TimeSegmentHour, TimeSegmentMinute}
+	if t.showSeconds {
+		segments = append(segments, TimeSegmentSecond)
+	}
+	if !t.use24Hour {
+		segments = append(segments, TimeSegmentPeriod)
+	}
+	return segments
+}
+
+// segmentText returns the text shown for a segment
+func (t *TimePicker) segmentText(segment TimeSegment) string {
+	switch segment {
+	case TimeSegmentHour:
+		hour := t.Hour()
+		if !t.use24Hour {
+			hour %= 12
+			if hour == 0 {
+				hour = 12
+			}
+		}
+		return fmt.Sprintf("%02d", hour)
+	case TimeSegmentMinute:
+		return fmt.Sprintf("%02d", t.Minute())
+	case TimeSegmentSecond:
+		return fmt.Sprintf("%02d", t.Second())
+	case TimeSegmentPeriod:
+		if t.Hour() < 12 {
+			return "AM"
+		}
+		return "PM"
+	}
+	return ""
+}
+
+// String returns the time as displayed, e.g. "09:30" or "09:30 AM"
+func (t *TimePicker) String() string {
+	text := ""
+	for i, segment := range t.segments() {
+		switch {
+		case i == 0:
+		case segment == TimeSegmentPeriod:
+			text += " "
+		default:
+			text += ":"
+		}
+		text += t.segmentText(segment)
+	}
+	return text
+}
+
+// segmentRect returns the bounds of a segment's text in the field
+func (t *TimePicker) segmentRect(segment TimeSegment) Rect {
+	bounds := t.ComputedBounds()
+	charWidth := t.fontSize / 2
+	x := bounds.X + 6
+	for i, s := range t.segments() {
+		if i > 0 {
+			x += charWidth // Separator
+		}
+		width := len(t.segmentText(s)) * charWidth
+		if s == segment {
+			return Rect{X: x - 1, Y: bounds.Y + 4, Width: width + 2, Height: bounds.Height - 8}
+		}
+		x += width
+	}
+	return Rect{}
+}
+
+// spinRects returns the bounds of the up and down spin buttons
+func (t *TimePicker) spinRects() (Rect, Rect) {
+	bounds := t.ComputedBounds()
+	half := bounds.Height / 2
+	up := Rect{X: bounds.X + bounds.Width - 20, Y: bounds.Y, Width: 20, Height: half}
+	down := Rect{X: up.X, Y: bounds.Y + half, Width: 20, Height: bounds.Height - half}
+	return up, down
+}
+
+// Draw draws the time segments and spin buttons
+func (t *TimePicker) Draw(surface DrawSurface) {
+	if !t.IsVisible() {
+		return
+	}
+
+	bounds := t.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, t.fieldColor)
+	border := t.borderColor
+	if t.focused {
+		border = t.focusedColor
+		active := t.segmentRect(t.active)
+		surface.FillRect(active.X, active.Y, active.Width, active.Height, t.activeColor)
+	}
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, border)
+	surface.DrawText(t.String(), bounds.X+6, bounds.Y+(bounds.Height-t.fontSize)/2, t.textColor, t.fontSize)
+
+	up, down := t.spinRects()
+	for _, r := range []Rect{up, down} {
+		surface.FillRect(r.X, r.Y, r.Width, r.Height, t.buttonColor)
+		surface.DrawRect(r.X, r.Y, r.Width, r.Height, t.borderColor)
+	}
+	cx := up.X + up.Width/2
+	surface.DrawLine(cx-4, up.Y+up.Height/2+2, cx, up.Y+up.Height/2-2, t.textColor)
+	surface.DrawLine(cx, up.Y+up.Height/2-2, cx+4, up.Y+up.Height/2+2, t.textColor)
+	surface.DrawLine(cx-4, down.Y+down.Height/2-2, cx, down.Y+down.Height/2+2, t.textColor)
+	surface.DrawLine(cx, down.Y+down.Height/2+2, cx+4, down.Y+down.Height/2-2, t.textColor)
+}
+
+// HandleMouseDown focuses the field, activates the clicked segment and steps on the spin buttons
+func (t *TimePicker) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !t.IsVisible() {
+		return
+	}
+
+	p := Point{x, y}
+	if !PointInRect(p, t.ComputedBounds()) {
+		t.Blur()
+		return
+	}
+	t.focused = true
+
+	up, down := t.spinRects()
+	switch {
+	case PointInRect(p, up):
+		t.Step(1)
+	case PointInRect(p, down):
+		t.Step(-1)
+	default:
+		for _, segment := range t.segments() {
+			if r := t.segmentRect(segment); x >= r.X && x < r.X+r.Width {
+				t.SetActiveSegment(segment)
+				break
+			}
+		}
+	}
+	e.Consume()
+}
+
+// HandleMouseUp is a no-op; the time picker acts on mouse down
+func (t *TimePicker) HandleMouseUp(e *Event) {
+	return
+}
+
+// HandleScroll steps the active segment with the mouse wheel
+func (t *TimePicker) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !t.IsVisible() || !PointInRect(Point{x, y}, t.ComputedBounds()) || deltaY == 0 {
+		return false
+	}
+	if deltaY > 0 {
+		t.Step(1)
+	} else {
+		t.Step(-1)
+	}
+	return true
+}
+
+// HandleKeyDown changes the time while focused: Up/Down step the active
+// segment, PageUp/PageDown step it by ten, Left/Right move between segments,
+// Home/End jump to the min and max times, and digits type a value
+func (t *TimePicker) HandleKeyDown(event InputEvent) bool {
+	if !t.focused || !t.IsVisible() {
+		return false
+	}
+
+	if event.Type == InputTypeChar {
+		return t.typeChar(event.Char)
+	}
+	if event.Type != InputTypeKeyDown {
+		return false
+	}
+
+	segments := t.segments()
+	index := 0
+	for i, s := range segments {
+		if s == t.active {
+			index = i
+		}
+	}
+
+	switch event.Key.Base() {
+	case KeyUp:
+		t.Step(1)
+	case KeyDown:
+		t.Step(-1)
+	case KeyPageUp:
+		t.Step(10)
+	case KeyPageDown:
+		t.Step(-10)
+	case KeyLeft:
+		if index > 0 {
+			t.SetActiveSegment(segments[index-1])
+		}
+	case KeyRight:
+		if index < len(segments)-1 {
+			t.SetActiveSegment(segments[index+1])
+		}
+	case KeyHome:
+		t.SetValue(t.minTime)
+	case KeyEnd:
+		t.SetValue(t.maxTime)
+	case KeyEscape:
+		t.Blur()
+	default:
+		return false
+	}
+	return true
+}
+
+// typeChar applies a typed digit (or A/P for the period) to the active segment
+func (t *TimePicker) typeChar(ch rune) bool {
+	if t.active == TimeSegmentPeriod {
+		hour := t.Hour()
+		switch ch {
+		case 'a', 'A':
+			if hour >= 12 {
+				t.Step(-1)
+			}
+		case 'p', 'P':
+			if hour < 12 {
+				t.Step(1)
+			}
+		default:
+			return false
+		}
+		return true
+	}
+
+	if ch < '0' || ch > '9' {
+		return false
+	}
+
+	limit := 59
+	if t.active == TimeSegmentHour {
+		limit = 23
+		if !t.use24Hour {
+			limit = 12
+		}
+	}
+
+	// A digit that would overflow the segment starts a new value
+	t.typed += string(ch)
+	n := 0
+	fmt.Sscanf(t.typed, "%d", &n)
+	if n > limit {
+		t.typed = string(ch)
+		n = int(ch - '0')
+	}
+
+	hour, minute, second := t.Hour(), t.Minute(), t.Second()
+	switch t.active {
+	case TimeSegmentHour:
+		hour = n
+		if !t.use24Hour {
+			hour = n % 12
+			if t.Hour() >= 12 {
+				hour += 12
+			}
+		}
+	case TimeSegmentMinute:
+		minute = n
+	case TimeSegmentSecond:
+		second = n
+	}
+	t.SetValue(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute + time.Duration(second)*time.Second)
+
+	// Two digits complete the segment; move on to the next one
+	if len(t.typed) >= 2 {
+		segments := t.segments()
+		for i, s := range segments {
+			if s == t.active && i < len(segments)-1 {
+				t.SetActiveSegment(segments[i+1])
+				break
+			}
+		}
+		t.typed = ""
+	}
+	return true
+}
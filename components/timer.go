@@ -0,0 +1,75 @@
+package components
+
+import (
+	"sync"
+	"time"
+)
+
+// Timer calls a function after a delay, or repeatedly at an interval. It is
+// checked on each frame by RunFrameCallbacks, so the function runs on the UI
+// goroutine and follows the active Clock, letting tests drive it with a
+// ManualClock.
+type Timer struct {
+	mu       sync.Mutex
+	fn       func()
+	deadline time.Time
+	interval time.Duration // Zero for a one-shot timer
+	stopped  bool
+}
+
+// After calls fn once, on the first frame after the delay has passed
+func After(delay time.Duration, fn func()) *Timer {
+	t := &Timer{fn: fn, deadline: Now().Add(delay)}
+	scheduleFrameCallback(t)
+	return t
+}
+
+// Every calls fn each time the interval passes until the timer is stopped.
+// Intervals missed while frames were slow are skipped rather than run in a
+// burst.
+func Every(interval time.Duration, fn func()) *Timer {
+	t := &Timer{fn: fn, deadline: Now().Add(interval), interval: interval}
+	scheduleFrameCallback(t)
+	return t
+}
+
+// Stop cancels the timer; a one-shot timer that hasn't fired never will
+func (t *Timer) Stop() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stopped = true
+}
+
+// Active returns whether the timer will still fire
+func (t *Timer) Active() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return !t.stopped
+}
+
+// due calls the function once the deadline has passed, rescheduling a
+// repeating timer
+func (t *Timer) due(now time.Time) bool {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return true
+	}
+	if now.Before(t.deadline) {
+		t.mu.Unlock()
+		return false
+	}
+	if t.interval > 0 {
+		t.deadline = t.deadline.Add(t.interval)
+		if !t.deadline.After(now) {
+			t.deadline = now.Add(t.interval)
+		}
+	} else {
+		t.stopped = true
+	}
+	finished := t.stopped
+	t.mu.Unlock()
+
+	t.fn()
+	return finished
+}
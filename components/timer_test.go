@@ -0,0 +1,130 @@
+package components
+
+import (
+	"testing"
+	"time"
+)
+
+// useManualClock drives timing from a manual clock for the rest of a test,
+// with no frame callbacks left from other tests
+func useManualClock(t *testing.T) *ManualClock {
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	SetClock(clock)
+	frameMu.Lock()
+	frameCallbacks = nil
+	frameMu.Unlock()
+	t.Cleanup(func() {
+		SetClock(nil)
+		frameMu.Lock()
+		frameCallbacks = nil
+		frameMu.Unlock()
+	})
+	return clock
+}
+
+func TestAfter(t *testing.T) {
+	clock := useManualClock(t)
+	calls := 0
+	timer := After(100*time.Millisecond, func() { calls++ })
+
+	clock.Advance(99 * time.Millisecond)
+	RunFrameCallbacks()
+	if calls != 0 {
+		t.Fatal("timer fired before its delay")
+	}
+
+	clock.Advance(time.Millisecond)
+	RunFrameCallbacks()
+	if calls != 1 {
+		t.Fatalf("timer fired %d times at its delay, want 1", calls)
+	}
+	if timer.Active() {
+		t.Error("one-shot timer is still active after firing")
+	}
+
+	clock.Advance(time.Second)
+	RunFrameCallbacks()
+	if calls != 1 {
+		t.Errorf("one-shot timer fired %d times, want 1", calls)
+	}
+}
+
+func TestAfterStop(t *testing.T) {
+	clock := useManualClock(t)
+	calls := 0
+	timer := After(100*time.Millisecond, func() { calls++ })
+
+	timer.Stop()
+	clock.Advance(time.Second)
+	RunFrameCallbacks()
+
+	if calls != 0 {
+		t.Error("stopped timer fired")
+	}
+	if timer.Active() {
+		t.Error("stopped timer is active")
+	}
+}
+
+func TestEvery(t *testing.T) {
+	clock := useManualClock(t)
+	calls := 0
+	timer := Every(100*time.Millisecond, func() { calls++ })
+
+	for i := 0; i < 3; i++ {
+		clock.Advance(100 * time.Millisecond)
+		RunFrameCallbacks()
+	}
+	if calls != 3 {
+		t.Fatalf("timer fired %d times in 3 intervals, want 3", calls)
+	}
+
+	// Intervals missed during a slow frame are skipped
+	clock.Advance(time.Second)
+	RunFrameCallbacks()
+	if calls != 4 {
+		t.Fatalf("timer fired %d times after a slow frame, want 4", calls)
+	}
+	clock.Advance(50 * time.Millisecond)
+	RunFrameCallbacks()
+	if calls != 4 {
+		t.Fatalf("timer fired %d times before its next interval, want 4", calls)
+	}
+
+	timer.Stop()
+	clock.Advance(time.Second)
+	RunFrameCallbacks()
+	if calls != 4 {
+		t.Errorf("timer fired %d times after Stop, want 4", calls)
+	}
+}
+
+func TestRunFrameCallbacksPanic(t *testing.T) {
+	clock := useManualClock(t)
+	repeats, others := 0, 0
+	Every(100*time.Millisecond, func() {
+		repeats++
+		panic("repeating")
+	})
+	After(100*time.Millisecond, func() { others++ })
+
+	runRecovering := func() (panicked interface{}) {
+		defer func() { panicked = recover() }()
+		RunFrameCallbacks()
+		return nil
+	}
+
+	clock.Advance(100 * time.Millisecond)
+	if r := runRecovering(); r != "repeating" {
+		t.Errorf("RunFrameCallbacks raised %v, want the callback's panic", r)
+	}
+	if others != 1 {
+		t.Errorf("other timer fired %d times, want 1", others)
+	}
+
+	clock.Advance(100 * time.Millisecond)
+	runRecovering()
+	if repeats != 2 {
+		t.Errorf("repeating timer fired %d times, want 2", repeats)
+	}
+}
@@ -0,0 +1,231 @@
+package components
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time. Replace it with a ManualClock in tests
+// so animations, double clicks and key repeat can be driven deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock reads the wall clock
+type systemClock struct{}
+
+// Now returns the current wall clock time
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// ManualClock is a clock that only moves when told to
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock creates a manual clock starting at the given time
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now returns the clock's current time
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// Set moves the clock to the given time
+func (c *ManualClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+var (
+	timingMu    sync.RWMutex
+	activeClock Clock = systemClock{}
+)
+
+// SetClock replaces the clock used by components; nil restores the system clock
+func SetClock(clock Clock) {
+	timingMu.Lock()
+	defer timingMu.Unlock()
+	if clock == nil {
+		clock = systemClock{}
+	}
+	activeClock = clock
+}
+
+// Now returns the current time from the active clock
+func Now() time.Time {
+	timingMu.RLock()
+	defer timingMu.RUnlock()
+	return activeClock.Now()
+}
+
+// since returns the time elapsed on the active clock
+func since(t time.Time) time.Duration {
+	return Now().Sub(t)
+}
+
+// InputTiming holds the timing thresholds used when interpreting input
+type InputTiming struct {
+	DoubleClickInterval time.Duration // Maximum time between clicks of a double click
+	DoubleClickDistance int           // Maximum pointer movement between clicks, in pixels
+	KeyRepeatDelay      time.Duration // Time a key is held before it starts repeating
+	KeyRepeatInterval   time.Duration // Time between repeats while a key is held
+	LongPressThreshold  time.Duration // Time a press is held before it counts as a long press
+}
+
+// DefaultInputTiming returns timing values matching common desktop defaults
+func DefaultInputTiming() InputTiming {
+	return InputTiming{
+		DoubleClickInterval: 500 * time.Millisecond,
+		DoubleClickDistance: 4,
+		KeyRepeatDelay:      500 * time.Millisecond,
+		KeyRepeatInterval:   33 * time.Millisecond,
+		LongPressThreshold:  600 * time.Millisecond,
+	}
+}
+
+var inputTiming = DefaultInputTiming()
+
+// SetInputTiming sets the timing thresholds used by gestures, key repeat and text editing
+func SetInputTiming(timing InputTiming) {
+	timingMu.Lock()
+	defer timingMu.Unlock()
+	inputTiming = timing
+}
+
+// GetInputTiming returns the current input timing thresholds
+func GetInputTiming() InputTiming {
+	timingMu.RLock()
+	defer timingMu.RUnlock()
+	return inputTiming
+}
+
+// GestureTracker turns raw presses into click counts and long presses
+// according to the current InputTiming
+type GestureTracker struct {
+	pressed    bool
+	pressTime  time.Time
+	pressPos   Point
+	longFired  bool
+	lastClick  time.Time
+	lastPos    Point
+	clickCount int
+}
+
+// NewGestureTracker creates a new gesture tracker
+func NewGestureTracker() *GestureTracker {
+	return &GestureTracker{}
+}
+
+// Down records a press at the given position
+func (g *GestureTracker) Down(x, y int) {
+	g.pressed = true
+	g.pressTime = Now()
+	g.pressPos = Point{x, y}
+	g.longFired = false
+}
+
+// Up records a release and returns the click count (1 for a single click,
+// 2 for a double click, and so on), or 0 if the press became a long press
+func (g *GestureTracker) Up(x, y int) int {
+	if !g.pressed {
+		return 0
+	}
+	g.pressed = false
+
+	if g.longFired || since(g.pressTime) >= GetInputTiming().LongPressThreshold {
+		g.clickCount = 0
+		return 0
+	}
+
+	timing := GetInputTiming()
+	now := Now()
+	p := Point{x, y}
+	if g.clickCount > 0 && now.Sub(g.lastClick) <= timing.DoubleClickInterval &&
+		absInt(p.X-g.lastPos.X) <= timing.DoubleClickDistance &&
+		absInt(p.Y-g.lastPos.Y) <= timing.DoubleClickDistance {
+		g.clickCount++
+	} else {
+		g.clickCount = 1
+	}
+
+	g.lastClick = now
+	g.lastPos = p
+	return g.clickCount
+}
+
+// CheckLongPress returns true once when a held press crosses the long-press threshold
+func (g *GestureTracker) CheckLongPress() bool {
+	if !g.pressed || g.longFired {
+		return false
+	}
+	if since(g.pressTime) >= GetInputTiming().LongPressThreshold {
+		g.longFired = true
+		return true
+	}
+	return false
+}
+
+// IsPressed returns whether a press is in progress
+func (g *GestureTracker) IsPressed() bool {
+	return g.pressed
+}
+
+// KeyRepeater generates repeated key events for held keys according to the
+// current InputTiming
+type KeyRepeater struct {
+	nextRepeat map[Key]time.Time
+}
+
+// NewKeyRepeater creates a new key repeater
+func NewKeyRepeater() *KeyRepeater {
+	return &KeyRepeater{nextRepeat: make(map[Key]time.Time)}
+}
+
+// Press records that a key went down and returns true (the initial event always fires)
+func (r *KeyRepeater) Press(key Key) bool {
+	r.nextRepeat[key] = Now().Add(GetInputTiming().KeyRepeatDelay)
+	return true
+}
+
+// Held returns true if a held key should repeat this frame
+func (r *KeyRepeater) Held(key Key) bool {
+	next, ok := r.nextRepeat[key]
+	if !ok {
+		return r.Press(key)
+	}
+
+	now := Now()
+	if now.Before(next) {
+		return false
+	}
+
+	interval := GetInputTiming().KeyRepeatInterval
+	if interval <= 0 {
+		interval = time.Millisecond
+	}
+	for !now.Before(next) {
+		next = next.Add(interval)
+	}
+	r.nextRepeat[key] = next
+	return true
+}
+
+// Release forgets a key that is no longer held
+func (r *KeyRepeater) Release(key Key) {
+	delete(r.nextRepeat, key)
+}
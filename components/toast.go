@@ -0,0 +1,406 @@
+package components
+
+import (
+	"image/color"
+	"time"
+)
+
+// ToastKind sets a toast's accent color and icon
+type ToastKind int
+
+const (
+	ToastInfo ToastKind = iota
+	ToastSuccess
+	ToastError
+	ToastProgress // Shows a progress bar
+)
+
+// defaultToastDuration is how long a toast stays up unless told otherwise
+const defaultToastDuration = 4 * time.Second
+
+// ToastAction is a button on a toast
+type ToastAction struct {
+	Label   string
+	Handler func()
+}
+
+// Toast is a short message shown in a ToastCenter. Its fields are changed
+// through setters so it can be updated while shown, e.g. as a task runs.
+type Toast struct {
+	message   string
+	kind      ToastKind
+	progress  float64 // 0 to 1, or negative when unknown
+	actions   []ToastAction
+	duration  time.Duration
+	shown     time.Time // When the duration started counting
+	created   time.Time
+	dismissed bool
+	onDismiss func()
+}
+
+// SetMessage changes the text
+func (t *Toast) SetMessage(message string) {
+	t.message = message
+}
+
+// Message returns the text
+func (t *Toast) Message() string {
+	return t.message
+}
+
+// SetKind changes the accent color and icon
+func (t *Toast) SetKind(kind ToastKind) {
+	t.kind = kind
+}
+
+// Kind returns the kind of toast
+func (t *Toast) Kind() ToastKind {
+	return t.kind
+}
+
+// SetProgress sets the progress bar of a ToastProgress toast from 0 to 1;
+// a negative value shows an indeterminate bar
+func (t *Toast) SetProgress(progress float64) {
+	t.progress = min(progress, 1)
+}
+
+// SetActions replaces the buttons; clicking one runs its handler and
+// dismisses the toast
+func (t *Toast) SetActions(actions ...ToastAction) {
+	t.actions = actions
+}
+
+// SetDuration sets how long the toast stays up from now; zero keeps it up
+// until dismissed
+func (t *Toast) SetDuration(duration time.Duration) {
+	t.duration = duration
+	t.shown = Now()
+}
+
+// SetOnDismiss sets the handler called when the toast goes away
+func (t *Toast) SetOnDismiss(handler func()) {
+	t.onDismiss = handler
+}
+
+// Dismiss removes the toast
+func (t *Toast) Dismiss() {
+	if t.dismissed {
+		return
+	}
+	t.dismissed = true
+	if t.onDismiss != nil {
+		t.onDismiss()
+	}
+}
+
+// IsDismissed returns whether the toast has gone away
+func (t *Toast) IsDismissed() bool {
+	return t.dismissed
+}
+
+// expired returns whether the toast's time is up
+func (t *Toast) expired() bool {
+	return t.duration > 0 && since(t.shown) >= t.duration
+}
+
+// ToastCenter stacks toasts in the bottom right corner of its bounds,
+// newest at the bottom. It is added above the rest of the UI and only takes
+// clicks that land on a toast. Toasts stay up while hovered.
+type ToastCenter struct {
+	*Node
+	toasts          []*Toast
+	width           int
+	fontSize        int
+	margin          int
+	maxVisible      int
+	hovered         *Toast
+	pressedToast    *Toast
+	pressedAction   int // Index into actions, or toastClose
+	backgroundColor color.RGBA
+	textColor       color.RGBA
+	buttonColor     color.RGBA
+	trackColor      color.RGBA
+}
+
+// toastClose marks the close button as pressed
+const toastClose = -1
+
+// NewToastCenter creates an empty toast center
+func NewToastCenter(id string) *ToastCenter {
+	c := &ToastCenter{
+		Node:            NewNode(id),
+		width:           320,
+		fontSize:        14,
+		margin:          16,
+		maxVisible:      5,
+		backgroundColor: color.RGBA{45, 45, 48, 240},
+		textColor:       color.RGBA{240, 240, 240, 255},
+		buttonColor:     color.RGBA{130, 180, 255, 255},
+		trackColor:      color.RGBA{90, 90, 95, 255},
+	}
+	c.SetPositionType(PositionFixed)
+	c.Node.SetBounds(Rect{X: 0, Y: 0, Width: ScreenWidth, Height: ScreenHeight})
+	return c
+}
+
+// Show adds a toast that goes away after a few seconds
+func (c *ToastCenter) Show(message string, kind ToastKind) *Toast {
+	duration := defaultToastDuration
+	if kind == ToastProgress {
+		duration = 0
+	}
+	now := Now()
+	toast := &Toast{message: message, kind: kind, progress: -1, duration: duration, shown: now, created: now}
+	c.toasts = append(c.toasts, toast)
+	return toast
+}
+
+// Toasts returns the toasts being shown, oldest first
+func (c *ToastCenter) Toasts() []*Toast {
+	c.prune()
+	return c.toasts
+}
+
+// Clear dismisses every toast
+func (c *ToastCenter) Clear() {
+	for _, toast := range c.toasts {
+		toast.Dismiss()
+	}
+	c.toasts = nil
+}
+
+// SetWidth sets the width of toasts
+func (c *ToastCenter) SetWidth(width int) {
+	c.width = width
+}
+
+// prune dismisses expired toasts, except the hovered one, and drops
+// dismissed ones
+func (c *ToastCenter) prune() {
+	kept := c.toasts[:0]
+	for _, toast := range c.toasts {
+		if toast != c.hovered && toast.expired() {
+			toast.Dismiss()
+		}
+		if !toast.dismissed {
+			kept = append(kept, toast)
+		}
+	}
+	for i := len(kept); i < len(c.toasts); i++ {
+		c.toasts[i] = nil
+	}
+	c.toasts = kept
+}
+
+// Update dismisses expired toasts and updates children
+func (c *ToastCenter) Update() {
+	c.prune()
+	c.Node.Update()
+}
+
+// toastHeight returns the height of a toast
+func (c *ToastCenter) toastHeight(toast *Toast) int {
+	height := c.fontSize + 20
+	if toast.kind == ToastProgress {
+		height += 10
+	}
+	if len(toast.actions) > 0 {
+		height += c.fontSize + 10
+	}
+	return height
+}
+
+// placedToast is a toast with its position on screen
+type placedToast struct {
+	toast *Toast
+	rect  Rect
+}
+
+// layout positions the newest toasts from the bottom up
+func (c *ToastCenter) layout() []placedToast {
+	bounds := c.ComputedBounds()
+	var placed []placedToast
+	y := bounds.Y + bounds.Height - c.margin
+	x := bounds.X + bounds.Width - c.margin - c.width
+	for i := len(c.toasts) - 1; i >= 0 && len(placed) < c.maxVisible; i-- {
+		toast := c.toasts[i]
+		height := c.toastHeight(toast)
+		y -= height
+		placed = append(placed, placedToast{toast: toast, rect: Rect{X: x, Y: y, Width: c.width, Height: height}})
+		y -= 8
+	}
+	return placed
+}
+
+// closeRect returns the close button of a toast
+func (c *ToastCenter) closeRect(rect Rect) Rect {
+	return Rect{X: rect.X + rect.Width - 24, Y: rect.Y + 4, Width: 20, Height: 20}
+}
+
+// actionRects returns the buttons of a toast, right-aligned along its bottom
+func (c *ToastCenter) actionRects(toast *Toast, rect Rect) []Rect {
+	rects := make([]Rect, len(toast.actions))
+	x := rect.X + rect.Width - 10
+	y := rect.Y + rect.Height - c.fontSize - 14
+	for i := len(toast.actions) - 1; i >= 0; i-- {
+		width := MeasureText(toast.actions[i].Label) + 16
+		x -= width
+		rects[i] = Rect{X: x, Y: y, Width: width, Height: c.fontSize + 8}
+		x -= 6
+	}
+	return rects
+}
+
+// hitTest returns the toast and the button of it under a point; the button
+// is an action index, toastClose, or -2 for the toast's body
+func (c *ToastCenter) hitTest(x, y int) (*Toast, int) {
+	p := Point{x, y}
+	for _, placed := range c.layout() {
+		if !PointInRect(p, placed.rect) {
+			continue
+		}
+		if PointInRect(p, c.closeRect(placed.rect)) {
+			return placed.toast, toastClose
+		}
+		for i, r := range c.actionRects(placed.toast, placed.rect) {
+			if PointInRect(p, r) {
+				return placed.toast, i
+			}
+		}
+		return placed.toast, -2
+	}
+	return nil, 0
+}
+
+// accentColor returns the color of a kind of toast
+func (c *ToastCenter) accentColor(kind ToastKind) color.RGBA {
+	switch kind {
+	case ToastSuccess:
+		return color.RGBA{80, 190, 110, 255}
+	case ToastError:
+		return color.RGBA{230, 90, 80, 255}
+	case ToastProgress:
+		return c.buttonColor
+	}
+	return color.RGBA{160, 160, 170, 255}
+}
+
+// Draw draws the toasts
+func (c *ToastCenter) Draw(surface DrawSurface) {
+	if !c.IsVisible() {
+		return
+	}
+	c.prune()
+
+	for _, placed := range c.layout() {
+		toast, rect := placed.toast, placed.rect
+		accent := c.accentColor(toast.kind)
+		surface.FillRect(rect.X, rect.Y, rect.Width, rect.Height, c.backgroundColor)
+		surface.FillRect(rect.X, rect.Y, 4, rect.Height, accent)
+
+		// Message, cut to fit before the close button
+		message := toast.message
+		room := rect.Width - 48
+		for message != "" && MeasureText(message) > room {
+			runes := []rune(message)
+			message = string(runes[:len(runes)-1])
+			if MeasureText(message+"…") <= room {
+				message += "…"
+				break
+			}
+		}
+		surface.DrawText(message, rect.X+14, rect.Y+10, c.textColor, c.fontSize)
+
+		closeRect := c.closeRect(rect)
+		cx, cy := closeRect.X+closeRect.Width/2, closeRect.Y+closeRect.Height/2
+		surface.DrawLine(cx-4, cy-4, cx+4, cy+4, c.textColor)
+		surface.DrawLine(cx-4, cy+4, cx+4, cy-4, c.textColor)
+
+		if toast.kind == ToastProgress {
+			track := Rect{X: rect.X + 14, Y: rect.Y + c.fontSize + 18, Width: rect.Width - 28, Height: 4}
+			surface.FillRect(track.X, track.Y, track.Width, track.Height, c.trackColor)
+			if toast.progress >= 0 {
+				surface.FillRect(track.X, track.Y, int(toast.progress*float64(track.Width)), track.Height, accent)
+			} else {
+				// Indeterminate: a segment sliding along the track
+				segment := track.Width / 4
+				offset := int(since(toast.created)/(10*time.Millisecond)) % (track.Width + segment)
+				if ReducedMotion() {
+					offset = (track.Width + segment) / 2
+				}
+				left := max(track.X, track.X+offset-segment)
+				right := min(track.X+track.Width, track.X+offset)
+				surface.FillRect(left, track.Y, right-left, track.Height, accent)
+			}
+		}
+
+		for i, r := range c.actionRects(toast, rect) {
+			if toast == c.pressedToast && i == c.pressedAction {
+				surface.FillRect(r.X, r.Y, r.Width, r.Height, c.trackColor)
+			}
+			surface.DrawRect(r.X, r.Y, r.Width, r.Height, c.buttonColor)
+			surface.DrawText(toast.actions[i].Label, r.X+8, r.Y+4, c.buttonColor, c.fontSize)
+		}
+	}
+
+	for _, child := range c.Children() {
+		child.Draw(surface)
+	}
+}
+
+// HandleMouseDown presses a toast's button, and takes any click on a toast
+// so it doesn't reach the UI underneath
+func (c *ToastCenter) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !c.IsVisible() {
+		return
+	}
+	toast, button := c.hitTest(x, y)
+	if toast == nil {
+		c.Node.HandleMouseDown(e)
+		return
+	}
+	c.pressedToast, c.pressedAction = toast, button
+	e.Consume()
+}
+
+// HandleMouseUp runs the pressed button if released over it
+func (c *ToastCenter) HandleMouseUp(e *Event) {
+	x, y := e.X, e.Y
+	if c.pressedToast == nil {
+		c.Node.HandleMouseUp(e)
+		return
+	}
+	pressed, action := c.pressedToast, c.pressedAction
+	c.pressedToast = nil
+	toast, button := c.hitTest(x, y)
+	if toast != pressed || button != action {
+		e.Consume()
+		return
+	}
+	switch {
+	case button == toastClose:
+		toast.Dismiss()
+	case button >= 0:
+		handler := toast.actions[button].Handler
+		toast.Dismiss()
+		if handler != nil {
+			handler()
+		}
+	}
+	c.prune()
+	e.Consume()
+}
+
+// HandleMouseMove tracks the hovered toast so it stays up
+func (c *ToastCenter) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	c.hovered, _ = c.hitTest(x, y)
+	if c.hovered != nil {
+		// Restart the hovered toast's time so it doesn't vanish on leaving
+		c.hovered.shown = Now()
+		e.Consume()
+		return
+	}
+	c.Node.HandleMouseMove(e)
+}
@@ -0,0 +1,214 @@
+package components
+
+import (
+	"image/color"
+	"time"
+)
+
+// toggleDuration is how long the knob takes to slide across
+const toggleDuration = 120 * time.Millisecond
+
+// ToggleSwitch is an on/off switch with a sliding knob and an optional label
+type ToggleSwitch struct {
+	*Node
+	on         bool
+	label      string
+	pressed    bool
+	focused    bool
+	fontSize   int
+	position   float64   // Knob position, 0 off to 1 on
+	from       float64   // Knob position the slide started at
+	changed    time.Time // When the slide started
+	onChange   func(bool)
+	offColor   color.RGBA
+	onColor    color.RGBA
+	knobColor  color.RGBA
+	textColor  color.RGBA
+	focusColor color.RGBA
+}
+
+// NewToggleSwitch creates a switch that is off
+func NewToggleSwitch(id string, label string) *ToggleSwitch {
+	t := &ToggleSwitch{
+		Node:       NewNode(id),
+		label:      label,
+		fontSize:   14,
+		offColor:   color.RGBA{190, 190, 190, 255},
+		onColor:    color.RGBA{60, 120, 220, 255},
+		knobColor:  color.RGBA{255, 255, 255, 255},
+		textColor:  color.RGBA{0, 0, 0, 255},
+		focusColor: color.RGBA{60, 120, 220, 120},
+	}
+	t.SetCursorShape(CursorPointer)
+	return t
+}
+
+// SetOn switches the switch on or off, sliding the knob across
+func (t *ToggleSwitch) SetOn(on bool) {
+	if on == t.on {
+		return
+	}
+	t.on = on
+	t.from = t.position
+	t.changed = Now()
+	if t.onChange != nil {
+		t.onChange(on)
+	}
+}
+
+// IsOn returns whether the switch is on
+func (t *ToggleSwitch) IsOn() bool {
+	return t.on
+}
+
+// Toggle flips the switch
+func (t *ToggleSwitch) Toggle() {
+	t.SetOn(!t.on)
+}
+
+// SetLabel sets the text shown beside the switch
+func (t *ToggleSwitch) SetLabel(label string) {
+	t.label = label
+}
+
+// Label returns the text shown beside the switch
+func (t *ToggleSwitch) Label() string {
+	return t.label
+}
+
+// SetOnChange sets the handler called when the switch is flipped
+func (t *ToggleSwitch) SetOnChange(handler func(bool)) {
+	t.onChange = handler
+}
+
+// ApplyTheme fills the track with the accent while the switch is on
+func (t *ToggleSwitch) ApplyTheme(theme Theme) {
+	t.onColor = theme.Accent
+	t.offColor = theme.Border
+	t.knobColor = theme.Surface
+	t.textColor = theme.Text
+	t.focusColor = color.RGBA{theme.Accent.R, theme.Accent.G, theme.Accent.B, 120}
+}
+
+// trackRect returns the bounds of the switch's track
+func (t *ToggleSwitch) trackRect() Rect {
+	bounds := t.ComputedBounds()
+	height := 20
+	return Rect{X: bounds.X, Y: bounds.Y + (bounds.Height-height)/2, Width: 36, Height: height}
+}
+
+// advance moves the knob toward the side the switch is on
+func (t *ToggleSwitch) advance() {
+	target := 0.0
+	if t.on {
+		target = 1
+	}
+	if t.position == target {
+		return
+	}
+	progress := 1.0
+	if duration := AnimationDuration(toggleDuration); duration > 0 {
+		progress = float64(since(t.changed)) / float64(duration)
+	}
+	if progress >= 1 {
+		t.position = target
+		return
+	}
+	// Ease out so the knob settles gently
+	progress = 1 - (1-progress)*(1-progress)
+	t.position = t.from + (target-t.from)*progress
+}
+
+// blend mixes two colors, 0 giving a and 1 giving b
+func blend(a, b color.RGBA, amount float64) color.RGBA {
+	mix := func(x, y uint8) uint8 {
+		return uint8(float64(x) + (float64(y)-float64(x))*amount)
+	}
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), mix(a.A, b.A)}
+}
+
+// Draw draws the rounded track, the knob and the label
+func (t *ToggleSwitch) Draw(surface DrawSurface) {
+	if !t.IsVisible() {
+		return
+	}
+	t.advance()
+	track := t.trackRect()
+	radius := track.Height / 2
+	fill := blend(t.offColor, t.onColor, t.position)
+
+	if t.focused {
+		surface.FillCircle(track.X+radius, track.Y+radius, radius+2, t.focusColor)
+		surface.FillCircle(track.X+track.Width-radius, track.Y+radius, radius+2, t.focusColor)
+		surface.FillRect(track.X+radius, track.Y-2, track.Width-2*radius, track.Height+4, t.focusColor)
+	}
+	surface.FillCircle(track.X+radius, track.Y+radius, radius, fill)
+	surface.FillCircle(track.X+track.Width-radius, track.Y+radius, radius, fill)
+	surface.FillRect(track.X+radius, track.Y, track.Width-2*radius, track.Height, fill)
+
+	knobX := track.X + radius + int(t.position*float64(track.Width-2*radius))
+	surface.FillCircle(knobX, track.Y+radius, radius-3, t.knobColor)
+
+	if t.label != "" {
+		bounds := t.ComputedBounds()
+		surface.DrawText(t.label, track.X+track.Width+8, bounds.Y+(bounds.Height-t.fontSize)/2, t.textColor, t.fontSize)
+	}
+}
+
+// HandleMouseDown flips the switch when it, or its label, is pressed
+func (t *ToggleSwitch) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !t.IsVisible() {
+		return
+	}
+	if t.pressed {
+		e.Consume()
+		return
+	}
+	if !PointInRect(Point{x, y}, t.ComputedBounds()) {
+		t.focused = false
+		return
+	}
+	t.pressed = true
+	t.focused = true
+	t.Toggle()
+	e.Consume()
+}
+
+// HandleMouseUp ends a press
+func (t *ToggleSwitch) HandleMouseUp(e *Event) {
+	if !t.pressed {
+		return
+	}
+	t.pressed = false
+	e.Consume()
+}
+
+// Focus gives the switch keyboard focus
+func (t *ToggleSwitch) Focus() {
+	t.focused = true
+}
+
+// Blur removes keyboard focus from the switch
+func (t *ToggleSwitch) Blur() {
+	t.focused = false
+}
+
+// IsFocused returns whether the switch receives keys
+func (t *ToggleSwitch) IsFocused() bool {
+	return t.focused
+}
+
+// HandleKeyDown flips the switch with Space or Enter
+func (t *ToggleSwitch) HandleKeyDown(event InputEvent) bool {
+	if !t.focused || event.Type != InputTypeKeyDown {
+		return false
+	}
+	switch event.Key {
+	case KeySpace, KeyEnter:
+		t.Toggle()
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,397 @@
+package components
+
+import (
+	"image"
+	"image/color"
+)
+
+// ToolbarItemKind is the kind of entry in a toolbar
+type ToolbarItemKind int
+
+const (
+	ToolbarButton ToolbarItemKind = iota
+	ToolbarToggle
+	ToolbarSeparator
+)
+
+// ToolbarItem is a button, toggle button or separator in a toolbar
+type ToolbarItem struct {
+	Kind     ToolbarItemKind
+	Label    string // Shown when there is no icon, and in the overflow menu
+	Icon     image.Image
+	Disabled bool
+	Toggled  bool
+	Command  *Command // When set, the label, icon and enabled state follow the command
+	OnClick  func()
+	OnToggle func(bool)
+}
+
+// syncCommand copies the item's display state from its command
+func (item *ToolbarItem) syncCommand() {
+	if item.Command == nil {
+		return
+	}
+	item.Label = item.Command.Title
+	item.Icon = item.Command.Icon
+	item.Disabled = !item.Command.IsEnabled()
+}
+
+// activate clicks a button or flips a toggle
+func (item *ToolbarItem) activate() {
+	item.syncCommand()
+	if item.Disabled {
+		return
+	}
+	switch item.Kind {
+	case ToolbarToggle:
+		item.Toggled = !item.Toggled
+		if item.OnToggle != nil {
+			item.OnToggle(item.Toggled)
+		}
+	case ToolbarButton:
+		if item.Command != nil {
+			item.Command.Execute()
+		}
+		if item.OnClick != nil {
+			item.OnClick()
+		}
+	}
+}
+
+// Toolbar lays out buttons in a row. Items that don't fit move into a menu
+// opened from a "more" button at the end.
+type Toolbar struct {
+	*Node
+	items           []*ToolbarItem
+	visibleCount    int // Items shown in the bar; the rest overflow
+	pressed         int // Item the mouse went down on, -1 for none, or toolbarMore/toolbarIgnored
+	hovered         int
+	more            *PopupMenu
+	spacing         int
+	fontSize        int
+	backgroundColor color.RGBA
+	hoverColor      color.RGBA
+	pressedColor    color.RGBA
+	toggledColor    color.RGBA
+	textColor       color.RGBA
+	disabledColor   color.RGBA
+	separatorColor  color.RGBA
+}
+
+// Pressed values for the more button, and for a press that closed the
+// overflow menu and so shouldn't click anything when released
+const (
+	toolbarMore    = -2
+	toolbarIgnored = -3
+)
+
+// NewToolbar creates an empty toolbar
+func NewToolbar(id string) *Toolbar {
+	t := &Toolbar{
+		Node:            NewNode(id),
+		pressed:         -1,
+		hovered:         -1,
+		more:            NewPopupMenu(id + "_more"),
+		spacing:         2,
+		fontSize:        13,
+		backgroundColor: color.RGBA{240, 240, 240, 255},
+		hoverColor:      color.RGBA{220, 225, 235, 255},
+		pressedColor:    color.RGBA{195, 205, 225, 255},
+		toggledColor:    color.RGBA{200, 215, 240, 255},
+		textColor:       color.RGBA{0, 0, 0, 255},
+		disabledColor:   color.RGBA{160, 160, 160, 255},
+		separatorColor:  color.RGBA{190, 190, 190, 255},
+	}
+	t.AddChild(t.more)
+	return t
+}
+
+// add appends an item and lays out the toolbar again
+func (t *Toolbar) add(item *ToolbarItem) *ToolbarItem {
+	t.items = append(t.items, item)
+	t.layout()
+	return item
+}
+
+// AddButton adds a button with an icon; the label is used when the icon is nil
+// and in the overflow menu
+func (t *Toolbar) AddButton(label string, icon image.Image, handler func()) *ToolbarItem {
+	return t.add(&ToolbarItem{Kind: ToolbarButton, Label: label, Icon: icon, OnClick: handler})
+}
+
+// AddToggle adds a button that stays pressed until clicked again
+func (t *Toolbar) AddToggle(label string, icon image.Image, toggled bool, handler func(bool)) *ToolbarItem {
+	return t.add(&ToolbarItem{Kind: ToolbarToggle, Label: label, Icon: icon, Toggled: toggled, OnToggle: handler})
+}
+
+// AddCommand adds a button that runs a command and shows its current state
+func (t *Toolbar) AddCommand(command *Command) *ToolbarItem {
+	item := &ToolbarItem{Kind: ToolbarButton, Command: command}
+	item.syncCommand()
+	return t.add(item)
+}
+
+// AddSeparator adds a divider between groups of buttons
+func (t *Toolbar) AddSeparator() {
+	t.add(&ToolbarItem{Kind: ToolbarSeparator})
+}
+
+// Items returns the toolbar's items
+func (t *Toolbar) Items() []*ToolbarItem {
+	return t.items
+}
+
+// OverflowCount returns how many items are in the "more" menu
+func (t *Toolbar) OverflowCount() int {
+	return len(t.items) - t.visibleCount
+}
+
+// SetBounds sets the bounds and works out which items fit
+func (t *Toolbar) SetBounds(bounds Rect) {
+	t.Node.SetBounds(bounds)
+	t.layout()
+}
+
+// itemWidth returns the width of an item in the bar
+func (t *Toolbar) itemWidth(item *ToolbarItem) int {
+	height := t.Bounds().Height
+	switch {
+	case item.Kind == ToolbarSeparator:
+		return 9
+	case item.Icon != nil:
+		return height
+	default:
+		return max(height, MeasureText(item.Label)+16)
+	}
+}
+
+// layout decides how many items fit, leaving room for the more button if
+// any don't
+func (t *Toolbar) layout() {
+	bounds := t.Bounds()
+	total := 0
+	for _, item := range t.items {
+		item.syncCommand()
+		total += t.itemWidth(item) + t.spacing
+	}
+	if total <= bounds.Width {
+		t.visibleCount = len(t.items)
+		return
+	}
+
+	available := bounds.Width - bounds.Height - t.spacing
+	used := 0
+	t.visibleCount = 0
+	for _, item := range t.items {
+		width := t.itemWidth(item) + t.spacing
+		if used+width > available {
+			break
+		}
+		used += width
+		t.visibleCount++
+	}
+
+	// Don't end the bar on a separator
+	for t.visibleCount > 0 && t.items[t.visibleCount-1].Kind == ToolbarSeparator {
+		t.visibleCount--
+	}
+}
+
+// itemRect returns the bounds of a visible item
+func (t *Toolbar) itemRect(index int) Rect {
+	bounds := t.ComputedBounds()
+	x := bounds.X
+	for i := 0; i < index; i++ {
+		x += t.itemWidth(t.items[i]) + t.spacing
+	}
+	return Rect{X: x, Y: bounds.Y, Width: t.itemWidth(t.items[index]), Height: bounds.Height}
+}
+
+// moreRect returns the bounds of the more button
+func (t *Toolbar) moreRect() Rect {
+	bounds := t.ComputedBounds()
+	return Rect{X: bounds.X + bounds.Width - bounds.Height, Y: bounds.Y, Width: bounds.Height, Height: bounds.Height}
+}
+
+// itemAt returns the index of the visible item at a point, toolbarMore for
+// the more button, or -1
+func (t *Toolbar) itemAt(x, y int) int {
+	p := Point{x, y}
+	if t.visibleCount < len(t.items) && PointInRect(p, t.moreRect()) {
+		return toolbarMore
+	}
+	for i := 0; i < t.visibleCount; i++ {
+		if t.items[i].Kind != ToolbarSeparator && PointInRect(p, t.itemRect(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// openMore fills the overflow menu with the items that don't fit and shows it
+func (t *Toolbar) openMore() {
+	t.more.Clear()
+	for _, item := range t.items[t.visibleCount:] {
+		item := item
+		switch item.Kind {
+		case ToolbarSeparator:
+			t.more.AddSeparator()
+		case ToolbarToggle:
+			entry := t.more.AddCheckItem(item.Label, item.Toggled, item.activate)
+			entry.Icon = item.Icon
+			entry.Disabled = item.Disabled
+		default:
+			if item.Command != nil && item.OnClick == nil {
+				t.more.AddCommand(item.Command)
+				continue
+			}
+			entry := t.more.AddIconItem(item.Label, item.Icon, item.activate)
+			entry.Disabled = item.Disabled
+		}
+	}
+
+	// Line the menu up with the right edge of the more button
+	t.more.updateSize()
+	rect := t.moreRect()
+	t.more.place(rect.X+rect.Width-t.more.Bounds().Width, rect.Y+rect.Height, rect.X+rect.Width, rect.Y)
+}
+
+// Draw draws the buttons, separators, the more button and its menu
+func (t *Toolbar) Draw(surface DrawSurface) {
+	if !t.IsVisible() {
+		return
+	}
+
+	bounds := t.ComputedBounds()
+	surface.FillRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, t.backgroundColor)
+
+	for i := 0; i < t.visibleCount; i++ {
+		item := t.items[i]
+		item.syncCommand()
+		rect := t.itemRect(i)
+
+		if item.Kind == ToolbarSeparator {
+			x := rect.X + rect.Width/2
+			surface.DrawLine(x, rect.Y+4, x, rect.Y+rect.Height-4, t.separatorColor)
+			continue
+		}
+		t.drawButton(surface, rect, i, item.Toggled, item.Disabled)
+
+		if item.Icon != nil {
+			inset := 4
+			surface.DrawImage(item.Icon, rect.X+inset, rect.Y+inset, rect.Width-inset*2, rect.Height-inset*2, ImageFitContain)
+		} else {
+			clr := t.textColor
+			if item.Disabled {
+				clr = t.disabledColor
+			}
+			surface.DrawText(item.Label, rect.X+8, rect.Y+(rect.Height-t.fontSize)/2, clr, t.fontSize)
+		}
+	}
+
+	if t.visibleCount < len(t.items) {
+		rect := t.moreRect()
+		t.drawButton(surface, rect, toolbarMore, t.more.IsOpen(), false)
+		cx, cy := rect.X+rect.Width/2, rect.Y+rect.Height/2
+		for dx := -5; dx <= 5; dx += 5 {
+			surface.FillCircle(cx+dx, cy, 1, t.textColor)
+		}
+	}
+
+	t.more.Draw(surface)
+}
+
+// drawButton draws the background of a button for its hover, press and toggle state
+func (t *Toolbar) drawButton(surface DrawSurface, rect Rect, index int, toggled, disabled bool) {
+	switch {
+	case disabled:
+		return
+	case t.pressed == index && t.hovered == index:
+		surface.FillRect(rect.X, rect.Y+2, rect.Width, rect.Height-4, t.pressedColor)
+	case toggled:
+		surface.FillRect(rect.X, rect.Y+2, rect.Width, rect.Height-4, t.toggledColor)
+	case t.hovered == index:
+		surface.FillRect(rect.X, rect.Y+2, rect.Width, rect.Height-4, t.hoverColor)
+	default:
+		return
+	}
+	surface.DrawRect(rect.X, rect.Y+2, rect.Width, rect.Height-4, t.separatorColor)
+}
+
+// HandleMouseDown presses the button under the pointer; it is clicked when
+// the mouse is released over it
+func (t *Toolbar) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !t.IsVisible() {
+		return
+	}
+	if t.pressed != -1 {
+		e.Consume()
+		return
+	}
+	if t.more.IsOpen() {
+		if t.itemAt(x, y) == toolbarMore {
+			t.more.Hide()
+			t.pressed = toolbarIgnored
+			e.Consume()
+			return
+		}
+		if t.more.HandleMouseDown(e); e.Consumed() {
+			return
+		}
+	}
+
+	index := t.itemAt(x, y)
+	if index == -1 {
+		if PointInRect(Point{x, y}, t.ComputedBounds()) {
+			e.Consume()
+		}
+		return
+	}
+	if index >= 0 && t.items[index].Disabled {
+		e.Consume()
+		return
+	}
+	t.pressed = index
+	e.Consume()
+}
+
+// HandleMouseUp clicks the pressed button if the pointer is still over it
+func (t *Toolbar) HandleMouseUp(e *Event) {
+	x, y := e.X, e.Y
+	if t.more.IsOpen() {
+		if t.more.HandleMouseUp(e); e.Consumed() {
+			return
+		}
+	}
+
+	pressed := t.pressed
+	t.pressed = -1
+	if pressed == -1 || t.itemAt(x, y) != pressed {
+		return
+	}
+
+	if pressed == toolbarMore {
+		t.openMore()
+	} else {
+		t.items[pressed].activate()
+	}
+	e.Consume()
+}
+
+// HandleMouseMove tracks the hovered button
+func (t *Toolbar) HandleMouseMove(e *Event) {
+	x, y := e.X, e.Y
+	if !t.IsVisible() {
+		return
+	}
+	if t.more.IsOpen() {
+		if t.more.HandleMouseMove(e); e.Consumed() {
+			return
+		}
+	}
+	t.hovered = t.itemAt(x, y)
+	if t.hovered != -1 {
+		e.Consume()
+	}
+}
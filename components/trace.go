@@ -0,0 +1,268 @@
+package components
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Trace actions
+const (
+	TraceVisit    = "visit"    // A new screen was shown
+	TraceClick    = "click"    // An element was clicked
+	TraceType     = "type"     // Keys went to an element
+	TraceScroll   = "scroll"   // An element was scrolled
+	TraceDrop     = "drop"     // Files were dropped on an element
+	TraceShortcut = "shortcut" // A keyboard shortcut ran
+)
+
+// TraceStep is one thing the user did during a traced flow
+type TraceStep struct {
+	Screen   string        `json:"screen"`
+	Action   string        `json:"action"`
+	Target   string        `json:"target,omitempty"` // e.g. `Button "Save"`
+	At       time.Duration `json:"at"`               // Since the trace started
+	Duration time.Duration `json:"duration"`         // Until the next step, or the end
+}
+
+// key identifies a step across app versions, ignoring timing
+func (s TraceStep) key() string {
+	return s.Screen + "\x00" + s.Action + "\x00" + s.Target
+}
+
+// describe returns the step as "click Button "Save"" or "visit Checkout"
+func (s TraceStep) describe() string {
+	switch {
+	case s.Action == TraceVisit:
+		return "visit " + s.Screen
+	case s.Target == "":
+		return s.Action
+	}
+	return s.Action + " " + s.Target
+}
+
+// Trace is a recorded user flow. Steps name elements by type and text
+// rather than by ID, so traces from two versions of an app can be compared.
+// It can be saved and loaded with encoding/json.
+type Trace struct {
+	Name     string        `json:"name"`
+	Steps    []TraceStep   `json:"steps"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Screens returns the screens visited, in order
+func (t *Trace) Screens() []string {
+	var screens []string
+	for _, step := range t.Steps {
+		if len(screens) == 0 || screens[len(screens)-1] != step.Screen {
+			screens = append(screens, step.Screen)
+		}
+	}
+	return screens
+}
+
+// TraceRecorder records the steps of a flow as the user goes through it.
+// Consecutive typing into, or scrolling of, one element is a single step.
+type TraceRecorder struct {
+	trace     *Trace
+	started   time.Time
+	screen    string
+	recording bool
+}
+
+// NewTraceRecorder creates a recorder that isn't recording yet
+func NewTraceRecorder() *TraceRecorder {
+	return &TraceRecorder{}
+}
+
+// Start begins a new trace, keeping the current screen
+func (r *TraceRecorder) Start(name string) {
+	r.trace = &Trace{Name: name}
+	r.started = Now()
+	r.recording = true
+}
+
+// Stop ends the trace and returns it, or nil if none was recording
+func (r *TraceRecorder) Stop() *Trace {
+	if !r.recording {
+		return nil
+	}
+	r.recording = false
+	r.trace.Duration = since(r.started)
+	r.closeStep(r.trace.Duration)
+	return r.trace
+}
+
+// IsRecording returns whether a trace is being recorded
+func (r *TraceRecorder) IsRecording() bool {
+	return r.recording
+}
+
+// SetScreen records that a screen was shown; later steps happen on it
+func (r *TraceRecorder) SetScreen(name string) {
+	if name == r.screen {
+		return
+	}
+	r.screen = name
+	r.add(TraceVisit, "")
+}
+
+// Record adds a step acting on an element; the element may be nil
+func (r *TraceRecorder) Record(action string, target Element) {
+	r.add(action, DescribeElement(target))
+}
+
+// RecordShortcut adds a step for a shortcut key run from the keyboard
+func (r *TraceRecorder) RecordShortcut(event InputEvent) {
+	var parts []string
+	if event.CtrlDown {
+		parts = append(parts, "Ctrl")
+	}
+	if event.AltDown {
+		parts = append(parts, "Alt")
+	}
+	if event.ShiftDown {
+		parts = append(parts, "Shift")
+	}
+	r.add(TraceShortcut, strings.Join(append(parts, event.Key.String()), "+"))
+}
+
+// add appends a step, merging repeated typing and scrolling
+func (r *TraceRecorder) add(action, target string) {
+	if !r.recording {
+		return
+	}
+	steps := r.trace.Steps
+	if n := len(steps); n > 0 && (action == TraceType || action == TraceScroll) {
+		last := steps[n-1]
+		if last.Action == action && last.Target == target && last.Screen == r.screen {
+			return
+		}
+	}
+	at := since(r.started)
+	r.closeStep(at)
+	r.trace.Steps = append(steps, TraceStep{Screen: r.screen, Action: action, Target: target, At: at})
+}
+
+// closeStep sets the duration of the last step to end at the given time
+func (r *TraceRecorder) closeStep(at time.Duration) {
+	if n := len(r.trace.Steps); n > 0 {
+		last := &r.trace.Steps[n-1]
+		last.Duration = at - last.At
+	}
+}
+
+// DescribeElement names an element by its type and text, such as
+// `Button "Save"`, falling back to its ID for elements without text
+func DescribeElement(element Element) string {
+	if element == nil {
+		return ""
+	}
+	name := reflect.TypeOf(element).String()
+	name = name[strings.LastIndex(name, ".")+1:]
+
+	var text string
+	switch e := element.(type) {
+	case interface{ GetText() string }:
+		text = e.GetText()
+	case interface{ Text() string }:
+		text = e.Text()
+	}
+	if text = strings.TrimSpace(text); text != "" {
+		if runes := []rune(text); len(runes) > 30 {
+			text = string(runes[:29]) + "…"
+		}
+		return fmt.Sprintf("%s %q", name, text)
+	}
+	return name + " #" + element.ID()
+}
+
+// TraceChange is a step of one trace matched with the other
+type TraceChange struct {
+	Before *TraceStep // nil if the step was added
+	After  *TraceStep // nil if the step was removed
+}
+
+// TraceComparison lines up the steps of two traces of the same flow
+type TraceComparison struct {
+	Before  *Trace
+	After   *Trace
+	Changes []TraceChange
+}
+
+// CompareTraces matches the steps two traces have in common, in order, and
+// marks the rest as removed or added
+func CompareTraces(before, after *Trace) TraceComparison {
+	a, b := before.Steps, after.Steps
+
+	// Longest common subsequence of step keys
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i].key() == b[j].key() {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	c := TraceComparison{Before: before, After: after}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i].key() == b[j].key():
+			c.Changes = append(c.Changes, TraceChange{Before: &a[i], After: &b[j]})
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lengths[i][j+1] >= lengths[i+1][j]):
+			c.Changes = append(c.Changes, TraceChange{After: &b[j]})
+			j++
+		default:
+			c.Changes = append(c.Changes, TraceChange{Before: &a[i]})
+			i++
+		}
+	}
+	return c
+}
+
+// Report returns the comparison as text for a UX review: totals, the
+// screens visited, then every step marked = kept, - removed or + added
+// with its time in each version
+func (c TraceComparison) Report() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Flow %q compared with %q\n", c.Before.Name, c.After.Name)
+	fmt.Fprintf(&b, "Steps: %d -> %d\n", len(c.Before.Steps), len(c.After.Steps))
+	fmt.Fprintf(&b, "Time: %s -> %s\n", formatTraceTime(c.Before.Duration), formatTraceTime(c.After.Duration))
+	fmt.Fprintf(&b, "Screens: %s -> %s\n\n", strings.Join(c.Before.Screens(), ", "), strings.Join(c.After.Screens(), ", "))
+
+	width := 0
+	for _, change := range c.Changes {
+		step := change.Before
+		if step == nil {
+			step = change.After
+		}
+		width = max(width, len([]rune(step.describe())))
+	}
+	for _, change := range c.Changes {
+		switch {
+		case change.Before == nil:
+			fmt.Fprintf(&b, "+ %-*s  %s\n", width, change.After.describe(), formatTraceTime(change.After.Duration))
+		case change.After == nil:
+			fmt.Fprintf(&b, "- %-*s  %s\n", width, change.Before.describe(), formatTraceTime(change.Before.Duration))
+		default:
+			fmt.Fprintf(&b, "= %-*s  %s -> %s\n", width, change.Before.describe(),
+				formatTraceTime(change.Before.Duration), formatTraceTime(change.After.Duration))
+		}
+	}
+	return b.String()
+}
+
+// formatTraceTime formats a step time to a tenth of a second
+func formatTraceTime(d time.Duration) string {
+	return fmt.Sprintf("%.1fs", d.Seconds())
+}
@@ -0,0 +1,322 @@
+package components
+
+import (
+	"image/color"
+)
+
+// TreeItem is a node in a TreeView's model. Items are compared by identity,
+// so implementations should be pointer types.
+type TreeItem interface {
+	Label() string
+	Items() []TreeItem
+}
+
+// treeRow is a visible row of a TreeView
+type treeRow struct {
+	item  TreeItem
+	depth int
+}
+
+// TreeView shows a hierarchy of items with expandable branches
+type TreeView struct {
+	*Node
+	items         []TreeItem
+	expanded      map[TreeItem]bool
+	selected      TreeItem
+	cursor        int // Row with keyboard focus
+	scroll        int // First visible row
+	rowHeight     int
+	indent        int
+	fontSize      int
+	focused       bool
+	onSelect      func(TreeItem)
+	textColor     color.RGBA
+	selectedColor color.RGBA
+	cursorColor   color.RGBA
+	borderColor   color.RGBA
+}
+
+// NewTreeView creates an empty tree view
+func NewTreeView(id string) *TreeView {
+	return &TreeView{
+		Node:          NewNode(id),
+		expanded:      make(map[TreeItem]bool),
+		rowHeight:     22,
+		indent:        16,
+		fontSize:      13,
+		textColor:     color.RGBA{0, 0, 0, 255},
+		selectedColor: color.RGBA{200, 220, 255, 255},
+		cursorColor:   color.RGBA{70, 130, 180, 255},
+		borderColor:   color.RGBA{200, 200, 200, 255},
+	}
+}
+
+// SetItems sets the top-level items
+func (t *TreeView) SetItems(items []TreeItem) {
+	t.items = items
+	t.cursor = 0
+	t.scroll = 0
+}
+
+// Items returns the top-level items
+func (t *TreeView) Items() []TreeItem {
+	return t.items
+}
+
+// Expand opens a branch
+func (t *TreeView) Expand(item TreeItem) {
+	t.expanded[item] = true
+}
+
+// Collapse closes a branch
+func (t *TreeView) Collapse(item TreeItem) {
+	delete(t.expanded, item)
+	t.clampCursor()
+}
+
+// IsExpanded returns whether a branch is open
+func (t *TreeView) IsExpanded(item TreeItem) bool {
+	return t.expanded[item]
+}
+
+// ExpandAll opens every branch
+func (t *TreeView) ExpandAll() {
+	var walk func(items []TreeItem)
+	walk = func(items []TreeItem) {
+		for _, item := range items {
+			if children := item.Items(); len(children) > 0 {
+				t.expanded[item] = true
+				walk(children)
+			}
+		}
+	}
+	walk(t.items)
+}
+
+// Select selects an item and calls the select handler
+func (t *TreeView) Select(item TreeItem) {
+	t.selected = item
+	for i, row := range t.rows() {
+		if row.item == item {
+			t.cursor = i
+			t.scrollToCursor()
+		}
+	}
+	if t.onSelect != nil {
+		t.onSelect(item)
+	}
+}
+
+// Selected returns the selected item, or nil
+func (t *TreeView) Selected() TreeItem {
+	return t.selected
+}
+
+// SetOnSelect sets the handler called when an item is selected
+func (t *TreeView) SetOnSelect(handler func(TreeItem)) {
+	t.onSelect = handler
+}
+
+// rows flattens the expanded part of the tree
+func (t *TreeView) rows() []treeRow {
+	var rows []treeRow
+	var walk func(items []TreeItem, depth int)
+	walk = func(items []TreeItem, depth int) {
+		for _, item := range items {
+			rows = append(rows, treeRow{item: item, depth: depth})
+			if t.expanded[item] {
+				walk(item.Items(), depth+1)
+			}
+		}
+	}
+	walk(t.items, 0)
+	return rows
+}
+
+// scaledRowHeight returns the row height, grown to fit the text scale
+func (t *TreeView) scaledRowHeight() int {
+	return max(t.rowHeight, ScaleTextSize(t.fontSize)+6)
+}
+
+// visibleRows returns how many rows fit
+func (t *TreeView) visibleRows() int {
+	return max(1, t.Bounds().Height/t.scaledRowHeight())
+}
+
+// clampCursor keeps the cursor and scroll position within the rows
+func (t *TreeView) clampCursor() {
+	count := len(t.rows())
+	t.cursor = max(0, min(t.cursor, count-1))
+	t.scroll = max(0, min(t.scroll, count-t.visibleRows()))
+}
+
+// ScrollOffset returns the first visible row for saving in a session
+func (t *TreeView) ScrollOffset() Point {
+	return Point{X: 0, Y: t.scroll}
+}
+
+// SetScrollOffset restores a saved first visible row
+func (t *TreeView) SetScrollOffset(offset Point) {
+	t.scroll = offset.Y
+	t.clampCursor()
+}
+
+// scrollToCursor scrolls so the cursor row is visible
+func (t *TreeView) scrollToCursor() {
+	if t.cursor < t.scroll {
+		t.scroll = t.cursor
+	} else if t.cursor >= t.scroll+t.visibleRows() {
+		t.scroll = t.cursor - t.visibleRows() + 1
+	}
+}
+
+// Focus gives the tree keyboard focus
+func (t *TreeView) Focus() {
+	t.focused = true
+}
+
+// Blur removes keyboard focus from the tree
+func (t *TreeView) Blur() {
+	t.focused = false
+}
+
+// IsFocused returns whether the tree receives navigation keys
+func (t *TreeView) IsFocused() bool {
+	return t.focused
+}
+
+// Draw draws the visible rows with expand arrows and indentation
+func (t *TreeView) Draw(surface DrawSurface) {
+	if !t.IsVisible() {
+		return
+	}
+
+	bounds := t.ComputedBounds()
+	surface.DrawRect(bounds.X, bounds.Y, bounds.Width, bounds.Height, t.borderColor)
+	surface.SetClipRect(bounds.X, bounds.Y, bounds.Width, bounds.Height)
+
+	rows := t.rows()
+	for i := t.scroll; i < len(rows) && i < t.scroll+t.visibleRows(); i++ {
+		row := rows[i]
+		y := bounds.Y + (i-t.scroll)*t.scaledRowHeight()
+		if row.item == t.selected {
+			surface.FillRect(bounds.X+1, y, bounds.Width-2, t.scaledRowHeight(), t.selectedColor)
+		}
+		if t.focused && i == t.cursor {
+			surface.DrawRect(bounds.X+1, y, bounds.Width-3, t.scaledRowHeight()-1, t.cursorColor)
+		}
+
+		x := bounds.X + 6 + row.depth*t.indent
+		cy := y + t.scaledRowHeight()/2
+		if len(row.item.Items()) > 0 {
+			if t.expanded[row.item] {
+				surface.DrawLine(x, cy-2, x+4, cy+2, t.textColor)
+				surface.DrawLine(x+4, cy+2, x+8, cy-2, t.textColor)
+			} else {
+				surface.DrawLine(x+2, cy-4, x+6, cy, t.textColor)
+				surface.DrawLine(x+6, cy, x+2, cy+4, t.textColor)
+			}
+		}
+		surface.DrawText(row.item.Label(), x+14, y+(t.scaledRowHeight()-t.fontSize)/2, t.textColor, t.fontSize)
+	}
+	surface.ResetClipRect()
+}
+
+// HandleMouseDown toggles a branch when its arrow is clicked and selects
+// the item when its label is clicked
+func (t *TreeView) HandleMouseDown(e *Event) {
+	x, y := e.X, e.Y
+	if !t.IsVisible() {
+		return
+	}
+	bounds := t.ComputedBounds()
+	if !PointInRect(Point{x, y}, bounds) {
+		t.focused = false
+		return
+	}
+	t.focused = true
+
+	rows := t.rows()
+	index := t.scroll + (y-bounds.Y)/t.scaledRowHeight()
+	if index < 0 || index >= len(rows) {
+		e.Consume()
+		return
+	}
+	row := rows[index]
+	t.cursor = index
+
+	arrowX := bounds.X + 6 + row.depth*t.indent
+	if len(row.item.Items()) > 0 && x < arrowX+12 {
+		if t.expanded[row.item] {
+			t.Collapse(row.item)
+		} else {
+			t.Expand(row.item)
+		}
+		e.Consume()
+		return
+	}
+	t.Select(row.item)
+	e.Consume()
+}
+
+// HandleScroll scrolls the rows with the mouse wheel
+func (t *TreeView) HandleScroll(x, y int, deltaX, deltaY float64) bool {
+	if !t.IsVisible() || !PointInRect(Point{x, y}, t.ComputedBounds()) {
+		return false
+	}
+	t.scroll -= int(deltaY * 3)
+	t.clampCursor()
+	return true
+}
+
+// HandleKeyDown moves between rows, opens and closes branches with
+// Right/Left and selects with Enter or Space while focused
+func (t *TreeView) HandleKeyDown(event InputEvent) bool {
+	if !t.focused || !t.IsVisible() || event.Type != InputTypeKeyDown {
+		return false
+	}
+
+	rows := t.rows()
+	if len(rows) == 0 {
+		return false
+	}
+	row := rows[t.cursor]
+
+	switch event.Key {
+	case KeyRight:
+		if len(row.item.Items()) > 0 {
+			if !t.expanded[row.item] {
+				t.Expand(row.item)
+			} else {
+				t.cursor++
+				t.scrollToCursor()
+			}
+		}
+		return true
+	case KeyLeft:
+		if t.expanded[row.item] {
+			t.Collapse(row.item)
+			return true
+		}
+		// Move to the parent
+		for i := t.cursor - 1; i >= 0; i-- {
+			if rows[i].depth < row.depth {
+				t.cursor = i
+				t.scrollToCursor()
+				break
+			}
+		}
+		return true
+	case KeyEnter, KeySpace:
+		t.Select(row.item)
+		return true
+	}
+
+	position, ok := NavigateList(event, t.cursor, len(rows), t.visibleRows())
+	if !ok {
+		return false
+	}
+	t.cursor = position
+	t.scrollToCursor()
+	return true
+}
@@ -114,14 +114,14 @@ func (g *Game) handleInput() {
 	
 	// Handle mouse events
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		g.rootContainer.HandleMouseDown(x, y)
+		components.DispatchEvent(g.rootContainer, components.NewMouseEvent(components.InputTypeMouseDown, x, y))
 	}
 	
 	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
-		g.rootContainer.HandleMouseUp(x, y)
+		components.DispatchEvent(g.rootContainer, components.NewMouseEvent(components.InputTypeMouseUp, x, y))
 	}
 	
-	g.rootContainer.HandleMouseMove(x, y)
+	components.DispatchEvent(g.rootContainer, components.NewMouseEvent(components.InputTypeMouseMove, x, y))
 }
 
 func main() {
@@ -21,10 +21,11 @@ func main() {
 	ui.SetPageConfig("Todo List App", "column")
 	
 	// Create reactive state
-	todos := ui.State([]TodoItem{
+	todos := finch.NewState([]TodoItem{
 		{ID: "todo_1", Text: "Buy groceries", Done: false},
 		{ID: "todo_2", Text: "Finish project", Done: false},
 	})
+	nextID := 3 // IDs stay unique after todos are removed
 	
 	// Add a title
 	ui.Title("Todo List").Centered().Size(24)
@@ -32,7 +33,7 @@ func main() {
 	// Create input area
 	ui.Container().Layout("row", func(c *finch.Container) {
 		// Add a text input
-		input := c.TextInput("Enter a new todo...")
+		input := c.TextInput("Enter a new todo...").SingleLine(true)
 		
 		// Add a button
 		c.Button("Add").OnClick(func() {
@@ -41,24 +42,24 @@ func main() {
 			
 			if text != "" {
 				// Add a new todo
-				todos.Update(func(value interface{}) interface{} {
-					items := value.([]TodoItem)
+				todos.Update(func(items []TodoItem) []TodoItem {
 					newItem := TodoItem{
-						ID:   fmt.Sprintf("todo_%d", len(items)+1),
+						ID:   fmt.Sprintf("todo_%d", nextID),
 						Text: text,
 						Done: false,
 					}
+					nextID++
 					return append(items, newItem)
 				})
 				
 				// Clear the input
 				input.Clear()
 			}
-		})
+		}).Shortcut("enter") // Enter adds the todo too
 	})
 	
-	// Create todo list container
-	listContainer := ui.Container().Background("#ffffff").Margin(10).Padding(10).Grow(1)
+	// Create todo list container, scrolling once the todos don't fit
+	listContainer := ui.Container().Background("#ffffff").Margin(10).Padding(10).Grow(1).Scrollable()
 	
 	// Create status text
 	statusText := ui.Text("")
@@ -67,8 +68,7 @@ func main() {
 	ui.Container().Layout("row", func(c *finch.Container) {
 		// Add a clear completed button
 		c.Button("Clear Completed").OnClick(func() {
-			todos.Update(func(value interface{}) interface{} {
-				items := value.([]TodoItem)
+			todos.Update(func(items []TodoItem) []TodoItem {
 				result := []TodoItem{}
 				
 				// Filter out completed todos
@@ -83,59 +83,66 @@ func main() {
 		})
 	})
 	
-	// Watch for changes to todos
-	todos.Watch(func(value interface{}) {
-		items := value.([]TodoItem)
-		
-		// Update the list container with the current todos
-		listContainer.RemoveAllChildren()
-		
-		// Add each todo as a row
-		for i, item := range items {
-			index := i // Capture the index for closure
+	// Show a row per todo; rows are matched to todos by ID, so only the
+	// rows of added, removed or changed todos are rebuilt
+	listContainer.Layout("column", func(c *finch.Container) {
+		finch.ForEach(ui, todos, func(item TodoItem) string { return item.ID }, func(row *finch.Container, item TodoItem) {
+			// Add checkbox
+			checkbox := row.Checkbox(item.Text)
+			checkbox.SetValue(item.Done)
 			
-			// Create a row for this todo
-			listContainer.Layout("row", func(row *finch.Container) {
-				// Add checkbox
-				checkbox := row.Checkbox(item.Text)
-				checkbox.SetValue(item.Done)
-				
-				// Handle checkbox changes
-				checkbox.OnChange(func(checked bool) {
-					todos.Update(func(value interface{}) interface{} {
-						items := value.([]TodoItem)
-						items[index].Done = checked
-						return items
-					})
+			// Handle checkbox changes
+			checkbox.OnChange(func(checked bool) {
+				todos.Update(func(items []TodoItem) []TodoItem {
+					for i := range items {
+						if items[i].ID == item.ID {
+							items[i].Done = checked
+						}
+					}
+					return items
 				})
-				
-				// Add delete button
-				row.Button("×").OnClick(func() {
-					todos.Update(func(value interface{}) interface{} {
-						items := value.([]TodoItem)
-						
-						// Remove the item at this index
-						return append(items[:index], items[index+1:]...)
-					})
+			})
+			
+			// Add delete button
+			row.Button("×").OnClick(func() {
+				todos.Update(func(items []TodoItem) []TodoItem {
+					// Remove the todo with this ID
+					result := []TodoItem{}
+					for _, other := range items {
+						if other.ID != item.ID {
+							result = append(result, other)
+						}
+					}
+					return result
 				})
 			})
-		}
-		
-		// Update status text
-		completed := 0
-		for _, item := range items {
+		})
+	})
+	
+	// Count completed todos, worked out again only when the todos change
+	completed := finch.NewComputed(func() int {
+		count := 0
+		for _, item := range todos.Get() {
 			if item.Done {
-				completed++
+				count++
 			}
 		}
-		
-		if len(items) == 0 {
-			statusText.SetText("No items")
-		} else if len(items) == 1 {
-			statusText.SetText("1 item")
-		} else {
-			statusText.SetText(fmt.Sprintf("%d items, %d completed", len(items), completed))
+		return count
+	}, todos)
+	
+	// Describe the todos, updating the status text only when it changes
+	status := finch.NewComputed(func() string {
+		switch items := todos.Get(); len(items) {
+		case 0:
+			return "No items"
+		case 1:
+			return "1 item"
+		default:
+			return fmt.Sprintf("%d items, %d completed", len(items), completed.Get())
 		}
+	}, todos, completed)
+	status.Watch(func(text string) {
+		statusText.SetText(text)
 	})
 	
 	// Run the UI
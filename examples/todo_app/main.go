@@ -26,6 +26,8 @@ type Game struct {
 	addButton     *components.Button
 	clearButton   *components.Button
 	statusLabel   *components.Label
+	shortcuts     *components.ShortcutManager
+	devOverlay    *components.DevOverlay
 }
 
 // NewGame creates a new game
@@ -64,6 +66,7 @@ func (g *Game) initUI() {
 	g.inputField.SetBounds(components.Rect{X: 0, Y: 0, Width: ScreenWidth - 200, Height: 40})
 	g.inputField.SetText("")
 	g.inputField.SetPlaceholder("Enter a new todo...")
+	g.inputField.SetSingleLine(true)
 	inputContainer.AddChild(g.inputField)
 	
 	// Create add button
@@ -116,6 +119,14 @@ func (g *Game) initUI() {
 	g.todoList.AddTodo("Finish project")
 	g.todoList.AddTodo("Call John")
 	
+	// F12 shows frame rates, draw time and the last events, on top of everything
+	g.devOverlay = components.NewDevOverlay("dev_overlay", root)
+	root.AddChild(g.devOverlay)
+	
+	// Enter adds the todo typed in the input field
+	g.shortcuts = components.NewShortcutManager()
+	g.shortcuts.Bind("Enter", components.NewCommand("todo.add", "Add Todo", g.addTodo))
+	
 	// Update status
 	g.updateStatus()
 }
@@ -165,8 +176,10 @@ func (g *Game) Draw(screen *ebiten.Image) {
 	// Create a draw surface
 	surface := components.NewEbitenDrawSurface(screen)
 	
-	// Draw the UI
+	// Draw the UI, timed for the dev overlay
+	g.devOverlay.BeginDraw()
 	g.rootContainer.Draw(surface)
+	g.devOverlay.EndDraw()
 }
 
 // Layout implements the ebiten.Game interface
@@ -181,18 +194,22 @@ func (g *Game) handleInput() {
 	
 	// Handle mouse events
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		g.rootContainer.HandleMouseDown(x, y)
+		if components.DispatchEvent(g.rootContainer, components.NewMouseEvent(components.InputTypeMouseDown, x, y)) {
+			g.devOverlay.Record(components.TraceClick, components.ElementAt(g.rootContainer, x, y))
+		}
 	}
 	
 	if inpututil.IsMouseButtonJustReleased(ebiten.MouseButtonLeft) {
-		g.rootContainer.HandleMouseUp(x, y)
+		components.DispatchEvent(g.rootContainer, components.NewMouseEvent(components.InputTypeMouseUp, x, y))
 	}
 	
-	g.rootContainer.HandleMouseMove(x, y)
+	components.DispatchEvent(g.rootContainer, components.NewMouseEvent(components.InputTypeMouseMove, x, y))
 	
-	// Handle keyboard events for adding todos with Enter key
-	if inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
-		g.addTodo()
+	// Keys go to the elements first, then to shortcuts
+	for _, event := range components.PollKeyEvents() {
+		if !components.DispatchKeyEvent(g.rootContainer, event) && g.shortcuts.HandleKeyDown(event) {
+			g.devOverlay.Record(components.TraceShortcut, nil)
+		}
 	}
 }
 
@@ -139,7 +139,8 @@ func (t *Todo) Draw(surface components.DrawSurface) {
 }
 
 // HandleMouseMove handles mouse move events
-func (t *Todo) HandleMouseMove(x, y int) bool {
+func (t *Todo) HandleMouseMove(e *components.Event) {
+	x, y := e.X, e.Y
 	prevHovered := t.hovered
 	bounds := t.ComputedBounds()
 	t.hovered = components.PointInRect(components.Point{x, y}, bounds)
@@ -147,41 +148,42 @@ func (t *Todo) HandleMouseMove(x, y int) bool {
 	// Check if any children handle the event
 	for i := len(t.Children()) - 1; i >= 0; i-- {
 		child := t.Children()[i]
-		if child.HandleMouseMove(x, y) {
-			return true
+		if child.HandleMouseMove(e); e.Consumed() {
+			return
 		}
 	}
 	
 	// Return true if hover state changed
-	return t.hovered || prevHovered != t.hovered
+	if t.hovered || prevHovered != t.hovered {
+		e.Consume()
+	}
 }
 
 // HandleMouseDown handles mouse down events
-func (t *Todo) HandleMouseDown(x, y int) bool {
+func (t *Todo) HandleMouseDown(e *components.Event) {
+	x, y := e.X, e.Y
 	bounds := t.ComputedBounds()
 	if components.PointInRect(components.Point{x, y}, bounds) {
 		// Check if any children handle the event
 		for i := len(t.Children()) - 1; i >= 0; i-- {
 			child := t.Children()[i]
-			if child.HandleMouseDown(x, y) {
-				return true
+			if child.HandleMouseDown(e); e.Consumed() {
+				return
 			}
 		}
-		return true
+		e.Consume()
 	}
-	return false
 }
 
 // HandleMouseUp handles mouse up events
-func (t *Todo) HandleMouseUp(x, y int) bool {
+func (t *Todo) HandleMouseUp(e *components.Event) {
 	// Check if any children handle the event
 	for i := len(t.Children()) - 1; i >= 0; i-- {
 		child := t.Children()[i]
-		if child.HandleMouseUp(x, y) {
-			return true
+		if child.HandleMouseUp(e); e.Consumed() {
+			return
 		}
 	}
-	return false
 }
 
 // SetBackgroundColor sets the todo item background color
@@ -228,9 +230,9 @@ func (t *Todo) GetDeleteButton() *components.Button {
 	return t.deleteButton
 }
 
-// TodoList represents a list of todo items
+// TodoList represents a list of todo items that can be dragged to reorder them
 type TodoList struct {
-	*components.FlexContainer
+	*components.ListView
 	todos         map[string]*Todo
 	nextID        int
 	onItemChanged func(item TodoItem)
@@ -240,13 +242,13 @@ type TodoList struct {
 // NewTodoList creates a new todo list
 func NewTodoList(id string) *TodoList {
 	list := &TodoList{
-		FlexContainer: components.NewFlexContainer(id),
-		todos:         make(map[string]*Todo),
-		nextID:        1,
+		ListView: components.NewListView(id),
+		todos:    make(map[string]*Todo),
+		nextID:   1,
 	}
 	
-	// Set vertical layout
-	list.SetFlexDirection(components.FlexColumn)
+	// Let todos be dragged into a new order
+	list.SetReorderable(true)
 	
 	return list
 }
@@ -276,18 +278,18 @@ func (tl *TodoList) AddTodo(text string) *Todo {
 	// Store the todo in our map
 	tl.todos[id] = todo
 	
-	// Add the todo to the container
-	tl.AddChild(todo)
+	// Add the todo to the end of the list
+	tl.AddItem(todo)
 	
 	// Return the new todo
 	return todo
 }
 
-// GetTodos returns all todo items
+// GetTodos returns all todo items in list order
 func (tl *TodoList) GetTodos() []TodoItem {
 	result := make([]TodoItem, 0, len(tl.todos))
-	for _, todo := range tl.todos {
-		result = append(result, todo.GetItem())
+	for _, item := range tl.Items() {
+		result = append(result, item.(*Todo).GetItem())
 	}
 	return result
 }
@@ -295,8 +297,8 @@ func (tl *TodoList) GetTodos() []TodoItem {
 // RemoveTodo removes a todo item from the list
 func (tl *TodoList) RemoveTodo(id string) {
 	if todo, ok := tl.todos[id]; ok {
-		// Remove from the container
-		tl.RemoveChild(todo)
+		// Remove from the list
+		tl.RemoveItem(tl.IndexOf(todo))
 		
 		// Remove from our map
 		delete(tl.todos, id)
@@ -354,18 +356,8 @@ func (tl *TodoList) ClearCompleted() {
 
 // UpdateLayout updates the layout of all todo items
 func (tl *TodoList) UpdateLayout() {
-	// Update the width of all todos to match the container width
-	y := 0
-	for _, todo := range tl.todos {
-		bounds := todo.Bounds()
-		todo.SetBounds(components.Rect{
-			X:      0,
-			Y:      y,
-			Width:  tl.Bounds().Width,
-			Height: bounds.Height,
-		})
-		y += bounds.Height
-	}
+	// Stack the todos in list order at the container width
+	tl.SetBounds(tl.Bounds())
 }
 
 // GetTodoByID returns a todo by its ID
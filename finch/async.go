@@ -0,0 +1,97 @@
+package finch
+
+import (
+	"runtime/debug"
+	"time"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Dispatch runs fn on the UI goroutine on the next update. It is safe to
+// call from any goroutine; elements and States should only be changed on
+// the UI goroutine, since Update and Draw read them there.
+func (ui *UI) Dispatch(fn func()) *UI {
+	components.Dispatch(guarded("UI.Dispatch", fn))
+	return ui
+}
+
+// After calls fn on the UI goroutine once the delay has passed, checked on
+// each update rather than by sleeping. Stop the returned timer to cancel it.
+func (ui *UI) After(delay time.Duration, fn func()) *components.Timer {
+	return components.After(delay, guarded("UI.After", fn))
+}
+
+// Every calls fn on the UI goroutine each time the interval passes, until
+// the returned timer is stopped
+//
+//	clock := ui.Every(time.Second, func() {
+//		now.SetText(time.Now().Format("15:04:05"))
+//	})
+//	defer clock.Stop()
+func (ui *UI) Every(interval time.Duration, fn func()) *components.Timer {
+	return components.Every(interval, guarded("UI.Every", fn))
+}
+
+// Async is work running on its own goroutine whose result is delivered on
+// the UI goroutine
+type Async[T any] struct {
+	done   bool
+	result T
+	then   []func(T)
+}
+
+// Go runs fn on a new goroutine and hands its result to the Then handlers
+// on the UI goroutine, so they can update elements and States directly. If
+// fn panics, the panic is reported as a handler's is and the Then handlers
+// aren't called. (Go is a function rather than a UI method because methods
+// can't have type parameters.)
+//
+//	finch.Go(ui, fetchUsers).Then(func(users []User) {
+//		people.Set(users)
+//	})
+func Go[T any](ui *UI, fn func() T) *Async[T] {
+	a := &Async[T]{}
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				err := &PanicError{Where: "finch.Go", Value: r, Stack: string(debug.Stack())}
+				ui.Dispatch(func() { ui.report(err) })
+			}
+		}()
+		result := fn()
+		ui.Dispatch(func() { a.finish(result) })
+	}()
+	return a
+}
+
+// finish stores the result and calls the handlers
+func (a *Async[T]) finish(result T) {
+	a.done = true
+	a.result = result
+	for _, handler := range a.then {
+		handler(result)
+	}
+	a.then = nil
+}
+
+// Then adds a handler called with the result on the UI goroutine, right
+// away if the work has already finished. Call it from the UI goroutine.
+func (a *Async[T]) Then(handler func(result T)) *Async[T] {
+	handler = guarded1("Async.Then", handler)
+	if a.done {
+		handler(a.result)
+		return a
+	}
+	a.then = append(a.then, handler)
+	return a
+}
+
+// Done returns whether the result has been delivered
+func (a *Async[T]) Done() bool {
+	return a.done
+}
+
+// Result returns the result, and whether it has been delivered yet
+func (a *Async[T]) Result() (T, bool) {
+	return a.result, a.done
+}
@@ -0,0 +1,251 @@
+package finch
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Component is a reusable piece of UI with its own state, such as a counter
+// with its buttons. Build adds the component's elements through the
+// context, which works like a Container, and may return one more element
+// to add, or nil. Embed BaseComponent to get empty lifecycle hooks.
+//
+//	type Counter struct {
+//		finch.BaseComponent
+//		count *finch.State[int]
+//	}
+//
+//	func (c *Counter) Build(ctx *finch.Context) components.Element {
+//		ctx.Watch(c.count)
+//		ctx.Text(fmt.Sprintf("Count: %d", c.count.Get()))
+//		ctx.Button("+").OnClick(func() { c.count.Update(func(n int) int { return n + 1 }) })
+//		return nil
+//	}
+type Component interface {
+	// Build adds the component's elements; it runs again on Rebuild
+	Build(ctx *Context) components.Element
+	// OnMount is called once the component is first built and added
+	OnMount(ctx *Context)
+	// OnUnmount is called when the component is removed
+	OnUnmount(ctx *Context)
+	// OnUpdate is called after the component is rebuilt
+	OnUpdate(ctx *Context)
+}
+
+// BaseComponent gives a component empty lifecycle hooks, so it only needs
+// to define the ones it uses
+type BaseComponent struct{}
+
+// OnMount does nothing
+func (BaseComponent) OnMount(ctx *Context) {}
+
+// OnUnmount does nothing
+func (BaseComponent) OnUnmount(ctx *Context) {}
+
+// OnUpdate does nothing
+func (BaseComponent) OnUpdate(ctx *Context) {}
+
+// emptyComponent stands in for components that couldn't be created
+type emptyComponent struct {
+	BaseComponent
+}
+
+// Build adds nothing
+func (emptyComponent) Build(ctx *Context) components.Element {
+	return nil
+}
+
+// Context is a mounted component: the container its elements are built in,
+// which it can be built with like any Container, and its place in the UI
+type Context struct {
+	*Container
+	component Component
+	parent    components.Element
+	watched   map[Observable]bool
+	mounted   bool
+}
+
+var (
+	componentsMu         sync.RWMutex
+	registeredComponents = make(map[string]func() Component)
+)
+
+// RegisterComponent makes a component available by name to Use, with a
+// factory that creates a new instance each time it's used
+func RegisterComponent(name string, factory func() Component) {
+	componentsMu.Lock()
+	defer componentsMu.Unlock()
+	registeredComponents[name] = factory
+}
+
+// newComponent creates an instance of a registered component
+func newComponent(name string) (Component, bool) {
+	componentsMu.RLock()
+	defer componentsMu.RUnlock()
+	factory, ok := registeredComponents[name]
+	if !ok {
+		return nil, false
+	}
+	return factory(), true
+}
+
+// Mount builds a component and adds it to the current parent
+func (ui *UI) Mount(component Component) *Context {
+	host := components.NewFlexContainer(newID("component"))
+	host.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 0})
+	host.SetFlexDirection(components.FlexColumn)
+
+	ui.currentParent.AddChild(host)
+
+	ctx := &Context{
+		Container: &Container{container: host, ui: ui},
+		component: component,
+		parent:    ui.currentParent,
+		watched:   make(map[Observable]bool),
+		mounted:   true,
+	}
+	ctx.build()
+	ctx.hook("OnMount", component.OnMount)
+	return ctx
+}
+
+// Use mounts a new instance of a component registered with RegisterComponent
+func (ui *UI) Use(name string) *Context {
+	component, ok := newComponent(name)
+	if !ok {
+		fmt.Printf("Error using component: %q is not registered\n", name)
+		component = emptyComponent{}
+	}
+	return ui.Mount(component)
+}
+
+// Mount builds a component and adds it to the container
+func (c *Container) Mount(component Component) *Context {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+
+	// Add the component
+	ctx := c.ui.Mount(component)
+
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+
+	return ctx
+}
+
+// Use mounts a new instance of a registered component in the container
+func (c *Container) Use(name string) *Context {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+
+	// Add the component
+	ctx := c.ui.Use(name)
+
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+
+	return ctx
+}
+
+// UI returns the UI the component is mounted in
+func (ctx *Context) UI() *UI {
+	return ctx.ui
+}
+
+// Component returns the mounted component
+func (ctx *Context) Component() Component {
+	return ctx.component
+}
+
+// IsMounted returns whether the component is in the UI
+func (ctx *Context) IsMounted() bool {
+	return ctx.mounted
+}
+
+// Watch rebuilds the component whenever one of the sources changes. It can
+// be called from Build, as sources already watched are skipped.
+func (ctx *Context) Watch(sources ...Observable) {
+	for _, source := range sources {
+		if ctx.watched[source] {
+			continue
+		}
+		ctx.watched[source] = true
+		first := true
+		source.WatchValue(func(interface{}) {
+			if first {
+				first = false
+				return
+			}
+			ctx.Rebuild()
+		})
+	}
+}
+
+// Rebuild replaces the component's elements with newly built ones, then
+// calls OnUpdate. Rebuilds asked for in a batch happen once, at its end.
+func (ctx *Context) Rebuild() {
+	components.Batched(ctx, func() {
+		if !ctx.mounted {
+			return
+		}
+		ctx.container.RemoveAllChildren()
+		ctx.build()
+		ctx.hook("OnUpdate", ctx.component.OnUpdate)
+	})
+}
+
+// Unmount calls OnUnmount and removes the component from the UI. Sources it
+// watches no longer rebuild it.
+func (ctx *Context) Unmount() {
+	if !ctx.mounted {
+		return
+	}
+	ctx.hook("OnUnmount", ctx.component.OnUnmount)
+	ctx.mounted = false
+	ctx.parent.RemoveChild(ctx.container)
+}
+
+// hook runs one of the component's lifecycle hooks, reporting a panic in it
+func (ctx *Context) hook(name string, fn func(ctx *Context)) {
+	ctx.ui.guard(fmt.Sprintf("%T.%s", ctx.component, name), func() { fn(ctx) })
+}
+
+// build runs the component's Build with the host as the current parent and
+// fits the host's height to what was built
+func (ctx *Context) build() {
+	// Save the current parent
+	originalParent := ctx.ui.currentParent
+
+	// Build inside the host
+	ctx.ui.currentParent = ctx.container
+	ctx.ui.guard(fmt.Sprintf("%T.Build", ctx.component), func() {
+		if element := ctx.component.Build(ctx); element != nil && element.Parent() == nil {
+			ctx.container.AddChild(element)
+		}
+	})
+
+	// Restore the original parent
+	ctx.ui.currentParent = originalParent
+
+	bounds := ctx.container.Bounds()
+	bounds.Height = columnHeight(ctx.container)
+	ctx.container.SetBounds(bounds)
+}
+
+// columnHeight returns the height of a column container's children stacked
+// with its spacing
+func columnHeight(column *components.FlexContainer) int {
+	height := 0
+	for _, child := range column.Children() {
+		height += child.Bounds().Height + column.Spacing()
+	}
+	return max(0, height-column.Spacing())
+}
@@ -0,0 +1,237 @@
+package finch
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aggnr/finch/components"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Compositor draws several independent UIs into one window, e.g. the main
+// UI, an in-game HUD and a debug overlay. Each UI keeps its own theme,
+// focus and commands. Layers are drawn bottom to top by priority, and input
+// goes top down: a layer that uses the pointer or a key hides it from the
+// layers below, while empty areas of a transparent layer let it through.
+type Compositor struct {
+	layers []*Layer
+	width  int
+	height int
+	title  string
+}
+
+// Layer is a UI in a compositor
+type Layer struct {
+	ui         *UI
+	compositor *Compositor
+	scale      float64
+	priority   int
+	order      int // Insertion order, breaking priority ties
+	visible    bool
+	offscreen  *ebiten.Image
+}
+
+// NewCompositor creates an empty compositor
+func NewCompositor() *Compositor {
+	return &Compositor{title: "Finch UI App"}
+}
+
+// Add adds a UI above the existing layers. UIs after the first are made
+// transparent so the layers below show through.
+func (c *Compositor) Add(ui *UI) *Layer {
+	layer := &Layer{ui: ui, compositor: c, scale: 1, order: len(c.layers), visible: true}
+	if len(c.layers) > 0 {
+		ui.SetTransparent(true)
+	}
+	c.layers = append(c.layers, layer)
+	c.sortLayers()
+	return layer
+}
+
+// Remove takes a UI's layer out of the compositor
+func (c *Compositor) Remove(ui *UI) {
+	for i, layer := range c.layers {
+		if layer.ui == ui {
+			c.layers = append(c.layers[:i], c.layers[i+1:]...)
+			return
+		}
+	}
+}
+
+// Layers returns the layers from bottom to top
+func (c *Compositor) Layers() []*Layer {
+	return c.layers
+}
+
+// Title sets the window title
+func (c *Compositor) Title(title string) *Compositor {
+	c.title = title
+	return c
+}
+
+// sortLayers orders layers bottom to top
+func (c *Compositor) sortLayers() {
+	sort.SliceStable(c.layers, func(i, j int) bool {
+		a, b := c.layers[i], c.layers[j]
+		if a.priority != b.priority {
+			return a.priority < b.priority
+		}
+		return a.order < b.order
+	})
+}
+
+// UI returns the layer's UI
+func (l *Layer) UI() *UI {
+	return l.ui
+}
+
+// Scale draws the layer enlarged or shrunk, e.g. 2 for a pixel-art HUD;
+// the UI is laid out at the window size divided by the scale
+func (l *Layer) Scale(scale float64) *Layer {
+	if scale > 0 {
+		l.scale = scale
+	}
+	return l
+}
+
+// Priority sets the layer's place in the stack; higher layers are drawn on
+// top and get input first
+func (l *Layer) Priority(priority int) *Layer {
+	l.priority = priority
+	l.compositor.sortLayers()
+	return l
+}
+
+// Theme fixes the layer's theme
+func (l *Layer) Theme(theme components.Theme) *Layer {
+	l.ui.SetTheme(theme)
+	return l
+}
+
+// Visible shows or hides the layer; hidden layers get no input
+func (l *Layer) Visible(visible bool) *Layer {
+	l.visible = visible
+	return l
+}
+
+// IsVisible returns whether the layer is shown
+func (l *Layer) IsVisible() bool {
+	return l.visible
+}
+
+// logicalSize returns the size the layer's UI is laid out at
+func (l *Layer) logicalSize(width, height int) (int, int) {
+	return int(float64(width) / l.scale), int(float64(height) / l.scale)
+}
+
+// Run opens the window and runs every layer until it closes
+func (c *Compositor) Run(width, height int) {
+	c.width = width
+	c.height = height
+	for _, layer := range c.layers {
+		layer.ui.prepare(layer.logicalSize(width, height))
+		layer.ui.theme.StartWatching(2 * time.Second)
+		defer layer.ui.theme.StopWatching()
+	}
+
+	ebiten.SetWindowSize(width, height)
+	ebiten.SetWindowTitle(c.title)
+
+	// Reopen where the user left off
+	for _, layer := range c.layers {
+		layer.ui.applyRestoredSession()
+	}
+
+	if err := ebiten.RunGame(c); err != nil {
+		fmt.Printf("Error running game: %v\n", err)
+	}
+	for _, layer := range c.layers {
+		layer.ui.saveSessionFile()
+	}
+}
+
+// Update implements ebiten.Game's Update method, passing input to the
+// layers from the top down
+func (c *Compositor) Update() error {
+	// State changes made by this frame's handlers notify once, at the end
+	components.BeginBatch()
+	defer components.EndBatch()
+
+	// Run debounced and throttled handlers that are due
+	components.RunFrameCallbacks()
+
+	in := readFrameInput()
+	for i := len(c.layers) - 1; i >= 0; i-- {
+		layer := c.layers[i]
+		if !layer.visible {
+			continue
+		}
+		// A panic in one layer is reported there and doesn't stop the others
+		layer.ui.guard("update", func() {
+			// Remember the window geometry for the session
+			layer.ui.trackWindow()
+
+			// Lay the page out again when the window has been resized
+			if width, height := layer.logicalSize(c.width, c.height); width != layer.ui.width || height != layer.ui.height {
+				layer.ui.prepare(width, height)
+			}
+			layer.ui.update(&in, layer.scale)
+		})
+	}
+	components.SetSystemCursor(in.cursor)
+	components.SetIMEClient(in.ime, in.imeScale)
+	return nil
+}
+
+// Draw implements ebiten.Game's Draw method, drawing the layers from the
+// bottom up
+func (c *Compositor) Draw(screen *ebiten.Image) {
+	for _, layer := range c.layers {
+		if !layer.visible {
+			continue
+		}
+		layer.ui.guard("draw", func() {
+			layer.draw(screen, c.width, c.height)
+		})
+	}
+}
+
+// draw draws the layer's UI onto the screen, sized for a window of the
+// given size
+func (l *Layer) draw(screen *ebiten.Image, windowWidth, windowHeight int) {
+	if l.scale == 1 {
+		l.ui.draw(screen)
+		return
+	}
+
+	// Draw scaled layers at their logical size, then stretch them
+	width, height := l.logicalSize(windowWidth, windowHeight)
+	if width <= 0 || height <= 0 {
+		return
+	}
+	if l.offscreen == nil || l.offscreen.Bounds().Dx() != width || l.offscreen.Bounds().Dy() != height {
+		if l.offscreen != nil {
+			l.offscreen.Deallocate()
+		}
+		l.offscreen = ebiten.NewImage(width, height)
+	}
+	l.offscreen.Clear()
+	l.ui.draw(l.offscreen)
+
+	op := &ebiten.DrawImageOptions{}
+	op.GeoM.Scale(l.scale, l.scale)
+	if l.scale != float64(int(l.scale)) {
+		op.Filter = ebiten.FilterLinear
+	}
+	screen.DrawImage(l.offscreen, op)
+}
+
+// Layout implements ebiten.Game's Layout method, following the size of the
+// window so the layers can be laid out again on the next Update
+func (c *Compositor) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if outsideWidth > 0 && outsideHeight > 0 {
+		c.width, c.height = outsideWidth, outsideHeight
+	}
+	return c.width, c.height
+}
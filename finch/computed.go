@@ -0,0 +1,83 @@
+package finch
+
+import "github.com/aggnr/finch/components"
+
+// Computed is a value derived from one or more states, such as the number
+// of completed items in a list. It is worked out again when a source
+// changes, and its watchers are only called when the result is different,
+// as compared with reflect.DeepEqual unless Equal is set.
+type Computed[T any] struct {
+	compute  func() T
+	value    T
+	equal    func(a, b T) bool // Nil compares with reflect.DeepEqual
+	ready    bool              // Set once the sources are watched
+	watchers []func(T)
+}
+
+// NewComputed creates a value worked out by compute from the sources. The
+// sources can be States or other Computed values; compute reads them with
+// Get.
+func NewComputed[T any](compute func() T, sources ...Observable) *Computed[T] {
+	c := &Computed[T]{compute: compute}
+	for _, source := range sources {
+		source.WatchValue(func(interface{}) {
+			if c.ready {
+				c.recompute()
+			}
+		})
+	}
+	c.value = compute()
+	c.ready = true
+	return c
+}
+
+// Get returns the last computed value
+func (c *Computed[T]) Get() T {
+	return c.value
+}
+
+// Watch calls watcher with the current value and again whenever it changes
+func (c *Computed[T]) Watch(watcher func(T)) {
+	watcher = guarded1("Computed watcher", watcher)
+	c.watchers = append(c.watchers, watcher)
+	watcher(c.value)
+}
+
+// Value returns the current value as an interface{}. Prefer Get, which
+// returns it as a T.
+func (c *Computed[T]) Value() interface{} {
+	return c.value
+}
+
+// WatchValue is Watch for watchers that don't know the value's type
+func (c *Computed[T]) WatchValue(watcher func(interface{})) {
+	c.Watch(func(value T) { watcher(value) })
+}
+
+// Equal sets how a new result is compared with the old one to decide
+// whether the watchers are notified, as for a State
+func (c *Computed[T]) Equal(equal func(a, b T) bool) *Computed[T] {
+	c.equal = equal
+	return c
+}
+
+// recompute works the value out again, notifying the watchers if it changed.
+// Like State.Set, it keeps the new value even if it is equal to the old one.
+func (c *Computed[T]) recompute() {
+	value := c.compute()
+	unchanged := equalValues(c.equal, c.value, value)
+	c.value = value
+	if unchanged {
+		return
+	}
+
+	// Notify watchers, once with the final value if in a batch
+	components.Batched(c, c.notify)
+}
+
+// notify calls the watchers with the current value
+func (c *Computed[T]) notify() {
+	for _, watcher := range c.watchers {
+		watcher(c.value)
+	}
+}
@@ -0,0 +1,67 @@
+package finch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComputedRecomputesOnSourceChange(t *testing.T) {
+	a := NewState(1)
+	b := NewState(2)
+	calls := 0
+	sum := NewComputed(func() int {
+		calls++
+		return a.Get() + b.Get()
+	}, a, b)
+
+	if sum.Get() != 3 {
+		t.Fatalf("Get() = %d, want 3", sum.Get())
+	}
+	if calls != 1 {
+		t.Errorf("compute called %d times while creating, want 1", calls)
+	}
+
+	a.Set(10)
+	if sum.Get() != 12 {
+		t.Errorf("Get() = %d after a source changed, want 12", sum.Get())
+	}
+	b.Set(5)
+	if sum.Get() != 15 {
+		t.Errorf("Get() = %d after a source changed, want 15", sum.Get())
+	}
+}
+
+func TestComputedNotifiesOnlyWhenResultChanges(t *testing.T) {
+	n := NewState(1)
+	even := NewComputed(func() bool { return n.Get()%2 == 0 }, n)
+	var got []bool
+	even.Watch(func(v bool) { got = append(got, v) })
+
+	n.Set(3) // Still odd
+	n.Set(4)
+	n.Set(6) // Still even
+
+	if want := []bool{false, true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("watcher got %v, want %v", got, want)
+	}
+}
+
+func TestComputedChained(t *testing.T) {
+	n := NewState(2)
+	double := NewComputed(func() int { return n.Get() * 2 }, n)
+	label := NewComputed(func() string {
+		if double.Get() > 10 {
+			return "big"
+		}
+		return "small"
+	}, double)
+	var got []string
+	label.Watch(func(v string) { got = append(got, v) })
+
+	n.Set(3)
+	n.Set(8)
+
+	if want := []string{"small", "big"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("watcher got %v, want %v", got, want)
+	}
+}
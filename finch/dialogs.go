@@ -0,0 +1,171 @@
+package finch
+
+import (
+	"github.com/aggnr/finch/components"
+)
+
+// Dialog sizes and spacing
+const (
+	dialogWidth       = 400
+	dialogPadding     = 16
+	dialogTitleHeight = 32 // The modal's title bar
+)
+
+// PromptResult is the answer to a Prompt
+type PromptResult struct {
+	Value string // Text entered
+	OK    bool   // False if the prompt was cancelled
+}
+
+// Dialog is a standard dialog opened by Alert, Confirm or Prompt. It closes
+// once answered, giving its result to the OnResult handler and to the
+// Result channel.
+type Dialog[T any] struct {
+	modal    *components.Modal
+	ui       *UI
+	cancel   T // Result when dismissed with Esc or the close button
+	result   chan T
+	onResult func(T)
+	answered bool
+}
+
+// Alert opens a dialog showing a message with an OK button
+func (ui *UI) Alert(message string) *Dialog[bool] {
+	d := newDialog(ui, true)
+	d.build(message, nil, []dialogButton[bool]{{"OK", func() bool { return true }}})
+	return d
+}
+
+// Confirm opens a dialog asking a question, answered with true for OK and
+// false for Cancel
+func (ui *UI) Confirm(message string) *Dialog[bool] {
+	d := newDialog(ui, false)
+	d.build(message, nil, []dialogButton[bool]{
+		{"Cancel", func() bool { return false }},
+		{"OK", func() bool { return true }},
+	})
+	return d
+}
+
+// Prompt opens a dialog asking for a line of text, starting with a default
+func (ui *UI) Prompt(message, defaultValue string) *Dialog[PromptResult] {
+	d := newDialog(ui, PromptResult{Value: defaultValue})
+	input := components.NewTextArea(newID("prompt_input"))
+	input.SetSingleLine(true)
+	input.SetText(defaultValue)
+	input.SetBounds(components.Rect{X: 0, Y: 0, Width: dialogWidth - 2*dialogPadding, Height: components.ScaleTextSize(32)})
+	d.build(message, input, []dialogButton[PromptResult]{
+		{"Cancel", func() PromptResult { return PromptResult{Value: defaultValue} }},
+		{"OK", func() PromptResult { return PromptResult{Value: input.GetText(), OK: true} }},
+	})
+	ui.focus.SetFocus(input)
+	return d
+}
+
+// dialogButton is a button of a standard dialog and the result it answers with
+type dialogButton[T any] struct {
+	label  string
+	result func() T
+}
+
+// newDialog creates a dialog that answers with cancel when dismissed
+func newDialog[T any](ui *UI, cancel T) *Dialog[T] {
+	modal := components.NewModal(newID("dialog"), ui.title)
+	modal.SetFocusManager(ui.focus)
+	ui.rootContainer.AddChild(modal)
+
+	d := &Dialog[T]{
+		modal:  modal,
+		ui:     ui,
+		cancel: cancel,
+		result: make(chan T, 1),
+	}
+	modal.SetOnClose(func() { d.answer(d.cancel) })
+	return d
+}
+
+// build fills the dialog with the message, an optional input and a row of
+// buttons, the last of which is Enter's default, and opens it
+func (d *Dialog[T]) build(message string, input components.Element, buttons []dialogButton[T]) {
+	theme := d.ui.theme.Current()
+	content := d.modal.Content()
+	content.SetBoxModel(components.BoxModel{
+		Padding: components.Spacing{Top: dialogPadding, Right: dialogPadding, Bottom: dialogPadding, Left: dialogPadding},
+	})
+	content.SetSpacing(12)
+
+	label := components.NewLabel(d.modal.ID()+"_message", message, 16, theme.Text)
+	label.SetBounds(components.Rect{X: 0, Y: 0, Width: dialogWidth - 2*dialogPadding, Height: components.ScaleTextSize(20)})
+	content.AddChild(label)
+	height := label.Bounds().Height
+	if input != nil {
+		content.AddChild(input)
+		height += content.Spacing() + input.Bounds().Height
+	}
+
+	row := components.NewFlexContainer(d.modal.ID() + "_buttons")
+	row.SetFlexDirection(components.FlexRow)
+	row.SetBounds(components.Rect{X: 0, Y: 0, Width: dialogWidth - 2*dialogPadding, Height: components.ScaleTextSize(36)})
+	for _, b := range buttons {
+		button := components.NewButton(row.ID()+"_"+b.label, b.label)
+		button.SetBounds(components.Rect{X: 0, Y: 0, Width: 96, Height: components.ScaleTextSize(36)})
+		button.ApplyTheme(theme)
+		result := b.result
+		button.SetOnClick(func() { d.answer(result()) })
+		row.AddChild(button)
+	}
+	content.AddChild(row)
+	height += content.Spacing() + row.Bounds().Height
+
+	last := buttons[len(buttons)-1].result
+	d.modal.SetOnSubmit(func() { d.answer(last()) })
+	d.modal.SetDialogSize(dialogWidth, height+2*dialogPadding+dialogTitleHeight)
+	d.modal.Open()
+}
+
+// answer closes the dialog with a result, the first time it's called
+func (d *Dialog[T]) answer(result T) {
+	if d.answered {
+		return
+	}
+	d.answered = true
+	d.result <- result
+	d.modal.Close()
+	if d.onResult != nil {
+		d.onResult(result)
+	}
+
+	// Drop the dialog once the frame's event handling is done
+	components.Batched(d.modal, func() {
+		d.ui.rootContainer.RemoveChild(d.modal)
+	})
+}
+
+// Title sets the title shown above the message, the page title by default
+func (d *Dialog[T]) Title(title string) *Dialog[T] {
+	d.modal.SetTitle(title)
+	return d
+}
+
+// OnResult sets the handler called with the answer when the dialog closes
+func (d *Dialog[T]) OnResult(handler func(result T)) *Dialog[T] {
+	handler = guarded1("Dialog.OnResult", handler)
+	d.onResult = handler
+	return d
+}
+
+// Result returns a channel that receives the answer when the dialog
+// closes. Handlers run on the UI goroutine, so wait on it from another.
+func (d *Dialog[T]) Result() <-chan T {
+	return d.result
+}
+
+// IsOpen returns whether the dialog is waiting for an answer
+func (d *Dialog[T]) IsOpen() bool {
+	return !d.answered
+}
+
+// Close dismisses the dialog as if it were cancelled
+func (d *Dialog[T]) Close() {
+	d.answer(d.cancel)
+}
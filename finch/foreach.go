@@ -0,0 +1,110 @@
+package finch
+
+import (
+	"reflect"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Listable is a list that can be read and watched, such as a State or
+// Computed holding a slice
+type Listable[T any] interface {
+	Get() []T
+	Watch(watcher func([]T))
+}
+
+// forEachRow is the row built for one item of a ForEach
+type forEachRow[T any] struct {
+	item T
+	row  *components.FlexContainer
+}
+
+// ForEach adds a column to the current parent with a row for each item in a
+// list, made by builder. When the list changes, rows are matched to items
+// by key: rows are built for new keys and for items whose value changed,
+// rows of removed keys are removed, and the rest are moved into place
+// as they are, keeping state such as hover and scroll positions. Items with
+// a key already seen in the list are skipped.
+//
+//	finch.ForEach(ui, todos, func(todo Todo) string { return todo.ID },
+//		func(row *finch.Container, todo Todo) {
+//			row.Checkbox(todo.Text).SetValue(todo.Done)
+//		})
+func ForEach[T any, K comparable](ui *UI, items Listable[T], key func(T) K, builder func(row *Container, item T)) *Container {
+	list := components.NewFlexContainer(newID("foreach"))
+	list.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 0})
+	list.SetFlexDirection(components.FlexColumn)
+
+	ui.currentParent.AddChild(list)
+
+	rows := make(map[K]*forEachRow[T])
+	items.Watch(func(values []T) {
+		// Lay the list out once, after every row is in place
+		components.Batch(func() {
+			seen := make(map[K]bool, len(values))
+			order := make([]*components.FlexContainer, 0, len(values))
+			for _, value := range values {
+				k := key(value)
+				if seen[k] {
+					continue
+				}
+				seen[k] = true
+
+				existing, ok := rows[k]
+				if ok && !reflect.DeepEqual(existing.item, value) {
+					list.RemoveChild(existing.row)
+					ok = false
+				}
+				if !ok {
+					existing = &forEachRow[T]{item: value, row: buildRow(ui, list.Bounds().Width, builder, value)}
+					rows[k] = existing
+				}
+				order = append(order, existing.row)
+			}
+
+			for k, existing := range rows {
+				if !seen[k] {
+					list.RemoveChild(existing.row)
+					delete(rows, k)
+				}
+			}
+
+			// Move rows that aren't in place, inserting new ones
+			for i, row := range order {
+				if children := list.Children(); i < len(children) && children[i] == row {
+					continue
+				}
+				list.RemoveChild(row)
+				list.InsertChild(i, row)
+			}
+
+			bounds := list.Bounds()
+			bounds.Height = columnHeight(list)
+			list.SetBounds(bounds)
+		})
+	})
+
+	return &Container{
+		container: list,
+		ui:        ui,
+	}
+}
+
+// buildRow builds the row for an item of a list, not yet added to it
+func buildRow[T any](ui *UI, width int, builder func(row *Container, item T), item T) *components.FlexContainer {
+	row := components.NewFlexContainer(newID("foreach_row"))
+	row.SetBounds(components.Rect{X: 0, Y: 0, Width: width, Height: components.ScaleTextSize(40)})
+	row.SetFlexDirection(components.FlexRow)
+
+	// Save the current parent
+	originalParent := ui.currentParent
+
+	// Build the row's content inside it
+	ui.currentParent = row
+	ui.guard("builder", func() { builder(&Container{container: row, ui: ui}, item) })
+
+	// Restore the original parent
+	ui.currentParent = originalParent
+
+	return row
+}
@@ -0,0 +1,88 @@
+package finch
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/aggnr/finch/components"
+)
+
+type foreachItem struct {
+	ID   int
+	Text string
+}
+
+func TestForEachKeyedReuse(t *testing.T) {
+	ui := New()
+	items := NewState([]foreachItem{{1, "a"}, {2, "b"}, {3, "c"}})
+	var built []string
+	list := ForEach(ui, items, func(item foreachItem) int { return item.ID },
+		func(row *Container, item foreachItem) {
+			built = append(built, item.Text)
+		})
+	rows := slices.Clone(list.container.Children())
+	if len(rows) != 3 {
+		t.Fatalf("%d rows, want 3", len(rows))
+	}
+
+	// Move 3 to the front, change 2 and remove 1
+	built = nil
+	items.Set([]foreachItem{{3, "c"}, {2, "B"}})
+
+	if want := []string{"B"}; !reflect.DeepEqual(built, want) {
+		t.Errorf("built rows for %v, want only %v", built, want)
+	}
+	after := list.container.Children()
+	if len(after) != 2 {
+		t.Fatalf("%d rows, want 2", len(after))
+	}
+	if after[0] != rows[2] {
+		t.Error("row of the moved item was rebuilt")
+	}
+	if after[1] == rows[1] {
+		t.Error("row of the changed item was kept")
+	}
+	for _, row := range after {
+		if row == rows[0] {
+			t.Error("row of the removed item is still shown")
+		}
+	}
+}
+
+func TestForEachSkipsDuplicateKeys(t *testing.T) {
+	ui := New()
+	items := NewState([]foreachItem{{1, "a"}, {1, "again"}, {2, "b"}})
+	var built []string
+	list := ForEach(ui, items, func(item foreachItem) int { return item.ID },
+		func(row *Container, item foreachItem) {
+			built = append(built, item.Text)
+		})
+
+	if want := []string{"a", "b"}; !reflect.DeepEqual(built, want) {
+		t.Errorf("built rows for %v, want %v", built, want)
+	}
+	if n := len(list.container.Children()); n != 2 {
+		t.Errorf("%d rows, want 2", n)
+	}
+}
+
+func TestForEachRowsInOrder(t *testing.T) {
+	ui := New()
+	items := NewState([]foreachItem{{1, "a"}, {2, "b"}, {3, "c"}})
+	texts := make(map[components.Element]string)
+	list := ForEach(ui, items, func(item foreachItem) int { return item.ID },
+		func(row *Container, item foreachItem) {
+			texts[row.container] = item.Text
+		})
+
+	items.Set([]foreachItem{{3, "c"}, {1, "a"}, {4, "d"}, {2, "b"}})
+
+	var got []string
+	for _, row := range list.container.Children() {
+		got = append(got, texts[row])
+	}
+	if want := []string{"c", "a", "d", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rows are %v, want %v", got, want)
+	}
+}
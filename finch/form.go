@@ -0,0 +1,241 @@
+package finch
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Form groups labeled fields that are validated together on submit and
+// read into a struct
+type Form struct {
+	form     *components.Form
+	body     *components.FlexContainer
+	ui       *UI
+	onSubmit reflect.Value // func(T) called with the typed values
+}
+
+// Field is a labeled input of a form, with a slot for its validation
+// message. Its name is the input's ID, which form data and struct fields
+// are matched by.
+type Field struct {
+	form       *Form
+	name       string
+	label      *Text
+	input      *TextInput // Nil for checkboxes
+	validators []components.Validator
+}
+
+// Form adds a form, built by adding fields to it. OnSubmit receives the
+// values as a struct whose fields match the fields' names, ignoring case,
+// or their `form:"name"` tags.
+//
+//	type Signup struct {
+//		Email string
+//		Age   int
+//	}
+//
+//	ui.Form(func(f *finch.Form) {
+//		f.Field("email").Required().Email()
+//		f.Field("age").Required()
+//		f.Submit("Sign up")
+//	}).OnSubmit(func(s Signup) {
+//		fmt.Println(s.Email, s.Age)
+//	})
+func (ui *UI) Form(builder func(*Form)) *Form {
+	form := components.NewForm(newID("form"))
+	body := components.NewFlexContainer(newID("form_body"))
+	body.SetFlexDirection(components.FlexColumn)
+	body.SetSpacing(4)
+	body.SetFitContent(true)
+	body.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 0})
+	form.AddChild(body)
+	ui.currentParent.AddChild(form)
+
+	f := &Form{form: form, body: body, ui: ui}
+
+	// Save the current parent
+	originalParent := ui.currentParent
+
+	// Set the form's body as the current parent
+	ui.currentParent = body
+
+	// Call the builder function
+	if builder != nil {
+		ui.guard("builder", func() { builder(f) })
+	}
+
+	// Restore the original parent
+	ui.currentParent = originalParent
+
+	// Take the height of the fields
+	form.SetBounds(body.Bounds())
+
+	return f
+}
+
+// add builds part of the form with its body as the current parent
+func (f *Form) add(build func()) {
+	originalParent := f.ui.currentParent
+	f.ui.currentParent = f.body
+	build()
+	f.ui.currentParent = originalParent
+}
+
+// fieldLabel turns a field name into a label, e.g. "email" into "Email"
+func fieldLabel(name string) string {
+	if name == "" {
+		return ""
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// errorSlot adds the slot that shows a field's validation message
+func (f *Form) errorSlot(name string) {
+	slot := components.NewErrorText(newID("form_error"), name)
+	slot.SetBounds(components.Rect{X: 0, Y: 0, Width: f.ui.width, Height: components.ScaleTextSize(16)})
+	slot.ApplyTheme(f.ui.theme.Current())
+	f.ui.theme.OnThemeChanged(slot.ApplyTheme)
+	f.body.AddChild(slot)
+}
+
+// Field adds a labeled text field named name
+func (f *Form) Field(name string) *Field {
+	field := &Field{form: f, name: name}
+	f.add(func() {
+		field.label = f.ui.Text(fieldLabel(name))
+		field.input = f.ui.TextInput("")
+		field.input.input.SetID(name)
+		f.errorSlot(name)
+	})
+	return field
+}
+
+// Checkbox adds a checkbox named name, which reads as a bool
+func (f *Form) Checkbox(name, label string) *Field {
+	field := &Field{form: f, name: name}
+	f.add(func() {
+		checkbox := f.ui.Checkbox(label)
+		checkbox.checkbox.SetID(name)
+		field.label = &Text{label: checkbox.label, ui: f.ui}
+		f.errorSlot(name)
+	})
+	return field
+}
+
+// Submit adds a button that validates the form and, if every field is
+// valid, calls the OnSubmit handler
+func (f *Form) Submit(label string) *Button {
+	var button *Button
+	f.add(func() {
+		button = f.ui.Button(label).OnClick(f.submit)
+	})
+	return button
+}
+
+// OnSubmit sets the handler called with the values once every field is
+// valid. It takes a struct, whose fields are matched to the form's fields
+// and converted to their types, or a map[string]string of the raw values.
+func (f *Form) OnSubmit(handler interface{}) *Form {
+	if values, ok := handler.(func(map[string]string)); ok {
+		f.form.SetOnSubmit(guarded1("Form.OnSubmit", values))
+		return f
+	}
+	fn := reflect.ValueOf(handler)
+	if fn.Kind() != reflect.Func || fn.Type().NumIn() != 1 || fn.Type().In(0).Kind() != reflect.Struct {
+		fmt.Printf("Error: OnSubmit needs a func taking a struct, got %T\n", handler)
+		return f
+	}
+	f.onSubmit = fn
+	return f
+}
+
+// OnInvalid sets the handler called with the messages by field name when
+// a submit finds invalid fields
+func (f *Form) OnInvalid(handler func(errors map[string]string)) *Form {
+	handler = guarded1("Form.OnInvalid", handler)
+	f.form.SetOnValidationFailed(handler)
+	return f
+}
+
+// Error shows a message under a field, e.g. from a server; an empty
+// message clears it
+func (f *Form) Error(name, message string) *Form {
+	f.form.SetFieldError(name, message)
+	return f
+}
+
+// submit validates the form and, if every field is valid, calls the
+// OnSubmit handler. It first ties the form to a new value of the handler's
+// struct, so fields are also checked for values that can't be converted.
+func (f *Form) submit() {
+	if f.onSubmit.IsValid() {
+		target := reflect.New(f.onSubmit.Type().In(0))
+		if err := f.form.Attach(target.Interface()); err != nil {
+			fmt.Printf("Error reading form: %v\n", err)
+			return
+		}
+		f.form.SetOnBoundSubmit(func(err error) {
+			if err == nil {
+				guardCallback("Form.OnSubmit", func() {
+					f.onSubmit.Call([]reflect.Value{target.Elem()})
+				})
+			}
+		})
+	}
+	f.form.Submit()
+}
+
+// Label sets the text shown above the field
+func (field *Field) Label(text string) *Field {
+	field.label.SetText(text)
+	return field
+}
+
+// Placeholder sets the hint shown while a text field is empty
+func (field *Field) Placeholder(text string) *Field {
+	if field.input != nil {
+		field.input.input.SetPlaceholder(text)
+	}
+	return field
+}
+
+// Value sets the text of a text field
+func (field *Field) Value(text string) *Field {
+	if field.input != nil {
+		field.input.SetValue(text)
+	}
+	return field
+}
+
+// Validate adds a check run on submit, after those added before it; the
+// first error found is shown under the field
+func (field *Field) Validate(validator func(value string) error) *Field {
+	field.validators = append(field.validators, validator)
+	field.form.form.SetValidators(field.name, field.validators...)
+	return field
+}
+
+// Required rejects an empty field, or an unchecked checkbox
+func (field *Field) Required() *Field {
+	return field.Validate(components.ValidateRequired(fieldLabel(field.name) + " is required"))
+}
+
+// Email rejects text that isn't an email address
+func (field *Field) Email() *Field {
+	return field.Validate(components.ValidateEmail("Enter a valid email address"))
+}
+
+// MinLength rejects text shorter than n characters
+func (field *Field) MinLength(n int) *Field {
+	return field.Validate(components.ValidateMinLength(n, fmt.Sprintf("Use at least %d characters", n)))
+}
+
+// Matches rejects text that doesn't match a regular expression, showing a
+// message
+func (field *Field) Matches(pattern, message string) *Field {
+	return field.Validate(components.ValidatePattern(regexp.MustCompile(pattern), message))
+}
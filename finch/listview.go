@@ -0,0 +1,231 @@
+package finch
+
+import (
+	"reflect"
+
+	"github.com/aggnr/finch/components"
+)
+
+// ListView is a list bound to a state holding a slice, with a row made by
+// a template for each item its filter accepts. Rows can be dragged into a
+// new order, which moves the items in the state.
+type ListView[T any] struct {
+	list      *components.ListView
+	ui        *UI
+	items     *State[[]T]
+	template  func(row *Container, item T)
+	filter    func(item T) bool
+	key       func(item T) any
+	rows      []*forEachRow[T] // Row of each item shown, in order
+	shown     []int            // Index in the items of each row
+	onReorder func(oldIndex, newIndex int)
+}
+
+// Parent is something a generic builder such as List can add its element
+// to: the UI, which adds to its current parent, a Container or a Tab.
+// (Methods can't have type parameters, so List takes its parent instead of
+// being a method of each.)
+type Parent interface {
+	parentElement() (*UI, components.Element)
+}
+
+// parentElement returns the UI and its current parent
+func (ui *UI) parentElement() (*UI, components.Element) {
+	return ui, ui.currentParent
+}
+
+// parentElement returns the UI and the container
+func (c *Container) parentElement() (*UI, components.Element) {
+	return c.ui, c.container
+}
+
+// parentElement returns the UI and the tab's content
+func (t *Tab) parentElement() (*UI, components.Element) {
+	return t.ui, t.container
+}
+
+// List adds a list to a parent, such as the UI or a Container, showing the
+// items of a state, with each row built by template. When the state
+// changes, a row is kept for an item equal to the one it shows at its
+// place, or with the same key once Key is set, so only the rows of new or
+// changed items are built.
+//
+//	search := finch.NewState("")
+//	finch.List(ui, todos, func(row *finch.Container, todo Todo) {
+//		row.Checkbox(todo.Text).SetValue(todo.Done)
+//	}).Filter(func(todo Todo) bool {
+//		return strings.Contains(todo.Text, search.Get())
+//	}, search)
+func List[T any](parent Parent, items *State[[]T], template func(row *Container, item T)) *ListView[T] {
+	ui, container := parent.parentElement()
+	list := components.NewListView(newID("list"))
+	list.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 200})
+	list.SetReorderable(true)
+	list.SetSpacing(2)
+	list.ApplyTheme(ui.theme.Current())
+
+	container.AddChild(list)
+
+	l := &ListView[T]{
+		list:     list,
+		ui:       ui,
+		items:    items,
+		template: template,
+	}
+	list.SetOnReorder(l.reordered)
+	items.Watch(func([]T) {
+		l.render()
+	})
+	return l
+}
+
+// Filter shows only the items a filter accepts. It is applied again
+// whenever one of the sources changes, such as the state of a search box.
+func (l *ListView[T]) Filter(filter func(item T) bool, sources ...Observable) *ListView[T] {
+	l.filter = filter
+	l.render()
+	for _, source := range sources {
+		source.WatchValue(func(interface{}) {
+			l.render()
+		})
+	}
+	return l
+}
+
+// Key sets a function giving each item a key, such as its ID, which must be
+// comparable. Rows are then matched to items by key, so an item inserted
+// or moved doesn't rebuild the rows of the items around it.
+//
+//	finch.List(ui, todos, todoRow).Key(func(todo Todo) any { return todo.ID })
+func (l *ListView[T]) Key(key func(item T) any) *ListView[T] {
+	l.key = key
+	l.render()
+	return l
+}
+
+// OnReorder sets a handler called after a row is dragged to a new place,
+// with the item's old and new index in the state
+func (l *ListView[T]) OnReorder(handler func(oldIndex, newIndex int)) *ListView[T] {
+	handler = guarded2("ListView.OnReorder", handler)
+	l.onReorder = handler
+	return l
+}
+
+// Reorderable sets whether rows can be dragged to a new place
+func (l *ListView[T]) Reorderable(reorderable bool) *ListView[T] {
+	l.list.SetReorderable(reorderable)
+	return l
+}
+
+// Height sets the height of the list
+func (l *ListView[T]) Height(height int) *ListView[T] {
+	bounds := l.list.Bounds()
+	bounds.Height = height
+	l.list.SetBounds(bounds)
+	return l
+}
+
+// render shows a row for each item the filter accepts. A row built before
+// is kept for an equal item at the same place, or with the same key, so
+// only the rows of new or changed items are built, and after a drag the
+// rows are in place already.
+func (l *ListView[T]) render() {
+	old := l.rows
+	kept := make([]bool, len(old))
+
+	// Old rows by key, in order, for items with the same key to take in turn
+	var byKey map[any][]int
+	if l.key != nil {
+		byKey = make(map[any][]int, len(old))
+		for i, row := range old {
+			k := l.key(row.item)
+			byKey[k] = append(byKey[k], i)
+		}
+	}
+
+	// match returns the old row to keep for an item shown at a place: the
+	// next with its key, or without a key the one at that place, as long as
+	// it was built for an equal item
+	match := func(at int, item T) int {
+		j := -1
+		if byKey != nil {
+			k := l.key(item)
+			if queue := byKey[k]; len(queue) > 0 {
+				j, byKey[k] = queue[0], queue[1:]
+			}
+		} else if at < len(old) {
+			j = at
+		}
+		if j < 0 || !reflect.DeepEqual(old[j].item, item) {
+			return -1
+		}
+		return j
+	}
+
+	var rows []*forEachRow[T]
+	l.shown = l.shown[:0]
+	width := l.list.Bounds().Width
+	for i, item := range l.items.Get() {
+		if l.filter != nil && !l.filter(item) {
+			continue
+		}
+		if j := match(len(rows), item); j >= 0 {
+			kept[j] = true
+			rows = append(rows, old[j])
+		} else {
+			rows = append(rows, &forEachRow[T]{item: item, row: buildRow(l.ui, width, l.template, item)})
+		}
+		l.shown = append(l.shown, i)
+	}
+
+	// Remove the rows no longer shown, then move the rest into place. The
+	// list holds the old rows in order, so most are found at their index
+	// without a search.
+	indexOf := func(i int, row *forEachRow[T]) int {
+		if l.list.Item(i) == components.Element(row.row) {
+			return i
+		}
+		return l.list.IndexOf(row.row)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		if !kept[i] {
+			l.list.RemoveItem(indexOf(i, old[i]))
+		}
+	}
+	for i, row := range rows {
+		if current := indexOf(i, row); current < 0 {
+			l.list.InsertItem(i, row.row)
+		} else if current != i {
+			l.list.MoveItem(current, i)
+		}
+	}
+	l.rows = rows
+}
+
+// reordered moves the dragged item within the state's items. With a filter
+// the item lands next to the row it was dropped by.
+func (l *ListView[T]) reordered(oldRow, newRow int) {
+	// The list moved the row already; keep the rows in the same order
+	row := l.rows[oldRow]
+	if oldRow < newRow {
+		copy(l.rows[oldRow:], l.rows[oldRow+1:newRow+1])
+	} else {
+		copy(l.rows[newRow+1:], l.rows[newRow:oldRow])
+	}
+	l.rows[newRow] = row
+
+	oldIndex, newIndex := l.shown[oldRow], l.shown[newRow]
+	items := append([]T(nil), l.items.Get()...)
+	item := items[oldIndex]
+	if oldIndex < newIndex {
+		copy(items[oldIndex:], items[oldIndex+1:newIndex+1])
+	} else {
+		copy(items[newIndex+1:], items[newIndex:oldIndex])
+	}
+	items[newIndex] = item
+	l.items.Set(items)
+
+	if l.onReorder != nil {
+		l.onReorder(oldIndex, newIndex)
+	}
+}
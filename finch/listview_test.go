@@ -0,0 +1,117 @@
+package finch
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// listRows returns the text of the item each row of a list was built for
+func listRows(l *ListView[foreachItem], texts map[*Container]string) []string {
+	var got []string
+	for _, row := range l.list.Items() {
+		for c, text := range texts {
+			if c.container == row {
+				got = append(got, text)
+			}
+		}
+	}
+	return got
+}
+
+// testList returns a list of items and the text of each row it builds, in
+// the order they're built
+func testList(items *State[[]foreachItem]) (*ListView[foreachItem], *[]string, map[*Container]string) {
+	var built []string
+	texts := make(map[*Container]string)
+	l := List(New(), items, func(row *Container, item foreachItem) {
+		built = append(built, item.Text)
+		texts[row] = item.Text
+	})
+	return l, &built, texts
+}
+
+func TestListKeepsRowsInPlace(t *testing.T) {
+	items := NewState([]foreachItem{{1, "a"}, {2, "b"}, {3, "c"}})
+	l, built, texts := testList(items)
+	rows := slices.Clone(l.list.Items())
+
+	*built = nil
+	items.Set([]foreachItem{{1, "a"}, {2, "B"}, {3, "c"}, {4, "d"}})
+
+	if want := []string{"B", "d"}; !reflect.DeepEqual(*built, want) {
+		t.Errorf("built rows for %v, want %v", *built, want)
+	}
+	after := l.list.Items()
+	if after[0] != rows[0] || after[2] != rows[2] {
+		t.Error("rows of unchanged items were rebuilt")
+	}
+	if want := []string{"a", "B", "c", "d"}; !reflect.DeepEqual(listRows(l, texts), want) {
+		t.Errorf("rows are %v, want %v", listRows(l, texts), want)
+	}
+}
+
+func TestListKey(t *testing.T) {
+	items := NewState([]foreachItem{{1, "a"}, {2, "b"}, {3, "c"}})
+	l, built, texts := testList(items)
+	l.Key(func(item foreachItem) any { return item.ID })
+	rows := slices.Clone(l.list.Items())
+
+	// Insert at the front, move 3 up and drop 2
+	*built = nil
+	items.Set([]foreachItem{{0, "z"}, {3, "c"}, {1, "a"}})
+
+	if want := []string{"z"}; !reflect.DeepEqual(*built, want) {
+		t.Errorf("built rows for %v, want %v", *built, want)
+	}
+	after := l.list.Items()
+	if after[1] != rows[2] || after[2] != rows[0] {
+		t.Error("rows of moved items were rebuilt")
+	}
+	if want := []string{"z", "c", "a"}; !reflect.DeepEqual(listRows(l, texts), want) {
+		t.Errorf("rows are %v, want %v", listRows(l, texts), want)
+	}
+}
+
+func TestListFilter(t *testing.T) {
+	items := NewState([]foreachItem{{1, "apple"}, {2, "banana"}, {3, "apricot"}})
+	search := NewState("")
+	l, _, texts := testList(items)
+	l.Filter(func(item foreachItem) bool {
+		return strings.HasPrefix(item.Text, search.Get())
+	}, search)
+
+	search.Set("ap")
+	if want := []string{"apple", "apricot"}; !reflect.DeepEqual(listRows(l, texts), want) {
+		t.Errorf("rows are %v, want %v", listRows(l, texts), want)
+	}
+	search.Set("")
+	if n := l.list.ItemCount(); n != 3 {
+		t.Errorf("%d rows after clearing the filter, want 3", n)
+	}
+}
+
+func TestListReorderKeepsRows(t *testing.T) {
+	items := NewState([]foreachItem{{1, "a"}, {2, "b"}, {3, "c"}})
+	l, built, texts := testList(items)
+	rows := slices.Clone(l.list.Items())
+
+	// As a drag does: the list moves the row, then reports the move
+	*built = nil
+	l.list.MoveItem(0, 2)
+	l.reordered(0, 2)
+
+	if want := []foreachItem{{2, "b"}, {3, "c"}, {1, "a"}}; !reflect.DeepEqual(items.Get(), want) {
+		t.Errorf("items are %v, want %v", items.Get(), want)
+	}
+	if len(*built) != 0 {
+		t.Errorf("built rows for %v after a drag, want none", *built)
+	}
+	if l.list.Item(2) != rows[0] {
+		t.Error("dragged row was rebuilt")
+	}
+	if want := []string{"b", "c", "a"}; !reflect.DeepEqual(listRows(l, texts), want) {
+		t.Errorf("rows are %v, want %v", listRows(l, texts), want)
+	}
+}
@@ -0,0 +1,123 @@
+package finch
+
+import (
+	"fmt"
+
+	"github.com/aggnr/finch/components"
+)
+
+// menuBarHeight is the height of the menu bar across the top of the window
+const menuBarHeight = 28
+
+// Menu is a drop-down menu of the menu bar, or a submenu of one. Its items
+// run commands, so they show their shortcuts and are greyed out while
+// disabled.
+type Menu struct {
+	menu *components.PopupMenu
+	ui   *UI
+	last *components.Command // Command of the item added last
+}
+
+// Menu adds a menu to the bar across the top of the window, which is
+// added with the first menu. The builder adds the menu's items.
+//
+//	ui.Menu("File", func(m *finch.Menu) {
+//		m.Item("Open", open).Shortcut("ctrl+o")
+//		m.Item("Save", save).Shortcut("ctrl+s").Enabled(dirty)
+//		m.Separator().Item("Quit", quit)
+//	})
+func (ui *UI) Menu(title string, builder func(*Menu)) *Menu {
+	if ui.menuBar == nil {
+		ui.addMenuBar()
+	}
+	menu := &Menu{menu: ui.menuBar.AddMenu(title), ui: ui}
+	if builder != nil {
+		builder(menu)
+	}
+	return menu
+}
+
+// addMenuBar adds the menu bar, moving the page down below it. It floats
+// above the page so open menus are drawn over the content.
+func (ui *UI) addMenuBar() {
+	bar := components.NewMenuBar(newID("menubar"))
+	bar.SetPositionType(components.PositionFixed)
+	bar.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: menuBarHeight})
+	bar.SetScreenBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: ui.height})
+	bar.SetTriggerAccelerators(false) // Items' shortcuts are bound to the UI's shortcuts
+	ui.menuBar = bar
+
+	padding := ui.rootContainer.GetBoxModel().Padding
+	padding.Top += menuBarHeight
+	ui.rootContainer.SetPadding(padding)
+
+	// Once the page is prepared, the overlays are in place already
+	if ui.prepared {
+		ui.rootContainer.AddChild(bar)
+	}
+}
+
+// Item adds an item that runs a handler when chosen
+func (m *Menu) Item(label string, handler func()) *Menu {
+	command := components.NewCommand(newID("menu_item"), label, guarded("Menu.Item", handler))
+	m.menu.AddCommand(command)
+	m.last = command
+	return m
+}
+
+// Command adds an item that runs a command, with its title, shortcut and
+// enabled state
+func (m *Menu) Command(command *Command) *Menu {
+	m.menu.AddCommand(command.command)
+	m.last = command.command
+	return m
+}
+
+// Separator adds a line between groups of items
+func (m *Menu) Separator() *Menu {
+	m.menu.AddSeparator()
+	m.last = nil
+	return m
+}
+
+// Submenu adds an item that opens a nested menu, built by the builder
+func (m *Menu) Submenu(label string, builder func(*Menu)) *Menu {
+	submenu := &Menu{menu: m.menu.AddSubmenu(label), ui: m.ui}
+	if builder != nil {
+		builder(submenu)
+	}
+	m.last = nil
+	return m
+}
+
+// Shortcut binds a keyboard shortcut such as "ctrl+o" to the item added
+// last and shows it beside the item. Like other shortcuts it runs only for
+// keys no element used, and not while a dialog is open.
+func (m *Menu) Shortcut(accelerator string) *Menu {
+	if m.last == nil {
+		return m
+	}
+	shortcuts := m.ui.commands.Shortcuts()
+	if m.last.Shortcut != "" {
+		shortcuts.Unbind(m.last.Shortcut)
+	}
+	m.last.Shortcut = accelerator
+	if err := shortcuts.Bind(accelerator, m.last); err != nil {
+		fmt.Printf("Error binding shortcut for %s: %v\n", m.last.Title, err)
+	}
+	return m
+}
+
+// Enabled binds whether the item added last can be chosen to a state
+func (m *Menu) Enabled(state *State[bool]) *Menu {
+	return m.EnabledWhen(state.Get)
+}
+
+// EnabledWhen sets a function that decides whether the item added last can
+// be chosen, checked each time the item is shown or its shortcut pressed
+func (m *Menu) EnabledWhen(predicate func() bool) *Menu {
+	if m.last != nil {
+		m.last.SetEnabledWhen(predicate)
+	}
+	return m
+}
@@ -0,0 +1,68 @@
+package finch
+
+import (
+	"time"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Notification is a short message shown in the corner of the window by
+// Notify. It goes away after a few seconds, or when its close button is
+// clicked; it stays up while hovered.
+type Notification struct {
+	toast   *components.Toast
+	actions []components.ToastAction
+}
+
+// Notify shows a message as a notification in the bottom right corner
+//
+//	ui.Notify("Saved").Success()
+//	ui.Notify("Couldn't reach the server").Error().Duration(10 * time.Second)
+func (ui *UI) Notify(message string) *Notification {
+	return &Notification{toast: ui.toasts.Show(message, components.ToastInfo)}
+}
+
+// Success marks the notification as reporting something that worked
+func (n *Notification) Success() *Notification {
+	n.toast.SetKind(components.ToastSuccess)
+	return n
+}
+
+// Error marks the notification as reporting a failure
+func (n *Notification) Error() *Notification {
+	n.toast.SetKind(components.ToastError)
+	return n
+}
+
+// Duration sets how long the notification stays up from now; zero keeps it
+// up until it's closed
+func (n *Notification) Duration(duration time.Duration) *Notification {
+	n.toast.SetDuration(duration)
+	return n
+}
+
+// Action adds a button, such as "Undo"; clicking it runs the handler and
+// closes the notification
+func (n *Notification) Action(label string, handler func()) *Notification {
+	n.actions = append(n.actions, components.ToastAction{Label: label, Handler: guarded("Notification.Action", handler)})
+	n.toast.SetActions(n.actions...)
+	return n
+}
+
+// Message changes the text while the notification is up
+func (n *Notification) Message(message string) *Notification {
+	n.toast.SetMessage(message)
+	return n
+}
+
+// OnDismiss sets a handler called when the notification goes away
+func (n *Notification) OnDismiss(handler func()) *Notification {
+	handler = guarded("Notification.OnDismiss", handler)
+	n.toast.SetOnDismiss(handler)
+	return n
+}
+
+// Dismiss closes the notification
+func (n *Notification) Dismiss() {
+	n.toast.Dismiss()
+}
@@ -0,0 +1,107 @@
+package finch
+
+import (
+	"github.com/aggnr/finch/components"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Overlay runs a UI inside a game's own ebiten.Game, e.g. as a HUD or pause
+// menu, in place of Run handing the whole loop to finch. The page is drawn
+// without a background, so the game shows through empty areas.
+//
+//	func (g *Game) Update() error {
+//		input := finch.ReadInput()
+//		g.hud.Update(input)
+//		if !input.PointerCaptured() && ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+//			g.fire()
+//		}
+//		return nil
+//	}
+//
+//	func (g *Game) Draw(screen *ebiten.Image) {
+//		g.drawWorld(screen)
+//		g.hud.Draw(screen)
+//	}
+type Overlay struct {
+	ui        *UI
+	cursorSet bool // The overlay asked for a cursor other than the default
+}
+
+// NewOverlay creates an overlay that runs a UI
+func NewOverlay(ui *UI) *Overlay {
+	ui.SetTransparent(true)
+	return &Overlay{ui: ui}
+}
+
+// UI returns the overlay's UI
+func (o *Overlay) UI() *UI {
+	return o.ui
+}
+
+// Input is a frame of mouse and key input. Overlays remove what their UIs
+// use, so the game acts only on what's left.
+type Input struct {
+	frame frameInput
+}
+
+// ReadInput reads this frame's input, once per game Update. Pass it to
+// each overlay from the top down, then check what was captured.
+func ReadInput() *Input {
+	return &Input{frame: readFrameInput()}
+}
+
+// PointerCaptured returns whether an overlay used the pointer this frame,
+// by pressing, hovering or scrolling one of its elements
+func (i *Input) PointerCaptured() bool {
+	return i.frame.pointerTaken
+}
+
+// TextFocused returns whether a text field in an overlay has focus, so
+// the game shouldn't treat typed keys as game controls
+func (i *Input) TextFocused() bool {
+	return i.frame.ime != nil
+}
+
+// Keys returns the key events no element or shortcut of an overlay used
+func (i *Input) Keys() []components.InputEvent {
+	return i.frame.keys
+}
+
+// Update handles a frame of input. Call it from the game's Update.
+func (o *Overlay) Update(input *Input) {
+	// A panic in a handler ends the UI's work for the frame, not the game
+	o.ui.guard("update", func() {
+		o.update(&input.frame)
+	})
+}
+
+// update handles a frame of input and runs the UI's handlers
+func (o *Overlay) update(in *frameInput) {
+	// State changes made by this frame's handlers notify once, at the end
+	components.BeginBatch()
+	defer components.EndBatch()
+
+	// Run debounced and throttled handlers that are due
+	components.RunFrameCallbacks()
+
+	o.ui.update(in, 1)
+
+	// Leave the game's cursor alone unless the overlay wants its own
+	if in.cursor != components.CursorDefault || o.cursorSet {
+		components.SetSystemCursor(in.cursor)
+		o.cursorSet = in.cursor != components.CursorDefault
+	}
+	components.SetIMEClient(in.ime, in.imeScale)
+}
+
+// Draw draws the UI over the screen, laying it out again when the screen's
+// size changes. Call it from the game's Draw, after drawing the game.
+func (o *Overlay) Draw(screen *ebiten.Image) {
+	width, height := screen.Bounds().Dx(), screen.Bounds().Dy()
+	if width != o.ui.width || height != o.ui.height || !o.ui.prepared {
+		o.ui.prepare(width, height)
+	}
+	o.ui.guard("draw", func() {
+		o.ui.draw(screen)
+	})
+}
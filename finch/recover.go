@@ -0,0 +1,135 @@
+package finch
+
+import (
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/aggnr/finch/components"
+)
+
+// PanicError is a panic recovered from an app's handler or builder. The
+// app keeps running without the rest of the work it interrupted.
+type PanicError struct {
+	Where string      // What was running, e.g. "Button.OnClick" or "update"
+	Value interface{} // The value passed to panic
+	Stack string
+}
+
+// Error describes the panic
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic in %s: %v", e.Where, e.Value)
+}
+
+// OnError adds a handler for panics recovered from the app's handlers,
+// watchers and builders. Without one, each is shown in an error panel with
+// its stack trace; either way it is logged.
+func (ui *UI) OnError(handler func(err *PanicError)) *UI {
+	ui.onError = append(ui.onError, handler)
+	return ui
+}
+
+// activeUI is the UI whose work is running: the one updating or drawing,
+// or else the last one created. Panics in handlers and watchers, which
+// don't know their UI, are reported to it.
+var activeUI *UI
+
+// guard runs fn, recovering and reporting a panic in it. Returns whether fn
+// finished.
+func (ui *UI) guard(where string, fn func()) (finished bool) {
+	previous := activeUI
+	activeUI = ui
+	defer func() {
+		activeUI = previous
+		if r := recover(); r != nil {
+			ui.report(&PanicError{Where: where, Value: r, Stack: string(debug.Stack())})
+		}
+	}()
+	fn()
+	return true
+}
+
+// guardCallback runs a handler or watcher, reporting a panic in it to the
+// active UI, so one bad callback doesn't stop the work around it
+func guardCallback(where string, fn func()) {
+	if ui := activeUI; ui != nil {
+		ui.guard(where, fn)
+		return
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("Error: panic in %s: %v\n%s", where, r, debug.Stack())
+		}
+	}()
+	fn()
+}
+
+// guarded wraps a handler so a panic in it is recovered and reported
+func guarded(where string, handler func()) func() {
+	if handler == nil {
+		return nil
+	}
+	return func() {
+		guardCallback(where, handler)
+	}
+}
+
+// guarded1 is guarded for handlers taking an argument
+func guarded1[A any](where string, handler func(A)) func(A) {
+	if handler == nil {
+		return nil
+	}
+	return func(a A) {
+		guardCallback(where, func() { handler(a) })
+	}
+}
+
+// guarded2 is guarded for handlers taking two arguments
+func guarded2[A, B any](where string, handler func(A, B)) func(A, B) {
+	if handler == nil {
+		return nil
+	}
+	return func(a A, b B) {
+		guardCallback(where, func() { handler(a, b) })
+	}
+}
+
+// panicRepeatWindow is how long after a panic the same one again is taken
+// as a repeat and not reported
+const panicRepeatWindow = time.Second
+
+// report logs a recovered panic and passes it to the error handlers, or
+// shows it. A handler that panics on every frame is reported once, until
+// it has stopped for a moment or its error panel is dismissed.
+func (ui *UI) report(err *PanicError) {
+	now := components.Now()
+	if err.Error() == ui.lastPanic && now.Sub(ui.lastPanicAt) < panicRepeatWindow {
+		ui.lastPanicAt = now
+		return
+	}
+	ui.lastPanic = err.Error()
+	ui.lastPanicAt = now
+	fmt.Printf("Error: %v\n%s", err, err.Stack)
+
+	if len(ui.onError) > 0 {
+		for _, handler := range ui.onError {
+			handler(err)
+		}
+		return
+	}
+	ui.showPanic(err)
+}
+
+// showPanic opens a dialog with a recovered panic and its stack trace
+func (ui *UI) showPanic(err *PanicError) {
+	stack := components.NewCodeView(newID("panic_stack"), err.Stack)
+	stack.SetShowLineNumbers(false)
+	stack.SetFontSize(12)
+	stack.SetBounds(components.Rect{X: 0, Y: 0, Width: dialogWidth - 2*dialogPadding, Height: 240})
+	stack.ApplyTheme(ui.theme.Current())
+
+	d := newDialog(ui, true)
+	d.Title("Something went wrong")
+	d.onResult = func(bool) { ui.lastPanic = "" }
+	d.build(err.Error(), stack, []dialogButton[bool]{{"Dismiss", func() bool { return true }}})
+}
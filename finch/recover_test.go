@@ -0,0 +1,122 @@
+package finch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aggnr/finch/components"
+)
+
+func TestReportRepeatedPanics(t *testing.T) {
+	clock := components.NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	components.SetClock(clock)
+	defer components.SetClock(nil)
+
+	ui := New()
+	reported := 0
+	ui.OnError(func(*PanicError) { reported++ })
+	broken := guarded("Button.OnClick", func() { panic("broken") })
+
+	// A handler panicking on every frame is reported once
+	for i := 0; i < 5; i++ {
+		broken()
+		clock.Advance(16 * time.Millisecond)
+	}
+	if reported != 1 {
+		t.Fatalf("panic on every frame reported %d times, want 1", reported)
+	}
+
+	// The same panic once it has stopped for a moment is reported again
+	clock.Advance(2 * time.Second)
+	broken()
+	if reported != 2 {
+		t.Errorf("panic after a pause reported %d times in all, want 2", reported)
+	}
+
+	// A different panic is reported straight away
+	guarded("Button.OnClick", func() { panic("other") })()
+	if reported != 3 {
+		t.Errorf("different panic reported %d times in all, want 3", reported)
+	}
+}
+
+func TestGuardedRecovers(t *testing.T) {
+	ui := New()
+	var got *PanicError
+	ui.OnError(func(err *PanicError) { got = err })
+
+	ran := false
+	guarded1("State watcher", func(int) { panic("bad watcher") })(1)
+	guarded("after", func() { ran = true })()
+
+	if got == nil || got.Where != "State watcher" || got.Value != "bad watcher" {
+		t.Errorf("reported %+v, want the watcher's panic", got)
+	}
+	if !ran {
+		t.Error("handler after the panic didn't run")
+	}
+	if guarded("nil", nil) != nil {
+		t.Error("guarded wrapped a nil handler")
+	}
+}
+
+func TestGoReportsPanic(t *testing.T) {
+	ui := New()
+	var got *PanicError
+	ui.OnError(func(err *PanicError) { got = err })
+
+	thenCalled := false
+	Go(ui, func() int { panic("fetch failed") }).Then(func(int) { thenCalled = true })
+
+	deadline := time.Now().Add(5 * time.Second)
+	for got == nil && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+		components.RunFrameCallbacks()
+	}
+	if got == nil || got.Value != "fetch failed" {
+		t.Fatalf("reported %+v, want the goroutine's panic", got)
+	}
+	if thenCalled {
+		t.Error("Then handler called after the work panicked")
+	}
+}
+
+func TestBreakpointRecovers(t *testing.T) {
+	ui := New()
+	var got *PanicError
+	ui.OnError(func(err *PanicError) { got = err })
+
+	ui.Breakpoint(1000, func(bool) { panic("bad layout") })
+
+	if got == nil || got.Where != "UI.Breakpoint" {
+		t.Errorf("reported %+v, want the breakpoint handler's panic", got)
+	}
+}
+
+// panickyComponent panics in every lifecycle hook
+type panickyComponent struct {
+	BaseComponent
+}
+
+func (panickyComponent) Build(ctx *Context) components.Element { return nil }
+func (panickyComponent) OnMount(ctx *Context)                  { panic("mount") }
+func (panickyComponent) OnUpdate(ctx *Context)                 { panic("update") }
+func (panickyComponent) OnUnmount(ctx *Context)                { panic("unmount") }
+
+func TestComponentHooksRecover(t *testing.T) {
+	ui := New()
+	var got []interface{}
+	ui.OnError(func(err *PanicError) { got = append(got, err.Value) })
+
+	ctx := ui.Mount(panickyComponent{})
+	ctx.Rebuild()
+	ctx.Unmount()
+
+	if want := []interface{}{"mount", "update", "unmount"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reported %v, want %v", got, want)
+	}
+	if ctx.IsMounted() {
+		t.Error("component still mounted after its OnUnmount panicked")
+	}
+}
@@ -0,0 +1,54 @@
+package finch
+
+import "github.com/aggnr/finch/components"
+
+// OnResize adds a handler called with the page's new size whenever the
+// window is resized, once the page has been laid out at that size
+func (ui *UI) OnResize(handler func(width, height int)) *UI {
+	handler = guarded2("UI.OnResize", handler)
+	ui.onResize = append(ui.onResize, handler)
+	return ui
+}
+
+// Breakpoint calls a handler with whether the page is narrower than a
+// width: now, and again each time a resize crosses the width
+//
+//	ui.Breakpoint(600, func(narrow bool) {
+//		if narrow {
+//			title.Size(20)
+//		} else {
+//			title.Size(32)
+//		}
+//	})
+func (ui *UI) Breakpoint(width int, handler func(narrow bool)) *UI {
+	handler = guarded1("UI.Breakpoint", handler)
+	narrow := ui.width < width
+	handler(narrow)
+	return ui.OnResize(func(w, h int) {
+		if (w < width) != narrow {
+			narrow = w < width
+			handler(narrow)
+		}
+	})
+}
+
+// Below switches the container to a direction, "row" or "column", while the
+// page is narrower than a width, and back to the direction it has now
+// above it. Call it after Layout.
+//
+//	ui.Container().Layout("row", builder).Below(600, "column")
+func (c *Container) Below(width int, direction string) *Container {
+	wide := c.container.FlexDirection()
+	narrow := components.FlexColumn
+	if direction == "row" {
+		narrow = components.FlexRow
+	}
+	c.ui.Breakpoint(width, func(isNarrow bool) {
+		if isNarrow {
+			c.container.SetFlexDirection(narrow)
+		} else {
+			c.container.SetFlexDirection(wide)
+		}
+	})
+	return c
+}
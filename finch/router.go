@@ -0,0 +1,275 @@
+package finch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Params are the values a path gave a route's parameters, such as
+// {"id": "42"} for "/users/42" on the route "/users/:id"
+type Params map[string]string
+
+// route is a page the router can show
+type route struct {
+	pattern  string
+	segments []string
+	build    func(page *Page)
+}
+
+// Page is a page the router is showing or has stacked below it. It is built
+// like any Container.
+type Page struct {
+	*Container
+	Route  string // Pattern of the route, e.g. "/users/:id"
+	Path   string // Path navigated to, e.g. "/users/42"
+	Params Params
+	router *Router
+}
+
+// Param returns the value of a route parameter, or "" if it has none
+func (p *Page) Param(name string) string {
+	return p.Params[name]
+}
+
+// Router returns the router showing the page
+func (p *Page) Router() *Router {
+	return p.router
+}
+
+// Router shows one page of an app at a time. Pages are built by the route
+// matching the path navigated to and kept, with their state, while pages
+// pushed over them are showing.
+//
+//	router := ui.Router().
+//		Route("/", func(page *finch.Page) {
+//			page.Button("Open").OnClick(func() { page.Router().Push("/items/7") })
+//		}).
+//		Route("/items/:id", func(page *finch.Page) {
+//			page.Text("Item " + page.Param("id"))
+//		})
+//	router.Push("/")
+type Router struct {
+	host       *components.FlexContainer
+	ui         *UI
+	routes     []*route
+	stack      []*Page
+	onNavigate func(page *Page)
+}
+
+// Router adds an empty router to the current parent; add routes and push
+// the first page to show
+func (ui *UI) Router() *Router {
+	host := components.NewFlexContainer(newID("router"))
+	host.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: ui.height})
+	host.SetFlexDirection(components.FlexColumn)
+
+	ui.currentParent.AddChild(host)
+
+	return &Router{
+		host: host,
+		ui:   ui,
+	}
+}
+
+// Router adds an empty router to the container
+func (c *Container) Router() *Router {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+
+	// Add the router
+	router := c.ui.Router()
+
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+
+	return router
+}
+
+// Route adds a route that builds a page for paths matching a pattern. A
+// pattern is a name such as "settings" or a path such as "/users/:id",
+// whose segments starting with ":" match any value and are given to the
+// page as parameters. Routes are tried in the order they were added.
+func (r *Router) Route(pattern string, build func(page *Page)) *Router {
+	r.routes = append(r.routes, &route{
+		pattern:  pattern,
+		segments: pathSegments(pattern),
+		build:    build,
+	})
+	return r
+}
+
+// Height sets the height of the area pages are shown in
+func (r *Router) Height(height int) *Router {
+	bounds := r.host.Bounds()
+	bounds.Height = height
+	r.host.SetBounds(bounds)
+	for _, page := range r.stack {
+		page.container.SetBounds(components.Rect{X: bounds.X, Y: bounds.Y, Width: bounds.Width, Height: height})
+	}
+	return r
+}
+
+// OnNavigate sets a handler called with the page shown after each
+// navigation
+func (r *Router) OnNavigate(handler func(page *Page)) *Router {
+	handler = guarded1("Router.OnNavigate", handler)
+	r.onNavigate = handler
+	return r
+}
+
+// Push shows the page for a path over the current one, which is kept to go
+// back to with Pop
+func (r *Router) Push(path string) *Router {
+	page := r.buildPage(path)
+	if page == nil {
+		return r
+	}
+	if current := r.Current(); current != nil {
+		r.host.RemoveChild(current.container)
+	}
+	r.stack = append(r.stack, page)
+	r.show(page)
+	return r
+}
+
+// Replace shows the page for a path in place of the current one
+func (r *Router) Replace(path string) *Router {
+	page := r.buildPage(path)
+	if page == nil {
+		return r
+	}
+	if current := r.Current(); current != nil {
+		r.host.RemoveChild(current.container)
+		r.stack = r.stack[:len(r.stack)-1]
+	}
+	r.stack = append(r.stack, page)
+	r.show(page)
+	return r
+}
+
+// Pop goes back to the page below the current one, as it was left. The
+// first page can't be popped.
+func (r *Router) Pop() *Router {
+	if !r.CanPop() {
+		return r
+	}
+	r.host.RemoveChild(r.Current().container)
+	r.stack = r.stack[:len(r.stack)-1]
+	r.show(r.Current())
+	return r
+}
+
+// PopTo goes back to the topmost page of a route pattern, if one is stacked
+func (r *Router) PopTo(pattern string) *Router {
+	for i := len(r.stack) - 1; i >= 0; i-- {
+		if r.stack[i].Route != pattern {
+			continue
+		}
+		if i < len(r.stack)-1 {
+			r.host.RemoveChild(r.Current().container)
+			r.stack = r.stack[:i+1]
+			r.show(r.Current())
+		}
+		break
+	}
+	return r
+}
+
+// CanPop returns whether there is a page to go back to
+func (r *Router) CanPop() bool {
+	return len(r.stack) > 1
+}
+
+// Current returns the page showing, or nil before the first push
+func (r *Router) Current() *Page {
+	if len(r.stack) == 0 {
+		return nil
+	}
+	return r.stack[len(r.stack)-1]
+}
+
+// Depth returns how many pages are stacked, counting the current one
+func (r *Router) Depth() int {
+	return len(r.stack)
+}
+
+// buildPage builds the page of the first route matching a path, or returns
+// nil if none does
+func (r *Router) buildPage(path string) *Page {
+	for _, rt := range r.routes {
+		params, ok := rt.match(pathSegments(path))
+		if !ok {
+			continue
+		}
+
+		bounds := r.host.Bounds()
+		container := components.NewFlexContainer(newID("page"))
+		container.SetBounds(components.Rect{X: bounds.X, Y: bounds.Y, Width: bounds.Width, Height: bounds.Height})
+		container.SetFlexDirection(components.FlexColumn)
+		page := &Page{
+			Container: &Container{container: container, ui: r.ui},
+			Route:     rt.pattern,
+			Path:      path,
+			Params:    params,
+			router:    r,
+		}
+
+		// Save the current parent
+		originalParent := r.ui.currentParent
+
+		// Build the page's content inside it
+		r.ui.currentParent = container
+		if rt.build != nil {
+			r.ui.guard("page builder", func() { rt.build(page) })
+		}
+
+		// Restore the original parent
+		r.ui.currentParent = originalParent
+
+		return page
+	}
+	fmt.Printf("Error navigating: no route matches %q\n", path)
+	return nil
+}
+
+// show adds a page to the router and tells traces and the navigate handler
+func (r *Router) show(page *Page) {
+	r.host.AddChild(page.container)
+	r.ui.TraceScreen(page.Route)
+	if r.onNavigate != nil {
+		r.onNavigate(page)
+	}
+}
+
+// match returns the parameters a path gives the route, if it matches
+func (rt *route) match(segments []string) (Params, bool) {
+	if len(segments) != len(rt.segments) {
+		return nil, false
+	}
+	params := Params{}
+	for i, segment := range rt.segments {
+		if strings.HasPrefix(segment, ":") {
+			params[segment[1:]] = segments[i]
+		} else if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// pathSegments splits a path into its segments, ignoring leading, trailing
+// and doubled slashes
+func pathSegments(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
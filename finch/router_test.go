@@ -0,0 +1,108 @@
+package finch
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPathSegments(t *testing.T) {
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"", nil},
+		{"/", nil},
+		{"settings", []string{"settings"}},
+		{"/users/42", []string{"users", "42"}},
+		{"//users//42/", []string{"users", "42"}},
+	}
+	for _, tt := range tests {
+		if got := pathSegments(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("pathSegments(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRouteMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		ok      bool
+		params  Params
+	}{
+		{"/", "/", true, Params{}},
+		{"/", "/users", false, nil},
+		{"settings", "/settings", true, Params{}},
+		{"/settings", "/settings/", true, Params{}},
+		{"/settings", "/profile", false, nil},
+		{"/users/:id", "/users/42", true, Params{"id": "42"}},
+		{"/users/:id", "/users", false, nil},
+		{"/users/:id", "/users/42/posts", false, nil},
+		{"/users/:id", "/teams/42", false, nil},
+		{"/users/:user/posts/:post", "/users/7/posts/9", true, Params{"user": "7", "post": "9"}},
+	}
+	for _, tt := range tests {
+		rt := &route{pattern: tt.pattern, segments: pathSegments(tt.pattern)}
+		params, ok := rt.match(pathSegments(tt.path))
+		if ok != tt.ok || !reflect.DeepEqual(params, tt.params) {
+			t.Errorf("%q matching %q = %v, %v; want %v, %v", tt.pattern, tt.path, params, ok, tt.params, tt.ok)
+		}
+	}
+}
+
+func TestRouterFirstMatchingRouteWins(t *testing.T) {
+	ui := New()
+	var built []string
+	router := ui.Router().
+		Route("/users/new", func(page *Page) { built = append(built, "new") }).
+		Route("/users/:id", func(page *Page) { built = append(built, "user "+page.Param("id")) })
+
+	router.Push("/users/new").Push("/users/7")
+
+	if want := []string{"new", "user 7"}; !reflect.DeepEqual(built, want) {
+		t.Errorf("built %v, want %v", built, want)
+	}
+	if page := router.Current(); page.Route != "/users/:id" || page.Path != "/users/7" {
+		t.Errorf("current page is %q at %q", page.Route, page.Path)
+	}
+}
+
+func TestRouterStack(t *testing.T) {
+	ui := New()
+	router := ui.Router().
+		Route("/", nil).
+		Route("/items/:id", nil).
+		Route("/settings", nil)
+
+	router.Push("/nowhere")
+	if router.Current() != nil {
+		t.Fatal("a path no route matches was pushed")
+	}
+
+	router.Push("/").Push("/items/1").Push("/items/2")
+	if router.Depth() != 3 || router.Current().Param("id") != "2" {
+		t.Fatalf("depth %d at %q, want 3 at /items/2", router.Depth(), router.Current().Path)
+	}
+
+	router.Replace("/settings")
+	if router.Depth() != 3 || router.Current().Route != "/settings" {
+		t.Errorf("after Replace, depth %d at %q", router.Depth(), router.Current().Path)
+	}
+
+	router.Pop()
+	if router.Current().Path != "/items/1" {
+		t.Errorf("after Pop, at %q, want /items/1", router.Current().Path)
+	}
+
+	router.Push("/items/3").PopTo("/")
+	if router.Depth() != 1 || router.CanPop() {
+		t.Errorf("after PopTo, depth %d", router.Depth())
+	}
+	router.Pop()
+	if router.Depth() != 1 {
+		t.Error("the first page was popped")
+	}
+	if n := len(router.host.Children()); n != 1 {
+		t.Errorf("router shows %d pages, want 1", n)
+	}
+}
@@ -0,0 +1,133 @@
+package finch
+
+import "github.com/aggnr/finch/components"
+
+// frameElement is the element a container takes its place in the layout
+// with
+type frameElement interface {
+	components.NodeElement
+	SetMargin(margin components.Spacing)
+	SetFlexGrow(weight int)
+}
+
+// frame returns the element the container takes its place in the layout
+// with: its scroll viewport once Scrollable, or the container itself
+func (c *Container) frame() frameElement {
+	if c.scroll != nil {
+		return c.scroll
+	}
+	return c.container
+}
+
+// parentOf returns the element with an element among its children, or nil
+// if it isn't in the page
+func (ui *UI) parentOf(element components.Element) components.Element {
+	var find func(parent components.Element) components.Element
+	find = func(parent components.Element) components.Element {
+		for _, child := range parent.Children() {
+			if child == element {
+				return parent
+			}
+			if found := find(child); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return find(ui.rootContainer)
+}
+
+// Scrollable shows the container in a viewport of its current size that
+// scrolls with the mouse wheel when its content is taller. The container
+// then grows to fit its content, and Height, Margin and Grow apply to the
+// viewport.
+//
+//	list := ui.Container().Height(300).Scrollable()
+//	list.BindScroll(scrollY)
+func (c *Container) Scrollable() *Container {
+	if c.scroll != nil {
+		return c
+	}
+	parent := c.ui.parentOf(c.container)
+	if parent == nil {
+		return c
+	}
+
+	scroll := components.NewScrollContainer(newID("scroll"))
+	scroll.SetBounds(c.container.Bounds())
+	scroll.SetSpacing(0)
+	scroll.SetMargin(c.container.GetBoxModel().Margin)
+	scroll.SetFlexGrow(c.container.FlexGrow())
+	scroll.SetBackgroundColor(c.container.BackgroundColor())
+	c.container.SetMargin(components.Spacing{})
+	c.container.SetFlexGrow(0)
+
+	// Put the viewport where the container was, and the container in it
+	index := 0
+	for i, child := range parent.Children() {
+		if child == c.container {
+			index = i
+		}
+	}
+	parent.RemoveChild(c.container)
+	if inserter, ok := parent.(interface {
+		InsertChild(index int, child components.Element)
+	}); ok {
+		inserter.InsertChild(index, scroll)
+	} else {
+		parent.AddChild(scroll)
+	}
+	c.container.SetFitContent(true)
+	scroll.AddChild(c.container)
+	c.scroll = scroll
+	return c
+}
+
+// ScrollTo scrolls a Scrollable container so its content is y pixels down
+func (c *Container) ScrollTo(y int) *Container {
+	if c.scroll != nil {
+		c.scroll.ScrollTo(y)
+	}
+	return c
+}
+
+// ScrollToBottom scrolls a Scrollable container to the end of its content
+func (c *Container) ScrollToBottom() *Container {
+	if c.scroll != nil {
+		c.scroll.ScrollTo(c.scroll.MaxScroll())
+	}
+	return c
+}
+
+// ScrollY returns how far a Scrollable container is scrolled down
+func (c *Container) ScrollY() int {
+	if c.scroll == nil {
+		return 0
+	}
+	return c.scroll.ScrollY()
+}
+
+// OnScroll sets a handler called with the new position when a Scrollable
+// container is scrolled
+func (c *Container) OnScroll(handler func(y int)) *Container {
+	handler = guarded1("Container.OnScroll", handler)
+	if c.scroll != nil {
+		c.scroll.SetOnScroll(handler)
+	}
+	return c
+}
+
+// BindScroll keeps a Scrollable container's scroll position and a state in
+// step: scrolling sets the state, and setting the state scrolls
+func (c *Container) BindScroll(state *State[int]) *Container {
+	if c.scroll == nil {
+		return c
+	}
+	state.Watch(func(y int) {
+		c.scroll.ScrollTo(y)
+	})
+	c.scroll.SetOnScroll(func(y int) {
+		state.Set(y)
+	})
+	return c
+}
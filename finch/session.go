@@ -0,0 +1,162 @@
+package finch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/aggnr/finch/components"
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// sessionVersion is bumped when the session format changes incompatibly
+const sessionVersion = 1
+
+// Session is a snapshot of where the user left off: window geometry, the
+// values of persisted states and scroll positions
+type Session struct {
+	Version int                         `json:"version"`
+	Window  *WindowGeometry             `json:"window,omitempty"`
+	States  map[string]json.RawMessage  `json:"states,omitempty"`
+	Scroll  map[string]components.Point `json:"scroll,omitempty"`
+}
+
+// WindowGeometry is the position and size of the app window
+type WindowGeometry struct {
+	X         int  `json:"x"`
+	Y         int  `json:"y"`
+	Width     int  `json:"width"`
+	Height    int  `json:"height"`
+	Maximized bool `json:"maximized,omitempty"`
+}
+
+// PersistentState is a state that can be saved with the session; every
+// State is one
+type PersistentState interface {
+	Observable
+	restore(data json.RawMessage) error
+}
+
+// Persist saves a state's value with the session under a key. If a restored
+// session has a value for the key, the state is updated to it. Values must
+// survive a round trip through JSON.
+func (ui *UI) Persist(key string, state PersistentState) *UI {
+	ui.persisted[key] = state
+	if ui.restored != nil {
+		if data, ok := ui.restored.States[key]; ok {
+			restoreState(state, data)
+		}
+	}
+	return ui
+}
+
+// restoreState decodes JSON into a state's value
+func restoreState(state PersistentState, data json.RawMessage) {
+	if err := state.restore(data); err != nil {
+		fmt.Printf("Error restoring state: %v\n", err)
+	}
+}
+
+// SaveSession captures the window geometry, persisted states and scroll
+// positions as one JSON blob
+func (ui *UI) SaveSession() ([]byte, error) {
+	session := Session{
+		Version: sessionVersion,
+		States:  make(map[string]json.RawMessage, len(ui.persisted)),
+		Scroll:  components.ScrollOffsets(ui.rootContainer),
+	}
+	if ui.window.Width > 0 {
+		window := ui.window
+		session.Window = &window
+	}
+	for key, state := range ui.persisted {
+		data, err := json.Marshal(state.Value())
+		if err != nil {
+			return nil, fmt.Errorf("state %q: %w", key, err)
+		}
+		session.States[key] = data
+	}
+	return json.MarshalIndent(session, "", "  ")
+}
+
+// RestoreSession loads a blob from SaveSession. States are updated as they
+// are persisted, and scroll positions and window geometry are applied when
+// the app starts running, once the UI has been built.
+func (ui *UI) RestoreSession(data []byte) error {
+	session := &Session{}
+	if err := json.Unmarshal(data, session); err != nil {
+		return err
+	}
+	if session.Version != sessionVersion {
+		return fmt.Errorf("unsupported session version %d", session.Version)
+	}
+
+	ui.restored = session
+	for key, state := range ui.persisted {
+		if data, ok := session.States[key]; ok {
+			restoreState(state, data)
+		}
+	}
+	return nil
+}
+
+// SessionFile restores the session from a file, if it exists, when the app
+// starts and saves it there when the app exits
+func (ui *UI) SessionFile(path string) *UI {
+	ui.sessionPath = path
+	data, err := os.ReadFile(path)
+	if err == nil {
+		err = ui.RestoreSession(data)
+	}
+	if err != nil && !os.IsNotExist(err) {
+		fmt.Printf("Error restoring session: %v\n", err)
+	}
+	return ui
+}
+
+// applyRestoredSession applies the parts of a restored session that need the
+// finished UI and the window
+func (ui *UI) applyRestoredSession() {
+	if ui.restored == nil {
+		return
+	}
+	components.RestoreScrollOffsets(ui.rootContainer, ui.restored.Scroll)
+	if window := ui.restored.Window; window != nil {
+		ebiten.SetWindowSize(window.Width, window.Height)
+		ebiten.SetWindowPosition(window.X, window.Y)
+		if window.Maximized {
+			ebiten.MaximizeWindow()
+		}
+	}
+}
+
+// saveSessionFile writes the session to the session file, if one is set
+func (ui *UI) saveSessionFile() {
+	if ui.sessionPath == "" {
+		return
+	}
+	data, err := ui.SaveSession()
+	if err == nil {
+		err = os.WriteFile(ui.sessionPath, data, 0644)
+	}
+	if err != nil {
+		fmt.Printf("Error saving session: %v\n", err)
+	}
+}
+
+// trackWindow records the window geometry each frame, since it can't be
+// read once the window has closed
+func (ui *UI) trackWindow() {
+	if ebiten.IsWindowMinimized() {
+		return
+	}
+	maximized := ebiten.IsWindowMaximized()
+	if maximized && ui.window.Width > 0 {
+		// Keep the restored size to return to when unmaximized
+		ui.window.Maximized = true
+		return
+	}
+	x, y := ebiten.WindowPosition()
+	width, height := ebiten.WindowSize()
+	ui.window = WindowGeometry{X: x, Y: y, Width: width, Height: height, Maximized: maximized}
+}
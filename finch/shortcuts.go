@@ -0,0 +1,60 @@
+package finch
+
+import (
+	"fmt"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Shortcut runs a handler when a key combination such as "ctrl+n" is
+// pressed. Like every shortcut it runs only when no element used the key,
+// so not while a text field takes it or a dialog is open.
+//
+//	ui.Shortcut("ctrl+n", newDocument)
+func (ui *UI) Shortcut(accelerator string, handler func()) *UI {
+	command := components.NewCommand(newID("shortcut"), accelerator, guarded("UI.Shortcut", handler))
+	command.Shortcut = accelerator
+	if err := ui.commands.Shortcuts().Bind(accelerator, command); err != nil {
+		fmt.Printf("Error binding shortcut %s: %v\n", accelerator, err)
+	}
+	return ui
+}
+
+// Shortcut clicks the button when a key combination such as "enter" is
+// pressed, while the button is enabled and shown on the page
+//
+//	c.Button("Add").OnClick(addTodo).Shortcut("enter")
+func (b *Button) Shortcut(accelerator string) *Button {
+	command := components.NewCommand(b.button.ID()+".shortcut", b.button.GetText(), func() {
+		b.button.Click()
+	})
+	command.Shortcut = accelerator
+	command.SetEnabledWhen(func() bool {
+		return !b.button.IsDisabled() && b.ui.showing(b.button)
+	})
+	if err := b.ui.commands.Shortcuts().Bind(accelerator, command); err != nil {
+		fmt.Printf("Error binding shortcut %s: %v\n", accelerator, err)
+	}
+	return b
+}
+
+// showing returns whether an element is in the page with it and every
+// element around it visible
+func (ui *UI) showing(element components.Element) bool {
+	var find func(parent components.Element) bool
+	find = func(parent components.Element) bool {
+		if v, ok := parent.(interface{ IsVisible() bool }); ok && !v.IsVisible() {
+			return false
+		}
+		if parent == element {
+			return true
+		}
+		for _, child := range parent.Children() {
+			if find(child) {
+				return true
+			}
+		}
+		return false
+	}
+	return find(ui.rootContainer)
+}
@@ -0,0 +1,154 @@
+package finch
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Observable is a value that can be read and watched without knowing its
+// type, so widgets can bind to a State of any type
+type Observable interface {
+	// Value returns the current value
+	Value() interface{}
+	// WatchValue calls watcher with the current value and again whenever
+	// it changes
+	WatchValue(watcher func(interface{}))
+}
+
+// State is a reactive value of type T. Watchers are called with the new
+// value whenever it is set to a different one.
+type State[T any] struct {
+	value    T
+	equal    func(a, b T) bool // Nil compares with reflect.DeepEqual
+	watchers []func(T)
+}
+
+// NewState creates a state holding an initial value
+func NewState[T any](initialValue T) *State[T] {
+	return &State[T]{value: initialValue}
+}
+
+// AnyState is a State holding a value of any type, as ui.State returns.
+// State used to be this untyped kind, so code naming *finch.State can name
+// *finch.AnyState instead.
+//
+// Deprecated: Use State[T] with the type of the value.
+type AnyState = State[any]
+
+// Get returns the current value
+func (s *State[T]) Get() T {
+	return s.value
+}
+
+// Set replaces the value and notifies the watchers, unless it is equal to
+// the old one. A slice or map changed in place is equal to itself, so
+// change a copy, as Update does.
+func (s *State[T]) Set(value T) {
+	unchanged := equalValues(s.equal, s.value, value)
+	s.value = value
+	if unchanged {
+		return
+	}
+
+	// Notify watchers, once with the final value if in a batch
+	components.Batched(s, s.notify)
+}
+
+// Update replaces the value with the result of a transform of it. A slice
+// or map is passed as a copy, so the transform can change it in place.
+func (s *State[T]) Update(transform func(T) T) {
+	s.Set(transform(shallowCopy(s.value)))
+}
+
+// Equal sets how a new value is compared with the old one to decide whether
+// the watchers are notified; by default with reflect.DeepEqual. An equal
+// that always returns false notifies on every Set.
+func (s *State[T]) Equal(equal func(a, b T) bool) *State[T] {
+	s.equal = equal
+	return s
+}
+
+// Watch calls watcher with the current value and again whenever it changes
+func (s *State[T]) Watch(watcher func(T)) {
+	watcher = guarded1("State watcher", watcher)
+	s.watchers = append(s.watchers, watcher)
+	watcher(s.value)
+}
+
+// Value returns the current value as an interface{}. Prefer Get, which
+// returns it as a T.
+func (s *State[T]) Value() interface{} {
+	return s.value
+}
+
+// WatchValue is Watch for watchers that don't know the state's type
+func (s *State[T]) WatchValue(watcher func(interface{})) {
+	s.Watch(func(value T) { watcher(value) })
+}
+
+// notify calls the watchers with the current value
+func (s *State[T]) notify() {
+	for _, watcher := range s.watchers {
+		watcher(s.value)
+	}
+}
+
+// equalValues compares values with equal, or with reflect.DeepEqual
+// without one. States and Computed values both use it to decide whether
+// their value changed.
+func equalValues[T any](equal func(a, b T) bool, a, b T) bool {
+	if equal != nil {
+		return equal(a, b)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// shallowCopy returns a copy of a slice or map value, sharing its elements;
+// other values are returned as they are
+func shallowCopy[T any](value T) T {
+	v := reflect.ValueOf(&value).Elem()
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	var copied reflect.Value
+	switch {
+	case v.Kind() == reflect.Slice && !v.IsNil():
+		copied = reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(copied, v)
+	case v.Kind() == reflect.Map && !v.IsNil():
+		copied = reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), iter.Value())
+		}
+	default:
+		return value
+	}
+	result, _ := copied.Interface().(T)
+	return result
+}
+
+// Batch runs fn with state notifications and layout held back until it
+// returns, so watchers of states changed several times run once with the
+// final values. Event handlers already run in a batch per frame.
+func Batch(fn func()) {
+	components.Batch(fn)
+}
+
+// restore sets the value from JSON saved with the session. A State[any]
+// decodes into the type of its current value, so a restored slice of
+// structs is still a slice of structs.
+func (s *State[T]) restore(data json.RawMessage) error {
+	target := reflect.New(reflect.TypeOf(&s.value).Elem())
+	if current := s.Value(); current != nil && target.Elem().Kind() == reflect.Interface {
+		target = reflect.New(reflect.TypeOf(current))
+	}
+	if err := json.Unmarshal(data, target.Interface()); err != nil {
+		return err
+	}
+	value, _ := target.Elem().Interface().(T)
+	s.Set(value)
+	return nil
+}
@@ -0,0 +1,202 @@
+package finch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestStateSetNotifiesWatchers(t *testing.T) {
+	s := NewState(1)
+	var got []int
+	s.Watch(func(v int) { got = append(got, v) })
+
+	s.Set(2)
+	s.Set(3)
+
+	if s.Get() != 3 {
+		t.Errorf("Get() = %d, want 3", s.Get())
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("watcher got %v, want %v", got, want)
+	}
+}
+
+func TestStateWatchValue(t *testing.T) {
+	s := NewState("a")
+	var got []interface{}
+	s.WatchValue(func(v interface{}) { got = append(got, v) })
+
+	s.Set("b")
+
+	if want := []interface{}{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("watcher got %v, want %v", got, want)
+	}
+	if s.Value() != "b" {
+		t.Errorf("Value() = %v, want b", s.Value())
+	}
+}
+
+func TestStateUpdate(t *testing.T) {
+	s := NewState(10)
+	s.Update(func(v int) int { return v * 2 })
+	if s.Get() != 20 {
+		t.Errorf("Get() = %d, want 20", s.Get())
+	}
+}
+
+func TestAnyState(t *testing.T) {
+	// Code written for the old untyped State still works with AnyState
+	var s *AnyState = New().State(1)
+	s.Update(func(v interface{}) interface{} { return v.(int) + 1 })
+	var got []interface{}
+	s.Watch(func(v interface{}) { got = append(got, v) })
+	if want := []interface{}{2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("watcher got %v, want %v", got, want)
+	}
+}
+
+func TestStateUpdatePassesCopy(t *testing.T) {
+	original := []string{"a", "b"}
+	s := NewState(original)
+	s.Update(func(items []string) []string {
+		items[0] = "z"
+		return items
+	})
+
+	if original[0] != "a" {
+		t.Errorf("Update changed the old slice in place: %v", original)
+	}
+	if want := []string{"z", "b"}; !reflect.DeepEqual(s.Get(), want) {
+		t.Errorf("Get() = %v, want %v", s.Get(), want)
+	}
+}
+
+func TestStateRestore(t *testing.T) {
+	s := NewState([]string{"a"})
+	var got [][]string
+	s.Watch(func(v []string) { got = append(got, v) })
+
+	if err := s.restore(json.RawMessage(`["b","c"]`)); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if want := []string{"b", "c"}; !reflect.DeepEqual(s.Get(), want) {
+		t.Errorf("Get() = %v, want %v", s.Get(), want)
+	}
+	if len(got) != 2 {
+		t.Errorf("watcher called %d times, want 2", len(got))
+	}
+}
+
+func TestStateRestoreAnyKeepsType(t *testing.T) {
+	type todo struct {
+		Text string
+		Done bool
+	}
+	s := NewState[any]([]todo{{Text: "old"}})
+
+	if err := s.restore(json.RawMessage(`[{"Text":"new","Done":true}]`)); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	want := []todo{{Text: "new", Done: true}}
+	if !reflect.DeepEqual(s.Get(), want) {
+		t.Errorf("Get() = %#v, want %#v", s.Get(), want)
+	}
+}
+
+func TestStateRestoreInvalid(t *testing.T) {
+	s := NewState(5)
+	if err := s.restore(json.RawMessage(`"five"`)); err == nil {
+		t.Error("restore of a string into an int state succeeded")
+	}
+	if s.Get() != 5 {
+		t.Errorf("Get() = %d after a failed restore, want 5", s.Get())
+	}
+}
+
+func TestStateSetEqualValueDoesNotNotify(t *testing.T) {
+	s := NewState([]string{"a", "b"})
+	calls := 0
+	s.Watch(func([]string) { calls++ })
+
+	s.Set([]string{"a", "b"})
+
+	if calls != 1 {
+		t.Errorf("watcher called %d times, want 1", calls)
+	}
+}
+
+func TestStateUpdateInPlaceNotifies(t *testing.T) {
+	s := NewState(map[string]int{"a": 1})
+	var got []int
+	s.Watch(func(m map[string]int) { got = append(got, m["a"]) })
+
+	s.Update(func(m map[string]int) map[string]int {
+		m["a"] = 2
+		return m
+	})
+
+	if want := []int{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("watcher got %v, want %v", got, want)
+	}
+}
+
+func TestStateEqual(t *testing.T) {
+	s := NewState(1).Equal(func(a, b int) bool { return false })
+	calls := 0
+	s.Watch(func(int) { calls++ })
+
+	s.Set(1)
+	s.Set(1)
+
+	if calls != 3 {
+		t.Errorf("watcher called %d times, want 3", calls)
+	}
+}
+
+func TestComputedEqual(t *testing.T) {
+	n := NewState(1)
+	tens := NewComputed(func() int { return n.Get() }, n).Equal(func(a, b int) bool {
+		return a/10 == b/10
+	})
+	calls := 0
+	tens.Watch(func(int) { calls++ })
+
+	n.Set(5) // Same tens
+	if tens.Get() != 5 {
+		t.Errorf("Get() = %d after an equal result, want 5", tens.Get())
+	}
+	n.Set(12) // Different tens
+
+	if calls != 2 {
+		t.Errorf("watcher called %d times, want 2", calls)
+	}
+	if tens.Get() != 12 {
+		t.Errorf("Get() = %d, want 12", tens.Get())
+	}
+}
+
+func TestBatchNotifiesOnceWithFinalValue(t *testing.T) {
+	a := NewState(0)
+	b := NewState(0)
+	sum := NewComputed(func() int { return a.Get() + b.Get() }, a, b)
+	var gotA, gotSum []int
+	a.Watch(func(v int) { gotA = append(gotA, v) })
+	sum.Watch(func(v int) { gotSum = append(gotSum, v) })
+
+	Batch(func() {
+		a.Set(1)
+		a.Set(2)
+		b.Set(3)
+		if len(gotA) != 1 {
+			t.Errorf("watcher called during the batch: %v", gotA)
+		}
+	})
+
+	if want := []int{0, 2}; !reflect.DeepEqual(gotA, want) {
+		t.Errorf("state watcher got %v, want %v", gotA, want)
+	}
+	if want := []int{0, 5}; !reflect.DeepEqual(gotSum, want) {
+		t.Errorf("computed watcher got %v, want %v", gotSum, want)
+	}
+}
@@ -0,0 +1,134 @@
+package finch
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Style overrides theme colors for a single widget. Colors are hex strings
+// such as "#e53935"; empty fields keep the theme's. Styled widgets still
+// follow theme changes for the colors that aren't overridden.
+//
+//	ui.Slider(0, 10).Style(finch.Style{Accent: "#e53935"})
+type Style struct {
+	Background string // Page background
+	Surface    string // Cards, panels and knobs
+	Text       string
+	MutedText  string
+	Accent     string // Fills, selections and focus rings
+	Border     string // Outlines and empty tracks
+	FontSize   int    // Text size of buttons and text; 0 keeps it
+}
+
+// themed is an element drawn in theme colors
+type themed interface {
+	ApplyTheme(theme components.Theme)
+}
+
+// parseColor parses a "#rrggbb" hex color
+func parseColor(hexColor string) (color.RGBA, bool) {
+	var r, g, b uint8
+	if _, err := fmt.Sscanf(hexColor, "#%02x%02x%02x", &r, &g, &b); err != nil {
+		return color.RGBA{}, false
+	}
+	return color.RGBA{r, g, b, 255}, true
+}
+
+// apply returns a theme with the style's colors in place of the theme's
+func (s Style) apply(theme components.Theme) components.Theme {
+	for _, field := range []struct {
+		hex    string
+		target *color.RGBA
+	}{
+		{s.Background, &theme.Background},
+		{s.Surface, &theme.Surface},
+		{s.Text, &theme.Text},
+		{s.MutedText, &theme.MutedText},
+		{s.Accent, &theme.Accent},
+		{s.Border, &theme.Border},
+	} {
+		if c, ok := parseColor(field.hex); ok {
+			*field.target = c
+		}
+	}
+	return theme
+}
+
+// styled draws an element in the current theme with a style applied, now
+// and whenever the theme changes
+func (ui *UI) styled(element themed, style Style) {
+	element.ApplyTheme(style.apply(ui.theme.Current()))
+	ui.theme.OnThemeChanged(func(theme components.Theme) {
+		element.ApplyTheme(style.apply(theme))
+	})
+}
+
+// Style overrides the button's background, text color and font size
+func (b *Button) Style(style Style) *Button {
+	b.ui.styled(b.button, style)
+	if c, ok := parseColor(style.Background); ok {
+		b.button.SetBackgroundColor(c)
+	}
+	if c, ok := parseColor(style.Text); ok {
+		b.button.SetTextColor(c)
+	}
+	if style.FontSize > 0 {
+		b.button.SetFontSize(style.FontSize)
+	}
+	return b
+}
+
+// Style overrides the text's color and font size
+func (t *Text) Style(style Style) *Text {
+	if c, ok := parseColor(style.Text); ok {
+		t.label.SetTextColor(c)
+	}
+	if style.FontSize > 0 {
+		t.label.SetFontSize(style.FontSize)
+	}
+	return t
+}
+
+// Style overrides the theme colors of the card
+func (c *Card) Style(style Style) *Card {
+	c.ui.styled(c.card, style)
+	return c
+}
+
+// Style overrides the theme colors of the code view
+func (c *CodeView) Style(style Style) *CodeView {
+	c.ui.styled(c.codeView, style)
+	return c
+}
+
+// Style overrides the theme colors of the slider
+func (s *Slider) Style(style Style) *Slider {
+	s.ui.styled(s.slider, style)
+	return s
+}
+
+// Style overrides the theme colors of the progress bar
+func (p *Progress) Style(style Style) *Progress {
+	p.ui.styled(p.bar, style)
+	return p
+}
+
+// Style overrides the theme colors of the radio group
+func (r *Radio) Style(style Style) *Radio {
+	r.ui.styled(r.group, style)
+	return r
+}
+
+// Style overrides the theme colors of the switch
+func (t *Toggle) Style(style Style) *Toggle {
+	t.ui.styled(t.toggle, style)
+	return t
+}
+
+// Style overrides the theme's text color for the icon
+func (i *Icon) Style(style Style) *Icon {
+	i.ui.styled(i.icon, style)
+	return i
+}
@@ -0,0 +1,214 @@
+package finch
+
+import (
+	"fmt"
+
+	"github.com/aggnr/finch/components"
+)
+
+// Table shows a slice of structs as rows, one column per field unless
+// columns are declared. It wraps a DataGrid.
+type Table struct {
+	grid     *components.DataGrid
+	ui       *UI
+	inferred bool // Columns were inferred from the struct's fields
+}
+
+// Table adds a table of a slice of structs, or of a State holding one, which
+// the table follows as it changes. Columns are inferred from the exported
+// fields, named by a `grid:"Title"` tag and skipped by `grid:"-"`, until
+// declared with Column.
+//
+//	people := finch.NewState([]Person{{"Ada", 36}, {"Alan", 41}})
+//	ui.Table(people).OnRowClick(func(index int, row interface{}) {
+//		fmt.Println(row.(Person).Name)
+//	})
+func (ui *UI) Table(data interface{}) *Table {
+	grid := components.NewDataGrid(newID("table"))
+	grid.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 240})
+
+	t := &Table{
+		grid:     grid,
+		ui:       ui,
+		inferred: true,
+	}
+	if state, ok := data.(Observable); ok {
+		bound := false
+		state.WatchValue(func(value interface{}) {
+			if bound {
+				t.rebind(value)
+				return
+			}
+			bound = true
+			t.bind(value)
+		})
+	} else {
+		t.bind(data)
+	}
+
+	ui.currentParent.AddChild(grid)
+
+	return t
+}
+
+// Table adds a table of a slice of structs, or of a State holding one, to
+// the container
+func (c *Container) Table(data interface{}) *Table {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+
+	// Add the table
+	table := c.ui.Table(data)
+
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+
+	return table
+}
+
+// bind sets the rows for the first time, inferring the columns
+func (t *Table) bind(data interface{}) {
+	if err := t.grid.Bind(data); err != nil {
+		fmt.Printf("Error binding table: %v\n", err)
+	}
+}
+
+// rebind replaces the rows, keeping the sort order and scroll position
+func (t *Table) rebind(data interface{}) {
+	if err := t.grid.Rebind(data); err != nil {
+		fmt.Printf("Error binding table: %v\n", err)
+	}
+}
+
+// Column declares a column showing a struct field. The first call replaces
+// the inferred columns.
+func (t *Table) Column(title, field string, width int) *Table {
+	if t.inferred {
+		t.grid.ClearColumns()
+		t.inferred = false
+	}
+	t.grid.AddColumn(title, field, width)
+	return t
+}
+
+// column returns the column showing a field, or nil
+func (t *Table) column(field string) *components.DataGridColumn {
+	for _, column := range t.grid.Columns() {
+		if column.Field == field {
+			return column
+		}
+	}
+	return nil
+}
+
+// Format sets how a field's values are written, e.g. components.NumberFormat(2)
+func (t *Table) Format(field string, format components.ValueFormat) *Table {
+	if column := t.column(field); column != nil {
+		column.Format = format
+	}
+	return t
+}
+
+// Sortable sets whether clicking a column's header sorts by it, toggling
+// between ascending and descending. With no fields, it applies to every
+// column.
+func (t *Table) Sortable(sortable bool, fields ...string) *Table {
+	for _, column := range t.grid.Columns() {
+		if len(fields) == 0 {
+			column.Sortable = sortable
+			continue
+		}
+		for _, field := range fields {
+			if column.Field == field {
+				column.Sortable = sortable
+			}
+		}
+	}
+	return t
+}
+
+// SortBy sorts the rows by a field
+func (t *Table) SortBy(field string, ascending bool) *Table {
+	for i, column := range t.grid.Columns() {
+		if column.Field == field {
+			t.grid.SortBy(i, ascending)
+			break
+		}
+	}
+	return t
+}
+
+// OnSort sets a handler called with the field and direction when the sort
+// changes
+func (t *Table) OnSort(handler func(field string, ascending bool)) *Table {
+	handler = guarded2("Table.OnSort", handler)
+	t.grid.SetOnSort(func(column int, ascending bool) {
+		if column < 0 {
+			handler("", ascending)
+			return
+		}
+		handler(t.grid.Columns()[column].Field, ascending)
+	})
+	return t
+}
+
+// OnRowClick sets a handler called with the index in the bound slice and
+// the item of a row that is clicked
+func (t *Table) OnRowClick(handler func(index int, row interface{})) *Table {
+	handler = guarded2("Table.OnRowClick", handler)
+	t.grid.SetOnRowClick(func(index int) {
+		handler(index, t.grid.Row(index))
+	})
+	return t
+}
+
+// OnSelect sets a handler called with the selected items when the
+// selection changes
+func (t *Table) OnSelect(handler func(rows []interface{})) *Table {
+	handler = guarded1("Table.OnSelect", handler)
+	t.grid.SetOnSelectionChanged(func([]int) {
+		handler(t.grid.SelectedItems())
+	})
+	return t
+}
+
+// MultiSelect sets whether clicking rows toggles them in a selection of
+// several
+func (t *Table) MultiSelect(multi bool) *Table {
+	t.grid.SetMultiSelect(multi)
+	return t
+}
+
+// Selected returns the selected items
+func (t *Table) Selected() []interface{} {
+	return t.grid.SelectedItems()
+}
+
+// PageSize splits the rows into pages of a size; 0 shows them all
+func (t *Table) PageSize(size int) *Table {
+	t.grid.SetPageSize(size)
+	return t
+}
+
+// Height sets the height of the table
+func (t *Table) Height(height int) *Table {
+	bounds := t.grid.Bounds()
+	bounds.Height = height
+	t.grid.SetBounds(bounds)
+	return t
+}
+
+// Refresh re-reads a bound slice after its items have been changed in place
+func (t *Table) Refresh() *Table {
+	t.grid.Refresh()
+	return t
+}
+
+// Bind replaces the rows with another slice of the same struct type
+func (t *Table) Bind(data interface{}) *Table {
+	t.rebind(data)
+	return t
+}
@@ -1,11 +1,16 @@
 package finch
 
 import (
+	"context"
 	"fmt"
 	"image/color"
+	"io/fs"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/aggnr/finch/components"
+	"golang.org/x/image/font"
 )
 
 // UI is the main entry point for the Finch UI framework
@@ -15,6 +20,36 @@ type UI struct {
 	height        int
 	title         string
 	currentParent components.Element
+	colorFilter   *components.ColorFilterPass
+	focus         *components.FocusManager
+	theme         *components.ThemeManager
+	commands      *components.CommandRegistry
+	contextMenus  *components.ContextMenuManager
+	toasts        *components.ToastCenter
+	popups        *components.PopupLayer
+	menuBar       *components.MenuBar // Nil until a menu is added
+	lint          *components.DesignLintOverlay
+	lintRules     components.DesignRules
+	dev           *components.DevOverlay
+	trace         *components.TraceRecorder
+	clicks        *components.ClickDetector
+	wasPressed    bool // Left button was held last frame
+	onUpdate      []func(delta time.Duration)
+	onFrame       []func(delta time.Duration)
+	onResize      []func(width, height int)
+	onError       []func(err *PanicError)
+	lastPanic     string // Error of the last panic reported
+	lastPanicAt   time.Time // When it last happened, reported or not
+	lastUpdate    time.Time
+	lastFrame     time.Time
+	prepared      bool // Overlays have been added to the root
+	transparent   bool // No page background, for UIs composited over others
+	persisted     map[string]PersistentState
+	restored      *Session
+	sessionPath   string
+	window        WindowGeometry
+	windowSized   bool   // Window().Size was set, so Run keeps it
+	windowLimits  [4]int // Minimum and maximum window width and height; 0 for none
 }
 
 // PageConfig represents configuration for the page
@@ -34,13 +69,32 @@ func New() *UI {
 		width:         800,
 		height:        600,
 		title:         "Finch UI App",
+		colorFilter:   components.NewColorFilterPass(),
+		focus:         components.NewFocusManager(root),
+		theme:         components.NewThemeManager(),
+		commands:      components.NewCommandRegistry(),
+		contextMenus:  components.NewContextMenuManager(root),
+		toasts:        components.NewToastCenter("toasts"),
+		popups:        components.NewPopupLayer("popups"),
+		lintRules:     components.DefaultDesignRules(),
+		trace:         components.NewTraceRecorder(),
+		clicks:        components.NewClickDetector(),
+		persisted:     make(map[string]PersistentState),
 	}
 	
 	// Set default properties
 	root.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: ui.height})
-	root.SetBackgroundColor(color.RGBA{240, 240, 240, 255})
+	root.SetBackgroundColor(ui.theme.Current().Background)
+	ui.theme.OnThemeChanged(func(theme components.Theme) {
+		if !ui.transparent {
+			root.SetBackgroundColor(theme.Background)
+		}
+	})
 	root.SetFlexDirection(components.FlexColumn)
 	
+	// Handlers that panic while the app is being built report to this UI
+	activeUI = ui
+	
 	return ui
 }
 
@@ -57,10 +111,324 @@ func (ui *UI) SetPageConfig(title string, layout string) *UI {
 	return ui
 }
 
+// IDPrefix puts text before the IDs of elements created from now on, e.g.
+// "settings." to tell apart the elements of several UIs in one process
+func (ui *UI) IDPrefix(prefix string) *UI {
+	components.SetIDPrefix(prefix)
+	return ui
+}
+
+// SetTransparent sets whether the page background is left out, so that
+// UIs composited below this one show through, e.g. for a HUD or overlay
+func (ui *UI) SetTransparent(transparent bool) *UI {
+	ui.transparent = transparent
+	if transparent {
+		ui.rootContainer.SetBackgroundColor(color.RGBA{})
+	} else {
+		ui.rootContainer.SetBackgroundColor(ui.theme.Current().Background)
+	}
+	return ui
+}
+
+// DesignLint turns on outlines around elements whose colors aren't the
+// theme's, or whose font sizes, spacing or corner radii break the design
+// rules; hovering an outlined element lists its issues
+func (ui *UI) DesignLint(enabled bool) *UI {
+	if ui.lint == nil {
+		if !enabled {
+			return ui
+		}
+		ui.lint = components.NewDesignLintOverlay("designlint", ui.rootContainer, ui.theme.Current(), ui.lintRules)
+		ui.theme.OnThemeChanged(ui.lint.SetTheme)
+		if ui.prepared {
+			ui.rootContainer.AddChild(ui.lint)
+		}
+	}
+	ui.lint.SetVisible(enabled)
+	return ui
+}
+
+// DevOverlay turns on a panel for developing the app, shown and hidden
+// with F12, with the frame rates, draw time, element count and last input
+// events. Turning it off removes the panel.
+func (ui *UI) DevOverlay(enabled bool) *UI {
+	if !enabled {
+		if ui.dev != nil {
+			ui.rootContainer.RemoveChild(ui.dev)
+			ui.dev = nil
+		}
+		return ui
+	}
+	if ui.dev == nil {
+		ui.dev = components.NewDevOverlay("devoverlay", ui.rootContainer)
+		if ui.prepared {
+			ui.rootContainer.AddChild(ui.dev)
+		}
+	}
+	return ui
+}
+
+// record tells traces and the dev overlay about an event acting on an
+// element
+func (ui *UI) record(action string, target components.Element) {
+	ui.trace.Record(action, target)
+	if ui.dev != nil {
+		ui.dev.Record(action, target)
+	}
+}
+
+// DesignRules sets the type scale, spacing grid, corner radii and extra
+// colors that DesignLint and LintReport check against
+func (ui *UI) DesignRules(rules components.DesignRules) *UI {
+	ui.lintRules = rules
+	if ui.lint != nil {
+		ui.lint.SetRules(rules)
+	}
+	return ui
+}
+
+// LintReport checks the whole UI against the design rules now and returns
+// the issues, e.g. to print with components.FormatLintReport
+func (ui *UI) LintReport() []components.LintIssue {
+	return components.DesignLint(ui.rootContainer, ui.theme.Current(), ui.lintRules)
+}
+
+// StartTrace starts recording a trace of the user's flow: the screens
+// visited, the elements used and the time spent on each step
+func (ui *UI) StartTrace(name string) *UI {
+	ui.trace.Start(name)
+	return ui
+}
+
+// StopTrace stops recording and returns the trace, or nil if none was
+// recording. Compare traces from two versions of the app with
+// components.CompareTraces.
+func (ui *UI) StopTrace() *components.Trace {
+	return ui.trace.Stop()
+}
+
+// TraceScreen tells traces that a new screen is showing, e.g. "Checkout"
+func (ui *UI) TraceScreen(name string) *UI {
+	ui.trace.SetScreen(name)
+	return ui
+}
+
+// SetColorFilter applies a debug color filter (e.g. color-blindness simulation) to every frame
+func (ui *UI) SetColorFilter(filter components.ColorFilter) *UI {
+	ui.colorFilter.SetFilter(filter)
+	return ui
+}
+
+// Focus returns the focus manager, used to move keyboard focus and to open
+// focus scopes for dialogs and popovers
+func (ui *UI) Focus() *components.FocusManager {
+	return ui.focus
+}
+
+// Theme returns the theme manager, which follows the OS light/dark
+// preference unless overridden
+func (ui *UI) Theme() *components.ThemeManager {
+	return ui.theme
+}
+
+// SetTheme fixes the theme, ignoring the OS preference
+func (ui *UI) SetTheme(theme components.Theme) *UI {
+	ui.theme.Override(theme)
+	return ui
+}
+
+// UseDarkMode switches to the dark theme, or the light one, ignoring the OS
+// preference. Use Theme().ClearOverride() to follow the OS again.
+func (ui *UI) UseDarkMode(dark bool) *UI {
+	light, darkTheme := ui.theme.Themes()
+	if dark {
+		ui.theme.Override(darkTheme)
+	} else {
+		ui.theme.Override(light)
+	}
+	return ui
+}
+
+// HighContrast switches to the high-contrast theme, or back to following the
+// OS preference
+func (ui *UI) HighContrast(enabled bool) *UI {
+	if enabled {
+		ui.theme.Override(components.HighContrastTheme())
+	} else {
+		ui.theme.ClearOverride()
+	}
+	return ui
+}
+
+// TextScale draws all text larger, e.g. components.TextScaleLarger for
+// 150%. Set it before adding elements so their heights make room for it.
+func (ui *UI) TextScale(scale float64) *UI {
+	components.SetTextScale(scale)
+	return ui
+}
+
+// Locale sets the locale numbers, money and dates are formatted for, such as
+// "de-DE"
+func (ui *UI) Locale(locale string) *UI {
+	if err := components.SetLocale(locale); err != nil {
+		fmt.Printf("Error setting locale: %v\n", err)
+	}
+	return ui
+}
+
+// OnThemeChanged sets a handler called when the theme changes, e.g. when the
+// OS switches to dark mode
+func (ui *UI) OnThemeChanged(handler func(components.Theme)) *UI {
+	handler = guarded1("UI.OnThemeChanged", handler)
+	ui.theme.OnThemeChanged(handler)
+	return ui
+}
+
+// FontFallback sets the fonts text is drawn with, in fallback order, e.g. a
+// UI font, then a CJK font, then an emoji font. Themes with their own Fonts
+// chain replace it while active.
+func (ui *UI) FontFallback(faces ...font.Face) *UI {
+	components.SetFontChain(components.NewFontChain(faces...))
+	return ui
+}
+
+// SetShaper sets how text is shaped, e.g. a HarfBuzzShaper (built with
+// -tags harfbuzz) for Arabic and Indic scripts; nil restores the simple shaper
+func (ui *UI) SetShaper(shaper components.Shaper) *UI {
+	components.SetShaper(shaper)
+	return ui
+}
+
+// SetReducedMotion sets whether non-essential animations are skipped,
+// overriding the OS accessibility setting
+func (ui *UI) SetReducedMotion(reduced bool) *UI {
+	if reduced {
+		components.SetMotionPreference(components.MotionReduced)
+	} else {
+		components.SetMotionPreference(components.MotionFull)
+	}
+	return ui
+}
+
+// Command registers an action once so menus, toolbars, shortcuts and the
+// command palette all show the same title and enabled state
+func (ui *UI) Command(id, title string, handler func()) *Command {
+	command := components.NewCommand(id, title, guarded("UI.Command", handler))
+	ui.commands.Register(command)
+	
+	return &Command{
+		command: command,
+		ui:      ui,
+	}
+}
+
+// Commands returns the registry of the app's commands
+func (ui *UI) Commands() *components.CommandRegistry {
+	return ui.commands
+}
+
+// CommandPalette adds a searchable list of every command, opened with the
+// given shortcut such as "Ctrl+Shift+P"
+func (ui *UI) CommandPalette(shortcut string) *UI {
+	palette := components.NewCommandPalette(newID("palette"), ui.commands)
+	palette.SetFocusManager(ui.focus)
+	ui.rootContainer.AddChild(palette)
+	
+	if err := ui.commands.Shortcuts().Bind(shortcut, components.NewCommand("palette.open", "Show All Commands", palette.Open)); err != nil {
+		fmt.Printf("Error binding command palette shortcut: %v\n", err)
+	}
+	return ui
+}
+
+// ContextMenu sets the right-click menu of the element added last, built
+// from commands; nil adds a separator. Disabled commands show as disabled.
+//
+//	ui.Text("Document")
+//	ui.ContextMenu(cut, copy, nil, paste)
+func (ui *UI) ContextMenu(commands ...*Command) *UI {
+	children := ui.currentParent.Children()
+	if len(children) == 0 {
+		return ui
+	}
+	target, ok := children[len(children)-1].(interface{ SetContextMenu(...*components.Command) })
+	if !ok {
+		return ui
+	}
+	
+	list := make([]*components.Command, len(commands))
+	for i, command := range commands {
+		if command != nil {
+			list[i] = command.command
+		}
+	}
+	target.SetContextMenu(list...)
+	return ui
+}
+
+// OnUpdate adds a handler for app logic, called on every update tick (60
+// times a second by default) after input is handled, with the time since
+// the previous tick. State set by the handler notifies once, with the
+// tick's other changes.
+func (ui *UI) OnUpdate(handler func(delta time.Duration)) *UI {
+	handler = guarded1("UI.OnUpdate", handler)
+	ui.onUpdate = append(ui.onUpdate, handler)
+	return ui
+}
+
+// OnFrame adds a handler called before every frame is drawn, with the time
+// since the previous frame, e.g. to advance an animation. Frames can be
+// drawn more or less often than updates run, so keep app logic in OnUpdate.
+func (ui *UI) OnFrame(handler func(delta time.Duration)) *UI {
+	handler = guarded1("UI.OnFrame", handler)
+	ui.onFrame = append(ui.onFrame, handler)
+	return ui
+}
+
+// Batch runs fn with layout and state notifications held back until it
+// returns, so bulk changes such as loading a thousand todos lay out and
+// notify watchers once instead of after every change. Batches may nest.
+//
+//	ui.Batch(func() {
+//		for _, todo := range todos {
+//			ui.Text(todo.Title)
+//		}
+//	})
+func (ui *UI) Batch(fn func()) *UI {
+	components.Batch(fn)
+	return ui
+}
+
+// Debounce wraps a handler so it runs once, with the latest value, after
+// calls stop for the wait time:
+//
+//	ui.TextInput("Search").OnChange(finch.Debounce(300*time.Millisecond, search))
+func Debounce[T any](wait time.Duration, handler func(T)) func(T) {
+	return components.Debounce(wait, guarded1("Debounce", handler))
+}
+
+// Throttle wraps a handler so it runs at most once per interval, with a
+// final call for the latest value
+func Throttle[T any](interval time.Duration, handler func(T)) func(T) {
+	return components.Throttle(interval, guarded1("Throttle", handler))
+}
+
+// Task runs fn in the background with a toast showing its progress and a
+// Cancel button, replaced by a success or failure message when it ends. fn
+// should return when ctx is canceled and may report progress from 0 to 1.
+//
+//	ui.Task("Exporting", export).ResultAction("Open", openExport)
+func (ui *UI) Task(title string, fn func(ctx context.Context, report func(progress float64)) error) *Task {
+	task := components.StartTask(title, fn)
+	return &Task{
+		task:  task,
+		toast: ui.toasts.TrackTask(task),
+	}
+}
+
 // Title adds a title to the UI
 func (ui *UI) Title(text string) *Text {
-	title := components.NewLabel("title_"+randomID(), text, 24, color.RGBA{50, 50, 50, 255})
-	title.SetBounds(components.Rect{X: 0, Y: 20, Width: ui.width, Height: 40})
+	title := components.NewLabel(newID("title"), text, 24, color.RGBA{50, 50, 50, 255})
+	title.SetBounds(components.Rect{X: 0, Y: 20, Width: ui.width, Height: components.ScaleTextSize(40)})
 	
 	ui.currentParent.AddChild(title)
 	
@@ -72,8 +440,8 @@ func (ui *UI) Title(text string) *Text {
 
 // Text adds a text element to the UI
 func (ui *UI) Text(text string) *Text {
-	label := components.NewLabel("text_"+randomID(), text, 16, color.RGBA{0, 0, 0, 255})
-	label.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 20})
+	label := components.NewLabel(newID("text"), text, 16, color.RGBA{0, 0, 0, 255})
+	label.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: components.ScaleTextSize(20)})
 	
 	ui.currentParent.AddChild(label)
 	
@@ -83,9 +451,23 @@ func (ui *UI) Text(text string) *Text {
 	}
 }
 
+// TimeAgo adds text showing a time relative to now, such as "5 minutes
+// ago", that keeps itself current
+func (ui *UI) TimeAgo(t time.Time) *Text {
+	label := components.NewTimeAgoLabel(newID("timeago"), t, 16, color.RGBA{0, 0, 0, 255})
+	label.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: components.ScaleTextSize(20)})
+	
+	ui.currentParent.AddChild(label)
+	
+	return &Text{
+		label: label.Label,
+		ui:    ui,
+	}
+}
+
 // Container creates a container for organizing UI elements
 func (ui *UI) Container() *Container {
-	container := components.NewFlexContainer("container_" + randomID())
+	container := components.NewFlexContainer(newID("container"))
 	container.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 100})
 	container.SetFlexDirection(components.FlexColumn)
 	
@@ -99,8 +481,10 @@ func (ui *UI) Container() *Container {
 
 // Button adds a button to the UI
 func (ui *UI) Button(label string) *Button {
-	button := components.NewButton("button_"+randomID(), label)
-	button.SetBounds(components.Rect{X: 0, Y: 0, Width: 120, Height: 40})
+	button := components.NewButton(newID("button"), label)
+	button.SetBounds(components.Rect{X: 0, Y: 0, Width: 120, Height: components.ScaleTextSize(40)})
+	button.ApplyTheme(ui.theme.Current())
+	ui.theme.OnThemeChanged(button.ApplyTheme)
 	
 	ui.currentParent.AddChild(button)
 	
@@ -112,8 +496,8 @@ func (ui *UI) Button(label string) *Button {
 
 // TextInput adds a text input field to the UI
 func (ui *UI) TextInput(placeholder string) *TextInput {
-	input := components.NewTextArea("input_" + randomID())
-	input.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width - 150, Height: 40})
+	input := components.NewTextArea(newID("input"))
+	input.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width - 150, Height: components.ScaleTextSize(40)})
 	input.SetPlaceholder(placeholder)
 	
 	ui.currentParent.AddChild(input)
@@ -124,20 +508,400 @@ func (ui *UI) TextInput(placeholder string) *TextInput {
 	}
 }
 
+// TextArea adds a multi-line text editor of the given height that wraps
+// long lines and scrolls when the text is taller than it
+func (ui *UI) TextArea(placeholder string, height int) *TextInput {
+	input := components.NewTextArea(newID("textarea"))
+	input.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width - 150, Height: height})
+	input.SetPlaceholder(placeholder)
+	
+	ui.currentParent.AddChild(input)
+	
+	return &TextInput{
+		input: input,
+		ui:    ui,
+	}
+}
+
+// MaskedInput adds a field that formats typed text with a pattern, where #
+// is a digit, A a letter and * either, e.g. "(###) ###-####"
+func (ui *UI) MaskedInput(mask string) *MaskedInput {
+	input := components.NewMaskedInput(newID("masked"), mask)
+	input.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width - 150, Height: components.ScaleTextSize(40)})
+	
+	ui.currentParent.AddChild(input)
+	
+	return &MaskedInput{
+		input: input,
+		ui:    ui,
+	}
+}
+
+// ComboBox adds an editable text field with a filtered dropdown of suggestions
+func (ui *UI) ComboBox(placeholder string, items []string) *ComboBox {
+	combo := components.NewComboBox(newID("combo"), items)
+	combo.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width - 150, Height: components.ScaleTextSize(40)})
+	combo.SetPlaceholder(placeholder)
+	
+	ui.currentParent.AddChild(combo)
+	
+	return &ComboBox{
+		combo: combo,
+		ui:    ui,
+	}
+}
+
+// Select adds a dropdown list of options, with none selected
+func (ui *UI) Select(options []string) *Select {
+	sel := components.NewSelect(newID("select"), options)
+	sel.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width - 150, Height: components.ScaleTextSize(32)})
+	
+	ui.currentParent.AddChild(sel)
+	
+	return &Select{
+		sel: sel,
+		ui:  ui,
+	}
+}
+
+// DatePicker adds a date field with a calendar popup to the UI
+func (ui *UI) DatePicker() *DatePicker {
+	picker := components.NewDatePicker(newID("date"))
+	picker.SetBounds(components.Rect{X: 0, Y: 0, Width: 200, Height: components.ScaleTextSize(40)})
+	
+	ui.currentParent.AddChild(picker)
+	
+	return &DatePicker{
+		picker: picker,
+		ui:     ui,
+	}
+}
+
+// TimePicker adds a time of day field to the UI
+func (ui *UI) TimePicker() *TimePicker {
+	picker := components.NewTimePicker(newID("time"))
+	picker.SetBounds(components.Rect{X: 0, Y: 0, Width: 140, Height: components.ScaleTextSize(40)})
+	
+	ui.currentParent.AddChild(picker)
+	
+	return &TimePicker{
+		picker: picker,
+		ui:     ui,
+	}
+}
+
+// DrawingLayer adds a freehand drawing area to the UI
+func (ui *UI) DrawingLayer(height int) *DrawingLayer {
+	layer := components.NewDrawingLayer(newID("drawing"))
+	layer.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: height})
+	
+	ui.currentParent.AddChild(layer)
+	
+	return &DrawingLayer{
+		layer: layer,
+		ui:    ui,
+	}
+}
+
+// Canvas adds an area that the draw callback paints each frame, in local
+// coordinates with (0, 0) at its top left
+func (ui *UI) Canvas(height int, draw func(surface components.DrawSurface, width, height int)) *Canvas {
+	canvas := components.NewCanvas(newID("canvas"), draw)
+	canvas.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: height})
+	
+	ui.currentParent.AddChild(canvas)
+	
+	return &Canvas{
+		canvas: canvas,
+		ui:     ui,
+	}
+}
+
+// CodeView adds read-only source code with line numbers, highlighted as
+// the given language or file name, e.g. "go" or "config.json"
+func (ui *UI) CodeView(code, language string, height int) *CodeView {
+	codeView := components.NewCodeView(newID("code"), code)
+	codeView.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: height})
+	codeView.SetLanguage(language)
+	codeView.ApplyTheme(ui.theme.Current())
+	ui.theme.OnThemeChanged(codeView.ApplyTheme)
+	
+	ui.currentParent.AddChild(codeView)
+	
+	return &CodeView{
+		codeView: codeView,
+		ui:       ui,
+	}
+}
+
+// RichText adds a block of wrapped paragraphs
+func (ui *UI) RichText(height int) *RichText {
+	richText := components.NewRichText(newID("richtext"))
+	richText.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: height})
+	
+	ui.currentParent.AddChild(richText)
+	
+	return &RichText{
+		richText: richText,
+		style:    components.DefaultParagraphStyle(),
+		ui:       ui,
+	}
+}
+
+// Markdown adds text written in Markdown, as tall as its content
+func (ui *UI) Markdown(src string) *RichText {
+	return ui.RichText(0).Markdown(src).FitContent()
+}
+
+// TreeView adds a hierarchical list
+func (ui *UI) TreeView(height int, items []components.TreeItem) *TreeView {
+	tree := components.NewTreeView(newID("tree"))
+	tree.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: height})
+	tree.SetItems(items)
+	tree.ExpandAll()
+	
+	ui.currentParent.AddChild(tree)
+	
+	return &TreeView{
+		tree: tree,
+		ui:   ui,
+	}
+}
+
+// TableOfContents adds a tree of a document's headings; selecting one
+// scrolls the document to it. Call it after the document's content is added.
+func (ui *UI) TableOfContents(document *RichText, height int) *TreeView {
+	outline := document.richText.Outline()
+	items := make([]components.TreeItem, len(outline))
+	for i, entry := range outline {
+		items[i] = entry
+	}
+	
+	tree := ui.TreeView(height, items)
+	tree.tree.SetOnSelect(func(item components.TreeItem) {
+		if entry, ok := item.(*components.OutlineEntry); ok {
+			document.richText.ScrollToAnchor(entry.Anchor)
+		}
+	})
+	return tree
+}
+
+// Rating adds a row of stars for picking a score
+func (ui *UI) Rating(max int) *Rating {
+	rating := components.NewRating(newID("rating"))
+	rating.SetMax(max)
+	rating.SetBounds(components.Rect{X: 0, Y: 0, Width: max * 28, Height: 26})
+	
+	ui.currentParent.AddChild(rating)
+	
+	return &Rating{
+		rating: rating,
+		ui:     ui,
+	}
+}
+
+// Slider adds a slider for picking a number between min and max
+func (ui *UI) Slider(min, max float64) *Slider {
+	slider := components.NewSlider(newID("slider"), min, max)
+	slider.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 24})
+	slider.ApplyTheme(ui.theme.Current())
+	ui.theme.OnThemeChanged(slider.ApplyTheme)
+	
+	ui.currentParent.AddChild(slider)
+	
+	return &Slider{
+		slider: slider,
+		ui:     ui,
+	}
+}
+
+// Progress adds a progress bar, empty until given a value
+func (ui *UI) Progress() *Progress {
+	bar := components.NewProgressBar(newID("progress"))
+	bar.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 8})
+	bar.ApplyTheme(ui.theme.Current())
+	ui.theme.OnThemeChanged(bar.ApplyTheme)
+	
+	ui.currentParent.AddChild(bar)
+	
+	return &Progress{
+		bar: bar,
+		ui:  ui,
+	}
+}
+
+// Radio adds a column of options of which one can be selected
+func (ui *UI) Radio(options []string) *Radio {
+	group := components.NewRadioGroup(newID("radio"), options)
+	group.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: group.PreferredHeight()})
+	group.ApplyTheme(ui.theme.Current())
+	ui.theme.OnThemeChanged(group.ApplyTheme)
+	
+	ui.currentParent.AddChild(group)
+	
+	return &Radio{
+		group: group,
+		ui:    ui,
+	}
+}
+
+// Toggle adds an on/off switch with a label
+func (ui *UI) Toggle(label string) *Toggle {
+	toggle := components.NewToggleSwitch(newID("toggle"), label)
+	toggle.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: components.ScaleTextSize(30)})
+	toggle.ApplyTheme(ui.theme.Current())
+	ui.theme.OnThemeChanged(toggle.ApplyTheme)
+	
+	ui.currentParent.AddChild(toggle)
+	
+	return &Toggle{
+		toggle: toggle,
+		ui:     ui,
+	}
+}
+
+// Image adds an image loaded from a PNG, JPEG or GIF file in the
+// background; a placeholder is shown until it is ready
+func (ui *UI) Image(path string) *Image {
+	image := components.NewImage(newID("image"))
+	image.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 200})
+	image.SetSourcePath(path)
+	
+	ui.currentParent.AddChild(image)
+	
+	return &Image{
+		image: image,
+		ui:    ui,
+	}
+}
+
+// Icon adds a named icon, such as "close" or "search", or one added with
+// components.RegisterIcon
+func (ui *UI) Icon(name string) *Icon {
+	icon := components.NewIcon(newID("icon"), name)
+	icon.SetBounds(components.Rect{X: 0, Y: 0, Width: 24, Height: 24})
+	icon.ApplyTheme(ui.theme.Current())
+	ui.theme.OnThemeChanged(icon.ApplyTheme)
+	
+	ui.currentParent.AddChild(icon)
+	
+	return &Icon{
+		icon: icon,
+		ui:   ui,
+	}
+}
+
+// Card adds a raised panel; the builder adds elements to its body
+func (ui *UI) Card(height int, builder func()) *Card {
+	card := components.NewCard(newID("card"))
+	card.ApplyTheme(ui.theme.Current())
+	ui.theme.OnThemeChanged(card.ApplyTheme)
+	card.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: height})
+	
+	ui.currentParent.AddChild(card)
+	
+	wrapper := &Card{
+		card: card,
+		ui:   ui,
+	}
+	wrapper.fill(card.Body(), builder)
+	return wrapper
+}
+
+// Carousel adds a container that pages through slides; use Slide to add them
+func (ui *UI) Carousel(height int) *Carousel {
+	carousel := components.NewCarousel(newID("carousel"))
+	carousel.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: height})
+	
+	ui.currentParent.AddChild(carousel)
+	
+	return &Carousel{
+		carousel: carousel,
+		ui:       ui,
+	}
+}
+
+// Toolbar adds a row of buttons; those that don't fit move into a "more" menu
+func (ui *UI) Toolbar() *Toolbar {
+	toolbar := components.NewToolbar(newID("toolbar"))
+	toolbar.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 32})
+	
+	ui.currentParent.AddChild(toolbar)
+	
+	return &Toolbar{
+		toolbar: toolbar,
+		ui:      ui,
+	}
+}
+
+// Modal creates a closed dialog above the page; the builder adds elements to its content
+func (ui *UI) Modal(title string, builder func()) *Modal {
+	modal := components.NewModal(newID("modal"), title)
+	modal.SetFocusManager(ui.focus)
+	
+	ui.rootContainer.AddChild(modal)
+	
+	// Save the original parent
+	originalParent := ui.currentParent
+	
+	// Add the builder's elements to the dialog's content
+	ui.currentParent = modal.Content()
+	if builder != nil {
+		ui.guard("builder", builder)
+	}
+	
+	// Restore the original parent
+	ui.currentParent = originalParent
+	
+	return &Modal{
+		modal: modal,
+		ui:    ui,
+	}
+}
+
+// FileDialog creates a closed file open or save dialog
+func (ui *UI) FileDialog(mode components.FileDialogMode) *FileDialog {
+	dialog := components.NewFileDialog(newID("filedialog"), mode)
+	dialog.SetFocusManager(ui.focus)
+	
+	ui.rootContainer.AddChild(dialog)
+	
+	return &FileDialog{
+		dialog: dialog,
+		ui:     ui,
+	}
+}
+
+// AttachmentList adds a row of file chips; files are added with its button
+// or by dropping them on it
+func (ui *UI) AttachmentList() *AttachmentList {
+	list := components.NewAttachmentList(newID("attachments"))
+	list.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 80})
+	list.Dialog().SetFocusManager(ui.focus)
+	
+	ui.currentParent.AddChild(list)
+	ui.rootContainer.AddChild(list.Dialog())
+	
+	return &AttachmentList{
+		list: list,
+		ui:   ui,
+	}
+}
+
 // Checkbox adds a checkbox to the UI
 func (ui *UI) Checkbox(label string) *Checkbox {
 	// Create a container for the checkbox and label
-	container := components.NewFlexContainer("checkbox_container_" + randomID())
-	container.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 30})
+	container := components.NewFlexContainer(newID("checkbox_container"))
+	container.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: components.ScaleTextSize(30)})
 	container.SetFlexDirection(components.FlexRow)
 	
 	// Create the checkbox
-	checkbox := components.NewCheckbox("checkbox_" + randomID())
+	checkbox := components.NewCheckbox(newID("checkbox"))
 	checkbox.SetBounds(components.Rect{X: 0, Y: 5, Width: 20, Height: 20})
 	
 	// Create the label
-	textLabel := components.NewLabel("checkbox_label_"+randomID(), label, 16, color.RGBA{0, 0, 0, 255})
-	textLabel.SetBounds(components.Rect{X: 30, Y: 5, Width: ui.width - 50, Height: 20})
+	textLabel := components.NewLabel(newID("checkbox_label"), label, 16, color.RGBA{0, 0, 0, 255})
+	textLabel.SetBounds(components.Rect{X: 30, Y: 5, Width: ui.width - 50, Height: components.ScaleTextSize(20)})
 	
 	// Add to container
 	container.AddChild(checkbox)
@@ -155,7 +919,7 @@ func (ui *UI) Checkbox(label string) *Checkbox {
 
 // Columns creates a set of columns
 func (ui *UI) Columns(count int, builder func([]*Column)) *UI {
-	columnsContainer := components.NewFlexContainer("columns_" + randomID())
+	columnsContainer := components.NewFlexContainer(newID("columns"))
 	columnsContainer.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 100})
 	columnsContainer.SetFlexDirection(components.FlexRow)
 	
@@ -163,7 +927,7 @@ func (ui *UI) Columns(count int, builder func([]*Column)) *UI {
 	columnWidth := ui.width / count
 	
 	for i := 0; i < count; i++ {
-		colContainer := components.NewFlexContainer(fmt.Sprintf("column_%d_%s", i, randomID()))
+		colContainer := components.NewFlexContainer(newID(fmt.Sprintf("column_%d", i)))
 		colContainer.SetBounds(components.Rect{X: i * columnWidth, Y: 0, Width: columnWidth, Height: 100})
 		colContainer.SetFlexDirection(components.FlexColumn)
 		
@@ -182,7 +946,7 @@ func (ui *UI) Columns(count int, builder func([]*Column)) *UI {
 	
 	// Call the builder function with our columns
 	if builder != nil {
-		builder(columns)
+		ui.guard("builder", func() { builder(columns) })
 	}
 	
 	// Restore the original parent
@@ -193,68 +957,28 @@ func (ui *UI) Columns(count int, builder func([]*Column)) *UI {
 
 // Tabs creates a set of tabs
 func (ui *UI) Tabs(names []string, builder func([]*Tab)) *UI {
-	tabsContainer := components.NewFlexContainer("tabs_container_" + randomID())
-	tabsContainer.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 300})
-	tabsContainer.SetFlexDirection(components.FlexColumn)
-	
-	// Create tab headers container
-	tabHeadersContainer := components.NewFlexContainer("tab_headers_" + randomID())
-	tabHeadersContainer.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 40})
-	tabHeadersContainer.SetFlexDirection(components.FlexRow)
-	tabHeadersContainer.SetBackgroundColor(color.RGBA{220, 220, 220, 255})
-	
-	// Create content container
-	contentContainer := components.NewFlexContainer("tab_content_" + randomID())
-	contentContainer.SetBounds(components.Rect{X: 0, Y: 40, Width: ui.width, Height: 260})
-	contentContainer.SetFlexDirection(components.FlexColumn)
-	
-	// Add to tabs container
-	tabsContainer.AddChild(tabHeadersContainer)
-	tabsContainer.AddChild(contentContainer)
-	
-	// Create tabs
-	tabs := make([]*Tab, len(names))
-	tabWidth := ui.width / len(names)
+	tabControl := components.NewTabControl(newID("tabs"))
+	tabControl.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 300})
 	
-	// Create content containers (only one is visible at a time)
+	// Create a tab page for each name
+	tabs := make([]*Tab, len(names))
 	for i, name := range names {
-		// Create tab header
-		tabHeader := components.NewButton("tab_header_"+randomID(), name)
-		tabHeader.SetBounds(components.Rect{X: i * tabWidth, Y: 0, Width: tabWidth, Height: 40})
-		tabHeadersContainer.AddChild(tabHeader)
-		
-		// Create content container for this tab
-		tabContent := components.NewFlexContainer("tab_content_"+randomID())
-		tabContent.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 260})
-		tabContent.SetFlexDirection(components.FlexColumn)
-		tabContent.SetVisible(i == 0) // Only first tab visible by default
-		contentContainer.AddChild(tabContent)
-		
-		// Create tab object
+		page := tabControl.AddTab(name)
 		tabs[i] = &Tab{
-			header:    tabHeader,
-			container: tabContent,
+			page:      page,
+			container: page.Content.(*components.FlexContainer),
 			ui:        ui,
 		}
-		
-		// Set up tab switching
-		index := i // Capture index for closure
-		tabHeader.SetOnClick(func() {
-			// Hide all tab contents
-			for j := 0; j < len(tabs); j++ {
-				tabs[j].container.SetVisible(j == index)
-			}
-		})
 	}
 	
-	ui.currentParent.AddChild(tabsContainer)
+	ui.currentParent.AddChild(tabControl)
 	
 	// Save the original parent
 	originalParent := ui.currentParent
 	
 	// Call the builder function with our tabs
 	if builder != nil {
-		builder(tabs)
+		ui.guard("builder", func() { builder(tabs) })
 	}
 	
 	// Restore the original parent
@@ -263,75 +987,417 @@ func (ui *UI) Tabs(names []string, builder func([]*Tab)) *UI {
 	return ui
 }
 
-// State creates a new reactive state value
-func (ui *UI) State(initialValue interface{}) *State {
-	return &State{
-		value:    initialValue,
-		watchers: make([]func(interface{}), 0),
+// Expander creates a collapsible section; the builder adds elements to its content
+func (ui *UI) Expander(title string, builder func()) *Expander {
+	expander := components.NewExpander(newID("expander"), title)
+	expander.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 0})
+	expander.SetContentHeight(200)
+	
+	ui.currentParent.AddChild(expander)
+	
+	// Save the original parent
+	originalParent := ui.currentParent
+	
+	// Add the builder's elements to the expander's content
+	ui.currentParent = expander.Content()
+	if builder != nil {
+		ui.guard("builder", builder)
+	}
+	
+	// Restore the original parent
+	ui.currentParent = originalParent
+	
+	return &Expander{
+		expander: expander,
+		ui:       ui,
+	}
+}
+
+// ExpandableText adds text that shows maxLines lines until "Show more" is
+// clicked; its height follows the lines shown
+func (ui *UI) ExpandableText(text string, maxLines int) *ExpandableText {
+	expandable := components.NewExpandableText(newID("expandable"), text, maxLines)
+	expandable.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 0})
+	
+	ui.currentParent.AddChild(expandable)
+	
+	return &ExpandableText{
+		text: expandable,
+		ui:   ui,
+	}
+}
+
+// Accordion creates a stack of collapsible sections where only one is open at a time
+func (ui *UI) Accordion(titles []string, builder func([]*Expander)) *UI {
+	accordion := components.NewAccordion(newID("accordion"))
+	accordion.SetBounds(components.Rect{X: 0, Y: 0, Width: ui.width, Height: 300})
+	
+	// Create a section for each title
+	sections := make([]*Expander, len(titles))
+	for i, title := range titles {
+		section := accordion.AddSection(title)
+		section.SetContentHeight(150)
+		sections[i] = &Expander{
+			expander: section,
+			ui:       ui,
+		}
+	}
+	
+	ui.currentParent.AddChild(accordion)
+	
+	// Save the original parent
+	originalParent := ui.currentParent
+	
+	// Call the builder function with our sections
+	if builder != nil {
+		ui.guard("builder", func() { builder(sections) })
 	}
+	
+	// Restore the original parent
+	ui.currentParent = originalParent
+	
+	return ui
+}
+
+// State creates a new reactive state value
+//
+// Deprecated: Use NewState, whose value has a type and needs no type
+// assertions.
+func (ui *UI) State(initialValue interface{}) *AnyState {
+	return NewState[any](initialValue)
 }
 
 // Run starts the UI application
 func (ui *UI) Run(width, height int) {
-	ui.width = width
-	ui.height = height
-	ui.rootContainer.SetBounds(components.Rect{X: 0, Y: 0, Width: width, Height: height})
+	ui.prepare(width, height)
 	
 	// Create the game
 	game := &Game{
-		rootContainer: ui.rootContainer,
-		width:         width,
-		height:        height,
+		width:  width,
+		height: height,
+		ui:     ui,
 	}
 	
+	// Follow OS light/dark changes while running
+	ui.theme.StartWatching(2 * time.Second)
+	defer ui.theme.StopWatching()
+	
 	// Run the game
-	ebiten.SetWindowSize(width, height)
+	if !ui.windowSized {
+		ebiten.SetWindowSize(width, height)
+	}
 	ebiten.SetWindowTitle(ui.title)
 	
+	// Reopen where the user left off
+	ui.applyRestoredSession()
+	
 	if err := ebiten.RunGame(game); err != nil {
 		fmt.Printf("Error running game: %v\n", err)
 	}
+	ui.saveSessionFile()
+}
+
+// prepare sizes the UI and adds the overlays that sit above the page. It is
+// safe to call more than once, and tells resize handlers when the size
+// changes.
+func (ui *UI) prepare(width, height int) {
+	resized := width != ui.width || height != ui.height
+	ui.width = width
+	ui.height = height
+	ui.rootContainer.SetBounds(components.Rect{X: 0, Y: 0, Width: width, Height: height})
+	ui.toasts.SetBounds(components.Rect{X: 0, Y: 0, Width: width, Height: height})
+	ui.popups.SetBounds(components.Rect{X: 0, Y: 0, Width: width, Height: height})
+	if ui.dev != nil {
+		ui.dev.SetBounds(components.Rect{X: 0, Y: 0, Width: width, Height: height})
+	}
+	if ui.menuBar != nil {
+		ui.menuBar.SetBounds(components.Rect{X: 0, Y: 0, Width: width, Height: menuBarHeight})
+		ui.menuBar.SetScreenBounds(components.Rect{X: 0, Y: 0, Width: width, Height: height})
+	}
+	if resized {
+		for _, handler := range ui.onResize {
+			handler(width, height)
+		}
+	}
+	if ui.prepared {
+		return
+	}
+	ui.prepared = true
+	
+	// Lint outlines, the menu bar, toasts and drop-down lists float above
+	// the page, then context menus, and the dev overlay above everything
+	if ui.lint != nil {
+		ui.rootContainer.AddChild(ui.lint)
+	}
+	if ui.menuBar != nil {
+		ui.rootContainer.AddChild(ui.menuBar)
+	}
+	ui.rootContainer.AddChild(ui.toasts)
+	ui.rootContainer.AddChild(ui.popups)
+	ui.rootContainer.AddChild(ui.contextMenus.Menu())
+	if ui.dev != nil {
+		ui.rootContainer.AddChild(ui.dev)
+	}
+}
+
+// offscreen is a pointer position that is over nothing, given to a UI when
+// a UI above it has taken the pointer so hover states clear
+const offscreen = -1 << 20
+
+// frameInput is the input for one frame, read once and passed to each UI
+// in the window from the top down. Each UI removes what it uses.
+type frameInput struct {
+	x, y           int
+	pressed        bool // Left button held
+	rightPressed   bool
+	middlePressed  bool
+	rightClick     bool
+	wheelX, wheelY float64
+	dropped        fs.FS
+	keys           []components.InputEvent
+	pointerTaken   bool // A UI above has used the pointer
+	cursor         components.CursorShape // Asked for by the topmost UI under the pointer
+	ime            components.IMEClient   // Focused text field of the topmost UI that has one
+	imeScale       float64
+}
+
+// readFrameInput reads this frame's mouse, wheel, file drop and key input
+func readFrameInput() frameInput {
+	x, y := ebiten.CursorPosition()
+	wheelX, wheelY := ebiten.Wheel()
+	return frameInput{
+		x:          x,
+		y:          y,
+		pressed:       ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft),
+		rightPressed:  ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight),
+		middlePressed: ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle),
+		rightClick:    inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight),
+		wheelX:        wheelX,
+		wheelY:        wheelY,
+		dropped:       ebiten.DroppedFiles(),
+		keys:          components.PollKeyEvents(),
+	}
+}
+
+// update handles a frame of input, with the pointer scaled down by the
+// UI's scale. The pointer is marked taken if an element used it, and keys
+// an element or shortcut used are removed.
+func (ui *UI) update(in *frameInput, scale float64) {
+	// Apply any OS theme change found by the watcher
+	ui.theme.Poll()
+	
+	root := ui.rootContainer
+	x, y := int(float64(in.x)/scale), int(float64(in.y)/scale)
+	if in.pointerTaken {
+		x, y = offscreen, offscreen
+	}
+	
+	// Mouse buttons are dispatched when pressed and when released, not on
+	// every frame they're held or up; the root's own background doesn't
+	// count as taking the pointer, so UIs below show through empty areas
+	taken := false
+	if in.pressed != ui.wasPressed {
+		// Record the change first, so a handler that panics doesn't get
+		// the same press again on the next frame
+		ui.wasPressed = in.pressed
+		
+		button := components.NewMouseEvent(components.InputTypeMouseUp, x, y)
+		if in.pressed {
+			button.Type = components.InputTypeMouseDown
+		}
+		taken = components.DispatchEvent(root, button)
+		if in.pressed {
+			ui.focus.Sync()
+			if taken {
+				ui.record(components.TraceClick, button.HandledBy)
+			}
+		}
+	}
+	
+	// Moves go out every frame, so hover states follow elements that move
+	// under a still pointer and drags carry on while the button is held
+	if components.DispatchEvent(root, components.NewMouseEvent(components.InputTypeMouseMove, x, y)) {
+		taken = true
+	}
+	
+	// Clicks, double clicks and context clicks complete on release
+	held := [...]bool{in.pressed, in.rightPressed, in.middlePressed}
+	for button, down := range held {
+		for _, click := range ui.clicks.Update(root, components.MouseButton(button), down, x, y) {
+			if components.DispatchEvent(root, click) {
+				taken = true
+			}
+		}
+	}
+	
+	if !in.pointerTaken {
+		// Right click opens the context menu of the element under the pointer
+		if in.rightClick && ui.contextMenus.OpenAt(x, y) {
+			taken = true
+		}
+		
+		// Mouse wheel
+		if (in.wheelX != 0 || in.wheelY != 0) && components.DispatchScrollEvent(root, x, y, in.wheelX, in.wheelY) {
+			ui.record(components.TraceScroll, components.ElementAt(root, x, y))
+			taken = true
+		}
+		
+		// Files dropped on the window from the OS
+		if in.dropped != nil && components.DispatchFileDrop(root, x, y, in.dropped) {
+			ui.record(components.TraceDrop, components.ElementAt(root, x, y))
+			in.dropped = nil
+			taken = true
+		}
+	}
+	
+	// The element under the pointer picks the cursor, unless a UI above has it
+	if !in.pointerTaken {
+		in.cursor = components.CursorAt(root, x, y)
+	}
+	in.pointerTaken = in.pointerTaken || taken
+	
+	// Keyboard events
+	unused := in.keys[:0]
+	for _, event := range in.keys {
+		// Shortcuts run only when no element used the key
+		if components.DispatchKeyEvent(root, event) {
+			if event.Type == components.InputTypeKeyDown {
+				ui.record(components.TraceType, ui.focus.Focused())
+			}
+			continue
+		}
+		if focused := ui.focus.Focused(); focused != nil && ui.contextMenus.HandleKeyDown(event, focused) {
+			continue
+		}
+		if ui.commands.Shortcuts().HandleKeyDown(event) {
+			ui.trace.RecordShortcut(event)
+			if ui.dev != nil {
+				shortcut := components.Accelerator{Key: event.Key, Ctrl: event.CtrlDown, Shift: event.ShiftDown, Alt: event.AltDown}
+				ui.dev.Record(components.TraceShortcut+" "+shortcut.String(), nil)
+			}
+			continue
+		}
+		unused = append(unused, event)
+	}
+	in.keys = unused
+	
+	// Input method text goes to the focused text field
+	if in.ime == nil {
+		if client, ok := ui.focus.Focused().(components.IMEClient); ok {
+			in.ime, in.imeScale = client, scale
+		}
+	}
+	
+	// App logic runs once the frame's input is handled
+	delta := elapsed(&ui.lastUpdate)
+	for _, handler := range ui.onUpdate {
+		handler(delta)
+	}
+}
+
+// elapsed returns the time since the last call with the same clock, or
+// zero on the first call
+func elapsed(last *time.Time) time.Duration {
+	now := components.Now()
+	var delta time.Duration
+	if !last.IsZero() {
+		delta = now.Sub(*last)
+	}
+	*last = now
+	return delta
+}
+
+// draw draws the UI onto a target image
+func (ui *UI) draw(screen *ebiten.Image) {
+	// Per-frame handlers, e.g. for animations, see the frame about to be drawn
+	delta := elapsed(&ui.lastFrame)
+	for _, handler := range ui.onFrame {
+		handler(delta)
+	}
+	
+	// Draw into the filter buffer when a debug filter is active
+	target := screen
+	if ui.colorFilter != nil {
+		target = ui.colorFilter.Begin(screen)
+	}
+	
+	// Use the theme's font fallback chain
+	if fonts := ui.theme.Current().Fonts; fonts != nil && fonts != components.CurrentFontChain() {
+		components.SetFontChain(fonts)
+	}
+	
+	// Create a draw surface
+	surface := components.NewEbitenDrawSurface(target)
+	
+	// Draw the UI, timed for the dev overlay
+	if ui.dev != nil {
+		ui.dev.BeginDraw()
+	}
+	ui.rootContainer.Draw(surface)
+	if ui.dev != nil {
+		ui.dev.EndDraw()
+	}
+	
+	// Composite the filtered frame
+	if ui.colorFilter != nil {
+		ui.colorFilter.End(screen)
+	}
 }
 
 // Game implements the ebiten.Game interface
 type Game struct {
-	rootContainer *components.FlexContainer
-	width         int
-	height        int
+	width  int
+	height int
+	ui     *UI
 }
 
 // Update implements ebiten.Game's Update method
 func (g *Game) Update() error {
-	// Handle input in a simpler way
-	x, y := ebiten.CursorPosition()
+	// A panic in a handler ends the frame's work, not the app
+	g.ui.guard("update", g.update)
+	return nil
+}
+
+// update handles a frame of input and runs the app's handlers
+func (g *Game) update() {
+	// State changes made by this frame's handlers notify once, at the end
+	components.BeginBatch()
+	defer components.EndBatch()
 	
-	// Mouse events
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		g.rootContainer.HandleMouseDown(x, y)
-	} else {
-		g.rootContainer.HandleMouseUp(x, y)
-	}
+	// Run debounced and throttled handlers that are due
+	components.RunFrameCallbacks()
 	
-	g.rootContainer.HandleMouseMove(x, y)
+	// Remember the window geometry for the session
+	g.ui.trackWindow()
 	
-	return nil
+	// Lay the page out again when the window has been resized
+	if g.width != g.ui.width || g.height != g.ui.height {
+		g.ui.prepare(g.width, g.height)
+	}
+	
+	in := readFrameInput()
+	g.ui.update(&in, 1)
+	components.SetSystemCursor(in.cursor)
+	components.SetIMEClient(in.ime, in.imeScale)
 }
 
 // Draw implements ebiten.Game's Draw method
 func (g *Game) Draw(screen *ebiten.Image) {
-	// Create a draw surface
-	surface := components.NewEbitenDrawSurface(screen)
-	
-	// Draw the UI
-	g.rootContainer.Draw(surface)
+	g.ui.guard("draw", func() {
+		g.ui.draw(screen)
+	})
 }
 
-// Layout implements ebiten.Game's Layout method
+// Layout implements ebiten.Game's Layout method, following the size of the
+// window so the page can be laid out again on the next Update
 func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
+	if outsideWidth > 0 && outsideHeight > 0 {
+		g.width, g.height = outsideWidth, outsideHeight
+	}
 	return g.width, g.height
 }
 
-// Helper function to generate random IDs
-func randomID() string {
-	return fmt.Sprintf("%d", ebiten.TPS())
+// newID returns a unique ID for an element of a kind, e.g. "button_12"
+func newID(kind string) string {
+	return components.NewID(kind)
 } 
\ No newline at end of file
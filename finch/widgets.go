@@ -2,7 +2,10 @@ package finch
 
 import (
 	"fmt"
+	"image"
 	"image/color"
+	"strings"
+	"time"
 
 	"github.com/aggnr/finch/components"
 )
@@ -40,232 +43,1729 @@ func (t *Text) SetText(text string) *Text {
 	return t
 }
 
+// Value shows a value formatted for the locale, e.g. with
+// components.PercentFormat(1); a nil format picks one by the value's type
+func (t *Text) Value(value interface{}, format components.ValueFormat) *Text {
+	t.label.SetValue(value, format)
+	return t
+}
+
+// Number shows a number with the locale's digit grouping and decimal separator
+func (t *Text) Number(value float64, decimals int) *Text {
+	return t.Value(value, components.NumberFormat(decimals))
+}
+
+// Currency shows an amount of money in a currency such as "EUR", or in the
+// locale's own currency when code is empty
+func (t *Text) Currency(amount float64, code string) *Text {
+	return t.Value(amount, components.CurrencyFormat(code))
+}
+
+// Date shows a date the way the locale writes it
+func (t *Text) Date(date time.Time) *Text {
+	return t.Value(date, components.DateFormat())
+}
+
 // Container represents a container element for layout
 type Container struct {
 	container *components.FlexContainer
 	ui        *UI
+	scroll    *components.ScrollContainer // Viewport the container scrolls in, once Scrollable
 }
 
 // Background sets the background color
 func (c *Container) Background(hexColor string) *Container {
 	// Parse hex color (simplified)
-	var r, g, b uint8 = 255, 255, 255
+	var r, g, b uint8 = 255, 255, 255
+	fmt.Sscanf(hexColor, "#%02x%02x%02x", &r, &g, &b)
+	c.container.SetBackgroundColor(color.RGBA{r, g, b, 255})
+	if c.scroll != nil {
+		c.scroll.SetBackgroundColor(color.RGBA{r, g, b, 255})
+	}
+	return c
+}
+
+// Padding sets the padding on every side
+func (c *Container) Padding(padding int) *Container {
+	return c.padding(func(s *components.Spacing) {
+		*s = components.Spacing{padding, padding, padding, padding}
+	})
+}
+
+// PaddingX sets the left and right padding
+func (c *Container) PaddingX(padding int) *Container {
+	return c.padding(func(s *components.Spacing) { s.Left, s.Right = padding, padding })
+}
+
+// PaddingY sets the top and bottom padding
+func (c *Container) PaddingY(padding int) *Container {
+	return c.padding(func(s *components.Spacing) { s.Top, s.Bottom = padding, padding })
+}
+
+// PaddingTop sets the top padding
+func (c *Container) PaddingTop(padding int) *Container {
+	return c.padding(func(s *components.Spacing) { s.Top = padding })
+}
+
+// PaddingRight sets the right padding
+func (c *Container) PaddingRight(padding int) *Container {
+	return c.padding(func(s *components.Spacing) { s.Right = padding })
+}
+
+// PaddingBottom sets the bottom padding
+func (c *Container) PaddingBottom(padding int) *Container {
+	return c.padding(func(s *components.Spacing) { s.Bottom = padding })
+}
+
+// PaddingLeft sets the left padding
+func (c *Container) PaddingLeft(padding int) *Container {
+	return c.padding(func(s *components.Spacing) { s.Left = padding })
+}
+
+// padding changes some sides of the padding, keeping the others and the
+// margin and border
+func (c *Container) padding(change func(*components.Spacing)) *Container {
+	padding := c.container.GetBoxModel().Padding
+	change(&padding)
+	c.container.SetPadding(padding)
+	return c
+}
+
+// Margin sets the margin on every side
+func (c *Container) Margin(margin int) *Container {
+	return c.margin(func(s *components.Spacing) {
+		*s = components.Spacing{margin, margin, margin, margin}
+	})
+}
+
+// MarginX sets the left and right margin
+func (c *Container) MarginX(margin int) *Container {
+	return c.margin(func(s *components.Spacing) { s.Left, s.Right = margin, margin })
+}
+
+// MarginY sets the top and bottom margin
+func (c *Container) MarginY(margin int) *Container {
+	return c.margin(func(s *components.Spacing) { s.Top, s.Bottom = margin, margin })
+}
+
+// MarginTop sets the top margin
+func (c *Container) MarginTop(margin int) *Container {
+	return c.margin(func(s *components.Spacing) { s.Top = margin })
+}
+
+// MarginRight sets the right margin
+func (c *Container) MarginRight(margin int) *Container {
+	return c.margin(func(s *components.Spacing) { s.Right = margin })
+}
+
+// MarginBottom sets the bottom margin
+func (c *Container) MarginBottom(margin int) *Container {
+	return c.margin(func(s *components.Spacing) { s.Bottom = margin })
+}
+
+// MarginLeft sets the left margin
+func (c *Container) MarginLeft(margin int) *Container {
+	return c.margin(func(s *components.Spacing) { s.Left = margin })
+}
+
+// margin changes some sides of the margin, keeping the others and the
+// padding and border
+func (c *Container) margin(change func(*components.Spacing)) *Container {
+	margin := c.frame().GetBoxModel().Margin
+	change(&margin)
+	c.frame().SetMargin(margin)
+	return c
+}
+
+// Border draws a solid border of a width and color inside the container's
+// edges
+func (c *Container) Border(width int, hexColor string) *Container {
+	// Parse hex color (simplified)
+	var r, g, b uint8 = 0, 0, 0
+	fmt.Sscanf(hexColor, "#%02x%02x%02x", &r, &g, &b)
+	c.container.SetBorder(components.Border{
+		Width: components.Spacing{width, width, width, width},
+		Color: color.RGBA{r, g, b, 255},
+		Style: components.BorderSolid,
+	})
+	return c
+}
+
+// Width sets the width
+func (c *Container) Width(width interface{}) *Container {
+	switch w := width.(type) {
+	case int:
+		c.setWidth(w)
+	case string:
+		// For percentage strings like "80%"
+		var percentage int
+		fmt.Sscanf(w, "%d%%", &percentage)
+		if percentage > 0 {
+			c.setWidth(c.ui.width * percentage / 100)
+		}
+	}
+	return c
+}
+
+// setWidth sets the width of the container and of its scroll viewport
+func (c *Container) setWidth(width int) {
+	bounds := c.container.Bounds()
+	bounds.Width = width
+	c.container.SetBounds(bounds)
+	if c.scroll != nil {
+		bounds = c.scroll.Bounds()
+		bounds.Width = width
+		c.scroll.SetBounds(bounds)
+	}
+}
+
+// Height sets the height, or once Scrollable the height of the viewport
+func (c *Container) Height(height int) *Container {
+	bounds := c.frame().Bounds()
+	bounds.Height = height
+	c.frame().SetBounds(bounds)
+	return c
+}
+
+// Cursor sets the cursor shown while the pointer is over the container,
+// e.g. components.CursorResizeEW for a split handle
+func (c *Container) Cursor(shape components.CursorShape) *Container {
+	c.container.SetCursorShape(shape)
+	return c
+}
+
+// Grow makes the container take a share of the space its siblings leave,
+// along its parent's direction: its height in a column, its width in a row.
+// Growing siblings split the space in proportion to their factors; 0 stops
+// the container growing.
+func (c *Container) Grow(factor int) *Container {
+	c.frame().SetFlexGrow(factor)
+	return c
+}
+
+// Layout sets the layout direction and runs the builder function
+func (c *Container) Layout(direction string, builder func(*Container)) *Container {
+	if direction == "row" {
+		c.container.SetFlexDirection(components.FlexRow)
+	} else {
+		c.container.SetFlexDirection(components.FlexColumn)
+	}
+	
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Call the builder function
+	if builder != nil {
+		c.ui.guard("builder", func() { builder(c) })
+	}
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return c
+}
+
+// Text adds a text element to the container
+func (c *Container) Text(text string) *Text {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the text
+	textElement := c.ui.Text(text)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return textElement
+}
+
+// Button adds a button to the container
+func (c *Container) Button(label string) *Button {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the button
+	button := c.ui.Button(label)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return button
+}
+
+// TextInput adds a text input to the container
+func (c *Container) TextInput(placeholder string) *TextInput {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the text input
+	input := c.ui.TextInput(placeholder)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return input
+}
+
+// MaskedInput adds a pattern-formatted field to the container
+func (c *Container) MaskedInput(mask string) *MaskedInput {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the field
+	input := c.ui.MaskedInput(mask)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return input
+}
+
+// Checkbox adds a checkbox to the container
+func (c *Container) Checkbox(label string) *Checkbox {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the checkbox
+	checkbox := c.ui.Checkbox(label)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return checkbox
+}
+
+// Slider adds a slider to the container
+func (c *Container) Slider(min, max float64) *Slider {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the slider
+	slider := c.ui.Slider(min, max)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return slider
+}
+
+// Progress adds a progress bar to the container
+func (c *Container) Progress() *Progress {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the progress bar
+	progress := c.ui.Progress()
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return progress
+}
+
+// Radio adds a group of radio options to the container
+func (c *Container) Radio(options []string) *Radio {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the radio group
+	radio := c.ui.Radio(options)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return radio
+}
+
+// Toggle adds an on/off switch to the container
+func (c *Container) Toggle(label string) *Toggle {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the switch
+	toggle := c.ui.Toggle(label)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return toggle
+}
+
+// Image adds an image loaded from a file to the container
+func (c *Container) Image(path string) *Image {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the image
+	image := c.ui.Image(path)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return image
+}
+
+// Icon adds a named icon to the container
+func (c *Container) Icon(name string) *Icon {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the icon
+	icon := c.ui.Icon(name)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return icon
+}
+
+// Select adds a dropdown list of options to the container
+func (c *Container) Select(options []string) *Select {
+	// Save the current parent
+	originalParent := c.ui.currentParent
+	
+	// Set this container as the current parent
+	c.ui.currentParent = c.container
+	
+	// Add the select
+	sel := c.ui.Select(options)
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	
+	return sel
+}
+
+// RemoveAllChildren removes all child elements from this container
+func (c *Container) RemoveAllChildren() {
+	c.container.RemoveAllChildren()
+}
+
+// Button represents a button element
+type Button struct {
+	button *components.Button
+	ui     *UI
+}
+
+// OnClick sets the click handler
+func (b *Button) OnClick(handler func()) *Button {
+	handler = guarded("Button.OnClick", handler)
+	b.button.SetOnClick(handler)
+	return b
+}
+
+// Width sets the button width
+func (b *Button) Width(width int) *Button {
+	bounds := b.button.Bounds()
+	bounds.Width = width
+	b.button.SetBounds(bounds)
+	return b
+}
+
+// Cursor sets the cursor shown while the pointer is over the button
+func (b *Button) Cursor(shape components.CursorShape) *Button {
+	b.button.SetCursorShape(shape)
+	return b
+}
+
+// TextInput represents a text input field
+type TextInput struct {
+	input *components.TextArea
+	ui    *UI
+}
+
+// Value gets the current text value
+func (t *TextInput) Value() string {
+	return t.input.GetText()
+}
+
+// SetValue sets the text value
+func (t *TextInput) SetValue(value string) *TextInput {
+	t.input.SetText(value)
+	return t
+}
+
+// Clear clears the input
+func (t *TextInput) Clear() *TextInput {
+	t.input.SetText("")
+	return t
+}
+
+// Wrap sets whether long lines wrap to fit the width
+func (t *TextInput) Wrap(wrap bool) *TextInput {
+	t.input.SetWrap(wrap)
+	return t
+}
+
+// SingleLine keeps the text to one line, leaving Enter to shortcuts such as
+// a button's
+func (t *TextInput) SingleLine(singleLine bool) *TextInput {
+	t.input.SetSingleLine(singleLine)
+	return t
+}
+
+// OnChange sets the change handler
+func (t *TextInput) OnChange(handler func(string)) *TextInput {
+	handler = guarded1("TextInput.OnChange", handler)
+	t.input.SetOnChange(handler)
+	return t
+}
+
+// Filter restricts what can be entered, e.g.
+// Filter(components.DigitsOnly(), components.MaxLength(4)). Filters run
+// before OnChange, so the handler only sees accepted text.
+func (t *TextInput) Filter(filters ...components.InputFilter) *TextInput {
+	t.input.SetInputFilters(filters...)
+	return t
+}
+
+// OnPasteImage sets the handler called with an image pasted from the
+// clipboard while the input is focused, e.g. to attach it to a message
+func (t *TextInput) OnPasteImage(handler func(image.Image)) *TextInput {
+	handler = guarded1("TextInput.OnPasteImage", handler)
+	t.input.SetOnPasteImage(handler)
+	return t
+}
+
+// Focus gives the input keyboard focus
+func (t *TextInput) Focus() *TextInput {
+	t.ui.focus.SetFocus(t.input)
+	return t
+}
+
+// MaskedInput represents a field formatted by a pattern
+type MaskedInput struct {
+	input *components.MaskedInput
+	ui    *UI
+}
+
+// Value gets the typed characters without the pattern's literals
+func (m *MaskedInput) Value() string {
+	return m.input.RawValue()
+}
+
+// Formatted gets the value with the pattern's literals
+func (m *MaskedInput) Formatted() string {
+	return m.input.FormattedValue()
+}
+
+// SetValue sets the value, raw or formatted
+func (m *MaskedInput) SetValue(value string) *MaskedInput {
+	m.input.SetValue(value)
+	return m
+}
+
+// Complete returns whether every part of the pattern is filled in
+func (m *MaskedInput) Complete() bool {
+	return m.input.IsComplete()
+}
+
+// OnChange sets the handler called with the raw and formatted value
+func (m *MaskedInput) OnChange(handler func(raw, formatted string)) *MaskedInput {
+	handler = guarded2("MaskedInput.OnChange", handler)
+	m.input.SetOnChange(handler)
+	return m
+}
+
+// OnComplete sets the handler called when the pattern is filled in
+func (m *MaskedInput) OnComplete(handler func(formatted string)) *MaskedInput {
+	handler = guarded1("MaskedInput.OnComplete", handler)
+	m.input.SetOnComplete(handler)
+	return m
+}
+
+// Focus gives the field keyboard focus
+func (m *MaskedInput) Focus() *MaskedInput {
+	m.ui.focus.SetFocus(m.input)
+	return m
+}
+
+// Select represents a dropdown list of options
+type Select struct {
+	sel *components.Select
+	ui  *UI
+}
+
+// Value gets the selected option, or "" if none is selected
+func (s *Select) Value() string {
+	return s.sel.GetSelectedOption()
+}
+
+// Index gets the index of the selected option, or -1
+func (s *Select) Index() int {
+	return s.sel.GetSelectedIndex()
+}
+
+// Selected selects the option with the given text
+func (s *Select) Selected(option string) *Select {
+	for i, o := range s.sel.GetOptions() {
+		if o == option {
+			s.sel.SetSelectedIndex(i)
+			break
+		}
+	}
+	return s
+}
+
+// SelectedIndex selects the option at an index, or none for -1
+func (s *Select) SelectedIndex(index int) *Select {
+	s.sel.SetSelectedIndex(index)
+	return s
+}
+
+// Options replaces the options, keeping the selection if it still exists
+func (s *Select) Options(options []string) *Select {
+	s.sel.SetOptions(options)
+	return s
+}
+
+// MaxVisible sets how many options the open list shows before it scrolls
+func (s *Select) MaxVisible(count int) *Select {
+	s.sel.SetMaxVisibleOptions(count)
+	return s
+}
+
+// Width sets the width of the select box
+func (s *Select) Width(width int) *Select {
+	bounds := s.sel.Bounds()
+	bounds.Width = width
+	s.sel.SetBounds(bounds)
+	return s
+}
+
+// OnChange sets the handler called with the index and text of a newly
+// selected option; the index is -1 when the selection is cleared
+func (s *Select) OnChange(handler func(index int, value string)) *Select {
+	handler = guarded2("Select.OnChange", handler)
+	s.sel.SetOnChange(func(index int) {
+		handler(index, s.sel.GetSelectedOption())
+	})
+	return s
+}
+
+// BindValue binds a string pointer to the selected option
+func (s *Select) BindValue(value *string) *Select {
+	// Set initial value
+	s.Selected(*value)
+	
+	// Set up change handler
+	return s.OnChange(func(index int, option string) {
+		*value = option
+	})
+}
+
+// ComboBox represents an editable text field with suggestions
+type ComboBox struct {
+	combo *components.ComboBox
+	ui    *UI
+}
+
+// Value gets the current text
+func (c *ComboBox) Value() string {
+	return c.combo.GetText()
+}
+
+// SetValue sets the text
+func (c *ComboBox) SetValue(value string) *ComboBox {
+	c.combo.SetText(value)
+	return c
+}
+
+// Items replaces the suggestions
+func (c *ComboBox) Items(items []string) *ComboBox {
+	c.combo.SetItems(items)
+	return c
+}
+
+// Filter sets how suggestions are matched against the typed text
+func (c *ComboBox) Filter(filter func(item, text string) bool) *ComboBox {
+	c.combo.SetFilter(filter)
+	return c
+}
+
+// OnSelect sets the handler for when a suggestion is chosen
+func (c *ComboBox) OnSelect(handler func(string)) *ComboBox {
+	handler = guarded1("ComboBox.OnSelect", handler)
+	c.combo.SetOnSelect(func(index int, item string) {
+		handler(item)
+	})
+	return c
+}
+
+// OnChange sets the handler for when the text changes
+func (c *ComboBox) OnChange(handler func(string)) *ComboBox {
+	handler = guarded1("ComboBox.OnChange", handler)
+	c.combo.SetOnChange(handler)
+	return c
+}
+
+// InputFilter restricts what can be typed, applied before OnChange
+func (c *ComboBox) InputFilter(filters ...components.InputFilter) *ComboBox {
+	c.combo.SetInputFilters(filters...)
+	return c
+}
+
+// Focus gives the combo box keyboard focus
+func (c *ComboBox) Focus() *ComboBox {
+	c.ui.focus.SetFocus(c.combo)
+	return c
+}
+
+// DatePicker represents a date field with a calendar popup
+type DatePicker struct {
+	picker *components.DatePicker
+	ui     *UI
+}
+
+// Value gets the selected date, or the zero time if none is selected
+func (d *DatePicker) Value() time.Time {
+	return d.picker.Value()
+}
+
+// SetValue sets the selected date
+func (d *DatePicker) SetValue(date time.Time) *DatePicker {
+	d.picker.SetValue(date)
+	return d
+}
+
+// Min sets the earliest selectable date
+func (d *DatePicker) Min(date time.Time) *DatePicker {
+	d.picker.SetMinDate(date)
+	return d
+}
+
+// Max sets the latest selectable date
+func (d *DatePicker) Max(date time.Time) *DatePicker {
+	d.picker.SetMaxDate(date)
+	return d
+}
+
+// Locale sets the first day of the week from a locale such as "en-US"
+func (d *DatePicker) Locale(locale string) *DatePicker {
+	d.picker.SetLocale(locale)
+	return d
+}
+
+// Format sets the time layout used to display the date
+func (d *DatePicker) Format(layout string) *DatePicker {
+	d.picker.SetFormat(layout)
+	return d
+}
+
+// OnChange sets the handler for when the selected date changes
+func (d *DatePicker) OnChange(handler func(time.Time)) *DatePicker {
+	handler = guarded1("DatePicker.OnChange", handler)
+	d.picker.SetOnChange(handler)
+	return d
+}
+
+// TimePicker represents a time of day field
+type TimePicker struct {
+	picker *components.TimePicker
+	ui     *UI
+}
+
+// Value gets the time of day as a duration since midnight
+func (t *TimePicker) Value() time.Duration {
+	return t.picker.Value()
+}
+
+// Time gets the time of day on the given date
+func (t *TimePicker) Time(date time.Time) time.Time {
+	return t.picker.Time(date)
+}
+
+// SetValue sets the time of day as a duration since midnight
+func (t *TimePicker) SetValue(d time.Duration) *TimePicker {
+	t.picker.SetValue(d)
+	return t
+}
+
+// TwelveHour switches to 12-hour display with AM/PM
+func (t *TimePicker) TwelveHour() *TimePicker {
+	t.picker.SetUse24Hour(false)
+	return t
+}
+
+// Seconds shows a seconds segment
+func (t *TimePicker) Seconds() *TimePicker {
+	t.picker.SetShowSeconds(true)
+	return t
+}
+
+// MinuteStep sets how many minutes one step changes
+func (t *TimePicker) MinuteStep(step int) *TimePicker {
+	t.picker.SetMinuteStep(step)
+	return t
+}
+
+// Range limits the selectable times of day
+func (t *TimePicker) Range(min, max time.Duration) *TimePicker {
+	t.picker.SetMinTime(min)
+	t.picker.SetMaxTime(max)
+	return t
+}
+
+// OnChange sets the handler for when the time changes
+func (t *TimePicker) OnChange(handler func(time.Duration)) *TimePicker {
+	handler = guarded1("TimePicker.OnChange", handler)
+	t.picker.SetOnChange(handler)
+	return t
+}
+
+// DrawingLayer represents a freehand drawing area
+type DrawingLayer struct {
+	layer *components.DrawingLayer
+	ui    *UI
+}
+
+// Brush sets the stroke color and the widths at no and full pen pressure
+func (d *DrawingLayer) Brush(hexColor string, minWidth, maxWidth float64) *DrawingLayer {
+	// Parse hex color (simplified)
+	var r, g, b uint8 = 0, 0, 0
+	fmt.Sscanf(hexColor, "#%02x%02x%02x", &r, &g, &b)
+	brush := d.layer.Brush()
+	brush.Color = color.RGBA{r, g, b, 255}
+	brush.MinWidth = minWidth
+	brush.MaxWidth = maxWidth
+	d.layer.SetBrush(brush)
+	return d
+}
+
+// Clear removes all strokes
+func (d *DrawingLayer) Clear() *DrawingLayer {
+	d.layer.Clear()
+	return d
+}
+
+// Undo removes the most recent stroke
+func (d *DrawingLayer) Undo() *DrawingLayer {
+	d.layer.Undo()
+	return d
+}
+
+// OnStroke sets the handler called when a stroke is finished
+func (d *DrawingLayer) OnStroke(handler func(*components.Stroke)) *DrawingLayer {
+	handler = guarded1("DrawingLayer.OnStroke", handler)
+	d.layer.SetOnStroke(handler)
+	return d
+}
+
+// OnPasteImage lets images be pasted onto the layer from the clipboard; the
+// handler is called with each pasted image
+func (d *DrawingLayer) OnPasteImage(handler func(image.Image)) *DrawingLayer {
+	handler = guarded1("DrawingLayer.OnPasteImage", handler)
+	d.layer.SetOnPasteImage(handler)
+	return d
+}
+
+// Canvas represents an area drawn by a callback each frame
+type Canvas struct {
+	canvas *components.Canvas
+	ui     *UI
+}
+
+// Background sets a color filled behind the drawing
+func (c *Canvas) Background(hexColor string) *Canvas {
+	// Parse hex color (simplified)
+	var r, g, b uint8 = 0, 0, 0
+	fmt.Sscanf(hexColor, "#%02x%02x%02x", &r, &g, &b)
+	c.canvas.SetBackgroundColor(color.RGBA{r, g, b, 255})
+	return c
+}
+
+// OnMouseDown sets the handler for presses, in canvas coordinates
+func (c *Canvas) OnMouseDown(handler func(x, y int)) *Canvas {
+	handler = guarded2("Canvas.OnMouseDown", handler)
+	c.canvas.SetOnMouseDown(handler)
+	return c
+}
+
+// OnMouseUp sets the handler for releases, in canvas coordinates
+func (c *Canvas) OnMouseUp(handler func(x, y int)) *Canvas {
+	handler = guarded2("Canvas.OnMouseUp", handler)
+	c.canvas.SetOnMouseUp(handler)
+	return c
+}
+
+// OnMouseMove sets the handler for moves and drags, in canvas coordinates
+func (c *Canvas) OnMouseMove(handler func(x, y int)) *Canvas {
+	handler = guarded2("Canvas.OnMouseMove", handler)
+	c.canvas.SetOnMouseMove(handler)
+	return c
+}
+
+// OnScroll sets the handler for the mouse wheel, in canvas coordinates
+func (c *Canvas) OnScroll(handler func(x, y int, deltaX, deltaY float64)) *Canvas {
+	if handler != nil {
+		scrolled := handler
+		handler = func(x, y int, deltaX, deltaY float64) {
+			guardCallback("Canvas.OnScroll", func() { scrolled(x, y, deltaX, deltaY) })
+		}
+	}
+	c.canvas.SetOnScroll(handler)
+	return c
+}
+
+// CodeView represents read-only source code with syntax highlighting
+type CodeView struct {
+	codeView *components.CodeView
+	ui       *UI
+}
+
+// Code replaces the code shown
+func (v *CodeView) Code(code string) *CodeView {
+	v.codeView.SetCode(code)
+	return v
+}
+
+// Language highlights the code as a language or file name, such as "go"
+// or "data.json"
+func (v *CodeView) Language(language string) *CodeView {
+	v.codeView.SetLanguage(language)
+	return v
+}
+
+// Highlighter sets a custom highlighter
+func (v *CodeView) Highlighter(highlighter components.Highlighter) *CodeView {
+	v.codeView.SetHighlighter(highlighter)
+	return v
+}
+
+// LineNumbers shows or hides the line numbers
+func (v *CodeView) LineNumbers(show bool) *CodeView {
+	v.codeView.SetShowLineNumbers(show)
+	return v
+}
+
+// TabWidth sets how many columns a tab advances to
+func (v *CodeView) TabWidth(width int) *CodeView {
+	v.codeView.SetTabWidth(width)
+	return v
+}
+
+// HighlightLine marks a line, counted from 1, and scrolls it into view
+func (v *CodeView) HighlightLine(line int) *CodeView {
+	v.codeView.SetHighlightedLine(line)
+	v.codeView.ScrollToLine(line)
+	return v
+}
+
+// RichText represents a block of wrapped paragraphs
+type RichText struct {
+	richText *components.RichText
+	style    components.ParagraphStyle // Used by paragraphs added after it is set
+	ui       *UI
+}
+
+// Paragraph adds a paragraph in the current style
+func (r *RichText) Paragraph(text string) *RichText {
+	r.richText.AddParagraph(text, r.style)
+	return r
+}
+
+// Style sets the style of paragraphs added after it
+func (r *RichText) Style(style components.ParagraphStyle) *RichText {
+	r.style = style
+	return r
+}
+
+// Justify sets whether following paragraphs are justified
+func (r *RichText) Justify(justify bool) *RichText {
+	if justify {
+		r.style.Align = components.TextAlignJustify
+	} else {
+		r.style.Align = components.TextAlignLeft
+	}
+	return r
+}
+
+// Heading adds a heading of the given level (1-6) that appears in the outline
+func (r *RichText) Heading(level int, text string) *RichText {
+	r.richText.AddHeading(level, text)
+	return r
+}
+
+// ScrollToAnchor scrolls to the heading or paragraph with the anchor id
+func (r *RichText) ScrollToAnchor(id string) *RichText {
+	r.richText.ScrollToAnchor(id)
+	return r
+}
+
+// OnLink sets the handler for clicked links that point outside the document
+func (r *RichText) OnLink(handler func(target string)) *RichText {
+	handler = guarded1("RichText.OnLink", handler)
+	r.richText.SetOnLinkClicked(handler)
+	return r
+}
+
+// Hyphenate turns on hyphenation for following paragraphs in the given
+// language, e.g. "en"; an empty language turns it off
+func (r *RichText) Hyphenate(language string) *RichText {
+	r.style.Hyphenate = language != ""
+	r.style.Language = language
+	return r
+}
+
+// Markdown adds paragraphs written in Markdown: headings, lists, quotes and
+// fenced code, with **bold**, `code` and [label](target) links inside them
+func (r *RichText) Markdown(src string) *RichText {
+	r.richText.AddParagraphs(components.ParseMarkdown(src, components.DefaultMarkdownStyles()))
+	return r
+}
+
+// Spans adds a paragraph in the current style made of spans of plain,
+// bold and code text and links
+//
+//	doc.Spans(finch.Plain("Press "), finch.Code("Ctrl+S"), finch.Plain(" to "), finch.Bold("save"))
+func (r *RichText) Spans(spans ...Span) *RichText {
+	var text strings.Builder
+	for _, span := range spans {
+		text.WriteString(string(span))
+	}
+	style := r.style
+	style.Marks = true
+	r.richText.AddParagraph(text.String(), style)
+	return r
+}
+
+// FitContent sets the height to that of the paragraphs, so the text doesn't
+// scroll
+func (r *RichText) FitContent() *RichText {
+	bounds := r.richText.Bounds()
+	bounds.Height = r.richText.ContentHeight()
+	r.richText.SetBounds(bounds)
+	return r
+}
+
+// Span is a run of text in a paragraph added with RichText.Spans
+type Span string
+
+// Plain returns a span of plain text
+func Plain(text string) Span {
+	return Span(text)
+}
+
+// Bold returns a span of bold text
+func Bold(text string) Span {
+	return Span("**" + text + "**")
+}
+
+// Code returns a span of text shaded as code
+func Code(text string) Span {
+	return Span("`" + text + "`")
+}
+
+// Link returns a span of link text; "#anchor" targets scroll to a heading
+// and others go to the OnLink handler
+func Link(label, target string) Span {
+	return Span("[" + label + "](" + target + ")")
+}
+
+// TreeView represents a hierarchical list
+type TreeView struct {
+	tree *components.TreeView
+	ui   *UI
+}
+
+// OnSelect sets the handler called when an item is selected
+func (t *TreeView) OnSelect(handler func(components.TreeItem)) *TreeView {
+	handler = guarded1("TreeView.OnSelect", handler)
+	t.tree.SetOnSelect(handler)
+	return t
+}
+
+// Selected returns the selected item, or nil
+func (t *TreeView) Selected() components.TreeItem {
+	return t.tree.Selected()
+}
+
+// Rating represents a star rating
+type Rating struct {
+	rating *components.Rating
+	ui     *UI
+}
+
+// Value gets the score
+func (r *Rating) Value() float64 {
+	return r.rating.Value()
+}
+
+// SetValue sets the score
+func (r *Rating) SetValue(value float64) *Rating {
+	r.rating.SetValue(value)
+	return r
+}
+
+// HalfSteps sets whether half stars can be picked
+func (r *Rating) HalfSteps(half bool) *Rating {
+	r.rating.SetHalfSteps(half)
+	return r
+}
+
+// ReadOnly sets whether the rating only displays its value
+func (r *Rating) ReadOnly(readOnly bool) *Rating {
+	r.rating.SetReadOnly(readOnly)
+	return r
+}
+
+// OnChange sets the change handler
+func (r *Rating) OnChange(handler func(float64)) *Rating {
+	handler = guarded1("Rating.OnChange", handler)
+	r.rating.SetOnChange(handler)
+	return r
+}
+
+// Slider represents a slider
+type Slider struct {
+	slider *components.Slider
+	ui     *UI
+}
+
+// Value gets the value
+func (s *Slider) Value() float64 {
+	return s.slider.Value()
+}
+
+// SetValue sets the value, snapped to the step
+func (s *Slider) SetValue(value float64) *Slider {
+	s.slider.SetValue(value)
+	return s
+}
+
+// Step sets the increment values snap to, e.g. 1 for whole numbers
+func (s *Slider) Step(step float64) *Slider {
+	s.slider.SetStep(step)
+	return s
+}
+
+// Range sets the lowest and highest values
+func (s *Slider) Range(min, max float64) *Slider {
+	s.slider.SetRange(min, max)
+	return s
+}
+
+// Width sets the width of the slider
+func (s *Slider) Width(width int) *Slider {
+	bounds := s.slider.Bounds()
+	bounds.Width = width
+	s.slider.SetBounds(bounds)
+	return s
+}
+
+// OnChange sets the change handler, called while the thumb is dragged
+func (s *Slider) OnChange(handler func(float64)) *Slider {
+	handler = guarded1("Slider.OnChange", handler)
+	s.slider.SetOnChange(handler)
+	return s
+}
+
+// BindValue binds a float pointer to the slider
+func (s *Slider) BindValue(value *float64) *Slider {
+	// Set initial value
+	s.slider.SetValue(*value)
+	
+	// Set up change handler
+	s.slider.SetOnChange(func(v float64) {
+		*value = v
+	})
+	
+	return s
+}
+
+// Progress represents a progress bar
+type Progress struct {
+	bar *components.ProgressBar
+	ui  *UI
+}
+
+// Value gets how far along the task is, from 0 to 1
+func (p *Progress) Value() float64 {
+	return p.bar.Value()
+}
+
+// SetValue sets how far along the task is, from 0 to 1
+func (p *Progress) SetValue(value float64) *Progress {
+	p.bar.SetValue(value)
+	return p
+}
+
+// Indeterminate sets whether the bar shows a moving segment instead of a
+// value, for tasks whose progress isn't known
+func (p *Progress) Indeterminate(indeterminate bool) *Progress {
+	p.bar.SetIndeterminate(indeterminate)
+	return p
+}
+
+// ShowPercent writes the value on the bar as a percentage, making the bar
+// tall enough for the text
+func (p *Progress) ShowPercent(show bool) *Progress {
+	p.bar.SetShowPercent(show)
+	bounds := p.bar.Bounds()
+	if show {
+		bounds.Height = components.ScaleTextSize(18)
+	} else {
+		bounds.Height = 8
+	}
+	p.bar.SetBounds(bounds)
+	return p
+}
+
+// Height sets the height of the bar
+func (p *Progress) Height(height int) *Progress {
+	bounds := p.bar.Bounds()
+	bounds.Height = height
+	p.bar.SetBounds(bounds)
+	return p
+}
+
+// Bind sets the value whenever a State changes
+func (p *Progress) Bind(state *State[float64]) *Progress {
+	state.Watch(func(value float64) {
+		p.bar.SetValue(value)
+	})
+	return p
+}
+
+// Radio represents a group of radio options
+type Radio struct {
+	group *components.RadioGroup
+	ui    *UI
+}
+
+// Value gets the selected option, or "" if none is selected
+func (r *Radio) Value() string {
+	return r.group.SelectedOption()
+}
+
+// SetValue selects the option with the given text
+func (r *Radio) SetValue(option string) *Radio {
+	for i, o := range r.group.Options() {
+		if o == option {
+			r.group.SetSelected(i)
+			break
+		}
+	}
+	return r
+}
+
+// Index gets the index of the selected option, or -1
+func (r *Radio) Index() int {
+	return r.group.Selected()
+}
+
+// SetIndex selects the option at an index, or none for -1
+func (r *Radio) SetIndex(index int) *Radio {
+	r.group.SetSelected(index)
+	return r
+}
+
+// OnChange sets the change handler, called with the newly selected option
+func (r *Radio) OnChange(handler func(string)) *Radio {
+	handler = guarded1("Radio.OnChange", handler)
+	r.group.SetOnChange(func(index int) {
+		if index >= 0 {
+			handler(r.group.Options()[index])
+		} else {
+			handler("")
+		}
+	})
+	return r
+}
+
+// BindValue binds a string pointer to the selected option
+func (r *Radio) BindValue(value *string) *Radio {
+	// Set initial value
+	r.SetValue(*value)
+	
+	// Set up change handler
+	return r.OnChange(func(option string) {
+		*value = option
+	})
+}
+
+// Toggle represents an on/off switch
+type Toggle struct {
+	toggle *components.ToggleSwitch
+	ui     *UI
+}
+
+// Value gets whether the switch is on
+func (t *Toggle) Value() bool {
+	return t.toggle.IsOn()
+}
+
+// SetValue switches the switch on or off
+func (t *Toggle) SetValue(on bool) *Toggle {
+	t.toggle.SetOn(on)
+	return t
+}
+
+// Label sets the text shown beside the switch
+func (t *Toggle) Label(label string) *Toggle {
+	t.toggle.SetLabel(label)
+	return t
+}
+
+// OnChange sets the change handler
+func (t *Toggle) OnChange(handler func(bool)) *Toggle {
+	handler = guarded1("Toggle.OnChange", handler)
+	t.toggle.SetOnChange(handler)
+	return t
+}
+
+// BindValue binds a boolean pointer to the switch
+func (t *Toggle) BindValue(value *bool) *Toggle {
+	// Set initial value
+	t.toggle.SetOn(*value)
+	
+	// Set up change handler
+	t.toggle.SetOnChange(func(on bool) {
+		*value = on
+	})
+	
+	return t
+}
+
+// Image represents an image
+type Image struct {
+	image *components.Image
+	ui    *UI
+}
+
+// Fit sets how the image fits its bounds: "contain" keeps it whole,
+// "cover" fills the bounds, cropping it, and "fill" stretches it
+func (i *Image) Fit(fit string) *Image {
+	switch fit {
+	case "cover":
+		i.image.SetFitMethod(components.ImageFitCover)
+	case "fill":
+		i.image.SetFitMethod(components.ImageFitFill)
+	default:
+		i.image.SetFitMethod(components.ImageFitContain)
+	}
+	return i
+}
+
+// Size sets the size of the image's bounds
+func (i *Image) Size(width, height int) *Image {
+	bounds := i.image.Bounds()
+	bounds.Width = width
+	bounds.Height = height
+	i.image.SetBounds(bounds)
+	return i
+}
+
+// Source loads another file in place of the image
+func (i *Image) Source(path string) *Image {
+	i.image.SetSourcePath(path)
+	return i
+}
+
+// OnClick sets the click handler
+func (i *Image) OnClick(handler func()) *Image {
+	handler = guarded("Image.OnClick", handler)
+	i.image.SetOnClick(handler)
+	return i
+}
+
+// OnLoad sets a handler called when the file has loaded, with the error if
+// it failed
+func (i *Image) OnLoad(handler func(error)) *Image {
+	handler = guarded1("Image.OnLoad", handler)
+	i.image.SetOnLoad(handler)
+	return i
+}
+
+// Icon represents a named icon
+type Icon struct {
+	icon *components.Icon
+	ui   *UI
+}
+
+// Size sets the width and height of the icon
+func (i *Icon) Size(size int) *Icon {
+	bounds := i.icon.Bounds()
+	bounds.Width = size
+	bounds.Height = size
+	i.icon.SetBounds(bounds)
+	return i
+}
+
+// Color sets the color of a line icon, in place of the theme's text color
+func (i *Icon) Color(hexColor string) *Icon {
+	// Parse hex color (simplified)
+	var r, g, b uint8
 	fmt.Sscanf(hexColor, "#%02x%02x%02x", &r, &g, &b)
-	c.container.SetBackgroundColor(color.RGBA{r, g, b, 255})
-	return c
+	i.icon.SetColor(color.RGBA{r, g, b, 255})
+	return i
 }
 
-// Padding sets the padding
-func (c *Container) Padding(padding int) *Container {
-	c.container.SetBoxModel(components.BoxModel{
-		Padding: components.Spacing{padding, padding, padding, padding},
-	})
-	return c
+// Name changes which icon is shown
+func (i *Icon) Name(name string) *Icon {
+	i.icon.SetName(name)
+	return i
 }
 
-// Margin sets the margin
-func (c *Container) Margin(margin int) *Container {
-	c.container.SetBoxModel(components.BoxModel{
-		Margin: components.Spacing{margin, margin, margin, margin},
-	})
-	return c
+// OnClick sets the click handler
+func (i *Icon) OnClick(handler func()) *Icon {
+	handler = guarded("Icon.OnClick", handler)
+	i.icon.SetOnClick(handler)
+	return i
 }
 
-// Width sets the width
-func (c *Container) Width(width interface{}) *Container {
-	switch w := width.(type) {
-	case int:
-		bounds := c.container.Bounds()
-		bounds.Width = w
-		c.container.SetBounds(bounds)
-	case string:
-		// For percentage strings like "80%"
-		var percentage int
-		fmt.Sscanf(w, "%d%%", &percentage)
-		if percentage > 0 {
-			bounds := c.container.Bounds()
-			bounds.Width = c.ui.width * percentage / 100
-			c.container.SetBounds(bounds)
-		}
+// Card represents a raised panel with optional header and footer
+type Card struct {
+	card *components.Card
+	ui   *UI
+}
+
+// fill runs a builder with the given section as the parent
+func (c *Card) fill(section *components.FlexContainer, builder func()) {
+	// Save the original parent
+	originalParent := c.ui.currentParent
+	
+	c.ui.currentParent = section
+	if builder != nil {
+		c.ui.guard("builder", builder)
 	}
+	
+	// Restore the original parent
+	c.ui.currentParent = originalParent
+	c.card.Layout()
+}
+
+// Header adds elements to the card's header
+func (c *Card) Header(builder func()) *Card {
+	c.fill(c.card.Header(), builder)
 	return c
 }
 
-// Height sets the height
-func (c *Container) Height(height int) *Container {
-	bounds := c.container.Bounds()
-	bounds.Height = height
-	c.container.SetBounds(bounds)
+// Footer adds elements to the card's footer
+func (c *Card) Footer(builder func()) *Card {
+	c.fill(c.card.Footer(), builder)
 	return c
 }
 
-// Grow makes the container take available space
-func (c *Container) Grow(factor int) *Container {
-	// In a real implementation, this would need a proper layout system
-	bounds := c.container.Bounds()
-	bounds.Height = c.ui.height - bounds.Y - 50 // Simplified
-	c.container.SetBounds(bounds)
+// Elevation sets the shadow depth in pixels
+func (c *Card) Elevation(elevation int) *Card {
+	c.card.SetElevation(elevation)
 	return c
 }
 
-// Layout sets the layout direction and runs the builder function
-func (c *Container) Layout(direction string, builder func(*Container)) *Container {
-	if direction == "row" {
-		c.container.SetFlexDirection(components.FlexRow)
-	} else {
-		c.container.SetFlexDirection(components.FlexColumn)
-	}
+// Padding sets the space around the card's content
+func (c *Card) Padding(padding int) *Card {
+	c.card.SetPadding(padding)
+	return c
+}
+
+// Carousel represents a container showing one slide at a time
+type Carousel struct {
+	carousel *components.Carousel
+	ui       *UI
+}
+
+// Slide adds a slide; the builder adds elements to it
+func (c *Carousel) Slide(builder func()) *Carousel {
+	slide := c.carousel.AddSlide()
 	
-	// Save the current parent
+	// Save the original parent
 	originalParent := c.ui.currentParent
 	
-	// Set this container as the current parent
-	c.ui.currentParent = c.container
-	
-	// Call the builder function
+	c.ui.currentParent = slide
 	if builder != nil {
-		builder(c)
+		c.ui.guard("builder", builder)
 	}
 	
 	// Restore the original parent
 	c.ui.currentParent = originalParent
-	
 	return c
 }
 
-// Text adds a text element to the container
-func (c *Container) Text(text string) *Text {
-	// Save the current parent
-	originalParent := c.ui.currentParent
-	
-	// Set this container as the current parent
-	c.ui.currentParent = c.container
-	
-	// Add the text
-	textElement := c.ui.Text(text)
-	
-	// Restore the original parent
-	c.ui.currentParent = originalParent
-	
-	return textElement
+// AutoAdvance moves to the next slide at the given interval
+func (c *Carousel) AutoAdvance(interval time.Duration) *Carousel {
+	c.carousel.SetAutoAdvance(interval)
+	return c
 }
 
-// Button adds a button to the container
-func (c *Container) Button(label string) *Button {
-	// Save the current parent
-	originalParent := c.ui.currentParent
-	
-	// Set this container as the current parent
-	c.ui.currentParent = c.container
-	
-	// Add the button
-	button := c.ui.Button(label)
-	
-	// Restore the original parent
-	c.ui.currentParent = originalParent
-	
-	return button
+// Wrap sets whether paging wraps around at the ends
+func (c *Carousel) Wrap(wrap bool) *Carousel {
+	c.carousel.SetWrap(wrap)
+	return c
 }
 
-// TextInput adds a text input to the container
-func (c *Container) TextInput(placeholder string) *TextInput {
-	// Save the current parent
-	originalParent := c.ui.currentParent
-	
-	// Set this container as the current parent
-	c.ui.currentParent = c.container
-	
-	// Add the text input
-	input := c.ui.TextInput(placeholder)
-	
-	// Restore the original parent
-	c.ui.currentParent = originalParent
-	
-	return input
+// Indicators sets whether the dot indicators are shown
+func (c *Carousel) Indicators(show bool) *Carousel {
+	c.carousel.SetShowIndicators(show)
+	return c
 }
 
-// Checkbox adds a checkbox to the container
-func (c *Container) Checkbox(label string) *Checkbox {
-	// Save the current parent
-	originalParent := c.ui.currentParent
-	
-	// Set this container as the current parent
-	c.ui.currentParent = c.container
-	
-	// Add the checkbox
-	checkbox := c.ui.Checkbox(label)
-	
-	// Restore the original parent
-	c.ui.currentParent = originalParent
-	
-	return checkbox
+// GoTo shows the slide at the given index
+func (c *Carousel) GoTo(index int) *Carousel {
+	c.carousel.GoTo(index)
+	return c
 }
 
-// RemoveAllChildren removes all child elements from this container
-func (c *Container) RemoveAllChildren() {
-	c.container.RemoveAllChildren()
+// Current returns the index of the slide being shown
+func (c *Carousel) Current() int {
+	return c.carousel.Current()
+}
+
+// OnChange sets the handler called with the new index when the slide changes
+func (c *Carousel) OnChange(handler func(int)) *Carousel {
+	handler = guarded1("Carousel.OnChange", handler)
+	c.carousel.SetOnChange(handler)
+	return c
+}
+
+// Command represents an action shared by menus, toolbars, shortcuts and the command palette
+type Command struct {
+	command *components.Command
+	ui      *UI
 }
 
-// TodoList adds a todo list to the container (sample implementation)
-func (c *Container) TodoList() *TodoList {
-	// Create a todo list
-	todoList := &TodoList{
-		container: c.container,
-		ui:        c.ui,
+// Shortcut binds a keyboard shortcut such as "Ctrl+S" to the command
+func (c *Command) Shortcut(shortcut string) *Command {
+	if c.command.Shortcut != "" {
+		c.ui.commands.Shortcuts().Unbind(c.command.Shortcut)
 	}
-	
-	return todoList
+	c.command.Shortcut = shortcut
+	if err := c.ui.commands.Shortcuts().Bind(shortcut, c.command); err != nil {
+		fmt.Printf("Error binding shortcut for %s: %v\n", c.command.ID, err)
+	}
+	return c
 }
 
-// Button represents a button element
-type Button struct {
-	button *components.Button
-	ui     *UI
+// Icon sets the icon shown in menus and toolbars
+func (c *Command) Icon(icon image.Image) *Command {
+	c.command.Icon = icon
+	return c
 }
 
-// OnClick sets the click handler
-func (b *Button) OnClick(handler func()) *Button {
-	b.button.SetOnClick(handler)
-	return b
+// Category groups the command in the palette, e.g. "File"
+func (c *Command) Category(category string) *Command {
+	c.command.Category = category
+	return c
 }
 
-// Width sets the button width
-func (b *Button) Width(width int) *Button {
-	bounds := b.button.Bounds()
-	bounds.Width = width
-	b.button.SetBounds(bounds)
-	return b
+// Enabled enables or disables the command everywhere it appears
+func (c *Command) Enabled(enabled bool) *Command {
+	c.command.SetEnabled(enabled)
+	return c
 }
 
-// TextInput represents a text input field
-type TextInput struct {
-	input *components.TextArea
-	ui    *UI
+// EnabledWhen sets a predicate deciding whether the command is enabled
+func (c *Command) EnabledWhen(predicate func() bool) *Command {
+	c.command.SetEnabledWhen(predicate)
+	return c
 }
 
-// Value gets the current text value
-func (t *TextInput) Value() string {
-	return t.input.GetText()
+// Title sets the label shown everywhere the command appears
+func (c *Command) Title(title string) *Command {
+	c.command.Title = title
+	return c
 }
 
-// SetValue sets the text value
-func (t *TextInput) SetValue(value string) *TextInput {
-	t.input.SetText(value)
+// Execute runs the command if it is enabled
+func (c *Command) Execute() bool {
+	return c.command.Execute()
+}
+
+// Component returns the underlying command, e.g. to add it to a PopupMenu
+func (c *Command) Component() *components.Command {
+	return c.command
+}
+
+// Toolbar represents a row of buttons
+type Toolbar struct {
+	toolbar *components.Toolbar
+	ui      *UI
+}
+
+// Button adds a button; the label is shown when icon is nil and in the overflow menu
+func (t *Toolbar) Button(label string, icon image.Image, handler func()) *Toolbar {
+	t.toolbar.AddButton(label, icon, handler)
 	return t
 }
 
-// Clear clears the input
-func (t *TextInput) Clear() *TextInput {
-	t.input.SetText("")
+// Toggle adds a button that stays pressed until clicked again
+func (t *Toolbar) Toggle(label string, icon image.Image, toggled bool, handler func(bool)) *Toolbar {
+	t.toolbar.AddToggle(label, icon, toggled, handler)
 	return t
 }
 
-// OnChange sets the change handler
-func (t *TextInput) OnChange(handler func(string)) *TextInput {
-	t.input.SetOnChange(handler)
+// Command adds a button for a registered command
+func (t *Toolbar) Command(command *Command) *Toolbar {
+	t.toolbar.AddCommand(command.command)
+	return t
+}
+
+// Separator adds a divider between groups of buttons
+func (t *Toolbar) Separator() *Toolbar {
+	t.toolbar.AddSeparator()
 	return t
 }
 
+// Modal represents a dialog shown above the page
+type Modal struct {
+	modal *components.Modal
+	ui    *UI
+}
+
+// Open shows the dialog
+func (m *Modal) Open() *Modal {
+	m.modal.Open()
+	return m
+}
+
+// Close hides the dialog
+func (m *Modal) Close() *Modal {
+	m.modal.Close()
+	return m
+}
+
+// IsOpen returns whether the dialog is showing
+func (m *Modal) IsOpen() bool {
+	return m.modal.IsOpen()
+}
+
+// Size sets the size of the dialog box
+func (m *Modal) Size(width, height int) *Modal {
+	m.modal.SetDialogSize(width, height)
+	return m
+}
+
+// Dismissible sets whether Esc, the close button and backdrop clicks close the dialog
+func (m *Modal) Dismissible(dismissible bool) *Modal {
+	m.modal.SetDismissible(dismissible)
+	return m
+}
+
+// OnClose sets the handler called when the dialog closes
+func (m *Modal) OnClose(handler func()) *Modal {
+	handler = guarded("Modal.OnClose", handler)
+	m.modal.SetOnClose(handler)
+	return m
+}
+
+// FileDialog represents a file open or save dialog
+type FileDialog struct {
+	dialog  *components.FileDialog
+	filters []components.FileFilter
+	ui      *UI
+}
+
+// Open shows the dialog
+func (f *FileDialog) Open() *FileDialog {
+	f.dialog.Open()
+	return f
+}
+
+// Directory sets the directory the dialog starts in
+func (f *FileDialog) Directory(dir string) *FileDialog {
+	f.dialog.SetDirectory(dir)
+	return f
+}
+
+// Filter adds an extension filter, e.g. Filter("Images", ".png", ".jpg")
+func (f *FileDialog) Filter(name string, extensions ...string) *FileDialog {
+	f.filters = append(f.filters, components.FileFilter{Name: name, Extensions: extensions})
+	f.dialog.SetFilters(f.filters)
+	return f
+}
+
+// FileName sets the name in the file name box
+func (f *FileDialog) FileName(name string) *FileDialog {
+	f.dialog.SetFileName(name)
+	return f
+}
+
+// ShowHidden sets whether hidden files are listed
+func (f *FileDialog) ShowHidden(show bool) *FileDialog {
+	f.dialog.SetShowHidden(show)
+	return f
+}
+
+// OnResult sets the handler called with the chosen path, or with ok false if cancelled
+func (f *FileDialog) OnResult(handler func(path string, ok bool)) *FileDialog {
+	handler = guarded2("FileDialog.OnResult", handler)
+	f.dialog.SetOnResult(handler)
+	return f
+}
+
+// AttachmentList represents a list of attached files
+type AttachmentList struct {
+	list *components.AttachmentList
+	ui   *UI
+}
+
+// Files returns the attached files
+func (a *AttachmentList) Files() []components.Attachment {
+	return a.list.Attachments()
+}
+
+// Filter adds an extension filter for the dialog and dropped files, e.g.
+// Filter("Images", ".png", ".jpg")
+func (a *AttachmentList) Filter(name string, extensions ...string) *AttachmentList {
+	a.list.SetFilters(append(a.list.Filters(), components.FileFilter{Name: name, Extensions: extensions}))
+	return a
+}
+
+// MaxFiles limits how many files can be attached
+func (a *AttachmentList) MaxFiles(max int) *AttachmentList {
+	a.list.SetMaxFiles(max)
+	return a
+}
+
+// OnChange sets the handler called when files are added or removed
+func (a *AttachmentList) OnChange(handler func([]components.Attachment)) *AttachmentList {
+	handler = guarded1("AttachmentList.OnChange", handler)
+	a.list.SetOnChange(handler)
+	return a
+}
+
+// Clear removes every file
+func (a *AttachmentList) Clear() *AttachmentList {
+	a.list.Clear()
+	return a
+}
+
 // Checkbox represents a checkbox element
 type Checkbox struct {
 	checkbox *components.Checkbox
@@ -286,6 +1786,7 @@ func (c *Checkbox) SetValue(checked bool) *Checkbox {
 
 // OnChange sets the change handler
 func (c *Checkbox) OnChange(handler func(bool)) *Checkbox {
+	handler = guarded1("Checkbox.OnChange", handler)
 	c.checkbox.SetCheckedChanged(handler)
 	return c
 }
@@ -345,7 +1846,7 @@ func (c *Column) Button(label string) *Button {
 
 // Tab represents a tab in a tabs layout
 type Tab struct {
-	header    *components.Button
+	page      *components.TabPage
 	container *components.FlexContainer
 	ui        *UI
 }
@@ -384,17 +1885,6 @@ func (t *Tab) Button(label string) *Button {
 	return button
 }
 
-// TodoList adds a todo list to the tab
-func (t *Tab) TodoList() *TodoList {
-	// Create a todo list
-	todoList := &TodoList{
-		container: t.container,
-		ui:        t.ui,
-	}
-	
-	return todoList
-}
-
 // Checkbox adds a checkbox to the tab
 func (t *Tab) Checkbox(label string) *Checkbox {
 	// Save the current parent
@@ -412,67 +1902,121 @@ func (t *Tab) Checkbox(label string) *Checkbox {
 	return checkbox
 }
 
-// State represents a reactive state value
-type State struct {
-	value    interface{}
-	watchers []func(interface{})
+// Expander represents a collapsible section
+type Expander struct {
+	expander *components.Expander
+	ui       *UI
+}
+
+// Expanded sets whether the section is open
+func (e *Expander) Expanded(expanded bool) *Expander {
+	e.expander.SetExpanded(expanded)
+	return e
 }
 
-// Update updates the state value using a transform function
-func (s *State) Update(transform func(interface{}) interface{}) {
-	newValue := transform(s.value)
-	s.value = newValue
+// ContentHeight sets the height of the section's content when open
+func (e *Expander) ContentHeight(height int) *Expander {
+	e.expander.SetContentHeight(height)
+	return e
+}
+
+// OnToggle sets a handler for when the section is opened or closed
+func (e *Expander) OnToggle(handler func(bool)) *Expander {
+	handler = guarded1("Expander.OnToggle", handler)
+	e.expander.SetOnToggle(handler)
+	return e
+}
+
+// Content runs the builder with the section's content as the current parent
+func (e *Expander) Content(builder func()) *Expander {
+	// Save the current parent
+	originalParent := e.ui.currentParent
+	
+	// Set this section as the current parent
+	e.ui.currentParent = e.expander.Content()
 	
-	// Notify watchers
-	for _, watcher := range s.watchers {
-		watcher(s.value)
+	if builder != nil {
+		e.ui.guard("builder", builder)
 	}
+	
+	// Restore the original parent
+	e.ui.currentParent = originalParent
+	
+	return e
 }
 
-// Watch adds a watcher function that is called when the state changes
-func (s *State) Watch(watcher func(interface{})) {
-	s.watchers = append(s.watchers, watcher)
-	
-	// Call the watcher with the current value
-	watcher(s.value)
+// ExpandableText represents text cut to a few lines with a "Show more" toggle
+type ExpandableText struct {
+	text *components.ExpandableText
+	ui   *UI
 }
 
-// Value gets the current state value
-func (s *State) Value() interface{} {
-	return s.value
+// Expanded sets whether all of the text is shown
+func (e *ExpandableText) Expanded(expanded bool) *ExpandableText {
+	e.text.SetExpanded(expanded)
+	return e
 }
 
-// TodoList represents a todo list (simplified example)
-type TodoList struct {
-	list      interface{} // This would be an actual TodoList component
-	container *components.FlexContainer
-	ui        *UI
-	items     []interface{}
-	onChange  func(interface{})
+// Style sets how the text is wrapped and drawn
+func (e *ExpandableText) Style(style components.ParagraphStyle) *ExpandableText {
+	e.text.SetStyle(style)
+	return e
 }
 
-// BindItems binds a list of items to the todo list
-func (t *TodoList) BindItems(state *State) *TodoList {
-	// This is a simplified implementation
-	t.items = state.Value().([]interface{})
-	
-	// Watch for changes
-	state.Watch(func(value interface{}) {
-		t.items = value.([]interface{})
-		// Update the UI (simplified)
-	})
-	
+// Labels sets the link text for expanding and collapsing
+func (e *ExpandableText) Labels(more, less string) *ExpandableText {
+	e.text.SetLabels(more, less)
+	return e
+}
+
+// OnToggle sets a handler for when the text is expanded or collapsed
+func (e *ExpandableText) OnToggle(handler func(bool)) *ExpandableText {
+	handler = guarded1("ExpandableText.OnToggle", handler)
+	e.text.SetOnToggle(handler)
+	return e
+}
+
+// Task represents background work followed by a toast
+type Task struct {
+	task  *components.Task
+	toast *components.TaskToast
+}
+
+// SuccessMessage sets the toast's message when the task succeeds
+func (t *Task) SuccessMessage(message string) *Task {
+	t.toast.SetSuccessMessage(message)
+	return t
+}
+
+// FailureMessage sets the toast's message, before the error, when the task
+// fails
+func (t *Task) FailureMessage(message string) *Task {
+	t.toast.SetFailureMessage(message)
+	return t
+}
+
+// ResultAction adds a button to the success toast, such as "Open result"
+func (t *Task) ResultAction(label string, handler func()) *Task {
+	t.toast.SetResultAction(label, handler)
 	return t
 }
 
-// OnItemChange sets a handler for when an item changes
-func (t *TodoList) OnItemChange(handler func(interface{})) *TodoList {
-	t.onChange = handler
+// OnProgress sets a handler for each progress report
+func (t *Task) OnProgress(handler func(float64)) *Task {
+	handler = guarded1("Task.OnProgress", handler)
+	t.task.SetOnProgress(handler)
 	return t
 }
 
-// FilterItems filters the displayed items
-func (t *TodoList) FilterItems(filter func(interface{}) bool) *TodoList {
-	// This would actually filter the displayed items
+// OnDone sets a handler for when the task ends, with its error
+func (t *Task) OnDone(handler func(error)) *Task {
+	handler = guarded1("Task.OnDone", handler)
+	t.task.SetOnDone(handler)
 	return t
-} 
\ No newline at end of file
+}
+
+// Cancel asks the task to stop
+func (t *Task) Cancel() *Task {
+	t.task.Cancel()
+	return t
+}
@@ -0,0 +1,91 @@
+package finch
+
+import (
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Window configures the app window. Settings apply at once and can be made
+// before Run, which then keeps them when it opens the window.
+//
+//	ui.Window().Title("Editor").Size(1024, 768).MinSize(640, 480).Resizable(true)
+type Window struct {
+	ui *UI
+}
+
+// Window returns the configuration of the app window
+func (ui *UI) Window() *Window {
+	return &Window{ui: ui}
+}
+
+// Title sets the window's title
+func (w *Window) Title(title string) *Window {
+	w.ui.title = title
+	ebiten.SetWindowTitle(title)
+	return w
+}
+
+// Size sets the window's size, in place of the size given to Run
+func (w *Window) Size(width, height int) *Window {
+	w.ui.windowSized = true
+	ebiten.SetWindowSize(width, height)
+	return w
+}
+
+// MinSize sets the smallest size the window can be resized to; 0 leaves a
+// dimension unlimited
+func (w *Window) MinSize(width, height int) *Window {
+	w.ui.windowLimits[0], w.ui.windowLimits[1] = width, height
+	w.applyLimits()
+	return w
+}
+
+// MaxSize sets the largest size the window can be resized to; 0 leaves a
+// dimension unlimited
+func (w *Window) MaxSize(width, height int) *Window {
+	w.ui.windowLimits[2], w.ui.windowLimits[3] = width, height
+	w.applyLimits()
+	return w
+}
+
+// applyLimits passes the size limits to the window, where -1 is unlimited
+func (w *Window) applyLimits() {
+	var limits [4]int
+	for i, limit := range w.ui.windowLimits {
+		limits[i] = limit
+		if limit <= 0 {
+			limits[i] = -1
+		}
+	}
+	ebiten.SetWindowSizeLimits(limits[0], limits[1], limits[2], limits[3])
+}
+
+// Resizable sets whether the user can resize the window. The page follows
+// the window's size, laying itself out again.
+func (w *Window) Resizable(resizable bool) *Window {
+	if resizable {
+		ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
+	} else {
+		ebiten.SetWindowResizingMode(ebiten.WindowResizingModeDisabled)
+	}
+	return w
+}
+
+// Fullscreen switches the window to fill the screen, or back
+func (w *Window) Fullscreen(fullscreen bool) *Window {
+	ebiten.SetFullscreen(fullscreen)
+	return w
+}
+
+// IsFullscreen returns whether the window fills the screen
+func (w *Window) IsFullscreen() bool {
+	return ebiten.IsFullscreen()
+}
+
+// Icon sets the window's icon. Give several sizes, e.g. 16x16, 32x32 and
+// 48x48, and the best one is picked for each place the icon is shown.
+func (w *Window) Icon(icons ...image.Image) *Window {
+	ebiten.SetWindowIcon(icons)
+	return w
+}
@@ -0,0 +1,193 @@
+package test
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/aggnr/finch/components"
+)
+
+// ChaosConfig controls the disturbances injected while tests run
+type ChaosConfig struct {
+	Seed            int64         // Random seed; runs with the same seed inject the same chaos
+	Jitter          int           // Maximum pixel offset added to pointer coordinates
+	MaxDelay        time.Duration // Maximum extra delay before delivering each input event
+	ResizeChance    float64       // Probability per step of resizing the UI under test
+	MinScale        float64       // Smallest size as a fraction of the original, e.g. 0.7
+	DropFrameChance float64       // Probability per frame of skipping the runner's update
+}
+
+// DefaultChaosConfig returns a moderate chaos configuration seeded from the clock
+func DefaultChaosConfig() ChaosConfig {
+	return ChaosConfig{
+		Seed:            time.Now().UnixNano(),
+		Jitter:          3,
+		MaxDelay:        150 * time.Millisecond,
+		ResizeChance:    0.2,
+		MinScale:        0.75,
+		DropFrameChance: 0.1,
+	}
+}
+
+// ChaosMonkey injects input jitter, delayed delivery, resizes and dropped
+// frames into a test run so UIs that depend on exact timing or layout fail
+type ChaosMonkey struct {
+	config       ChaosConfig
+	rng          *rand.Rand
+	original     components.Rect
+	hasOriginal  bool
+	droppedCount int
+	resizeCount  int
+}
+
+// NewChaosMonkey creates a chaos monkey with the given configuration
+func NewChaosMonkey(config ChaosConfig) *ChaosMonkey {
+	if config.MinScale <= 0 || config.MinScale > 1 {
+		config.MinScale = 1
+	}
+	return &ChaosMonkey{
+		config: config,
+		rng:    rand.New(rand.NewSource(config.Seed)),
+	}
+}
+
+// Config returns the chaos configuration
+func (c *ChaosMonkey) Config() ChaosConfig {
+	return c.config
+}
+
+// JitterPoint offsets a pointer position by up to the configured jitter
+func (c *ChaosMonkey) JitterPoint(x, y int) (int, int) {
+	if c.config.Jitter <= 0 {
+		return x, y
+	}
+	span := 2*c.config.Jitter + 1
+	return x + c.rng.Intn(span) - c.config.Jitter, y + c.rng.Intn(span) - c.config.Jitter
+}
+
+// Delay waits a random time up to the configured maximum delay
+func (c *ChaosMonkey) Delay() time.Duration {
+	if c.config.MaxDelay <= 0 {
+		return 0
+	}
+	delay := time.Duration(c.rng.Int63n(int64(c.config.MaxDelay)))
+	time.Sleep(delay)
+	return delay
+}
+
+// ShouldDropFrame decides whether the current frame's update is skipped
+func (c *ChaosMonkey) ShouldDropFrame() bool {
+	if c.rng.Float64() < c.config.DropFrameChance {
+		c.droppedCount++
+		return true
+	}
+	return false
+}
+
+// MaybeResize randomly resizes the element as a window resize would.
+// Returns true if the element was resized.
+func (c *ChaosMonkey) MaybeResize(element components.Element) bool {
+	if c.rng.Float64() >= c.config.ResizeChance {
+		return false
+	}
+
+	if !c.hasOriginal {
+		c.original = element.Bounds()
+		c.hasOriginal = true
+	}
+
+	scale := func() float64 {
+		return c.config.MinScale + c.rng.Float64()*(1-c.config.MinScale)
+	}
+	element.SetBounds(components.Rect{
+		X:      c.original.X,
+		Y:      c.original.Y,
+		Width:  int(float64(c.original.Width) * scale()),
+		Height: int(float64(c.original.Height) * scale()),
+	})
+	c.resizeCount++
+	return true
+}
+
+// Restore puts a resized element back to its original size
+func (c *ChaosMonkey) Restore(element components.Element) {
+	if c.hasOriginal {
+		element.SetBounds(c.original)
+		c.hasOriginal = false
+	}
+}
+
+// Summary describes the chaos injected so far
+func (c *ChaosMonkey) Summary() string {
+	return fmt.Sprintf("Chaos (seed %d): %d resizes, %d dropped frames", c.config.Seed, c.resizeCount, c.droppedCount)
+}
+
+// CheckConsistency walks the element tree looking for states a UI should never
+// reach, such as negative sizes or broken parent links. Returns one message per problem.
+func CheckConsistency(root components.Element) []string {
+	problems := make([]string, 0)
+
+	var walk func(element components.Element)
+	walk = func(element components.Element) {
+		bounds := element.Bounds()
+		if bounds.Width < 0 || bounds.Height < 0 {
+			problems = append(problems, fmt.Sprintf("%s has negative size %dx%d", element.ID(), bounds.Width, bounds.Height))
+		}
+		for _, child := range element.Children() {
+			if child.Parent() == nil {
+				problems = append(problems, fmt.Sprintf("%s is a child of %s but has no parent", child.ID(), element.ID()))
+			}
+			walk(child)
+		}
+	}
+	walk(root)
+
+	return problems
+}
+
+// SetChaos enables chaos mode for subsequent steps
+func (f *UITestFrame) SetChaos(config ChaosConfig) {
+	f.chaos = NewChaosMonkey(config)
+	f.Log(fmt.Sprintf("Chaos mode enabled (seed %d)", config.Seed))
+}
+
+// DisableChaos turns chaos mode off and restores the UI under test to its original size
+func (f *UITestFrame) DisableChaos() {
+	if f.chaos == nil {
+		return
+	}
+	if f.rootElement != nil {
+		f.chaos.Restore(f.rootElement)
+	}
+	f.Log(f.chaos.Summary())
+	f.chaos = nil
+	f.Log("Chaos mode disabled")
+}
+
+// IsChaosEnabled returns whether chaos mode is on
+func (f *UITestFrame) IsChaosEnabled() bool {
+	return f.chaos != nil
+}
+
+// applyChaosBeforeStep resizes the UI under test at random before an action
+func (f *UITestFrame) applyChaosBeforeStep() {
+	if f.chaos == nil || f.rootElement == nil {
+		return
+	}
+	if f.chaos.MaybeResize(f.rootElement) {
+		b := f.rootElement.Bounds()
+		f.Log(fmt.Sprintf("Chaos: resized UI to %dx%d", b.Width, b.Height))
+	}
+}
+
+// checkChaosConsistency fails the step if chaos left the UI in an inconsistent state
+func (f *UITestFrame) checkChaosConsistency() {
+	if f.chaos == nil || f.rootElement == nil {
+		return
+	}
+	for _, problem := range CheckConsistency(f.rootElement) {
+		f.Log("Chaos: inconsistent UI: " + problem)
+		f.stepFailed = true
+	}
+}
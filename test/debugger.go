@@ -0,0 +1,203 @@
+package test
+
+import (
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/aggnr/finch/components"
+)
+
+// BreakpointHit describes where the runner paused on a breakpoint
+type BreakpointHit struct {
+	TestIndex int
+	Step      int
+	Action    UITestAction
+	Target    components.Element // Resolved target element, nil if not found
+	HitAt     time.Time
+}
+
+// SetBreakpoint enables or disables a breakpoint on the action at the given step
+func (tc *UITestCase) SetBreakpoint(step int, enabled bool) {
+	if step < 0 || step >= len(tc.Actions) {
+		return
+	}
+	tc.Actions[step].Breakpoint = enabled
+}
+
+// ToggleBreakpoint flips the breakpoint on the action at the given step
+func (tc *UITestCase) ToggleBreakpoint(step int) bool {
+	if step < 0 || step >= len(tc.Actions) {
+		return false
+	}
+	tc.Actions[step].Breakpoint = !tc.Actions[step].Breakpoint
+	return tc.Actions[step].Breakpoint
+}
+
+// Breakpoints returns the steps that have breakpoints
+func (tc *UITestCase) Breakpoints() []int {
+	steps := make([]int, 0)
+	for i, action := range tc.Actions {
+		if action.Breakpoint {
+			steps = append(steps, i)
+		}
+	}
+	return steps
+}
+
+// AddAssertAction adds a step that checks a condition on the target element
+func (tc *UITestCase) AddAssertAction(targetID string, description string, check func(components.Element) error) {
+	tc.Actions = append(tc.Actions, UITestAction{
+		Type:        "assert",
+		TargetID:    targetID,
+		Description: description,
+		Check:       check,
+	})
+}
+
+// Breakpoint returns the breakpoint the runner is paused on, or nil
+func (f *UITestFrame) Breakpoint() *BreakpointHit {
+	return f.breakpoint
+}
+
+// ToggleBreakpointAtNextStep flips the breakpoint on the step that will run next
+func (f *UITestFrame) ToggleBreakpointAtNextStep() {
+	if len(f.testCases) == 0 {
+		return
+	}
+
+	testCase := f.testCases[f.currentTest]
+	step := f.currentStep + 1
+	if step >= len(testCase.Actions) {
+		f.Log("No next step to set a breakpoint on")
+		return
+	}
+
+	if testCase.ToggleBreakpoint(step) {
+		f.Log(fmt.Sprintf("Breakpoint set on step %d: %s", step+1, testCase.Actions[step].Description))
+	} else {
+		f.Log(fmt.Sprintf("Breakpoint cleared on step %d", step+1))
+	}
+}
+
+// checkBreakpoint pauses the runner before an action with a breakpoint.
+// Returns true if execution should stop for this frame.
+func (f *UITestFrame) checkBreakpoint(step int, action UITestAction) bool {
+	if !action.Breakpoint {
+		return false
+	}
+
+	// Continuing from this breakpoint runs the action
+	if f.breakpoint != nil && f.breakpoint.TestIndex == f.currentTest && f.breakpoint.Step == step {
+		f.breakpoint = nil
+		return false
+	}
+
+	target := action.Target
+	if target == nil && action.TargetID != "" {
+		target = f.FindElementByID(action.TargetID)
+	}
+
+	f.breakpoint = &BreakpointHit{
+		TestIndex: f.currentTest,
+		Step:      step,
+		Action:    action,
+		Target:    target,
+		HitAt:     time.Now(),
+	}
+	f.playingTest = false
+	f.statusLabel.SetText(fmt.Sprintf("Paused at breakpoint: step %d/%d: %s",
+		step+1, len(f.testCases[f.currentTest].Actions), action.Description))
+	f.Log(fmt.Sprintf("Breakpoint hit at step %d: %s", step+1, action.Description))
+
+	if f.onBreakpoint != nil {
+		f.onBreakpoint(f.breakpoint)
+	}
+	return true
+}
+
+// breakpointContext returns the lines shown in the breakpoint panel
+func (f *UITestFrame) breakpointContext() []string {
+	hit := f.breakpoint
+	if hit == nil {
+		return nil
+	}
+
+	testCase := f.testCases[hit.TestIndex]
+	lines := []string{
+		fmt.Sprintf("Test: %s", testCase.Name),
+		fmt.Sprintf("Step %d/%d: %s", hit.Step+1, len(testCase.Actions), hit.Action.Description),
+		fmt.Sprintf("Action: %s  Target: %s", hit.Action.Type, hit.Action.TargetID),
+	}
+
+	if hit.Target == nil {
+		if hit.Action.TargetID != "" {
+			lines = append(lines, "Target not found")
+		}
+	} else {
+		b := hit.Target.Bounds()
+		lines = append(lines, fmt.Sprintf("Bounds: (%d,%d) %dx%d", b.X, b.Y, b.Width, b.Height))
+		if node, ok := hit.Target.(components.NodeElement); ok {
+			cb := node.ComputedBounds()
+			visible := true
+			if v, ok := node.(interface{ IsVisible() bool }); ok {
+				visible = v.IsVisible()
+			}
+			lines = append(lines, fmt.Sprintf("Computed: (%d,%d) %dx%d  Visible: %v", cb.X, cb.Y, cb.Width, cb.Height, visible))
+			if classes := node.GetClassNames(); len(classes) > 0 {
+				lines = append(lines, "Classes: "+strings.Join(classes, " "))
+			}
+		}
+		if hit.Action.Check != nil {
+			if err := hit.Action.Check(hit.Target); err != nil {
+				lines = append(lines, "Assertion would fail: "+err.Error())
+			} else {
+				lines = append(lines, "Assertion would pass")
+			}
+		}
+	}
+
+	// Most recent results give context for what led here
+	results := testCase.Results
+	if len(results) > 3 {
+		results = results[len(results)-3:]
+	}
+	for _, result := range results {
+		lines = append(lines, "  "+result)
+	}
+
+	return lines
+}
+
+// drawBreakpointPanel draws the paused step's context and outlines its target
+func (f *UITestFrame) drawBreakpointPanel(surface components.DrawSurface) {
+	lines := f.breakpointContext()
+	if lines == nil {
+		return
+	}
+
+	// Outline the target in the UI under test
+	if target := f.breakpoint.Target; target != nil {
+		b := target.Bounds()
+		if node, ok := target.(components.NodeElement); ok {
+			b = node.ComputedBounds()
+		}
+		red := color.RGBA{220, 20, 60, 255}
+		surface.DrawRect(b.X-2, b.Y-2, b.Width+4, b.Height+4, red)
+		surface.DrawRect(b.X-3, b.Y-3, b.Width+6, b.Height+6, red)
+	}
+
+	lineHeight := 14
+	width := 420
+	height := len(lines)*lineHeight + 28
+	x := 10
+	y := f.Bounds().Y - height - 10
+
+	surface.FillRect(x, y, width, height, color.RGBA{255, 250, 220, 240})
+	surface.DrawRect(x, y, width, height, color.RGBA{200, 150, 0, 255})
+	surface.DrawText("Breakpoint (Play to continue, Step to run this action)", x+6, y+4, color.RGBA{150, 90, 0, 255}, 11)
+	for i, line := range lines {
+		surface.DrawText(line, x+6, y+22+i*lineHeight, color.RGBA{0, 0, 0, 255}, 10)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"image/color"
 	"image/png"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/aggnr/finch/components"
@@ -33,6 +34,8 @@ type UITest struct {
 	events      []TestEvent
 	results     []TestResult
 	surface     components.DrawSurface
+	goldens     *GoldenSet // Optional screenshot storage; nil writes files to the working directory
+	review      *GoldenReview // Optional visual review; screenshots that differ are uploaded instead of saved
 }
 
 // NewUITest creates a new UI test
@@ -81,7 +84,7 @@ func (t *UITest) Run() {
 		switch event.Type {
 		case "click":
 			// Simulate mouse down
-			handled = t.rootElement.HandleMouseDown(event.X, event.Y)
+			handled = components.DispatchEvent(t.rootElement, components.NewMouseEvent(components.InputTypeMouseDown, event.X, event.Y))
 			
 			// Render the UI after mouse down
 			t.rootElement.Draw(t.surface)
@@ -91,7 +94,7 @@ func (t *UITest) Run() {
 			time.Sleep(100 * time.Millisecond)
 			
 			// Simulate mouse up
-			handled = t.rootElement.HandleMouseUp(event.X, event.Y)
+			handled = components.DispatchEvent(t.rootElement, components.NewMouseEvent(components.InputTypeMouseUp, event.X, event.Y))
 			
 		case "key":
 			// Keyboard events would be handled here
@@ -139,7 +142,7 @@ func (t *UITest) Interactive() {
 		switch event.Type {
 		case "click":
 			// Simulate mouse down
-			handled = t.rootElement.HandleMouseDown(event.X, event.Y)
+			handled = components.DispatchEvent(t.rootElement, components.NewMouseEvent(components.InputTypeMouseDown, event.X, event.Y))
 			
 			// Render the UI after mouse down
 			t.rootElement.Draw(t.surface)
@@ -149,7 +152,7 @@ func (t *UITest) Interactive() {
 			time.Sleep(100 * time.Millisecond)
 			
 			// Simulate mouse up
-			handled = t.rootElement.HandleMouseUp(event.X, event.Y)
+			handled = components.DispatchEvent(t.rootElement, components.NewMouseEvent(components.InputTypeMouseUp, event.X, event.Y))
 			
 		case "key":
 			// Keyboard events would be handled here
@@ -177,10 +180,43 @@ func (t *UITest) Interactive() {
 	t.PrintResults()
 }
 
+// SetGoldenSet stores screenshots in the given golden set instead of local files
+func (t *UITest) SetGoldenSet(goldens *GoldenSet) {
+	t.goldens = goldens
+}
+
+// SetReview sends screenshots that differ from their goldens to a visual
+// review service instead of overwriting the goldens
+func (t *UITest) SetReview(review *GoldenReview) {
+	t.review = review
+}
+
 // SaveScreenshot saves the current UI state as an image
 func (t *UITest) SaveScreenshot(filename string) {
 	image := t.surface.(*MemorySurface).Image()
 	
+	if t.review != nil {
+		result, err := t.review.Check(strings.TrimSuffix(filename, ".png"), image, nil)
+		if err != nil {
+			fmt.Println("Error sending screenshot for review:", err)
+		} else if result.Matched {
+			fmt.Printf("Screenshot %s matches its golden\n", filename)
+		} else {
+			fmt.Printf("Screenshot %s sent for review as %s (%d pixels differ)\n", filename, result.SnapshotID, result.DiffPixels)
+		}
+		return
+	}
+	
+	if t.goldens != nil {
+		key, err := t.goldens.Save(strings.TrimSuffix(filename, ".png"), image)
+		if err != nil {
+			fmt.Println("Error storing screenshot:", err)
+		} else {
+			fmt.Printf("Screenshot %s stored as %s\n", filename, key)
+		}
+		return
+	}
+	
 	f, err := os.Create(filename)
 	if err != nil {
 		fmt.Println("Error creating file:", err)
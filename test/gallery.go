@@ -0,0 +1,213 @@
+package test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/aggnr/finch/components"
+)
+
+// APIMethod is an exported method found by reflection
+type APIMethod struct {
+	Name      string
+	Signature string
+}
+
+// GalleryEntry is one component in the widget gallery
+type GalleryEntry struct {
+	Name        string
+	Description string
+	Type        string
+	PNG         []byte
+	SVG         []byte
+	Methods     []APIMethod
+	Inherited   int // Methods promoted from Node, listed once for all components
+}
+
+// Gallery is a document describing every registered component, generated
+// from the components themselves rather than written by hand
+type Gallery struct {
+	Title       string
+	Entries     []*GalleryEntry
+	NodeMethods []APIMethod
+}
+
+// galleryPadding is the margin drawn around each component
+const galleryPadding = 8
+
+// BuildGallery creates each registered component with its default props,
+// renders it to PNG and SVG and lists its public API
+func BuildGallery(title string) *Gallery {
+	nodeMethods := exportedMethods(reflect.TypeOf(&components.Node{}))
+	nodeNames := make(map[string]bool, len(nodeMethods))
+	for _, m := range nodeMethods {
+		nodeNames[m.Name] = true
+	}
+
+	gallery := &Gallery{Title: title, NodeMethods: nodeMethods}
+	for _, info := range components.RegisteredComponents() {
+		element := info.New("gallery_" + strings.ToLower(info.Name))
+		element.SetBounds(components.Rect{X: galleryPadding, Y: galleryPadding, Width: info.Width, Height: info.Height})
+
+		entry := &GalleryEntry{
+			Name:        info.Name,
+			Description: info.Description,
+			Type:        reflect.TypeOf(element).String(),
+		}
+		entry.PNG, entry.SVG = renderComponent(element, info.Width+2*galleryPadding, info.Height+2*galleryPadding)
+		for _, m := range exportedMethods(reflect.TypeOf(element)) {
+			if nodeNames[m.Name] {
+				entry.Inherited++
+				continue
+			}
+			entry.Methods = append(entry.Methods, m)
+		}
+		gallery.Entries = append(gallery.Entries, entry)
+	}
+	return gallery
+}
+
+// renderComponent draws an element to a PNG and an SVG of the given size
+func renderComponent(element components.Element, width, height int) ([]byte, []byte) {
+	white := color.RGBA{255, 255, 255, 255}
+
+	memory := NewMemorySurface(width, height)
+	memory.Clear(white)
+	element.Draw(memory)
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, memory.Image().SubImage(image.Rect(0, 0, width, height))); err != nil {
+		fmt.Printf("Error encoding gallery image: %v\n", err)
+	}
+
+	svg := NewSVGSurface(width, height)
+	svg.Clear(white)
+	element.Draw(svg)
+	return buf.Bytes(), svg.Bytes()
+}
+
+// exportedMethods lists the exported methods of a type with their signatures
+func exportedMethods(t reflect.Type) []APIMethod {
+	methods := make([]APIMethod, 0, t.NumMethod())
+	for i := 0; i < t.NumMethod(); i++ {
+		m := t.Method(i)
+		methods = append(methods, APIMethod{Name: m.Name, Signature: methodSignature(m)})
+	}
+	return methods
+}
+
+// methodSignature formats a method like "SetText(string)" or "Bounds() components.Rect"
+func methodSignature(m reflect.Method) string {
+	ft := m.Type
+	args := make([]string, 0, ft.NumIn())
+	for i := 1; i < ft.NumIn(); i++ { // Skip the receiver
+		arg := ft.In(i).String()
+		if ft.IsVariadic() && i == ft.NumIn()-1 {
+			arg = "..." + ft.In(i).Elem().String()
+		}
+		args = append(args, arg)
+	}
+	results := make([]string, 0, ft.NumOut())
+	for i := 0; i < ft.NumOut(); i++ {
+		results = append(results, ft.Out(i).String())
+	}
+
+	sig := m.Name + "(" + strings.Join(args, ", ") + ")"
+	switch len(results) {
+	case 0:
+	case 1:
+		sig += " " + results[0]
+	default:
+		sig += " (" + strings.Join(results, ", ") + ")"
+	}
+	return sig
+}
+
+// galleryTemplate renders a standalone, printable page with the images inlined
+var galleryTemplate = template.Must(template.New("gallery").Funcs(template.FuncMap{
+	"png": func(data []byte) template.URL {
+		return template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(data))
+	},
+	"svg": func(data []byte) template.HTML {
+		return template.HTML(data)
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 24px; color: #222; }
+nav a { margin-right: 10px; }
+.component { border-top: 1px solid #ddd; padding: 16px 0; page-break-inside: avoid; }
+.component h2 { margin: 0 0 4px 0; }
+.type { color: #666; font-family: monospace; }
+.renders { display: flex; gap: 24px; align-items: flex-start; margin: 12px 0; }
+.renders figure { margin: 0; }
+.renders figcaption { font-size: 12px; color: #666; }
+.renders img, .renders svg { border: 1px solid #ccc; }
+ul.api { columns: 2; font-family: monospace; font-size: 13px; }
+@media print { nav { display: none; } ul.api { columns: 1; } }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<nav>{{range .Entries}}<a href="#{{.Name}}">{{.Name}}</a>{{end}}</nav>
+{{range .Entries}}
+<div class="component" id="{{.Name}}">
+<h2>{{.Name}}</h2>
+<div class="type">{{.Type}}</div>
+<p>{{.Description}}</p>
+<div class="renders">
+<figure><img src="{{png .PNG}}" alt="{{.Name}}"><figcaption>PNG</figcaption></figure>
+<figure>{{svg .SVG}}<figcaption>SVG</figcaption></figure>
+</div>
+<ul class="api">{{range .Methods}}<li>{{.Signature}}</li>{{end}}</ul>
+{{if .Inherited}}<p>Plus {{.Inherited}} methods shared with every element; see <a href="#node">Node</a>.</p>{{end}}
+</div>
+{{end}}
+<div class="component" id="node">
+<h2>Node</h2>
+<p>Methods every element gets from the embedded *components.Node.</p>
+<ul class="api">{{range .NodeMethods}}<li>{{.Signature}}</li>{{end}}</ul>
+</div>
+</body>
+</html>
+`))
+
+// WriteHTML writes the gallery as a standalone HTML page
+func (g *Gallery) WriteHTML(w io.Writer) error {
+	return galleryTemplate.Execute(w, g)
+}
+
+// Save writes index.html plus a PNG and SVG file per component to a directory
+func (g *Gallery) Save(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, entry := range g.Entries {
+		base := filepath.Join(dir, strings.ToLower(entry.Name))
+		if err := os.WriteFile(base+".png", entry.PNG, 0644); err != nil {
+			return err
+		}
+		if err := os.WriteFile(base+".svg", entry.SVG, 0644); err != nil {
+			return err
+		}
+	}
+
+	f, err := os.Create(filepath.Join(dir, "index.html"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return g.WriteHTML(f)
+}
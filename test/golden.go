@@ -0,0 +1,377 @@
+package test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrAssetNotFound is returned when an asset is missing from a store
+var ErrAssetNotFound = errors.New("asset not found")
+
+// ErrInvalidKey is returned by a DirStore for a key that, once cleaned,
+// would lead outside its directory, such as "../x" or "/etc/x"
+var ErrInvalidKey = errors.New("invalid asset key")
+
+// AssetStore is the storage backend for screenshots and golden images.
+// Keys are slash-separated paths, typically produced by ContentKey.
+type AssetStore interface {
+	Put(key string, data []byte) error
+	Get(key string) ([]byte, error)
+	Has(key string) (bool, error)
+}
+
+// ContentKey returns a content-addressed key for the data, e.g. "sha256/ab/ab12...ef.png"
+func ContentKey(data []byte, ext string) string {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	return fmt.Sprintf("sha256/%s/%s%s", hash[:2], hash, ext)
+}
+
+// DirStore stores assets as files under a local directory
+type DirStore struct {
+	root string
+}
+
+// NewDirStore creates a store rooted at the given directory
+func NewDirStore(root string) *DirStore {
+	return &DirStore{root: root}
+}
+
+// path converts a key to a file path inside the store, rejecting keys that
+// are absolute or climb out of it with ".."
+func (s *DirStore) path(key string) (string, error) {
+	local := filepath.FromSlash(key)
+	if !filepath.IsLocal(local) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidKey, key)
+	}
+	return filepath.Join(s.root, local), nil
+}
+
+// Put writes an asset to disk
+func (s *DirStore) Put(key string, data []byte) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Get reads an asset from disk
+func (s *DirStore) Get(key string) ([]byte, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrAssetNotFound
+	}
+	return data, err
+}
+
+// Has checks if an asset exists on disk
+func (s *DirStore) Has(key string) (bool, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return false, err
+	}
+	_, err = os.Stat(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// MemoryStore keeps assets in memory, useful for tests of the test framework itself
+type MemoryStore struct {
+	mu     sync.RWMutex
+	assets map[string][]byte
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{assets: make(map[string][]byte)}
+}
+
+// Put stores a copy of the asset
+func (s *MemoryStore) Put(key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assets[key] = append([]byte(nil), data...)
+	return nil
+}
+
+// Get returns the stored asset
+func (s *MemoryStore) Get(key string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	data, ok := s.assets[key]
+	if !ok {
+		return nil, ErrAssetNotFound
+	}
+	return data, nil
+}
+
+// Has checks if an asset is stored
+func (s *MemoryStore) Has(key string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.assets[key]
+	return ok, nil
+}
+
+// Keys returns all stored keys in sorted order
+func (s *MemoryStore) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	keys := make([]string, 0, len(s.assets))
+	for key := range s.assets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// HTTPStore stores assets in a remote object store using plain HTTP
+// GET/PUT/HEAD requests against baseURL + "/" + key.
+type HTTPStore struct {
+	baseURL string
+	client  *http.Client
+	header  http.Header
+}
+
+// NewHTTPStore creates a store backed by the given base URL
+func NewHTTPStore(baseURL string) *HTTPStore {
+	return &HTTPStore{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  http.DefaultClient,
+		header:  make(http.Header),
+	}
+}
+
+// SetHeader sets a header sent with every request (e.g. Authorization)
+func (s *HTTPStore) SetHeader(name, value string) {
+	s.header.Set(name, value)
+}
+
+// SetClient sets the HTTP client used for requests
+func (s *HTTPStore) SetClient(client *http.Client) {
+	s.client = client
+}
+
+// do performs a request against the store
+func (s *HTTPStore) do(method, key string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequest(method, s.baseURL+"/"+key, reader)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range s.header {
+		req.Header[name] = values
+	}
+
+	return s.client.Do(req)
+}
+
+// Put uploads an asset
+func (s *HTTPStore) Put(key string, data []byte) error {
+	resp, err := s.do(http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("put %s: unexpected status %s", key, resp.Status)
+	}
+	return nil
+}
+
+// Get downloads an asset
+func (s *HTTPStore) Get(key string) ([]byte, error) {
+	resp, err := s.do(http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrAssetNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("get %s: unexpected status %s", key, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Has checks if an asset exists remotely
+func (s *HTTPStore) Has(key string) (bool, error) {
+	resp, err := s.do(http.MethodHead, key, nil)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if resp.StatusCode/100 != 2 {
+		return false, fmt.Errorf("head %s: unexpected status %s", key, resp.Status)
+	}
+	return true, nil
+}
+
+// GoldenSet maps golden image names to content-addressed keys in a store.
+// The small manifest can be checked into the repository while the images
+// themselves live in any AssetStore.
+type GoldenSet struct {
+	store    AssetStore
+	manifest map[string]string
+}
+
+// NewGoldenSet creates a golden set backed by the given store
+func NewGoldenSet(store AssetStore) *GoldenSet {
+	return &GoldenSet{
+		store:    store,
+		manifest: make(map[string]string),
+	}
+}
+
+// Store returns the backing asset store
+func (g *GoldenSet) Store() AssetStore {
+	return g.store
+}
+
+// LoadManifest reads a JSON manifest of name-to-key entries
+func (g *GoldenSet) LoadManifest(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &g.manifest)
+}
+
+// SaveManifest writes the manifest as JSON
+func (g *GoldenSet) SaveManifest(path string) error {
+	data, err := json.MarshalIndent(g.manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Names returns the golden names in sorted order
+func (g *GoldenSet) Names() []string {
+	names := make([]string, 0, len(g.manifest))
+	for name := range g.manifest {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Key returns the content key recorded for a golden name
+func (g *GoldenSet) Key(name string) (string, bool) {
+	key, ok := g.manifest[name]
+	return key, ok
+}
+
+// Save encodes the image, stores it under its content key and records it by name
+func (g *GoldenSet) Save(name string, img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+
+	data := buf.Bytes()
+	key := ContentKey(data, ".png")
+
+	// Identical content is already stored
+	exists, err := g.store.Has(key)
+	if err != nil {
+		return "", err
+	}
+	if !exists {
+		if err := g.store.Put(key, data); err != nil {
+			return "", err
+		}
+	}
+
+	g.manifest[name] = key
+	return key, nil
+}
+
+// Load fetches and decodes the golden image recorded for a name
+func (g *GoldenSet) Load(name string) (image.Image, error) {
+	key, ok := g.manifest[name]
+	if !ok {
+		return nil, fmt.Errorf("no golden named %q: %w", name, ErrAssetNotFound)
+	}
+
+	data, err := g.store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return png.Decode(bytes.NewReader(data))
+}
+
+// Compare checks an image against the golden of the same name.
+// Returns the number of differing pixels.
+func (g *GoldenSet) Compare(name string, img image.Image) (int, error) {
+	golden, err := g.Load(name)
+	if err != nil {
+		return 0, err
+	}
+	return countPixelDiff(golden, img), nil
+}
+
+// countPixelDiff counts pixels that differ between two images, treating
+// any area outside the overlap as different
+func countPixelDiff(a, b image.Image) int {
+	ab, bb := a.Bounds(), b.Bounds()
+	width, height := ab.Dx(), ab.Dy()
+	if bb.Dx() > width {
+		width = bb.Dx()
+	}
+	if bb.Dy() > height {
+		height = bb.Dy()
+	}
+
+	diff := 0
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			pa := image.Point{ab.Min.X + x, ab.Min.Y + y}
+			pb := image.Point{bb.Min.X + x, bb.Min.Y + y}
+			if !pa.In(ab) || !pb.In(bb) {
+				diff++
+				continue
+			}
+			r1, g1, b1, a1 := a.At(pa.X, pa.Y).RGBA()
+			r2, g2, b2, a2 := b.At(pb.X, pb.Y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				diff++
+			}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,46 @@
+package test
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDirStoreRejectsKeysOutsideRoot(t *testing.T) {
+	store := NewDirStore(t.TempDir())
+	for _, key := range []string{
+		"",
+		"..",
+		"../outside.png",
+		"sha256/../../outside.png",
+		"/etc/passwd",
+	} {
+		if err := store.Put(key, []byte("data")); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Put(%q) = %v, want ErrInvalidKey", key, err)
+		}
+		if _, err := store.Get(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Get(%q) = %v, want ErrInvalidKey", key, err)
+		}
+		if _, err := store.Has(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Has(%q) = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+func TestDirStoreRoundTrip(t *testing.T) {
+	store := NewDirStore(t.TempDir())
+	key := ContentKey([]byte("data"), ".png")
+
+	if ok, err := store.Has(key); err != nil || ok {
+		t.Fatalf("Has before Put = %v, %v", ok, err)
+	}
+	if err := store.Put(key, []byte("data")); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	data, err := store.Get(key)
+	if err != nil || string(data) != "data" {
+		t.Errorf("Get = %q, %v", data, err)
+	}
+	if _, err := store.Get("sha256/missing.png"); err != ErrAssetNotFound {
+		t.Errorf("Get of a missing key = %v, want ErrAssetNotFound", err)
+	}
+}
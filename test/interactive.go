@@ -57,6 +57,7 @@ type InteractiveTest struct {
 	showMouse      bool
 	logFile        *os.File
 	screenshotDir  string
+	goldens        *GoldenSet // Optional screenshot storage; nil writes to screenshotDir
 }
 
 // NewInteractiveTest creates a new interactive test manager
@@ -428,7 +429,7 @@ func (t *InteractiveTest) executeAction(action InteractiveAction) ActionResult {
 		t.takeScreenshot(fmt.Sprintf("test%d_click_before", t.currentTest+1))
 		
 		// Handle mouse down event
-		downResult := t.rootElement.HandleMouseDown(action.X, action.Y)
+		downResult := components.DispatchEvent(t.rootElement, components.NewMouseEvent(components.InputTypeMouseDown, action.X, action.Y))
 		
 		// Small delay to simulate real interaction
 		time.Sleep(100 * time.Millisecond)
@@ -439,7 +440,7 @@ func (t *InteractiveTest) executeAction(action InteractiveAction) ActionResult {
 		t.takeScreenshot(fmt.Sprintf("test%d_click_down", t.currentTest+1))
 		
 		// Handle mouse up event
-		upResult := t.rootElement.HandleMouseUp(action.X, action.Y)
+		upResult := components.DispatchEvent(t.rootElement, components.NewMouseEvent(components.InputTypeMouseUp, action.X, action.Y))
 		
 		if !downResult && !upResult {
 			result.Success = false
@@ -452,7 +453,7 @@ func (t *InteractiveTest) executeAction(action InteractiveAction) ActionResult {
 		t.simulatedMouse = image.Point{action.X, action.Y}
 		
 		// Handle mouse move event
-		moveResult := t.rootElement.HandleMouseMove(action.X, action.Y)
+		moveResult := components.DispatchEvent(t.rootElement, components.NewMouseEvent(components.InputTypeMouseMove, action.X, action.Y))
 		
 		if !moveResult {
 			result.Success = false
@@ -480,6 +481,11 @@ func (t *InteractiveTest) resetTests() {
 	t.Log("All tests reset to initial state")
 }
 
+// SetGoldenSet stores screenshots in the given golden set instead of screenshotDir
+func (t *InteractiveTest) SetGoldenSet(goldens *GoldenSet) {
+	t.goldens = goldens
+}
+
 // takeScreenshot captures the current UI state and saves it to a file
 func (t *InteractiveTest) takeScreenshot(prefix string) string {
 	filename := fmt.Sprintf("%s/%s_%s.png", 
@@ -498,6 +504,16 @@ func (t *InteractiveTest) takeScreenshot(prefix string) string {
 	// Save image
 	image := t.surface.(*MemorySurface).Image()
 	
+	if t.goldens != nil {
+		key, err := t.goldens.Save(prefix, image)
+		if err != nil {
+			t.Log(fmt.Sprintf("Error storing screenshot: %v", err))
+			return ""
+		}
+		t.Log(fmt.Sprintf("Screenshot %s stored as %s", prefix, key))
+		return key
+	}
+	
 	f, err := os.Create(filename)
 	if err != nil {
 		t.Log(fmt.Sprintf("Error creating screenshot file: %v", err))
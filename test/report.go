@@ -0,0 +1,260 @@
+package test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html/template"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"time"
+
+	"github.com/aggnr/finch/components"
+)
+
+// StepReport records the outcome of a single test action
+type StepReport struct {
+	Index       int
+	Type        string
+	TargetID    string
+	Description string
+	Result      string
+	Failed      bool
+	Duration    time.Duration
+	Screenshot  []byte // PNG of the UI under test after the step, may be nil
+}
+
+// TestReport records one test case run
+type TestReport struct {
+	Name     string
+	Suite    string
+	Tags     []string
+	Status   TestStatus
+	Started  time.Time
+	Duration time.Duration
+	Steps    []*StepReport
+	Logs     []string
+}
+
+// RunReport records a batch of test case runs for export
+type RunReport struct {
+	Title    string
+	Started  time.Time
+	Finished time.Time
+	Tests    []*TestReport
+}
+
+// NewRunReport creates an empty report
+func NewRunReport(title string) *RunReport {
+	return &RunReport{
+		Title:   title,
+		Started: time.Now(),
+		Tests:   make([]*TestReport, 0),
+	}
+}
+
+// Counts returns the passed, failed and total number of tests in the report
+func (r *RunReport) Counts() (int, int, int) {
+	passed, failed := 0, 0
+	for _, t := range r.Tests {
+		switch t.Status {
+		case TestPassed:
+			passed++
+		case TestFailed:
+			failed++
+		}
+	}
+	return passed, failed, len(r.Tests)
+}
+
+// reportTemplate renders a standalone page; screenshots are inlined as data URIs
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"screenshot": func(data []byte) template.URL {
+		return template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(data))
+	},
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 24px; color: #222; background: #f6f6f6; }
+h1 { margin-bottom: 4px; }
+.summary { color: #555; margin-bottom: 24px; }
+.test { background: #fff; border: 1px solid #ddd; border-radius: 4px; margin-bottom: 16px; padding: 12px 16px; }
+.test h2 { font-size: 18px; margin: 0 0 4px 0; }
+.passed { color: #2e8b57; }
+.failed { color: #dc143c; }
+.tag { background: #eef; border-radius: 3px; padding: 1px 6px; font-size: 12px; margin-right: 4px; }
+.step { border-top: 1px solid #eee; padding: 8px 0; }
+.step.fail { background: #fff0f0; }
+.step img { max-width: 480px; border: 1px solid #ccc; display: block; margin-top: 6px; }
+details pre { background: #fafafa; padding: 8px; font-size: 12px; overflow-x: auto; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="summary">{{.Passed}} passed, {{.Failed}} failed, {{.Total}} total &middot; {{.Started.Format "2006-01-02 15:04:05"}} &middot; {{.Duration}}</div>
+{{range .Tests}}
+<div class="test">
+<h2 class="{{.Status}}">{{.Name}} &mdash; {{.Status}}</h2>
+<div>{{if .Suite}}Suite: {{.Suite}} {{end}}{{range .Tags}}<span class="tag">{{.}}</span>{{end}} &middot; {{.Duration}}</div>
+{{range .Steps}}
+<div class="step{{if .Failed}} fail{{end}}">
+<strong>Step {{inc .Index}}</strong> [{{.Type}}{{if .TargetID}} {{.TargetID}}{{end}}] {{.Description}}{{if .Result}} &rarr; {{.Result}}{{end}}
+{{if .Screenshot}}<img src="{{screenshot .Screenshot}}" alt="Step {{inc .Index}}">{{end}}
+</div>
+{{end}}
+{{if .Logs}}<details><summary>Log ({{len .Logs}} lines)</summary><pre>{{range .Logs}}{{.}}
+{{end}}</pre></details>{{end}}
+</div>
+{{end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a standalone HTML page
+func (r *RunReport) WriteHTML(w io.Writer) error {
+	passed, failed, total := r.Counts()
+	finished := r.Finished
+	if finished.IsZero() {
+		finished = time.Now()
+	}
+
+	return reportTemplate.Execute(w, struct {
+		*RunReport
+		Passed, Failed, Total int
+		Duration              time.Duration
+	}{r, passed, failed, total, finished.Sub(r.Started).Round(time.Millisecond)})
+}
+
+// SaveHTML writes the report to an HTML file
+func (r *RunReport) SaveHTML(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return r.WriteHTML(f)
+}
+
+// captureElement renders an element into an in-memory PNG
+func captureElement(element components.Element) []byte {
+	bounds := element.Bounds()
+	if bounds.Width <= 0 || bounds.Height <= 0 {
+		return nil
+	}
+
+	surface := NewMemorySurface(bounds.X+bounds.Width, bounds.Y+bounds.Height)
+	surface.Clear(color.RGBA{255, 255, 255, 255})
+	element.Draw(surface)
+
+	img := surface.Image().SubImage(image.Rect(bounds.X, bounds.Y, bounds.X+bounds.Width, bounds.Y+bounds.Height))
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		fmt.Printf("Error encoding report screenshot: %v\n", err)
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// LastReport returns the report of the current or most recent run, or nil
+func (f *UITestFrame) LastReport() *RunReport {
+	return f.report
+}
+
+// SetReportPath sets a file the HTML report is written to whenever a batch finishes
+func (f *UITestFrame) SetReportPath(path string) {
+	f.reportPath = path
+}
+
+// ExportReport writes the current or most recent run as an HTML report
+func (f *UITestFrame) ExportReport(path string) error {
+	if f.report == nil {
+		return fmt.Errorf("no test run to export")
+	}
+	if err := f.report.SaveHTML(path); err != nil {
+		return err
+	}
+	f.Log("Report written to " + path)
+	return nil
+}
+
+// beginTestReport starts recording the current test case
+func (f *UITestFrame) beginTestReport() {
+	if f.testReport != nil {
+		return
+	}
+	if f.report == nil {
+		f.report = NewRunReport("Finch UI Test Run")
+	}
+
+	testCase := f.testCases[f.currentTest]
+	f.testReport = &TestReport{
+		Name:    testCase.Name,
+		Suite:   testCase.suiteName(),
+		Tags:    testCase.Tags,
+		Status:  TestRunning,
+		Started: time.Now(),
+		Steps:   make([]*StepReport, 0),
+	}
+	f.report.Tests = append(f.report.Tests, f.testReport)
+}
+
+// recordStep adds an executed action and a screenshot of the UI under test to the report
+func (f *UITestFrame) recordStep(step int, action UITestAction, failed bool, duration time.Duration) {
+	if f.testReport == nil {
+		return
+	}
+
+	result := ""
+	if results := f.testCases[f.currentTest].Results; len(results) > 0 {
+		result = results[len(results)-1]
+	}
+
+	var screenshot []byte
+	if f.rootElement != nil {
+		screenshot = captureElement(f.rootElement)
+	}
+
+	f.testReport.Steps = append(f.testReport.Steps, &StepReport{
+		Index:       step,
+		Type:        action.Type,
+		TargetID:    action.TargetID,
+		Description: action.Description,
+		Result:      result,
+		Failed:      failed,
+		Duration:    duration,
+		Screenshot:  screenshot,
+	})
+}
+
+// finishTestReport records the final status of the current test case
+func (f *UITestFrame) finishTestReport(status TestStatus) {
+	if f.testReport == nil {
+		return
+	}
+	f.testReport.Status = status
+	f.testReport.Duration = time.Since(f.testReport.Started).Round(time.Millisecond)
+	f.testReport = nil
+}
+
+// finishRunReport closes the run and writes it out if a report path is set
+func (f *UITestFrame) finishRunReport() {
+	if f.report == nil {
+		return
+	}
+	f.report.Finished = time.Now()
+
+	if f.reportPath != "" {
+		if err := f.ExportReport(f.reportPath); err != nil {
+			f.Log(fmt.Sprintf("Error writing report: %v", err))
+		}
+	}
+}
@@ -0,0 +1,543 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReviewStatus is the decision on an uploaded snapshot
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"
+	ReviewApproved ReviewStatus = "approved"
+	ReviewRejected ReviewStatus = "rejected"
+)
+
+// Snapshot is a labeled screenshot sent for visual review. The image travels
+// as base64 in JSON; everything else is metadata for the reviewer.
+type Snapshot struct {
+	ID          string            `json:"id,omitempty"`
+	Name        string            `json:"name"`
+	Build       string            `json:"build,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Image       []byte            `json:"image,omitempty"` // PNG
+	BaselineKey string            `json:"baselineKey,omitempty"`
+	DiffPixels  int               `json:"diffPixels"`
+	Status      ReviewStatus      `json:"status,omitempty"`
+	Reviewer    string            `json:"reviewer,omitempty"`
+	Comment     string            `json:"comment,omitempty"`
+	Created     time.Time         `json:"created"`
+}
+
+// ReviewService is an external visual review service. Snapshots are uploaded
+// as pending and later approved or rejected by a person.
+type ReviewService interface {
+	Upload(snapshot *Snapshot) (string, error)
+	Get(id string) (*Snapshot, error)
+	Decide(id string, status ReviewStatus, reviewer, comment string) error
+}
+
+// HTTPReviewClient talks to a review service over the HTTP contract served
+// by ReviewServer:
+//
+//	POST /snapshots                 upload a Snapshot as JSON, returns {"id": ...}
+//	GET  /snapshots?status=pending  list snapshots without images
+//	GET  /snapshots/{id}            fetch a Snapshot as JSON
+//	GET  /snapshots/{id}/image      fetch the PNG
+//	POST /snapshots/{id}/approve    body {"reviewer": ..., "comment": ...}
+//	POST /snapshots/{id}/reject     body {"reviewer": ..., "comment": ...}
+type HTTPReviewClient struct {
+	baseURL string
+	client  *http.Client
+	header  http.Header
+}
+
+// NewHTTPReviewClient creates a client for the service at the given base URL
+func NewHTTPReviewClient(baseURL string) *HTTPReviewClient {
+	return &HTTPReviewClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  http.DefaultClient,
+		header:  make(http.Header),
+	}
+}
+
+// SetHeader sets a header sent with every request (e.g. Authorization)
+func (c *HTTPReviewClient) SetHeader(name, value string) {
+	c.header.Set(name, value)
+}
+
+// SetClient sets the HTTP client used for requests
+func (c *HTTPReviewClient) SetClient(client *http.Client) {
+	c.client = client
+}
+
+// do sends a JSON request and decodes a JSON response into out, if given
+func (c *HTTPReviewClient) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	for name, values := range c.header {
+		req.Header[name] = values
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrSnapshotNotFound
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s %s: unexpected status %s", method, path, resp.Status)
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+// Upload sends a snapshot and returns the ID the service assigned
+func (c *HTTPReviewClient) Upload(snapshot *Snapshot) (string, error) {
+	var resp struct {
+		ID string `json:"id"`
+	}
+	if err := c.do(http.MethodPost, "/snapshots", snapshot, &resp); err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// Get fetches a snapshot with its current status
+func (c *HTTPReviewClient) Get(id string) (*Snapshot, error) {
+	snapshot := &Snapshot{}
+	if err := c.do(http.MethodGet, "/snapshots/"+id, nil, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// Decide approves or rejects a snapshot
+func (c *HTTPReviewClient) Decide(id string, status ReviewStatus, reviewer, comment string) error {
+	action, err := decisionAction(status)
+	if err != nil {
+		return err
+	}
+	decision := reviewDecision{Reviewer: reviewer, Comment: comment}
+	return c.do(http.MethodPost, "/snapshots/"+id+"/"+action, decision, nil)
+}
+
+// ErrSnapshotNotFound is returned when a review service has no such snapshot
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// reviewDecision is the body of an approve or reject request
+type reviewDecision struct {
+	Reviewer string `json:"reviewer"`
+	Comment  string `json:"comment"`
+}
+
+// decisionAction maps a decision to its URL path segment
+func decisionAction(status ReviewStatus) (string, error) {
+	switch status {
+	case ReviewApproved:
+		return "approve", nil
+	case ReviewRejected:
+		return "reject", nil
+	}
+	return "", fmt.Errorf("cannot decide a snapshot as %q", status)
+}
+
+// ReviewServer is a reference implementation of the review HTTP contract.
+// Snapshots are kept in memory with their images in an AssetStore, and a
+// plain HTML page at / lists pending snapshots with approve and reject buttons.
+type ReviewServer struct {
+	mu         sync.Mutex
+	store      AssetStore
+	snapshots  map[string]*Snapshot
+	imageKeys  map[string]string
+	nextID     int
+	onDecision func(*Snapshot)
+}
+
+// NewReviewServer creates a review server storing images in the given store
+func NewReviewServer(store AssetStore) *ReviewServer {
+	return &ReviewServer{
+		store:     store,
+		snapshots: make(map[string]*Snapshot),
+		imageKeys: make(map[string]string),
+	}
+}
+
+// SetOnDecision sets a handler called after a snapshot is approved or rejected
+func (s *ReviewServer) SetOnDecision(handler func(*Snapshot)) {
+	s.onDecision = handler
+}
+
+// Upload stores a snapshot as pending and returns its ID
+func (s *ReviewServer) Upload(snapshot *Snapshot) (string, error) {
+	if snapshot.Name == "" || len(snapshot.Image) == 0 {
+		return "", errors.New("snapshot needs a name and an image")
+	}
+	key := ContentKey(snapshot.Image, ".png")
+	if err := s.store.Put(key, snapshot.Image); err != nil {
+		return "", err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	stored := *snapshot
+	stored.ID = strconv.Itoa(s.nextID)
+	stored.Image = nil
+	stored.Status = ReviewPending
+	stored.Reviewer, stored.Comment = "", ""
+	if stored.Created.IsZero() {
+		stored.Created = time.Now()
+	}
+	s.snapshots[stored.ID] = &stored
+	s.imageKeys[stored.ID] = key
+	return stored.ID, nil
+}
+
+// Get returns a copy of a snapshot including its image
+func (s *ReviewServer) Get(id string) (*Snapshot, error) {
+	s.mu.Lock()
+	snapshot, ok := s.snapshots[id]
+	key := s.imageKeys[id]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrSnapshotNotFound
+	}
+
+	data, err := s.store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	result := *snapshot
+	result.Image = data
+	return &result, nil
+}
+
+// Decide approves or rejects a snapshot
+func (s *ReviewServer) Decide(id string, status ReviewStatus, reviewer, comment string) error {
+	if _, err := decisionAction(status); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	snapshot, ok := s.snapshots[id]
+	if ok {
+		snapshot.Status = status
+		snapshot.Reviewer = reviewer
+		snapshot.Comment = comment
+	}
+	s.mu.Unlock()
+	if !ok {
+		return ErrSnapshotNotFound
+	}
+
+	if s.onDecision != nil {
+		result := *snapshot
+		s.onDecision(&result)
+	}
+	return nil
+}
+
+// List returns snapshots with the given status, or all if status is empty,
+// oldest first and without images
+func (s *ReviewServer) List(status ReviewStatus) []*Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := make([]*Snapshot, 0, len(s.snapshots))
+	for _, snapshot := range s.snapshots {
+		if status == "" || snapshot.Status == status {
+			result := *snapshot
+			list = append(list, &result)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		a, _ := strconv.Atoi(list[i].ID)
+		b, _ := strconv.Atoi(list[j].ID)
+		return a < b
+	})
+	return list
+}
+
+// ServeHTTP implements the review HTTP contract and the review page
+func (s *ReviewServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+
+	switch {
+	case path == "" && r.Method == http.MethodGet:
+		s.servePage(w)
+	case path == "snapshots" && r.Method == http.MethodPost:
+		snapshot := &Snapshot{}
+		if err := json.NewDecoder(r.Body).Decode(snapshot); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id, err := s.Upload(snapshot)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, map[string]string{"id": id})
+	case path == "snapshots" && r.Method == http.MethodGet:
+		writeJSON(w, s.List(ReviewStatus(r.URL.Query().Get("status"))))
+	case len(parts) == 2 && parts[0] == "snapshots" && r.Method == http.MethodGet:
+		snapshot, err := s.Get(parts[1])
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, snapshot)
+	case len(parts) == 3 && parts[0] == "snapshots" && parts[2] == "image" && r.Method == http.MethodGet:
+		snapshot, err := s.Get(parts[1])
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(snapshot.Image)
+	case len(parts) == 3 && parts[0] == "snapshots" && r.Method == http.MethodPost:
+		status := ReviewApproved
+		if parts[2] == "reject" {
+			status = ReviewRejected
+		} else if parts[2] != "approve" {
+			http.NotFound(w, r)
+			return
+		}
+
+		decision := reviewDecision{}
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			if err := json.NewDecoder(r.Body).Decode(&decision); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		} else {
+			// Submitted from the review page
+			decision.Reviewer = r.FormValue("reviewer")
+			decision.Comment = r.FormValue("comment")
+		}
+		if err := s.Decide(parts[1], status, decision.Reviewer, decision.Comment); err != nil {
+			writeError(w, err)
+			return
+		}
+		if r.FormValue("redirect") != "" {
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// writeJSON writes a value as a JSON response
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError maps an error to a response status
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrSnapshotNotFound) || errors.Is(err, ErrAssetNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+// reviewPageTemplate lists pending snapshots with approve and reject forms
+var reviewPageTemplate = template.Must(template.New("review").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Visual review</title>
+<style>
+body { font-family: sans-serif; margin: 24px; color: #222; }
+.snapshot { border-top: 1px solid #ddd; padding: 12px 0; }
+.labels span { background: #eef; border-radius: 3px; padding: 1px 6px; margin-right: 4px; font-size: 12px; }
+img { border: 1px solid #ccc; max-width: 100%; }
+</style>
+</head>
+<body>
+<h1>Pending snapshots ({{len .}})</h1>
+{{range .}}
+<div class="snapshot">
+<h2>{{.Name}}</h2>
+<div>Build {{.Build}} &middot; {{.DiffPixels}} pixels differ{{if not .BaselineKey}} &middot; no baseline{{end}}</div>
+<div class="labels">{{range $k, $v := .Labels}}<span>{{$k}}={{$v}}</span>{{end}}</div>
+<p><img src="/snapshots/{{.ID}}/image" alt="{{.Name}}"></p>
+<form method="post">
+<input type="hidden" name="redirect" value="1">
+<input name="reviewer" placeholder="Reviewer">
+<input name="comment" placeholder="Comment" size="40">
+<button formaction="/snapshots/{{.ID}}/approve">Approve</button>
+<button formaction="/snapshots/{{.ID}}/reject">Reject</button>
+</form>
+</div>
+{{else}}
+<p>Nothing to review.</p>
+{{end}}
+</body>
+</html>
+`))
+
+// servePage writes the review page
+func (s *ReviewServer) servePage(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := reviewPageTemplate.Execute(w, s.List(ReviewPending)); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// ReviewResult is the outcome of checking a screenshot against its golden
+type ReviewResult struct {
+	Name       string
+	Matched    bool   // Identical to the golden, nothing was uploaded
+	SnapshotID string // Set when the screenshot was sent for review
+	DiffPixels int
+}
+
+// GoldenReview sends screenshots that differ from their goldens to a review
+// service, and promotes approved ones to new goldens when synced
+type GoldenReview struct {
+	goldens *GoldenSet
+	service ReviewService
+	build   string
+	labels  map[string]string
+	pending map[string]string // Snapshot ID to golden name
+}
+
+// NewGoldenReview creates a review workflow for a golden set
+func NewGoldenReview(goldens *GoldenSet, service ReviewService, build string) *GoldenReview {
+	return &GoldenReview{
+		goldens: goldens,
+		service: service,
+		build:   build,
+		labels:  make(map[string]string),
+		pending: make(map[string]string),
+	}
+}
+
+// SetLabel adds a label sent with every snapshot, such as the platform or branch
+func (r *GoldenReview) SetLabel(name, value string) {
+	r.labels[name] = value
+}
+
+// Check compares a screenshot with its golden and uploads it for review if
+// it differs or there is no golden yet
+func (r *GoldenReview) Check(name string, img image.Image, labels map[string]string) (ReviewResult, error) {
+	result := ReviewResult{Name: name}
+
+	baselineKey, hasBaseline := r.goldens.Key(name)
+	if hasBaseline {
+		diff, err := r.goldens.Compare(name, img)
+		if err != nil {
+			return result, err
+		}
+		if diff == 0 {
+			result.Matched = true
+			return result, nil
+		}
+		result.DiffPixels = diff
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return result, err
+	}
+
+	merged := make(map[string]string, len(r.labels)+len(labels))
+	for k, v := range r.labels {
+		merged[k] = v
+	}
+	for k, v := range labels {
+		merged[k] = v
+	}
+
+	id, err := r.service.Upload(&Snapshot{
+		Name:        name,
+		Build:       r.build,
+		Labels:      merged,
+		Image:       buf.Bytes(),
+		BaselineKey: baselineKey,
+		DiffPixels:  result.DiffPixels,
+		Created:     time.Now(),
+	})
+	if err != nil {
+		return result, err
+	}
+	r.pending[id] = name
+	result.SnapshotID = id
+	return result, nil
+}
+
+// Pending returns the IDs of snapshots still awaiting a decision
+func (r *GoldenReview) Pending() []string {
+	ids := make([]string, 0, len(r.pending))
+	for id := range r.pending {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Sync fetches decisions for pending snapshots. Approved snapshots become the
+// new goldens; rejected ones are returned so the run can fail. Snapshots still
+// pending are kept for the next sync.
+func (r *GoldenReview) Sync() (approved, rejected []*Snapshot, err error) {
+	for _, id := range r.Pending() {
+		snapshot, err := r.service.Get(id)
+		if err != nil {
+			return approved, rejected, err
+		}
+
+		switch snapshot.Status {
+		case ReviewApproved:
+			img, err := png.Decode(bytes.NewReader(snapshot.Image))
+			if err != nil {
+				return approved, rejected, err
+			}
+			if _, err := r.goldens.Save(r.pending[id], img); err != nil {
+				return approved, rejected, err
+			}
+			approved = append(approved, snapshot)
+			delete(r.pending, id)
+		case ReviewRejected:
+			rejected = append(rejected, snapshot)
+			delete(r.pending, id)
+		}
+	}
+	return approved, rejected, nil
+}
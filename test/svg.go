@@ -0,0 +1,124 @@
+package test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+
+	"github.com/aggnr/finch/components"
+)
+
+// SVGSurface is a DrawSurface that records drawing as SVG elements, giving
+// a resolution-independent picture of a component
+type SVGSurface struct {
+	width, height int
+	buf           bytes.Buffer
+	clipID        int
+	clipOpen      bool
+}
+
+// NewSVGSurface creates an SVG surface of the given size
+func NewSVGSurface(width, height int) *SVGSurface {
+	return &SVGSurface{width: width, height: height}
+}
+
+// svgColor formats a color as an SVG paint with opacity
+func svgColor(attr string, c color.RGBA) string {
+	return fmt.Sprintf(`%s="rgb(%d,%d,%d)" %s-opacity="%.3f"`, attr, c.R, c.G, c.B, attr, float64(c.A)/255)
+}
+
+// Clear fills the whole surface
+func (s *SVGSurface) Clear(c color.RGBA) {
+	s.FillRect(0, 0, s.width, s.height, c)
+}
+
+// DrawText writes a text element using the 7x13 metrics of the built-in font
+func (s *SVGSurface) DrawText(text string, x, y int, c color.RGBA, fontSize int) {
+	fmt.Fprintf(&s.buf, `<text x="%d" y="%d" font-family="monospace" font-size="13" %s>%s</text>`+"\n",
+		x, y+11, svgColor("fill", c), html.EscapeString(text))
+}
+
+// DrawRect outlines a rectangle
+func (s *SVGSurface) DrawRect(x, y, width, height int, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<rect x="%d.5" y="%d.5" width="%d" height="%d" fill="none" %s/>`+"\n",
+		x, y, width, height, svgColor("stroke", c))
+}
+
+// FillRect fills a rectangle
+func (s *SVGSurface) FillRect(x, y, width, height int, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<rect x="%d" y="%d" width="%d" height="%d" %s/>`+"\n",
+		x, y, width, height, svgColor("fill", c))
+}
+
+// DrawLine draws a one pixel line
+func (s *SVGSurface) DrawLine(x1, y1, x2, y2 int, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<line x1="%d" y1="%d" x2="%d" y2="%d" %s/>`+"\n",
+		x1, y1, x2, y2, svgColor("stroke", c))
+}
+
+// FillCircle fills a circle
+func (s *SVGSurface) FillCircle(x, y, radius int, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<circle cx="%d" cy="%d" r="%d" %s/>`+"\n", x, y, radius, svgColor("fill", c))
+}
+
+// DrawCircle outlines a circle
+func (s *SVGSurface) DrawCircle(x, y, radius int, c color.RGBA) {
+	fmt.Fprintf(&s.buf, `<circle cx="%d" cy="%d" r="%d" fill="none" %s/>`+"\n", x, y, radius, svgColor("stroke", c))
+}
+
+// SetClipRect clips following drawing to a rectangle
+func (s *SVGSurface) SetClipRect(x, y, width, height int) {
+	s.ResetClipRect()
+	s.clipID++
+	fmt.Fprintf(&s.buf, `<clipPath id="clip%d"><rect x="%d" y="%d" width="%d" height="%d"/></clipPath>`+"\n",
+		s.clipID, x, y, width, height)
+	fmt.Fprintf(&s.buf, `<g clip-path="url(#clip%d)">`+"\n", s.clipID)
+	s.clipOpen = true
+}
+
+// ResetClipRect ends clipping
+func (s *SVGSurface) ResetClipRect() {
+	if s.clipOpen {
+		s.buf.WriteString("</g>\n")
+		s.clipOpen = false
+	}
+}
+
+// DrawImage embeds an image as a PNG data URI, fitted like the other surfaces
+func (s *SVGSurface) DrawImage(img image.Image, x, y, width, height int, fitMethod components.ImageFitMethod) {
+	if img == nil {
+		s.FillRect(x, y, width, height, color.RGBA{200, 200, 200, 255})
+		s.DrawRect(x, y, width, height, color.RGBA{150, 150, 150, 255})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return
+	}
+
+	aspect := "none"
+	switch fitMethod {
+	case components.ImageFitContain:
+		aspect = "xMidYMid meet"
+	case components.ImageFitCover:
+		aspect = "xMidYMid slice"
+	}
+	fmt.Fprintf(&s.buf, `<image x="%d" y="%d" width="%d" height="%d" preserveAspectRatio="%s" href="data:image/png;base64,%s"/>`+"\n",
+		x, y, width, height, aspect, base64.StdEncoding.EncodeToString(buf.Bytes()))
+}
+
+// Bytes returns the complete SVG document
+func (s *SVGSurface) Bytes() []byte {
+	s.ResetClipRect()
+	var out bytes.Buffer
+	fmt.Fprintf(&out, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		s.width, s.height, s.width, s.height)
+	out.Write(s.buf.Bytes())
+	out.WriteString("</svg>\n")
+	return out.Bytes()
+}
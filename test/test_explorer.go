@@ -0,0 +1,374 @@
+package test
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strings"
+
+	"github.com/aggnr/finch/components"
+)
+
+// TestStatus represents the outcome of a test case in the interactive runner
+type TestStatus int
+
+const (
+	TestNotRun TestStatus = iota
+	TestRunning
+	TestPassed
+	TestFailed
+)
+
+// String returns a readable name for the status
+func (s TestStatus) String() string {
+	switch s {
+	case TestRunning:
+		return "running"
+	case TestPassed:
+		return "passed"
+	case TestFailed:
+		return "failed"
+	default:
+		return "not run"
+	}
+}
+
+// defaultSuite is the suite used for test cases that don't declare one
+const defaultSuite = "Default"
+
+// explorerRowHeight is the height of a row in the test tree
+const explorerRowHeight = 16
+
+// explorerRow is a visible line in the test tree: a suite header or a test case
+type explorerRow struct {
+	suite     string
+	testIndex int // -1 for suite headers
+}
+
+// TestExplorer shows test cases grouped by suite with a filter box,
+// per-case status icons, and batch run buttons
+type TestExplorer struct {
+	*components.BaseElement
+	frame         *UITestFrame
+	filter        string
+	filterFocused bool
+	collapsed     map[string]bool
+	scrollRow     int
+	onRunFiltered func()
+	onRerunFailed func()
+}
+
+// newTestExplorer creates the test explorer panel for a test frame
+func newTestExplorer(frame *UITestFrame) *TestExplorer {
+	return &TestExplorer{
+		BaseElement: components.NewBaseElement("test_explorer"),
+		frame:       frame,
+		collapsed:   make(map[string]bool),
+	}
+}
+
+// Filter returns the current filter text
+func (e *TestExplorer) Filter() string {
+	return e.filter
+}
+
+// SetFilter sets the filter text. Plain words match test names, while
+// "tag:name" and "suite:name" match tags and suites.
+func (e *TestExplorer) SetFilter(filter string) {
+	e.filter = filter
+	e.scrollRow = 0
+}
+
+// IsFilterFocused returns whether the filter box receives typed characters
+func (e *TestExplorer) IsFilterFocused() bool {
+	return e.filterFocused
+}
+
+// TypeChars appends typed characters to the filter box
+func (e *TestExplorer) TypeChars(chars []rune) {
+	if !e.filterFocused {
+		return
+	}
+	for _, ch := range chars {
+		if ch >= 32 {
+			e.filter += string(ch)
+		}
+	}
+	e.scrollRow = 0
+}
+
+// Backspace removes the last character from the filter box
+func (e *TestExplorer) Backspace() {
+	if e.filterFocused && len(e.filter) > 0 {
+		runes := []rune(e.filter)
+		e.filter = string(runes[:len(runes)-1])
+	}
+}
+
+// Blur removes focus from the filter box
+func (e *TestExplorer) Blur() {
+	e.filterFocused = false
+}
+
+// Scroll scrolls the test tree by the given number of rows
+func (e *TestExplorer) Scroll(rows int) {
+	e.scrollRow += rows
+	maxRow := len(e.rows()) - e.visibleRowCount()
+	if e.scrollRow > maxRow {
+		e.scrollRow = maxRow
+	}
+	if e.scrollRow < 0 {
+		e.scrollRow = 0
+	}
+}
+
+// Matches checks if a test case passes the current filter
+func (e *TestExplorer) Matches(tc *UITestCase) bool {
+	for _, term := range strings.Fields(strings.ToLower(e.filter)) {
+		switch {
+		case strings.HasPrefix(term, "tag:"):
+			if !tc.HasTag(strings.TrimPrefix(term, "tag:")) {
+				return false
+			}
+		case strings.HasPrefix(term, "suite:"):
+			if !strings.Contains(strings.ToLower(tc.suiteName()), strings.TrimPrefix(term, "suite:")) {
+				return false
+			}
+		case term == "failed" || term == "is:failed":
+			if tc.Status != TestFailed {
+				return false
+			}
+		default:
+			if !strings.Contains(strings.ToLower(tc.Name), term) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// FilteredIndices returns the indices of test cases passing the filter, in order
+func (e *TestExplorer) FilteredIndices() []int {
+	indices := make([]int, 0)
+	for i, tc := range e.frame.testCases {
+		if e.Matches(tc) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// rows builds the visible tree rows from the filtered test cases
+func (e *TestExplorer) rows() []explorerRow {
+	bySuite := make(map[string][]int)
+	suites := make([]string, 0)
+	for _, i := range e.FilteredIndices() {
+		suite := e.frame.testCases[i].suiteName()
+		if _, ok := bySuite[suite]; !ok {
+			suites = append(suites, suite)
+		}
+		bySuite[suite] = append(bySuite[suite], i)
+	}
+	sort.Strings(suites)
+
+	rows := make([]explorerRow, 0)
+	for _, suite := range suites {
+		rows = append(rows, explorerRow{suite: suite, testIndex: -1})
+		if e.collapsed[suite] {
+			continue
+		}
+		for _, i := range bySuite[suite] {
+			rows = append(rows, explorerRow{suite: suite, testIndex: i})
+		}
+	}
+	return rows
+}
+
+// filterRect returns the bounds of the filter box
+func (e *TestExplorer) filterRect() components.Rect {
+	b := e.Bounds()
+	return components.Rect{X: b.X, Y: b.Y, Width: b.Width - 170, Height: 20}
+}
+
+// runFilteredRect returns the bounds of the "Run Filtered" button
+func (e *TestExplorer) runFilteredRect() components.Rect {
+	b := e.Bounds()
+	return components.Rect{X: b.X + b.Width - 165, Y: b.Y, Width: 80, Height: 20}
+}
+
+// rerunFailedRect returns the bounds of the "Re-run Failed" button
+func (e *TestExplorer) rerunFailedRect() components.Rect {
+	b := e.Bounds()
+	return components.Rect{X: b.X + b.Width - 80, Y: b.Y, Width: 80, Height: 20}
+}
+
+// treeRect returns the bounds of the test tree
+func (e *TestExplorer) treeRect() components.Rect {
+	b := e.Bounds()
+	return components.Rect{X: b.X, Y: b.Y + 24, Width: b.Width, Height: b.Height - 24}
+}
+
+// visibleRowCount returns how many tree rows fit in the panel
+func (e *TestExplorer) visibleRowCount() int {
+	return e.treeRect().Height / explorerRowHeight
+}
+
+// Draw draws the filter box, buttons and test tree
+func (e *TestExplorer) Draw(surface components.DrawSurface) {
+	black := color.RGBA{0, 0, 0, 255}
+	gray := color.RGBA{120, 120, 120, 255}
+
+	// Filter box
+	fr := e.filterRect()
+	surface.FillRect(fr.X, fr.Y, fr.Width, fr.Height, color.RGBA{255, 255, 255, 255})
+	border := color.RGBA{150, 150, 150, 255}
+	if e.filterFocused {
+		border = color.RGBA{30, 144, 255, 255}
+	}
+	surface.DrawRect(fr.X, fr.Y, fr.Width, fr.Height, border)
+	if e.filter == "" && !e.filterFocused {
+		surface.DrawText("Filter (name, tag:x, suite:x, failed)", fr.X+4, fr.Y+3, gray, 10)
+	} else {
+		text := e.filter
+		if e.filterFocused {
+			text += "|"
+		}
+		surface.DrawText(text, fr.X+4, fr.Y+3, black, 10)
+	}
+
+	// Batch buttons
+	for _, button := range []struct {
+		rect  components.Rect
+		label string
+	}{
+		{e.runFilteredRect(), "Run Filtered"},
+		{e.rerunFailedRect(), "Re-run Failed"},
+	} {
+		surface.FillRect(button.rect.X, button.rect.Y, button.rect.Width, button.rect.Height, color.RGBA{70, 130, 180, 255})
+		surface.DrawText(button.label, button.rect.X+4, button.rect.Y+3, color.RGBA{255, 255, 255, 255}, 10)
+	}
+
+	// Test tree
+	tr := e.treeRect()
+	surface.FillRect(tr.X, tr.Y, tr.Width, tr.Height, color.RGBA{245, 245, 245, 255})
+	surface.DrawRect(tr.X, tr.Y, tr.Width, tr.Height, color.RGBA{100, 100, 150, 255})
+
+	rows := e.rows()
+	y := tr.Y + 2
+	for i := e.scrollRow; i < len(rows) && i < e.scrollRow+e.visibleRowCount(); i++ {
+		row := rows[i]
+		if row.testIndex < 0 {
+			marker := "v"
+			if e.collapsed[row.suite] {
+				marker = ">"
+			}
+			passed, failed, total := e.suiteCounts(row.suite)
+			label := fmt.Sprintf("%s %s (%d/%d passed", marker, row.suite, passed, total)
+			if failed > 0 {
+				label += fmt.Sprintf(", %d failed", failed)
+			}
+			surface.DrawText(label+")", tr.X+4, y, black, 10)
+		} else {
+			tc := e.frame.testCases[row.testIndex]
+			if row.testIndex == e.frame.currentTest {
+				surface.FillRect(tr.X+1, y-1, tr.Width-2, explorerRowHeight, color.RGBA{200, 200, 255, 255})
+			}
+			e.drawStatusIcon(surface, tr.X+20, y+6, tc.Status)
+			label := tc.Name
+			if len(tc.Tags) > 0 {
+				label += " [" + strings.Join(tc.Tags, ", ") + "]"
+			}
+			surface.DrawText(label, tr.X+32, y, black, 10)
+		}
+		y += explorerRowHeight
+	}
+}
+
+// drawStatusIcon draws the pass/fail/running indicator for a test case
+func (e *TestExplorer) drawStatusIcon(surface components.DrawSurface, x, y int, status TestStatus) {
+	switch status {
+	case TestPassed:
+		surface.FillCircle(x, y, 5, color.RGBA{60, 179, 113, 255})
+	case TestFailed:
+		surface.FillCircle(x, y, 5, color.RGBA{220, 20, 60, 255})
+	case TestRunning:
+		surface.FillCircle(x, y, 5, color.RGBA{255, 165, 0, 255})
+	default:
+		surface.DrawCircle(x, y, 5, color.RGBA{150, 150, 150, 255})
+	}
+}
+
+// suiteCounts returns the passed, failed and total counts for a suite
+func (e *TestExplorer) suiteCounts(suite string) (int, int, int) {
+	passed, failed, total := 0, 0, 0
+	for _, tc := range e.frame.testCases {
+		if tc.suiteName() != suite || !e.Matches(tc) {
+			continue
+		}
+		total++
+		switch tc.Status {
+		case TestPassed:
+			passed++
+		case TestFailed:
+			failed++
+		}
+	}
+	return passed, failed, total
+}
+
+// HandleMouseDown handles clicks on the filter box, buttons and tree rows
+func (e *TestExplorer) HandleMouseDown(event *components.Event) {
+	x, y := event.X, event.Y
+	p := components.Point{X: x, Y: y}
+	e.filterFocused = components.PointInRect(p, e.filterRect())
+	if e.filterFocused {
+		event.Consume()
+		return
+	}
+
+	if components.PointInRect(p, e.runFilteredRect()) {
+		if e.onRunFiltered != nil {
+			e.onRunFiltered()
+		}
+		event.Consume()
+		return
+	}
+
+	if components.PointInRect(p, e.rerunFailedRect()) {
+		if e.onRerunFailed != nil {
+			e.onRerunFailed()
+		}
+		event.Consume()
+		return
+	}
+
+	tr := e.treeRect()
+	if !components.PointInRect(p, tr) {
+		return
+	}
+
+	rowIndex := e.scrollRow + (y-tr.Y-2)/explorerRowHeight
+	rows := e.rows()
+	if rowIndex < 0 || rowIndex >= len(rows) {
+		event.Consume()
+		return
+	}
+
+	row := rows[rowIndex]
+	if row.testIndex < 0 {
+		e.collapsed[row.suite] = !e.collapsed[row.suite]
+	} else {
+		e.frame.SelectTestCase(row.testIndex)
+	}
+	event.Consume()
+}
+
+// HandleMouseUp is a no-op; the explorer acts on mouse down
+func (e *TestExplorer) HandleMouseUp(event *components.Event) {
+	return
+}
+
+// HandleMouseMove is a no-op; the explorer has no hover state
+func (e *TestExplorer) HandleMouseMove(event *components.Event) {
+	return
+}
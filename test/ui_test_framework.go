@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"image/color"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -27,24 +28,41 @@ type UITestFrame struct {
 	statusLabel   *components.Label
 	testResult    *components.Label
 	rootElement   components.Element // Root element of the UI being tested
+	explorer      *TestExplorer      // Suite tree, filter box and batch buttons
+	runQueue      []int              // Test case indices still to run in this batch
+	batchRun      []int              // Test case indices run in this batch
+	batchStart    time.Time
+	stepFailed    bool               // Whether any step of the current test failed
+	breakpoint    *BreakpointHit     // Breakpoint the runner is paused on, if any
+	onBreakpoint  func(*BreakpointHit)
+	report        *RunReport         // Current or most recent run, for HTML export
+	testReport    *TestReport        // Entry for the test case being run
+	reportPath    string             // Written when a batch finishes, if set
+	chaos         *ChaosMonkey       // Injects input jitter, delays and resizes when set
+	clock         *components.ManualClock // When set, wait actions advance it instead of sleeping
 }
 
 // UITestCase represents a sequence of test actions
 type UITestCase struct {
 	Name        string
 	Description string
+	Suite       string   // Group shown in the test tree; empty means "Default"
+	Tags        []string // Labels usable in the filter box as tag:name
 	Actions     []UITestAction
 	Results     []string
+	Status      TestStatus
 }
 
 // UITestAction represents a single action to be performed during testing
 type UITestAction struct {
-	Type         string          // "click", "hover", "wait"
+	Type         string          // "click", "hover", "wait", "assert"
 	TargetID     string          // ID of target element
 	Target       components.Element // Reference to target
 	X, Y         int             // Coordinates for actions like click
 	Description  string          // Human-readable description
 	Delay        time.Duration   // Delay after action
+	Breakpoint   bool            // Pause before running this action
+	Check        func(components.Element) error // Condition verified by "assert" actions
 }
 
 // TestControls contains the control buttons and UI for the test framework
@@ -109,11 +127,22 @@ func NewUITestFrame(rootUI components.Element) *UITestFrame {
 	frame.AddChild(frame.testResult)
 	
 	// Create log panel at bottom of frame - position it to use the remaining space
+	// next to the test explorer
+	explorerWidth := 330
 	logPanelHeight := frameHeight - 110 // Allow space for controls and status
 	frame.logPanel = createLogPanel()
-	frame.logPanel.SetBounds(components.Rect{X: 10, Y: 105, Width: frameWidth - 20, Height: logPanelHeight})
+	frame.logPanel.SetBounds(components.Rect{X: 10, Y: 105, Width: frameWidth - explorerWidth - 30, Height: logPanelHeight})
 	frame.AddChild(frame.logPanel)
 	
+	// Create test explorer on the right side of the frame
+	frame.explorer = newTestExplorer(frame)
+	frame.explorer.SetBounds(components.Rect{X: frameWidth - explorerWidth - 10, Y: frameY + 55, Width: explorerWidth, Height: frameHeight - 60})
+	frame.explorer.onRunFiltered = func() {
+		frame.RunBatch(frame.explorer.FilteredIndices())
+	}
+	frame.explorer.onRerunFailed = frame.RerunFailed
+	frame.AddChild(frame.explorer)
+	
 	// Set up button handlers
 	frame.setupControlHandlers()
 	
@@ -290,7 +319,10 @@ func (f *UITestFrame) Draw(surface components.DrawSurface) {
 	}
 	
 	// Display hint about inspector mode
-	surface.DrawText("Press 'I' to toggle component inspector", debugX + 500, debugY, color.RGBA{50, 50, 50, 255}, 10)
+	surface.DrawText("Press 'I' to toggle component inspector, 'M' to measure (hold Alt), 'B' to break on next step, 'E' to export report, 'C' for chaos mode", debugX + 500, debugY, color.RGBA{50, 50, 50, 255}, 10)
+	
+	// Show the paused step's context on top of the UI under test
+	f.drawBreakpointPanel(surface)
 }
 
 // findElementAtPosition recursively finds the element at the given position
@@ -332,9 +364,7 @@ func (f *UITestFrame) setupControlHandlers() {
 			return
 		}
 		
-		f.playingTest = true
-		f.statusLabel.SetText("Running test: " + f.testCases[f.currentTest].Name)
-		f.Log("Started test: " + f.testCases[f.currentTest].Name)
+		f.Play()
 	})
 	
 	// Pause button
@@ -437,6 +467,106 @@ func (f *UITestFrame) setupControlHandlers() {
 	})
 }
 
+// Play starts running the current test case followed by the remaining
+// test cases that pass the explorer filter
+func (f *UITestFrame) Play() {
+	if len(f.testCases) == 0 {
+		f.Log("No test cases to run")
+		return
+	}
+	
+	// Resuming a paused test (or a breakpoint) keeps the existing batch
+	if f.currentStep >= 0 || f.breakpoint != nil {
+		f.playingTest = true
+		f.statusLabel.SetText("Running test: " + f.testCases[f.currentTest].Name)
+		f.Log("Resumed test: " + f.testCases[f.currentTest].Name)
+		return
+	}
+	
+	indices := []int{f.currentTest}
+	for _, i := range f.explorer.FilteredIndices() {
+		if i > f.currentTest {
+			indices = append(indices, i)
+		}
+	}
+	f.RunBatch(indices)
+}
+
+// RunBatch runs the given test cases in order and reports a summary at the end
+func (f *UITestFrame) RunBatch(indices []int) {
+	if len(indices) == 0 {
+		f.Log("No test cases match the filter")
+		return
+	}
+	
+	f.batchRun = make([]int, 0, len(indices))
+	f.batchStart = time.Now()
+	f.report = NewRunReport("Finch UI Test Run")
+	f.testReport = nil
+	f.currentTest = indices[0]
+	f.runQueue = append([]int(nil), indices[1:]...)
+	f.currentStep = -1
+	f.updateTestCaseLabel()
+	
+	f.playingTest = true
+	f.testResult.SetText("")
+	f.statusLabel.SetText("Running test: " + f.testCases[f.currentTest].Name)
+	f.Log(fmt.Sprintf("Started batch of %d test(s): %s", len(indices), f.testCases[f.currentTest].Name))
+}
+
+// RerunFailed runs every test case whose last run failed
+func (f *UITestFrame) RerunFailed() {
+	failed := make([]int, 0)
+	for i, tc := range f.testCases {
+		if tc.Status == TestFailed {
+			failed = append(failed, i)
+		}
+	}
+	
+	if len(failed) == 0 {
+		f.Log("No failed test cases to re-run")
+		return
+	}
+	f.RunBatch(failed)
+}
+
+// SelectTestCase makes the test case at the given index current
+func (f *UITestFrame) SelectTestCase(index int) {
+	if index < 0 || index >= len(f.testCases) {
+		return
+	}
+	f.currentTest = index
+	f.runQueue = nil
+	f.updateTestCaseLabel()
+	f.ResetTest()
+}
+
+// recordBatchResult records a finished test case in the current batch
+func (f *UITestFrame) recordBatchResult(index int) {
+	for _, i := range f.batchRun {
+		if i == index {
+			return
+		}
+	}
+	f.batchRun = append(f.batchRun, index)
+}
+
+// BatchSummary returns a one-line summary of the last batch run
+func (f *UITestFrame) BatchSummary() string {
+	passed, failed := 0, 0
+	for _, i := range f.batchRun {
+		switch f.testCases[i].Status {
+		case TestPassed:
+			passed++
+		case TestFailed:
+			failed++
+		}
+	}
+	
+	return fmt.Sprintf("Batch finished: %d passed, %d failed, %d total in %s",
+		passed, failed, len(f.batchRun), time.Since(f.batchStart).Round(time.Second))
+}
+
 // updateTestCaseLabel updates the test case label with the current test name
 func (f *UITestFrame) updateTestCaseLabel() {
 	if len(f.testCases) > 0 {
@@ -452,6 +582,8 @@ func (f *UITestFrame) updateTestCaseLabel() {
 // ResetTest resets the current test to initial state
 func (f *UITestFrame) ResetTest() {
 	f.currentStep = -1
+	f.breakpoint = nil
+	f.testReport = nil
 	f.testResult.SetText("")
 	f.playingTest = false
 	f.statusLabel.SetText("Test reset: Ready to run")
@@ -477,6 +609,10 @@ func (f *UITestFrame) Update() {
 	
 	// Process test steps if playing and not in step mode
 	if f.playingTest && !f.stepMode {
+		// Chaos mode skips frames so tests can't rely on exact frame timing
+		if f.chaos != nil && f.chaos.ShouldDropFrame() {
+			return
+		}
 		f.ExecuteNextStep()
 	}
 }
@@ -495,12 +631,30 @@ func (f *UITestFrame) ExecuteNextStep() {
 	// Move to next step
 	f.currentStep++
 	
+	// Mark the test as running when its first step starts
+	if f.currentStep == 0 {
+		f.stepFailed = false
+		testCase.Status = TestRunning
+		f.beginTestReport()
+	}
+	
 	// Check if test is complete
 	if f.currentStep >= len(testCase.Actions) {
 		f.statusLabel.SetText("Test completed: " + testCase.Name)
-		f.testResult.SetText("Test Passed!")
-		f.testResult.SetTextColor(color.RGBA{0, 128, 0, 255})
-		f.Log("Test completed successfully")
+		if f.stepFailed {
+			testCase.Status = TestFailed
+			f.testResult.SetText("Test Failed!")
+			f.testResult.SetTextColor(color.RGBA{200, 0, 0, 255})
+			f.Log("Test completed with failures")
+			f.finishTestReport(TestFailed)
+		} else {
+			testCase.Status = TestPassed
+			f.testResult.SetText("Test Passed!")
+			f.testResult.SetTextColor(color.RGBA{0, 128, 0, 255})
+			f.Log("Test completed successfully")
+			f.finishTestReport(TestPassed)
+		}
+		f.recordBatchResult(f.currentTest)
 		
 		// If in step mode, don't auto-advance; wait for next button click
 		if f.stepMode {
@@ -509,17 +663,23 @@ func (f *UITestFrame) ExecuteNextStep() {
 			return
 		}
 		
-		// Check if this was the last test case
-		if f.currentTest == len(f.testCases) - 1 {
+		// Check if there are more test cases queued in this batch
+		if len(f.runQueue) == 0 {
 			f.Log("All test cases completed")
-			f.statusLabel.SetText("All test cases completed")
+			f.statusLabel.SetText(f.BatchSummary())
+			f.Log(f.BatchSummary())
+			if f.chaos != nil {
+				f.Log(f.chaos.Summary())
+			}
+			f.finishRunReport()
 			f.playingTest = false
 			f.currentStep = -1
 			return
 		}
 		
-		// Move to next test case
-		f.currentTest++
+		// Move to next queued test case
+		f.currentTest = f.runQueue[0]
+		f.runQueue = f.runQueue[1:]
 		f.updateTestCaseLabel()
 		f.currentStep = -1
 		
@@ -536,6 +696,12 @@ func (f *UITestFrame) ExecuteNextStep() {
 	// Get current action
 	action := testCase.Actions[f.currentStep]
 	
+	// Pause before actions with breakpoints; the step runs when execution continues
+	if f.checkBreakpoint(f.currentStep, action) {
+		f.currentStep--
+		return
+	}
+	
 	// Log the action
 	f.Log(fmt.Sprintf("Step %d/%d: %s", 
 		f.currentStep+1, 
@@ -548,8 +714,15 @@ func (f *UITestFrame) ExecuteNextStep() {
 		len(testCase.Actions), 
 		action.Description))
 	
-	// Execute the action
+	// Execute the action, tracking whether this step failed on its own
+	failedBefore := f.stepFailed
+	f.stepFailed = false
+	started := time.Now()
+	f.applyChaosBeforeStep()
 	f.executeAction(action)
+	f.checkChaosConsistency()
+	f.recordStep(f.currentStep, action, f.stepFailed, time.Since(started))
+	f.stepFailed = f.stepFailed || failedBefore
 }
 
 // executeAction performs a single test action
@@ -597,6 +770,11 @@ func (f *UITestFrame) executeAction(action UITestAction) {
 				fmt.Printf("Using specified click point: (%d,%d)\n", x, y)
 			}
 			
+			// Chaos mode makes the pointer imprecise
+			if f.chaos != nil {
+				x, y = f.chaos.JitterPoint(x, y)
+			}
+			
 			// Update virtual cursor position
 			if game != nil {
 				game.virtualCursor.x = x
@@ -608,13 +786,22 @@ func (f *UITestFrame) executeAction(action UITestAction) {
 			// Add visual delay before clicking to make it visible
 			time.Sleep(time.Duration(float64(500 * time.Millisecond) * delayMultiplier))
 			
-			// Simulate mouse down
+			// Chaos mode delays event delivery
+			if f.chaos != nil {
+				f.chaos.Delay()
+			}
+			
+			// Simulate mouse down through the tree, as a real click would go
 			fmt.Printf("Simulating mouse down on %s at (%d,%d)\n", action.Target.ID(), x, y)
 			f.Log(fmt.Sprintf("Mouse down on %s at (%d,%d)", action.Target.ID(), x, y))
-			action.Target.HandleMouseDown(x, y)
+			down := components.NewMouseEvent(components.InputTypeMouseDown, x, y)
+			components.DispatchEvent(f.rootElement, down)
 			
-			// Record result
+			// Record result, with the element that took the press
 			result := fmt.Sprintf("Clicked element %s at (%d,%d)", action.TargetID, x, y)
+			if down.HandledBy != nil && down.HandledBy != action.Target {
+				result += fmt.Sprintf(", handled by %s", down.HandledBy.ID())
+			}
 			f.testCases[f.currentTest].Results = append(f.testCases[f.currentTest].Results, result)
 			
 			// Update virtual cursor click time
@@ -625,16 +812,21 @@ func (f *UITestFrame) executeAction(action UITestAction) {
 			// Small delay to simulate real interaction
 			time.Sleep(time.Duration(float64(300 * time.Millisecond) * delayMultiplier))
 			
+			if f.chaos != nil {
+				f.chaos.Delay()
+			}
+			
 			// Simulate mouse up
 			fmt.Printf("Simulating mouse up on %s at (%d,%d)\n", action.Target.ID(), x, y)
 			f.Log(fmt.Sprintf("Mouse up on %s at (%d,%d)", action.Target.ID(), x, y))
-			action.Target.HandleMouseUp(x, y)
+			components.DispatchEvent(f.rootElement, components.NewMouseEvent(components.InputTypeMouseUp, x, y))
 			
 			// Add time to see the result of the interaction
 			time.Sleep(time.Duration(float64(700 * time.Millisecond) * delayMultiplier))
 		} else {
 			fmt.Printf("Error: Could not find target element '%s'\n", action.TargetID)
 			f.Log(fmt.Sprintf("Error: Could not find target element '%s'", action.TargetID))
+			f.stepFailed = true
 		}
 		
 	case "hover":
@@ -654,6 +846,12 @@ func (f *UITestFrame) executeAction(action UITestAction) {
 				y = bounds.Y + bounds.Height/2
 			}
 			
+			// Chaos mode makes the pointer imprecise and late
+			if f.chaos != nil {
+				x, y = f.chaos.JitterPoint(x, y)
+				f.chaos.Delay()
+			}
+			
 			// Update virtual cursor position
 			if game != nil {
 				game.virtualCursor.x = x
@@ -666,25 +864,68 @@ func (f *UITestFrame) executeAction(action UITestAction) {
 			
 			// Simulate mouse move
 			f.Log(fmt.Sprintf("Mouse move on %s at (%d,%d)", action.Target.ID(), x, y))
-			action.Target.HandleMouseMove(x, y)
+			action.Target.HandleMouseMove(components.NewMouseEvent(components.InputTypeMouseMove, x, y))
 			
 			// Record result
 			result := fmt.Sprintf("Hovered over element %s at (%d,%d)", action.TargetID, x, y)
 			f.testCases[f.currentTest].Results = append(f.testCases[f.currentTest].Results, result)
 		} else {
 			f.Log(fmt.Sprintf("Error: Could not find target element %s", action.TargetID))
+			f.stepFailed = true
 		}
 		
+	case "assert":
+		// Find the target if needed
+		if action.Target == nil && action.TargetID != "" {
+			action.Target = f.FindElementByID(action.TargetID)
+		}
+		
+		if action.Target == nil {
+			f.Log(fmt.Sprintf("Error: Could not find target element %s", action.TargetID))
+			f.stepFailed = true
+		} else if action.Check != nil {
+			if err := action.Check(action.Target); err != nil {
+				f.Log(fmt.Sprintf("Assertion failed: %s: %v", action.Description, err))
+				f.stepFailed = true
+			} else {
+				f.Log("Assertion passed: " + action.Description)
+			}
+		}
+		
+		result := fmt.Sprintf("Asserted %s on %s", action.Description, action.TargetID)
+		f.testCases[f.currentTest].Results = append(f.testCases[f.currentTest].Results, result)
+		
 	case "wait":
 		// Just wait for the specified duration
 		result := fmt.Sprintf("Waited for %v", action.Delay)
 		f.testCases[f.currentTest].Results = append(f.testCases[f.currentTest].Results, result)
+		
+		// With a manual clock, time passes for the UI without real sleeping
+		if f.clock != nil {
+			f.clock.Advance(action.Delay)
+			return
+		}
 	}
 	
 	// Add delay after action
 	time.Sleep(time.Duration(float64(action.Delay) * delayMultiplier))
 }
 
+// UseManualClock installs a manual clock for the components so wait actions
+// advance time deterministically (double-click intervals, key repeat,
+// animations) instead of sleeping
+func (f *UITestFrame) UseManualClock() *components.ManualClock {
+	f.clock = components.NewManualClock(time.Now())
+	components.SetClock(f.clock)
+	return f.clock
+}
+
+// UseSystemClock restores the wall clock for the components
+func (f *UITestFrame) UseSystemClock() {
+	f.clock = nil
+	components.SetClock(nil)
+}
+
 // Log adds a message to the log panel
 func (f *UITestFrame) Log(message string) {
 	// Add timestamp to log
@@ -693,6 +934,11 @@ func (f *UITestFrame) Log(message string) {
 	// Add to logs
 	f.logPanel.logs = append(f.logPanel.logs, logEntry)
 	
+	// Keep the full log of the running test for the report
+	if f.testReport != nil {
+		f.testReport.Logs = append(f.testReport.Logs, logEntry)
+	}
+	
 	// Limit log size
 	if len(f.logPanel.logs) > 100 {
 		f.logPanel.logs = f.logPanel.logs[len(f.logPanel.logs)-100:]
@@ -729,6 +975,36 @@ func NewUITestCase(name, description string) *UITestCase {
 	}
 }
 
+// InSuite sets the suite the test case is grouped under
+func (tc *UITestCase) InSuite(suite string) *UITestCase {
+	tc.Suite = suite
+	return tc
+}
+
+// WithTags adds tags to the test case
+func (tc *UITestCase) WithTags(tags ...string) *UITestCase {
+	tc.Tags = append(tc.Tags, tags...)
+	return tc
+}
+
+// HasTag checks if the test case has the given tag (case-insensitive)
+func (tc *UITestCase) HasTag(tag string) bool {
+	for _, t := range tc.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// suiteName returns the suite name, falling back to the default suite
+func (tc *UITestCase) suiteName() string {
+	if tc.Suite == "" {
+		return defaultSuite
+	}
+	return tc.Suite
+}
+
 // AddClickAction adds a click action to a test case
 func (tc *UITestCase) AddClickAction(targetID string, x, y int, description string, delay time.Duration) {
 	tc.Actions = append(tc.Actions, UITestAction{
@@ -820,6 +1096,9 @@ type UITestGame struct {
 		active    bool
 		clickTime time.Time
 	}
+	measureOverlay *components.MeasureOverlay // Edge distance measurement (toggle with 'M')
+	colorFilter    *components.ColorFilterPass // Color vision simulation (cycle with 'F')
+	inspector      *components.Inspector       // Element tree (toggle with 'I'), opened on breakpoints
 }
 
 // NewUITestGame creates a new UI test game
@@ -844,6 +1123,24 @@ func NewUITestGame(rootUI components.Element) *UITestGame {
 		rootElement: testFrame,
 		testFrame:   testFrame,
 		renderer:    nil,
+		colorFilter: components.NewColorFilterPass(),
+	}
+	
+	// Create measurement overlay if the UI under test is a node tree
+	if nodeRoot, ok := rootUI.(components.NodeElement); ok {
+		game.measureOverlay = components.NewMeasureOverlay("measure_overlay", nodeRoot)
+		
+		game.inspector = components.NewInspector("test_inspector", nodeRoot)
+		game.inspector.SetPositionType(components.PositionFixed)
+		game.inspector.SetBounds(components.Rect{X: components.ScreenWidth - 310, Y: 10, Width: 300, Height: targetUIBounds.Height - 20})
+		game.inspector.SetVisible(false)
+		
+		// Breakpoints open the inspector on the action's target
+		testFrame.onBreakpoint = func(hit *BreakpointHit) {
+			if node, ok := hit.Target.(components.NodeElement); ok && game.inspector.SelectNode(node) {
+				game.inspector.SetVisible(true)
+			}
+		}
 	}
 	
 	// Store reference to current game
@@ -873,20 +1170,64 @@ func (g *UITestGame) Update() error {
 		}
 	}
 	
+	// Route typing to the test filter box while it has focus
+	if g.testFrame.explorer.IsFilterFocused() {
+		g.testFrame.explorer.TypeChars(ebiten.AppendInputChars(nil))
+		if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) {
+			g.testFrame.explorer.Backspace()
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || inpututil.IsKeyJustPressed(ebiten.KeyEnter) {
+			g.testFrame.explorer.Blur()
+		}
+	}
+	
+	// Scroll the test tree with the mouse wheel
+	if _, wheelY := ebiten.Wheel(); wheelY != 0 && isPointInRect(g.mouseX, g.mouseY, g.testFrame.explorer.Bounds()) {
+		g.testFrame.explorer.Scroll(-int(wheelY))
+	}
+	
+	// Toggle the inspector and breakpoints
+	if !g.testFrame.explorer.IsFilterFocused() {
+		if g.inspector != nil && inpututil.IsKeyJustPressed(ebiten.KeyI) {
+			g.inspector.SetVisible(!g.inspector.IsVisible())
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyB) {
+			g.testFrame.ToggleBreakpointAtNextStep()
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyC) {
+			if g.testFrame.IsChaosEnabled() {
+				g.testFrame.DisableChaos()
+			} else {
+				g.testFrame.SetChaos(DefaultChaosConfig())
+			}
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyE) {
+			if err := g.testFrame.ExportReport("test_report.html"); err != nil {
+				g.testFrame.Log(fmt.Sprintf("Error exporting report: %v", err))
+			}
+		}
+	}
+	
 	// Handle mouse events
 	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 		g.mousePressed = true
 		fmt.Printf("Mouse DOWN at (%d,%d)\n", g.mouseX, g.mouseY)
 		
+		// The inspector sits above the UI under test
+		if g.inspector != nil && g.inspector.IsVisible() {
+			down := components.NewMouseEvent(components.InputTypeMouseDown, g.mouseX, g.mouseY)
+			if g.inspector.HandleMouseDown(down); down.Consumed() {
+				return nil
+			}
+		}
+		
 		// Check for direct button clicks
 		if g.testFrame.controls != nil {
 			// Play button
 			if isPointInRect(g.mouseX, g.mouseY, g.testFrame.controls.playButton.Bounds()) {
 				fmt.Println("DIRECT PLAY BUTTON CLICK")
 				if len(g.testFrame.testCases) > 0 {
-					g.testFrame.playingTest = true
-					g.testFrame.statusLabel.SetText("Running test: " + g.testFrame.testCases[g.testFrame.currentTest].Name)
-					g.testFrame.Log("Started test: " + g.testFrame.testCases[g.testFrame.currentTest].Name)
+					g.testFrame.Play()
 				} else {
 					g.testFrame.Log("No test cases to run")
 				}
@@ -1002,7 +1343,7 @@ func (g *UITestGame) Update() error {
 		}
 		
 		// Always propagate the event to all elements
-		g.rootElement.HandleMouseDown(g.mouseX, g.mouseY)
+		components.DispatchEvent(g.rootElement, components.NewMouseEvent(components.InputTypeMouseDown, g.mouseX, g.mouseY))
 	}
 	
 	// Handle mouse release events
@@ -1012,10 +1353,12 @@ func (g *UITestGame) Update() error {
 			fmt.Printf("Mouse UP at (%d,%d)\n", g.mouseX, g.mouseY)
 			
 			// Always propagate the event to all elements
-			g.rootElement.HandleMouseUp(g.mouseX, g.mouseY)
+			up := components.NewMouseEvent(components.InputTypeMouseUp, g.mouseX, g.mouseY)
+			components.DispatchEvent(g.rootElement, up)
 			
-			// Check if mouse is still over the same control button
-			if g.clickedButton != "" {
+			// Check if mouse is still over the same control button, unless
+			// the tree already handled the release
+			if g.clickedButton != "" && !up.Handled() {
 				var buttonBounds components.Rect
 				
 				// Get bounds of the clicked button
@@ -1046,21 +1389,21 @@ func (g *UITestGame) Update() error {
 					switch g.clickedButton {
 					case "play_button":
 						// Call play button handler
-						g.testFrame.controls.playButton.HandleMouseUp(g.mouseX, g.mouseY)
+						g.testFrame.controls.playButton.HandleMouseUp(components.NewMouseEvent(components.InputTypeMouseUp, g.mouseX, g.mouseY))
 					case "pause_button":
-						g.testFrame.controls.pauseButton.HandleMouseUp(g.mouseX, g.mouseY)
+						g.testFrame.controls.pauseButton.HandleMouseUp(components.NewMouseEvent(components.InputTypeMouseUp, g.mouseX, g.mouseY))
 					case "step_button":
-						g.testFrame.controls.stepButton.HandleMouseUp(g.mouseX, g.mouseY)
+						g.testFrame.controls.stepButton.HandleMouseUp(components.NewMouseEvent(components.InputTypeMouseUp, g.mouseX, g.mouseY))
 					case "stop_button":
-						g.testFrame.controls.stopButton.HandleMouseUp(g.mouseX, g.mouseY)
+						g.testFrame.controls.stopButton.HandleMouseUp(components.NewMouseEvent(components.InputTypeMouseUp, g.mouseX, g.mouseY))
 					case "reset_button":
-						g.testFrame.controls.resetButton.HandleMouseUp(g.mouseX, g.mouseY)
+						g.testFrame.controls.resetButton.HandleMouseUp(components.NewMouseEvent(components.InputTypeMouseUp, g.mouseX, g.mouseY))
 					case "prev_test_button":
-						g.testFrame.controls.prevTestButton.HandleMouseUp(g.mouseX, g.mouseY)
+						g.testFrame.controls.prevTestButton.HandleMouseUp(components.NewMouseEvent(components.InputTypeMouseUp, g.mouseX, g.mouseY))
 					case "next_test_button":
-						g.testFrame.controls.nextTestButton.HandleMouseUp(g.mouseX, g.mouseY)
+						g.testFrame.controls.nextTestButton.HandleMouseUp(components.NewMouseEvent(components.InputTypeMouseUp, g.mouseX, g.mouseY))
 					case "step_mode_button":
-						g.testFrame.controls.stepModeCheckbox.HandleMouseUp(g.mouseX, g.mouseY)
+						g.testFrame.controls.stepModeCheckbox.HandleMouseUp(components.NewMouseEvent(components.InputTypeMouseUp, g.mouseX, g.mouseY))
 					}
 				}
 				
@@ -1070,7 +1413,23 @@ func (g *UITestGame) Update() error {
 	}
 	
 	// Propagate mouse move events
-	g.rootElement.HandleMouseMove(g.mouseX, g.mouseY)
+	components.DispatchEvent(g.rootElement, components.NewMouseEvent(components.InputTypeMouseMove, g.mouseX, g.mouseY))
+	
+	// Cycle color vision filters
+	if !g.testFrame.explorer.IsFilterFocused() && inpututil.IsKeyJustPressed(ebiten.KeyF) {
+		filter := g.colorFilter.CycleFilter()
+		g.testFrame.Log(fmt.Sprintf("Color filter: %s", filter))
+	}
+	
+	// Update measurement mode
+	if g.measureOverlay != nil {
+		if !g.testFrame.explorer.IsFilterFocused() && inpututil.IsKeyJustPressed(ebiten.KeyM) {
+			g.measureOverlay.SetEnabled(!g.measureOverlay.IsEnabled())
+			g.testFrame.Log(fmt.Sprintf("Measure mode: %v", g.measureOverlay.IsEnabled()))
+		}
+		g.measureOverlay.SetAltDown(ebiten.IsKeyPressed(ebiten.KeyAlt))
+		g.measureOverlay.HandleMouseMove(components.NewMouseEvent(components.InputTypeMouseMove, g.mouseX, g.mouseY))
+	}
 	
 	// Update test frame
 	g.testFrame.Update()
@@ -1088,10 +1447,12 @@ func isPointInRect(x, y int, rect components.Rect) bool {
 
 // Draw draws the game
 func (g *UITestGame) Draw(screen *ebiten.Image) {
-	// Create renderer if needed
-	if g.renderer == nil {
-		g.renderer = components.NewEbitenRenderer(screen)
-	}
+	// Draw into the filter buffer when a color filter is active
+	target := g.colorFilter.Begin(screen)
+	defer g.colorFilter.End(screen)
+	
+	// Create a renderer for this frame's target
+	g.renderer = components.NewEbitenRenderer(target)
 	
 	// Clear the screen
 	g.renderer.Clear(color.RGBA{255, 255, 255, 255})
@@ -1099,6 +1460,16 @@ func (g *UITestGame) Draw(screen *ebiten.Image) {
 	// Draw all UI elements
 	g.rootElement.Draw(g.renderer)
 	
+	// Draw measurements on top of the UI
+	if g.measureOverlay != nil {
+		g.measureOverlay.Draw(g.renderer)
+	}
+	
+	// Draw the inspector on top of everything but the cursor
+	if g.inspector != nil {
+		g.inspector.Draw(g.renderer)
+	}
+	
 	// Draw virtual cursor during test execution
 	if g.testFrame.playingTest && g.virtualCursor.active {
 		cursorSize := 10